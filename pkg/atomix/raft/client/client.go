@@ -18,24 +18,39 @@ import (
 	"container/list"
 	"context"
 	"errors"
+	"fmt"
 	"github.com/atomix/go-framework/pkg/atomix/cluster"
 	"github.com/atomix/go-framework/pkg/atomix/node"
 	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"sync"
+	"time"
 )
 
 // NewClient returns a new Raft client
-func NewClient(config cluster.Cluster, consistency raft.ReadConsistency) *Client {
-	cluster := raft.NewCluster(config)
-	return newClient(cluster, raft.NewClient(cluster), consistency)
+func NewClient(config cluster.Cluster, protocolConfig *config.ProtocolConfig, consistency raft.ReadConsistency) (*Client, error) {
+	cluster, err := raft.NewCluster(config)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(cluster, raft.NewClient(cluster), protocolConfig, consistency), nil
+}
+
+// NewGroupClient returns a new Raft client that sends operations to the given Raft group
+func NewGroupClient(config cluster.Cluster, protocolConfig *config.ProtocolConfig, consistency raft.ReadConsistency, group raft.GroupID) (*Client, error) {
+	cluster, err := raft.NewCluster(config)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(cluster, raft.NewGroupClient(cluster, group), protocolConfig, consistency), nil
 }
 
 // newClient returns a new Raft client
-func newClient(cluster raft.Cluster, client raft.Client, consistency raft.ReadConsistency) *Client {
+func newClient(cluster raft.Cluster, client raft.Client, config *config.ProtocolConfig, consistency raft.ReadConsistency) *Client {
 	members := list.New()
 	for _, member := range cluster.Members() {
 		members.PushBack(member)
@@ -44,6 +59,7 @@ func newClient(cluster raft.Cluster, client raft.Client, consistency raft.ReadCo
 		client:      client,
 		members:     members,
 		consistency: consistency,
+		queries:     newQuerySequencer(config.GetOrderedQueries()),
 		log:         util.NewNodeLogger(string(cluster.Member())),
 	}
 }
@@ -57,6 +73,7 @@ type Client struct {
 	leader      *raft.MemberID
 	client      raft.Client
 	consistency raft.ReadConsistency
+	queries     *querySequencer
 	mu          sync.RWMutex
 	log         util.Logger
 }
@@ -94,9 +111,11 @@ func (c *Client) Write(ctx context.Context, in []byte, stream streams.WriteStrea
 
 // Read sends a read operation to the cluster
 func (c *Client) Read(ctx context.Context, in []byte, stream streams.WriteStream) error {
+	sequenceNumber, stream := c.queries.newStream(stream)
 	request := &raft.QueryRequest{
 		Value:           in,
 		ReadConsistency: c.consistency,
+		SequenceNumber:  sequenceNumber,
 	}
 
 	errCh := make(chan error)
@@ -109,6 +128,133 @@ func (c *Client) Read(ctx context.Context, in []byte, stream streams.WriteStream
 	return <-errCh
 }
 
+// SubmitBatch submits a batch of commands to the cluster. The leader appends the commands to the log as a
+// contiguous batch in the order in which they're provided, and the result of each command is returned in
+// the same order. A command that fails when applied to the state machine does not fail the rest of the
+// batch; its error is reported in its result.
+func (c *Client) SubmitBatch(ctx context.Context, values [][]byte) ([]streams.Result, error) {
+	request := &raft.CommandBatchRequest{
+		Values: values,
+	}
+
+	for {
+		leader := c.getLeader()
+		c.log.Trace("Sending CommandBatchRequest %+v to %s", request, leader)
+		response, err := c.client.CommandBatch(ctx, request, leader)
+		if err != nil {
+			c.log.Trace("Received CommandBatchRequest error %s from %s", err, leader)
+			if e, ok := status.FromError(err); ok && e.Code() == codes.Unavailable {
+				c.resetLeader(leader, nil)
+				continue
+			}
+			return nil, err
+		}
+
+		c.log.Trace("Received CommandBatchResponse %+v from %s", response, leader)
+		if response.Status == raft.ResponseStatus_OK {
+			results := make([]streams.Result, len(response.Results))
+			for i, result := range response.Results {
+				if result.Status == raft.ResponseStatus_OK {
+					results[i] = streams.Result{Value: result.Output}
+				} else {
+					results[i] = streams.Result{Error: errors.New(result.Message)}
+				}
+			}
+			return results, nil
+		} else if response.Error == raft.ResponseError_ILLEGAL_MEMBER_STATE {
+			// If possible, update the current leader and retry the batch
+			if leader == response.Leader {
+				c.resetLeader(leader, nil)
+			} else if response.Leader != "" && c.resetLeader(leader, &response.Leader) {
+				continue
+			} else if response.Leader == "" && c.resetLeader(leader, nil) {
+				continue
+			} else {
+				return nil, errors.New(response.Message)
+			}
+		} else {
+			return nil, fmt.Errorf("failed to submit batch: %s", response.Error)
+		}
+	}
+}
+
+// SetReadOnly sets whether the cluster is in read-only mode.
+// While the cluster is in read-only mode, writes are rejected and reads continue to be served.
+func (c *Client) SetReadOnly(ctx context.Context, readOnly bool) error {
+	request := &raft.SetReadOnlyRequest{
+		ReadOnly: readOnly,
+	}
+
+	// Send the request to the leader if known, otherwise try each member until the leader accepts the request.
+	leader := c.getLeader()
+	members := []raft.MemberID{leader}
+	c.mu.RLock()
+	for element := c.members.Front(); element != nil; element = element.Next() {
+		if member := element.Value.(raft.MemberID); member != leader {
+			members = append(members, member)
+		}
+	}
+	c.mu.RUnlock()
+
+	var err error
+	for _, member := range members {
+		c.log.Trace("Sending SetReadOnlyRequest %+v to %s", request, member)
+		response, e := c.client.SetReadOnly(ctx, request, member)
+		if e != nil {
+			c.log.Trace("Received SetReadOnlyRequest error %s from %s", e, member)
+			err = e
+		} else if response.Status == raft.ResponseStatus_OK {
+			c.log.Trace("Received SetReadOnlyResponse %+v from %s", response, member)
+			return nil
+		} else if response.Error != raft.ResponseError_ILLEGAL_MEMBER_STATE {
+			return fmt.Errorf("failed to set read-only mode: %s", response.Error)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return errors.New("failed to set read-only mode: no leader found")
+}
+
+// FreezeElections freezes elections cluster-wide for the given duration, capped at the configured maximum
+// election freeze. While elections are frozen, members do not campaign for leadership. A zero duration
+// lifts the freeze.
+func (c *Client) FreezeElections(ctx context.Context, duration time.Duration) error {
+	request := &raft.FreezeElectionsRequest{
+		Duration: duration,
+	}
+
+	// Send the request to the leader if known, otherwise try each member until the leader accepts the request.
+	leader := c.getLeader()
+	members := []raft.MemberID{leader}
+	c.mu.RLock()
+	for element := c.members.Front(); element != nil; element = element.Next() {
+		if member := element.Value.(raft.MemberID); member != leader {
+			members = append(members, member)
+		}
+	}
+	c.mu.RUnlock()
+
+	var err error
+	for _, member := range members {
+		c.log.Trace("Sending FreezeElectionsRequest %+v to %s", request, member)
+		response, e := c.client.FreezeElections(ctx, request, member)
+		if e != nil {
+			c.log.Trace("Received FreezeElectionsRequest error %s from %s", e, member)
+			err = e
+		} else if response.Status == raft.ResponseStatus_OK {
+			c.log.Trace("Received FreezeElectionsResponse %+v from %s", response, member)
+			return nil
+		} else if response.Error != raft.ResponseError_ILLEGAL_MEMBER_STATE {
+			return fmt.Errorf("failed to freeze elections: %s", response.Error)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return errors.New("failed to freeze elections: no leader found")
+}
+
 // getLeader gets the leader node or a random member
 func (c *Client) getLeader() raft.MemberID {
 	c.mu.RLock()
@@ -180,6 +326,8 @@ func (c *Client) sendWrite(ctx context.Context, request *raft.CommandRequest, st
 
 // receiveWrite process write responses
 func (c *Client) receiveWrite(ctx context.Context, request *raft.CommandRequest, stream streams.WriteStream, leader raft.MemberID, ch <-chan *raft.CommandStreamResponse) {
+	// Large outputs are streamed in partial chunks which are buffered until the output is complete.
+	var partial []byte
 	for streamResponse := range ch {
 		if streamResponse.Failed() {
 			c.log.Trace("Received CommandResponse error %s from %s", streamResponse.Error, leader)
@@ -198,8 +346,15 @@ func (c *Client) receiveWrite(ctx context.Context, request *raft.CommandRequest,
 
 		response := streamResponse.Response
 		c.log.Trace("Received CommandResponse %+v from %s", response, leader)
-		if response.Status == raft.ResponseStatus_OK {
-			stream.Value(response.Output)
+		if response.Status == raft.ResponseStatus_OK && response.Partial {
+			partial = append(partial, response.Output...)
+		} else if response.Status == raft.ResponseStatus_OK {
+			if partial != nil {
+				stream.Value(append(partial, response.Output...))
+				partial = nil
+			} else {
+				stream.Value(response.Output)
+			}
 		} else if response.Error == raft.ResponseError_ILLEGAL_MEMBER_STATE {
 			// If possible, update the current leader
 			if leader == response.Leader {
@@ -304,7 +459,9 @@ func (c *Client) receiveRead(ctx context.Context, request *raft.QueryRequest, st
 
 		response := streamResponse.Response
 		c.log.Trace("Received QueryResponse %+v from %s", response, member)
-		if response.Status == raft.ResponseStatus_OK {
+		if response.Status == raft.ResponseStatus_OK && response.ReadRepair {
+			c.log.Debug("Read from %s may have been stale by %d entries", member, response.StaleBy)
+		} else if response.Status == raft.ResponseStatus_OK {
 			stream.Value(response.Output)
 		} else if response.Error == raft.ResponseError_ILLEGAL_MEMBER_STATE {
 			c.resetMember()
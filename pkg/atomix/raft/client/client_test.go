@@ -17,7 +17,8 @@ package client
 import (
 	"context"
 	"github.com/atomix/go-framework/pkg/atomix/cluster"
-	"github.com/atomix/go-framework/pkg/atomix/node"
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/protocol/mock"
 	"github.com/golang/mock/gomock"
@@ -30,23 +31,27 @@ func newTestClient(client raft.Client) *Client {
 		MemberID: "foo",
 		Members: map[string]cluster.Member{
 			"foo": {
-				ID:   "foo",
-				Host: "localhost",
-				Port: 5000,
+				ID:           "foo",
+				Host:         "localhost",
+				ProtocolPort: 5000,
 			},
 			"bar": {
-				ID:   "bar",
-				Host: "localhost",
-				Port: 5001,
+				ID:           "bar",
+				Host:         "localhost",
+				ProtocolPort: 5001,
 			},
 			"baz": {
-				ID:   "baz",
-				Host: "localhost",
-				Port: 5002,
+				ID:           "baz",
+				Host:         "localhost",
+				ProtocolPort: 5002,
 			},
 		},
 	}
-	return newClient(raft.NewCluster(members), client, raft.ReadConsistency_SEQUENTIAL)
+	cluster, err := raft.NewCluster(members)
+	if err != nil {
+		panic(err)
+	}
+	return newClient(cluster, client, &config.ProtocolConfig{}, raft.ReadConsistency_SEQUENTIAL)
 }
 
 func TestClient(t *testing.T) {
@@ -122,17 +127,77 @@ func TestClient(t *testing.T) {
 
 	client := newTestClient(protocol)
 
-	ch := make(chan node.Output)
-	assert.NoError(t, client.Write(context.Background(), []byte("Hello world!"), ch))
-	assert.Equal(t, "foo", string((<-ch).Value))
-	assert.Equal(t, "bar", string((<-ch).Value))
+	ch := make(chan streams.Result)
+	assert.NoError(t, client.Write(context.Background(), []byte("Hello world!"), streams.NewChannelStream(ch)))
+	assert.Equal(t, "foo", string((<-ch).Value.([]byte)))
+	assert.Equal(t, "bar", string((<-ch).Value.([]byte)))
 	_, ok := <-ch
 	assert.False(t, ok)
 
-	ch = make(chan node.Output)
-	assert.NoError(t, client.Read(context.Background(), []byte("Hello world again!"), ch))
-	assert.Equal(t, "bar", string((<-ch).Value))
-	assert.Equal(t, "baz", string((<-ch).Value))
+	ch = make(chan streams.Result)
+	assert.NoError(t, client.Read(context.Background(), []byte("Hello world again!"), streams.NewChannelStream(ch)))
+	assert.Equal(t, "bar", string((<-ch).Value.([]byte)))
+	assert.Equal(t, "baz", string((<-ch).Value.([]byte)))
 	_, ok = <-ch
 	assert.False(t, ok)
 }
+
+func TestClientSubmitBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol := mock.NewMockClient(ctrl)
+
+	members := []raft.MemberID{
+		"foo",
+		"bar",
+		"baz",
+	}
+	protocol.EXPECT().
+		CommandBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&raft.CommandBatchResponse{
+			Status:  raft.ResponseStatus_ERROR,
+			Error:   raft.ResponseError_ILLEGAL_MEMBER_STATE,
+			Leader:  raft.MemberID("bar"),
+			Term:    raft.Term(1),
+			Members: members,
+		}, nil)
+	protocol.EXPECT().
+		CommandBatch(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("bar"))).
+		DoAndReturn(func(ctx context.Context, request *raft.CommandBatchRequest, member raft.MemberID) (*raft.CommandBatchResponse, error) {
+			assert.Equal(t, [][]byte{[]byte("foo"), []byte("bar"), []byte("baz")}, request.Values)
+			return &raft.CommandBatchResponse{
+				Status:  raft.ResponseStatus_OK,
+				Leader:  raft.MemberID("bar"),
+				Term:    raft.Term(1),
+				Members: members,
+				Results: []*raft.CommandResult{
+					{
+						Status: raft.ResponseStatus_OK,
+						Index:  raft.Index(2),
+						Output: []byte("foo"),
+					},
+					{
+						Status:  raft.ResponseStatus_ERROR,
+						Error:   raft.ResponseError_APPLICATION_ERROR,
+						Message: "failed",
+						Index:   raft.Index(3),
+					},
+					{
+						Status: raft.ResponseStatus_OK,
+						Index:  raft.Index(4),
+						Output: []byte("baz"),
+					},
+				},
+			}, nil
+		})
+
+	client := newTestClient(protocol)
+	results, err := client.SubmitBatch(context.Background(), [][]byte{[]byte("foo"), []byte("bar"), []byte("baz")})
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.True(t, results[0].Succeeded())
+	assert.Equal(t, "foo", string(results[0].Value.([]byte)))
+	assert.True(t, results[1].Failed())
+	assert.EqualError(t, results[1].Error, "failed")
+	assert.True(t, results[2].Succeeded())
+	assert.Equal(t, "baz", string(results[2].Value.([]byte)))
+}
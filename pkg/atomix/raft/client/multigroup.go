@@ -0,0 +1,126 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/atomix/go-framework/pkg/atomix/node"
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"sync"
+)
+
+// GroupCommand is the set of commands to apply to a single group in each phase of a multi-group command
+type GroupCommand struct {
+	// Prepare is the command written to the group in the prepare phase
+	Prepare []byte
+	// Commit is the command written to the group once all groups have prepared
+	Commit []byte
+	// Abort is the command written to the group if any group fails to prepare
+	Abort []byte
+}
+
+// NewMultiGroupCommand returns a new coordinator for commands spanning the given groups
+func NewMultiGroupCommand(groups map[string]node.Client) *MultiGroupCommand {
+	return &MultiGroupCommand{
+		groups: groups,
+	}
+}
+
+// MultiGroupCommand coordinates a command across multiple Raft groups using two-phase commit.
+// The prepare command is written to each group, and the commit command is written to all groups
+// only if every group prepared successfully. Otherwise, the abort command is written to each
+// group that was prepared.
+type MultiGroupCommand struct {
+	groups map[string]node.Client
+}
+
+// Execute executes the given commands, returning the output of the commit command for each group
+func (c *MultiGroupCommand) Execute(ctx context.Context, commands map[string]*GroupCommand) (map[string][]byte, error) {
+	for group := range commands {
+		if _, ok := c.groups[group]; !ok {
+			return nil, fmt.Errorf("unknown group %s", group)
+		}
+	}
+
+	// Prepare the command on all groups.
+	prepared, err := c.execute(ctx, commands, func(command *GroupCommand) []byte {
+		return command.Prepare
+	})
+	if err != nil {
+		// If any group failed to prepare, abort the command on the groups that were prepared.
+		aborts := make(map[string]*GroupCommand)
+		for group := range prepared {
+			aborts[group] = commands[group]
+		}
+		_, _ = c.execute(ctx, aborts, func(command *GroupCommand) []byte {
+			return command.Abort
+		})
+		return nil, err
+	}
+
+	// Once all groups have prepared, commit the command on all groups.
+	return c.execute(ctx, commands, func(command *GroupCommand) []byte {
+		return command.Commit
+	})
+}
+
+// execute writes a phase of the given commands to each group concurrently, returning the outputs of
+// the groups that succeeded and the first error returned by any group
+func (c *MultiGroupCommand) execute(ctx context.Context, commands map[string]*GroupCommand, f func(*GroupCommand) []byte) (map[string][]byte, error) {
+	wg := &sync.WaitGroup{}
+	mu := &sync.Mutex{}
+	outputs := make(map[string][]byte)
+	var err error
+	for group, command := range commands {
+		wg.Add(1)
+		go func(group string, client node.Client, input []byte) {
+			defer wg.Done()
+			output, e := writeGroup(ctx, client, input)
+			mu.Lock()
+			defer mu.Unlock()
+			if e != nil {
+				if err == nil {
+					err = fmt.Errorf("group %s: %v", group, e)
+				}
+			} else {
+				outputs[group] = output
+			}
+		}(group, c.groups[group], f(command))
+	}
+	wg.Wait()
+	return outputs, err
+}
+
+// writeGroup writes a command to the given group and waits for the result
+func writeGroup(ctx context.Context, client node.Client, input []byte) ([]byte, error) {
+	ch := make(chan streams.Result)
+	if err := client.Write(ctx, input, streams.NewChannelStream(ch)); err != nil {
+		return nil, err
+	}
+
+	var output []byte
+	var err error
+	for result := range ch {
+		if result.Failed() {
+			if err == nil {
+				err = result.Error
+			}
+		} else if value, ok := result.Value.([]byte); ok {
+			output = value
+		}
+	}
+	return output, err
+}
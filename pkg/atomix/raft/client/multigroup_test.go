@@ -0,0 +1,105 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/atomix/go-framework/pkg/atomix/node"
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+// testGroupClient is a group client that records written commands and fails the configured commands
+type testGroupClient struct {
+	node.Client
+	fail    string
+	written []string
+	mu      sync.Mutex
+}
+
+func (c *testGroupClient) Write(ctx context.Context, in []byte, stream streams.WriteStream) error {
+	c.mu.Lock()
+	c.written = append(c.written, string(in))
+	c.mu.Unlock()
+	go func() {
+		if string(in) == c.fail {
+			stream.Error(errors.New("failed"))
+		} else {
+			stream.Value(append([]byte("ok:"), in...))
+		}
+		stream.Close()
+	}()
+	return nil
+}
+
+func newTestGroupCommands(groups ...string) map[string]*GroupCommand {
+	commands := make(map[string]*GroupCommand)
+	for _, group := range groups {
+		commands[group] = &GroupCommand{
+			Prepare: []byte("prepare"),
+			Commit:  []byte("commit"),
+			Abort:   []byte("abort"),
+		}
+	}
+	return commands
+}
+
+func TestMultiGroupCommandCommit(t *testing.T) {
+	foo := &testGroupClient{}
+	bar := &testGroupClient{}
+	command := NewMultiGroupCommand(map[string]node.Client{
+		"foo": foo,
+		"bar": bar,
+	})
+
+	outputs, err := command.Execute(context.TODO(), newTestGroupCommands("foo", "bar"))
+	assert.NoError(t, err)
+	assert.Equal(t, "ok:commit", string(outputs["foo"]))
+	assert.Equal(t, "ok:commit", string(outputs["bar"]))
+	assert.Equal(t, []string{"prepare", "commit"}, foo.written)
+	assert.Equal(t, []string{"prepare", "commit"}, bar.written)
+}
+
+func TestMultiGroupCommandAbort(t *testing.T) {
+	foo := &testGroupClient{}
+	bar := &testGroupClient{fail: "prepare"}
+	baz := &testGroupClient{}
+	command := NewMultiGroupCommand(map[string]node.Client{
+		"foo": foo,
+		"bar": bar,
+		"baz": baz,
+	})
+
+	outputs, err := command.Execute(context.TODO(), newTestGroupCommands("foo", "bar", "baz"))
+	assert.Error(t, err)
+	assert.Nil(t, outputs)
+	assert.Equal(t, []string{"prepare", "abort"}, foo.written)
+	assert.Equal(t, []string{"prepare"}, bar.written)
+	assert.Equal(t, []string{"prepare", "abort"}, baz.written)
+}
+
+func TestMultiGroupCommandUnknownGroup(t *testing.T) {
+	foo := &testGroupClient{}
+	command := NewMultiGroupCommand(map[string]node.Client{
+		"foo": foo,
+	})
+
+	_, err := command.Execute(context.TODO(), newTestGroupCommands("foo", "bar"))
+	assert.Error(t, err)
+	assert.Empty(t, foo.written)
+}
@@ -0,0 +1,120 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"sync"
+)
+
+// newQuerySequencer returns a new query sequencer
+func newQuerySequencer(ordered bool) *querySequencer {
+	return &querySequencer{
+		ordered:  ordered,
+		next:     1,
+		sequence: make(map[uint64]*sequencedStream),
+	}
+}
+
+// querySequencer assigns sequence numbers to queries and optionally delivers query results in sequence order.
+// Queries may complete out of order, e.g. when they're batched in a shared leadership check. If the sequencer
+// is ordered, results for a query are buffered until all queries with lower sequence numbers have completed.
+type querySequencer struct {
+	ordered      bool
+	lastSequence uint64
+	next         uint64
+	sequence     map[uint64]*sequencedStream
+	mu           sync.Mutex
+}
+
+// newStream assigns the next sequence number to a query and returns the stream to which to write its results
+func (s *querySequencer) newStream(stream streams.WriteStream) (uint64, streams.WriteStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastSequence++
+	sequenceNumber := s.lastSequence
+	if !s.ordered {
+		return sequenceNumber, stream
+	}
+	sequenced := &sequencedStream{
+		sequencer:      s,
+		sequenceNumber: sequenceNumber,
+		stream:         stream,
+	}
+	s.sequence[sequenceNumber] = sequenced
+	return sequenceNumber, sequenced
+}
+
+// send sends a result for the given query, buffering the result if prior queries have not completed
+func (s *querySequencer) send(stream *sequencedStream, result streams.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stream.sequenceNumber == s.next {
+		stream.stream.Send(result)
+	} else {
+		stream.results = append(stream.results, result)
+	}
+}
+
+// close completes the given query and delivers the buffered results of queries that are next in sequence
+func (s *querySequencer) close(stream *sequencedStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stream.closed = true
+	for sequenced, ok := s.sequence[s.next]; ok; sequenced, ok = s.sequence[s.next] {
+		for _, result := range sequenced.results {
+			sequenced.stream.Send(result)
+		}
+		sequenced.results = nil
+		if !sequenced.closed {
+			return
+		}
+		sequenced.stream.Close()
+		delete(s.sequence, s.next)
+		s.next++
+	}
+}
+
+// sequencedStream is a query stream that delivers results through a query sequencer
+type sequencedStream struct {
+	sequencer      *querySequencer
+	sequenceNumber uint64
+	stream         streams.WriteStream
+	results        []streams.Result
+	closed         bool
+}
+
+func (s *sequencedStream) Send(result streams.Result) {
+	s.sequencer.send(s, result)
+}
+
+func (s *sequencedStream) Result(value interface{}, err error) {
+	s.Send(streams.Result{
+		Value: value,
+		Error: err,
+	})
+}
+
+func (s *sequencedStream) Value(value interface{}) {
+	s.Result(value, nil)
+}
+
+func (s *sequencedStream) Error(err error) {
+	s.Result(nil, err)
+}
+
+func (s *sequencedStream) Close() {
+	s.sequencer.close(s)
+}
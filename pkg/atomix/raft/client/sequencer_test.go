@@ -0,0 +1,93 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// assertNoResult asserts that no result is available on the given channel
+func assertNoResult(t *testing.T, ch chan streams.Result) {
+	select {
+	case result := <-ch:
+		assert.Fail(t, "unexpected result", result)
+	default:
+	}
+}
+
+func TestUnorderedQueries(t *testing.T) {
+	sequencer := newQuerySequencer(false)
+	ch1 := make(chan streams.Result, 1)
+	ch2 := make(chan streams.Result, 1)
+	sequenceNumber1, stream1 := sequencer.newStream(streams.NewChannelStream(ch1))
+	sequenceNumber2, stream2 := sequencer.newStream(streams.NewChannelStream(ch2))
+	assert.Equal(t, uint64(1), sequenceNumber1)
+	assert.Equal(t, uint64(2), sequenceNumber2)
+
+	// Verify results are delivered as soon as they complete
+	stream2.Value("bar")
+	stream2.Close()
+	assert.Equal(t, "bar", (<-ch2).Value)
+	_, ok := <-ch2
+	assert.False(t, ok)
+
+	stream1.Value("foo")
+	stream1.Close()
+	assert.Equal(t, "foo", (<-ch1).Value)
+	_, ok = <-ch1
+	assert.False(t, ok)
+}
+
+func TestOrderedQueries(t *testing.T) {
+	sequencer := newQuerySequencer(true)
+	ch1 := make(chan streams.Result, 2)
+	ch2 := make(chan streams.Result, 2)
+	ch3 := make(chan streams.Result, 2)
+	sequenceNumber1, stream1 := sequencer.newStream(streams.NewChannelStream(ch1))
+	sequenceNumber2, stream2 := sequencer.newStream(streams.NewChannelStream(ch2))
+	sequenceNumber3, stream3 := sequencer.newStream(streams.NewChannelStream(ch3))
+	assert.Equal(t, uint64(1), sequenceNumber1)
+	assert.Equal(t, uint64(2), sequenceNumber2)
+	assert.Equal(t, uint64(3), sequenceNumber3)
+
+	// Verify results for later queries are buffered until prior queries complete
+	stream3.Value("baz")
+	stream3.Close()
+	stream2.Value("bar")
+	assertNoResult(t, ch2)
+	assertNoResult(t, ch3)
+
+	// Verify results for the next query in sequence are delivered immediately
+	stream1.Value("foo")
+	assert.Equal(t, "foo", (<-ch1).Value)
+
+	// Verify buffered results are delivered once the prior query completes
+	stream1.Close()
+	_, ok := <-ch1
+	assert.False(t, ok)
+	assert.Equal(t, "bar", (<-ch2).Value)
+	assertNoResult(t, ch3)
+
+	stream2.Value("bar2")
+	assert.Equal(t, "bar2", (<-ch2).Value)
+	stream2.Close()
+	_, ok = <-ch2
+	assert.False(t, ok)
+	assert.Equal(t, "baz", (<-ch3).Value)
+	_, ok = <-ch3
+	assert.False(t, ok)
+}
@@ -14,11 +14,17 @@
 
 package config
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	defaultElectionTimeout   = 5 * time.Second
 	defaultHeartbeatInterval = 500 * time.Millisecond
+	defaultProbeRangeSize    = 100
+	defaultMaxElectionFreeze = 10 * time.Minute
+	defaultRoundTripWindow   = 10
 )
 
 // GetElectionTimeoutOrDefault returns the configured election timeout if set, otherwise the default election timeout
@@ -38,3 +44,151 @@ func (c *ProtocolConfig) GetHeartbeatIntervalOrDefault() time.Duration {
 	}
 	return defaultHeartbeatInterval
 }
+
+// GetMaxElectionTimeoutOrDefault returns the configured maximum election timeout if set, otherwise twice the election timeout
+func (c *ProtocolConfig) GetMaxElectionTimeoutOrDefault() time.Duration {
+	timeout := c.GetMaxElectionTimeout()
+	if timeout != nil {
+		return *timeout
+	}
+	return c.GetElectionTimeoutOrDefault() * 2
+}
+
+// GetElectionJitterOrDefault returns the configured fraction of the election timeout by which election timeouts
+// are randomized if set, otherwise 1, randomizing election timeouts between the election timeout and twice the
+// election timeout
+func (c *ProtocolConfig) GetElectionJitterOrDefault() float32 {
+	jitter := c.GetElectionJitter()
+	if jitter > 0 {
+		return jitter
+	}
+	return 1
+}
+
+// GetTransferTimeoutOrDefault returns the configured leadership transfer timeout if set, otherwise the election timeout
+func (c *ProtocolConfig) GetTransferTimeoutOrDefault() time.Duration {
+	timeout := c.GetTransferTimeout()
+	if timeout != nil {
+		return *timeout
+	}
+	return c.GetElectionTimeoutOrDefault()
+}
+
+// GetMinAppliedIndexTimeoutOrDefault returns the configured maximum duration for which a query waits for its
+// minimum applied index to be applied if set, otherwise the election timeout
+func (c *ProtocolConfig) GetMinAppliedIndexTimeoutOrDefault() time.Duration {
+	timeout := c.GetMinAppliedIndexTimeout()
+	if timeout != nil {
+		return *timeout
+	}
+	return c.GetElectionTimeoutOrDefault()
+}
+
+// GetMaxElectionFreezeOrDefault returns the configured maximum duration for which elections may be frozen if set,
+// otherwise the default maximum election freeze
+func (c *ProtocolConfig) GetMaxElectionFreezeOrDefault() time.Duration {
+	freeze := c.GetMaxElectionFreeze()
+	if freeze != nil {
+		return *freeze
+	}
+	return defaultMaxElectionFreeze
+}
+
+// GetMaxAdaptiveElectionTimeoutOrDefault returns the configured upper bound for adaptive election timeouts
+// if set, otherwise the max election timeout
+func (c *ProtocolConfig) GetMaxAdaptiveElectionTimeoutOrDefault() time.Duration {
+	timeout := c.GetAdaptiveElectionTimeout().GetMaxTimeout()
+	if timeout != nil {
+		return *timeout
+	}
+	return c.GetMaxElectionTimeoutOrDefault()
+}
+
+// GetPriority returns the configured election priority for the given member, otherwise 0
+func (c *ProtocolConfig) GetPriority(member string) int32 {
+	return c.GetPriorities()[member]
+}
+
+// GetRetainedSnapshotsOrDefault returns the configured number of snapshots to retain if set, otherwise 1
+func (c *ProtocolConfig) GetRetainedSnapshotsOrDefault() int {
+	retained := c.GetRetainedSnapshots()
+	if retained > 0 {
+		return int(retained)
+	}
+	return 1
+}
+
+// GetMaxInflightAppendsOrDefault returns the configured maximum number of append requests in flight to a follower
+// if set, otherwise 1
+func (c *ProtocolConfig) GetMaxInflightAppendsOrDefault() int {
+	inflight := c.GetMaxInflightAppends()
+	if inflight > 0 {
+		return int(inflight)
+	}
+	return 1
+}
+
+// GetZone returns the configured zone for the given member, otherwise an empty string
+func (c *ProtocolConfig) GetZone(member string) string {
+	return c.GetZones()[member]
+}
+
+// IsWitness returns whether the given member is configured as a witness
+func (c *ProtocolConfig) IsWitness(member string) bool {
+	for _, witness := range c.GetWitnesses() {
+		if witness == member {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLocalZoneWaitOrDefault returns the configured time to wait for local zone followers to confirm a read index
+// if set, otherwise the heartbeat interval
+func (c *ProtocolConfig) GetLocalZoneWaitOrDefault() time.Duration {
+	wait := c.GetReadIndex().GetLocalZoneWait()
+	if wait != nil {
+		return *wait
+	}
+	return c.GetHeartbeatIntervalOrDefault()
+}
+
+// GetRangeSizeOrDefault returns the configured number of entries to compare in each consistency probe if set,
+// otherwise the default range size
+func (c *ConsistencyProbeConfig) GetRangeSizeOrDefault() uint64 {
+	size := c.GetRangeSize()
+	if size > 0 {
+		return size
+	}
+	return defaultProbeRangeSize
+}
+
+// GetWindowOrDefault returns the configured number of round trip times to track for each member if set,
+// otherwise the default window
+func (c *AdaptiveTimeoutConfig) GetWindowOrDefault() int {
+	window := c.GetWindow()
+	if window > 0 {
+		return int(window)
+	}
+	return defaultRoundTripWindow
+}
+
+// Validate validates the protocol configuration
+func (c *ProtocolConfig) Validate() error {
+	if c.GetMaxElectionTimeoutOrDefault() < c.GetElectionTimeoutOrDefault() {
+		return fmt.Errorf("max election timeout %s is less than the election timeout %s", c.GetMaxElectionTimeoutOrDefault(), c.GetElectionTimeoutOrDefault())
+	}
+	if timeout := c.GetAdaptiveElectionTimeout().GetMaxTimeout(); timeout != nil && *timeout < c.GetElectionTimeoutOrDefault() {
+		return fmt.Errorf("max adaptive election timeout %s is less than the election timeout %s", *timeout, c.GetElectionTimeoutOrDefault())
+	}
+	if c.GetElectionTimeoutOrDefault() <= c.GetHeartbeatIntervalOrDefault() {
+		return fmt.Errorf("election timeout %s is not greater than the heartbeat interval %s", c.GetElectionTimeoutOrDefault(), c.GetHeartbeatIntervalOrDefault())
+	}
+	if c.ElectionJitter < 0 {
+		return fmt.Errorf("election jitter %f is negative", c.ElectionJitter)
+	}
+	if c.LeaseHeartbeatRatio < 0 || c.LeaseHeartbeatRatio > 1 {
+		return fmt.Errorf("lease heartbeat ratio %f is not between 0 and 1", c.LeaseHeartbeatRatio)
+	}
+	return nil
+}
@@ -26,7 +26,7 @@ var _ = time.Kitchen
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type StorageLevel int32
 
@@ -53,11 +53,166 @@ func (StorageLevel) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_e09be49defe43eb0, []int{0}
 }
 
+type ApplyErrorPolicy int32
+
+const (
+	ApplyErrorPolicy_SKIP ApplyErrorPolicy = 0
+	ApplyErrorPolicy_HALT ApplyErrorPolicy = 1
+)
+
+var ApplyErrorPolicy_name = map[int32]string{
+	0: "SKIP",
+	1: "HALT",
+}
+
+var ApplyErrorPolicy_value = map[string]int32{
+	"SKIP": 0,
+	"HALT": 1,
+}
+
+func (x ApplyErrorPolicy) String() string {
+	return proto.EnumName(ApplyErrorPolicy_name, int32(x))
+}
+
+func (ApplyErrorPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{1}
+}
+
+type PromotionPolicy int32
+
+const (
+	PromotionPolicy_IMMEDIATE PromotionPolicy = 0
+	PromotionPolicy_STABLE    PromotionPolicy = 1
+)
+
+var PromotionPolicy_name = map[int32]string{
+	0: "IMMEDIATE",
+	1: "STABLE",
+}
+
+var PromotionPolicy_value = map[string]int32{
+	"IMMEDIATE": 0,
+	"STABLE":    1,
+}
+
+func (x PromotionPolicy) String() string {
+	return proto.EnumName(PromotionPolicy_name, int32(x))
+}
+
+func (PromotionPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{2}
+}
+
+type EvenClusterPolicy int32
+
+const (
+	EvenClusterPolicy_ALLOW  EvenClusterPolicy = 0
+	EvenClusterPolicy_WARN   EvenClusterPolicy = 1
+	EvenClusterPolicy_REJECT EvenClusterPolicy = 2
+)
+
+var EvenClusterPolicy_name = map[int32]string{
+	0: "ALLOW",
+	1: "WARN",
+	2: "REJECT",
+}
+
+var EvenClusterPolicy_value = map[string]int32{
+	"ALLOW":  0,
+	"WARN":   1,
+	"REJECT": 2,
+}
+
+func (x EvenClusterPolicy) String() string {
+	return proto.EnumName(EvenClusterPolicy_name, int32(x))
+}
+
+func (EvenClusterPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{3}
+}
+
+type CommitOverflowPolicy int32
+
+const (
+	CommitOverflowPolicy_CLAMP   CommitOverflowPolicy = 0
+	CommitOverflowPolicy_DISCARD CommitOverflowPolicy = 1
+)
+
+var CommitOverflowPolicy_name = map[int32]string{
+	0: "CLAMP",
+	1: "DISCARD",
+}
+
+var CommitOverflowPolicy_value = map[string]int32{
+	"CLAMP":   0,
+	"DISCARD": 1,
+}
+
+func (x CommitOverflowPolicy) String() string {
+	return proto.EnumName(CommitOverflowPolicy_name, int32(x))
+}
+
+func (CommitOverflowPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{4}
+}
+
 type ProtocolConfig struct {
-	ElectionTimeout   *time.Duration    `protobuf:"bytes,1,opt,name=election_timeout,json=electionTimeout,proto3,stdduration" json:"election_timeout,omitempty"`
-	HeartbeatInterval *time.Duration    `protobuf:"bytes,2,opt,name=heartbeat_interval,json=heartbeatInterval,proto3,stdduration" json:"heartbeat_interval,omitempty"`
-	Storage           *StorageConfig    `protobuf:"bytes,3,opt,name=storage,proto3" json:"storage,omitempty"`
-	Compaction        *CompactionConfig `protobuf:"bytes,4,opt,name=compaction,proto3" json:"compaction,omitempty"`
+	ElectionTimeout           *time.Duration          `protobuf:"bytes,1,opt,name=election_timeout,json=electionTimeout,proto3,stdduration" json:"election_timeout,omitempty"`
+	HeartbeatInterval         *time.Duration          `protobuf:"bytes,2,opt,name=heartbeat_interval,json=heartbeatInterval,proto3,stdduration" json:"heartbeat_interval,omitempty"`
+	Storage                   *StorageConfig          `protobuf:"bytes,3,opt,name=storage,proto3" json:"storage,omitempty"`
+	Compaction                *CompactionConfig       `protobuf:"bytes,4,opt,name=compaction,proto3" json:"compaction,omitempty"`
+	MaxElectionTimeout        *time.Duration          `protobuf:"bytes,5,opt,name=max_election_timeout,json=maxElectionTimeout,proto3,stdduration" json:"max_election_timeout,omitempty"`
+	Priorities                map[string]int32        `protobuf:"bytes,6,rep,name=priorities,proto3" json:"priorities,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Metrics                   *MetricsConfig          `protobuf:"bytes,7,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	TransferTimeout           *time.Duration          `protobuf:"bytes,8,opt,name=transfer_timeout,json=transferTimeout,proto3,stdduration" json:"transfer_timeout,omitempty"`
+	OrderedQueries            bool                    `protobuf:"varint,9,opt,name=ordered_queries,json=orderedQueries,proto3" json:"ordered_queries,omitempty"`
+	Apply                     *ApplyConfig            `protobuf:"bytes,10,opt,name=apply,proto3" json:"apply,omitempty"`
+	Zones                     map[string]string       `protobuf:"bytes,11,rep,name=zones,proto3" json:"zones,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReadIndex                 *ReadIndexConfig        `protobuf:"bytes,12,opt,name=read_index,json=readIndex,proto3" json:"read_index,omitempty"`
+	ConsistencyProbe          *ConsistencyProbeConfig `protobuf:"bytes,13,opt,name=consistency_probe,json=consistencyProbe,proto3" json:"consistency_probe,omitempty"`
+	Recovery                  *RecoveryConfig         `protobuf:"bytes,14,opt,name=recovery,proto3" json:"recovery,omitempty"`
+	RetainedSnapshots         uint32                  `protobuf:"varint,15,opt,name=retained_snapshots,json=retainedSnapshots,proto3" json:"retained_snapshots,omitempty"`
+	TruncationAlertThreshold  uint64                  `protobuf:"varint,16,opt,name=truncation_alert_threshold,json=truncationAlertThreshold,proto3" json:"truncation_alert_threshold,omitempty"`
+	DisableStickyPolls        bool                    `protobuf:"varint,17,opt,name=disable_sticky_polls,json=disableStickyPolls,proto3" json:"disable_sticky_polls,omitempty"`
+	MaxCommandStreams         uint32                  `protobuf:"varint,18,opt,name=max_command_streams,json=maxCommandStreams,proto3" json:"max_command_streams,omitempty"`
+	FairProposals             bool                    `protobuf:"varint,19,opt,name=fair_proposals,json=fairProposals,proto3" json:"fair_proposals,omitempty"`
+	DisableInstallHeartbeats  bool                    `protobuf:"varint,20,opt,name=disable_install_heartbeats,json=disableInstallHeartbeats,proto3" json:"disable_install_heartbeats,omitempty"`
+	PromotionPolicy           PromotionPolicy         `protobuf:"varint,21,opt,name=promotion_policy,json=promotionPolicy,proto3,enum=atomix.raft.config.PromotionPolicy" json:"promotion_policy,omitempty"`
+	FollowerBufferSize        uint32                  `protobuf:"varint,22,opt,name=follower_buffer_size,json=followerBufferSize,proto3" json:"follower_buffer_size,omitempty"`
+	CheckVoteConfiguration    bool                    `protobuf:"varint,23,opt,name=check_vote_configuration,json=checkVoteConfiguration,proto3" json:"check_vote_configuration,omitempty"`
+	MaxElectionFreeze         *time.Duration          `protobuf:"bytes,24,opt,name=max_election_freeze,json=maxElectionFreeze,proto3,stdduration" json:"max_election_freeze,omitempty"`
+	MaxClockDrift             *time.Duration          `protobuf:"bytes,25,opt,name=max_clock_drift,json=maxClockDrift,proto3,stdduration" json:"max_clock_drift,omitempty"`
+	MaxAppendSize             uint32                  `protobuf:"varint,26,opt,name=max_append_size,json=maxAppendSize,proto3" json:"max_append_size,omitempty"`
+	MaxCommandResultSize      uint32                  `protobuf:"varint,27,opt,name=max_command_result_size,json=maxCommandResultSize,proto3" json:"max_command_result_size,omitempty"`
+	ReadRepairWindow          *time.Duration          `protobuf:"bytes,28,opt,name=read_repair_window,json=readRepairWindow,proto3,stdduration" json:"read_repair_window,omitempty"`
+	ReportLastLeader          bool                    `protobuf:"varint,29,opt,name=report_last_leader,json=reportLastLeader,proto3" json:"report_last_leader,omitempty"`
+	BatchElectionMetadata     bool                    `protobuf:"varint,30,opt,name=batch_election_metadata,json=batchElectionMetadata,proto3" json:"batch_election_metadata,omitempty"`
+	InstallTimeout            *time.Duration          `protobuf:"bytes,31,opt,name=install_timeout,json=installTimeout,proto3,stdduration" json:"install_timeout,omitempty"`
+	ProposalBatchInterval     *time.Duration          `protobuf:"bytes,32,opt,name=proposal_batch_interval,json=proposalBatchInterval,proto3,stdduration" json:"proposal_batch_interval,omitempty"`
+	UrgentProposalTimeout     *time.Duration          `protobuf:"bytes,33,opt,name=urgent_proposal_timeout,json=urgentProposalTimeout,proto3,stdduration" json:"urgent_proposal_timeout,omitempty"`
+	StrictReads               bool                    `protobuf:"varint,34,opt,name=strict_reads,json=strictReads,proto3" json:"strict_reads,omitempty"`
+	AppendBatchWindow         *time.Duration          `protobuf:"bytes,35,opt,name=append_batch_window,json=appendBatchWindow,proto3,stdduration" json:"append_batch_window,omitempty"`
+	EvenClusterPolicy         EvenClusterPolicy       `protobuf:"varint,36,opt,name=even_cluster_policy,json=evenClusterPolicy,proto3,enum=atomix.raft.config.EvenClusterPolicy" json:"even_cluster_policy,omitempty"`
+	InitializeTimeout         *time.Duration          `protobuf:"bytes,37,opt,name=initialize_timeout,json=initializeTimeout,proto3,stdduration" json:"initialize_timeout,omitempty"`
+	MinAppliedIndexTimeout    *time.Duration          `protobuf:"bytes,38,opt,name=min_applied_index_timeout,json=minAppliedIndexTimeout,proto3,stdduration" json:"min_applied_index_timeout,omitempty"`
+	LeaderStabilizationPeriod *time.Duration          `protobuf:"bytes,39,opt,name=leader_stabilization_period,json=leaderStabilizationPeriod,proto3,stdduration" json:"leader_stabilization_period,omitempty"`
+	RequestSnapshots          bool                    `protobuf:"varint,40,opt,name=request_snapshots,json=requestSnapshots,proto3" json:"request_snapshots,omitempty"`
+	CommitOverflowPolicy      CommitOverflowPolicy    `protobuf:"varint,41,opt,name=commit_overflow_policy,json=commitOverflowPolicy,proto3,enum=atomix.raft.config.CommitOverflowPolicy" json:"commit_overflow_policy,omitempty"`
+	LeaseHeartbeatRatio       float32                 `protobuf:"fixed32,42,opt,name=lease_heartbeat_ratio,json=leaseHeartbeatRatio,proto3" json:"lease_heartbeat_ratio,omitempty"`
+	ElectionRateLimit         *RateLimitConfig        `protobuf:"bytes,43,opt,name=election_rate_limit,json=electionRateLimit,proto3" json:"election_rate_limit,omitempty"`
+	LearnerCatchUpThreshold   uint64                  `protobuf:"varint,44,opt,name=learner_catch_up_threshold,json=learnerCatchUpThreshold,proto3" json:"learner_catch_up_threshold,omitempty"`
+	MaxAppendBatchSize        uint32                  `protobuf:"varint,45,opt,name=max_append_batch_size,json=maxAppendBatchSize,proto3" json:"max_append_batch_size,omitempty"`
+	MaxInflightAppends        uint32                  `protobuf:"varint,46,opt,name=max_inflight_appends,json=maxInflightAppends,proto3" json:"max_inflight_appends,omitempty"`
+	AdaptiveElectionTimeout   *AdaptiveTimeoutConfig  `protobuf:"bytes,47,opt,name=adaptive_election_timeout,json=adaptiveElectionTimeout,proto3" json:"adaptive_election_timeout,omitempty"`
+	VerifyVotes               bool                    `protobuf:"varint,48,opt,name=verify_votes,json=verifyVotes,proto3" json:"verify_votes,omitempty"`
+	CheckQuorum               bool                    `protobuf:"varint,49,opt,name=check_quorum,json=checkQuorum,proto3" json:"check_quorum,omitempty"`
+	ElectionJitter            float32                 `protobuf:"fixed32,50,opt,name=election_jitter,json=electionJitter,proto3" json:"election_jitter,omitempty"`
+	JointConsensus            bool                    `protobuf:"varint,51,opt,name=joint_consensus,json=jointConsensus,proto3" json:"joint_consensus,omitempty"`
+	Witnesses                 []string                `protobuf:"bytes,52,rep,name=witnesses,proto3" json:"witnesses,omitempty"`
+	ForwardCommands           bool                    `protobuf:"varint,53,opt,name=forward_commands,json=forwardCommands,proto3" json:"forward_commands,omitempty"`
+	SingleNode                bool                    `protobuf:"varint,54,opt,name=single_node,json=singleNode,proto3" json:"single_node,omitempty"`
+	CommandStreamBuffer       uint32                  `protobuf:"varint,55,opt,name=command_stream_buffer,json=commandStreamBuffer,proto3" json:"command_stream_buffer,omitempty"`
+	Dedup                     *DedupConfig            `protobuf:"bytes,56,opt,name=dedup,proto3" json:"dedup,omitempty"`
 }
 
 func (m *ProtocolConfig) Reset()         { *m = ProtocolConfig{} }
@@ -121,670 +276,5843 @@ func (m *ProtocolConfig) GetCompaction() *CompactionConfig {
 	return nil
 }
 
-type StorageConfig struct {
-	Directory     string       `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
-	Level         StorageLevel `protobuf:"varint,2,opt,name=level,proto3,enum=atomix.raft.config.StorageLevel" json:"level,omitempty"`
-	MaxEntrySize  uint32       `protobuf:"varint,3,opt,name=max_entry_size,json=maxEntrySize,proto3" json:"max_entry_size,omitempty"`
-	SegmentSize   uint32       `protobuf:"varint,4,opt,name=segment_size,json=segmentSize,proto3" json:"segment_size,omitempty"`
-	FlushOnCommit bool         `protobuf:"varint,5,opt,name=flush_on_commit,json=flushOnCommit,proto3" json:"flush_on_commit,omitempty"`
+func (m *ProtocolConfig) GetMaxElectionTimeout() *time.Duration {
+	if m != nil {
+		return m.MaxElectionTimeout
+	}
+	return nil
 }
 
-func (m *StorageConfig) Reset()         { *m = StorageConfig{} }
-func (m *StorageConfig) String() string { return proto.CompactTextString(m) }
-func (*StorageConfig) ProtoMessage()    {}
-func (*StorageConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e09be49defe43eb0, []int{1}
+func (m *ProtocolConfig) GetPriorities() map[string]int32 {
+	if m != nil {
+		return m.Priorities
+	}
+	return nil
 }
-func (m *StorageConfig) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+
+func (m *ProtocolConfig) GetMetrics() *MetricsConfig {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
 }
-func (m *StorageConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_StorageConfig.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+
+func (m *ProtocolConfig) GetTransferTimeout() *time.Duration {
+	if m != nil {
+		return m.TransferTimeout
 	}
+	return nil
 }
-func (m *StorageConfig) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StorageConfig.Merge(m, src)
+
+func (m *ProtocolConfig) GetOrderedQueries() bool {
+	if m != nil {
+		return m.OrderedQueries
+	}
+	return false
 }
-func (m *StorageConfig) XXX_Size() int {
-	return m.Size()
+
+func (m *ProtocolConfig) GetApply() *ApplyConfig {
+	if m != nil {
+		return m.Apply
+	}
+	return nil
 }
-func (m *StorageConfig) XXX_DiscardUnknown() {
-	xxx_messageInfo_StorageConfig.DiscardUnknown(m)
+
+func (m *ProtocolConfig) GetZones() map[string]string {
+	if m != nil {
+		return m.Zones
+	}
+	return nil
 }
 
-var xxx_messageInfo_StorageConfig proto.InternalMessageInfo
+func (m *ProtocolConfig) GetReadIndex() *ReadIndexConfig {
+	if m != nil {
+		return m.ReadIndex
+	}
+	return nil
+}
 
-func (m *StorageConfig) GetDirectory() string {
+func (m *ProtocolConfig) GetConsistencyProbe() *ConsistencyProbeConfig {
 	if m != nil {
-		return m.Directory
+		return m.ConsistencyProbe
 	}
-	return ""
+	return nil
 }
 
-func (m *StorageConfig) GetLevel() StorageLevel {
+func (m *ProtocolConfig) GetRecovery() *RecoveryConfig {
 	if m != nil {
-		return m.Level
+		return m.Recovery
 	}
-	return StorageLevel_DISK
+	return nil
 }
 
-func (m *StorageConfig) GetMaxEntrySize() uint32 {
+func (m *ProtocolConfig) GetRetainedSnapshots() uint32 {
 	if m != nil {
-		return m.MaxEntrySize
+		return m.RetainedSnapshots
 	}
 	return 0
 }
 
-func (m *StorageConfig) GetSegmentSize() uint32 {
+func (m *ProtocolConfig) GetTruncationAlertThreshold() uint64 {
 	if m != nil {
-		return m.SegmentSize
+		return m.TruncationAlertThreshold
 	}
 	return 0
 }
 
-func (m *StorageConfig) GetFlushOnCommit() bool {
+func (m *ProtocolConfig) GetDisableStickyPolls() bool {
 	if m != nil {
-		return m.FlushOnCommit
+		return m.DisableStickyPolls
 	}
 	return false
 }
 
-type CompactionConfig struct {
-	Dynamic          bool    `protobuf:"varint,1,opt,name=dynamic,proto3" json:"dynamic,omitempty"`
-	FreeDiskBuffer   float32 `protobuf:"fixed32,2,opt,name=free_disk_buffer,json=freeDiskBuffer,proto3" json:"free_disk_buffer,omitempty"`
-	FreeMemoryBuffer float32 `protobuf:"fixed32,3,opt,name=free_memory_buffer,json=freeMemoryBuffer,proto3" json:"free_memory_buffer,omitempty"`
+func (m *ProtocolConfig) GetMaxCommandStreams() uint32 {
+	if m != nil {
+		return m.MaxCommandStreams
+	}
+	return 0
 }
 
-func (m *CompactionConfig) Reset()         { *m = CompactionConfig{} }
-func (m *CompactionConfig) String() string { return proto.CompactTextString(m) }
-func (*CompactionConfig) ProtoMessage()    {}
-func (*CompactionConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e09be49defe43eb0, []int{2}
-}
-func (m *CompactionConfig) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+func (m *ProtocolConfig) GetFairProposals() bool {
+	if m != nil {
+		return m.FairProposals
+	}
+	return false
 }
-func (m *CompactionConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_CompactionConfig.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+
+func (m *ProtocolConfig) GetDisableInstallHeartbeats() bool {
+	if m != nil {
+		return m.DisableInstallHeartbeats
 	}
+	return false
 }
-func (m *CompactionConfig) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CompactionConfig.Merge(m, src)
+
+func (m *ProtocolConfig) GetPromotionPolicy() PromotionPolicy {
+	if m != nil {
+		return m.PromotionPolicy
+	}
+	return PromotionPolicy_IMMEDIATE
 }
-func (m *CompactionConfig) XXX_Size() int {
-	return m.Size()
+
+func (m *ProtocolConfig) GetFollowerBufferSize() uint32 {
+	if m != nil {
+		return m.FollowerBufferSize
+	}
+	return 0
 }
-func (m *CompactionConfig) XXX_DiscardUnknown() {
-	xxx_messageInfo_CompactionConfig.DiscardUnknown(m)
+
+func (m *ProtocolConfig) GetCheckVoteConfiguration() bool {
+	if m != nil {
+		return m.CheckVoteConfiguration
+	}
+	return false
 }
 
-var xxx_messageInfo_CompactionConfig proto.InternalMessageInfo
+func (m *ProtocolConfig) GetMaxElectionFreeze() *time.Duration {
+	if m != nil {
+		return m.MaxElectionFreeze
+	}
+	return nil
+}
 
-func (m *CompactionConfig) GetDynamic() bool {
+func (m *ProtocolConfig) GetMaxClockDrift() *time.Duration {
 	if m != nil {
-		return m.Dynamic
+		return m.MaxClockDrift
 	}
-	return false
+	return nil
 }
 
-func (m *CompactionConfig) GetFreeDiskBuffer() float32 {
+func (m *ProtocolConfig) GetMaxAppendSize() uint32 {
 	if m != nil {
-		return m.FreeDiskBuffer
+		return m.MaxAppendSize
 	}
 	return 0
 }
 
-func (m *CompactionConfig) GetFreeMemoryBuffer() float32 {
+func (m *ProtocolConfig) GetMaxCommandResultSize() uint32 {
 	if m != nil {
-		return m.FreeMemoryBuffer
+		return m.MaxCommandResultSize
 	}
 	return 0
 }
 
-func init() {
-	proto.RegisterEnum("atomix.raft.config.StorageLevel", StorageLevel_name, StorageLevel_value)
-	proto.RegisterType((*ProtocolConfig)(nil), "atomix.raft.config.ProtocolConfig")
-	proto.RegisterType((*StorageConfig)(nil), "atomix.raft.config.StorageConfig")
-	proto.RegisterType((*CompactionConfig)(nil), "atomix.raft.config.CompactionConfig")
+func (m *ProtocolConfig) GetReadRepairWindow() *time.Duration {
+	if m != nil {
+		return m.ReadRepairWindow
+	}
+	return nil
 }
 
-func init() { proto.RegisterFile("atomix/raft/config/config.proto", fileDescriptor_e09be49defe43eb0) }
-
-var fileDescriptor_e09be49defe43eb0 = []byte{
-	// 511 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x4f, 0x6f, 0xd3, 0x30,
-	0x18, 0xc6, 0xeb, 0xad, 0xdb, 0xba, 0x77, 0x6d, 0x57, 0x2c, 0x0e, 0x61, 0x42, 0x5e, 0x57, 0x55,
-	0xa8, 0x42, 0x28, 0x95, 0x86, 0xc4, 0x85, 0x13, 0x6d, 0x77, 0x18, 0x30, 0xa8, 0x52, 0xee, 0x91,
-	0x9b, 0x3a, 0x99, 0xb5, 0x38, 0x9e, 0x1c, 0x77, 0x6a, 0x77, 0xe6, 0x03, 0x70, 0xe4, 0x23, 0x70,
-	0xe3, 0xca, 0x47, 0xe0, 0xb8, 0x13, 0xe2, 0x06, 0xa4, 0x5f, 0x82, 0x23, 0x8a, 0x9d, 0x8c, 0xf1,
-	0x47, 0x88, 0x53, 0xde, 0x3c, 0xfe, 0x3d, 0xef, 0xeb, 0xe7, 0x95, 0x61, 0x9f, 0x6a, 0x29, 0xf8,
-	0xa2, 0xaf, 0x68, 0xa8, 0xfb, 0x81, 0x4c, 0x42, 0x1e, 0x15, 0x1f, 0xf7, 0x5c, 0x49, 0x2d, 0x31,
-	0xb6, 0x80, 0x9b, 0x03, 0xae, 0x3d, 0xd9, 0x23, 0x91, 0x94, 0x51, 0xcc, 0xfa, 0x86, 0x98, 0xce,
-	0xc3, 0xfe, 0x6c, 0xae, 0xa8, 0xe6, 0x32, 0xb1, 0x9e, 0xbd, 0xdb, 0x91, 0x8c, 0xa4, 0x29, 0xfb,
-	0x79, 0x65, 0xd5, 0xce, 0xfb, 0x35, 0x68, 0x8e, 0xf3, 0x2a, 0x90, 0xf1, 0xd0, 0x34, 0xc2, 0x4f,
-	0xa1, 0xc5, 0x62, 0x16, 0xe4, 0x56, 0x5f, 0x73, 0xc1, 0xe4, 0x5c, 0x3b, 0xa8, 0x8d, 0x7a, 0x3b,
-	0x87, 0x77, 0x5c, 0x3b, 0xc3, 0x2d, 0x67, 0xb8, 0xa3, 0x62, 0xc6, 0xa0, 0xfa, 0xf6, 0xcb, 0x3e,
-	0xf2, 0x76, 0x4b, 0xe3, 0x2b, 0xeb, 0xc3, 0x2f, 0x00, 0x9f, 0x32, 0xaa, 0xf4, 0x94, 0x51, 0xed,
-	0xf3, 0x44, 0x33, 0x75, 0x41, 0x63, 0x67, 0xed, 0xff, 0xba, 0xdd, 0xba, 0xb6, 0x1e, 0x17, 0x4e,
-	0xfc, 0x18, 0xb6, 0x52, 0x2d, 0x15, 0x8d, 0x98, 0xb3, 0x6e, 0x9a, 0x1c, 0xb8, 0x7f, 0xae, 0xc2,
-	0x9d, 0x58, 0xc4, 0xe6, 0xf1, 0x4a, 0x07, 0x1e, 0x01, 0x04, 0x52, 0x9c, 0x53, 0x73, 0x43, 0xa7,
-	0x6a, 0xfc, 0xdd, 0xbf, 0xf9, 0x87, 0xd7, 0x54, 0xd1, 0xe2, 0x86, 0xaf, 0xf3, 0x09, 0x41, 0xe3,
-	0x97, 0x01, 0xf8, 0x2e, 0x6c, 0xcf, 0xb8, 0x62, 0x81, 0x96, 0x6a, 0x69, 0x36, 0xb5, 0xed, 0xfd,
-	0x14, 0xf0, 0x23, 0xd8, 0x88, 0xd9, 0x05, 0xb3, 0xa9, 0x9b, 0x87, 0xed, 0x7f, 0x5c, 0xf8, 0x79,
-	0xce, 0x79, 0x16, 0xc7, 0x5d, 0x68, 0x0a, 0xba, 0xf0, 0x59, 0xa2, 0xd5, 0xd2, 0x4f, 0xf9, 0xa5,
-	0x4d, 0xdc, 0xf0, 0xea, 0x82, 0x2e, 0x8e, 0x72, 0x71, 0xc2, 0x2f, 0x19, 0x3e, 0x80, 0x7a, 0xca,
-	0x22, 0xc1, 0x12, 0x6d, 0x99, 0xaa, 0x61, 0x76, 0x0a, 0xcd, 0x20, 0xf7, 0x60, 0x37, 0x8c, 0xe7,
-	0xe9, 0xa9, 0x2f, 0x13, 0x3f, 0x90, 0x42, 0x70, 0xed, 0x6c, 0xb4, 0x51, 0xaf, 0xe6, 0x35, 0x8c,
-	0xfc, 0x32, 0x19, 0x1a, 0xb1, 0xf3, 0x1a, 0x41, 0xeb, 0xf7, 0xe4, 0xd8, 0x81, 0xad, 0xd9, 0x32,
-	0xa1, 0x82, 0x07, 0x26, 0x59, 0xcd, 0x2b, 0x7f, 0x71, 0x0f, 0x5a, 0xa1, 0x62, 0xcc, 0x9f, 0xf1,
-	0xf4, 0xcc, 0x9f, 0xce, 0xc3, 0x90, 0x29, 0x13, 0x71, 0xcd, 0x6b, 0xe6, 0xfa, 0x88, 0xa7, 0x67,
-	0x03, 0xa3, 0xe2, 0x07, 0x80, 0x0d, 0x29, 0x98, 0x90, 0x6a, 0x59, 0xb2, 0xeb, 0x86, 0x35, 0x3d,
-	0x4e, 0xcc, 0x81, 0xa5, 0xef, 0x77, 0xa1, 0x7e, 0x73, 0x1d, 0xb8, 0x06, 0xd5, 0xd1, 0xf1, 0xe4,
-	0x59, 0xab, 0x82, 0x01, 0x36, 0x4f, 0x9e, 0x8c, 0xc7, 0x47, 0xa3, 0x16, 0x1a, 0x74, 0xbf, 0x7f,
-	0x23, 0xe8, 0x5d, 0x46, 0xd0, 0x87, 0x8c, 0xa0, 0x8f, 0x19, 0x41, 0x57, 0x19, 0x41, 0x5f, 0x33,
-	0x82, 0xde, 0xac, 0x48, 0xe5, 0x6a, 0x45, 0x2a, 0x9f, 0x57, 0xa4, 0x32, 0xdd, 0x34, 0x4f, 0xeb,
-	0xe1, 0x8f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xdc, 0x48, 0x2b, 0x0a, 0x51, 0x03, 0x00, 0x00,
+func (m *ProtocolConfig) GetReportLastLeader() bool {
+	if m != nil {
+		return m.ReportLastLeader
+	}
+	return false
 }
 
-func (this *ProtocolConfig) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *ProtocolConfig) GetBatchElectionMetadata() bool {
+	if m != nil {
+		return m.BatchElectionMetadata
 	}
+	return false
+}
 
-	that1, ok := that.(*ProtocolConfig)
-	if !ok {
-		that2, ok := that.(ProtocolConfig)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *ProtocolConfig) GetInstallTimeout() *time.Duration {
+	if m != nil {
+		return m.InstallTimeout
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return nil
+}
+
+func (m *ProtocolConfig) GetProposalBatchInterval() *time.Duration {
+	if m != nil {
+		return m.ProposalBatchInterval
 	}
-	if this.ElectionTimeout != nil && that1.ElectionTimeout != nil {
-		if *this.ElectionTimeout != *that1.ElectionTimeout {
-			return false
-		}
-	} else if this.ElectionTimeout != nil {
-		return false
-	} else if that1.ElectionTimeout != nil {
-		return false
+	return nil
+}
+
+func (m *ProtocolConfig) GetUrgentProposalTimeout() *time.Duration {
+	if m != nil {
+		return m.UrgentProposalTimeout
 	}
-	if this.HeartbeatInterval != nil && that1.HeartbeatInterval != nil {
-		if *this.HeartbeatInterval != *that1.HeartbeatInterval {
-			return false
-		}
-	} else if this.HeartbeatInterval != nil {
-		return false
-	} else if that1.HeartbeatInterval != nil {
-		return false
+	return nil
+}
+
+func (m *ProtocolConfig) GetStrictReads() bool {
+	if m != nil {
+		return m.StrictReads
 	}
-	if !this.Storage.Equal(that1.Storage) {
-		return false
+	return false
+}
+
+func (m *ProtocolConfig) GetAppendBatchWindow() *time.Duration {
+	if m != nil {
+		return m.AppendBatchWindow
 	}
-	if !this.Compaction.Equal(that1.Compaction) {
-		return false
+	return nil
+}
+
+func (m *ProtocolConfig) GetEvenClusterPolicy() EvenClusterPolicy {
+	if m != nil {
+		return m.EvenClusterPolicy
 	}
-	return true
+	return EvenClusterPolicy_ALLOW
 }
-func (this *StorageConfig) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*StorageConfig)
-	if !ok {
-		that2, ok := that.(StorageConfig)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Directory != that1.Directory {
-		return false
-	}
-	if this.Level != that1.Level {
-		return false
-	}
-	if this.MaxEntrySize != that1.MaxEntrySize {
-		return false
-	}
-	if this.SegmentSize != that1.SegmentSize {
-		return false
-	}
-	if this.FlushOnCommit != that1.FlushOnCommit {
-		return false
+func (m *ProtocolConfig) GetInitializeTimeout() *time.Duration {
+	if m != nil {
+		return m.InitializeTimeout
 	}
-	return true
+	return nil
 }
-func (this *CompactionConfig) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*CompactionConfig)
-	if !ok {
-		that2, ok := that.(CompactionConfig)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Dynamic != that1.Dynamic {
-		return false
-	}
-	if this.FreeDiskBuffer != that1.FreeDiskBuffer {
-		return false
-	}
-	if this.FreeMemoryBuffer != that1.FreeMemoryBuffer {
-		return false
+func (m *ProtocolConfig) GetMinAppliedIndexTimeout() *time.Duration {
+	if m != nil {
+		return m.MinAppliedIndexTimeout
 	}
-	return true
+	return nil
 }
-func (m *ProtocolConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+
+func (m *ProtocolConfig) GetLeaderStabilizationPeriod() *time.Duration {
+	if m != nil {
+		return m.LeaderStabilizationPeriod
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ProtocolConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ProtocolConfig) GetRequestSnapshots() bool {
+	if m != nil {
+		return m.RequestSnapshots
+	}
+	return false
 }
 
-func (m *ProtocolConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Compaction != nil {
-		{
-			size, err := m.Compaction.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConfig(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.Storage != nil {
-		{
-			size, err := m.Storage.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConfig(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+func (m *ProtocolConfig) GetCommitOverflowPolicy() CommitOverflowPolicy {
+	if m != nil {
+		return m.CommitOverflowPolicy
 	}
-	if m.HeartbeatInterval != nil {
-		n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.HeartbeatInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatInterval):])
-		if err3 != nil {
-			return 0, err3
-		}
-		i -= n3
-		i = encodeVarintConfig(dAtA, i, uint64(n3))
-		i--
-		dAtA[i] = 0x12
+	return CommitOverflowPolicy_CLAMP
+}
+
+func (m *ProtocolConfig) GetLeaseHeartbeatRatio() float32 {
+	if m != nil {
+		return m.LeaseHeartbeatRatio
 	}
-	if m.ElectionTimeout != nil {
-		n4, err4 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ElectionTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ElectionTimeout):])
-		if err4 != nil {
-			return 0, err4
-		}
-		i -= n4
-		i = encodeVarintConfig(dAtA, i, uint64(n4))
-		i--
-		dAtA[i] = 0xa
+	return 0
+}
+
+func (m *ProtocolConfig) GetElectionRateLimit() *RateLimitConfig {
+	if m != nil {
+		return m.ElectionRateLimit
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *StorageConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ProtocolConfig) GetLearnerCatchUpThreshold() uint64 {
+	if m != nil {
+		return m.LearnerCatchUpThreshold
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *StorageConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ProtocolConfig) GetMaxAppendBatchSize() uint32 {
+	if m != nil {
+		return m.MaxAppendBatchSize
+	}
+	return 0
 }
 
-func (m *StorageConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.FlushOnCommit {
-		i--
-		if m.FlushOnCommit {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x28
+func (m *ProtocolConfig) GetMaxInflightAppends() uint32 {
+	if m != nil {
+		return m.MaxInflightAppends
 	}
-	if m.SegmentSize != 0 {
-		i = encodeVarintConfig(dAtA, i, uint64(m.SegmentSize))
-		i--
-		dAtA[i] = 0x20
+	return 0
+}
+
+func (m *ProtocolConfig) GetAdaptiveElectionTimeout() *AdaptiveTimeoutConfig {
+	if m != nil {
+		return m.AdaptiveElectionTimeout
 	}
-	if m.MaxEntrySize != 0 {
-		i = encodeVarintConfig(dAtA, i, uint64(m.MaxEntrySize))
-		i--
-		dAtA[i] = 0x18
+	return nil
+}
+
+func (m *ProtocolConfig) GetVerifyVotes() bool {
+	if m != nil {
+		return m.VerifyVotes
 	}
-	if m.Level != 0 {
-		i = encodeVarintConfig(dAtA, i, uint64(m.Level))
-		i--
-		dAtA[i] = 0x10
+	return false
+}
+
+func (m *ProtocolConfig) GetCheckQuorum() bool {
+	if m != nil {
+		return m.CheckQuorum
 	}
-	if len(m.Directory) > 0 {
-		i -= len(m.Directory)
-		copy(dAtA[i:], m.Directory)
-		i = encodeVarintConfig(dAtA, i, uint64(len(m.Directory)))
-		i--
-		dAtA[i] = 0xa
+	return false
+}
+
+func (m *ProtocolConfig) GetElectionJitter() float32 {
+	if m != nil {
+		return m.ElectionJitter
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *CompactionConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ProtocolConfig) GetJointConsensus() bool {
+	if m != nil {
+		return m.JointConsensus
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *CompactionConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ProtocolConfig) GetWitnesses() []string {
+	if m != nil {
+		return m.Witnesses
+	}
+	return nil
 }
 
-func (m *CompactionConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.FreeMemoryBuffer != 0 {
-		i -= 4
-		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.FreeMemoryBuffer))))
-		i--
-		dAtA[i] = 0x1d
+func (m *ProtocolConfig) GetForwardCommands() bool {
+	if m != nil {
+		return m.ForwardCommands
 	}
-	if m.FreeDiskBuffer != 0 {
-		i -= 4
-		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.FreeDiskBuffer))))
-		i--
-		dAtA[i] = 0x15
+	return false
+}
+
+func (m *ProtocolConfig) GetSingleNode() bool {
+	if m != nil {
+		return m.SingleNode
 	}
-	if m.Dynamic {
-		i--
-		if m.Dynamic {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x8
+	return false
+}
+
+func (m *ProtocolConfig) GetCommandStreamBuffer() uint32 {
+	if m != nil {
+		return m.CommandStreamBuffer
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func encodeVarintConfig(dAtA []byte, offset int, v uint64) int {
-	offset -= sovConfig(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *ProtocolConfig) GetDedup() *DedupConfig {
+	if m != nil {
+		return m.Dedup
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return nil
 }
-func NewPopulatedProtocolConfig(r randyConfig, easy bool) *ProtocolConfig {
-	this := &ProtocolConfig{}
-	if r.Intn(5) != 0 {
-		this.ElectionTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
-	}
-	if r.Intn(5) != 0 {
-		this.HeartbeatInterval = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
-	}
-	if r.Intn(5) != 0 {
-		this.Storage = NewPopulatedStorageConfig(r, easy)
-	}
-	if r.Intn(5) != 0 {
-		this.Compaction = NewPopulatedCompactionConfig(r, easy)
+
+type StorageConfig struct {
+	Directory     string       `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
+	Level         StorageLevel `protobuf:"varint,2,opt,name=level,proto3,enum=atomix.raft.config.StorageLevel" json:"level,omitempty"`
+	MaxEntrySize  uint32       `protobuf:"varint,3,opt,name=max_entry_size,json=maxEntrySize,proto3" json:"max_entry_size,omitempty"`
+	SegmentSize   uint32       `protobuf:"varint,4,opt,name=segment_size,json=segmentSize,proto3" json:"segment_size,omitempty"`
+	FlushOnCommit bool         `protobuf:"varint,5,opt,name=flush_on_commit,json=flushOnCommit,proto3" json:"flush_on_commit,omitempty"`
+}
+
+func (m *StorageConfig) Reset()         { *m = StorageConfig{} }
+func (m *StorageConfig) String() string { return proto.CompactTextString(m) }
+func (*StorageConfig) ProtoMessage()    {}
+func (*StorageConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{1}
+}
+func (m *StorageConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if !easy && r.Intn(10) != 0 {
+}
+func (m *StorageConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageConfig.Merge(m, src)
+}
+func (m *StorageConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageConfig proto.InternalMessageInfo
+
+func (m *StorageConfig) GetDirectory() string {
+	if m != nil {
+		return m.Directory
 	}
-	return this
+	return ""
 }
 
-func NewPopulatedStorageConfig(r randyConfig, easy bool) *StorageConfig {
-	this := &StorageConfig{}
-	this.Directory = string(randStringConfig(r))
-	this.Level = StorageLevel([]int32{0, 1}[r.Intn(2)])
-	this.MaxEntrySize = uint32(r.Uint32())
-	this.SegmentSize = uint32(r.Uint32())
-	this.FlushOnCommit = bool(bool(r.Intn(2) == 0))
-	if !easy && r.Intn(10) != 0 {
+func (m *StorageConfig) GetLevel() StorageLevel {
+	if m != nil {
+		return m.Level
 	}
-	return this
+	return StorageLevel_DISK
 }
 
-func NewPopulatedCompactionConfig(r randyConfig, easy bool) *CompactionConfig {
-	this := &CompactionConfig{}
-	this.Dynamic = bool(bool(r.Intn(2) == 0))
-	this.FreeDiskBuffer = float32(r.Float32())
-	if r.Intn(2) == 0 {
-		this.FreeDiskBuffer *= -1
+func (m *StorageConfig) GetMaxEntrySize() uint32 {
+	if m != nil {
+		return m.MaxEntrySize
 	}
-	this.FreeMemoryBuffer = float32(r.Float32())
-	if r.Intn(2) == 0 {
-		this.FreeMemoryBuffer *= -1
+	return 0
+}
+
+func (m *StorageConfig) GetSegmentSize() uint32 {
+	if m != nil {
+		return m.SegmentSize
 	}
-	if !easy && r.Intn(10) != 0 {
+	return 0
+}
+
+func (m *StorageConfig) GetFlushOnCommit() bool {
+	if m != nil {
+		return m.FlushOnCommit
 	}
-	return this
+	return false
 }
 
-type randyConfig interface {
-	Float32() float32
-	Float64() float64
-	Int63() int64
-	Int31() int32
-	Uint32() uint32
-	Intn(n int) int
+type ApplyConfig struct {
+	Deadline     *time.Duration   `protobuf:"bytes,1,opt,name=deadline,proto3,stdduration" json:"deadline,omitempty"`
+	ErrorPolicy  ApplyErrorPolicy `protobuf:"varint,2,opt,name=error_policy,json=errorPolicy,proto3,enum=atomix.raft.config.ApplyErrorPolicy" json:"error_policy,omitempty"`
+	StallTimeout *time.Duration   `protobuf:"bytes,3,opt,name=stall_timeout,json=stallTimeout,proto3,stdduration" json:"stall_timeout,omitempty"`
 }
 
-func randUTF8RuneConfig(r randyConfig) rune {
-	ru := r.Intn(62)
-	if ru < 10 {
-		return rune(ru + 48)
-	} else if ru < 36 {
-		return rune(ru + 55)
-	}
-	return rune(ru + 61)
+func (m *ApplyConfig) Reset()         { *m = ApplyConfig{} }
+func (m *ApplyConfig) String() string { return proto.CompactTextString(m) }
+func (*ApplyConfig) ProtoMessage()    {}
+func (*ApplyConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{2}
 }
-func randStringConfig(r randyConfig) string {
-	v1 := r.Intn(100)
-	tmps := make([]rune, v1)
-	for i := 0; i < v1; i++ {
-		tmps[i] = randUTF8RuneConfig(r)
-	}
-	return string(tmps)
+func (m *ApplyConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func randUnrecognizedConfig(r randyConfig, maxFieldNumber int) (dAtA []byte) {
-	l := r.Intn(5)
-	for i := 0; i < l; i++ {
-		wire := r.Intn(4)
-		if wire == 3 {
-			wire = 5
+func (m *ApplyConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApplyConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		fieldNumber := maxFieldNumber + r.Intn(100)
-		dAtA = randFieldConfig(dAtA, r, fieldNumber, wire)
+		return b[:n], nil
 	}
-	return dAtA
 }
-func randFieldConfig(dAtA []byte, r randyConfig, fieldNumber int, wire int) []byte {
-	key := uint32(fieldNumber)<<3 | uint32(wire)
-	switch wire {
-	case 0:
-		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
-		v2 := r.Int63()
-		if r.Intn(2) == 0 {
-			v2 *= -1
-		}
-		dAtA = encodeVarintPopulateConfig(dAtA, uint64(v2))
-	case 1:
-		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	case 2:
-		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
-		ll := r.Intn(100)
-		dAtA = encodeVarintPopulateConfig(dAtA, uint64(ll))
-		for j := 0; j < ll; j++ {
-			dAtA = append(dAtA, byte(r.Intn(256)))
-		}
-	default:
-		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	}
-	return dAtA
+func (m *ApplyConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyConfig.Merge(m, src)
 }
-func encodeVarintPopulateConfig(dAtA []byte, v uint64) []byte {
-	for v >= 1<<7 {
-		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
-		v >>= 7
-	}
-	dAtA = append(dAtA, uint8(v))
-	return dAtA
+func (m *ApplyConfig) XXX_Size() int {
+	return m.Size()
 }
-func (m *ProtocolConfig) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ElectionTimeout != nil {
-		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ElectionTimeout)
-		n += 1 + l + sovConfig(uint64(l))
-	}
-	if m.HeartbeatInterval != nil {
-		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatInterval)
-		n += 1 + l + sovConfig(uint64(l))
-	}
-	if m.Storage != nil {
-		l = m.Storage.Size()
-		n += 1 + l + sovConfig(uint64(l))
-	}
-	if m.Compaction != nil {
-		l = m.Compaction.Size()
-		n += 1 + l + sovConfig(uint64(l))
-	}
-	return n
+func (m *ApplyConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyConfig.DiscardUnknown(m)
 }
 
-func (m *StorageConfig) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Directory)
-	if l > 0 {
-		n += 1 + l + sovConfig(uint64(l))
+var xxx_messageInfo_ApplyConfig proto.InternalMessageInfo
+
+func (m *ApplyConfig) GetDeadline() *time.Duration {
+	if m != nil {
+		return m.Deadline
 	}
-	if m.Level != 0 {
-		n += 1 + sovConfig(uint64(m.Level))
+	return nil
+}
+
+func (m *ApplyConfig) GetErrorPolicy() ApplyErrorPolicy {
+	if m != nil {
+		return m.ErrorPolicy
 	}
-	if m.MaxEntrySize != 0 {
-		n += 1 + sovConfig(uint64(m.MaxEntrySize))
+	return ApplyErrorPolicy_SKIP
+}
+
+func (m *ApplyConfig) GetStallTimeout() *time.Duration {
+	if m != nil {
+		return m.StallTimeout
 	}
-	if m.SegmentSize != 0 {
-		n += 1 + sovConfig(uint64(m.SegmentSize))
+	return nil
+}
+
+type ReadIndexConfig struct {
+	PreferLocalZone   bool           `protobuf:"varint,1,opt,name=prefer_local_zone,json=preferLocalZone,proto3" json:"prefer_local_zone,omitempty"`
+	LocalZoneWait     *time.Duration `protobuf:"bytes,2,opt,name=local_zone_wait,json=localZoneWait,proto3,stdduration" json:"local_zone_wait,omitempty"`
+	ConfirmLeadership bool           `protobuf:"varint,3,opt,name=confirm_leadership,json=confirmLeadership,proto3" json:"confirm_leadership,omitempty"`
+}
+
+func (m *ReadIndexConfig) Reset()         { *m = ReadIndexConfig{} }
+func (m *ReadIndexConfig) String() string { return proto.CompactTextString(m) }
+func (*ReadIndexConfig) ProtoMessage()    {}
+func (*ReadIndexConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{3}
+}
+func (m *ReadIndexConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReadIndexConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReadIndexConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.FlushOnCommit {
-		n += 2
+}
+func (m *ReadIndexConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadIndexConfig.Merge(m, src)
+}
+func (m *ReadIndexConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReadIndexConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadIndexConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadIndexConfig proto.InternalMessageInfo
+
+func (m *ReadIndexConfig) GetPreferLocalZone() bool {
+	if m != nil {
+		return m.PreferLocalZone
 	}
-	return n
+	return false
 }
 
-func (m *CompactionConfig) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ReadIndexConfig) GetLocalZoneWait() *time.Duration {
+	if m != nil {
+		return m.LocalZoneWait
 	}
-	var l int
-	_ = l
-	if m.Dynamic {
-		n += 2
+	return nil
+}
+
+func (m *ReadIndexConfig) GetConfirmLeadership() bool {
+	if m != nil {
+		return m.ConfirmLeadership
 	}
-	if m.FreeDiskBuffer != 0 {
-		n += 5
+	return false
+}
+
+type ConsistencyProbeConfig struct {
+	Interval  *time.Duration `protobuf:"bytes,1,opt,name=interval,proto3,stdduration" json:"interval,omitempty"`
+	RangeSize uint64         `protobuf:"varint,2,opt,name=range_size,json=rangeSize,proto3" json:"range_size,omitempty"`
+}
+
+func (m *ConsistencyProbeConfig) Reset()         { *m = ConsistencyProbeConfig{} }
+func (m *ConsistencyProbeConfig) String() string { return proto.CompactTextString(m) }
+func (*ConsistencyProbeConfig) ProtoMessage()    {}
+func (*ConsistencyProbeConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{4}
+}
+func (m *ConsistencyProbeConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConsistencyProbeConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConsistencyProbeConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConsistencyProbeConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsistencyProbeConfig.Merge(m, src)
+}
+func (m *ConsistencyProbeConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConsistencyProbeConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsistencyProbeConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsistencyProbeConfig proto.InternalMessageInfo
+
+func (m *ConsistencyProbeConfig) GetInterval() *time.Duration {
+	if m != nil {
+		return m.Interval
+	}
+	return nil
+}
+
+func (m *ConsistencyProbeConfig) GetRangeSize() uint64 {
+	if m != nil {
+		return m.RangeSize
+	}
+	return 0
+}
+
+type RecoveryConfig struct {
+	VerifySnapshot      bool `protobuf:"varint,1,opt,name=verify_snapshot,json=verifySnapshot,proto3" json:"verify_snapshot,omitempty"`
+	TruncateTornEntries bool `protobuf:"varint,2,opt,name=truncate_torn_entries,json=truncateTornEntries,proto3" json:"truncate_torn_entries,omitempty"`
+}
+
+func (m *RecoveryConfig) Reset()         { *m = RecoveryConfig{} }
+func (m *RecoveryConfig) String() string { return proto.CompactTextString(m) }
+func (*RecoveryConfig) ProtoMessage()    {}
+func (*RecoveryConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{5}
+}
+func (m *RecoveryConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecoveryConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecoveryConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RecoveryConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoveryConfig.Merge(m, src)
+}
+func (m *RecoveryConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecoveryConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoveryConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoveryConfig proto.InternalMessageInfo
+
+func (m *RecoveryConfig) GetVerifySnapshot() bool {
+	if m != nil {
+		return m.VerifySnapshot
+	}
+	return false
+}
+
+func (m *RecoveryConfig) GetTruncateTornEntries() bool {
+	if m != nil {
+		return m.TruncateTornEntries
+	}
+	return false
+}
+
+type AdaptiveTimeoutConfig struct {
+	RttMultiplier uint32         `protobuf:"varint,1,opt,name=rtt_multiplier,json=rttMultiplier,proto3" json:"rtt_multiplier,omitempty"`
+	MaxTimeout    *time.Duration `protobuf:"bytes,2,opt,name=max_timeout,json=maxTimeout,proto3,stdduration" json:"max_timeout,omitempty"`
+	Window        uint32         `protobuf:"varint,3,opt,name=window,proto3" json:"window,omitempty"`
+}
+
+func (m *AdaptiveTimeoutConfig) Reset()         { *m = AdaptiveTimeoutConfig{} }
+func (m *AdaptiveTimeoutConfig) String() string { return proto.CompactTextString(m) }
+func (*AdaptiveTimeoutConfig) ProtoMessage()    {}
+func (*AdaptiveTimeoutConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{6}
+}
+func (m *AdaptiveTimeoutConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AdaptiveTimeoutConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AdaptiveTimeoutConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AdaptiveTimeoutConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdaptiveTimeoutConfig.Merge(m, src)
+}
+func (m *AdaptiveTimeoutConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *AdaptiveTimeoutConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdaptiveTimeoutConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdaptiveTimeoutConfig proto.InternalMessageInfo
+
+func (m *AdaptiveTimeoutConfig) GetRttMultiplier() uint32 {
+	if m != nil {
+		return m.RttMultiplier
+	}
+	return 0
+}
+
+func (m *AdaptiveTimeoutConfig) GetMaxTimeout() *time.Duration {
+	if m != nil {
+		return m.MaxTimeout
+	}
+	return nil
+}
+
+func (m *AdaptiveTimeoutConfig) GetWindow() uint32 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+type RateLimitConfig struct {
+	MaxRequests uint32         `protobuf:"varint,1,opt,name=max_requests,json=maxRequests,proto3" json:"max_requests,omitempty"`
+	Interval    *time.Duration `protobuf:"bytes,2,opt,name=interval,proto3,stdduration" json:"interval,omitempty"`
+}
+
+func (m *RateLimitConfig) Reset()         { *m = RateLimitConfig{} }
+func (m *RateLimitConfig) String() string { return proto.CompactTextString(m) }
+func (*RateLimitConfig) ProtoMessage()    {}
+func (*RateLimitConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{7}
+}
+func (m *RateLimitConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RateLimitConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RateLimitConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RateLimitConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimitConfig.Merge(m, src)
+}
+func (m *RateLimitConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *RateLimitConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimitConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimitConfig proto.InternalMessageInfo
+
+func (m *RateLimitConfig) GetMaxRequests() uint32 {
+	if m != nil {
+		return m.MaxRequests
+	}
+	return 0
+}
+
+func (m *RateLimitConfig) GetInterval() *time.Duration {
+	if m != nil {
+		return m.Interval
+	}
+	return nil
+}
+
+type MetricsConfig struct {
+	LeaderOnly bool `protobuf:"varint,1,opt,name=leader_only,json=leaderOnly,proto3" json:"leader_only,omitempty"`
+	Metadata   bool `protobuf:"varint,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Locks      bool `protobuf:"varint,3,opt,name=locks,proto3" json:"locks,omitempty"`
+	Node       bool `protobuf:"varint,4,opt,name=node,proto3" json:"node,omitempty"`
+}
+
+func (m *MetricsConfig) Reset()         { *m = MetricsConfig{} }
+func (m *MetricsConfig) String() string { return proto.CompactTextString(m) }
+func (*MetricsConfig) ProtoMessage()    {}
+func (*MetricsConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{8}
+}
+func (m *MetricsConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MetricsConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricsConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MetricsConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsConfig.Merge(m, src)
+}
+func (m *MetricsConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricsConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricsConfig proto.InternalMessageInfo
+
+func (m *MetricsConfig) GetLeaderOnly() bool {
+	if m != nil {
+		return m.LeaderOnly
+	}
+	return false
+}
+
+func (m *MetricsConfig) GetMetadata() bool {
+	if m != nil {
+		return m.Metadata
+	}
+	return false
+}
+
+func (m *MetricsConfig) GetLocks() bool {
+	if m != nil {
+		return m.Locks
+	}
+	return false
+}
+
+func (m *MetricsConfig) GetNode() bool {
+	if m != nil {
+		return m.Node
+	}
+	return false
+}
+
+type DedupConfig struct {
+	MaxBytes   uint64         `protobuf:"varint,1,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	Expiration *time.Duration `protobuf:"bytes,2,opt,name=expiration,proto3,stdduration" json:"expiration,omitempty"`
+}
+
+func (m *DedupConfig) Reset()         { *m = DedupConfig{} }
+func (m *DedupConfig) String() string { return proto.CompactTextString(m) }
+func (*DedupConfig) ProtoMessage()    {}
+func (*DedupConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{9}
+}
+func (m *DedupConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DedupConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DedupConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DedupConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DedupConfig.Merge(m, src)
+}
+func (m *DedupConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *DedupConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_DedupConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DedupConfig proto.InternalMessageInfo
+
+func (m *DedupConfig) GetMaxBytes() uint64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *DedupConfig) GetExpiration() *time.Duration {
+	if m != nil {
+		return m.Expiration
+	}
+	return nil
+}
+
+type CompactionConfig struct {
+	Dynamic                 bool           `protobuf:"varint,1,opt,name=dynamic,proto3" json:"dynamic,omitempty"`
+	FreeDiskBuffer          float32        `protobuf:"fixed32,2,opt,name=free_disk_buffer,json=freeDiskBuffer,proto3" json:"free_disk_buffer,omitempty"`
+	FreeMemoryBuffer        float32        `protobuf:"fixed32,3,opt,name=free_memory_buffer,json=freeMemoryBuffer,proto3" json:"free_memory_buffer,omitempty"`
+	ShutdownSnapshotTimeout *time.Duration `protobuf:"bytes,4,opt,name=shutdown_snapshot_timeout,json=shutdownSnapshotTimeout,proto3,stdduration" json:"shutdown_snapshot_timeout,omitempty"`
+	EntryThreshold          uint64         `protobuf:"varint,5,opt,name=entry_threshold,json=entryThreshold,proto3" json:"entry_threshold,omitempty"`
+	SizeThreshold           uint64         `protobuf:"varint,6,opt,name=size_threshold,json=sizeThreshold,proto3" json:"size_threshold,omitempty"`
+}
+
+func (m *CompactionConfig) Reset()         { *m = CompactionConfig{} }
+func (m *CompactionConfig) String() string { return proto.CompactTextString(m) }
+func (*CompactionConfig) ProtoMessage()    {}
+func (*CompactionConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e09be49defe43eb0, []int{10}
+}
+func (m *CompactionConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactionConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactionConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompactionConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactionConfig.Merge(m, src)
+}
+func (m *CompactionConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactionConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactionConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactionConfig proto.InternalMessageInfo
+
+func (m *CompactionConfig) GetDynamic() bool {
+	if m != nil {
+		return m.Dynamic
+	}
+	return false
+}
+
+func (m *CompactionConfig) GetFreeDiskBuffer() float32 {
+	if m != nil {
+		return m.FreeDiskBuffer
+	}
+	return 0
+}
+
+func (m *CompactionConfig) GetFreeMemoryBuffer() float32 {
+	if m != nil {
+		return m.FreeMemoryBuffer
+	}
+	return 0
+}
+
+func (m *CompactionConfig) GetShutdownSnapshotTimeout() *time.Duration {
+	if m != nil {
+		return m.ShutdownSnapshotTimeout
+	}
+	return nil
+}
+
+func (m *CompactionConfig) GetEntryThreshold() uint64 {
+	if m != nil {
+		return m.EntryThreshold
+	}
+	return 0
+}
+
+func (m *CompactionConfig) GetSizeThreshold() uint64 {
+	if m != nil {
+		return m.SizeThreshold
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("atomix.raft.config.StorageLevel", StorageLevel_name, StorageLevel_value)
+	proto.RegisterEnum("atomix.raft.config.ApplyErrorPolicy", ApplyErrorPolicy_name, ApplyErrorPolicy_value)
+	proto.RegisterEnum("atomix.raft.config.PromotionPolicy", PromotionPolicy_name, PromotionPolicy_value)
+	proto.RegisterEnum("atomix.raft.config.EvenClusterPolicy", EvenClusterPolicy_name, EvenClusterPolicy_value)
+	proto.RegisterEnum("atomix.raft.config.CommitOverflowPolicy", CommitOverflowPolicy_name, CommitOverflowPolicy_value)
+	proto.RegisterType((*ProtocolConfig)(nil), "atomix.raft.config.ProtocolConfig")
+	proto.RegisterMapType((map[string]int32)(nil), "atomix.raft.config.ProtocolConfig.PrioritiesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "atomix.raft.config.ProtocolConfig.ZonesEntry")
+	proto.RegisterType((*StorageConfig)(nil), "atomix.raft.config.StorageConfig")
+	proto.RegisterType((*ApplyConfig)(nil), "atomix.raft.config.ApplyConfig")
+	proto.RegisterType((*ReadIndexConfig)(nil), "atomix.raft.config.ReadIndexConfig")
+	proto.RegisterType((*ConsistencyProbeConfig)(nil), "atomix.raft.config.ConsistencyProbeConfig")
+	proto.RegisterType((*RecoveryConfig)(nil), "atomix.raft.config.RecoveryConfig")
+	proto.RegisterType((*AdaptiveTimeoutConfig)(nil), "atomix.raft.config.AdaptiveTimeoutConfig")
+	proto.RegisterType((*RateLimitConfig)(nil), "atomix.raft.config.RateLimitConfig")
+	proto.RegisterType((*MetricsConfig)(nil), "atomix.raft.config.MetricsConfig")
+	proto.RegisterType((*DedupConfig)(nil), "atomix.raft.config.DedupConfig")
+	proto.RegisterType((*CompactionConfig)(nil), "atomix.raft.config.CompactionConfig")
+}
+
+func init() { proto.RegisterFile("atomix/raft/config/config.proto", fileDescriptor_e09be49defe43eb0) }
+
+var fileDescriptor_e09be49defe43eb0 = []byte{
+	// 2290 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0x5b, 0x53, 0x1c, 0xc7,
+	0x15, 0xd6, 0x70, 0x91, 0xe0, 0xc0, 0x5e, 0x68, 0x6e, 0x03, 0xb6, 0x11, 0x5a, 0x23, 0x19, 0x63,
+	0x69, 0x91, 0xb1, 0xa5, 0xa8, 0xa2, 0xdc, 0x96, 0x4b, 0x2c, 0x64, 0x90, 0xd0, 0x80, 0x43, 0x95,
+	0x53, 0x95, 0xa9, 0x66, 0xa6, 0x17, 0xda, 0xcc, 0x4c, 0x8f, 0xba, 0x7b, 0x81, 0xe5, 0x57, 0xe4,
+	0xd1, 0x3f, 0x21, 0xaf, 0x79, 0x48, 0x55, 0x7e, 0x42, 0x1e, 0xfd, 0x94, 0x72, 0x9e, 0x92, 0x48,
+	0x7f, 0x22, 0x8f, 0xa9, 0xbe, 0xcd, 0x2e, 0x68, 0xe5, 0x6c, 0x9e, 0x76, 0xe6, 0x9c, 0xef, 0x3b,
+	0xd3, 0x7d, 0xfa, 0xdc, 0x7a, 0xe1, 0x36, 0x96, 0x2c, 0xa5, 0x17, 0xab, 0x1c, 0x37, 0xe5, 0x6a,
+	0xc4, 0xb2, 0x26, 0x3d, 0xb6, 0x3f, 0xf5, 0x9c, 0x33, 0xc9, 0x10, 0x32, 0x80, 0xba, 0x02, 0xd4,
+	0x8d, 0x66, 0x7e, 0xe1, 0x98, 0xb1, 0xe3, 0x84, 0xac, 0x6a, 0xc4, 0x51, 0xab, 0xb9, 0x1a, 0xb7,
+	0x38, 0x96, 0x94, 0x65, 0x86, 0x33, 0x3f, 0x75, 0xcc, 0x8e, 0x99, 0x7e, 0x5c, 0x55, 0x4f, 0x46,
+	0x5a, 0xfb, 0xf1, 0x43, 0x28, 0xef, 0xa9, 0xa7, 0x88, 0x25, 0x1b, 0xda, 0x10, 0x7a, 0x0e, 0x55,
+	0x92, 0x90, 0x48, 0x51, 0x43, 0x49, 0x53, 0xc2, 0x5a, 0xd2, 0xf7, 0x16, 0xbd, 0xe5, 0xb1, 0xb5,
+	0xb9, 0xba, 0xf9, 0x46, 0xdd, 0x7d, 0xa3, 0xbe, 0x69, 0xbf, 0xb1, 0x3e, 0xf4, 0xfd, 0x3f, 0x6f,
+	0x7b, 0x41, 0xc5, 0x11, 0x0f, 0x0c, 0x0f, 0xbd, 0x00, 0x74, 0x42, 0x30, 0x97, 0x47, 0x04, 0xcb,
+	0x90, 0x66, 0x92, 0xf0, 0x33, 0x9c, 0xf8, 0x03, 0xfd, 0x59, 0x9b, 0x28, 0xa8, 0xdb, 0x96, 0x89,
+	0x9e, 0xc2, 0x2d, 0x21, 0x19, 0xc7, 0xc7, 0xc4, 0x1f, 0xd4, 0x46, 0xee, 0xd4, 0xdf, 0x75, 0x45,
+	0x7d, 0xdf, 0x40, 0xcc, 0x7e, 0x02, 0xc7, 0x40, 0x9b, 0x00, 0x11, 0x4b, 0x73, 0xac, 0x57, 0xe8,
+	0x0f, 0x69, 0xfe, 0x52, 0x2f, 0xfe, 0x46, 0x81, 0xb2, 0x26, 0xba, 0x78, 0xe8, 0x15, 0x4c, 0xa5,
+	0xf8, 0x22, 0x7c, 0xc7, 0x45, 0xc3, 0xfd, 0x6d, 0x0a, 0xa5, 0xf8, 0x62, 0xeb, 0x9a, 0x97, 0x02,
+	0x80, 0x9c, 0x53, 0xc6, 0xa9, 0xa4, 0x44, 0xf8, 0x37, 0x17, 0x07, 0x97, 0xc7, 0xd6, 0xd6, 0x7a,
+	0x2d, 0xec, 0xea, 0x49, 0xd5, 0xf7, 0x0a, 0xd2, 0x56, 0x26, 0x79, 0x3b, 0xe8, 0xb2, 0xa2, 0x3c,
+	0x95, 0x12, 0xc9, 0x69, 0x24, 0xfc, 0x5b, 0xef, 0xf7, 0xd4, 0xae, 0x81, 0x38, 0x4f, 0x59, 0x86,
+	0x0a, 0x01, 0xc9, 0x71, 0x26, 0x9a, 0x84, 0x17, 0xfb, 0x1b, 0xe9, 0x33, 0x04, 0x1c, 0xd1, 0x6d,
+	0xee, 0x13, 0xa8, 0x30, 0x1e, 0x13, 0x4e, 0xe2, 0xf0, 0x75, 0x8b, 0x70, 0xb5, 0xc3, 0xd1, 0x45,
+	0x6f, 0x79, 0x24, 0x28, 0x5b, 0xf1, 0x2b, 0x23, 0x45, 0x8f, 0x60, 0x18, 0xe7, 0x79, 0xd2, 0xf6,
+	0x41, 0x7f, 0xe9, 0x76, 0xaf, 0xf5, 0x36, 0x14, 0xc0, 0xae, 0xd6, 0xa0, 0xd1, 0x06, 0x0c, 0x5f,
+	0xb2, 0x8c, 0x08, 0x7f, 0x4c, 0xfb, 0xed, 0x41, 0x1f, 0x7e, 0xfb, 0x96, 0x65, 0xce, 0x65, 0x86,
+	0x8b, 0xd6, 0x01, 0x38, 0xc1, 0x71, 0x48, 0xb3, 0x98, 0x5c, 0xf8, 0xe3, 0x7a, 0x01, 0x1f, 0xf7,
+	0xb2, 0x14, 0x10, 0x1c, 0x6f, 0x2b, 0x90, 0x5d, 0xc4, 0x28, 0x77, 0x02, 0x74, 0x08, 0x13, 0x11,
+	0xcb, 0x04, 0x15, 0x92, 0x64, 0x51, 0x3b, 0xcc, 0x39, 0x3b, 0x22, 0x7e, 0x49, 0x9b, 0x5a, 0xe9,
+	0x1d, 0x65, 0x05, 0x78, 0x4f, 0x61, 0xad, 0xc5, 0x6a, 0x74, 0x4d, 0x8e, 0x7e, 0x05, 0x23, 0x9c,
+	0x44, 0xec, 0x8c, 0xf0, 0xb6, 0x5f, 0xd6, 0xf6, 0x6a, 0xbd, 0x97, 0x66, 0x30, 0xd6, 0x4e, 0xc1,
+	0x41, 0x0f, 0x00, 0x71, 0x22, 0x31, 0xcd, 0x48, 0x1c, 0x8a, 0x0c, 0xe7, 0xe2, 0x84, 0x49, 0xe1,
+	0x57, 0x16, 0xbd, 0xe5, 0x52, 0x30, 0xe1, 0x34, 0xfb, 0x4e, 0x81, 0x7e, 0x01, 0xf3, 0x92, 0xb7,
+	0xb2, 0x48, 0x9f, 0x6a, 0x88, 0x13, 0xc2, 0x65, 0x28, 0x4f, 0x38, 0x11, 0x27, 0x2c, 0x89, 0xfd,
+	0xea, 0xa2, 0xb7, 0x3c, 0x14, 0xf8, 0x1d, 0x44, 0x43, 0x01, 0x0e, 0x9c, 0x1e, 0x3d, 0x84, 0xa9,
+	0x98, 0x0a, 0x7c, 0x94, 0x90, 0x50, 0x48, 0x1a, 0x9d, 0xb6, 0xc3, 0x9c, 0x25, 0x89, 0xf0, 0x27,
+	0xf4, 0x99, 0x23, 0xab, 0xdb, 0xd7, 0xaa, 0x3d, 0xa5, 0x41, 0x75, 0x98, 0x54, 0x09, 0x15, 0xb1,
+	0x34, 0xc5, 0x59, 0x1c, 0x0a, 0xc9, 0x09, 0x4e, 0x85, 0x8f, 0xcc, 0xfa, 0x52, 0x7c, 0xb1, 0x61,
+	0x34, 0xfb, 0x46, 0x81, 0xee, 0x42, 0xb9, 0x89, 0x29, 0x57, 0x0e, 0xce, 0x99, 0xc0, 0x89, 0xf0,
+	0x27, 0xb5, 0xed, 0x92, 0x92, 0xee, 0x39, 0xa1, 0xda, 0x86, 0x5b, 0x08, 0xcd, 0x84, 0xc4, 0x49,
+	0x12, 0x16, 0xf5, 0x44, 0xf8, 0x53, 0x9a, 0xe2, 0x5b, 0xc4, 0xb6, 0x01, 0x3c, 0x2b, 0xf4, 0xe8,
+	0x05, 0x54, 0x73, 0xce, 0x52, 0xa6, 0x7d, 0x90, 0xb3, 0x84, 0x46, 0x6d, 0x7f, 0x7a, 0xd1, 0x5b,
+	0x2e, 0xf7, 0x0e, 0x8b, 0x3d, 0x87, 0xdd, 0xd3, 0xd0, 0xa0, 0x92, 0x5f, 0x15, 0x28, 0xb7, 0x34,
+	0x59, 0x92, 0xb0, 0x73, 0xc2, 0xc3, 0xa3, 0x56, 0x53, 0x25, 0x96, 0xa0, 0x97, 0xc4, 0x9f, 0xd1,
+	0xbb, 0x44, 0x4e, 0xb7, 0xae, 0x55, 0xfb, 0xf4, 0x92, 0xa0, 0x27, 0xe0, 0x47, 0x27, 0x24, 0x3a,
+	0x0d, 0xcf, 0x98, 0x24, 0xa1, 0xf9, 0x8e, 0x4d, 0x35, 0x7f, 0x56, 0xaf, 0x7e, 0x46, 0xeb, 0x7f,
+	0xc7, 0x24, 0xd9, 0xe8, 0xd6, 0xa2, 0x97, 0x30, 0x79, 0xa5, 0x42, 0x35, 0x39, 0x21, 0x97, 0xc4,
+	0xf7, 0xfb, 0xac, 0xba, 0x5d, 0x05, 0xea, 0xb7, 0x9a, 0x89, 0xbe, 0x82, 0x8a, 0x3e, 0xa1, 0x84,
+	0x45, 0xa7, 0x61, 0xcc, 0x69, 0x53, 0xfa, 0x73, 0xfd, 0x19, 0x2b, 0xa9, 0xe3, 0x53, 0xb4, 0x4d,
+	0xc5, 0x42, 0xf7, 0x8c, 0x21, 0x9c, 0xe7, 0x24, 0x8b, 0x8d, 0x03, 0xe6, 0xb5, 0x03, 0x14, 0xae,
+	0xa1, 0xa5, 0x7a, 0xef, 0x8f, 0x60, 0xb6, 0x3b, 0x24, 0x38, 0x11, 0xad, 0x44, 0x1a, 0xfc, 0x07,
+	0x1a, 0x3f, 0xd5, 0x09, 0x8b, 0x40, 0x2b, 0x35, 0x6d, 0x57, 0x05, 0x3a, 0x56, 0xf8, 0x5c, 0x05,
+	0xc8, 0x39, 0xcd, 0x62, 0x76, 0xee, 0x7f, 0xd8, 0xdf, 0x52, 0xab, 0x8a, 0x1a, 0x68, 0xe6, 0xa1,
+	0x26, 0xa2, 0xfb, 0xca, 0x5c, 0xce, 0xb8, 0x0c, 0x13, 0x2c, 0x64, 0x98, 0x10, 0x1c, 0x13, 0xee,
+	0x7f, 0xa4, 0x7d, 0x5f, 0x35, 0x9a, 0x1d, 0x2c, 0xe4, 0x8e, 0x96, 0xa3, 0xc7, 0x30, 0x7b, 0x84,
+	0x65, 0x74, 0xd2, 0xf1, 0x7b, 0x4a, 0x24, 0x8e, 0xb1, 0xc4, 0xfe, 0x82, 0xa6, 0x4c, 0x6b, 0xb5,
+	0x73, 0xed, 0xae, 0x55, 0xa2, 0x67, 0x50, 0x71, 0xf1, 0xe9, 0x4a, 0xed, 0xed, 0xfe, 0x56, 0x5c,
+	0xb6, 0x3c, 0x57, 0x69, 0x0f, 0x61, 0xd6, 0xe5, 0x44, 0x68, 0x96, 0x52, 0x74, 0xdc, 0xc5, 0xfe,
+	0x2c, 0x4e, 0x3b, 0xfe, 0xba, 0xa2, 0x17, 0x5d, 0xf7, 0x10, 0x66, 0x5b, 0xfc, 0x98, 0x64, 0xb2,
+	0xc8, 0xb9, 0x62, 0xa9, 0x77, 0xfa, 0x34, 0x6c, 0xf8, 0x2e, 0x3b, 0xdd, 0x8a, 0xef, 0xc0, 0xb8,
+	0x50, 0x1d, 0x47, 0x86, 0xca, 0xf9, 0xc2, 0xaf, 0x69, 0x47, 0x8d, 0x19, 0x99, 0x2a, 0xb5, 0x42,
+	0x05, 0xb3, 0x0d, 0x17, 0xb3, 0x25, 0x7b, 0xa8, 0x1f, 0xf7, 0x19, 0xcc, 0x86, 0xab, 0xb7, 0x63,
+	0x4f, 0xf5, 0x1b, 0x98, 0x24, 0x67, 0x24, 0x0b, 0xa3, 0xa4, 0x25, 0x24, 0xe1, 0x2e, 0xb9, 0x97,
+	0x74, 0x72, 0xdf, 0xed, 0x95, 0xdc, 0x5b, 0x67, 0x24, 0xdb, 0x30, 0x68, 0x9b, 0xde, 0x13, 0xe4,
+	0xba, 0x48, 0x4d, 0x3a, 0x34, 0xa3, 0x92, 0xe2, 0x84, 0x5e, 0x92, 0xc2, 0x3d, 0x77, 0xfb, 0x5c,
+	0x66, 0x87, 0xea, 0x5c, 0xf3, 0x2d, 0xcc, 0xa5, 0x34, 0x53, 0xa9, 0x92, 0x50, 0x62, 0x1b, 0x53,
+	0x61, 0xf6, 0x5e, 0x7f, 0x66, 0x67, 0x52, 0x9a, 0x35, 0x8c, 0x01, 0xdd, 0xa2, 0x9c, 0xed, 0x10,
+	0x3e, 0x30, 0xc1, 0x1c, 0x0a, 0x89, 0x8f, 0x68, 0x42, 0x2f, 0x4d, 0xad, 0xcf, 0x09, 0xa7, 0x2c,
+	0xf6, 0x3f, 0xe9, 0xcf, 0xfa, 0x9c, 0xb1, 0xb1, 0xdf, 0x6d, 0x62, 0x4f, 0x5b, 0x40, 0x9f, 0xc1,
+	0x04, 0x27, 0xaf, 0x5b, 0x44, 0xc8, 0xae, 0x86, 0xb3, 0xec, 0x12, 0x47, 0x2b, 0x3a, 0xfd, 0xe6,
+	0x0f, 0x30, 0xa3, 0x12, 0x9d, 0xca, 0x50, 0xb5, 0xab, 0x66, 0xc2, 0xce, 0xdd, 0x99, 0x7c, 0xaa,
+	0xcf, 0x64, 0xf9, 0x3d, 0x23, 0x5a, 0x4a, 0xe5, 0x4b, 0x4b, 0xb0, 0xc7, 0x32, 0x15, 0xf5, 0x90,
+	0xa2, 0x35, 0x98, 0x4e, 0x08, 0x16, 0xa4, 0x53, 0xfe, 0x43, 0xbd, 0x0f, 0x7f, 0x65, 0xd1, 0x5b,
+	0x1e, 0x08, 0x26, 0xb5, 0xb2, 0x28, 0xfd, 0x81, 0x52, 0xa1, 0x7d, 0x98, 0x2c, 0xd2, 0x98, 0x63,
+	0x49, 0xc2, 0x84, 0xa6, 0x54, 0xfa, 0x9f, 0xfd, 0xc4, 0x60, 0x80, 0x25, 0xd9, 0x51, 0x20, 0xdb,
+	0x7e, 0x27, 0x1c, 0xbf, 0x50, 0xa0, 0xa7, 0x30, 0x9f, 0x10, 0xcc, 0x33, 0xc2, 0xc3, 0x48, 0xc7,
+	0x72, 0x2b, 0xef, 0x6a, 0xac, 0xf7, 0x75, 0x63, 0x9d, 0xb5, 0x88, 0x0d, 0x05, 0xf8, 0x26, 0xef,
+	0xf4, 0xd5, 0xcf, 0x61, 0xba, 0xab, 0x74, 0x9a, 0x5c, 0xd0, 0x05, 0xf1, 0x81, 0xe9, 0x20, 0x45,
+	0x01, 0xd5, 0xb1, 0xae, 0xcb, 0xe1, 0x43, 0x33, 0xa9, 0xd2, 0xac, 0x99, 0xd0, 0xe3, 0x13, 0x69,
+	0xb9, 0xc2, 0xaf, 0x17, 0x8c, 0x6d, 0xab, 0x32, 0x4c, 0x81, 0x08, 0xcc, 0xe1, 0x18, 0xe7, 0x92,
+	0x9e, 0x91, 0x77, 0x07, 0xdc, 0x55, 0xbd, 0xf9, 0x4f, 0x7b, 0x8e, 0x65, 0x96, 0x64, 0x03, 0xcc,
+	0xba, 0x60, 0xd6, 0xd9, 0xba, 0x3e, 0xef, 0xde, 0x81, 0xf1, 0x33, 0xc2, 0x69, 0xb3, 0xad, 0x7b,
+	0x9b, 0xf0, 0x1f, 0x9a, 0xb4, 0x37, 0x32, 0xd5, 0xcf, 0x84, 0x82, 0x98, 0xee, 0xf7, 0xba, 0xc5,
+	0x78, 0x2b, 0xf5, 0x3f, 0x37, 0x10, 0x2d, 0x7b, 0xa5, 0x45, 0x6a, 0xb0, 0x2c, 0xd6, 0xf8, 0x1d,
+	0x95, 0x92, 0x70, 0x7f, 0x4d, 0x9f, 0x68, 0xd9, 0x89, 0x9f, 0x6b, 0xa9, 0x02, 0x7e, 0xc7, 0x68,
+	0x26, 0x55, 0x13, 0x15, 0x24, 0x13, 0x2d, 0xe1, 0x7f, 0x61, 0x26, 0x50, 0x2d, 0xde, 0x70, 0x52,
+	0xf4, 0x21, 0x8c, 0x9e, 0x53, 0x99, 0x11, 0x21, 0x88, 0xf0, 0xbf, 0x5c, 0x1c, 0x5c, 0x1e, 0x0d,
+	0x3a, 0x02, 0xf4, 0x29, 0x54, 0x9b, 0x8c, 0x9f, 0x63, 0x1e, 0xbb, 0xc6, 0x24, 0xfc, 0x47, 0xda,
+	0x4e, 0xc5, 0xca, 0x6d, 0x47, 0x12, 0xe8, 0x36, 0x8c, 0x09, 0x9a, 0x1d, 0x27, 0x24, 0xcc, 0x58,
+	0x4c, 0xfc, 0xc7, 0x1a, 0x05, 0x46, 0xf4, 0x82, 0xc5, 0x44, 0xc5, 0xe4, 0xd5, 0x79, 0xc7, 0x0e,
+	0x05, 0xfe, 0xcf, 0xf4, 0xd9, 0x4c, 0x46, 0xdd, 0x23, 0x8f, 0x19, 0x0a, 0xd4, 0x7c, 0x1c, 0x93,
+	0xb8, 0x95, 0xfb, 0x4f, 0xde, 0x3f, 0x1f, 0x6f, 0x2a, 0x80, 0x9b, 0x8f, 0x35, 0x7a, 0xfe, 0x97,
+	0x50, 0xb9, 0x76, 0x4f, 0x40, 0x55, 0x18, 0x3c, 0x25, 0x6d, 0x7d, 0xa9, 0x1b, 0x0d, 0xd4, 0x23,
+	0x9a, 0x82, 0xe1, 0x33, 0x9c, 0xb4, 0x88, 0xbe, 0x9a, 0x0d, 0x07, 0xe6, 0xe5, 0xe7, 0x03, 0x4f,
+	0xbc, 0xf9, 0x27, 0x00, 0x9d, 0x71, 0xf9, 0x7f, 0x31, 0x47, 0xbb, 0x98, 0xb5, 0xbf, 0x7b, 0x50,
+	0xba, 0x72, 0x13, 0x53, 0xfe, 0x8d, 0x29, 0x27, 0x91, 0x64, 0xdc, 0xd9, 0xe8, 0x08, 0xd0, 0x63,
+	0x18, 0x4e, 0xc8, 0x19, 0x31, 0xd7, 0xc3, 0xf2, 0xda, 0xe2, 0x4f, 0xdc, 0xec, 0x76, 0x14, 0x2e,
+	0x30, 0x70, 0xb4, 0x04, 0x65, 0x3d, 0xee, 0xa8, 0x05, 0x9a, 0x94, 0x18, 0xd4, 0x4e, 0x1c, 0x57,
+	0x83, 0x8c, 0x12, 0xea, 0x64, 0x50, 0xad, 0x86, 0x1c, 0xa7, 0xaa, 0x89, 0x69, 0xcc, 0x90, 0xc6,
+	0x8c, 0x59, 0x99, 0x86, 0xdc, 0x83, 0x4a, 0x33, 0x69, 0x89, 0x93, 0x90, 0x65, 0xa1, 0xa9, 0x24,
+	0xfe, 0xb0, 0x9d, 0x2c, 0x95, 0xf8, 0x65, 0x66, 0x8a, 0x4e, 0xed, 0x1f, 0x1e, 0x8c, 0x75, 0x5d,
+	0x44, 0xd0, 0x53, 0x18, 0x89, 0x09, 0x8e, 0x13, 0x9a, 0x91, 0x7e, 0x2f, 0xca, 0x05, 0x01, 0x7d,
+	0x05, 0xe3, 0x84, 0x73, 0x56, 0xf4, 0x21, 0xb3, 0xf9, 0xa5, 0xf7, 0x5e, 0x7e, 0xb6, 0x14, 0xd8,
+	0xd6, 0xbb, 0x31, 0xd2, 0x79, 0x41, 0x9b, 0x50, 0xba, 0x3a, 0x45, 0x0c, 0xf6, 0xb7, 0x94, 0xf1,
+	0xee, 0x19, 0xa2, 0xf6, 0x17, 0x0f, 0x2a, 0xd7, 0xee, 0x38, 0x68, 0x05, 0x26, 0x72, 0x4e, 0xd4,
+	0xc8, 0x9a, 0xb0, 0x08, 0x27, 0xe1, 0x25, 0xb3, 0x1b, 0x1d, 0x09, 0x2a, 0x46, 0xb1, 0xa3, 0xe4,
+	0x2a, 0x4c, 0xd4, 0xa8, 0xd8, 0x01, 0x85, 0xe7, 0x98, 0xca, 0x7e, 0x6f, 0xfb, 0xa5, 0xc4, 0x19,
+	0x39, 0xc4, 0x54, 0xaa, 0x4b, 0x8b, 0xde, 0x36, 0x4f, 0xed, 0xe0, 0x25, 0x4e, 0x68, 0xae, 0xf7,
+	0x34, 0x12, 0x4c, 0x58, 0xcd, 0x4e, 0xa1, 0xa8, 0x49, 0x98, 0xe9, 0x7d, 0x9f, 0x52, 0xa7, 0x53,
+	0x8c, 0x41, 0xfd, 0x9e, 0x8e, 0x23, 0xa0, 0x8f, 0x00, 0x38, 0xce, 0x8e, 0x89, 0x89, 0x99, 0x01,
+	0x5d, 0xa2, 0x47, 0xb5, 0x44, 0x45, 0x4c, 0x2d, 0x85, 0xf2, 0xd5, 0x5b, 0x97, 0xaa, 0x35, 0xb6,
+	0xb4, 0xb9, 0xc6, 0x67, 0x3d, 0x55, 0x36, 0x62, 0xd7, 0xf6, 0x54, 0x05, 0xb0, 0x77, 0x28, 0x12,
+	0x4a, 0xc6, 0x33, 0x1d, 0xbf, 0xea, 0x72, 0x3c, 0xa0, 0xe1, 0x93, 0x4e, 0x79, 0xc0, 0x78, 0xb6,
+	0x65, 0x54, 0xb5, 0xef, 0x3d, 0x98, 0xee, 0x59, 0x6a, 0xd5, 0x9d, 0x88, 0x4b, 0x19, 0xa6, 0xad,
+	0x44, 0x52, 0xd5, 0xef, 0xb9, 0xfe, 0x6a, 0x29, 0x28, 0x71, 0x29, 0x77, 0x0b, 0x21, 0xfa, 0x0d,
+	0x8c, 0xa9, 0x54, 0x71, 0x11, 0xd2, 0xe7, 0xc9, 0x40, 0x8a, 0x8b, 0xd1, 0x61, 0x06, 0x6e, 0xda,
+	0x09, 0xcc, 0x24, 0x99, 0x7d, 0xab, 0xbd, 0x86, 0xca, 0xb5, 0x0e, 0xa8, 0x32, 0x4e, 0x7d, 0xcc,
+	0xf6, 0x7b, 0x61, 0x57, 0xa4, 0x16, 0x10, 0x58, 0xd1, 0x95, 0xb3, 0x19, 0xf8, 0x3f, 0xcf, 0xa6,
+	0x76, 0x06, 0xa5, 0x2b, 0x7f, 0x5f, 0xa8, 0xaa, 0x6b, 0xc7, 0x1a, 0x96, 0x25, 0x6d, 0xeb, 0x77,
+	0x30, 0xa2, 0x97, 0x59, 0xd2, 0x46, 0xf3, 0x30, 0x52, 0xcc, 0xe4, 0xc6, 0xcd, 0xc5, 0xbb, 0xaa,
+	0x63, 0xea, 0x9e, 0x22, 0x6c, 0x88, 0x99, 0x17, 0x84, 0x60, 0x48, 0x57, 0xf0, 0x21, 0x2d, 0xd4,
+	0xcf, 0xb5, 0x53, 0x18, 0xeb, 0x2a, 0xb3, 0xe8, 0x03, 0x18, 0x55, 0xdb, 0x3c, 0x6a, 0xab, 0x4e,
+	0xe6, 0xe9, 0x08, 0x19, 0x49, 0xf1, 0xc5, 0xba, 0x7a, 0x47, 0xbf, 0x06, 0x20, 0x17, 0x39, 0xb5,
+	0xd7, 0xb6, 0x7e, 0xfd, 0xdd, 0xa1, 0xd4, 0xfe, 0x3c, 0x00, 0xd5, 0xeb, 0x7f, 0x47, 0x21, 0x1f,
+	0x6e, 0xc5, 0xed, 0x0c, 0xa7, 0x34, 0xb2, 0x9b, 0x74, 0xaf, 0x68, 0x19, 0xaa, 0x4d, 0x4e, 0x48,
+	0x18, 0x53, 0x71, 0xea, 0x5a, 0xca, 0x80, 0x69, 0x8a, 0x4a, 0xbe, 0x49, 0xc5, 0xa9, 0xed, 0x26,
+	0xf7, 0x01, 0x69, 0x64, 0x4a, 0x52, 0xc6, 0xdb, 0x0e, 0x3b, 0xa8, 0xb1, 0xda, 0xc6, 0xae, 0x56,
+	0x58, 0xf4, 0xef, 0x61, 0x4e, 0x9c, 0xb4, 0x64, 0xcc, 0xce, 0xb3, 0x22, 0xb0, 0x8b, 0x30, 0x1a,
+	0xea, 0x6f, 0x5b, 0xb3, 0xce, 0x82, 0xcb, 0x81, 0xae, 0x7f, 0x88, 0x4c, 0xf1, 0xee, 0x0c, 0x43,
+	0xc3, 0xda, 0x8f, 0x65, 0x2d, 0xee, 0xcc, 0x40, 0x77, 0xa1, 0x2c, 0xf4, 0x74, 0x5d, 0xe0, 0x6e,
+	0x6a, 0x5c, 0x49, 0x49, 0x0b, 0xd8, 0xca, 0x12, 0x8c, 0x77, 0xf7, 0x09, 0x34, 0x02, 0x43, 0x9b,
+	0xdb, 0xfb, 0x5f, 0x57, 0x6f, 0x20, 0x80, 0x9b, 0xbb, 0x8d, 0xbd, 0xbd, 0xad, 0xcd, 0xaa, 0xb7,
+	0x72, 0x0f, 0xaa, 0xd7, 0x0b, 0xaa, 0x42, 0xee, 0x7f, 0xbd, 0xbd, 0x57, 0xbd, 0xa1, 0x9e, 0x9e,
+	0x35, 0x76, 0x0e, 0xaa, 0xde, 0xca, 0x7d, 0xd5, 0x3f, 0xaf, 0x5e, 0xe6, 0x4b, 0x30, 0xba, 0xbd,
+	0xbb, 0xbb, 0xb5, 0xb9, 0xdd, 0x38, 0xd8, 0x32, 0x56, 0xf7, 0x0f, 0x1a, 0xeb, 0x3b, 0x5b, 0x55,
+	0x6f, 0xe5, 0x4b, 0x98, 0x78, 0xe7, 0xba, 0x80, 0x46, 0x61, 0xb8, 0xb1, 0xb3, 0xf3, 0xf2, 0xd0,
+	0xd8, 0x3d, 0x6c, 0x04, 0x2f, 0xaa, 0x9e, 0x62, 0x05, 0x5b, 0xcf, 0xb7, 0x36, 0x0e, 0xaa, 0x03,
+	0x2b, 0x75, 0x98, 0xea, 0x35, 0xd0, 0x2a, 0xe2, 0xc6, 0x4e, 0x63, 0x57, 0x2d, 0x68, 0x0c, 0x6e,
+	0x6d, 0x6e, 0xef, 0x6f, 0x34, 0x82, 0xcd, 0xaa, 0xb7, 0xbe, 0xf4, 0x9f, 0x7f, 0x2f, 0x78, 0x7f,
+	0x7a, 0xb3, 0xe0, 0xfd, 0xf5, 0xcd, 0x82, 0xf7, 0xb7, 0x37, 0x0b, 0xde, 0x0f, 0x6f, 0x16, 0xbc,
+	0x7f, 0xbd, 0x59, 0xf0, 0xfe, 0xf8, 0x76, 0xe1, 0xc6, 0x0f, 0x6f, 0x17, 0x6e, 0xfc, 0xf8, 0x76,
+	0xe1, 0xc6, 0xd1, 0x4d, 0x7d, 0x12, 0x5f, 0xfc, 0x77, 0x00, 0x13, 0xa2, 0x43, 0x47, 0x4f, 0x16,
+	0x00, 0x00,
+}
+
+func (this *ProtocolConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ProtocolConfig)
+	if !ok {
+		that2, ok := that.(ProtocolConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ElectionTimeout != nil && that1.ElectionTimeout != nil {
+		if *this.ElectionTimeout != *that1.ElectionTimeout {
+			return false
+		}
+	} else if this.ElectionTimeout != nil {
+		return false
+	} else if that1.ElectionTimeout != nil {
+		return false
+	}
+	if this.HeartbeatInterval != nil && that1.HeartbeatInterval != nil {
+		if *this.HeartbeatInterval != *that1.HeartbeatInterval {
+			return false
+		}
+	} else if this.HeartbeatInterval != nil {
+		return false
+	} else if that1.HeartbeatInterval != nil {
+		return false
+	}
+	if !this.Storage.Equal(that1.Storage) {
+		return false
+	}
+	if !this.Compaction.Equal(that1.Compaction) {
+		return false
+	}
+	if this.MaxElectionTimeout != nil && that1.MaxElectionTimeout != nil {
+		if *this.MaxElectionTimeout != *that1.MaxElectionTimeout {
+			return false
+		}
+	} else if this.MaxElectionTimeout != nil {
+		return false
+	} else if that1.MaxElectionTimeout != nil {
+		return false
+	}
+	if len(this.Priorities) != len(that1.Priorities) {
+		return false
+	}
+	for i := range this.Priorities {
+		if this.Priorities[i] != that1.Priorities[i] {
+			return false
+		}
+	}
+	if !this.Metrics.Equal(that1.Metrics) {
+		return false
+	}
+	if this.TransferTimeout != nil && that1.TransferTimeout != nil {
+		if *this.TransferTimeout != *that1.TransferTimeout {
+			return false
+		}
+	} else if this.TransferTimeout != nil {
+		return false
+	} else if that1.TransferTimeout != nil {
+		return false
+	}
+	if this.OrderedQueries != that1.OrderedQueries {
+		return false
+	}
+	if !this.Apply.Equal(that1.Apply) {
+		return false
+	}
+	if len(this.Zones) != len(that1.Zones) {
+		return false
+	}
+	for i := range this.Zones {
+		if this.Zones[i] != that1.Zones[i] {
+			return false
+		}
+	}
+	if !this.ReadIndex.Equal(that1.ReadIndex) {
+		return false
+	}
+	if !this.ConsistencyProbe.Equal(that1.ConsistencyProbe) {
+		return false
+	}
+	if !this.Recovery.Equal(that1.Recovery) {
+		return false
+	}
+	if this.RetainedSnapshots != that1.RetainedSnapshots {
+		return false
+	}
+	if this.TruncationAlertThreshold != that1.TruncationAlertThreshold {
+		return false
+	}
+	if this.DisableStickyPolls != that1.DisableStickyPolls {
+		return false
+	}
+	if this.MaxCommandStreams != that1.MaxCommandStreams {
+		return false
+	}
+	if this.FairProposals != that1.FairProposals {
+		return false
+	}
+	if this.DisableInstallHeartbeats != that1.DisableInstallHeartbeats {
+		return false
+	}
+	if this.PromotionPolicy != that1.PromotionPolicy {
+		return false
+	}
+	if this.FollowerBufferSize != that1.FollowerBufferSize {
+		return false
+	}
+	if this.CheckVoteConfiguration != that1.CheckVoteConfiguration {
+		return false
+	}
+	if this.MaxElectionFreeze != nil && that1.MaxElectionFreeze != nil {
+		if *this.MaxElectionFreeze != *that1.MaxElectionFreeze {
+			return false
+		}
+	} else if this.MaxElectionFreeze != nil {
+		return false
+	} else if that1.MaxElectionFreeze != nil {
+		return false
+	}
+	if this.MaxClockDrift != nil && that1.MaxClockDrift != nil {
+		if *this.MaxClockDrift != *that1.MaxClockDrift {
+			return false
+		}
+	} else if this.MaxClockDrift != nil {
+		return false
+	} else if that1.MaxClockDrift != nil {
+		return false
+	}
+	if this.MaxAppendSize != that1.MaxAppendSize {
+		return false
+	}
+	if this.MaxCommandResultSize != that1.MaxCommandResultSize {
+		return false
+	}
+	if this.ReadRepairWindow != nil && that1.ReadRepairWindow != nil {
+		if *this.ReadRepairWindow != *that1.ReadRepairWindow {
+			return false
+		}
+	} else if this.ReadRepairWindow != nil {
+		return false
+	} else if that1.ReadRepairWindow != nil {
+		return false
+	}
+	if this.ReportLastLeader != that1.ReportLastLeader {
+		return false
+	}
+	if this.BatchElectionMetadata != that1.BatchElectionMetadata {
+		return false
+	}
+	if this.InstallTimeout != nil && that1.InstallTimeout != nil {
+		if *this.InstallTimeout != *that1.InstallTimeout {
+			return false
+		}
+	} else if this.InstallTimeout != nil {
+		return false
+	} else if that1.InstallTimeout != nil {
+		return false
+	}
+	if this.ProposalBatchInterval != nil && that1.ProposalBatchInterval != nil {
+		if *this.ProposalBatchInterval != *that1.ProposalBatchInterval {
+			return false
+		}
+	} else if this.ProposalBatchInterval != nil {
+		return false
+	} else if that1.ProposalBatchInterval != nil {
+		return false
+	}
+	if this.UrgentProposalTimeout != nil && that1.UrgentProposalTimeout != nil {
+		if *this.UrgentProposalTimeout != *that1.UrgentProposalTimeout {
+			return false
+		}
+	} else if this.UrgentProposalTimeout != nil {
+		return false
+	} else if that1.UrgentProposalTimeout != nil {
+		return false
+	}
+	if this.StrictReads != that1.StrictReads {
+		return false
+	}
+	if this.AppendBatchWindow != nil && that1.AppendBatchWindow != nil {
+		if *this.AppendBatchWindow != *that1.AppendBatchWindow {
+			return false
+		}
+	} else if this.AppendBatchWindow != nil {
+		return false
+	} else if that1.AppendBatchWindow != nil {
+		return false
+	}
+	if this.EvenClusterPolicy != that1.EvenClusterPolicy {
+		return false
+	}
+	if this.InitializeTimeout != nil && that1.InitializeTimeout != nil {
+		if *this.InitializeTimeout != *that1.InitializeTimeout {
+			return false
+		}
+	} else if this.InitializeTimeout != nil {
+		return false
+	} else if that1.InitializeTimeout != nil {
+		return false
+	}
+	if this.MinAppliedIndexTimeout != nil && that1.MinAppliedIndexTimeout != nil {
+		if *this.MinAppliedIndexTimeout != *that1.MinAppliedIndexTimeout {
+			return false
+		}
+	} else if this.MinAppliedIndexTimeout != nil {
+		return false
+	} else if that1.MinAppliedIndexTimeout != nil {
+		return false
+	}
+	if this.LeaderStabilizationPeriod != nil && that1.LeaderStabilizationPeriod != nil {
+		if *this.LeaderStabilizationPeriod != *that1.LeaderStabilizationPeriod {
+			return false
+		}
+	} else if this.LeaderStabilizationPeriod != nil {
+		return false
+	} else if that1.LeaderStabilizationPeriod != nil {
+		return false
+	}
+	if this.RequestSnapshots != that1.RequestSnapshots {
+		return false
+	}
+	if this.CommitOverflowPolicy != that1.CommitOverflowPolicy {
+		return false
+	}
+	if this.LeaseHeartbeatRatio != that1.LeaseHeartbeatRatio {
+		return false
+	}
+	if !this.ElectionRateLimit.Equal(that1.ElectionRateLimit) {
+		return false
+	}
+	if this.LearnerCatchUpThreshold != that1.LearnerCatchUpThreshold {
+		return false
+	}
+	if this.MaxAppendBatchSize != that1.MaxAppendBatchSize {
+		return false
+	}
+	if this.MaxInflightAppends != that1.MaxInflightAppends {
+		return false
+	}
+	if !this.AdaptiveElectionTimeout.Equal(that1.AdaptiveElectionTimeout) {
+		return false
+	}
+	if this.VerifyVotes != that1.VerifyVotes {
+		return false
+	}
+	if this.CheckQuorum != that1.CheckQuorum {
+		return false
+	}
+	if this.ElectionJitter != that1.ElectionJitter {
+		return false
+	}
+	if this.JointConsensus != that1.JointConsensus {
+		return false
+	}
+	if len(this.Witnesses) != len(that1.Witnesses) {
+		return false
+	}
+	for i := range this.Witnesses {
+		if this.Witnesses[i] != that1.Witnesses[i] {
+			return false
+		}
+	}
+	if this.ForwardCommands != that1.ForwardCommands {
+		return false
+	}
+	if this.SingleNode != that1.SingleNode {
+		return false
+	}
+	if this.CommandStreamBuffer != that1.CommandStreamBuffer {
+		return false
+	}
+	if !this.Dedup.Equal(that1.Dedup) {
+		return false
+	}
+	return true
+}
+func (this *StorageConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*StorageConfig)
+	if !ok {
+		that2, ok := that.(StorageConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Directory != that1.Directory {
+		return false
+	}
+	if this.Level != that1.Level {
+		return false
+	}
+	if this.MaxEntrySize != that1.MaxEntrySize {
+		return false
+	}
+	if this.SegmentSize != that1.SegmentSize {
+		return false
+	}
+	if this.FlushOnCommit != that1.FlushOnCommit {
+		return false
+	}
+	return true
+}
+func (this *ApplyConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ApplyConfig)
+	if !ok {
+		that2, ok := that.(ApplyConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Deadline != nil && that1.Deadline != nil {
+		if *this.Deadline != *that1.Deadline {
+			return false
+		}
+	} else if this.Deadline != nil {
+		return false
+	} else if that1.Deadline != nil {
+		return false
+	}
+	if this.ErrorPolicy != that1.ErrorPolicy {
+		return false
+	}
+	if this.StallTimeout != nil && that1.StallTimeout != nil {
+		if *this.StallTimeout != *that1.StallTimeout {
+			return false
+		}
+	} else if this.StallTimeout != nil {
+		return false
+	} else if that1.StallTimeout != nil {
+		return false
+	}
+	return true
+}
+func (this *ReadIndexConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ReadIndexConfig)
+	if !ok {
+		that2, ok := that.(ReadIndexConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.PreferLocalZone != that1.PreferLocalZone {
+		return false
+	}
+	if this.LocalZoneWait != nil && that1.LocalZoneWait != nil {
+		if *this.LocalZoneWait != *that1.LocalZoneWait {
+			return false
+		}
+	} else if this.LocalZoneWait != nil {
+		return false
+	} else if that1.LocalZoneWait != nil {
+		return false
+	}
+	if this.ConfirmLeadership != that1.ConfirmLeadership {
+		return false
+	}
+	return true
+}
+func (this *ConsistencyProbeConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ConsistencyProbeConfig)
+	if !ok {
+		that2, ok := that.(ConsistencyProbeConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Interval != nil && that1.Interval != nil {
+		if *this.Interval != *that1.Interval {
+			return false
+		}
+	} else if this.Interval != nil {
+		return false
+	} else if that1.Interval != nil {
+		return false
+	}
+	if this.RangeSize != that1.RangeSize {
+		return false
+	}
+	return true
+}
+func (this *RecoveryConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RecoveryConfig)
+	if !ok {
+		that2, ok := that.(RecoveryConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.VerifySnapshot != that1.VerifySnapshot {
+		return false
+	}
+	if this.TruncateTornEntries != that1.TruncateTornEntries {
+		return false
+	}
+	return true
+}
+func (this *AdaptiveTimeoutConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*AdaptiveTimeoutConfig)
+	if !ok {
+		that2, ok := that.(AdaptiveTimeoutConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.RttMultiplier != that1.RttMultiplier {
+		return false
+	}
+	if this.MaxTimeout != nil && that1.MaxTimeout != nil {
+		if *this.MaxTimeout != *that1.MaxTimeout {
+			return false
+		}
+	} else if this.MaxTimeout != nil {
+		return false
+	} else if that1.MaxTimeout != nil {
+		return false
+	}
+	if this.Window != that1.Window {
+		return false
+	}
+	return true
+}
+func (this *RateLimitConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RateLimitConfig)
+	if !ok {
+		that2, ok := that.(RateLimitConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MaxRequests != that1.MaxRequests {
+		return false
+	}
+	if this.Interval != nil && that1.Interval != nil {
+		if *this.Interval != *that1.Interval {
+			return false
+		}
+	} else if this.Interval != nil {
+		return false
+	} else if that1.Interval != nil {
+		return false
+	}
+	return true
+}
+func (this *MetricsConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MetricsConfig)
+	if !ok {
+		that2, ok := that.(MetricsConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.LeaderOnly != that1.LeaderOnly {
+		return false
+	}
+	if this.Metadata != that1.Metadata {
+		return false
+	}
+	if this.Locks != that1.Locks {
+		return false
+	}
+	if this.Node != that1.Node {
+		return false
+	}
+	return true
+}
+func (this *DedupConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*DedupConfig)
+	if !ok {
+		that2, ok := that.(DedupConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.MaxBytes != that1.MaxBytes {
+		return false
+	}
+	if this.Expiration != nil && that1.Expiration != nil {
+		if *this.Expiration != *that1.Expiration {
+			return false
+		}
+	} else if this.Expiration != nil {
+		return false
+	} else if that1.Expiration != nil {
+		return false
+	}
+	return true
+}
+func (this *CompactionConfig) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CompactionConfig)
+	if !ok {
+		that2, ok := that.(CompactionConfig)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Dynamic != that1.Dynamic {
+		return false
+	}
+	if this.FreeDiskBuffer != that1.FreeDiskBuffer {
+		return false
+	}
+	if this.FreeMemoryBuffer != that1.FreeMemoryBuffer {
+		return false
+	}
+	if this.ShutdownSnapshotTimeout != nil && that1.ShutdownSnapshotTimeout != nil {
+		if *this.ShutdownSnapshotTimeout != *that1.ShutdownSnapshotTimeout {
+			return false
+		}
+	} else if this.ShutdownSnapshotTimeout != nil {
+		return false
+	} else if that1.ShutdownSnapshotTimeout != nil {
+		return false
+	}
+	if this.EntryThreshold != that1.EntryThreshold {
+		return false
+	}
+	if this.SizeThreshold != that1.SizeThreshold {
+		return false
+	}
+	return true
+}
+func (m *ProtocolConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProtocolConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProtocolConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Dedup != nil {
+		{
+			size, err := m.Dedup.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConfig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xc2
+	}
+	if m.CommandStreamBuffer != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.CommandStreamBuffer))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.SingleNode {
+		i--
+		if m.SingleNode {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.ForwardCommands {
+		i--
+		if m.ForwardCommands {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xa8
+	}
+	if len(m.Witnesses) > 0 {
+		for iNdEx := len(m.Witnesses) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Witnesses[iNdEx])
+			copy(dAtA[i:], m.Witnesses[iNdEx])
+			i = encodeVarintConfig(dAtA, i, uint64(len(m.Witnesses[iNdEx])))
+			i--
+			dAtA[i] = 0x3
+			i--
+			dAtA[i] = 0xa2
+		}
+	}
+	if m.JointConsensus {
+		i--
+		if m.JointConsensus {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.ElectionJitter != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.ElectionJitter))))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x95
+	}
+	if m.CheckQuorum {
+		i--
+		if m.CheckQuorum {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.VerifyVotes {
+		i--
+		if m.VerifyVotes {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.AdaptiveElectionTimeout != nil {
+		{
+			size, err := m.AdaptiveElectionTimeout.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConfig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xfa
+	}
+	if m.MaxInflightAppends != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxInflightAppends))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.MaxAppendBatchSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxAppendBatchSize))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.LearnerCatchUpThreshold != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.LearnerCatchUpThreshold))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.ElectionRateLimit != nil {
+		{
+			size, err := m.ElectionRateLimit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConfig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xda
+	}
+	if m.LeaseHeartbeatRatio != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.LeaseHeartbeatRatio))))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd5
+	}
+	if m.CommitOverflowPolicy != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.CommitOverflowPolicy))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.RequestSnapshots {
+		i--
+		if m.RequestSnapshots {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.LeaderStabilizationPeriod != nil {
+		n4, err4 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.LeaderStabilizationPeriod, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.LeaderStabilizationPeriod):])
+		if err4 != nil {
+			return 0, err4
+		}
+		i -= n4
+		i = encodeVarintConfig(dAtA, i, uint64(n4))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xba
+	}
+	if m.MinAppliedIndexTimeout != nil {
+		n5, err5 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.MinAppliedIndexTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.MinAppliedIndexTimeout):])
+		if err5 != nil {
+			return 0, err5
+		}
+		i -= n5
+		i = encodeVarintConfig(dAtA, i, uint64(n5))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xb2
+	}
+	if m.InitializeTimeout != nil {
+		n6, err6 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.InitializeTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.InitializeTimeout):])
+		if err6 != nil {
+			return 0, err6
+		}
+		i -= n6
+		i = encodeVarintConfig(dAtA, i, uint64(n6))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xaa
+	}
+	if m.EvenClusterPolicy != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.EvenClusterPolicy))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.AppendBatchWindow != nil {
+		n7, err7 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.AppendBatchWindow, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.AppendBatchWindow):])
+		if err7 != nil {
+			return 0, err7
+		}
+		i -= n7
+		i = encodeVarintConfig(dAtA, i, uint64(n7))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x9a
+	}
+	if m.StrictReads {
+		i--
+		if m.StrictReads {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.UrgentProposalTimeout != nil {
+		n8, err8 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.UrgentProposalTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.UrgentProposalTimeout):])
+		if err8 != nil {
+			return 0, err8
+		}
+		i -= n8
+		i = encodeVarintConfig(dAtA, i, uint64(n8))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.ProposalBatchInterval != nil {
+		n9, err9 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ProposalBatchInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ProposalBatchInterval):])
+		if err9 != nil {
+			return 0, err9
+		}
+		i -= n9
+		i = encodeVarintConfig(dAtA, i, uint64(n9))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.InstallTimeout != nil {
+		n10, err10 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.InstallTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.InstallTimeout):])
+		if err10 != nil {
+			return 0, err10
+		}
+		i -= n10
+		i = encodeVarintConfig(dAtA, i, uint64(n10))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xfa
+	}
+	if m.BatchElectionMetadata {
+		i--
+		if m.BatchElectionMetadata {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.ReportLastLeader {
+		i--
+		if m.ReportLastLeader {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.ReadRepairWindow != nil {
+		n11, err11 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ReadRepairWindow, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ReadRepairWindow):])
+		if err11 != nil {
+			return 0, err11
+		}
+		i -= n11
+		i = encodeVarintConfig(dAtA, i, uint64(n11))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe2
+	}
+	if m.MaxCommandResultSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxCommandResultSize))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd8
+	}
+	if m.MaxAppendSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxAppendSize))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd0
+	}
+	if m.MaxClockDrift != nil {
+		n12, err12 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.MaxClockDrift, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.MaxClockDrift):])
+		if err12 != nil {
+			return 0, err12
+		}
+		i -= n12
+		i = encodeVarintConfig(dAtA, i, uint64(n12))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xca
+	}
+	if m.MaxElectionFreeze != nil {
+		n13, err13 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.MaxElectionFreeze, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.MaxElectionFreeze):])
+		if err13 != nil {
+			return 0, err13
+		}
+		i -= n13
+		i = encodeVarintConfig(dAtA, i, uint64(n13))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc2
+	}
+	if m.CheckVoteConfiguration {
+		i--
+		if m.CheckVoteConfiguration {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.FollowerBufferSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.FollowerBufferSize))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.PromotionPolicy != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.PromotionPolicy))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if m.DisableInstallHeartbeats {
+		i--
+		if m.DisableInstallHeartbeats {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.FairProposals {
+		i--
+		if m.FairProposals {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.MaxCommandStreams != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxCommandStreams))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.DisableStickyPolls {
+		i--
+		if m.DisableStickyPolls {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.TruncationAlertThreshold != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.TruncationAlertThreshold))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.RetainedSnapshots != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.RetainedSnapshots))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.Recovery != nil {
+		{
+			size, err := m.Recovery.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConfig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.ConsistencyProbe != nil {
+		{
+			size, err := m.ConsistencyProbe.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConfig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.ReadIndex != nil {
+		{
+			size, err := m.ReadIndex.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConfig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.Zones) > 0 {
+		for k := range m.Zones {
+			v := m.Zones[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintConfig(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintConfig(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintConfig(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if m.Apply != nil {
+		{
+			size, err := m.Apply.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConfig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.OrderedQueries {
+		i--
+		if m.OrderedQueries {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.TransferTimeout != nil {
+		n18, err18 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.TransferTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.TransferTimeout):])
+		if err18 != nil {
+			return 0, err18
+		}
+		i -= n18
+		i = encodeVarintConfig(dAtA, i, uint64(n18))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Metrics != nil {
+		{
+			size, err := m.Metrics.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConfig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Priorities) > 0 {
+		for k := range m.Priorities {
+			v := m.Priorities[k]
+			baseI := i
+			i = encodeVarintConfig(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintConfig(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintConfig(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.MaxElectionTimeout != nil {
+		n20, err20 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.MaxElectionTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.MaxElectionTimeout):])
+		if err20 != nil {
+			return 0, err20
+		}
+		i -= n20
+		i = encodeVarintConfig(dAtA, i, uint64(n20))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Compaction != nil {
+		{
+			size, err := m.Compaction.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConfig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Storage != nil {
+		{
+			size, err := m.Storage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConfig(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.HeartbeatInterval != nil {
+		n23, err23 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.HeartbeatInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatInterval):])
+		if err23 != nil {
+			return 0, err23
+		}
+		i -= n23
+		i = encodeVarintConfig(dAtA, i, uint64(n23))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ElectionTimeout != nil {
+		n24, err24 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ElectionTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ElectionTimeout):])
+		if err24 != nil {
+			return 0, err24
+		}
+		i -= n24
+		i = encodeVarintConfig(dAtA, i, uint64(n24))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FlushOnCommit {
+		i--
+		if m.FlushOnCommit {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.SegmentSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.SegmentSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxEntrySize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxEntrySize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Level != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.Level))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Directory) > 0 {
+		i -= len(m.Directory)
+		copy(dAtA[i:], m.Directory)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Directory)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ApplyConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplyConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApplyConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.StallTimeout != nil {
+		n25, err25 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.StallTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.StallTimeout):])
+		if err25 != nil {
+			return 0, err25
+		}
+		i -= n25
+		i = encodeVarintConfig(dAtA, i, uint64(n25))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ErrorPolicy != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.ErrorPolicy))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Deadline != nil {
+		n26, err26 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.Deadline, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Deadline):])
+		if err26 != nil {
+			return 0, err26
+		}
+		i -= n26
+		i = encodeVarintConfig(dAtA, i, uint64(n26))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReadIndexConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadIndexConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadIndexConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ConfirmLeadership {
+		i--
+		if m.ConfirmLeadership {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.LocalZoneWait != nil {
+		n27, err27 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.LocalZoneWait, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.LocalZoneWait):])
+		if err27 != nil {
+			return 0, err27
+		}
+		i -= n27
+		i = encodeVarintConfig(dAtA, i, uint64(n27))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.PreferLocalZone {
+		i--
+		if m.PreferLocalZone {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConsistencyProbeConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConsistencyProbeConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConsistencyProbeConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RangeSize != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.RangeSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Interval != nil {
+		n28, err28 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.Interval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Interval):])
+		if err28 != nil {
+			return 0, err28
+		}
+		i -= n28
+		i = encodeVarintConfig(dAtA, i, uint64(n28))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecoveryConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecoveryConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecoveryConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TruncateTornEntries {
+		i--
+		if m.TruncateTornEntries {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.VerifySnapshot {
+		i--
+		if m.VerifySnapshot {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AdaptiveTimeoutConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AdaptiveTimeoutConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AdaptiveTimeoutConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Window != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.Window))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxTimeout != nil {
+		n29, err29 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.MaxTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.MaxTimeout):])
+		if err29 != nil {
+			return 0, err29
+		}
+		i -= n29
+		i = encodeVarintConfig(dAtA, i, uint64(n29))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.RttMultiplier != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.RttMultiplier))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RateLimitConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RateLimitConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RateLimitConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Interval != nil {
+		n30, err30 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.Interval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Interval):])
+		if err30 != nil {
+			return 0, err30
+		}
+		i -= n30
+		i = encodeVarintConfig(dAtA, i, uint64(n30))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.MaxRequests != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxRequests))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricsConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricsConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricsConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Node {
+		i--
+		if m.Node {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Locks {
+		i--
+		if m.Locks {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Metadata {
+		i--
+		if m.Metadata {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.LeaderOnly {
+		i--
+		if m.LeaderOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DedupConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DedupConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DedupConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Expiration != nil {
+		n31, err31 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.Expiration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Expiration):])
+		if err31 != nil {
+			return 0, err31
+		}
+		i -= n31
+		i = encodeVarintConfig(dAtA, i, uint64(n31))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.MaxBytes != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxBytes))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompactionConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactionConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactionConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SizeThreshold != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.SizeThreshold))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.EntryThreshold != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.EntryThreshold))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ShutdownSnapshotTimeout != nil {
+		n32, err32 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ShutdownSnapshotTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ShutdownSnapshotTimeout):])
+		if err32 != nil {
+			return 0, err32
+		}
+		i -= n32
+		i = encodeVarintConfig(dAtA, i, uint64(n32))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.FreeMemoryBuffer != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.FreeMemoryBuffer))))
+		i--
+		dAtA[i] = 0x1d
+	}
+	if m.FreeDiskBuffer != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.FreeDiskBuffer))))
+		i--
+		dAtA[i] = 0x15
+	}
+	if m.Dynamic {
+		i--
+		if m.Dynamic {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintConfig(dAtA []byte, offset int, v uint64) int {
+	offset -= sovConfig(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func NewPopulatedProtocolConfig(r randyConfig, easy bool) *ProtocolConfig {
+	this := &ProtocolConfig{}
+	if r.Intn(5) != 0 {
+		this.ElectionTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.HeartbeatInterval = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.Storage = NewPopulatedStorageConfig(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.Compaction = NewPopulatedCompactionConfig(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.MaxElectionTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		v1 := r.Intn(10)
+		this.Priorities = make(map[string]int32)
+		for i := 0; i < v1; i++ {
+			v2 := randStringConfig(r)
+			this.Priorities[v2] = int32(r.Int31())
+			if r.Intn(2) == 0 {
+				this.Priorities[v2] *= -1
+			}
+		}
+	}
+	if r.Intn(5) != 0 {
+		this.Metrics = NewPopulatedMetricsConfig(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.TransferTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.OrderedQueries = bool(bool(r.Intn(2) == 0))
+	if r.Intn(5) != 0 {
+		this.Apply = NewPopulatedApplyConfig(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		v3 := r.Intn(10)
+		this.Zones = make(map[string]string)
+		for i := 0; i < v3; i++ {
+			this.Zones[randStringConfig(r)] = randStringConfig(r)
+		}
+	}
+	if r.Intn(5) != 0 {
+		this.ReadIndex = NewPopulatedReadIndexConfig(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.ConsistencyProbe = NewPopulatedConsistencyProbeConfig(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.Recovery = NewPopulatedRecoveryConfig(r, easy)
+	}
+	this.RetainedSnapshots = uint32(r.Uint32())
+	this.TruncationAlertThreshold = uint64(uint64(r.Uint32()))
+	this.DisableStickyPolls = bool(bool(r.Intn(2) == 0))
+	this.MaxCommandStreams = uint32(r.Uint32())
+	this.FairProposals = bool(bool(r.Intn(2) == 0))
+	this.DisableInstallHeartbeats = bool(bool(r.Intn(2) == 0))
+	this.PromotionPolicy = PromotionPolicy([]int32{0, 1}[r.Intn(2)])
+	this.FollowerBufferSize = uint32(r.Uint32())
+	this.CheckVoteConfiguration = bool(bool(r.Intn(2) == 0))
+	if r.Intn(5) != 0 {
+		this.MaxElectionFreeze = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.MaxClockDrift = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.MaxAppendSize = uint32(r.Uint32())
+	this.MaxCommandResultSize = uint32(r.Uint32())
+	if r.Intn(5) != 0 {
+		this.ReadRepairWindow = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.ReportLastLeader = bool(bool(r.Intn(2) == 0))
+	this.BatchElectionMetadata = bool(bool(r.Intn(2) == 0))
+	if r.Intn(5) != 0 {
+		this.InstallTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.ProposalBatchInterval = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.UrgentProposalTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.StrictReads = bool(bool(r.Intn(2) == 0))
+	if r.Intn(5) != 0 {
+		this.AppendBatchWindow = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.EvenClusterPolicy = EvenClusterPolicy([]int32{0, 1, 2}[r.Intn(3)])
+	if r.Intn(5) != 0 {
+		this.InitializeTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.MinAppliedIndexTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.LeaderStabilizationPeriod = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.RequestSnapshots = bool(bool(r.Intn(2) == 0))
+	this.CommitOverflowPolicy = CommitOverflowPolicy([]int32{0, 1}[r.Intn(2)])
+	this.LeaseHeartbeatRatio = float32(r.Float32())
+	if r.Intn(2) == 0 {
+		this.LeaseHeartbeatRatio *= -1
+	}
+	if r.Intn(5) != 0 {
+		this.ElectionRateLimit = NewPopulatedRateLimitConfig(r, easy)
+	}
+	this.LearnerCatchUpThreshold = uint64(uint64(r.Uint32()))
+	this.MaxAppendBatchSize = uint32(r.Uint32())
+	this.MaxInflightAppends = uint32(r.Uint32())
+	if r.Intn(5) != 0 {
+		this.AdaptiveElectionTimeout = NewPopulatedAdaptiveTimeoutConfig(r, easy)
+	}
+	this.VerifyVotes = bool(bool(r.Intn(2) == 0))
+	this.CheckQuorum = bool(bool(r.Intn(2) == 0))
+	this.ElectionJitter = float32(r.Float32())
+	if r.Intn(2) == 0 {
+		this.ElectionJitter *= -1
+	}
+	this.JointConsensus = bool(bool(r.Intn(2) == 0))
+	v4 := r.Intn(10)
+	this.Witnesses = make([]string, v4)
+	for i := 0; i < v4; i++ {
+		this.Witnesses[i] = string(randStringConfig(r))
+	}
+	this.ForwardCommands = bool(bool(r.Intn(2) == 0))
+	this.SingleNode = bool(bool(r.Intn(2) == 0))
+	this.CommandStreamBuffer = uint32(r.Uint32())
+	if r.Intn(5) != 0 {
+		this.Dedup = NewPopulatedDedupConfig(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedStorageConfig(r randyConfig, easy bool) *StorageConfig {
+	this := &StorageConfig{}
+	this.Directory = string(randStringConfig(r))
+	this.Level = StorageLevel([]int32{0, 1}[r.Intn(2)])
+	this.MaxEntrySize = uint32(r.Uint32())
+	this.SegmentSize = uint32(r.Uint32())
+	this.FlushOnCommit = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedApplyConfig(r randyConfig, easy bool) *ApplyConfig {
+	this := &ApplyConfig{}
+	if r.Intn(5) != 0 {
+		this.Deadline = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.ErrorPolicy = ApplyErrorPolicy([]int32{0, 1}[r.Intn(2)])
+	if r.Intn(5) != 0 {
+		this.StallTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedReadIndexConfig(r randyConfig, easy bool) *ReadIndexConfig {
+	this := &ReadIndexConfig{}
+	this.PreferLocalZone = bool(bool(r.Intn(2) == 0))
+	if r.Intn(5) != 0 {
+		this.LocalZoneWait = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.ConfirmLeadership = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedConsistencyProbeConfig(r randyConfig, easy bool) *ConsistencyProbeConfig {
+	this := &ConsistencyProbeConfig{}
+	if r.Intn(5) != 0 {
+		this.Interval = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.RangeSize = uint64(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedRecoveryConfig(r randyConfig, easy bool) *RecoveryConfig {
+	this := &RecoveryConfig{}
+	this.VerifySnapshot = bool(bool(r.Intn(2) == 0))
+	this.TruncateTornEntries = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedAdaptiveTimeoutConfig(r randyConfig, easy bool) *AdaptiveTimeoutConfig {
+	this := &AdaptiveTimeoutConfig{}
+	this.RttMultiplier = uint32(r.Uint32())
+	if r.Intn(5) != 0 {
+		this.MaxTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.Window = uint32(r.Uint32())
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedRateLimitConfig(r randyConfig, easy bool) *RateLimitConfig {
+	this := &RateLimitConfig{}
+	this.MaxRequests = uint32(r.Uint32())
+	if r.Intn(5) != 0 {
+		this.Interval = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedMetricsConfig(r randyConfig, easy bool) *MetricsConfig {
+	this := &MetricsConfig{}
+	this.LeaderOnly = bool(bool(r.Intn(2) == 0))
+	this.Metadata = bool(bool(r.Intn(2) == 0))
+	this.Locks = bool(bool(r.Intn(2) == 0))
+	this.Node = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedDedupConfig(r randyConfig, easy bool) *DedupConfig {
+	this := &DedupConfig{}
+	this.MaxBytes = uint64(uint64(r.Uint32()))
+	if r.Intn(5) != 0 {
+		this.Expiration = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedCompactionConfig(r randyConfig, easy bool) *CompactionConfig {
+	this := &CompactionConfig{}
+	this.Dynamic = bool(bool(r.Intn(2) == 0))
+	this.FreeDiskBuffer = float32(r.Float32())
+	if r.Intn(2) == 0 {
+		this.FreeDiskBuffer *= -1
+	}
+	this.FreeMemoryBuffer = float32(r.Float32())
+	if r.Intn(2) == 0 {
+		this.FreeMemoryBuffer *= -1
+	}
+	if r.Intn(5) != 0 {
+		this.ShutdownSnapshotTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	this.EntryThreshold = uint64(uint64(r.Uint32()))
+	this.SizeThreshold = uint64(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+type randyConfig interface {
+	Float32() float32
+	Float64() float64
+	Int63() int64
+	Int31() int32
+	Uint32() uint32
+	Intn(n int) int
+}
+
+func randUTF8RuneConfig(r randyConfig) rune {
+	ru := r.Intn(62)
+	if ru < 10 {
+		return rune(ru + 48)
+	} else if ru < 36 {
+		return rune(ru + 55)
+	}
+	return rune(ru + 61)
+}
+func randStringConfig(r randyConfig) string {
+	v5 := r.Intn(100)
+	tmps := make([]rune, v5)
+	for i := 0; i < v5; i++ {
+		tmps[i] = randUTF8RuneConfig(r)
+	}
+	return string(tmps)
+}
+func randUnrecognizedConfig(r randyConfig, maxFieldNumber int) (dAtA []byte) {
+	l := r.Intn(5)
+	for i := 0; i < l; i++ {
+		wire := r.Intn(4)
+		if wire == 3 {
+			wire = 5
+		}
+		fieldNumber := maxFieldNumber + r.Intn(100)
+		dAtA = randFieldConfig(dAtA, r, fieldNumber, wire)
+	}
+	return dAtA
+}
+func randFieldConfig(dAtA []byte, r randyConfig, fieldNumber int, wire int) []byte {
+	key := uint32(fieldNumber)<<3 | uint32(wire)
+	switch wire {
+	case 0:
+		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
+		v6 := r.Int63()
+		if r.Intn(2) == 0 {
+			v6 *= -1
+		}
+		dAtA = encodeVarintPopulateConfig(dAtA, uint64(v6))
+	case 1:
+		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	case 2:
+		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
+		ll := r.Intn(100)
+		dAtA = encodeVarintPopulateConfig(dAtA, uint64(ll))
+		for j := 0; j < ll; j++ {
+			dAtA = append(dAtA, byte(r.Intn(256)))
+		}
+	default:
+		dAtA = encodeVarintPopulateConfig(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	}
+	return dAtA
+}
+func encodeVarintPopulateConfig(dAtA []byte, v uint64) []byte {
+	for v >= 1<<7 {
+		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
+		v >>= 7
+	}
+	dAtA = append(dAtA, uint8(v))
+	return dAtA
+}
+func (m *ProtocolConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ElectionTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ElectionTimeout)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.HeartbeatInterval != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatInterval)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Storage != nil {
+		l = m.Storage.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Compaction != nil {
+		l = m.Compaction.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.MaxElectionTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.MaxElectionTimeout)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if len(m.Priorities) > 0 {
+		for k, v := range m.Priorities {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovConfig(uint64(len(k))) + 1 + sovConfig(uint64(v))
+			n += mapEntrySize + 1 + sovConfig(uint64(mapEntrySize))
+		}
+	}
+	if m.Metrics != nil {
+		l = m.Metrics.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.TransferTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.TransferTimeout)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.OrderedQueries {
+		n += 2
+	}
+	if m.Apply != nil {
+		l = m.Apply.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if len(m.Zones) > 0 {
+		for k, v := range m.Zones {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovConfig(uint64(len(k))) + 1 + len(v) + sovConfig(uint64(len(v)))
+			n += mapEntrySize + 1 + sovConfig(uint64(mapEntrySize))
+		}
+	}
+	if m.ReadIndex != nil {
+		l = m.ReadIndex.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.ConsistencyProbe != nil {
+		l = m.ConsistencyProbe.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Recovery != nil {
+		l = m.Recovery.Size()
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.RetainedSnapshots != 0 {
+		n += 1 + sovConfig(uint64(m.RetainedSnapshots))
+	}
+	if m.TruncationAlertThreshold != 0 {
+		n += 2 + sovConfig(uint64(m.TruncationAlertThreshold))
+	}
+	if m.DisableStickyPolls {
+		n += 3
+	}
+	if m.MaxCommandStreams != 0 {
+		n += 2 + sovConfig(uint64(m.MaxCommandStreams))
+	}
+	if m.FairProposals {
+		n += 3
+	}
+	if m.DisableInstallHeartbeats {
+		n += 3
+	}
+	if m.PromotionPolicy != 0 {
+		n += 2 + sovConfig(uint64(m.PromotionPolicy))
+	}
+	if m.FollowerBufferSize != 0 {
+		n += 2 + sovConfig(uint64(m.FollowerBufferSize))
+	}
+	if m.CheckVoteConfiguration {
+		n += 3
+	}
+	if m.MaxElectionFreeze != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.MaxElectionFreeze)
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.MaxClockDrift != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.MaxClockDrift)
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.MaxAppendSize != 0 {
+		n += 2 + sovConfig(uint64(m.MaxAppendSize))
+	}
+	if m.MaxCommandResultSize != 0 {
+		n += 2 + sovConfig(uint64(m.MaxCommandResultSize))
+	}
+	if m.ReadRepairWindow != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ReadRepairWindow)
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.ReportLastLeader {
+		n += 3
+	}
+	if m.BatchElectionMetadata {
+		n += 3
+	}
+	if m.InstallTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.InstallTimeout)
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.ProposalBatchInterval != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ProposalBatchInterval)
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.UrgentProposalTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.UrgentProposalTimeout)
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.StrictReads {
+		n += 3
+	}
+	if m.AppendBatchWindow != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.AppendBatchWindow)
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.EvenClusterPolicy != 0 {
+		n += 2 + sovConfig(uint64(m.EvenClusterPolicy))
+	}
+	if m.InitializeTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.InitializeTimeout)
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.MinAppliedIndexTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.MinAppliedIndexTimeout)
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.LeaderStabilizationPeriod != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.LeaderStabilizationPeriod)
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.RequestSnapshots {
+		n += 3
+	}
+	if m.CommitOverflowPolicy != 0 {
+		n += 2 + sovConfig(uint64(m.CommitOverflowPolicy))
+	}
+	if m.LeaseHeartbeatRatio != 0 {
+		n += 6
+	}
+	if m.ElectionRateLimit != nil {
+		l = m.ElectionRateLimit.Size()
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.LearnerCatchUpThreshold != 0 {
+		n += 2 + sovConfig(uint64(m.LearnerCatchUpThreshold))
+	}
+	if m.MaxAppendBatchSize != 0 {
+		n += 2 + sovConfig(uint64(m.MaxAppendBatchSize))
+	}
+	if m.MaxInflightAppends != 0 {
+		n += 2 + sovConfig(uint64(m.MaxInflightAppends))
+	}
+	if m.AdaptiveElectionTimeout != nil {
+		l = m.AdaptiveElectionTimeout.Size()
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if m.VerifyVotes {
+		n += 3
+	}
+	if m.CheckQuorum {
+		n += 3
+	}
+	if m.ElectionJitter != 0 {
+		n += 6
+	}
+	if m.JointConsensus {
+		n += 3
+	}
+	if len(m.Witnesses) > 0 {
+		for _, s := range m.Witnesses {
+			l = len(s)
+			n += 2 + l + sovConfig(uint64(l))
+		}
+	}
+	if m.ForwardCommands {
+		n += 3
+	}
+	if m.SingleNode {
+		n += 3
+	}
+	if m.CommandStreamBuffer != 0 {
+		n += 2 + sovConfig(uint64(m.CommandStreamBuffer))
+	}
+	if m.Dedup != nil {
+		l = m.Dedup.Size()
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	return n
+}
+
+func (m *StorageConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Directory)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Level != 0 {
+		n += 1 + sovConfig(uint64(m.Level))
+	}
+	if m.MaxEntrySize != 0 {
+		n += 1 + sovConfig(uint64(m.MaxEntrySize))
+	}
+	if m.SegmentSize != 0 {
+		n += 1 + sovConfig(uint64(m.SegmentSize))
+	}
+	if m.FlushOnCommit {
+		n += 2
+	}
+	return n
+}
+
+func (m *ApplyConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Deadline != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Deadline)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.ErrorPolicy != 0 {
+		n += 1 + sovConfig(uint64(m.ErrorPolicy))
+	}
+	if m.StallTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.StallTimeout)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	return n
+}
+
+func (m *ReadIndexConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PreferLocalZone {
+		n += 2
+	}
+	if m.LocalZoneWait != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.LocalZoneWait)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.ConfirmLeadership {
+		n += 2
+	}
+	return n
+}
+
+func (m *ConsistencyProbeConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Interval != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Interval)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.RangeSize != 0 {
+		n += 1 + sovConfig(uint64(m.RangeSize))
+	}
+	return n
+}
+
+func (m *RecoveryConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.VerifySnapshot {
+		n += 2
+	}
+	if m.TruncateTornEntries {
+		n += 2
+	}
+	return n
+}
+
+func (m *AdaptiveTimeoutConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RttMultiplier != 0 {
+		n += 1 + sovConfig(uint64(m.RttMultiplier))
+	}
+	if m.MaxTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.MaxTimeout)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Window != 0 {
+		n += 1 + sovConfig(uint64(m.Window))
+	}
+	return n
+}
+
+func (m *RateLimitConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxRequests != 0 {
+		n += 1 + sovConfig(uint64(m.MaxRequests))
+	}
+	if m.Interval != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Interval)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	return n
+}
+
+func (m *MetricsConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LeaderOnly {
+		n += 2
+	}
+	if m.Metadata {
+		n += 2
+	}
+	if m.Locks {
+		n += 2
+	}
+	if m.Node {
+		n += 2
+	}
+	return n
+}
+
+func (m *DedupConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxBytes != 0 {
+		n += 1 + sovConfig(uint64(m.MaxBytes))
+	}
+	if m.Expiration != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Expiration)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	return n
+}
+
+func (m *CompactionConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Dynamic {
+		n += 2
+	}
+	if m.FreeDiskBuffer != 0 {
+		n += 5
+	}
+	if m.FreeMemoryBuffer != 0 {
+		n += 5
+	}
+	if m.ShutdownSnapshotTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ShutdownSnapshotTimeout)
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.EntryThreshold != 0 {
+		n += 1 + sovConfig(uint64(m.EntryThreshold))
+	}
+	if m.SizeThreshold != 0 {
+		n += 1 + sovConfig(uint64(m.SizeThreshold))
+	}
+	return n
+}
+
+func sovConfig(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozConfig(x uint64) (n int) {
+	return sovConfig(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProtocolConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProtocolConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElectionTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ElectionTimeout == nil {
+				m.ElectionTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.ElectionTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HeartbeatInterval == nil {
+				m.HeartbeatInterval = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.HeartbeatInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Storage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Storage == nil {
+				m.Storage = &StorageConfig{}
+			}
+			if err := m.Storage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compaction", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Compaction == nil {
+				m.Compaction = &CompactionConfig{}
+			}
+			if err := m.Compaction.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxElectionTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxElectionTimeout == nil {
+				m.MaxElectionTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.MaxElectionTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priorities", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Priorities == nil {
+				m.Priorities = make(map[string]int32)
+			}
+			var mapkey string
+			var mapvalue int32
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipConfig(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Priorities[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metrics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metrics == nil {
+				m.Metrics = &MetricsConfig{}
+			}
+			if err := m.Metrics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TransferTimeout == nil {
+				m.TransferTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.TransferTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderedQueries", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OrderedQueries = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Apply", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Apply == nil {
+				m.Apply = &ApplyConfig{}
+			}
+			if err := m.Apply.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Zones", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Zones == nil {
+				m.Zones = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConfig
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConfig
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipConfig(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthConfig
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Zones[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadIndex", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReadIndex == nil {
+				m.ReadIndex = &ReadIndexConfig{}
+			}
+			if err := m.ReadIndex.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsistencyProbe", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsistencyProbe == nil {
+				m.ConsistencyProbe = &ConsistencyProbeConfig{}
+			}
+			if err := m.ConsistencyProbe.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Recovery", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Recovery == nil {
+				m.Recovery = &RecoveryConfig{}
+			}
+			if err := m.Recovery.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetainedSnapshots", wireType)
+			}
+			m.RetainedSnapshots = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetainedSnapshots |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TruncationAlertThreshold", wireType)
+			}
+			m.TruncationAlertThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TruncationAlertThreshold |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableStickyPolls", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DisableStickyPolls = bool(v != 0)
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCommandStreams", wireType)
+			}
+			m.MaxCommandStreams = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCommandStreams |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FairProposals", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FairProposals = bool(v != 0)
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableInstallHeartbeats", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DisableInstallHeartbeats = bool(v != 0)
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PromotionPolicy", wireType)
+			}
+			m.PromotionPolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PromotionPolicy |= PromotionPolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FollowerBufferSize", wireType)
+			}
+			m.FollowerBufferSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FollowerBufferSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckVoteConfiguration", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CheckVoteConfiguration = bool(v != 0)
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxElectionFreeze", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxElectionFreeze == nil {
+				m.MaxElectionFreeze = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.MaxElectionFreeze, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxClockDrift", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MaxClockDrift == nil {
+				m.MaxClockDrift = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.MaxClockDrift, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 26:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAppendSize", wireType)
+			}
+			m.MaxAppendSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAppendSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 27:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCommandResultSize", wireType)
+			}
+			m.MaxCommandResultSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCommandResultSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadRepairWindow", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReadRepairWindow == nil {
+				m.ReadRepairWindow = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.ReadRepairWindow, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 29:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReportLastLeader", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReportLastLeader = bool(v != 0)
+		case 30:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchElectionMetadata", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BatchElectionMetadata = bool(v != 0)
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstallTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InstallTimeout == nil {
+				m.InstallTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.InstallTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 32:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalBatchInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProposalBatchInterval == nil {
+				m.ProposalBatchInterval = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.ProposalBatchInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 33:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UrgentProposalTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UrgentProposalTimeout == nil {
+				m.UrgentProposalTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.UrgentProposalTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 34:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StrictReads", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StrictReads = bool(v != 0)
+		case 35:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppendBatchWindow", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AppendBatchWindow == nil {
+				m.AppendBatchWindow = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.AppendBatchWindow, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 36:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EvenClusterPolicy", wireType)
+			}
+			m.EvenClusterPolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EvenClusterPolicy |= EvenClusterPolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 37:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitializeTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InitializeTimeout == nil {
+				m.InitializeTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.InitializeTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 38:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinAppliedIndexTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.MinAppliedIndexTimeout == nil {
+				m.MinAppliedIndexTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.MinAppliedIndexTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 39:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderStabilizationPeriod", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeaderStabilizationPeriod == nil {
+				m.LeaderStabilizationPeriod = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.LeaderStabilizationPeriod, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 40:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestSnapshots", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RequestSnapshots = bool(v != 0)
+		case 41:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitOverflowPolicy", wireType)
+			}
+			m.CommitOverflowPolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitOverflowPolicy |= CommitOverflowPolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 42:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseHeartbeatRatio", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.LeaseHeartbeatRatio = float32(math.Float32frombits(v))
+		case 43:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElectionRateLimit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ElectionRateLimit == nil {
+				m.ElectionRateLimit = &RateLimitConfig{}
+			}
+			if err := m.ElectionRateLimit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 44:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LearnerCatchUpThreshold", wireType)
+			}
+			m.LearnerCatchUpThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LearnerCatchUpThreshold |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 45:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAppendBatchSize", wireType)
+			}
+			m.MaxAppendBatchSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAppendBatchSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 46:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxInflightAppends", wireType)
+			}
+			m.MaxInflightAppends = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxInflightAppends |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 47:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdaptiveElectionTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AdaptiveElectionTimeout == nil {
+				m.AdaptiveElectionTimeout = &AdaptiveTimeoutConfig{}
+			}
+			if err := m.AdaptiveElectionTimeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 48:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyVotes", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.VerifyVotes = bool(v != 0)
+		case 49:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckQuorum", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CheckQuorum = bool(v != 0)
+		case 50:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElectionJitter", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.ElectionJitter = float32(math.Float32frombits(v))
+		case 51:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JointConsensus", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.JointConsensus = bool(v != 0)
+		case 52:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Witnesses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Witnesses = append(m.Witnesses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 53:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForwardCommands", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ForwardCommands = bool(v != 0)
+		case 54:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SingleNode", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SingleNode = bool(v != 0)
+		case 55:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommandStreamBuffer", wireType)
+			}
+			m.CommandStreamBuffer = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommandStreamBuffer |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 56:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dedup", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Dedup == nil {
+				m.Dedup = &DedupConfig{}
+			}
+			if err := m.Dedup.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Directory", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Directory = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+			}
+			m.Level = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Level |= StorageLevel(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxEntrySize", wireType)
+			}
+			m.MaxEntrySize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxEntrySize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SegmentSize", wireType)
+			}
+			m.SegmentSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SegmentSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FlushOnCommit", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FlushOnCommit = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	if m.FreeMemoryBuffer != 0 {
-		n += 5
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApplyConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApplyConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApplyConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deadline", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Deadline == nil {
+				m.Deadline = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.Deadline, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorPolicy", wireType)
+			}
+			m.ErrorPolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorPolicy |= ApplyErrorPolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StallTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StallTimeout == nil {
+				m.StallTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.StallTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReadIndexConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadIndexConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadIndexConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreferLocalZone", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PreferLocalZone = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalZoneWait", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LocalZoneWait == nil {
+				m.LocalZoneWait = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.LocalZoneWait, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfirmLeadership", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ConfirmLeadership = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovConfig(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozConfig(x uint64) (n int) {
-	return sovConfig(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
+func (m *ConsistencyProbeConfig) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -807,15 +6135,15 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProtocolConfig: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConsistencyProbeConfig: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProtocolConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConsistencyProbeConfig: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ElectionTimeout", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -842,16 +6170,200 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ElectionTimeout == nil {
-				m.ElectionTimeout = new(time.Duration)
+			if m.Interval == nil {
+				m.Interval = new(time.Duration)
 			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.ElectionTimeout, dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.Interval, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RangeSize", wireType)
+			}
+			m.RangeSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RangeSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RecoveryConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RecoveryConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RecoveryConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifySnapshot", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.VerifySnapshot = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TruncateTornEntries", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TruncateTornEntries = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AdaptiveTimeoutConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AdaptiveTimeoutConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AdaptiveTimeoutConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RttMultiplier", wireType)
+			}
+			m.RttMultiplier = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RttMultiplier |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatInterval", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTimeout", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -878,18 +6390,90 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.HeartbeatInterval == nil {
-				m.HeartbeatInterval = new(time.Duration)
+			if m.MaxTimeout == nil {
+				m.MaxTimeout = new(time.Duration)
 			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.HeartbeatInterval, dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.MaxTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Window", wireType)
+			}
+			m.Window = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Window |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Storage", wireType)
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RateLimitConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RateLimitConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RateLimitConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxRequests", wireType)
 			}
-			var msglen int
+			m.MaxRequests = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfig
@@ -899,31 +6483,14 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.MaxRequests |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthConfig
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthConfig
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Storage == nil {
-				m.Storage = &StorageConfig{}
-			}
-			if err := m.Storage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Compaction", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -950,10 +6517,10 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Compaction == nil {
-				m.Compaction = &CompactionConfig{}
+			if m.Interval == nil {
+				m.Interval = new(time.Duration)
 			}
-			if err := m.Compaction.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.Interval, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -981,7 +6548,7 @@ func (m *ProtocolConfig) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StorageConfig) Unmarshal(dAtA []byte) error {
+func (m *MetricsConfig) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1004,17 +6571,17 @@ func (m *StorageConfig) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StorageConfig: wiretype end group for non-group")
+			return fmt.Errorf("proto: MetricsConfig: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StorageConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MetricsConfig: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Directory", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderOnly", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfig
@@ -1024,29 +6591,17 @@ func (m *StorageConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConfig
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthConfig
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Directory = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.LeaderOnly = bool(v != 0)
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Level", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
-			m.Level = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfig
@@ -1056,16 +6611,17 @@ func (m *StorageConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Level |= StorageLevel(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Metadata = bool(v != 0)
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxEntrySize", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Locks", wireType)
 			}
-			m.MaxEntrySize = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfig
@@ -1075,16 +6631,17 @@ func (m *StorageConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxEntrySize |= uint32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Locks = bool(v != 0)
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SegmentSize", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
 			}
-			m.SegmentSize = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfig
@@ -1094,16 +6651,70 @@ func (m *StorageConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SegmentSize |= uint32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			m.Node = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DedupConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DedupConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DedupConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FlushOnCommit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
 			}
-			var v int
+			m.MaxBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConfig
@@ -1113,12 +6724,47 @@ func (m *StorageConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.MaxBytes |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.FlushOnCommit = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expiration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expiration == nil {
+				m.Expiration = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.Expiration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -1214,6 +6860,80 @@ func (m *CompactionConfig) Unmarshal(dAtA []byte) error {
 			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
 			iNdEx += 4
 			m.FreeMemoryBuffer = float32(math.Float32frombits(v))
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShutdownSnapshotTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ShutdownSnapshotTimeout == nil {
+				m.ShutdownSnapshotTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.ShutdownSnapshotTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EntryThreshold", wireType)
+			}
+			m.EntryThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EntryThreshold |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SizeThreshold", wireType)
+			}
+			m.SizeThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SizeThreshold |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -1241,6 +6961,7 @@ func (m *CompactionConfig) Unmarshal(dAtA []byte) error {
 func skipConfig(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -1272,10 +6993,8 @@ func skipConfig(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -1296,55 +7015,30 @@ func skipConfig(dAtA []byte) (n int, err error) {
 				return 0, ErrInvalidLengthConfig
 			}
 			iNdEx += length
-			if iNdEx < 0 {
-				return 0, ErrInvalidLengthConfig
-			}
-			return iNdEx, nil
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowConfig
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipConfig(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-				if iNdEx < 0 {
-					return 0, ErrInvalidLengthConfig
-				}
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupConfig
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthConfig
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthConfig = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowConfig   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthConfig        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowConfig          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupConfig = fmt.Errorf("proto: unexpected end of group")
 )
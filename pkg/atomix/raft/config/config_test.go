@@ -24,13 +24,189 @@ func TestAdditionalConfigFunctions(t *testing.T) {
 	config := &ProtocolConfig{}
 	assert.Equal(t, defaultElectionTimeout, config.GetElectionTimeoutOrDefault())
 	assert.Equal(t, defaultHeartbeatInterval, config.GetHeartbeatIntervalOrDefault())
+	assert.Equal(t, defaultElectionTimeout*2, config.GetMaxElectionTimeoutOrDefault())
+	assert.Equal(t, defaultElectionTimeout, config.GetTransferTimeoutOrDefault())
+	assert.Equal(t, defaultElectionTimeout, config.GetMinAppliedIndexTimeoutOrDefault())
 
 	electionTimeout := 30 * time.Second
 	heartbeatInterval := 1 * time.Second
+	transferTimeout := 10 * time.Second
+	minAppliedIndexTimeout := 5 * time.Second
 	config = &ProtocolConfig{
-		ElectionTimeout:   &electionTimeout,
-		HeartbeatInterval: &heartbeatInterval,
+		ElectionTimeout:        &electionTimeout,
+		HeartbeatInterval:      &heartbeatInterval,
+		TransferTimeout:        &transferTimeout,
+		MinAppliedIndexTimeout: &minAppliedIndexTimeout,
 	}
 	assert.Equal(t, electionTimeout, config.GetElectionTimeoutOrDefault())
 	assert.Equal(t, heartbeatInterval, config.GetHeartbeatIntervalOrDefault())
+	assert.Equal(t, transferTimeout, config.GetTransferTimeoutOrDefault())
+	assert.Equal(t, minAppliedIndexTimeout, config.GetMinAppliedIndexTimeoutOrDefault())
+}
+
+func TestPriorities(t *testing.T) {
+	config := &ProtocolConfig{}
+	assert.Equal(t, int32(0), config.GetPriority("foo"))
+
+	config = &ProtocolConfig{
+		Priorities: map[string]int32{
+			"foo": 2,
+			"bar": 1,
+		},
+	}
+	assert.Equal(t, int32(2), config.GetPriority("foo"))
+	assert.Equal(t, int32(1), config.GetPriority("bar"))
+	assert.Equal(t, int32(0), config.GetPriority("baz"))
+}
+
+func TestZones(t *testing.T) {
+	config := &ProtocolConfig{}
+	assert.Equal(t, "", config.GetZone("foo"))
+	assert.Equal(t, config.GetHeartbeatIntervalOrDefault(), config.GetLocalZoneWaitOrDefault())
+
+	localZoneWait := 10 * time.Millisecond
+	config = &ProtocolConfig{
+		Zones: map[string]string{
+			"foo": "east",
+			"bar": "west",
+		},
+		ReadIndex: &ReadIndexConfig{
+			PreferLocalZone: true,
+			LocalZoneWait:   &localZoneWait,
+		},
+	}
+	assert.Equal(t, "east", config.GetZone("foo"))
+	assert.Equal(t, "west", config.GetZone("bar"))
+	assert.Equal(t, "", config.GetZone("baz"))
+	assert.Equal(t, localZoneWait, config.GetLocalZoneWaitOrDefault())
+}
+
+func TestWitnesses(t *testing.T) {
+	config := &ProtocolConfig{}
+	assert.False(t, config.IsWitness("foo"))
+	config = &ProtocolConfig{
+		Witnesses: []string{"foo"},
+	}
+	assert.True(t, config.IsWitness("foo"))
+	assert.False(t, config.IsWitness("bar"))
+}
+
+func TestConsistencyProbe(t *testing.T) {
+	config := &ProtocolConfig{}
+	assert.Nil(t, config.GetConsistencyProbe().GetInterval())
+	assert.Equal(t, uint64(defaultProbeRangeSize), config.GetConsistencyProbe().GetRangeSizeOrDefault())
+
+	interval := time.Second
+	config = &ProtocolConfig{
+		ConsistencyProbe: &ConsistencyProbeConfig{
+			Interval:  &interval,
+			RangeSize: 10,
+		},
+	}
+	assert.Equal(t, interval, *config.GetConsistencyProbe().GetInterval())
+	assert.Equal(t, uint64(10), config.GetConsistencyProbe().GetRangeSizeOrDefault())
+}
+
+func TestRetainedSnapshots(t *testing.T) {
+	config := &ProtocolConfig{}
+	assert.Equal(t, 1, config.GetRetainedSnapshotsOrDefault())
+	config.RetainedSnapshots = 3
+	assert.Equal(t, 3, config.GetRetainedSnapshotsOrDefault())
+}
+
+func TestValidateMaxElectionTimeout(t *testing.T) {
+	electionTimeout := 10 * time.Second
+	maxElectionTimeout := 15 * time.Second
+	config := &ProtocolConfig{
+		ElectionTimeout:    &electionTimeout,
+		MaxElectionTimeout: &maxElectionTimeout,
+	}
+	assert.Equal(t, maxElectionTimeout, config.GetMaxElectionTimeoutOrDefault())
+	assert.NoError(t, config.Validate())
+
+	maxElectionTimeout = electionTimeout
+	assert.NoError(t, config.Validate())
+
+	maxElectionTimeout = 5 * time.Second
+	assert.Error(t, config.Validate())
+}
+
+func TestValidateHeartbeatInterval(t *testing.T) {
+	electionTimeout := time.Second
+	heartbeatInterval := 100 * time.Millisecond
+	config := &ProtocolConfig{
+		ElectionTimeout:   &electionTimeout,
+		HeartbeatInterval: &heartbeatInterval,
+	}
+	assert.NoError(t, config.Validate())
+
+	heartbeatInterval = electionTimeout
+	assert.Error(t, config.Validate())
+
+	heartbeatInterval = 2 * time.Second
+	assert.Error(t, config.Validate())
+}
+
+func TestValidateLeaseHeartbeatRatio(t *testing.T) {
+	config := &ProtocolConfig{}
+	assert.NoError(t, config.Validate())
+
+	config.LeaseHeartbeatRatio = .5
+	assert.NoError(t, config.Validate())
+
+	config.LeaseHeartbeatRatio = 1
+	assert.NoError(t, config.Validate())
+
+	config.LeaseHeartbeatRatio = 1.5
+	assert.Error(t, config.Validate())
+
+	config.LeaseHeartbeatRatio = -.5
+	assert.Error(t, config.Validate())
+}
+
+func TestMaxInflightAppends(t *testing.T) {
+	config := &ProtocolConfig{}
+	assert.Equal(t, 1, config.GetMaxInflightAppendsOrDefault())
+
+	config = &ProtocolConfig{
+		MaxInflightAppends: 4,
+	}
+	assert.Equal(t, 4, config.GetMaxInflightAppendsOrDefault())
+}
+
+func TestAdaptiveElectionTimeout(t *testing.T) {
+	config := &ProtocolConfig{}
+	assert.Equal(t, config.GetMaxElectionTimeoutOrDefault(), config.GetMaxAdaptiveElectionTimeoutOrDefault())
+	assert.Equal(t, defaultRoundTripWindow, config.GetAdaptiveElectionTimeout().GetWindowOrDefault())
+
+	electionTimeout := 10 * time.Second
+	maxTimeout := 30 * time.Second
+	config = &ProtocolConfig{
+		ElectionTimeout: &electionTimeout,
+		AdaptiveElectionTimeout: &AdaptiveTimeoutConfig{
+			RttMultiplier: 10,
+			MaxTimeout:    &maxTimeout,
+			Window:        5,
+		},
+	}
+	assert.Equal(t, maxTimeout, config.GetMaxAdaptiveElectionTimeoutOrDefault())
+	assert.Equal(t, 5, config.GetAdaptiveElectionTimeout().GetWindowOrDefault())
+	assert.NoError(t, config.Validate())
+
+	// Verify the max adaptive election timeout may not be less than the election timeout
+	maxTimeout = 5 * time.Second
+	assert.Error(t, config.Validate())
+}
+
+func TestElectionJitter(t *testing.T) {
+	config := &ProtocolConfig{}
+	assert.Equal(t, float32(1), config.GetElectionJitterOrDefault())
+	assert.NoError(t, config.Validate())
+
+	config.ElectionJitter = .5
+	assert.Equal(t, float32(.5), config.GetElectionJitterOrDefault())
+	assert.NoError(t, config.Validate())
+
+	config.ElectionJitter = -.5
+	assert.Error(t, config.Validate())
 }
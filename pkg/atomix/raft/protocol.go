@@ -15,17 +15,23 @@
 package raft
 
 import (
+	"context"
 	"github.com/atomix/go-framework/pkg/atomix/cluster"
 	"github.com/atomix/go-framework/pkg/atomix/node"
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/client"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
 )
 
-// NewProtocol returns a new Raft Protocol instance
-func NewProtocol(config *config.ProtocolConfig) *Protocol {
+// NewProtocol returns a new Raft Protocol instance. The given options are applied to the Raft protocol
+// when the protocol is started.
+func NewProtocol(config *config.ProtocolConfig, opts ...raft.Option) *Protocol {
 	return &Protocol{
 		config: config,
+		opts:   opts,
 	}
 }
 
@@ -33,14 +39,26 @@ func NewProtocol(config *config.ProtocolConfig) *Protocol {
 type Protocol struct {
 	node.Protocol
 	config *config.ProtocolConfig
+	opts   []raft.Option
 	client *client.Client
 	server *Server
 }
 
 // Start starts the Raft protocol
 func (p *Protocol) Start(cluster cluster.Cluster, registry *node.Registry) error {
-	p.client = client.NewClient(cluster, raft.ReadConsistency_SEQUENTIAL)
-	p.server = NewServer(cluster, registry, p.config)
+	if err := p.config.Validate(); err != nil {
+		return err
+	}
+	client, err := client.NewClient(cluster, p.config, raft.ReadConsistency_SEQUENTIAL)
+	if err != nil {
+		return err
+	}
+	server, err := NewServer(cluster, registry, p.config, p.opts...)
+	if err != nil {
+		return err
+	}
+	p.client = client
+	p.server = server
 	go p.server.Start()
 	return p.server.WaitForReady()
 }
@@ -50,6 +68,26 @@ func (p *Protocol) Client() node.Client {
 	return p.client
 }
 
+// Metrics returns the Raft protocol metrics collector
+func (p *Protocol) Metrics() prometheus.Collector {
+	return p.server.raft.Metrics()
+}
+
+// SubmitBatch submits a batch of commands to be appended to the log in order, returning the result of each command
+func (p *Protocol) SubmitBatch(ctx context.Context, values [][]byte) ([]streams.Result, error) {
+	return p.client.SubmitBatch(ctx, values)
+}
+
+// SetReadOnly sets whether the cluster is in read-only mode
+func (p *Protocol) SetReadOnly(ctx context.Context, readOnly bool) error {
+	return p.client.SetReadOnly(ctx, readOnly)
+}
+
+// FreezeElections freezes elections cluster-wide for the given duration. A zero duration lifts the freeze.
+func (p *Protocol) FreezeElections(ctx context.Context, duration time.Duration) error {
+	return p.client.FreezeElections(ctx, duration)
+}
+
 // Stop stops the Raft protocol
 func (p *Protocol) Stop() error {
 	_ = p.client.Close()
@@ -0,0 +1,108 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditRecordType is the type of a state transition recorded in the audit log
+type AuditRecordType string
+
+const (
+	// AuditRecordTerm records a term change
+	AuditRecordTerm AuditRecordType = "Term"
+
+	// AuditRecordVote records a vote cast by the local member
+	AuditRecordVote AuditRecordType = "Vote"
+
+	// AuditRecordRole records a role change
+	AuditRecordRole AuditRecordType = "Role"
+
+	// AuditRecordLeader records a leader change
+	AuditRecordLeader AuditRecordType = "Leader"
+
+	// AuditRecordConfiguration records a membership change
+	AuditRecordConfiguration AuditRecordType = "Configuration"
+)
+
+// AuditRecord is a structured record of a state transition
+type AuditRecord struct {
+	// Time is the time at which the transition occurred
+	Time time.Time `json:"time"`
+	// Member is the local member on which the transition occurred
+	Member MemberID `json:"member"`
+	// Type is the type of the transition
+	Type AuditRecordType `json:"type"`
+	// Term is the term in which the transition occurred
+	Term Term `json:"term"`
+	// Role is the new role for role transitions
+	Role RoleType `json:"role,omitempty"`
+	// Leader is the new leader for leader transitions, or nil if the leader was unset
+	Leader *MemberID `json:"leader,omitempty"`
+	// Candidate is the candidate voted for for vote records
+	Candidate *MemberID `json:"candidate,omitempty"`
+	// Configuration is the new configuration for membership changes
+	Configuration *Configuration `json:"configuration,omitempty"`
+	// Committed indicates whether the configuration is committed for membership changes
+	Committed bool `json:"committed,omitempty"`
+}
+
+// AuditSink receives audit records for state transitions. Records are passed to the sink in the order
+// in which the transitions occur while the Raft state is locked, so sinks should not block.
+type AuditSink interface {
+	// Record records the given audit record
+	Record(record AuditRecord) error
+}
+
+// NewFileAuditSink returns an AuditSink that appends records to the file at the given path as
+// newline-delimited JSON. Each record is synced to disk before Record returns.
+func NewFileAuditSink(path string) (*FileAuditSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileAuditSink{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// FileAuditSink is an AuditSink that appends records to a file as newline-delimited JSON
+type FileAuditSink struct {
+	file    *os.File
+	encoder *json.Encoder
+	mu      sync.Mutex
+}
+
+// Record appends the given record to the file
+func (s *FileAuditSink) Record(record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.encoder.Encode(record); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Close closes the file
+func (s *FileAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,148 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"bufio"
+	"encoding/json"
+	atomix "github.com/atomix/go-framework/pkg/atomix/cluster"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// memoryAuditSink is an AuditSink that stores records in memory
+type memoryAuditSink struct {
+	records []AuditRecord
+}
+
+func (s *memoryAuditSink) Record(record AuditRecord) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func TestRaftAudit(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "bar",
+			},
+		},
+	}
+	roles := map[RoleType]func(Raft) Role{
+		RoleFollower: func(r Raft) Role {
+			return &followerRole{&testRole{}}
+		},
+		RoleLeader: func(r Raft) Role {
+			return &leaderRole{&testRole{}}
+		},
+	}
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, roles, newMemoryMetadataStore())
+	sink := &memoryAuditSink{}
+	raft.SetAuditSink(sink)
+
+	foo := MemberID("foo")
+	bar := MemberID("bar")
+	configuration := &Configuration{
+		Index: 1,
+		Term:  2,
+		Members: []*Member{
+			{MemberID: foo, Type: Member_ACTIVE},
+		},
+	}
+
+	raft.WriteLock()
+	raft.Init()
+	assert.NoError(t, raft.SetTerm(1))
+	assert.NoError(t, raft.SetLastVotedFor(bar))
+	assert.NoError(t, raft.SetLeader(&bar))
+	assert.NoError(t, raft.SetTermAndVote(2, foo))
+	raft.SetRole(RoleLeader)
+	assert.NoError(t, raft.SetLeader(&foo))
+	raft.SetConfiguration(configuration)
+	raft.Commit(1, 1)
+	assert.NoError(t, raft.SetLeader(nil))
+	raft.SetRole(RoleFollower)
+	raft.WriteUnlock()
+
+	expected := []AuditRecord{
+		{Type: AuditRecordRole, Role: RoleFollower},
+		{Type: AuditRecordTerm, Term: 1},
+		{Type: AuditRecordVote, Term: 1, Candidate: &bar},
+		{Type: AuditRecordLeader, Term: 1, Leader: &bar},
+		{Type: AuditRecordTerm, Term: 2},
+		{Type: AuditRecordVote, Term: 2, Candidate: &foo},
+		{Type: AuditRecordRole, Term: 2, Role: RoleLeader},
+		{Type: AuditRecordLeader, Term: 2, Leader: &foo},
+		{Type: AuditRecordConfiguration, Term: 2, Configuration: configuration},
+		{Type: AuditRecordConfiguration, Term: 2, Configuration: configuration, Committed: true},
+		{Type: AuditRecordLeader, Term: 2},
+		{Type: AuditRecordRole, Term: 2, Role: RoleFollower},
+	}
+	assert.Len(t, sink.records, len(expected))
+	for i, record := range sink.records {
+		assert.Equal(t, foo, record.Member)
+		assert.False(t, record.Time.IsZero())
+		record.Member = ""
+		record.Time = expected[i].Time
+		assert.Equal(t, expected[i], record)
+	}
+}
+
+func TestFileAuditSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	leader := MemberID("foo")
+	records := []AuditRecord{
+		{Member: "foo", Type: AuditRecordTerm, Term: 1},
+		{Member: "foo", Type: AuditRecordRole, Term: 1, Role: RoleLeader},
+		{Member: "foo", Type: AuditRecordLeader, Term: 1, Leader: &leader},
+	}
+
+	sink, err := NewFileAuditSink(path)
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Record(records[0]))
+	assert.NoError(t, sink.Close())
+
+	// Verify records are appended to an existing audit log
+	sink, err = NewFileAuditSink(path)
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Record(records[1]))
+	assert.NoError(t, sink.Record(records[2]))
+	assert.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	i := 0
+	for scanner.Scan() {
+		record := AuditRecord{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		assert.Equal(t, records[i], record)
+		i++
+	}
+	assert.Equal(t, len(records), i)
+}
@@ -0,0 +1,52 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import "sync"
+
+// CommandAuthorizer authorizes a command request before the command is appended to the log.
+// The authorizer returns an error describing the reason the command is denied, or nil if the command is allowed.
+type CommandAuthorizer func(request *CommandRequest) error
+
+// newAuthorizerChain returns a new empty command authorizer chain
+func newAuthorizerChain() *authorizerChain {
+	return &authorizerChain{}
+}
+
+// authorizerChain is a chain of command authorizers invoked in the order in which they were added
+type authorizerChain struct {
+	authorizers []CommandAuthorizer
+	mu          sync.RWMutex
+}
+
+// add adds an authorizer to the end of the chain
+func (c *authorizerChain) add(authorizer CommandAuthorizer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.authorizers = append(c.authorizers, authorizer)
+}
+
+// authorize invokes the authorizers in the chain, returning the error of the first authorizer to deny the request
+func (c *authorizerChain) authorize(request *CommandRequest) error {
+	c.mu.RLock()
+	authorizers := c.authorizers
+	c.mu.RUnlock()
+	for _, authorizer := range authorizers {
+		if err := authorizer(request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import "time"
+
+// Clock provides the wall-clock time used by the protocol
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+}
+
+// newSystemClock returns a Clock backed by the system clock
+func newSystemClock() Clock {
+	return systemClock{}
+}
+
+// systemClock is a Clock backed by the system clock
+type systemClock struct{}
+
+func (c systemClock) Now() time.Time {
+	return time.Now()
+}
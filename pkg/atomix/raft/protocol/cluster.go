@@ -37,8 +37,18 @@ type Cluster interface {
 	GetClient(memberID MemberID) (RaftServiceClient, error)
 }
 
+// IsVoter returns whether the member votes in elections and counts towards quorums. Witnesses vote
+// but do not store the log's commands or serve client requests.
+func (m *Member) IsVoter() bool {
+	return m.Type == Member_ACTIVE || m.Type == Member_WITNESS
+}
+
 // NewCluster returns a new Cluster with the given configuration
-func NewCluster(config node.Cluster) Cluster {
+// An error is returned if the configuration contains empty or duplicate member IDs.
+func NewCluster(config node.Cluster) (Cluster, error) {
+	if err := validateCluster(config); err != nil {
+		return nil, err
+	}
 	members := make(map[MemberID]*Member)
 	locations := make(map[MemberID]node.Member)
 	memberIDs := make([]MemberID, 0, len(config.Members))
@@ -58,7 +68,22 @@ func NewCluster(config node.Cluster) Cluster {
 		locations: locations,
 		conns:     make(map[MemberID]*grpc.ClientConn),
 		clients:   make(map[MemberID]RaftServiceClient),
+	}, nil
+}
+
+// validateCluster verifies that each member in the cluster configuration has a unique, non-empty ID
+func validateCluster(config node.Cluster) error {
+	memberIDs := make(map[string]string)
+	for id, member := range config.Members {
+		if id == "" || member.ID == "" {
+			return fmt.Errorf("cluster member ID cannot be empty")
+		}
+		if other, ok := memberIDs[member.ID]; ok {
+			return fmt.Errorf("duplicate cluster member ID %s configured for %s and %s", member.ID, other, id)
+		}
+		memberIDs[member.ID] = id
 	}
+	return nil
 }
 
 // Cluster manages the Raft cluster configuration
@@ -25,7 +25,7 @@ var _ = time.Kitchen
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Member_Type int32
 
@@ -34,6 +34,7 @@ const (
 	Member_PASSIVE    Member_Type = 1
 	Member_PROMOTABLE Member_Type = 2
 	Member_ACTIVE     Member_Type = 3
+	Member_WITNESS    Member_Type = 4
 )
 
 var Member_Type_name = map[int32]string{
@@ -41,6 +42,7 @@ var Member_Type_name = map[int32]string{
 	1: "PASSIVE",
 	2: "PROMOTABLE",
 	3: "ACTIVE",
+	4: "WITNESS",
 }
 
 var Member_Type_value = map[string]int32{
@@ -48,6 +50,7 @@ var Member_Type_value = map[string]int32{
 	"PASSIVE":    1,
 	"PROMOTABLE": 2,
 	"ACTIVE":     3,
+	"WITNESS":    4,
 }
 
 func (x Member_Type) String() string {
@@ -123,31 +126,33 @@ func init() {
 	proto.RegisterType((*Member)(nil), "atomix.raft.protocol.Member")
 }
 
-func init() { proto.RegisterFile("atomix/raft/protocol/cluster.proto", fileDescriptor_3fc94cd882917355) }
+func init() {
+	proto.RegisterFile("atomix/raft/protocol/cluster.proto", fileDescriptor_3fc94cd882917355)
+}
 
 var fileDescriptor_3fc94cd882917355 = []byte{
-	// 323 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x8f, 0xb1, 0x6a, 0xc2, 0x40,
-	0x18, 0xc7, 0xf3, 0xa9, 0x68, 0x3c, 0x8b, 0xc8, 0xe1, 0x10, 0x1c, 0x2e, 0x56, 0x3a, 0x38, 0x5d,
-	0xc0, 0xe2, 0xd8, 0x82, 0x69, 0x1d, 0x02, 0xb5, 0x4a, 0x0c, 0x5d, 0x4b, 0x34, 0x67, 0x10, 0x0c,
-	0x17, 0xe2, 0x09, 0xf5, 0x2d, 0x7c, 0x8c, 0x3e, 0x42, 0x1f, 0xc1, 0xd1, 0xb1, 0x93, 0x6d, 0xcf,
-	0x67, 0x28, 0x94, 0x4e, 0x25, 0xb9, 0x06, 0x3a, 0x74, 0xfb, 0xdd, 0x77, 0xbf, 0xff, 0xc7, 0xf7,
-	0x47, 0x1d, 0x5f, 0xf0, 0x68, 0xf9, 0x64, 0x25, 0xfe, 0x42, 0x58, 0x71, 0xc2, 0x05, 0x9f, 0xf3,
-	0x95, 0x35, 0x5f, 0x6d, 0xd6, 0x82, 0x25, 0x34, 0x1b, 0xe0, 0xa6, 0x72, 0x68, 0xea, 0xd0, 0xdc,
-	0x69, 0x99, 0x21, 0xe7, 0xe1, 0x8a, 0xa9, 0xd0, 0x6c, 0xb3, 0xb0, 0xc4, 0x32, 0x62, 0x6b, 0xe1,
-	0x47, 0xb1, 0x72, 0x5a, 0xcd, 0x90, 0x87, 0x3c, 0x43, 0x2b, 0x25, 0x35, 0xed, 0x7c, 0x02, 0x2a,
-	0x8f, 0x58, 0x34, 0x63, 0x09, 0xee, 0xa3, 0x6a, 0x94, 0xd1, 0xe3, 0x32, 0x30, 0xa0, 0x0d, 0xdd,
-	0xaa, 0x6d, 0xc8, 0xa3, 0xa9, 0xab, 0x6f, 0xe7, 0xf6, 0xfb, 0x0f, 0xbb, 0xba, 0x52, 0x9d, 0x00,
-	0xf7, 0x51, 0x49, 0x6c, 0x63, 0x66, 0x14, 0xda, 0xd0, 0xad, 0xf7, 0xce, 0xe9, 0x7f, 0xd7, 0x51,
-	0x95, 0xa3, 0xde, 0x36, 0x66, 0x6e, 0xa6, 0xe3, 0x6b, 0x54, 0xd9, 0xc4, 0x81, 0x2f, 0x58, 0x60,
-	0x14, 0xdb, 0xd0, 0xad, 0xf5, 0x5a, 0x54, 0x35, 0xa0, 0x79, 0x03, 0xea, 0xe5, 0x0d, 0x6c, 0x7d,
-	0x7f, 0x34, 0xb5, 0xdd, 0x9b, 0x09, 0x6e, 0x1e, 0xea, 0x5c, 0xa1, 0x52, 0xba, 0x0d, 0x9f, 0x21,
-	0xdd, 0xb9, 0x1f, 0xdc, 0x78, 0xce, 0xc3, 0xb0, 0xa1, 0xe1, 0x1a, 0xaa, 0x4c, 0x06, 0xd3, 0x69,
-	0xfa, 0x00, 0x5c, 0x47, 0x68, 0xe2, 0x8e, 0x47, 0x63, 0x6f, 0x60, 0xdf, 0x0d, 0x1b, 0x05, 0x8c,
-	0x50, 0xf9, 0x57, 0x2c, 0xda, 0x17, 0x5f, 0x1f, 0x04, 0x9e, 0x25, 0x81, 0x17, 0x49, 0x60, 0x2f,
-	0x09, 0x1c, 0x24, 0x81, 0x77, 0x49, 0x60, 0x77, 0x22, 0xda, 0xe1, 0x44, 0xb4, 0xd7, 0x13, 0xd1,
-	0x66, 0xe5, 0xec, 0x96, 0xcb, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xb8, 0x42, 0xf8, 0xfe, 0x97,
-	0x01, 0x00, 0x00,
+	// 330 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x8f, 0x3f, 0x6b, 0xc2, 0x40,
+	0x18, 0xc6, 0x73, 0x2a, 0x1a, 0x5f, 0x8b, 0xc8, 0xe1, 0x10, 0x1c, 0x2e, 0x56, 0x3a, 0x38, 0x5d,
+	0xc0, 0xe2, 0x5a, 0x30, 0xad, 0x43, 0x4a, 0xfd, 0x43, 0x12, 0xda, 0xb1, 0x44, 0x73, 0x06, 0xc1,
+	0x70, 0x21, 0x9e, 0x50, 0xd7, 0x7e, 0x02, 0x3f, 0x46, 0x3f, 0x42, 0x3f, 0x82, 0xa3, 0x63, 0x27,
+	0xdb, 0xc6, 0x2f, 0x51, 0x3a, 0x95, 0xe4, 0x1a, 0xe8, 0xd0, 0xed, 0x77, 0xef, 0xfd, 0x9e, 0x97,
+	0xf7, 0x81, 0x8e, 0x27, 0x78, 0xb8, 0x7c, 0x32, 0x62, 0x6f, 0x21, 0x8c, 0x28, 0xe6, 0x82, 0xcf,
+	0xf9, 0xca, 0x98, 0xaf, 0x36, 0x6b, 0xc1, 0x62, 0x9a, 0x0d, 0x70, 0x53, 0x3a, 0x34, 0x75, 0x68,
+	0xee, 0xb4, 0xf4, 0x80, 0xf3, 0x60, 0xc5, 0x64, 0x68, 0xb6, 0x59, 0x18, 0x62, 0x19, 0xb2, 0xb5,
+	0xf0, 0xc2, 0x48, 0x3a, 0xad, 0x66, 0xc0, 0x03, 0x9e, 0xa1, 0x91, 0x92, 0x9c, 0x76, 0x9e, 0x0b,
+	0x50, 0x1e, 0xb1, 0x70, 0xc6, 0x62, 0xdc, 0x87, 0x6a, 0x98, 0xd1, 0xe3, 0xd2, 0xd7, 0x50, 0x1b,
+	0x75, 0xab, 0xa6, 0x96, 0x1c, 0x75, 0x55, 0x7e, 0x5b, 0x37, 0xdf, 0x7f, 0xd8, 0x56, 0xa5, 0x6a,
+	0xf9, 0xb8, 0x0f, 0x25, 0xb1, 0x8d, 0x98, 0x56, 0x68, 0xa3, 0x6e, 0xbd, 0x77, 0x4e, 0xff, 0xbb,
+	0x8e, 0xca, 0x1c, 0x75, 0xb7, 0x11, 0xb3, 0x33, 0x1d, 0x5f, 0x41, 0x65, 0x13, 0xf9, 0x9e, 0x60,
+	0xbe, 0x56, 0x6c, 0xa3, 0x6e, 0xad, 0xd7, 0xa2, 0xb2, 0x01, 0xcd, 0x1b, 0x50, 0x37, 0x6f, 0x60,
+	0xaa, 0xfb, 0xa3, 0xae, 0xec, 0xde, 0x75, 0x64, 0xe7, 0xa1, 0xce, 0x2d, 0x94, 0xd2, 0x6d, 0xf8,
+	0x0c, 0x54, 0x6b, 0x3c, 0xb8, 0x76, 0xad, 0xfb, 0x61, 0x43, 0xc1, 0x35, 0xa8, 0x4c, 0x07, 0x8e,
+	0x93, 0x3e, 0x10, 0xae, 0x03, 0x4c, 0xed, 0xc9, 0x68, 0xe2, 0x0e, 0xcc, 0xbb, 0x61, 0xa3, 0x80,
+	0x01, 0xca, 0xbf, 0x62, 0x31, 0x15, 0x1f, 0x2c, 0x77, 0x3c, 0x74, 0x9c, 0x46, 0xc9, 0xbc, 0xf8,
+	0xfa, 0x24, 0xe8, 0x25, 0x21, 0xe8, 0x35, 0x21, 0x68, 0x9f, 0x10, 0x74, 0x48, 0x08, 0xfa, 0x48,
+	0x08, 0xda, 0x9d, 0x88, 0x72, 0x38, 0x11, 0xe5, 0xed, 0x44, 0x94, 0x59, 0x39, 0x3b, 0xec, 0xf2,
+	0x67, 0x00, 0x0b, 0x77, 0xb4, 0x06, 0xa4, 0x01, 0x00, 0x00,
 }
 
 func (this *Member) Equal(that interface{}) bool {
@@ -237,7 +242,7 @@ func encodeVarintCluster(dAtA []byte, offset int, v uint64) int {
 func NewPopulatedMember(r randyCluster, easy bool) *Member {
 	this := &Member{}
 	this.MemberID = MemberID(randStringCluster(r))
-	this.Type = Member_Type([]int32{0, 1, 2, 3}[r.Intn(4)])
+	this.Type = Member_Type([]int32{0, 1, 2, 3, 4}[r.Intn(5)])
 	v1 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
 	this.Updated = *v1
 	if !easy && r.Intn(10) != 0 {
@@ -481,6 +486,7 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 func skipCluster(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -512,10 +518,8 @@ func skipCluster(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -536,55 +540,30 @@ func skipCluster(dAtA []byte) (n int, err error) {
 				return 0, ErrInvalidLengthCluster
 			}
 			iNdEx += length
-			if iNdEx < 0 {
-				return 0, ErrInvalidLengthCluster
-			}
-			return iNdEx, nil
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowCluster
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipCluster(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-				if iNdEx < 0 {
-					return 0, ErrInvalidLengthCluster
-				}
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupCluster
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthCluster
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthCluster = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowCluster   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthCluster        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowCluster          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupCluster = fmt.Errorf("proto: unexpected end of group")
 )
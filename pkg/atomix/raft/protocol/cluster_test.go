@@ -0,0 +1,89 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	atomix "github.com/atomix/go-framework/pkg/atomix/cluster"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// mustNewCluster returns a new Cluster for the given configuration, panicking if the configuration is invalid
+func mustNewCluster(config atomix.Cluster) Cluster {
+	cluster, err := NewCluster(config)
+	if err != nil {
+		panic(err)
+	}
+	return cluster
+}
+
+func TestNewCluster(t *testing.T) {
+	cluster, err := NewCluster(atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "bar",
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, MemberID("foo"), cluster.Member())
+	assert.Len(t, cluster.Members(), 2)
+}
+
+func TestNewClusterDuplicateMember(t *testing.T) {
+	cluster, err := NewCluster(atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "foo",
+			},
+		},
+	})
+	assert.Error(t, err)
+	assert.Nil(t, cluster)
+}
+
+func TestNewClusterEmptyMember(t *testing.T) {
+	_, err := NewCluster(atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"": {
+				ID: "",
+			},
+		},
+	})
+	assert.Error(t, err)
+
+	_, err = NewCluster(atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {},
+		},
+	})
+	assert.Error(t, err)
+}
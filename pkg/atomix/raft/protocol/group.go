@@ -0,0 +1,228 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"sync"
+)
+
+// GroupID is the identifier of a Raft group. A single process may host many independent Raft groups, e.g. to
+// partition a keyspace, each with its own log, state machine, roles, and election timers. The zero value
+// identifies the default group.
+type GroupID string
+
+// groupMetadataKey is the gRPC metadata key identifying the group to which a request is routed
+const groupMetadataKey = "raft-group"
+
+// withGroup returns a context that routes requests sent with it to the given group
+func withGroup(ctx context.Context, group GroupID) context.Context {
+	if group == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, groupMetadataKey, string(group))
+}
+
+// getGroup returns the group to which the request received with the given context is routed
+func getGroup(ctx context.Context) GroupID {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(groupMetadataKey); len(values) > 0 {
+		return GroupID(values[0])
+	}
+	return ""
+}
+
+// NewRouter returns a new Router with no registered groups
+func NewRouter() *Router {
+	return &Router{
+		servers: make(map[GroupID]RaftServiceServer),
+	}
+}
+
+// Router is a RaftServiceServer that routes each request to the Server registered for the request's group,
+// allowing many Raft groups to share a single gRPC server. Requests that do not identify a group are routed
+// to the default group.
+type Router struct {
+	servers map[GroupID]RaftServiceServer
+	mu      sync.RWMutex
+}
+
+// Register registers the Server to which requests for the given group are routed
+func (r *Router) Register(group GroupID, server Server) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.servers[group] = NewServer(server)
+}
+
+// Unregister removes the Server registered for the given group
+func (r *Router) Unregister(group GroupID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.servers, group)
+}
+
+// route returns the server registered for the group of the request received with the given context
+func (r *Router) route(ctx context.Context) (RaftServiceServer, error) {
+	group := getGroup(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	server, ok := r.servers[group]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown Raft group '%s'", group)
+	}
+	return server, nil
+}
+
+func (r *Router) Join(ctx context.Context, request *JoinRequest) (*JoinResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Join(ctx, request)
+}
+
+func (r *Router) Leave(ctx context.Context, request *LeaveRequest) (*LeaveResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Leave(ctx, request)
+}
+
+func (r *Router) Configure(ctx context.Context, request *ConfigureRequest) (*ConfigureResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Configure(ctx, request)
+}
+
+func (r *Router) Reconfigure(ctx context.Context, request *ReconfigureRequest) (*ReconfigureResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Reconfigure(ctx, request)
+}
+
+func (r *Router) SetReadOnly(ctx context.Context, request *SetReadOnlyRequest) (*SetReadOnlyResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.SetReadOnly(ctx, request)
+}
+
+func (r *Router) FreezeElections(ctx context.Context, request *FreezeElectionsRequest) (*FreezeElectionsResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.FreezeElections(ctx, request)
+}
+
+func (r *Router) Poll(ctx context.Context, request *PollRequest) (*PollResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Poll(ctx, request)
+}
+
+func (r *Router) Vote(ctx context.Context, request *VoteRequest) (*VoteResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Vote(ctx, request)
+}
+
+func (r *Router) Transfer(ctx context.Context, request *TransferRequest) (*TransferResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Transfer(ctx, request)
+}
+
+func (r *Router) ConfirmLeadership(ctx context.Context, request *ConfirmLeadershipRequest) (*ConfirmLeadershipResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.ConfirmLeadership(ctx, request)
+}
+
+func (r *Router) Hash(ctx context.Context, request *HashRequest) (*HashResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Hash(ctx, request)
+}
+
+func (r *Router) Append(ctx context.Context, request *AppendRequest) (*AppendResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.Append(ctx, request)
+}
+
+func (r *Router) Install(stream RaftService_InstallServer) error {
+	server, err := r.route(stream.Context())
+	if err != nil {
+		return err
+	}
+	return server.Install(stream)
+}
+
+func (r *Router) Command(request *CommandRequest, stream RaftService_CommandServer) error {
+	server, err := r.route(stream.Context())
+	if err != nil {
+		return err
+	}
+	return server.Command(request, stream)
+}
+
+func (r *Router) Progress(request *ProgressRequest, stream RaftService_ProgressServer) error {
+	server, err := r.route(stream.Context())
+	if err != nil {
+		return err
+	}
+	return server.Progress(request, stream)
+}
+
+func (r *Router) CommandBatch(ctx context.Context, request *CommandBatchRequest) (*CommandBatchResponse, error) {
+	server, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return server.CommandBatch(ctx, request)
+}
+
+func (r *Router) Query(request *QueryRequest, stream RaftService_QueryServer) error {
+	server, err := r.route(stream.Context())
+	if err != nil {
+		return err
+	}
+	return server.Query(request, stream)
+}
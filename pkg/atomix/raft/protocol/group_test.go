@@ -0,0 +1,72 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// testGroupServer is a Server that responds to hash requests with the hash of its group
+type testGroupServer struct {
+	Server
+	hash uint64
+}
+
+func (s *testGroupServer) Hash(ctx context.Context, request *HashRequest) (*HashResponse, error) {
+	return &HashResponse{
+		Status: ResponseStatus_OK,
+		Hash:   s.hash,
+	}, nil
+}
+
+func TestRouter(t *testing.T) {
+	router := NewRouter()
+	router.Register("", &testGroupServer{hash: 1})
+	router.Register("foo", &testGroupServer{hash: 2})
+	router.Register("bar", &testGroupServer{hash: 3})
+
+	// hash sends a hash request to the router as a client of the given group would
+	hash := func(group GroupID) (*HashResponse, error) {
+		md, _ := metadata.FromOutgoingContext(withGroup(context.Background(), group))
+		return router.Hash(metadata.NewIncomingContext(context.Background(), md), &HashRequest{})
+	}
+
+	// Verify requests are routed to the server for the request's group
+	response, err := hash("")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), response.Hash)
+	response, err = hash("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), response.Hash)
+	response, err = hash("bar")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), response.Hash)
+
+	// Verify requests without group metadata are routed to the default group
+	response, err = router.Hash(context.Background(), &HashRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), response.Hash)
+
+	// Verify requests for an unknown group are rejected
+	router.Unregister("foo")
+	_, err = hash("foo")
+	assert.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,72 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"time"
+)
+
+// Health is a point-in-time view of the health of a Raft node, e.g. for readiness probes
+type Health struct {
+	// Status is the status of the node
+	Status Status
+	// Role is the current role of the node
+	Role RoleType
+	// Quorum indicates whether the node is part of a quorum. The leader is part of a quorum if a quorum
+	// of voters acknowledged it within the election timeout, and other members are part of a quorum if
+	// they received a successful heartbeat from a known leader within the election timeout.
+	Quorum bool
+	// CommitIndex is the local commit index
+	CommitIndex Index
+	// LeaderCommitIndex is the leader's commit index as of the last successful heartbeat
+	LeaderCommitIndex Index
+	// CommitLag is the number of entries by which the local commit index trails the leader's commit index
+	CommitLag Index
+	// LastHeartbeat is the time of the last successful heartbeat, or the zero time if no heartbeat succeeded
+	LastHeartbeat time.Time
+	// SinceHeartbeat is the time elapsed since the last successful heartbeat
+	SinceHeartbeat time.Duration
+}
+
+func (r *raft) Heartbeat(leaderCommitIndex Index) {
+	r.progress.heartbeat(leaderCommitIndex)
+}
+
+func (r *raft) Health() Health {
+	health := Health{
+		Status:      r.status,
+		Role:        r.Role(),
+		CommitIndex: r.commitIndex,
+	}
+
+	heartbeatTime, leaderCommitIndex := r.progress.getHeartbeat()
+	if health.Role == RoleLeader {
+		// A leader with no other voters is a quorum of itself and its commit index is the leader's commit index
+		leaderCommitIndex = r.commitIndex
+		if len(r.VotingMembers()) <= 1 {
+			heartbeatTime = time.Now()
+		}
+	}
+	if leaderCommitIndex > health.CommitIndex {
+		health.CommitLag = leaderCommitIndex - health.CommitIndex
+	}
+	health.LeaderCommitIndex = leaderCommitIndex
+	health.LastHeartbeat = heartbeatTime
+	if !heartbeatTime.IsZero() {
+		health.SinceHeartbeat = time.Since(heartbeatTime)
+		health.Quorum = (health.Role == RoleLeader || r.leader != nil) && health.SinceHeartbeat <= r.ConfiguredElectionTimeout()
+	}
+	return health
+}
@@ -0,0 +1,75 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	atomix "github.com/atomix/go-framework/pkg/atomix/cluster"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRaftHealth(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "bar",
+			},
+		},
+	}
+	electionTimeout := 100 * time.Millisecond
+	config := &config.ProtocolConfig{
+		ElectionTimeout: &electionTimeout,
+	}
+	raft := newRaft(mustNewCluster(cluster), config, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+
+	// Verify the node is not part of a quorum before it has received a heartbeat
+	health := raft.Health()
+	assert.False(t, health.Quorum)
+	assert.True(t, health.LastHeartbeat.IsZero())
+	assert.Equal(t, time.Duration(0), health.SinceHeartbeat)
+
+	// Verify the commit lag is computed from the leader's commit index in the last heartbeat
+	assert.NoError(t, raft.SetTerm(1))
+	leader := MemberID("bar")
+	assert.NoError(t, raft.SetLeader(&leader))
+	raft.Commit(4, 4)
+	raft.Heartbeat(10)
+	health = raft.Health()
+	assert.True(t, health.Quorum)
+	assert.Equal(t, Index(4), health.CommitIndex)
+	assert.Equal(t, Index(10), health.LeaderCommitIndex)
+	assert.Equal(t, Index(6), health.CommitLag)
+	assert.False(t, health.LastHeartbeat.IsZero())
+	assert.True(t, health.SinceHeartbeat < electionTimeout)
+
+	// Verify a stale leader commit index does not regress the leader's commit index
+	raft.Heartbeat(8)
+	raft.Commit(10, 10)
+	health = raft.Health()
+	assert.Equal(t, Index(10), health.LeaderCommitIndex)
+	assert.Equal(t, Index(0), health.CommitLag)
+
+	// Verify the node leaves the quorum once no heartbeat is received within the election timeout
+	time.Sleep(2 * electionTimeout)
+	health = raft.Health()
+	assert.False(t, health.Quorum)
+	assert.True(t, health.SinceHeartbeat >= 2*electionTimeout)
+}
@@ -26,7 +26,7 @@ var _ = time.Kitchen
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // Raft log entry
 type LogEntry struct {
@@ -37,6 +37,9 @@ type LogEntry struct {
 	//	*LogEntry_Configuration
 	//	*LogEntry_Command
 	//	*LogEntry_Query
+	//	*LogEntry_ReadOnly
+	//	*LogEntry_Freeze
+	//	*LogEntry_Timing
 	Entry isLogEntry_Entry `protobuf_oneof:"entry"`
 }
 
@@ -81,22 +84,34 @@ type isLogEntry_Entry interface {
 }
 
 type LogEntry_Initialize struct {
-	Initialize *InitializeEntry `protobuf:"bytes,3,opt,name=initialize,proto3,oneof"`
+	Initialize *InitializeEntry `protobuf:"bytes,3,opt,name=initialize,proto3,oneof" json:"initialize,omitempty"`
 }
 type LogEntry_Configuration struct {
-	Configuration *ConfigurationEntry `protobuf:"bytes,4,opt,name=configuration,proto3,oneof"`
+	Configuration *ConfigurationEntry `protobuf:"bytes,4,opt,name=configuration,proto3,oneof" json:"configuration,omitempty"`
 }
 type LogEntry_Command struct {
-	Command *CommandEntry `protobuf:"bytes,5,opt,name=command,proto3,oneof"`
+	Command *CommandEntry `protobuf:"bytes,5,opt,name=command,proto3,oneof" json:"command,omitempty"`
 }
 type LogEntry_Query struct {
-	Query *QueryEntry `protobuf:"bytes,6,opt,name=query,proto3,oneof"`
+	Query *QueryEntry `protobuf:"bytes,6,opt,name=query,proto3,oneof" json:"query,omitempty"`
+}
+type LogEntry_ReadOnly struct {
+	ReadOnly *ReadOnlyEntry `protobuf:"bytes,7,opt,name=read_only,json=readOnly,proto3,oneof" json:"read_only,omitempty"`
+}
+type LogEntry_Freeze struct {
+	Freeze *FreezeEntry `protobuf:"bytes,8,opt,name=freeze,proto3,oneof" json:"freeze,omitempty"`
+}
+type LogEntry_Timing struct {
+	Timing *TimingEntry `protobuf:"bytes,9,opt,name=timing,proto3,oneof" json:"timing,omitempty"`
 }
 
 func (*LogEntry_Initialize) isLogEntry_Entry()    {}
 func (*LogEntry_Configuration) isLogEntry_Entry() {}
 func (*LogEntry_Command) isLogEntry_Entry()       {}
 func (*LogEntry_Query) isLogEntry_Entry()         {}
+func (*LogEntry_ReadOnly) isLogEntry_Entry()      {}
+func (*LogEntry_Freeze) isLogEntry_Entry()        {}
+func (*LogEntry_Timing) isLogEntry_Entry()        {}
 
 func (m *LogEntry) GetEntry() isLogEntry_Entry {
 	if m != nil {
@@ -147,116 +162,38 @@ func (m *LogEntry) GetQuery() *QueryEntry {
 	return nil
 }
 
-// XXX_OneofFuncs is for the internal use of the proto package.
-func (*LogEntry) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
-	return _LogEntry_OneofMarshaler, _LogEntry_OneofUnmarshaler, _LogEntry_OneofSizer, []interface{}{
-		(*LogEntry_Initialize)(nil),
-		(*LogEntry_Configuration)(nil),
-		(*LogEntry_Command)(nil),
-		(*LogEntry_Query)(nil),
+func (m *LogEntry) GetReadOnly() *ReadOnlyEntry {
+	if x, ok := m.GetEntry().(*LogEntry_ReadOnly); ok {
+		return x.ReadOnly
 	}
+	return nil
 }
 
-func _LogEntry_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
-	m := msg.(*LogEntry)
-	// entry
-	switch x := m.Entry.(type) {
-	case *LogEntry_Initialize:
-		_ = b.EncodeVarint(3<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Initialize); err != nil {
-			return err
-		}
-	case *LogEntry_Configuration:
-		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Configuration); err != nil {
-			return err
-		}
-	case *LogEntry_Command:
-		_ = b.EncodeVarint(5<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Command); err != nil {
-			return err
-		}
-	case *LogEntry_Query:
-		_ = b.EncodeVarint(6<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Query); err != nil {
-			return err
-		}
-	case nil:
-	default:
-		return fmt.Errorf("LogEntry.Entry has unexpected type %T", x)
+func (m *LogEntry) GetFreeze() *FreezeEntry {
+	if x, ok := m.GetEntry().(*LogEntry_Freeze); ok {
+		return x.Freeze
 	}
 	return nil
 }
 
-func _LogEntry_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
-	m := msg.(*LogEntry)
-	switch tag {
-	case 3: // entry.initialize
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(InitializeEntry)
-		err := b.DecodeMessage(msg)
-		m.Entry = &LogEntry_Initialize{msg}
-		return true, err
-	case 4: // entry.configuration
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ConfigurationEntry)
-		err := b.DecodeMessage(msg)
-		m.Entry = &LogEntry_Configuration{msg}
-		return true, err
-	case 5: // entry.command
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(CommandEntry)
-		err := b.DecodeMessage(msg)
-		m.Entry = &LogEntry_Command{msg}
-		return true, err
-	case 6: // entry.query
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(QueryEntry)
-		err := b.DecodeMessage(msg)
-		m.Entry = &LogEntry_Query{msg}
-		return true, err
-	default:
-		return false, nil
-	}
-}
-
-func _LogEntry_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*LogEntry)
-	// entry
-	switch x := m.Entry.(type) {
-	case *LogEntry_Initialize:
-		s := proto.Size(x.Initialize)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *LogEntry_Configuration:
-		s := proto.Size(x.Configuration)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *LogEntry_Command:
-		s := proto.Size(x.Command)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *LogEntry_Query:
-		s := proto.Size(x.Query)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case nil:
-	default:
-		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+func (m *LogEntry) GetTiming() *TimingEntry {
+	if x, ok := m.GetEntry().(*LogEntry_Timing); ok {
+		return x.Timing
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*LogEntry) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*LogEntry_Initialize)(nil),
+		(*LogEntry_Configuration)(nil),
+		(*LogEntry_Command)(nil),
+		(*LogEntry_Query)(nil),
+		(*LogEntry_ReadOnly)(nil),
+		(*LogEntry_Freeze)(nil),
+		(*LogEntry_Timing)(nil),
 	}
-	return n
 }
 
 type InitializeEntry struct {
@@ -296,7 +233,8 @@ func (m *InitializeEntry) XXX_DiscardUnknown() {
 var xxx_messageInfo_InitializeEntry proto.InternalMessageInfo
 
 type ConfigurationEntry struct {
-	Members []*Member `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	Members    []*Member `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	OldMembers []*Member `protobuf:"bytes,2,rep,name=old_members,json=oldMembers,proto3" json:"old_members,omitempty"`
 }
 
 func (m *ConfigurationEntry) Reset()         { *m = ConfigurationEntry{} }
@@ -339,6 +277,13 @@ func (m *ConfigurationEntry) GetMembers() []*Member {
 	return nil
 }
 
+func (m *ConfigurationEntry) GetOldMembers() []*Member {
+	if m != nil {
+		return m.OldMembers
+	}
+	return nil
+}
+
 type CommandEntry struct {
 	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
 }
@@ -427,44 +372,199 @@ func (m *QueryEntry) GetValue() []byte {
 	return nil
 }
 
+type ReadOnlyEntry struct {
+	ReadOnly bool `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+}
+
+func (m *ReadOnlyEntry) Reset()         { *m = ReadOnlyEntry{} }
+func (m *ReadOnlyEntry) String() string { return proto.CompactTextString(m) }
+func (*ReadOnlyEntry) ProtoMessage()    {}
+func (*ReadOnlyEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_169d8cb0b7cb7546, []int{5}
+}
+func (m *ReadOnlyEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReadOnlyEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReadOnlyEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReadOnlyEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadOnlyEntry.Merge(m, src)
+}
+func (m *ReadOnlyEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReadOnlyEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadOnlyEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadOnlyEntry proto.InternalMessageInfo
+
+func (m *ReadOnlyEntry) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+type FreezeEntry struct {
+	Duration time.Duration `protobuf:"bytes,1,opt,name=duration,proto3,stdduration" json:"duration"`
+}
+
+func (m *FreezeEntry) Reset()         { *m = FreezeEntry{} }
+func (m *FreezeEntry) String() string { return proto.CompactTextString(m) }
+func (*FreezeEntry) ProtoMessage()    {}
+func (*FreezeEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_169d8cb0b7cb7546, []int{6}
+}
+func (m *FreezeEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FreezeEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FreezeEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FreezeEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FreezeEntry.Merge(m, src)
+}
+func (m *FreezeEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *FreezeEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_FreezeEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FreezeEntry proto.InternalMessageInfo
+
+func (m *FreezeEntry) GetDuration() time.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+type TimingEntry struct {
+	ElectionTimeout   time.Duration `protobuf:"bytes,1,opt,name=election_timeout,json=electionTimeout,proto3,stdduration" json:"election_timeout"`
+	HeartbeatInterval time.Duration `protobuf:"bytes,2,opt,name=heartbeat_interval,json=heartbeatInterval,proto3,stdduration" json:"heartbeat_interval"`
+}
+
+func (m *TimingEntry) Reset()         { *m = TimingEntry{} }
+func (m *TimingEntry) String() string { return proto.CompactTextString(m) }
+func (*TimingEntry) ProtoMessage()    {}
+func (*TimingEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_169d8cb0b7cb7546, []int{7}
+}
+func (m *TimingEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimingEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimingEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TimingEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimingEntry.Merge(m, src)
+}
+func (m *TimingEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimingEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimingEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimingEntry proto.InternalMessageInfo
+
+func (m *TimingEntry) GetElectionTimeout() time.Duration {
+	if m != nil {
+		return m.ElectionTimeout
+	}
+	return 0
+}
+
+func (m *TimingEntry) GetHeartbeatInterval() time.Duration {
+	if m != nil {
+		return m.HeartbeatInterval
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*LogEntry)(nil), "atomix.raft.protocol.LogEntry")
 	proto.RegisterType((*InitializeEntry)(nil), "atomix.raft.protocol.InitializeEntry")
 	proto.RegisterType((*ConfigurationEntry)(nil), "atomix.raft.protocol.ConfigurationEntry")
 	proto.RegisterType((*CommandEntry)(nil), "atomix.raft.protocol.CommandEntry")
 	proto.RegisterType((*QueryEntry)(nil), "atomix.raft.protocol.QueryEntry")
+	proto.RegisterType((*ReadOnlyEntry)(nil), "atomix.raft.protocol.ReadOnlyEntry")
+	proto.RegisterType((*FreezeEntry)(nil), "atomix.raft.protocol.FreezeEntry")
+	proto.RegisterType((*TimingEntry)(nil), "atomix.raft.protocol.TimingEntry")
 }
 
 func init() { proto.RegisterFile("atomix/raft/protocol/log.proto", fileDescriptor_169d8cb0b7cb7546) }
 
 var fileDescriptor_169d8cb0b7cb7546 = []byte{
-	// 413 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xc1, 0x8a, 0xd4, 0x30,
-	0x18, 0xc7, 0x1b, 0xa7, 0xdd, 0x19, 0xbf, 0x5d, 0x11, 0xc3, 0x1c, 0xca, 0xb0, 0xa4, 0x25, 0xac,
-	0xd0, 0x53, 0x0a, 0x2b, 0x88, 0x27, 0x0f, 0x15, 0x51, 0x61, 0x05, 0x2d, 0xfb, 0x02, 0x9d, 0x9a,
-	0x29, 0x81, 0xa6, 0x59, 0x33, 0xa9, 0xb8, 0x3e, 0x82, 0xa7, 0x7d, 0x0c, 0x1f, 0xc1, 0x47, 0xd8,
-	0xe3, 0x1e, 0x3d, 0xad, 0xda, 0x79, 0x09, 0xf1, 0x24, 0x4d, 0xcd, 0xce, 0xa8, 0xf5, 0x96, 0x7c,
-	0xdf, 0xef, 0xff, 0xfb, 0xbe, 0x10, 0x20, 0x85, 0x51, 0x52, 0xbc, 0x4f, 0x75, 0xb1, 0x32, 0xe9,
-	0x99, 0x56, 0x46, 0x95, 0xaa, 0x4e, 0x6b, 0x55, 0x31, 0x7b, 0xc1, 0xf3, 0xa1, 0xcf, 0xfa, 0x3e,
-	0x73, 0xfd, 0x05, 0x1d, 0x4d, 0x95, 0x75, 0xbb, 0x36, 0x5c, 0x0f, 0xd8, 0x22, 0xaa, 0x94, 0xaa,
-	0x6a, 0x3e, 0xb4, 0x97, 0xed, 0x2a, 0x35, 0x42, 0xf2, 0xb5, 0x29, 0xe4, 0xd9, 0x6f, 0x60, 0x5e,
-	0xa9, 0x4a, 0xd9, 0x63, 0xda, 0x9f, 0x86, 0x2a, 0xfd, 0x38, 0x81, 0xd9, 0x89, 0xaa, 0x9e, 0x36,
-	0x46, 0x9f, 0xe3, 0x43, 0xf0, 0x0d, 0xd7, 0x32, 0x44, 0x31, 0x4a, 0xfc, 0x6c, 0xf6, 0xf3, 0x3a,
-	0xf2, 0x4f, 0xb9, 0x96, 0xb9, 0xad, 0xe2, 0x0c, 0x6e, 0xdf, 0x38, 0xc3, 0x5b, 0x31, 0x4a, 0xf6,
-	0x8f, 0x17, 0x6c, 0x98, 0xca, 0xdc, 0x54, 0x76, 0xea, 0x88, 0x6c, 0x76, 0x79, 0x1d, 0x79, 0x17,
-	0x5f, 0x23, 0x94, 0x6f, 0x63, 0xf8, 0x19, 0x80, 0x68, 0x84, 0x11, 0x45, 0x2d, 0x3e, 0xf0, 0x70,
-	0x62, 0x25, 0xf7, 0xd9, 0xd8, 0xa3, 0xd9, 0x8b, 0x1b, 0xce, 0x2e, 0xf7, 0xdc, 0xcb, 0x77, 0xa2,
-	0xf8, 0x15, 0xdc, 0x29, 0x55, 0xb3, 0x12, 0x55, 0xab, 0x0b, 0x23, 0x54, 0x13, 0xfa, 0xd6, 0x95,
-	0x8c, 0xbb, 0x9e, 0xec, 0xa2, 0x4e, 0xf7, 0xa7, 0x00, 0x3f, 0x86, 0x69, 0xa9, 0xa4, 0x2c, 0x9a,
-	0x37, 0x61, 0x60, 0x5d, 0xf4, 0x7f, 0x2e, 0x0b, 0x39, 0x8b, 0x0b, 0xe1, 0x47, 0x10, 0xbc, 0x6d,
-	0xb9, 0x3e, 0x0f, 0xf7, 0x6c, 0x3a, 0x1e, 0x4f, 0xbf, 0xee, 0x11, 0x97, 0x1d, 0x02, 0xd9, 0x14,
-	0x02, 0xde, 0x57, 0xe8, 0x3d, 0xb8, 0xfb, 0xd7, 0xab, 0xe9, 0x09, 0xe0, 0x7f, 0x97, 0xc7, 0x0f,
-	0x61, 0x2a, 0xb9, 0x5c, 0x72, 0xbd, 0x0e, 0x51, 0x3c, 0x49, 0xf6, 0x8f, 0x0f, 0xc7, 0xa7, 0xbd,
-	0xb4, 0x50, 0xee, 0x60, 0x7a, 0x04, 0x07, 0xbb, 0xeb, 0xe3, 0x39, 0x04, 0xef, 0x8a, 0xba, 0xe5,
-	0xf6, 0xc7, 0x0f, 0xf2, 0xe1, 0x42, 0x29, 0xc0, 0x76, 0xcd, 0x71, 0x26, 0x3b, 0xfa, 0xf1, 0x9d,
-	0xa0, 0x4f, 0x1d, 0x41, 0x9f, 0x3b, 0x82, 0x2e, 0x3b, 0x82, 0xae, 0x3a, 0x82, 0xbe, 0x75, 0x04,
-	0x5d, 0x6c, 0x88, 0x77, 0xb5, 0x21, 0xde, 0x97, 0x0d, 0xf1, 0x96, 0x7b, 0x76, 0x93, 0x07, 0xbf,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0xd8, 0x9a, 0xdf, 0x97, 0xf7, 0x02, 0x00, 0x00,
+	// 598 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0x87, 0xb3, 0x6d, 0xd2, 0xb8, 0xe3, 0x56, 0xa5, 0xab, 0x1e, 0x4c, 0xa9, 0x9c, 0x60, 0x8a,
+	0x94, 0x03, 0x72, 0xa4, 0x22, 0x21, 0x24, 0x04, 0x48, 0xe1, 0x6f, 0x25, 0x28, 0x60, 0xe5, 0x1e,
+	0x6d, 0xe2, 0x8d, 0xb1, 0xb4, 0xf6, 0x96, 0xcd, 0xba, 0xa2, 0x7d, 0x05, 0x2e, 0x3d, 0xf2, 0x08,
+	0x5c, 0xb9, 0xf1, 0x08, 0x3d, 0xf6, 0xc8, 0xa9, 0x40, 0xfa, 0x12, 0x88, 0x13, 0xf2, 0xda, 0xeb,
+	0xb8, 0xad, 0x8b, 0xca, 0x6d, 0xbd, 0xf3, 0xfb, 0xbe, 0x8d, 0x66, 0x32, 0x60, 0x13, 0xc9, 0xa3,
+	0xf0, 0x63, 0x57, 0x90, 0xb1, 0xec, 0xee, 0x0a, 0x2e, 0xf9, 0x88, 0xb3, 0x2e, 0xe3, 0x81, 0xab,
+	0x3e, 0xf0, 0x5a, 0x56, 0x77, 0xd3, 0xba, 0xab, 0xeb, 0xeb, 0x4e, 0x25, 0x35, 0x62, 0xc9, 0x44,
+	0x52, 0x91, 0xc5, 0xd6, 0xed, 0x80, 0xf3, 0x80, 0xd1, 0xac, 0x3c, 0x4c, 0xc6, 0x5d, 0x3f, 0x11,
+	0x44, 0x86, 0x3c, 0xce, 0xeb, 0xad, 0xf3, 0x75, 0x19, 0x46, 0x74, 0x22, 0x49, 0xb4, 0x9b, 0x07,
+	0xd6, 0x02, 0x1e, 0x70, 0x75, 0xec, 0xa6, 0xa7, 0xec, 0xd6, 0x39, 0xaa, 0x83, 0xf1, 0x8a, 0x07,
+	0xcf, 0x62, 0x29, 0xf6, 0xf1, 0x06, 0xd4, 0x25, 0x15, 0x91, 0x85, 0xda, 0xa8, 0x53, 0xef, 0x19,
+	0x7f, 0x4e, 0x5a, 0xf5, 0x3e, 0x15, 0x91, 0xa7, 0x6e, 0x71, 0x0f, 0x16, 0x0b, 0xa7, 0x35, 0xd7,
+	0x46, 0x1d, 0x73, 0x6b, 0xdd, 0xcd, 0x5e, 0x75, 0xf5, 0xab, 0x6e, 0x5f, 0x27, 0x7a, 0xc6, 0xd1,
+	0x49, 0xab, 0x76, 0xf8, 0xa3, 0x85, 0xbc, 0x19, 0x86, 0x5f, 0x00, 0x84, 0x71, 0x28, 0x43, 0xc2,
+	0xc2, 0x03, 0x6a, 0xcd, 0x2b, 0xc9, 0x6d, 0xb7, 0xaa, 0x29, 0xee, 0x76, 0x91, 0x53, 0x3f, 0xee,
+	0x65, 0xcd, 0x2b, 0xa1, 0xf8, 0x2d, 0x2c, 0x8f, 0x78, 0x3c, 0x0e, 0x83, 0xbc, 0x0b, 0x56, 0x5d,
+	0xb9, 0x3a, 0xd5, 0xae, 0x27, 0xe5, 0xa8, 0xd6, 0x9d, 0x15, 0xe0, 0x47, 0xd0, 0x1c, 0xf1, 0x28,
+	0x22, 0xb1, 0x6f, 0x35, 0x94, 0xcb, 0xb9, 0xcc, 0xa5, 0x42, 0xda, 0xa2, 0x21, 0x7c, 0x1f, 0x1a,
+	0x1f, 0x12, 0x2a, 0xf6, 0xad, 0x05, 0x45, 0xb7, 0xab, 0xe9, 0x77, 0x69, 0x44, 0xb3, 0x19, 0x90,
+	0x36, 0x56, 0x50, 0xe2, 0x0f, 0x78, 0xcc, 0xf6, 0xad, 0xa6, 0xa2, 0x6f, 0x55, 0xd3, 0x1e, 0x25,
+	0xfe, 0x9b, 0x98, 0x15, 0x02, 0x43, 0xe4, 0x17, 0xf8, 0x01, 0x2c, 0x8c, 0x05, 0xa5, 0x07, 0xd4,
+	0x32, 0x94, 0xe0, 0x66, 0xb5, 0xe0, 0xb9, 0xca, 0x68, 0x3c, 0x47, 0x52, 0x58, 0x86, 0x51, 0x18,
+	0x07, 0xd6, 0xe2, 0xbf, 0xe0, 0xbe, 0xca, 0x14, 0x70, 0x86, 0xf4, 0x9a, 0xd0, 0xa0, 0xe9, 0x95,
+	0xb3, 0x0a, 0x2b, 0xe7, 0x66, 0xe6, 0x7c, 0x42, 0x80, 0x2f, 0xf6, 0x1e, 0xdf, 0x83, 0x66, 0x44,
+	0xa3, 0x21, 0x15, 0x13, 0x0b, 0xb5, 0xe7, 0x3b, 0xe6, 0xd6, 0x46, 0xf5, 0x83, 0xaf, 0x55, 0xc8,
+	0xd3, 0x61, 0xfc, 0x10, 0x4c, 0xce, 0xfc, 0x81, 0x66, 0xe7, 0xae, 0xc0, 0x02, 0x67, 0x7e, 0x76,
+	0x9c, 0x38, 0x9b, 0xb0, 0x54, 0x1e, 0x1e, 0x5e, 0x83, 0xc6, 0x1e, 0x61, 0x09, 0x55, 0xff, 0xf7,
+	0x25, 0x2f, 0xfb, 0x70, 0x1c, 0x80, 0xd9, 0x90, 0x2e, 0xc9, 0xdc, 0x81, 0xe5, 0x33, 0xa3, 0xc0,
+	0x37, 0xca, 0x23, 0x4c, 0xa3, 0xc6, 0x6c, 0x36, 0xce, 0x0e, 0x98, 0xa5, 0xbe, 0xe3, 0xc7, 0x60,
+	0xe8, 0xdd, 0x55, 0x51, 0x73, 0xeb, 0xfa, 0x85, 0x35, 0x7a, 0x9a, 0x07, 0xb2, 0x2d, 0xfa, 0x9c,
+	0x6e, 0x51, 0x01, 0x39, 0x5f, 0x11, 0x98, 0xa5, 0x59, 0xe0, 0x1d, 0xb8, 0x46, 0x19, 0x1d, 0xa5,
+	0xb5, 0x41, 0xba, 0x6a, 0x3c, 0x91, 0xff, 0x23, 0x5e, 0xd1, 0x70, 0x3f, 0x63, 0xb1, 0x07, 0xf8,
+	0x3d, 0x25, 0x42, 0x0e, 0x29, 0x91, 0x83, 0x30, 0x96, 0x54, 0xec, 0x11, 0x66, 0xcd, 0x5d, 0xdd,
+	0xb8, 0x5a, 0xe0, 0xdb, 0x39, 0xdd, 0xdb, 0xfc, 0xfd, 0xcb, 0x46, 0x5f, 0xa6, 0x36, 0xfa, 0x36,
+	0xb5, 0xd1, 0xd1, 0xd4, 0x46, 0xc7, 0x53, 0x1b, 0xfd, 0x9c, 0xda, 0xe8, 0xf0, 0xd4, 0xae, 0x1d,
+	0x9f, 0xda, 0xb5, 0xef, 0xa7, 0x76, 0x6d, 0xb8, 0xa0, 0xac, 0x77, 0xff, 0x0e, 0x00, 0xc7, 0x8e,
+	0xcb, 0xba, 0x47, 0x05, 0x00, 0x00,
 }
 
 func (this *LogEntry) Equal(that interface{}) bool {
@@ -599,6 +699,78 @@ func (this *LogEntry_Query) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *LogEntry_ReadOnly) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogEntry_ReadOnly)
+	if !ok {
+		that2, ok := that.(LogEntry_ReadOnly)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.ReadOnly.Equal(that1.ReadOnly) {
+		return false
+	}
+	return true
+}
+func (this *LogEntry_Freeze) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogEntry_Freeze)
+	if !ok {
+		that2, ok := that.(LogEntry_Freeze)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Freeze.Equal(that1.Freeze) {
+		return false
+	}
+	return true
+}
+func (this *LogEntry_Timing) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LogEntry_Timing)
+	if !ok {
+		that2, ok := that.(LogEntry_Timing)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Timing.Equal(that1.Timing) {
+		return false
+	}
+	return true
+}
 func (this *InitializeEntry) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -647,6 +819,14 @@ func (this *ConfigurationEntry) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.OldMembers) != len(that1.OldMembers) {
+		return false
+	}
+	for i := range this.OldMembers {
+		if !this.OldMembers[i].Equal(that1.OldMembers[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (this *CommandEntry) Equal(that interface{}) bool {
@@ -697,6 +877,81 @@ func (this *QueryEntry) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *ReadOnlyEntry) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ReadOnlyEntry)
+	if !ok {
+		that2, ok := that.(ReadOnlyEntry)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ReadOnly != that1.ReadOnly {
+		return false
+	}
+	return true
+}
+func (this *FreezeEntry) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FreezeEntry)
+	if !ok {
+		that2, ok := that.(FreezeEntry)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Duration != that1.Duration {
+		return false
+	}
+	return true
+}
+func (this *TimingEntry) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*TimingEntry)
+	if !ok {
+		that2, ok := that.(TimingEntry)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ElectionTimeout != that1.ElectionTimeout {
+		return false
+	}
+	if this.HeartbeatInterval != that1.HeartbeatInterval {
+		return false
+	}
+	return true
+}
 func (m *LogEntry) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -743,7 +998,8 @@ func (m *LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 }
 
 func (m *LogEntry_Initialize) MarshalTo(dAtA []byte) (int, error) {
-	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
 func (m *LogEntry_Initialize) MarshalToSizedBuffer(dAtA []byte) (int, error) {
@@ -763,7 +1019,8 @@ func (m *LogEntry_Initialize) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 func (m *LogEntry_Configuration) MarshalTo(dAtA []byte) (int, error) {
-	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
 func (m *LogEntry_Configuration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
@@ -783,7 +1040,8 @@ func (m *LogEntry_Configuration) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 func (m *LogEntry_Command) MarshalTo(dAtA []byte) (int, error) {
-	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
 func (m *LogEntry_Command) MarshalToSizedBuffer(dAtA []byte) (int, error) {
@@ -803,7 +1061,8 @@ func (m *LogEntry_Command) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 func (m *LogEntry_Query) MarshalTo(dAtA []byte) (int, error) {
-	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
 func (m *LogEntry_Query) MarshalToSizedBuffer(dAtA []byte) (int, error) {
@@ -822,18 +1081,81 @@ func (m *LogEntry_Query) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
-func (m *InitializeEntry) Marshal() (dAtA []byte, err error) {
+func (m *LogEntry_ReadOnly) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *InitializeEntry) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
+func (m *LogEntry_ReadOnly) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ReadOnly != nil {
+		{
+			size, err := m.ReadOnly.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLog(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *LogEntry_Freeze) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogEntry_Freeze) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Freeze != nil {
+		{
+			size, err := m.Freeze.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLog(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	return len(dAtA) - i, nil
+}
+func (m *LogEntry_Timing) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogEntry_Timing) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Timing != nil {
+		{
+			size, err := m.Timing.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLog(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *InitializeEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InitializeEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
@@ -865,6 +1187,20 @@ func (m *ConfigurationEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.OldMembers) > 0 {
+		for iNdEx := len(m.OldMembers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.OldMembers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLog(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
 	if len(m.Members) > 0 {
 		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -942,6 +1278,109 @@ func (m *QueryEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ReadOnlyEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadOnlyEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadOnlyEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ReadOnly {
+		i--
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FreezeEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FreezeEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FreezeEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n9, err9 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Duration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Duration):])
+	if err9 != nil {
+		return 0, err9
+	}
+	i -= n9
+	i = encodeVarintLog(dAtA, i, uint64(n9))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *TimingEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimingEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimingEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n10, err10 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.HeartbeatInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.HeartbeatInterval):])
+	if err10 != nil {
+		return 0, err10
+	}
+	i -= n10
+	i = encodeVarintLog(dAtA, i, uint64(n10))
+	i--
+	dAtA[i] = 0x12
+	n11, err11 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ElectionTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.ElectionTimeout):])
+	if err11 != nil {
+		return 0, err11
+	}
+	i -= n11
+	i = encodeVarintLog(dAtA, i, uint64(n11))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintLog(dAtA []byte, offset int, v uint64) int {
 	offset -= sovLog(v)
 	base := offset
@@ -958,7 +1397,7 @@ func NewPopulatedLogEntry(r randyLog, easy bool) *LogEntry {
 	this.Term = Term(uint64(r.Uint32()))
 	v1 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
 	this.Timestamp = *v1
-	oneofNumber_Entry := []int32{3, 4, 5, 6}[r.Intn(4)]
+	oneofNumber_Entry := []int32{3, 4, 5, 6, 7, 8, 9}[r.Intn(7)]
 	switch oneofNumber_Entry {
 	case 3:
 		this.Entry = NewPopulatedLogEntry_Initialize(r, easy)
@@ -968,6 +1407,12 @@ func NewPopulatedLogEntry(r randyLog, easy bool) *LogEntry {
 		this.Entry = NewPopulatedLogEntry_Command(r, easy)
 	case 6:
 		this.Entry = NewPopulatedLogEntry_Query(r, easy)
+	case 7:
+		this.Entry = NewPopulatedLogEntry_ReadOnly(r, easy)
+	case 8:
+		this.Entry = NewPopulatedLogEntry_Freeze(r, easy)
+	case 9:
+		this.Entry = NewPopulatedLogEntry_Timing(r, easy)
 	}
 	if !easy && r.Intn(10) != 0 {
 	}
@@ -994,6 +1439,21 @@ func NewPopulatedLogEntry_Query(r randyLog, easy bool) *LogEntry_Query {
 	this.Query = NewPopulatedQueryEntry(r, easy)
 	return this
 }
+func NewPopulatedLogEntry_ReadOnly(r randyLog, easy bool) *LogEntry_ReadOnly {
+	this := &LogEntry_ReadOnly{}
+	this.ReadOnly = NewPopulatedReadOnlyEntry(r, easy)
+	return this
+}
+func NewPopulatedLogEntry_Freeze(r randyLog, easy bool) *LogEntry_Freeze {
+	this := &LogEntry_Freeze{}
+	this.Freeze = NewPopulatedFreezeEntry(r, easy)
+	return this
+}
+func NewPopulatedLogEntry_Timing(r randyLog, easy bool) *LogEntry_Timing {
+	this := &LogEntry_Timing{}
+	this.Timing = NewPopulatedTimingEntry(r, easy)
+	return this
+}
 func NewPopulatedInitializeEntry(r randyLog, easy bool) *InitializeEntry {
 	this := &InitializeEntry{}
 	if !easy && r.Intn(10) != 0 {
@@ -1010,6 +1470,13 @@ func NewPopulatedConfigurationEntry(r randyLog, easy bool) *ConfigurationEntry {
 			this.Members[i] = NewPopulatedMember(r, easy)
 		}
 	}
+	if r.Intn(5) != 0 {
+		v3 := r.Intn(5)
+		this.OldMembers = make([]*Member, v3)
+		for i := 0; i < v3; i++ {
+			this.OldMembers[i] = NewPopulatedMember(r, easy)
+		}
+	}
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -1017,9 +1484,9 @@ func NewPopulatedConfigurationEntry(r randyLog, easy bool) *ConfigurationEntry {
 
 func NewPopulatedCommandEntry(r randyLog, easy bool) *CommandEntry {
 	this := &CommandEntry{}
-	v3 := r.Intn(100)
-	this.Value = make([]byte, v3)
-	for i := 0; i < v3; i++ {
+	v4 := r.Intn(100)
+	this.Value = make([]byte, v4)
+	for i := 0; i < v4; i++ {
 		this.Value[i] = byte(r.Intn(256))
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -1029,9 +1496,9 @@ func NewPopulatedCommandEntry(r randyLog, easy bool) *CommandEntry {
 
 func NewPopulatedQueryEntry(r randyLog, easy bool) *QueryEntry {
 	this := &QueryEntry{}
-	v4 := r.Intn(100)
-	this.Value = make([]byte, v4)
-	for i := 0; i < v4; i++ {
+	v5 := r.Intn(100)
+	this.Value = make([]byte, v5)
+	for i := 0; i < v5; i++ {
 		this.Value[i] = byte(r.Intn(256))
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -1039,6 +1506,34 @@ func NewPopulatedQueryEntry(r randyLog, easy bool) *QueryEntry {
 	return this
 }
 
+func NewPopulatedReadOnlyEntry(r randyLog, easy bool) *ReadOnlyEntry {
+	this := &ReadOnlyEntry{}
+	this.ReadOnly = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedFreezeEntry(r randyLog, easy bool) *FreezeEntry {
+	this := &FreezeEntry{}
+	v6 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.Duration = *v6
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedTimingEntry(r randyLog, easy bool) *TimingEntry {
+	this := &TimingEntry{}
+	v7 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.ElectionTimeout = *v7
+	v8 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.HeartbeatInterval = *v8
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
 type randyLog interface {
 	Float32() float32
 	Float64() float64
@@ -1058,9 +1553,9 @@ func randUTF8RuneLog(r randyLog) rune {
 	return rune(ru + 61)
 }
 func randStringLog(r randyLog) string {
-	v5 := r.Intn(100)
-	tmps := make([]rune, v5)
-	for i := 0; i < v5; i++ {
+	v9 := r.Intn(100)
+	tmps := make([]rune, v9)
+	for i := 0; i < v9; i++ {
 		tmps[i] = randUTF8RuneLog(r)
 	}
 	return string(tmps)
@@ -1082,11 +1577,11 @@ func randFieldLog(dAtA []byte, r randyLog, fieldNumber int, wire int) []byte {
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateLog(dAtA, uint64(key))
-		v6 := r.Int63()
+		v10 := r.Int63()
 		if r.Intn(2) == 0 {
-			v6 *= -1
+			v10 *= -1
 		}
-		dAtA = encodeVarintPopulateLog(dAtA, uint64(v6))
+		dAtA = encodeVarintPopulateLog(dAtA, uint64(v10))
 	case 1:
 		dAtA = encodeVarintPopulateLog(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -1176,6 +1671,42 @@ func (m *LogEntry_Query) Size() (n int) {
 	}
 	return n
 }
+func (m *LogEntry_ReadOnly) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ReadOnly != nil {
+		l = m.ReadOnly.Size()
+		n += 1 + l + sovLog(uint64(l))
+	}
+	return n
+}
+func (m *LogEntry_Freeze) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Freeze != nil {
+		l = m.Freeze.Size()
+		n += 1 + l + sovLog(uint64(l))
+	}
+	return n
+}
+func (m *LogEntry_Timing) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Timing != nil {
+		l = m.Timing.Size()
+		n += 1 + l + sovLog(uint64(l))
+	}
+	return n
+}
 func (m *InitializeEntry) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1197,6 +1728,12 @@ func (m *ConfigurationEntry) Size() (n int) {
 			n += 1 + l + sovLog(uint64(l))
 		}
 	}
+	if len(m.OldMembers) > 0 {
+		for _, e := range m.OldMembers {
+			l = e.Size()
+			n += 1 + l + sovLog(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1226,6 +1763,42 @@ func (m *QueryEntry) Size() (n int) {
 	return n
 }
 
+func (m *ReadOnlyEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ReadOnly {
+		n += 2
+	}
+	return n
+}
+
+func (m *FreezeEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Duration)
+	n += 1 + l + sovLog(uint64(l))
+	return n
+}
+
+func (m *TimingEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.ElectionTimeout)
+	n += 1 + l + sovLog(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.HeartbeatInterval)
+	n += 1 + l + sovLog(uint64(l))
+	return n
+}
+
 func sovLog(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1453,16 +2026,121 @@ func (m *LogEntry) Unmarshal(dAtA []byte) error {
 			}
 			m.Entry = &LogEntry_Query{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipLog(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthLog
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthLog
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLog
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ReadOnlyEntry{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Entry = &LogEntry_ReadOnly{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Freeze", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLog
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLog
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &FreezeEntry{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Entry = &LogEntry_Freeze{v}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timing", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLog
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLog
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &TimingEntry{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Entry = &LogEntry_Timing{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLog(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLog
+			}
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthLog
 			}
 			if (iNdEx + skippy) > l {
@@ -1593,6 +2271,40 @@ func (m *ConfigurationEntry) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldMembers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLog
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLog
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldMembers = append(m.OldMembers, &Member{})
+			if err := m.OldMembers[len(m.OldMembers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLog(dAtA[iNdEx:])
@@ -1791,9 +2503,288 @@ func (m *QueryEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ReadOnlyEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLog
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadOnlyEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadOnlyEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLog(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLog
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLog
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FreezeEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLog
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FreezeEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FreezeEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLog
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLog
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Duration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLog(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLog
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLog
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TimingEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLog
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TimingEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TimingEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElectionTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLog
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLog
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.ElectionTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLog
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLog
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.HeartbeatInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLog(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLog
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthLog
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipLog(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -1825,10 +2816,8 @@ func skipLog(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -1849,55 +2838,30 @@ func skipLog(dAtA []byte) (n int, err error) {
 				return 0, ErrInvalidLengthLog
 			}
 			iNdEx += length
-			if iNdEx < 0 {
-				return 0, ErrInvalidLengthLog
-			}
-			return iNdEx, nil
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowLog
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipLog(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-				if iNdEx < 0 {
-					return 0, ErrInvalidLengthLog
-				}
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupLog
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthLog
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthLog = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowLog   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthLog        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowLog          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupLog = fmt.Errorf("proto: unexpected end of group")
 )
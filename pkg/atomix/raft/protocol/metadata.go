@@ -14,51 +14,289 @@
 
 package protocol
 
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
 // newMemoryMetadataStore creates a new in-memory metadata store
 func newMemoryMetadataStore() MetadataStore {
 	return &memoryMetadataStore{}
 }
 
-// MetadataStore stores metadata for a Raft server
+// MetadataStore stores metadata for a Raft server. Writes are synchronous: a write must not return until
+// the metadata is persisted to stable storage, since a member that forgets its term or vote after a crash
+// may vote twice in the same term.
 type MetadataStore interface {
 	// StoreTerm stores the Raft term
-	StoreTerm(term Term)
+	StoreTerm(term Term) error
 
 	// LoadTerm loads the Raft term
 	LoadTerm() *Term
 
 	// StoreVote stores the Raft vote
-	StoreVote(vote *MemberID)
+	StoreVote(vote *MemberID) error
 
 	// LoadVote loads the Raft vote
 	LoadVote() *MemberID
 
+	// StoreTermAndVote stores the Raft term and vote atomically in a single write
+	StoreTermAndVote(term Term, vote *MemberID) error
+
+	// StoreReadOnly stores the committed read-only mode
+	StoreReadOnly(readOnly bool) error
+
+	// LoadReadOnly loads the committed read-only mode
+	LoadReadOnly() bool
+
 	// Close closes the store
 	Close() error
 }
 
 // memoryMetadataStore implements MetadataStore in memory
 type memoryMetadataStore struct {
-	term *Term
-	vote *MemberID
+	term     *Term
+	vote     *MemberID
+	readOnly bool
 }
 
-func (s *memoryMetadataStore) StoreTerm(term Term) {
+func (s *memoryMetadataStore) StoreTerm(term Term) error {
 	s.term = &term
+	return nil
 }
 
 func (s *memoryMetadataStore) LoadTerm() *Term {
 	return s.term
 }
 
-func (s *memoryMetadataStore) StoreVote(vote *MemberID) {
+func (s *memoryMetadataStore) StoreVote(vote *MemberID) error {
 	s.vote = vote
+	return nil
 }
 
 func (s *memoryMetadataStore) LoadVote() *MemberID {
 	return s.vote
 }
 
+func (s *memoryMetadataStore) StoreTermAndVote(term Term, vote *MemberID) error {
+	s.term = &term
+	s.vote = vote
+	return nil
+}
+
+func (s *memoryMetadataStore) StoreReadOnly(readOnly bool) error {
+	s.readOnly = readOnly
+	return nil
+}
+
+func (s *memoryMetadataStore) LoadReadOnly() bool {
+	return s.readOnly
+}
+
 func (s *memoryMetadataStore) Close() error {
 	return nil
 }
+
+// metadataFile is the name of the file to which a file metadata store writes the term, vote and read-only mode
+const metadataFile = "metadata"
+
+// NewFileMetadataStore returns a MetadataStore that persists the term, vote and read-only mode to a file in
+// the given directory, loading the metadata previously stored in the directory if any. Each write replaces the
+// file atomically and is synced to disk before it returns.
+func NewFileMetadataStore(directory string) (MetadataStore, error) {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, err
+	}
+	store := &fileMetadataStore{
+		directory: directory,
+	}
+	bytes, err := ioutil.ReadFile(filepath.Join(directory, metadataFile))
+	if os.IsNotExist(err) {
+		return store, nil
+	} else if err != nil {
+		return nil, err
+	}
+	metadata := &Metadata{}
+	if err := metadata.Unmarshal(bytes); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %s", err)
+	}
+	store.term = &metadata.Term
+	if metadata.Vote != "" {
+		store.vote = &metadata.Vote
+	}
+	store.readOnly = metadata.ReadOnly
+	return store, nil
+}
+
+// fileMetadataStore is a MetadataStore that persists the term, vote and read-only mode to a file
+type fileMetadataStore struct {
+	directory string
+	term      *Term
+	vote      *MemberID
+	readOnly  bool
+}
+
+func (s *fileMetadataStore) StoreTerm(term Term) error {
+	return s.StoreTermAndVote(term, s.vote)
+}
+
+func (s *fileMetadataStore) LoadTerm() *Term {
+	return s.term
+}
+
+func (s *fileMetadataStore) StoreVote(vote *MemberID) error {
+	var term Term
+	if s.term != nil {
+		term = *s.term
+	}
+	return s.StoreTermAndVote(term, vote)
+}
+
+func (s *fileMetadataStore) LoadVote() *MemberID {
+	return s.vote
+}
+
+func (s *fileMetadataStore) StoreTermAndVote(term Term, vote *MemberID) error {
+	if err := s.write(term, vote, s.readOnly); err != nil {
+		return err
+	}
+	s.term = &term
+	s.vote = vote
+	return nil
+}
+
+func (s *fileMetadataStore) StoreReadOnly(readOnly bool) error {
+	var term Term
+	if s.term != nil {
+		term = *s.term
+	}
+	if err := s.write(term, s.vote, readOnly); err != nil {
+		return err
+	}
+	s.readOnly = readOnly
+	return nil
+}
+
+func (s *fileMetadataStore) LoadReadOnly() bool {
+	return s.readOnly
+}
+
+// write replaces the metadata file with the given metadata. The metadata is written and synced to a temporary
+// file which is then renamed over the metadata file, and the directory is synced to persist the rename.
+func (s *fileMetadataStore) write(term Term, vote *MemberID, readOnly bool) error {
+	metadata := &Metadata{
+		Term:     term,
+		ReadOnly: readOnly,
+	}
+	if vote != nil {
+		metadata.Vote = *vote
+	}
+	bytes, err := metadata.Marshal()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(s.directory, metadataFile)
+	tmpPath := path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(bytes); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	dir, err := os.Open(s.directory)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+func (s *fileMetadataStore) Close() error {
+	return nil
+}
+
+// newMetricsMetadataStore returns a MetadataStore that records metrics for operations on the given store
+func newMetricsMetadataStore(store MetadataStore, metrics *RaftMetrics) MetadataStore {
+	return &metricsMetadataStore{
+		store:   store,
+		metrics: metrics,
+	}
+}
+
+// metricsMetadataStore is a MetadataStore that records the latency of operations on the underlying store.
+// Terms and votes are stored synchronously, so a slow metadata store delays elections.
+type metricsMetadataStore struct {
+	store   MetadataStore
+	metrics *RaftMetrics
+}
+
+func (s *metricsMetadataStore) StoreTerm(term Term) error {
+	start := time.Now()
+	err := s.store.StoreTerm(term)
+	s.metrics.ObserveMetadata("store_term", time.Since(start), err)
+	return err
+}
+
+func (s *metricsMetadataStore) LoadTerm() *Term {
+	start := time.Now()
+	term := s.store.LoadTerm()
+	s.metrics.ObserveMetadata("load_term", time.Since(start), nil)
+	return term
+}
+
+func (s *metricsMetadataStore) StoreVote(vote *MemberID) error {
+	start := time.Now()
+	err := s.store.StoreVote(vote)
+	s.metrics.ObserveMetadata("store_vote", time.Since(start), err)
+	return err
+}
+
+func (s *metricsMetadataStore) LoadVote() *MemberID {
+	start := time.Now()
+	vote := s.store.LoadVote()
+	s.metrics.ObserveMetadata("load_vote", time.Since(start), nil)
+	return vote
+}
+
+func (s *metricsMetadataStore) StoreTermAndVote(term Term, vote *MemberID) error {
+	start := time.Now()
+	err := s.store.StoreTermAndVote(term, vote)
+	s.metrics.ObserveMetadata("store_term_and_vote", time.Since(start), err)
+	return err
+}
+
+func (s *metricsMetadataStore) StoreReadOnly(readOnly bool) error {
+	start := time.Now()
+	err := s.store.StoreReadOnly(readOnly)
+	s.metrics.ObserveMetadata("store_read_only", time.Since(start), err)
+	return err
+}
+
+func (s *metricsMetadataStore) LoadReadOnly() bool {
+	start := time.Now()
+	readOnly := s.store.LoadReadOnly()
+	s.metrics.ObserveMetadata("load_read_only", time.Since(start), nil)
+	return readOnly
+}
+
+func (s *metricsMetadataStore) Close() error {
+	start := time.Now()
+	err := s.store.Close()
+	s.metrics.ObserveMetadata("close", time.Since(start), err)
+	return err
+}
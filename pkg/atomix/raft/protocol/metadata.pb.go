@@ -25,12 +25,13 @@ var _ = time.Kitchen
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // Raft system metadata
 type Metadata struct {
-	Term Term     `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Vote MemberID `protobuf:"bytes,2,opt,name=vote,proto3,casttype=MemberID" json:"vote,omitempty"`
+	Term     Term     `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Vote     MemberID `protobuf:"bytes,2,opt,name=vote,proto3,casttype=MemberID" json:"vote,omitempty"`
+	ReadOnly bool     `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
 }
 
 func (m *Metadata) Reset()         { *m = Metadata{} }
@@ -80,12 +81,20 @@ func (m *Metadata) GetVote() MemberID {
 	return ""
 }
 
+func (m *Metadata) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
 // Raft system configuration
 type Configuration struct {
-	Index     Index      `protobuf:"varint,1,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
-	Term      Term       `protobuf:"varint,2,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Timestamp *time.Time `protobuf:"bytes,3,opt,name=timestamp,proto3,stdtime" json:"timestamp,omitempty"`
-	Members   []*Member  `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	Index      Index      `protobuf:"varint,1,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	Term       Term       `protobuf:"varint,2,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Timestamp  *time.Time `protobuf:"bytes,3,opt,name=timestamp,proto3,stdtime" json:"timestamp,omitempty"`
+	Members    []*Member  `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	OldMembers []*Member  `protobuf:"bytes,5,rep,name=old_members,json=oldMembers,proto3" json:"old_members,omitempty"`
 }
 
 func (m *Configuration) Reset()         { *m = Configuration{} }
@@ -149,6 +158,13 @@ func (m *Configuration) GetMembers() []*Member {
 	return nil
 }
 
+func (m *Configuration) GetOldMembers() []*Member {
+	if m != nil {
+		return m.OldMembers
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Metadata)(nil), "atomix.raft.protocol.Metadata")
 	proto.RegisterType((*Configuration)(nil), "atomix.raft.protocol.Configuration")
@@ -159,28 +175,30 @@ func init() {
 }
 
 var fileDescriptor_b1c93df0fbe03b7c = []byte{
-	// 326 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x31, 0x6e, 0xc2, 0x30,
-	0x14, 0x86, 0x79, 0x60, 0x5a, 0x30, 0xed, 0x12, 0x31, 0x44, 0x08, 0xd9, 0x11, 0xed, 0x90, 0xc9,
-	0x91, 0xa8, 0xd4, 0xb1, 0x43, 0xda, 0x85, 0x4a, 0x2c, 0x11, 0x17, 0x30, 0x60, 0xa2, 0x48, 0x18,
-	0x23, 0x63, 0x2a, 0x8e, 0xc1, 0x31, 0x7a, 0x84, 0x9e, 0xa0, 0xea, 0xc8, 0xd8, 0x89, 0xb6, 0xe1,
-	0x12, 0x15, 0x53, 0x85, 0x4d, 0xe8, 0xc2, 0xf6, 0xf4, 0xbd, 0xef, 0xf9, 0xbd, 0xdf, 0xf8, 0x86,
-	0x1b, 0x25, 0xb3, 0x55, 0xa4, 0xf9, 0xc4, 0x44, 0x73, 0xad, 0x8c, 0x1a, 0xa9, 0x69, 0x24, 0x85,
-	0xe1, 0x63, 0x6e, 0x38, 0xb3, 0xc4, 0x6b, 0x3a, 0x89, 0x1d, 0x24, 0x56, 0x48, 0xad, 0xce, 0xd9,
-	0xd1, 0xd1, 0x74, 0xb9, 0x30, 0x42, 0x3b, 0xad, 0x45, 0x53, 0xa5, 0xd2, 0xa9, 0x70, 0xed, 0xe1,
-	0x72, 0x12, 0x99, 0x4c, 0x8a, 0x85, 0xe1, 0x72, 0x7e, 0x14, 0x9a, 0xa9, 0x4a, 0x95, 0x2d, 0xa3,
-	0x43, 0xe5, 0x68, 0xe7, 0x19, 0xd7, 0xfa, 0xc7, 0x13, 0xbc, 0x36, 0x46, 0x46, 0x68, 0xe9, 0x43,
-	0x00, 0x21, 0x8a, 0x6b, 0xfb, 0x2d, 0x45, 0x03, 0xa1, 0x65, 0x62, 0xa9, 0x17, 0x60, 0xf4, 0xa2,
-	0x8c, 0xf0, 0xcb, 0x01, 0x84, 0xf5, 0xf8, 0x6a, 0xbf, 0xa5, 0xb5, 0xbe, 0x90, 0x43, 0xa1, 0x7b,
-	0x4f, 0x89, 0xed, 0x74, 0xde, 0x01, 0x5f, 0x3f, 0xaa, 0xd9, 0x24, 0x4b, 0x97, 0x9a, 0x9b, 0x4c,
-	0xcd, 0x3c, 0x8a, 0xab, 0xd9, 0x6c, 0x2c, 0x56, 0xc7, 0x27, 0xeb, 0xfb, 0x2d, 0xad, 0xf6, 0x0e,
-	0x20, 0x71, 0xfc, 0xb4, 0xb2, 0x7c, 0x76, 0xe5, 0x03, 0xae, 0x9f, 0x52, 0xf8, 0x95, 0x00, 0xc2,
-	0x46, 0xb7, 0xc5, 0x5c, 0x4e, 0x56, 0xe4, 0x64, 0x83, 0xc2, 0x88, 0xd1, 0xfa, 0x8b, 0x42, 0xf2,
-	0x3f, 0xe2, 0xdd, 0xe3, 0x4b, 0x69, 0x4f, 0x5c, 0xf8, 0x28, 0xa8, 0x84, 0x8d, 0x6e, 0x9b, 0x9d,
-	0xfb, 0x5f, 0xe6, 0x72, 0x24, 0x85, 0x1c, 0xdf, 0xfe, 0xfe, 0x10, 0x78, 0xcd, 0x09, 0xbc, 0xe5,
-	0x04, 0x3e, 0x72, 0x02, 0x9b, 0x9c, 0xc0, 0x77, 0x4e, 0x60, 0xbd, 0x23, 0xa5, 0xcd, 0x8e, 0x94,
-	0x3e, 0x77, 0xa4, 0x34, 0xbc, 0xb0, 0xf3, 0x77, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x45, 0xc7,
-	0x59, 0xe1, 0xd9, 0x01, 0x00, 0x00,
+	// 364 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0xb1, 0x6e, 0xf2, 0x30,
+	0x14, 0x85, 0x31, 0x84, 0xff, 0x4f, 0x4c, 0xbb, 0x44, 0x0c, 0x11, 0x45, 0x4e, 0x44, 0x3b, 0x64,
+	0x72, 0x24, 0x2a, 0x75, 0x6b, 0x87, 0xb4, 0x0b, 0x03, 0xaa, 0x14, 0xb1, 0x23, 0x43, 0x4c, 0x14,
+	0x29, 0x8e, 0x91, 0x63, 0x2a, 0x78, 0x80, 0xee, 0x3c, 0x46, 0x1f, 0xa1, 0x8f, 0xd0, 0x91, 0xb1,
+	0x13, 0x6d, 0xc3, 0x4b, 0x54, 0x4c, 0x55, 0x62, 0x42, 0x17, 0x86, 0x6e, 0x57, 0xc7, 0xdf, 0xf1,
+	0x3d, 0xf7, 0xc0, 0x4b, 0x22, 0x39, 0x8b, 0x97, 0x9e, 0x20, 0x33, 0xe9, 0xcd, 0x05, 0x97, 0x7c,
+	0xca, 0x13, 0x8f, 0x51, 0x49, 0x42, 0x22, 0x09, 0x2e, 0x15, 0xb3, 0xad, 0x20, 0x5c, 0x40, 0xb8,
+	0x82, 0x3a, 0xbd, 0x93, 0xd6, 0x69, 0xb2, 0xc8, 0x24, 0x15, 0x0a, 0xeb, 0xd8, 0x11, 0xe7, 0x51,
+	0x42, 0xd5, 0xf3, 0x64, 0x31, 0xf3, 0x64, 0xcc, 0x68, 0x26, 0x09, 0x9b, 0x1f, 0x80, 0x76, 0xc4,
+	0x23, 0x5e, 0x8e, 0x5e, 0x31, 0x29, 0xb5, 0x17, 0x41, 0x7d, 0x78, 0x88, 0x60, 0x76, 0xa1, 0x26,
+	0xa9, 0x60, 0x16, 0x70, 0x80, 0xab, 0xf9, 0xfa, 0x7e, 0x6b, 0x6b, 0x23, 0x2a, 0x58, 0x50, 0xaa,
+	0xa6, 0x03, 0xb5, 0x27, 0x2e, 0xa9, 0x55, 0x77, 0x80, 0x6b, 0xf8, 0x67, 0xfb, 0xad, 0xad, 0x0f,
+	0x29, 0x9b, 0x50, 0x31, 0x78, 0x08, 0xca, 0x17, 0xf3, 0x02, 0x1a, 0x82, 0x92, 0x70, 0xcc, 0xd3,
+	0x64, 0x65, 0x35, 0x1c, 0xe0, 0xea, 0x81, 0x5e, 0x08, 0x8f, 0x69, 0xb2, 0xea, 0x3d, 0xd7, 0xe1,
+	0xf9, 0x3d, 0x4f, 0x67, 0x71, 0xb4, 0x10, 0x44, 0xc6, 0x3c, 0x35, 0x6d, 0xd8, 0x8c, 0xd3, 0x90,
+	0x2e, 0x0f, 0xfb, 0x8c, 0xfd, 0xd6, 0x6e, 0x0e, 0x0a, 0x21, 0x50, 0xfa, 0x31, 0x4f, 0xfd, 0x64,
+	0x9e, 0x3b, 0x68, 0x1c, 0x4f, 0x2c, 0xb7, 0xb5, 0xfa, 0x1d, 0xac, 0x4a, 0xc0, 0x55, 0x09, 0x78,
+	0x54, 0x11, 0xbe, 0xb6, 0xfe, 0xb0, 0x41, 0xf0, 0x6b, 0x31, 0x6f, 0xe0, 0x7f, 0x56, 0xe6, 0xcf,
+	0x2c, 0xcd, 0x69, 0xb8, 0xad, 0x7e, 0x17, 0x9f, 0x2a, 0x1f, 0xab, 0x23, 0x83, 0x0a, 0x36, 0x6f,
+	0x61, 0x8b, 0x27, 0xe1, 0xb8, 0xf2, 0x36, 0xff, 0xe0, 0x85, 0x3c, 0x09, 0xd5, 0x98, 0xf9, 0x57,
+	0xdf, 0x5f, 0x08, 0xbc, 0xe4, 0x08, 0xbc, 0xe6, 0x08, 0xbc, 0xe5, 0x08, 0x6c, 0x72, 0x04, 0x3e,
+	0x73, 0x04, 0xd6, 0x3b, 0x54, 0xdb, 0xec, 0x50, 0xed, 0x7d, 0x87, 0x6a, 0x93, 0x7f, 0xe5, 0x17,
+	0xd7, 0x3f, 0x03, 0x00, 0x4b, 0x5e, 0x1a, 0xa6, 0x35, 0x02, 0x00, 0x00,
 }
 
 func (this *Metadata) Equal(that interface{}) bool {
@@ -208,6 +226,9 @@ func (this *Metadata) Equal(that interface{}) bool {
 	if this.Vote != that1.Vote {
 		return false
 	}
+	if this.ReadOnly != that1.ReadOnly {
+		return false
+	}
 	return true
 }
 func (this *Configuration) Equal(that interface{}) bool {
@@ -250,6 +271,14 @@ func (this *Configuration) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.OldMembers) != len(that1.OldMembers) {
+		return false
+	}
+	for i := range this.OldMembers {
+		if !this.OldMembers[i].Equal(that1.OldMembers[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (m *Metadata) Marshal() (dAtA []byte, err error) {
@@ -272,6 +301,16 @@ func (m *Metadata) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ReadOnly {
+		i--
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.Vote) > 0 {
 		i -= len(m.Vote)
 		copy(dAtA[i:], m.Vote)
@@ -307,6 +346,20 @@ func (m *Configuration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.OldMembers) > 0 {
+		for iNdEx := len(m.OldMembers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.OldMembers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintMetadata(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if len(m.Members) > 0 {
 		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -359,6 +412,7 @@ func NewPopulatedMetadata(r randyMetadata, easy bool) *Metadata {
 	this := &Metadata{}
 	this.Term = Term(uint64(r.Uint32()))
 	this.Vote = MemberID(randStringMetadata(r))
+	this.ReadOnly = bool(bool(r.Intn(2) == 0))
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -378,6 +432,13 @@ func NewPopulatedConfiguration(r randyMetadata, easy bool) *Configuration {
 			this.Members[i] = NewPopulatedMember(r, easy)
 		}
 	}
+	if r.Intn(5) != 0 {
+		v2 := r.Intn(5)
+		this.OldMembers = make([]*Member, v2)
+		for i := 0; i < v2; i++ {
+			this.OldMembers[i] = NewPopulatedMember(r, easy)
+		}
+	}
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -402,9 +463,9 @@ func randUTF8RuneMetadata(r randyMetadata) rune {
 	return rune(ru + 61)
 }
 func randStringMetadata(r randyMetadata) string {
-	v2 := r.Intn(100)
-	tmps := make([]rune, v2)
-	for i := 0; i < v2; i++ {
+	v3 := r.Intn(100)
+	tmps := make([]rune, v3)
+	for i := 0; i < v3; i++ {
 		tmps[i] = randUTF8RuneMetadata(r)
 	}
 	return string(tmps)
@@ -426,11 +487,11 @@ func randFieldMetadata(dAtA []byte, r randyMetadata, fieldNumber int, wire int)
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateMetadata(dAtA, uint64(key))
-		v3 := r.Int63()
+		v4 := r.Int63()
 		if r.Intn(2) == 0 {
-			v3 *= -1
+			v4 *= -1
 		}
-		dAtA = encodeVarintPopulateMetadata(dAtA, uint64(v3))
+		dAtA = encodeVarintPopulateMetadata(dAtA, uint64(v4))
 	case 1:
 		dAtA = encodeVarintPopulateMetadata(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -468,6 +529,9 @@ func (m *Metadata) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovMetadata(uint64(l))
 	}
+	if m.ReadOnly {
+		n += 2
+	}
 	return n
 }
 
@@ -493,6 +557,12 @@ func (m *Configuration) Size() (n int) {
 			n += 1 + l + sovMetadata(uint64(l))
 		}
 	}
+	if len(m.OldMembers) > 0 {
+		for _, e := range m.OldMembers {
+			l = e.Size()
+			n += 1 + l + sovMetadata(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -582,6 +652,26 @@ func (m *Metadata) Unmarshal(dAtA []byte) error {
 			}
 			m.Vote = MemberID(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetadata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetadata(dAtA[iNdEx:])
@@ -743,6 +833,40 @@ func (m *Configuration) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldMembers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetadata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetadata
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetadata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldMembers = append(m.OldMembers, &Member{})
+			if err := m.OldMembers[len(m.OldMembers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetadata(dAtA[iNdEx:])
@@ -770,6 +894,7 @@ func (m *Configuration) Unmarshal(dAtA []byte) error {
 func skipMetadata(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -801,10 +926,8 @@ func skipMetadata(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -825,55 +948,30 @@ func skipMetadata(dAtA []byte) (n int, err error) {
 				return 0, ErrInvalidLengthMetadata
 			}
 			iNdEx += length
-			if iNdEx < 0 {
-				return 0, ErrInvalidLengthMetadata
-			}
-			return iNdEx, nil
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowMetadata
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipMetadata(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-				if iNdEx < 0 {
-					return 0, ErrInvalidLengthMetadata
-				}
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupMetadata
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthMetadata
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthMetadata = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowMetadata   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthMetadata        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowMetadata          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupMetadata = fmt.Errorf("proto: unexpected end of group")
 )
@@ -0,0 +1,375 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+	"time"
+)
+
+const metricsNamespace = "raft"
+
+// newRaftMetrics returns new metrics for the given member and group
+func newRaftMetrics(member MemberID, group GroupID, config *config.ProtocolConfig) *RaftMetrics {
+	labels := prometheus.Labels{"member": string(member)}
+	if group != "" {
+		labels["group"] = string(group)
+	}
+	return &RaftMetrics{
+		leaderOnly: config.GetMetrics().GetLeaderOnly(),
+		followerLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "leader",
+			Name:        "follower_lag",
+			Help:        "The number of entries by which each follower's log trails the leader's log",
+			ConstLabels: labels,
+		}, []string{"follower"}),
+		committed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "leader",
+			Name:        "committed_entries_total",
+			Help:        "The number of entries committed by the leader",
+			ConstLabels: labels,
+		}),
+		appendLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "leader",
+			Name:        "append_duration_seconds",
+			Help:        "The latency of append requests to each follower",
+			ConstLabels: labels,
+		}, []string{"follower"}),
+		node: config.GetMetrics().GetNode(),
+		role: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "node",
+			Name:        "role",
+			Help:        "The current role of the member, set to 1 for the current role",
+			ConstLabels: labels,
+		}, []string{"role"}),
+		status: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "node",
+			Name:        "status",
+			Help:        "The current status of the member, set to 1 for the current status",
+			ConstLabels: labels,
+		}, []string{"status"}),
+		term: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "node",
+			Name:        "term",
+			Help:        "The current term of the member",
+			ConstLabels: labels,
+		}),
+		commitIndex: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "node",
+			Name:        "commit_index",
+			Help:        "The highest index known to be committed by the member",
+			ConstLabels: labels,
+		}),
+		roleTransitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "node",
+			Name:        "role_transitions_total",
+			Help:        "The number of transitions of the member to each role",
+			ConstLabels: labels,
+		}, []string{"role"}),
+		leaderChanges: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "node",
+			Name:        "leader_changes_total",
+			Help:        "The number of times the member learned of a new leader",
+			ConstLabels: labels,
+		}),
+		elections: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "election",
+			Name:        "elections_total",
+			Help:        "The number of elections started by the member",
+			ConstLabels: labels,
+		}),
+		electionTimeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "election",
+			Name:        "timeouts_total",
+			Help:        "The number of heartbeat and election timeouts expired in each role",
+			ConstLabels: labels,
+		}, []string{"role"}),
+		votes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "election",
+			Name:        "votes_total",
+			Help:        "The number of vote requests granted and rejected by the member",
+			ConstLabels: labels,
+		}, []string{"result"}),
+		metadata: config.GetMetrics().GetMetadata(),
+		metadataLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "metadata",
+			Name:        "operation_duration_seconds",
+			Help:        "The latency of metadata store operations",
+			ConstLabels: labels,
+		}, []string{"operation"}),
+		metadataOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "metadata",
+			Name:        "operations_total",
+			Help:        "The number of metadata store operations",
+			ConstLabels: labels,
+		}, []string{"operation"}),
+		metadataErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "metadata",
+			Name:        "errors_total",
+			Help:        "The number of failed metadata store operations",
+			ConstLabels: labels,
+		}, []string{"operation"}),
+		locks: config.GetMetrics().GetLocks(),
+		lockWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "lock",
+			Name:        "wait_duration_seconds",
+			Help:        "The time spent waiting to acquire the Raft state lock",
+			ConstLabels: labels,
+		}, []string{"mode", "role"}),
+		lockHold: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   metricsNamespace,
+			Subsystem:   "lock",
+			Name:        "hold_duration_seconds",
+			Help:        "The time for which the Raft state write lock is held",
+			ConstLabels: labels,
+		}, []string{"mode", "role"}),
+	}
+}
+
+// RaftMetrics is a prometheus.Collector exporting Raft protocol metrics.
+// Leader metrics are only meaningful on the leader. If the metrics are configured to be leader-only,
+// leader metrics are cleared when the local member steps down and are not reported by followers.
+// Node, metadata store, and lock metrics are reported by all members if enabled.
+type RaftMetrics struct {
+	leaderOnly         bool
+	leader             bool
+	followerLag        *prometheus.GaugeVec
+	committed          prometheus.Counter
+	appendLatency      *prometheus.HistogramVec
+	node               bool
+	role               *prometheus.GaugeVec
+	status             *prometheus.GaugeVec
+	term               prometheus.Gauge
+	commitIndex        prometheus.Gauge
+	roleTransitions    *prometheus.CounterVec
+	leaderChanges      prometheus.Counter
+	elections          prometheus.Counter
+	electionTimeouts   *prometheus.CounterVec
+	votes              *prometheus.CounterVec
+	metadata           bool
+	metadataLatency    *prometheus.HistogramVec
+	metadataOperations *prometheus.CounterVec
+	metadataErrors     *prometheus.CounterVec
+	locks              bool
+	lockWait           *prometheus.HistogramVec
+	lockHold           *prometheus.HistogramVec
+	mu                 sync.RWMutex
+}
+
+// Describe implements prometheus.Collector
+func (m *RaftMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.followerLag.Describe(ch)
+	m.committed.Describe(ch)
+	m.appendLatency.Describe(ch)
+	if m.node {
+		m.role.Describe(ch)
+		m.status.Describe(ch)
+		m.term.Describe(ch)
+		m.commitIndex.Describe(ch)
+		m.roleTransitions.Describe(ch)
+		m.leaderChanges.Describe(ch)
+		m.elections.Describe(ch)
+		m.electionTimeouts.Describe(ch)
+		m.votes.Describe(ch)
+	}
+	if m.metadata {
+		m.metadataLatency.Describe(ch)
+		m.metadataOperations.Describe(ch)
+		m.metadataErrors.Describe(ch)
+	}
+	if m.locks {
+		m.lockWait.Describe(ch)
+		m.lockHold.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector
+func (m *RaftMetrics) Collect(ch chan<- prometheus.Metric) {
+	if m.node {
+		m.role.Collect(ch)
+		m.status.Collect(ch)
+		m.term.Collect(ch)
+		m.commitIndex.Collect(ch)
+		m.roleTransitions.Collect(ch)
+		m.leaderChanges.Collect(ch)
+		m.elections.Collect(ch)
+		m.electionTimeouts.Collect(ch)
+		m.votes.Collect(ch)
+	}
+	if m.metadata {
+		m.metadataLatency.Collect(ch)
+		m.metadataOperations.Collect(ch)
+		m.metadataErrors.Collect(ch)
+	}
+	if m.locks {
+		m.lockWait.Collect(ch)
+		m.lockHold.Collect(ch)
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.leaderOnly && !m.leader {
+		return
+	}
+	m.followerLag.Collect(ch)
+	m.committed.Collect(ch)
+	m.appendLatency.Collect(ch)
+}
+
+// SetFollowerLag sets the number of entries by which the given follower trails the leader
+func (m *RaftMetrics) SetFollowerLag(follower MemberID, lag Index) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.leaderOnly && !m.leader {
+		return
+	}
+	m.followerLag.WithLabelValues(string(follower)).Set(float64(lag))
+}
+
+// AddCommitted adds the given number of entries to the count of entries committed by the leader
+func (m *RaftMetrics) AddCommitted(count Index) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.leaderOnly && !m.leader {
+		return
+	}
+	m.committed.Add(float64(count))
+}
+
+// ObserveAppend records the latency of an append request to the given follower
+func (m *RaftMetrics) ObserveAppend(follower MemberID, latency time.Duration) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.leaderOnly && !m.leader {
+		return
+	}
+	m.appendLatency.WithLabelValues(string(follower)).Observe(latency.Seconds())
+}
+
+// SetCommitIndex sets the highest index known to be committed by the member
+func (m *RaftMetrics) SetCommitIndex(index Index) {
+	if !m.node {
+		return
+	}
+	m.commitIndex.Set(float64(index))
+}
+
+// IncElections increments the number of elections started by the member
+func (m *RaftMetrics) IncElections() {
+	if !m.node {
+		return
+	}
+	m.elections.Inc()
+}
+
+// IncElectionTimeouts increments the number of heartbeat or election timeouts expired in the given role
+func (m *RaftMetrics) IncElectionTimeouts(role RoleType) {
+	if !m.node {
+		return
+	}
+	m.electionTimeouts.WithLabelValues(string(role)).Inc()
+}
+
+// ObserveVote records whether a vote request was granted by the member
+func (m *RaftMetrics) ObserveVote(granted bool) {
+	if !m.node {
+		return
+	}
+	if granted {
+		m.votes.WithLabelValues("granted").Inc()
+	} else {
+		m.votes.WithLabelValues("rejected").Inc()
+	}
+}
+
+// ObserveMetadata records a metadata store operation with the given latency and error, if any
+func (m *RaftMetrics) ObserveMetadata(operation string, latency time.Duration, err error) {
+	if !m.metadata {
+		return
+	}
+	m.metadataLatency.WithLabelValues(operation).Observe(latency.Seconds())
+	m.metadataOperations.WithLabelValues(operation).Inc()
+	if err != nil {
+		m.metadataErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// ObserveLockWait records the time spent waiting to acquire the Raft state lock in the given mode and role
+func (m *RaftMetrics) ObserveLockWait(mode string, role RoleType, wait time.Duration) {
+	if !m.locks {
+		return
+	}
+	m.lockWait.WithLabelValues(mode, string(role)).Observe(wait.Seconds())
+}
+
+// ObserveLockHold records the time for which the Raft state lock was held in the given mode and role
+func (m *RaftMetrics) ObserveLockHold(mode string, role RoleType, hold time.Duration) {
+	if !m.locks {
+		return
+	}
+	m.lockHold.WithLabelValues(mode, string(role)).Observe(hold.Seconds())
+}
+
+// watch updates the metrics in response to Raft events
+func (m *RaftMetrics) watch(event Event) {
+	if m.node {
+		m.watchNode(event)
+	}
+	if event.Type != EventTypeRole {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.leader = event.Role == RoleLeader
+	if m.leaderOnly && !m.leader {
+		m.followerLag.Reset()
+		m.appendLatency.Reset()
+	}
+}
+
+// watchNode updates the node metrics in response to Raft events
+func (m *RaftMetrics) watchNode(event Event) {
+	m.term.Set(float64(event.Term))
+	switch event.Type {
+	case EventTypeRole:
+		m.role.Reset()
+		m.role.WithLabelValues(string(event.Role)).Set(1)
+		m.roleTransitions.WithLabelValues(string(event.Role)).Inc()
+	case EventTypeStatus:
+		m.status.Reset()
+		m.status.WithLabelValues(string(event.Status)).Set(1)
+	case EventTypeLeader:
+		if event.Leader != nil {
+			m.leaderChanges.Inc()
+		}
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"context"
+	atomix "github.com/atomix/go-framework/pkg/atomix/cluster"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// countMetrics returns the number of metrics reported by the given collector
+func countMetrics(collector prometheus.Collector) int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		collector.Collect(ch)
+		close(ch)
+	}()
+	count := 0
+	for range ch {
+		count++
+	}
+	return count
+}
+
+func newTestMetricsRaft(leaderOnly bool) Raft {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	roles := map[RoleType]func(Raft) Role{
+		RoleFollower: func(r Raft) Role {
+			return &followerRole{&testRole{}}
+		},
+		RoleLeader: func(r Raft) Role {
+			return &leaderRole{&testRole{}}
+		},
+	}
+	config := &config.ProtocolConfig{
+		Metrics: &config.MetricsConfig{
+			LeaderOnly: leaderOnly,
+		},
+	}
+	return newRaft(mustNewCluster(cluster), config, &unimplementedClient{}, roles, newMemoryMetadataStore())
+}
+
+func TestLeaderOnlyMetrics(t *testing.T) {
+	raft := newTestMetricsRaft(true)
+	metrics := raft.Metrics()
+	raft.SetRole(RoleFollower)
+
+	// Verify leader metrics are not populated on a follower
+	metrics.SetFollowerLag("bar", 10)
+	metrics.AddCommitted(1)
+	assert.Equal(t, 0, countMetrics(metrics))
+
+	// Verify leader metrics are populated once the member becomes the leader
+	raft.SetRole(RoleLeader)
+	metrics.SetFollowerLag("bar", 10)
+	metrics.SetFollowerLag("baz", 5)
+	metrics.AddCommitted(2)
+	assert.Equal(t, 3, countMetrics(metrics))
+	assert.Equal(t, float64(10), testutil.ToFloat64(metrics.followerLag.WithLabelValues("bar")))
+	assert.Equal(t, float64(5), testutil.ToFloat64(metrics.followerLag.WithLabelValues("baz")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.committed))
+
+	// Verify follower lag is cleared and leader metrics are absent once the member steps down
+	raft.SetRole(RoleFollower)
+	assert.Equal(t, 0, countMetrics(metrics))
+	metrics.SetFollowerLag("bar", 10)
+	raft.SetRole(RoleLeader)
+	assert.Equal(t, 1, countMetrics(metrics))
+}
+
+func TestMetrics(t *testing.T) {
+	raft := newTestMetricsRaft(false)
+	metrics := raft.Metrics()
+	raft.SetRole(RoleLeader)
+	metrics.SetFollowerLag("bar", 10)
+	metrics.AddCommitted(2)
+
+	// Verify metrics are retained when leader-only metrics are disabled
+	raft.SetRole(RoleFollower)
+	assert.Equal(t, 2, countMetrics(metrics))
+	assert.Equal(t, float64(10), testutil.ToFloat64(metrics.followerLag.WithLabelValues("bar")))
+}
+
+func TestMetadataMetrics(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	config := &config.ProtocolConfig{
+		Metrics: &config.MetricsConfig{
+			Metadata: true,
+		},
+	}
+	raft := newRaft(mustNewCluster(cluster), config, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+	metrics := raft.Metrics()
+
+	// Verify samples are recorded for term and vote updates
+	raft.WriteLock()
+	assert.NoError(t, raft.SetTerm(1))
+	assert.NoError(t, raft.SetLastVotedFor("foo"))
+	raft.WriteUnlock()
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.metadataOperations.WithLabelValues("store_term_and_vote")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.metadataOperations.WithLabelValues("store_vote")))
+	assert.Equal(t, 2, countMetrics(metrics.metadataLatency))
+	assert.Equal(t, 0, countMetrics(metrics.metadataErrors))
+
+	// Verify the metadata metrics are reported along with the committed entries counter
+	assert.Equal(t, 5, countMetrics(metrics))
+
+	// Verify samples are recorded when the metadata store is closed
+	assert.NoError(t, raft.Close())
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.metadataOperations.WithLabelValues("close")))
+	assert.Equal(t, 3, countMetrics(metrics.metadataLatency))
+}
+
+func TestMetadataMetricsDisabled(t *testing.T) {
+	raft := newTestMetricsRaft(false)
+	metrics := raft.Metrics()
+	raft.WriteLock()
+	assert.NoError(t, raft.SetTerm(1))
+	raft.WriteUnlock()
+	assert.Equal(t, 0, countMetrics(metrics.metadataOperations))
+}
+
+// sampleSum returns the sum of the samples observed by the given histogram
+func sampleSum(t *testing.T, histogram prometheus.Observer) float64 {
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, registry.Register(histogram.(prometheus.Collector)))
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	assert.Len(t, families, 1)
+	return families[0].GetMetric()[0].GetHistogram().GetSampleSum()
+}
+
+func TestLockMetrics(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	config := &config.ProtocolConfig{
+		Metrics: &config.MetricsConfig{
+			Locks: true,
+		},
+	}
+	raft := newRaft(mustNewCluster(cluster), config, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+	metrics := raft.Metrics()
+
+	// Hold the write lock while another goroutine waits to acquire a read lock
+	hold := 100 * time.Millisecond
+	raft.WriteLock()
+	locked := make(chan struct{})
+	go func() {
+		raft.ReadLock()
+		raft.ReadUnlock()
+		close(locked)
+	}()
+	time.Sleep(hold)
+	raft.WriteUnlock()
+	<-locked
+
+	// Verify the wait and hold times are recorded
+	assert.True(t, sampleSum(t, metrics.lockWait.WithLabelValues(lockModeRead, "")) >= hold.Seconds()/2)
+	assert.True(t, sampleSum(t, metrics.lockHold.WithLabelValues(lockModeWrite, "")) >= hold.Seconds())
+	assert.Equal(t, 2, countMetrics(metrics.lockWait))
+	assert.Equal(t, 1, countMetrics(metrics.lockHold))
+}
+
+func TestLockMetricsDisabled(t *testing.T) {
+	raft := newTestMetricsRaft(false)
+	metrics := raft.Metrics()
+	raft.WriteLock()
+	raft.WriteUnlock()
+	raft.ReadLock()
+	raft.ReadUnlock()
+	assert.Equal(t, 0, countMetrics(metrics.lockWait))
+	assert.Equal(t, 0, countMetrics(metrics.lockHold))
+}
+
+// votingRole is a follower that grants votes to the candidate "bar"
+type votingRole struct {
+	*followerRole
+}
+
+func (r *votingRole) Vote(ctx context.Context, request *VoteRequest) (*VoteResponse, error) {
+	return &VoteResponse{
+		Status: ResponseStatus_OK,
+		Voted:  request.Candidate == "bar",
+	}, nil
+}
+
+func TestNodeMetrics(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "bar",
+			},
+		},
+	}
+	roles := map[RoleType]func(Raft) Role{
+		RoleFollower: func(r Raft) Role {
+			return &votingRole{&followerRole{&testRole{}}}
+		},
+		RoleLeader: func(r Raft) Role {
+			return &leaderRole{&testRole{}}
+		},
+	}
+	config := &config.ProtocolConfig{
+		Metrics: &config.MetricsConfig{
+			Node: true,
+		},
+	}
+	raft := newRaft(mustNewCluster(cluster), config, &unimplementedClient{}, roles, newMemoryMetadataStore())
+	metrics := raft.Metrics()
+
+	// Verify the role, status, and term are reported once the member starts
+	raft.WriteLock()
+	raft.Init()
+	assert.NoError(t, raft.SetTerm(Term(2)))
+	raft.WriteUnlock()
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.role.WithLabelValues(string(RoleFollower))))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.status.WithLabelValues(string(StatusRunning))))
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.term))
+
+	// Verify role transitions and leader changes are counted
+	leader := MemberID("foo")
+	raft.WriteLock()
+	raft.SetRole(RoleLeader)
+	assert.NoError(t, raft.SetLeader(&leader))
+	raft.SetRole(RoleFollower)
+	assert.NoError(t, raft.SetLeader(nil))
+	raft.WriteUnlock()
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.role.WithLabelValues(string(RoleLeader))))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.role.WithLabelValues(string(RoleFollower))))
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.roleTransitions.WithLabelValues(string(RoleFollower))))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.roleTransitions.WithLabelValues(string(RoleLeader))))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.leaderChanges))
+
+	// Verify the commit index is reported
+	raft.WriteLock()
+	raft.Commit(Index(5), Index(10))
+	raft.WriteUnlock()
+	assert.Equal(t, float64(5), testutil.ToFloat64(metrics.commitIndex))
+
+	// Verify granted and rejected votes are counted
+	_, err := raft.Vote(context.TODO(), &VoteRequest{Term: Term(3), Candidate: "bar"})
+	assert.NoError(t, err)
+	_, err = raft.Vote(context.TODO(), &VoteRequest{Term: Term(3), Candidate: "baz"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.votes.WithLabelValues("granted")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.votes.WithLabelValues("rejected")))
+
+	// Verify election counters are reported
+	metrics.IncElections()
+	metrics.IncElectionTimeouts(RoleFollower)
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.elections))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.electionTimeouts.WithLabelValues(string(RoleFollower))))
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, registry.Register(metrics))
+	_, err = registry.Gather()
+	assert.NoError(t, err)
+}
+
+func TestNodeMetricsDisabled(t *testing.T) {
+	raft := newTestMetricsRaft(false)
+	metrics := raft.Metrics()
+	raft.WriteLock()
+	raft.Init()
+	assert.NoError(t, raft.SetTerm(Term(2)))
+	raft.Commit(Index(1), Index(1))
+	raft.WriteUnlock()
+	metrics.IncElections()
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.term))
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.commitIndex))
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.elections))
+	assert.Equal(t, 0, countMetrics(metrics.role))
+}
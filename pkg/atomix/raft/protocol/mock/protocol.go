@@ -94,6 +94,36 @@ func (mr *MockClientMockRecorder) Reconfigure(ctx, request, member interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconfigure", reflect.TypeOf((*MockClient)(nil).Reconfigure), ctx, request, member)
 }
 
+// SetReadOnly mocks base method
+func (m *MockClient) SetReadOnly(ctx context.Context, request *protocol.SetReadOnlyRequest, member protocol.MemberID) (*protocol.SetReadOnlyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReadOnly", ctx, request, member)
+	ret0, _ := ret[0].(*protocol.SetReadOnlyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReadOnly indicates an expected call of SetReadOnly
+func (mr *MockClientMockRecorder) SetReadOnly(ctx, request, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReadOnly", reflect.TypeOf((*MockClient)(nil).SetReadOnly), ctx, request, member)
+}
+
+// FreezeElections mocks base method
+func (m *MockClient) FreezeElections(ctx context.Context, request *protocol.FreezeElectionsRequest, member protocol.MemberID) (*protocol.FreezeElectionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeElections", ctx, request, member)
+	ret0, _ := ret[0].(*protocol.FreezeElectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeElections indicates an expected call of FreezeElections
+func (mr *MockClientMockRecorder) FreezeElections(ctx, request, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeElections", reflect.TypeOf((*MockClient)(nil).FreezeElections), ctx, request, member)
+}
+
 // Poll mocks base method
 func (m *MockClient) Poll(ctx context.Context, request *protocol.PollRequest, member protocol.MemberID) (*protocol.PollResponse, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +169,36 @@ func (mr *MockClientMockRecorder) Transfer(ctx, request, member interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockClient)(nil).Transfer), ctx, request, member)
 }
 
+// ConfirmLeadership mocks base method
+func (m *MockClient) ConfirmLeadership(ctx context.Context, request *protocol.ConfirmLeadershipRequest, member protocol.MemberID) (*protocol.ConfirmLeadershipResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmLeadership", ctx, request, member)
+	ret0, _ := ret[0].(*protocol.ConfirmLeadershipResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmLeadership indicates an expected call of ConfirmLeadership
+func (mr *MockClientMockRecorder) ConfirmLeadership(ctx, request, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmLeadership", reflect.TypeOf((*MockClient)(nil).ConfirmLeadership), ctx, request, member)
+}
+
+// Hash mocks base method
+func (m *MockClient) Hash(ctx context.Context, request *protocol.HashRequest, member protocol.MemberID) (*protocol.HashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", ctx, request, member)
+	ret0, _ := ret[0].(*protocol.HashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash
+func (mr *MockClientMockRecorder) Hash(ctx, request, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockClient)(nil).Hash), ctx, request, member)
+}
+
 // Append mocks base method
 func (m *MockClient) Append(ctx context.Context, request *protocol.AppendRequest, member protocol.MemberID) (*protocol.AppendResponse, error) {
 	m.ctrl.T.Helper()
@@ -185,6 +245,21 @@ func (mr *MockClientMockRecorder) Command(ctx, request, member interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Command", reflect.TypeOf((*MockClient)(nil).Command), ctx, request, member)
 }
 
+// CommandBatch mocks base method
+func (m *MockClient) CommandBatch(ctx context.Context, request *protocol.CommandBatchRequest, member protocol.MemberID) (*protocol.CommandBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommandBatch", ctx, request, member)
+	ret0, _ := ret[0].(*protocol.CommandBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommandBatch indicates an expected call of CommandBatch
+func (mr *MockClientMockRecorder) CommandBatch(ctx, request, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommandBatch", reflect.TypeOf((*MockClient)(nil).CommandBatch), ctx, request, member)
+}
+
 // Query mocks base method
 func (m *MockClient) Query(ctx context.Context, request *protocol.QueryRequest, member protocol.MemberID) (<-chan *protocol.QueryStreamResponse, error) {
 	m.ctrl.T.Helper()
@@ -283,6 +358,36 @@ func (mr *MockServerMockRecorder) Reconfigure(ctx, request interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconfigure", reflect.TypeOf((*MockServer)(nil).Reconfigure), ctx, request)
 }
 
+// SetReadOnly mocks base method
+func (m *MockServer) SetReadOnly(ctx context.Context, request *protocol.SetReadOnlyRequest) (*protocol.SetReadOnlyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReadOnly", ctx, request)
+	ret0, _ := ret[0].(*protocol.SetReadOnlyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReadOnly indicates an expected call of SetReadOnly
+func (mr *MockServerMockRecorder) SetReadOnly(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReadOnly", reflect.TypeOf((*MockServer)(nil).SetReadOnly), ctx, request)
+}
+
+// FreezeElections mocks base method
+func (m *MockServer) FreezeElections(ctx context.Context, request *protocol.FreezeElectionsRequest) (*protocol.FreezeElectionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeElections", ctx, request)
+	ret0, _ := ret[0].(*protocol.FreezeElectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeElections indicates an expected call of FreezeElections
+func (mr *MockServerMockRecorder) FreezeElections(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeElections", reflect.TypeOf((*MockServer)(nil).FreezeElections), ctx, request)
+}
+
 // Poll mocks base method
 func (m *MockServer) Poll(ctx context.Context, request *protocol.PollRequest) (*protocol.PollResponse, error) {
 	m.ctrl.T.Helper()
@@ -328,6 +433,36 @@ func (mr *MockServerMockRecorder) Transfer(ctx, request interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockServer)(nil).Transfer), ctx, request)
 }
 
+// ConfirmLeadership mocks base method
+func (m *MockServer) ConfirmLeadership(ctx context.Context, request *protocol.ConfirmLeadershipRequest) (*protocol.ConfirmLeadershipResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmLeadership", ctx, request)
+	ret0, _ := ret[0].(*protocol.ConfirmLeadershipResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmLeadership indicates an expected call of ConfirmLeadership
+func (mr *MockServerMockRecorder) ConfirmLeadership(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmLeadership", reflect.TypeOf((*MockServer)(nil).ConfirmLeadership), ctx, request)
+}
+
+// Hash mocks base method
+func (m *MockServer) Hash(ctx context.Context, request *protocol.HashRequest) (*protocol.HashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", ctx, request)
+	ret0, _ := ret[0].(*protocol.HashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash
+func (mr *MockServerMockRecorder) Hash(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockServer)(nil).Hash), ctx, request)
+}
+
 // Append mocks base method
 func (m *MockServer) Append(ctx context.Context, request *protocol.AppendRequest) (*protocol.AppendResponse, error) {
 	m.ctrl.T.Helper()
@@ -372,6 +507,21 @@ func (mr *MockServerMockRecorder) Command(request, ch interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Command", reflect.TypeOf((*MockServer)(nil).Command), request, ch)
 }
 
+// CommandBatch mocks base method
+func (m *MockServer) CommandBatch(ctx context.Context, request *protocol.CommandBatchRequest) (*protocol.CommandBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommandBatch", ctx, request)
+	ret0, _ := ret[0].(*protocol.CommandBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommandBatch indicates an expected call of CommandBatch
+func (mr *MockServerMockRecorder) CommandBatch(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommandBatch", reflect.TypeOf((*MockServer)(nil).CommandBatch), ctx, request)
+}
+
 // Query mocks base method
 func (m *MockServer) Query(request *protocol.QueryRequest, ch chan<- *protocol.QueryStreamResponse) error {
 	m.ctrl.T.Helper()
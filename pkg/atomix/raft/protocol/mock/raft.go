@@ -8,8 +8,11 @@ import (
 	context "context"
 	config "github.com/atomix/raft-replica/pkg/atomix/raft/config"
 	protocol "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	util "github.com/atomix/raft-replica/pkg/atomix/raft/util"
 	gomock "github.com/golang/mock/gomock"
+	rand "math/rand"
 	reflect "reflect"
+	time "time"
 )
 
 // MockRaft is a mock of Raft interface
@@ -95,6 +98,36 @@ func (mr *MockRaftMockRecorder) Reconfigure(ctx, request interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconfigure", reflect.TypeOf((*MockRaft)(nil).Reconfigure), ctx, request)
 }
 
+// SetReadOnly mocks base method
+func (m *MockRaft) SetReadOnly(ctx context.Context, request *protocol.SetReadOnlyRequest) (*protocol.SetReadOnlyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReadOnly", ctx, request)
+	ret0, _ := ret[0].(*protocol.SetReadOnlyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReadOnly indicates an expected call of SetReadOnly
+func (mr *MockRaftMockRecorder) SetReadOnly(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReadOnly", reflect.TypeOf((*MockRaft)(nil).SetReadOnly), ctx, request)
+}
+
+// FreezeElections mocks base method
+func (m *MockRaft) FreezeElections(ctx context.Context, request *protocol.FreezeElectionsRequest) (*protocol.FreezeElectionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeElections", ctx, request)
+	ret0, _ := ret[0].(*protocol.FreezeElectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeElections indicates an expected call of FreezeElections
+func (mr *MockRaftMockRecorder) FreezeElections(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeElections", reflect.TypeOf((*MockRaft)(nil).FreezeElections), ctx, request)
+}
+
 // Poll mocks base method
 func (m *MockRaft) Poll(ctx context.Context, request *protocol.PollRequest) (*protocol.PollResponse, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +173,36 @@ func (mr *MockRaftMockRecorder) Transfer(ctx, request interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockRaft)(nil).Transfer), ctx, request)
 }
 
+// ConfirmLeadership mocks base method
+func (m *MockRaft) ConfirmLeadership(ctx context.Context, request *protocol.ConfirmLeadershipRequest) (*protocol.ConfirmLeadershipResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmLeadership", ctx, request)
+	ret0, _ := ret[0].(*protocol.ConfirmLeadershipResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmLeadership indicates an expected call of ConfirmLeadership
+func (mr *MockRaftMockRecorder) ConfirmLeadership(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmLeadership", reflect.TypeOf((*MockRaft)(nil).ConfirmLeadership), ctx, request)
+}
+
+// Hash mocks base method
+func (m *MockRaft) Hash(ctx context.Context, request *protocol.HashRequest) (*protocol.HashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", ctx, request)
+	ret0, _ := ret[0].(*protocol.HashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash
+func (mr *MockRaftMockRecorder) Hash(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockRaft)(nil).Hash), ctx, request)
+}
+
 // Append mocks base method
 func (m *MockRaft) Append(ctx context.Context, request *protocol.AppendRequest) (*protocol.AppendResponse, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +247,21 @@ func (mr *MockRaftMockRecorder) Command(request, ch interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Command", reflect.TypeOf((*MockRaft)(nil).Command), request, ch)
 }
 
+// CommandBatch mocks base method
+func (m *MockRaft) CommandBatch(ctx context.Context, request *protocol.CommandBatchRequest) (*protocol.CommandBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommandBatch", ctx, request)
+	ret0, _ := ret[0].(*protocol.CommandBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommandBatch indicates an expected call of CommandBatch
+func (mr *MockRaftMockRecorder) CommandBatch(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommandBatch", reflect.TypeOf((*MockRaft)(nil).CommandBatch), ctx, request)
+}
+
 // Query mocks base method
 func (m *MockRaft) Query(request *protocol.QueryRequest, ch chan<- *protocol.QueryStreamResponse) error {
 	m.ctrl.T.Helper()
@@ -198,6 +276,20 @@ func (mr *MockRaftMockRecorder) Query(request, ch interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockRaft)(nil).Query), request, ch)
 }
 
+// Progress mocks base method
+func (m *MockRaft) Progress(ctx context.Context, request *protocol.ProgressRequest, ch chan<- *protocol.ProgressResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress", ctx, request, ch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Progress indicates an expected call of Progress
+func (mr *MockRaftMockRecorder) Progress(ctx, request, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockRaft)(nil).Progress), ctx, request, ch)
+}
+
 // Init mocks base method
 func (m *MockRaft) Init() {
 	m.ctrl.T.Helper()
@@ -210,6 +302,44 @@ func (mr *MockRaftMockRecorder) Init() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockRaft)(nil).Init))
 }
 
+// Watch mocks base method
+func (m *MockRaft) Watch(arg0 func(protocol.Event)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Watch", arg0)
+}
+
+// Watch indicates an expected call of Watch
+func (mr *MockRaftMockRecorder) Watch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockRaft)(nil).Watch), arg0)
+}
+
+// AuthorizeCommands mocks base method
+func (m *MockRaft) AuthorizeCommands(authorizer protocol.CommandAuthorizer) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AuthorizeCommands", authorizer)
+}
+
+// AuthorizeCommands indicates an expected call of AuthorizeCommands
+func (mr *MockRaftMockRecorder) AuthorizeCommands(authorizer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeCommands", reflect.TypeOf((*MockRaft)(nil).AuthorizeCommands), authorizer)
+}
+
+// AuthorizeCommand mocks base method
+func (m *MockRaft) AuthorizeCommand(request *protocol.CommandRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeCommand", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeCommand indicates an expected call of AuthorizeCommand
+func (mr *MockRaftMockRecorder) AuthorizeCommand(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeCommand", reflect.TypeOf((*MockRaft)(nil).AuthorizeCommand), request)
+}
+
 // Role mocks base method
 func (m *MockRaft) Role() protocol.RoleType {
 	m.ctrl.T.Helper()
@@ -224,18 +354,6 @@ func (mr *MockRaftMockRecorder) Role() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Role", reflect.TypeOf((*MockRaft)(nil).Role))
 }
 
-// WatchRole mocks base method
-func (m *MockRaft) WatchRole(arg0 func(protocol.RoleType)) {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "WatchRole", arg0)
-}
-
-// WatchRole indicates an expected call of WatchRole
-func (mr *MockRaftMockRecorder) WatchRole(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchRole", reflect.TypeOf((*MockRaft)(nil).WatchRole), arg0)
-}
-
 // Status mocks base method
 func (m *MockRaft) Status() protocol.Status {
 	m.ctrl.T.Helper()
@@ -250,18 +368,6 @@ func (mr *MockRaftMockRecorder) Status() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockRaft)(nil).Status))
 }
 
-// WatchStatus mocks base method
-func (m *MockRaft) WatchStatus(arg0 func(protocol.Status)) {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "WatchStatus", arg0)
-}
-
-// WatchStatus indicates an expected call of WatchStatus
-func (mr *MockRaftMockRecorder) WatchStatus(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchStatus", reflect.TypeOf((*MockRaft)(nil).WatchStatus), arg0)
-}
-
 // Config mocks base method
 func (m *MockRaft) Config() *config.ProtocolConfig {
 	m.ctrl.T.Helper()
@@ -276,6 +382,84 @@ func (mr *MockRaftMockRecorder) Config() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockRaft)(nil).Config))
 }
 
+// Metrics mocks base method
+func (m *MockRaft) Metrics() *protocol.RaftMetrics {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Metrics")
+	ret0, _ := ret[0].(*protocol.RaftMetrics)
+	return ret0
+}
+
+// Metrics indicates an expected call of Metrics
+func (mr *MockRaftMockRecorder) Metrics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metrics", reflect.TypeOf((*MockRaft)(nil).Metrics))
+}
+
+// Clock mocks base method
+func (m *MockRaft) Clock() protocol.Clock {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clock")
+	ret0, _ := ret[0].(protocol.Clock)
+	return ret0
+}
+
+// Clock indicates an expected call of Clock
+func (mr *MockRaftMockRecorder) Clock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clock", reflect.TypeOf((*MockRaft)(nil).Clock))
+}
+
+// SetClock mocks base method
+func (m *MockRaft) SetClock(clock protocol.Clock) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetClock", clock)
+}
+
+// SetClock indicates an expected call of SetClock
+func (mr *MockRaftMockRecorder) SetClock(clock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClock", reflect.TypeOf((*MockRaft)(nil).SetClock), clock)
+}
+
+// SetRandomSource mocks base method
+func (m *MockRaft) SetRandomSource(source rand.Source) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRandomSource", source)
+}
+
+// SetRandomSource indicates an expected call of SetRandomSource
+func (mr *MockRaftMockRecorder) SetRandomSource(source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRandomSource", reflect.TypeOf((*MockRaft)(nil).SetRandomSource), source)
+}
+
+// RandomDuration mocks base method
+func (m *MockRaft) RandomDuration(n time.Duration) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RandomDuration", n)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// RandomDuration indicates an expected call of RandomDuration
+func (mr *MockRaftMockRecorder) RandomDuration(n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RandomDuration", reflect.TypeOf((*MockRaft)(nil).RandomDuration), n)
+}
+
+// SetAuditSink mocks base method
+func (m *MockRaft) SetAuditSink(sink protocol.AuditSink) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAuditSink", sink)
+}
+
+// SetAuditSink indicates an expected call of SetAuditSink
+func (mr *MockRaftMockRecorder) SetAuditSink(sink interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAuditSink", reflect.TypeOf((*MockRaft)(nil).SetAuditSink), sink)
+}
+
 // Member mocks base method
 func (m *MockRaft) Member() protocol.MemberID {
 	m.ctrl.T.Helper()
@@ -304,6 +488,62 @@ func (mr *MockRaftMockRecorder) Members() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*MockRaft)(nil).Members))
 }
 
+// VotingMembers mocks base method
+func (m *MockRaft) VotingMembers() []protocol.MemberID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VotingMembers")
+	ret0, _ := ret[0].([]protocol.MemberID)
+	return ret0
+}
+
+// VotingMembers indicates an expected call of VotingMembers
+func (mr *MockRaftMockRecorder) VotingMembers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VotingMembers", reflect.TypeOf((*MockRaft)(nil).VotingMembers))
+}
+
+// IsVoter mocks base method
+func (m *MockRaft) IsVoter(memberID protocol.MemberID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsVoter", memberID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsVoter indicates an expected call of IsVoter
+func (mr *MockRaftMockRecorder) IsVoter(memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsVoter", reflect.TypeOf((*MockRaft)(nil).IsVoter), memberID)
+}
+
+// VoterSets mocks base method
+func (m *MockRaft) VoterSets() [][]protocol.MemberID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoterSets")
+	ret0, _ := ret[0].([][]protocol.MemberID)
+	return ret0
+}
+
+// VoterSets indicates an expected call of VoterSets
+func (mr *MockRaftMockRecorder) VoterSets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoterSets", reflect.TypeOf((*MockRaft)(nil).VoterSets))
+}
+
+// IsWitness mocks base method
+func (m *MockRaft) IsWitness(memberID protocol.MemberID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsWitness", memberID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsWitness indicates an expected call of IsWitness
+func (mr *MockRaftMockRecorder) IsWitness(memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWitness", reflect.TypeOf((*MockRaft)(nil).IsWitness), memberID)
+}
+
 // GetMember mocks base method
 func (m *MockRaft) GetMember(memberID protocol.MemberID) *protocol.Member {
 	m.ctrl.T.Helper()
@@ -318,6 +558,20 @@ func (mr *MockRaftMockRecorder) GetMember(memberID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockRaft)(nil).GetMember), memberID)
 }
 
+// NewLogger mocks base method
+func (m *MockRaft) NewLogger(role protocol.RoleType) util.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewLogger", role)
+	ret0, _ := ret[0].(util.Logger)
+	return ret0
+}
+
+// NewLogger indicates an expected call of NewLogger
+func (mr *MockRaftMockRecorder) NewLogger(role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewLogger", reflect.TypeOf((*MockRaft)(nil).NewLogger), role)
+}
+
 // Protocol mocks base method
 func (m *MockRaft) Protocol() protocol.Client {
 	m.ctrl.T.Helper()
@@ -388,6 +642,34 @@ func (mr *MockRaftMockRecorder) SetLeader(leader interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLeader", reflect.TypeOf((*MockRaft)(nil).SetLeader), leader)
 }
 
+// LastLeader mocks base method
+func (m *MockRaft) LastLeader() *protocol.MemberID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastLeader")
+	ret0, _ := ret[0].(*protocol.MemberID)
+	return ret0
+}
+
+// LastLeader indicates an expected call of LastLeader
+func (mr *MockRaftMockRecorder) LastLeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastLeader", reflect.TypeOf((*MockRaft)(nil).LastLeader))
+}
+
+// LastElection mocks base method
+func (m *MockRaft) LastElection() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastElection")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastElection indicates an expected call of LastElection
+func (mr *MockRaftMockRecorder) LastElection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastElection", reflect.TypeOf((*MockRaft)(nil).LastElection))
+}
+
 // LastVotedFor mocks base method
 func (m *MockRaft) LastVotedFor() *protocol.MemberID {
 	m.ctrl.T.Helper()
@@ -416,6 +698,20 @@ func (mr *MockRaftMockRecorder) SetLastVotedFor(memberID interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastVotedFor", reflect.TypeOf((*MockRaft)(nil).SetLastVotedFor), memberID)
 }
 
+// SetTermAndVote mocks base method
+func (m *MockRaft) SetTermAndVote(term protocol.Term, memberID protocol.MemberID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTermAndVote", term, memberID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTermAndVote indicates an expected call of SetTermAndVote
+func (mr *MockRaftMockRecorder) SetTermAndVote(term, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTermAndVote", reflect.TypeOf((*MockRaft)(nil).SetTermAndVote), term, memberID)
+}
+
 // CommitIndex mocks base method
 func (m *MockRaft) CommitIndex() protocol.Index {
 	m.ctrl.T.Helper()
@@ -443,17 +739,396 @@ func (mr *MockRaftMockRecorder) SetCommitIndex(index interface{}) *gomock.Call {
 }
 
 // Commit mocks base method
-func (m *MockRaft) Commit(index protocol.Index) protocol.Index {
+func (m *MockRaft) Commit(index, lastIndex protocol.Index) protocol.Index {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Commit", index)
+	ret := m.ctrl.Call(m, "Commit", index, lastIndex)
 	ret0, _ := ret[0].(protocol.Index)
 	return ret0
 }
 
 // Commit indicates an expected call of Commit
-func (mr *MockRaftMockRecorder) Commit(index interface{}) *gomock.Call {
+func (mr *MockRaftMockRecorder) Commit(index, lastIndex interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockRaft)(nil).Commit), index, lastIndex)
+}
+
+// SetAppliedIndex mocks base method
+func (m *MockRaft) SetAppliedIndex(index protocol.Index) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAppliedIndex", index)
+}
+
+// SetAppliedIndex indicates an expected call of SetAppliedIndex
+func (mr *MockRaftMockRecorder) SetAppliedIndex(index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAppliedIndex", reflect.TypeOf((*MockRaft)(nil).SetAppliedIndex), index)
+}
+
+// AppliedIndex mocks base method
+func (m *MockRaft) AppliedIndex() protocol.Index {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppliedIndex")
+	ret0, _ := ret[0].(protocol.Index)
+	return ret0
+}
+
+// AppliedIndex indicates an expected call of AppliedIndex
+func (mr *MockRaftMockRecorder) AppliedIndex() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppliedIndex", reflect.TypeOf((*MockRaft)(nil).AppliedIndex))
+}
+
+// SetLag mocks base method
+func (m *MockRaft) SetLag(lag protocol.Index) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLag", lag)
+}
+
+// SetLag indicates an expected call of SetLag
+func (mr *MockRaftMockRecorder) SetLag(lag interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockRaft)(nil).Commit), index)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLag", reflect.TypeOf((*MockRaft)(nil).SetLag), lag)
+}
+
+// Lag mocks base method
+func (m *MockRaft) Lag() protocol.Index {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lag")
+	ret0, _ := ret[0].(protocol.Index)
+	return ret0
+}
+
+// Lag indicates an expected call of Lag
+func (mr *MockRaftMockRecorder) Lag() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lag", reflect.TypeOf((*MockRaft)(nil).Lag))
+}
+
+// Heartbeat mocks base method
+func (m *MockRaft) Heartbeat(leaderCommitIndex protocol.Index) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Heartbeat", leaderCommitIndex)
+}
+
+// Heartbeat indicates an expected call of Heartbeat
+func (mr *MockRaftMockRecorder) Heartbeat(leaderCommitIndex interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockRaft)(nil).Heartbeat), leaderCommitIndex)
+}
+
+// Health mocks base method
+func (m *MockRaft) Health() protocol.Health {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health")
+	ret0, _ := ret[0].(protocol.Health)
+	return ret0
+}
+
+// Health indicates an expected call of Health
+func (mr *MockRaftMockRecorder) Health() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockRaft)(nil).Health))
+}
+
+// ApplyStalled mocks base method
+func (m *MockRaft) ApplyStalled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyStalled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ApplyStalled indicates an expected call of ApplyStalled
+func (mr *MockRaftMockRecorder) ApplyStalled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyStalled", reflect.TypeOf((*MockRaft)(nil).ApplyStalled))
+}
+
+// QuorumIndex mocks base method
+func (m *MockRaft) QuorumIndex() protocol.Index {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuorumIndex")
+	ret0, _ := ret[0].(protocol.Index)
+	return ret0
+}
+
+// QuorumIndex indicates an expected call of QuorumIndex
+func (mr *MockRaftMockRecorder) QuorumIndex() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuorumIndex", reflect.TypeOf((*MockRaft)(nil).QuorumIndex))
+}
+
+// SetQuorumIndex mocks base method
+func (m *MockRaft) SetQuorumIndex(index protocol.Index) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetQuorumIndex", index)
+}
+
+// SetQuorumIndex indicates an expected call of SetQuorumIndex
+func (mr *MockRaftMockRecorder) SetQuorumIndex(index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetQuorumIndex", reflect.TypeOf((*MockRaft)(nil).SetQuorumIndex), index)
+}
+
+// Configuration mocks base method
+func (m *MockRaft) Configuration() (*protocol.Configuration, *protocol.Configuration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Configuration")
+	ret0, _ := ret[0].(*protocol.Configuration)
+	ret1, _ := ret[1].(*protocol.Configuration)
+	return ret0, ret1
+}
+
+// Configuration indicates an expected call of Configuration
+func (mr *MockRaftMockRecorder) Configuration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configuration", reflect.TypeOf((*MockRaft)(nil).Configuration))
+}
+
+// SetConfiguration mocks base method
+func (m *MockRaft) SetConfiguration(configuration *protocol.Configuration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetConfiguration", configuration)
+}
+
+// SetConfiguration indicates an expected call of SetConfiguration
+func (mr *MockRaftMockRecorder) SetConfiguration(configuration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConfiguration", reflect.TypeOf((*MockRaft)(nil).SetConfiguration), configuration)
+}
+
+// IsReadOnly mocks base method
+func (m *MockRaft) IsReadOnly() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReadOnly")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsReadOnly indicates an expected call of IsReadOnly
+func (mr *MockRaftMockRecorder) IsReadOnly() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReadOnly", reflect.TypeOf((*MockRaft)(nil).IsReadOnly))
+}
+
+// ReadOnlyMode mocks base method
+func (m *MockRaft) ReadOnlyMode() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadOnlyMode")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ReadOnlyMode indicates an expected call of ReadOnlyMode
+func (mr *MockRaftMockRecorder) ReadOnlyMode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOnlyMode", reflect.TypeOf((*MockRaft)(nil).ReadOnlyMode))
+}
+
+// SetReadOnlyMode mocks base method
+func (m *MockRaft) SetReadOnlyMode(index protocol.Index, readOnly bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetReadOnlyMode", index, readOnly)
+}
+
+// SetReadOnlyMode indicates an expected call of SetReadOnlyMode
+func (mr *MockRaftMockRecorder) SetReadOnlyMode(index, readOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReadOnlyMode", reflect.TypeOf((*MockRaft)(nil).SetReadOnlyMode), index, readOnly)
+}
+
+// DiscardReadOnlyMode mocks base method
+func (m *MockRaft) DiscardReadOnlyMode(index protocol.Index) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DiscardReadOnlyMode", index)
+}
+
+// DiscardReadOnlyMode indicates an expected call of DiscardReadOnlyMode
+func (mr *MockRaftMockRecorder) DiscardReadOnlyMode(index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardReadOnlyMode", reflect.TypeOf((*MockRaft)(nil).DiscardReadOnlyMode), index)
+}
+
+// ElectionsFrozen mocks base method
+func (m *MockRaft) ElectionsFrozen() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ElectionsFrozen")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ElectionsFrozen indicates an expected call of ElectionsFrozen
+func (mr *MockRaftMockRecorder) ElectionsFrozen() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ElectionsFrozen", reflect.TypeOf((*MockRaft)(nil).ElectionsFrozen))
+}
+
+// ElectionFreeze mocks base method
+func (m *MockRaft) ElectionFreeze() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ElectionFreeze")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// ElectionFreeze indicates an expected call of ElectionFreeze
+func (mr *MockRaftMockRecorder) ElectionFreeze() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ElectionFreeze", reflect.TypeOf((*MockRaft)(nil).ElectionFreeze))
+}
+
+// SetElectionFreeze mocks base method
+func (m *MockRaft) SetElectionFreeze(index protocol.Index, duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetElectionFreeze", index, duration)
+}
+
+// SetElectionFreeze indicates an expected call of SetElectionFreeze
+func (mr *MockRaftMockRecorder) SetElectionFreeze(index, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetElectionFreeze", reflect.TypeOf((*MockRaft)(nil).SetElectionFreeze), index, duration)
+}
+
+// DiscardElectionFreeze mocks base method
+func (m *MockRaft) DiscardElectionFreeze(index protocol.Index) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DiscardElectionFreeze", index)
+}
+
+// DiscardElectionFreeze indicates an expected call of DiscardElectionFreeze
+func (mr *MockRaftMockRecorder) DiscardElectionFreeze(index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardElectionFreeze", reflect.TypeOf((*MockRaft)(nil).DiscardElectionFreeze), index)
+}
+
+// SetTiming mocks base method
+func (m *MockRaft) SetTiming(index protocol.Index, electionTimeout, heartbeatInterval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTiming", index, electionTimeout, heartbeatInterval)
+}
+
+// SetTiming indicates an expected call of SetTiming
+func (mr *MockRaftMockRecorder) SetTiming(index, electionTimeout, heartbeatInterval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTiming", reflect.TypeOf((*MockRaft)(nil).SetTiming), index, electionTimeout, heartbeatInterval)
+}
+
+// DiscardTiming mocks base method
+func (m *MockRaft) DiscardTiming(index protocol.Index) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DiscardTiming", index)
+}
+
+// DiscardTiming indicates an expected call of DiscardTiming
+func (mr *MockRaftMockRecorder) DiscardTiming(index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardTiming", reflect.TypeOf((*MockRaft)(nil).DiscardTiming), index)
+}
+
+// ConfiguredElectionTimeout mocks base method
+func (m *MockRaft) ConfiguredElectionTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfiguredElectionTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// ConfiguredElectionTimeout indicates an expected call of ConfiguredElectionTimeout
+func (mr *MockRaftMockRecorder) ConfiguredElectionTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfiguredElectionTimeout", reflect.TypeOf((*MockRaft)(nil).ConfiguredElectionTimeout))
+}
+
+// HeartbeatInterval mocks base method
+func (m *MockRaft) HeartbeatInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeartbeatInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// HeartbeatInterval indicates an expected call of HeartbeatInterval
+func (mr *MockRaftMockRecorder) HeartbeatInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeartbeatInterval", reflect.TypeOf((*MockRaft)(nil).HeartbeatInterval))
+}
+
+// ElectionBackoff mocks base method
+func (m *MockRaft) ElectionBackoff(member protocol.MemberID) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ElectionBackoff", member)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// ElectionBackoff indicates an expected call of ElectionBackoff
+func (mr *MockRaftMockRecorder) ElectionBackoff(member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ElectionBackoff", reflect.TypeOf((*MockRaft)(nil).ElectionBackoff), member)
+}
+
+// ObserveRoundTrip mocks base method
+func (m *MockRaft) ObserveRoundTrip(member protocol.MemberID, rtt time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveRoundTrip", member, rtt)
+}
+
+// ObserveRoundTrip indicates an expected call of ObserveRoundTrip
+func (mr *MockRaftMockRecorder) ObserveRoundTrip(member, rtt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveRoundTrip", reflect.TypeOf((*MockRaft)(nil).ObserveRoundTrip), member, rtt)
+}
+
+// ElectionTimeout mocks base method
+func (m *MockRaft) ElectionTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ElectionTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// ElectionTimeout indicates an expected call of ElectionTimeout
+func (mr *MockRaftMockRecorder) ElectionTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ElectionTimeout", reflect.TypeOf((*MockRaft)(nil).ElectionTimeout))
+}
+
+// ReportTruncation mocks base method
+func (m *MockRaft) ReportTruncation(member protocol.MemberID, truncated uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReportTruncation", member, truncated)
+}
+
+// ReportTruncation indicates an expected call of ReportTruncation
+func (mr *MockRaftMockRecorder) ReportTruncation(member, truncated interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportTruncation", reflect.TypeOf((*MockRaft)(nil).ReportTruncation), member, truncated)
+}
+
+// IsConsistent mocks base method
+func (m *MockRaft) IsConsistent() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsConsistent")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsConsistent indicates an expected call of IsConsistent
+func (mr *MockRaftMockRecorder) IsConsistent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConsistent", reflect.TypeOf((*MockRaft)(nil).IsConsistent))
+}
+
+// SetConsistent mocks base method
+func (m *MockRaft) SetConsistent(consistent bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetConsistent", consistent)
+}
+
+// SetConsistent indicates an expected call of SetConsistent
+func (mr *MockRaftMockRecorder) SetConsistent(consistent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConsistent", reflect.TypeOf((*MockRaft)(nil).SetConsistent), consistent)
 }
 
 // WriteLock mocks base method
@@ -505,7 +1180,7 @@ func (mr *MockRaftMockRecorder) ReadUnlock() *gomock.Call {
 }
 
 // SetRole mocks base method
-func (m *MockRaft) SetRole(role protocol.Role) {
+func (m *MockRaft) SetRole(role protocol.RoleType) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "SetRole", role)
 }
@@ -516,6 +1191,20 @@ func (mr *MockRaftMockRecorder) SetRole(role interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockRaft)(nil).SetRole), role)
 }
 
+// TransitionRole mocks base method
+func (m *MockRaft) TransitionRole(role protocol.RoleType, term protocol.Term) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionRole", role, term)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TransitionRole indicates an expected call of TransitionRole
+func (mr *MockRaftMockRecorder) TransitionRole(role, term interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionRole", reflect.TypeOf((*MockRaft)(nil).TransitionRole), role, term)
+}
+
 // Close mocks base method
 func (m *MockRaft) Close() error {
 	m.ctrl.T.Helper()
@@ -613,6 +1302,36 @@ func (mr *MockRoleMockRecorder) Reconfigure(ctx, request interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconfigure", reflect.TypeOf((*MockRole)(nil).Reconfigure), ctx, request)
 }
 
+// SetReadOnly mocks base method
+func (m *MockRole) SetReadOnly(ctx context.Context, request *protocol.SetReadOnlyRequest) (*protocol.SetReadOnlyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReadOnly", ctx, request)
+	ret0, _ := ret[0].(*protocol.SetReadOnlyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReadOnly indicates an expected call of SetReadOnly
+func (mr *MockRoleMockRecorder) SetReadOnly(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReadOnly", reflect.TypeOf((*MockRole)(nil).SetReadOnly), ctx, request)
+}
+
+// FreezeElections mocks base method
+func (m *MockRole) FreezeElections(ctx context.Context, request *protocol.FreezeElectionsRequest) (*protocol.FreezeElectionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeElections", ctx, request)
+	ret0, _ := ret[0].(*protocol.FreezeElectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeElections indicates an expected call of FreezeElections
+func (mr *MockRoleMockRecorder) FreezeElections(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeElections", reflect.TypeOf((*MockRole)(nil).FreezeElections), ctx, request)
+}
+
 // Poll mocks base method
 func (m *MockRole) Poll(ctx context.Context, request *protocol.PollRequest) (*protocol.PollResponse, error) {
 	m.ctrl.T.Helper()
@@ -658,6 +1377,36 @@ func (mr *MockRoleMockRecorder) Transfer(ctx, request interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockRole)(nil).Transfer), ctx, request)
 }
 
+// ConfirmLeadership mocks base method
+func (m *MockRole) ConfirmLeadership(ctx context.Context, request *protocol.ConfirmLeadershipRequest) (*protocol.ConfirmLeadershipResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmLeadership", ctx, request)
+	ret0, _ := ret[0].(*protocol.ConfirmLeadershipResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmLeadership indicates an expected call of ConfirmLeadership
+func (mr *MockRoleMockRecorder) ConfirmLeadership(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmLeadership", reflect.TypeOf((*MockRole)(nil).ConfirmLeadership), ctx, request)
+}
+
+// Hash mocks base method
+func (m *MockRole) Hash(ctx context.Context, request *protocol.HashRequest) (*protocol.HashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", ctx, request)
+	ret0, _ := ret[0].(*protocol.HashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash
+func (mr *MockRoleMockRecorder) Hash(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockRole)(nil).Hash), ctx, request)
+}
+
 // Append mocks base method
 func (m *MockRole) Append(ctx context.Context, request *protocol.AppendRequest) (*protocol.AppendResponse, error) {
 	m.ctrl.T.Helper()
@@ -702,6 +1451,21 @@ func (mr *MockRoleMockRecorder) Command(request, ch interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Command", reflect.TypeOf((*MockRole)(nil).Command), request, ch)
 }
 
+// CommandBatch mocks base method
+func (m *MockRole) CommandBatch(ctx context.Context, request *protocol.CommandBatchRequest) (*protocol.CommandBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommandBatch", ctx, request)
+	ret0, _ := ret[0].(*protocol.CommandBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommandBatch indicates an expected call of CommandBatch
+func (mr *MockRoleMockRecorder) CommandBatch(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommandBatch", reflect.TypeOf((*MockRole)(nil).CommandBatch), ctx, request)
+}
+
 // Query mocks base method
 func (m *MockRole) Query(request *protocol.QueryRequest, ch chan<- *protocol.QueryStreamResponse) error {
 	m.ctrl.T.Helper()
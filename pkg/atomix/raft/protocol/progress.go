@@ -0,0 +1,169 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ProgressServer is an interface for streaming the commit and applied indexes of a Raft node
+type ProgressServer interface {
+	// Progress streams commit and applied index updates on the given channel until the context is done.
+	// Updates are coalesced for slow consumers; indexes are always delivered in monotonic order.
+	Progress(ctx context.Context, request *ProgressRequest, ch chan<- *ProgressResponse) error
+}
+
+// newProgress returns a new progress tracker
+func newProgress() *progress {
+	return &progress{
+		watchers: make(map[chan struct{}]bool),
+	}
+}
+
+// progress tracks the commit and applied indexes and notifies watchers of changes
+type progress struct {
+	commitIndex  Index
+	appliedIndex Index
+	// lag is the number of entries by which the local log trails the leader's log
+	lag Index
+	// applyTime is the time at which the applied index last advanced or the applied index fell behind
+	// the commit index, whichever is later
+	applyTime time.Time
+	// heartbeatTime is the time of the last successful heartbeat and leaderCommitIndex is the leader's
+	// commit index as of that heartbeat
+	heartbeatTime     time.Time
+	leaderCommitIndex Index
+	watchers          map[chan struct{}]bool
+	mu                sync.RWMutex
+}
+
+// setCommitIndex updates the commit index if the given index is greater than the current commit index
+func (p *progress) setCommitIndex(index Index) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if index > p.commitIndex {
+		if p.appliedIndex >= p.commitIndex {
+			p.applyTime = time.Now()
+		}
+		p.commitIndex = index
+		p.notify()
+	}
+}
+
+// setAppliedIndex updates the applied index if the given index is greater than the current applied index
+func (p *progress) setAppliedIndex(index Index) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if index > p.appliedIndex {
+		p.appliedIndex = index
+		p.applyTime = time.Now()
+		p.notify()
+	}
+}
+
+// setLag updates the number of entries by which the local log trails the leader's log
+func (p *progress) setLag(lag Index) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if lag != p.lag {
+		p.lag = lag
+		p.notify()
+	}
+}
+
+// heartbeat records a successful heartbeat carrying the given leader commit index
+func (p *progress) heartbeat(leaderCommitIndex Index) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.heartbeatTime = time.Now()
+	if leaderCommitIndex > p.leaderCommitIndex {
+		p.leaderCommitIndex = leaderCommitIndex
+	}
+}
+
+// getHeartbeat returns the time of the last successful heartbeat and the leader's commit index
+func (p *progress) getHeartbeat() (time.Time, Index) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.heartbeatTime, p.leaderCommitIndex
+}
+
+// stalled returns whether committed entries have not been applied for longer than the given timeout
+func (p *progress) stalled(timeout time.Duration) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.appliedIndex < p.commitIndex && time.Since(p.applyTime) > timeout
+}
+
+// notify signals watchers without blocking. Each watcher's channel is buffered, so a watcher that has
+// not yet consumed a prior signal reads the latest indexes when it next wakes.
+func (p *progress) notify() {
+	for ch := range p.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// get returns the current commit and applied indexes and the lag
+func (p *progress) get() *ProgressResponse {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return &ProgressResponse{
+		CommitIndex:  p.commitIndex,
+		AppliedIndex: p.appliedIndex,
+		Lag:          p.lag,
+	}
+}
+
+// watch sends the current indexes and subsequent updates on the given channel until the context is done
+func (p *progress) watch(ctx context.Context, ch chan<- *ProgressResponse) {
+	notifyCh := make(chan struct{}, 1)
+	notifyCh <- struct{}{}
+	p.mu.Lock()
+	p.watchers[notifyCh] = true
+	p.mu.Unlock()
+
+	go func() {
+		defer func() {
+			p.mu.Lock()
+			delete(p.watchers, notifyCh)
+			p.mu.Unlock()
+			close(ch)
+		}()
+
+		var prev *ProgressResponse
+		for {
+			select {
+			case <-notifyCh:
+				next := p.get()
+				if prev != nil && next.CommitIndex == prev.CommitIndex && next.AppliedIndex == prev.AppliedIndex && next.Lag == prev.Lag {
+					continue
+				}
+				select {
+				case ch <- next:
+					prev = next
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,115 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"context"
+	atomix "github.com/atomix/go-framework/pkg/atomix/cluster"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRaftProgress(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan *ProgressResponse)
+	assert.NoError(t, raft.Progress(ctx, &ProgressRequest{}, ch))
+
+	// Verify the current indexes are sent when the stream is opened
+	response := <-ch
+	assert.Equal(t, Index(0), response.CommitIndex)
+	assert.Equal(t, Index(0), response.AppliedIndex)
+
+	// Verify updates do not block while the consumer is not reading from the stream
+	for i := Index(1); i <= 1000; i++ {
+		raft.Commit(i, i)
+		raft.SetAppliedIndex(i)
+	}
+
+	// Verify updates are delivered in monotonic order up to the latest indexes
+	prev := response
+	for prev.CommitIndex < 1000 || prev.AppliedIndex < 1000 {
+		response = <-ch
+		assert.True(t, response.CommitIndex >= prev.CommitIndex)
+		assert.True(t, response.AppliedIndex >= prev.AppliedIndex)
+		assert.False(t, response.CommitIndex == prev.CommitIndex && response.AppliedIndex == prev.AppliedIndex)
+		prev = response
+	}
+
+	// Verify stale indexes are ignored
+	raft.SetAppliedIndex(500)
+	raft.Commit(1001, 1001)
+	response = <-ch
+	assert.Equal(t, Index(1001), response.CommitIndex)
+	assert.Equal(t, Index(1000), response.AppliedIndex)
+
+	// Verify changes to the lag behind the leader are sent
+	raft.SetLag(5)
+	response = <-ch
+	assert.Equal(t, Index(5), response.Lag)
+	assert.Equal(t, Index(5), raft.Lag())
+
+	// Verify the stream is closed once the context is done
+	cancel()
+	for range ch {
+	}
+}
+
+func TestRaftApplyStalled(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	stallTimeout := 100 * time.Millisecond
+	config := &config.ProtocolConfig{
+		Apply: &config.ApplyConfig{
+			StallTimeout: &stallTimeout,
+		},
+	}
+	raft := newRaft(mustNewCluster(cluster), config, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+
+	// Verify the apply loop is not stalled while caught up with the commit index
+	time.Sleep(2 * stallTimeout)
+	assert.False(t, raft.ApplyStalled())
+
+	// Verify the apply loop is stalled once committed entries are not applied within the timeout
+	raft.Commit(1, 1)
+	assert.False(t, raft.ApplyStalled())
+	time.Sleep(2 * stallTimeout)
+	assert.True(t, raft.ApplyStalled())
+
+	// Verify the stall clears once the apply loop makes progress
+	raft.Commit(2, 2)
+	raft.SetAppliedIndex(1)
+	assert.False(t, raft.ApplyStalled())
+	raft.SetAppliedIndex(2)
+	time.Sleep(2 * stallTimeout)
+	assert.False(t, raft.ApplyStalled())
+}
@@ -16,12 +16,21 @@ package protocol
 
 import (
 	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"io"
+	"time"
 )
 
 // NewClient creates a new Raft protocol client
 func NewClient(cluster Cluster) Client {
-	return &gRPCClient{cluster}
+	return &gRPCClient{cluster: cluster}
+}
+
+// NewGroupClient creates a new Raft protocol client that sends messages to the given group on each node
+func NewGroupClient(cluster Cluster, group GroupID) Client {
+	return &gRPCClient{cluster: cluster, group: group}
 }
 
 // NewServer creates a new RaftServiceServer for the given Server
@@ -43,6 +52,12 @@ type Client interface {
 	// Reconfigure sends a reconfigure request
 	Reconfigure(ctx context.Context, request *ReconfigureRequest, member MemberID) (*ReconfigureResponse, error)
 
+	// SetReadOnly sends a set read-only request
+	SetReadOnly(ctx context.Context, request *SetReadOnlyRequest, member MemberID) (*SetReadOnlyResponse, error)
+
+	// FreezeElections sends an election freeze request
+	FreezeElections(ctx context.Context, request *FreezeElectionsRequest, member MemberID) (*FreezeElectionsResponse, error)
+
 	// Poll sends a poll request
 	Poll(ctx context.Context, request *PollRequest, member MemberID) (*PollResponse, error)
 
@@ -52,6 +67,12 @@ type Client interface {
 	// Transfer sends a leadership transfer request
 	Transfer(ctx context.Context, request *TransferRequest, member MemberID) (*TransferResponse, error)
 
+	// ConfirmLeadership sends a leadership confirmation request
+	ConfirmLeadership(ctx context.Context, request *ConfirmLeadershipRequest, member MemberID) (*ConfirmLeadershipResponse, error)
+
+	// Hash sends a log hash request
+	Hash(ctx context.Context, request *HashRequest, member MemberID) (*HashResponse, error)
+
 	// Append sends an append request
 	Append(ctx context.Context, request *AppendRequest, member MemberID) (*AppendResponse, error)
 
@@ -61,6 +82,9 @@ type Client interface {
 	// Command sends a command request
 	Command(ctx context.Context, request *CommandRequest, member MemberID) (<-chan *CommandStreamResponse, error)
 
+	// CommandBatch sends a command batch request
+	CommandBatch(ctx context.Context, request *CommandBatchRequest, member MemberID) (*CommandBatchResponse, error)
+
 	// Query sends a query request
 	Query(ctx context.Context, request *QueryRequest, member MemberID) (<-chan *QueryStreamResponse, error)
 }
@@ -79,6 +103,12 @@ type Server interface {
 	// Reconfigure handles a reconfigure request
 	Reconfigure(ctx context.Context, request *ReconfigureRequest) (*ReconfigureResponse, error)
 
+	// SetReadOnly handles a set read-only request
+	SetReadOnly(ctx context.Context, request *SetReadOnlyRequest) (*SetReadOnlyResponse, error)
+
+	// FreezeElections handles an election freeze request
+	FreezeElections(ctx context.Context, request *FreezeElectionsRequest) (*FreezeElectionsResponse, error)
+
 	// Poll handles a poll request
 	Poll(ctx context.Context, request *PollRequest) (*PollResponse, error)
 
@@ -88,6 +118,12 @@ type Server interface {
 	// Transfer handles a leadership transfer request
 	Transfer(ctx context.Context, request *TransferRequest) (*TransferResponse, error)
 
+	// ConfirmLeadership handles a leadership confirmation request
+	ConfirmLeadership(ctx context.Context, request *ConfirmLeadershipRequest) (*ConfirmLeadershipResponse, error)
+
+	// Hash handles a log hash request
+	Hash(ctx context.Context, request *HashRequest) (*HashResponse, error)
+
 	// Append handles an append request
 	Append(ctx context.Context, request *AppendRequest) (*AppendResponse, error)
 
@@ -97,6 +133,9 @@ type Server interface {
 	// Command handles a command request
 	Command(request *CommandRequest, ch chan<- *CommandStreamResponse) error
 
+	// CommandBatch handles a command batch request
+	CommandBatch(ctx context.Context, request *CommandBatchRequest) (*CommandBatchResponse, error)
+
 	// Query handles a query request
 	Query(request *QueryRequest, ch chan<- *QueryStreamResponse) error
 }
@@ -201,6 +240,14 @@ func (s *gRPCServer) Reconfigure(ctx context.Context, request *ReconfigureReques
 	return s.server.Reconfigure(ctx, request)
 }
 
+func (s *gRPCServer) SetReadOnly(ctx context.Context, request *SetReadOnlyRequest) (*SetReadOnlyResponse, error) {
+	return s.server.SetReadOnly(ctx, request)
+}
+
+func (s *gRPCServer) FreezeElections(ctx context.Context, request *FreezeElectionsRequest) (*FreezeElectionsResponse, error) {
+	return s.server.FreezeElections(ctx, request)
+}
+
 func (s *gRPCServer) Poll(ctx context.Context, request *PollRequest) (*PollResponse, error) {
 	return s.server.Poll(ctx, request)
 }
@@ -213,23 +260,37 @@ func (s *gRPCServer) Transfer(ctx context.Context, request *TransferRequest) (*T
 	return s.server.Transfer(ctx, request)
 }
 
+func (s *gRPCServer) ConfirmLeadership(ctx context.Context, request *ConfirmLeadershipRequest) (*ConfirmLeadershipResponse, error) {
+	return s.server.ConfirmLeadership(ctx, request)
+}
+
+func (s *gRPCServer) Hash(ctx context.Context, request *HashRequest) (*HashResponse, error) {
+	return s.server.Hash(ctx, request)
+}
+
 func (s *gRPCServer) Append(ctx context.Context, request *AppendRequest) (*AppendResponse, error) {
 	return s.server.Append(ctx, request)
 }
 
 func (s *gRPCServer) Install(stream RaftService_InstallServer) error {
 	ch := make(chan *InstallStreamRequest)
+	done := make(chan struct{})
+	defer close(done)
 	go func() {
+		defer close(ch)
 		for {
 			request, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			// Stop receiving if the server responded before the stream was completed.
+			select {
+			case ch <- NewInstallStreamRequest(request, err):
+			case <-done:
+				return
+			}
 			if err != nil {
-				if err != io.EOF {
-					ch <- NewInstallStreamRequest(nil, err)
-				}
-				close(ch)
-				break
-			} else {
-				ch <- NewInstallStreamRequest(request, nil)
+				return
 			}
 		}
 	}()
@@ -242,16 +303,40 @@ func (s *gRPCServer) Install(stream RaftService_InstallServer) error {
 }
 
 func (s *gRPCServer) Command(request *CommandRequest, stream RaftService_CommandServer) error {
+	// If the client did not specify a timeout, propagate the time remaining until the client's deadline.
+	if deadline, ok := stream.Context().Deadline(); ok && request.Timeout == nil {
+		timeout := time.Until(deadline)
+		request.Timeout = &timeout
+	}
+
+	// If the client did not specify metadata, propagate the metadata sent with the request for command authorizers.
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok && len(request.Metadata) == 0 {
+		request.Metadata = make(map[string]string)
+		for key, values := range md {
+			if len(values) > 0 {
+				request.Metadata[key] = values[0]
+			}
+		}
+	}
+
+	// Once the stream fails, continue draining responses so the server is never blocked by a dropped stream.
 	responseCh := make(chan *CommandStreamResponse)
-	errCh := make(chan error)
+	errCh := make(chan error, 1)
 	go func() {
+		var streamErr error
 		for response := range responseCh {
+			if streamErr != nil {
+				continue
+			}
 			if response.Failed() {
-				errCh <- response.Error
+				streamErr = response.Error
 			} else if err := stream.Send(response.Response); err != nil {
-				errCh <- response.Error
+				streamErr = err
 			}
 		}
+		if streamErr != nil {
+			errCh <- streamErr
+		}
 		close(errCh)
 	}()
 	if err := s.server.Command(request, responseCh); err != nil {
@@ -265,6 +350,30 @@ func (s *gRPCServer) Command(request *CommandRequest, stream RaftService_Command
 	return nil
 }
 
+func (s *gRPCServer) Progress(request *ProgressRequest, stream RaftService_ProgressServer) error {
+	server, ok := s.server.(ProgressServer)
+	if !ok {
+		return status.Error(codes.Unimplemented, "progress is not supported")
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	responseCh := make(chan *ProgressResponse)
+	if err := server.Progress(ctx, request, responseCh); err != nil {
+		return err
+	}
+	for response := range responseCh {
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *gRPCServer) CommandBatch(ctx context.Context, request *CommandBatchRequest) (*CommandBatchResponse, error) {
+	return s.server.CommandBatch(ctx, request)
+}
+
 func (s *gRPCServer) Query(request *QueryRequest, stream RaftService_QueryServer) error {
 	responseCh := make(chan *QueryStreamResponse)
 	errCh := make(chan error)
@@ -272,8 +381,12 @@ func (s *gRPCServer) Query(request *QueryRequest, stream RaftService_QueryServer
 		for response := range responseCh {
 			if response.Failed() {
 				errCh <- response.Error
-			} else if err := stream.Send(response.Response); err != nil {
-				errCh <- response.Error
+			} else {
+				// Tag the response with the request's sequence number to allow the client to reorder responses.
+				response.Response.SequenceNumber = request.SequenceNumber
+				if err := stream.Send(response.Response); err != nil {
+					errCh <- response.Error
+				}
 			}
 		}
 		close(errCh)
@@ -292,6 +405,7 @@ func (s *gRPCServer) Query(request *QueryRequest, stream RaftService_QueryServer
 // gRPCClient uses gRPC clients to send messages to remote nodes
 type gRPCClient struct {
 	cluster Cluster
+	group   GroupID
 }
 
 func (p *gRPCClient) Join(ctx context.Context, request *JoinRequest, member MemberID) (*JoinResponse, error) {
@@ -299,7 +413,7 @@ func (p *gRPCClient) Join(ctx context.Context, request *JoinRequest, member Memb
 	if err != nil {
 		return nil, err
 	}
-	return client.Join(ctx, request)
+	return client.Join(withGroup(ctx, p.group), request)
 }
 
 func (p *gRPCClient) Leave(ctx context.Context, request *LeaveRequest, member MemberID) (*LeaveResponse, error) {
@@ -307,7 +421,7 @@ func (p *gRPCClient) Leave(ctx context.Context, request *LeaveRequest, member Me
 	if err != nil {
 		return nil, err
 	}
-	return client.Leave(ctx, request)
+	return client.Leave(withGroup(ctx, p.group), request)
 }
 
 func (p *gRPCClient) Configure(ctx context.Context, request *ConfigureRequest, member MemberID) (*ConfigureResponse, error) {
@@ -315,7 +429,7 @@ func (p *gRPCClient) Configure(ctx context.Context, request *ConfigureRequest, m
 	if err != nil {
 		return nil, err
 	}
-	return client.Configure(ctx, request)
+	return client.Configure(withGroup(ctx, p.group), request)
 }
 
 func (p *gRPCClient) Reconfigure(ctx context.Context, request *ReconfigureRequest, member MemberID) (*ReconfigureResponse, error) {
@@ -323,7 +437,23 @@ func (p *gRPCClient) Reconfigure(ctx context.Context, request *ReconfigureReques
 	if err != nil {
 		return nil, err
 	}
-	return client.Reconfigure(ctx, request)
+	return client.Reconfigure(withGroup(ctx, p.group), request)
+}
+
+func (p *gRPCClient) SetReadOnly(ctx context.Context, request *SetReadOnlyRequest, member MemberID) (*SetReadOnlyResponse, error) {
+	client, err := p.cluster.GetClient(member)
+	if err != nil {
+		return nil, err
+	}
+	return client.SetReadOnly(withGroup(ctx, p.group), request)
+}
+
+func (p *gRPCClient) FreezeElections(ctx context.Context, request *FreezeElectionsRequest, member MemberID) (*FreezeElectionsResponse, error) {
+	client, err := p.cluster.GetClient(member)
+	if err != nil {
+		return nil, err
+	}
+	return client.FreezeElections(withGroup(ctx, p.group), request)
 }
 
 func (p *gRPCClient) Poll(ctx context.Context, request *PollRequest, member MemberID) (*PollResponse, error) {
@@ -331,7 +461,7 @@ func (p *gRPCClient) Poll(ctx context.Context, request *PollRequest, member Memb
 	if err != nil {
 		return nil, err
 	}
-	return client.Poll(ctx, request)
+	return client.Poll(withGroup(ctx, p.group), request)
 }
 
 func (p *gRPCClient) Vote(ctx context.Context, request *VoteRequest, member MemberID) (*VoteResponse, error) {
@@ -339,7 +469,7 @@ func (p *gRPCClient) Vote(ctx context.Context, request *VoteRequest, member Memb
 	if err != nil {
 		return nil, err
 	}
-	return client.Vote(ctx, request)
+	return client.Vote(withGroup(ctx, p.group), request)
 }
 
 func (p *gRPCClient) Transfer(ctx context.Context, request *TransferRequest, member MemberID) (*TransferResponse, error) {
@@ -347,7 +477,23 @@ func (p *gRPCClient) Transfer(ctx context.Context, request *TransferRequest, mem
 	if err != nil {
 		return nil, err
 	}
-	return client.Transfer(ctx, request)
+	return client.Transfer(withGroup(ctx, p.group), request)
+}
+
+func (p *gRPCClient) ConfirmLeadership(ctx context.Context, request *ConfirmLeadershipRequest, member MemberID) (*ConfirmLeadershipResponse, error) {
+	client, err := p.cluster.GetClient(member)
+	if err != nil {
+		return nil, err
+	}
+	return client.ConfirmLeadership(withGroup(ctx, p.group), request)
+}
+
+func (p *gRPCClient) Hash(ctx context.Context, request *HashRequest, member MemberID) (*HashResponse, error) {
+	client, err := p.cluster.GetClient(member)
+	if err != nil {
+		return nil, err
+	}
+	return client.Hash(withGroup(ctx, p.group), request)
 }
 
 func (p *gRPCClient) Append(ctx context.Context, request *AppendRequest, member MemberID) (*AppendResponse, error) {
@@ -355,7 +501,7 @@ func (p *gRPCClient) Append(ctx context.Context, request *AppendRequest, member
 	if err != nil {
 		return nil, err
 	}
-	return client.Append(ctx, request)
+	return client.Append(withGroup(ctx, p.group), request)
 }
 
 func (p *gRPCClient) Install(ctx context.Context, member MemberID) (chan<- *InstallRequest, <-chan *InstallStreamResponse, error) {
@@ -363,7 +509,7 @@ func (p *gRPCClient) Install(ctx context.Context, member MemberID) (chan<- *Inst
 	if err != nil {
 		return nil, nil, err
 	}
-	stream, err := client.Install(ctx)
+	stream, err := client.Install(withGroup(ctx, p.group))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -373,7 +519,12 @@ func (p *gRPCClient) Install(ctx context.Context, member MemberID) (chan<- *Inst
 	go func() {
 		for request := range requestCh {
 			if err := stream.Send(request); err != nil {
-				responseCh <- NewInstallStreamResponse(nil, err)
+				// If the server responded before the stream was completed, receive the server's response.
+				if err == io.EOF {
+					responseCh <- NewInstallStreamResponse(stream.CloseAndRecv())
+				} else {
+					responseCh <- NewInstallStreamResponse(nil, err)
+				}
 				close(responseCh)
 				return
 			}
@@ -391,7 +542,7 @@ func (p *gRPCClient) Command(ctx context.Context, request *CommandRequest, membe
 		return nil, err
 	}
 
-	stream, err := client.Command(ctx, request)
+	stream, err := client.Command(withGroup(ctx, p.group), request)
 	if err != nil {
 		return nil, err
 	}
@@ -414,13 +565,21 @@ func (p *gRPCClient) Command(ctx context.Context, request *CommandRequest, membe
 	return ch, nil
 }
 
+func (p *gRPCClient) CommandBatch(ctx context.Context, request *CommandBatchRequest, member MemberID) (*CommandBatchResponse, error) {
+	client, err := p.cluster.GetClient(member)
+	if err != nil {
+		return nil, err
+	}
+	return client.CommandBatch(withGroup(ctx, p.group), request)
+}
+
 func (p *gRPCClient) Query(ctx context.Context, request *QueryRequest, member MemberID) (<-chan *QueryStreamResponse, error) {
 	client, err := p.cluster.GetClient(member)
 	if err != nil {
 		return nil, err
 	}
 
-	stream, err := client.Query(ctx, request)
+	stream, err := client.Query(withGroup(ctx, p.group), request)
 	if err != nil {
 		return nil, err
 	}
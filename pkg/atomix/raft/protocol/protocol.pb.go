@@ -30,7 +30,7 @@ var _ = time.Kitchen
 // is compatible with the proto package it is being compiled against.
 // A compilation error at this line likely means your copy of the
 // proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type ReadConsistency int32
 
@@ -88,18 +88,24 @@ func (ResponseStatus) EnumDescriptor() ([]byte, []int) {
 type ResponseError int32
 
 const (
-	ResponseError_NO_LEADER            ResponseError = 0
-	ResponseError_QUERY_FAILURE        ResponseError = 1
-	ResponseError_COMMAND_FAILURE      ResponseError = 2
-	ResponseError_APPLICATION_ERROR    ResponseError = 3
-	ResponseError_ILLEGAL_MEMBER_STATE ResponseError = 4
-	ResponseError_UNKNOWN_CLIENT       ResponseError = 5
-	ResponseError_UNKNOWN_SESSION      ResponseError = 6
-	ResponseError_UNKNOWN_SERVICE      ResponseError = 7
-	ResponseError_CLOSED_SESSION       ResponseError = 8
-	ResponseError_PROTOCOL_ERROR       ResponseError = 9
-	ResponseError_CONFIGURATION_ERROR  ResponseError = 10
-	ResponseError_UNAVAILABLE          ResponseError = 11
+	ResponseError_NO_LEADER                 ResponseError = 0
+	ResponseError_QUERY_FAILURE             ResponseError = 1
+	ResponseError_COMMAND_FAILURE           ResponseError = 2
+	ResponseError_APPLICATION_ERROR         ResponseError = 3
+	ResponseError_ILLEGAL_MEMBER_STATE      ResponseError = 4
+	ResponseError_UNKNOWN_CLIENT            ResponseError = 5
+	ResponseError_UNKNOWN_SESSION           ResponseError = 6
+	ResponseError_UNKNOWN_SERVICE           ResponseError = 7
+	ResponseError_CLOSED_SESSION            ResponseError = 8
+	ResponseError_PROTOCOL_ERROR            ResponseError = 9
+	ResponseError_CONFIGURATION_ERROR       ResponseError = 10
+	ResponseError_UNAVAILABLE               ResponseError = 11
+	ResponseError_READ_ONLY                 ResponseError = 12
+	ResponseError_CONFIGURATION_IN_PROGRESS ResponseError = 13
+	ResponseError_NOT_READY                 ResponseError = 14
+	ResponseError_UNAUTHORIZED              ResponseError = 15
+	ResponseError_INCOMPATIBLE_SNAPSHOT     ResponseError = 16
+	ResponseError_RATE_LIMITED              ResponseError = 17
 )
 
 var ResponseError_name = map[int32]string{
@@ -115,21 +121,33 @@ var ResponseError_name = map[int32]string{
 	9:  "PROTOCOL_ERROR",
 	10: "CONFIGURATION_ERROR",
 	11: "UNAVAILABLE",
+	12: "READ_ONLY",
+	13: "CONFIGURATION_IN_PROGRESS",
+	14: "NOT_READY",
+	15: "UNAUTHORIZED",
+	16: "INCOMPATIBLE_SNAPSHOT",
+	17: "RATE_LIMITED",
 }
 
 var ResponseError_value = map[string]int32{
-	"NO_LEADER":            0,
-	"QUERY_FAILURE":        1,
-	"COMMAND_FAILURE":      2,
-	"APPLICATION_ERROR":    3,
-	"ILLEGAL_MEMBER_STATE": 4,
-	"UNKNOWN_CLIENT":       5,
-	"UNKNOWN_SESSION":      6,
-	"UNKNOWN_SERVICE":      7,
-	"CLOSED_SESSION":       8,
-	"PROTOCOL_ERROR":       9,
-	"CONFIGURATION_ERROR":  10,
-	"UNAVAILABLE":          11,
+	"NO_LEADER":                 0,
+	"QUERY_FAILURE":             1,
+	"COMMAND_FAILURE":           2,
+	"APPLICATION_ERROR":         3,
+	"ILLEGAL_MEMBER_STATE":      4,
+	"UNKNOWN_CLIENT":            5,
+	"UNKNOWN_SESSION":           6,
+	"UNKNOWN_SERVICE":           7,
+	"CLOSED_SESSION":            8,
+	"PROTOCOL_ERROR":            9,
+	"CONFIGURATION_ERROR":       10,
+	"UNAVAILABLE":               11,
+	"READ_ONLY":                 12,
+	"CONFIGURATION_IN_PROGRESS": 13,
+	"NOT_READY":                 14,
+	"UNAUTHORIZED":              15,
+	"INCOMPATIBLE_SNAPSHOT":     16,
+	"RATE_LIMITED":              17,
 }
 
 func (x ResponseError) String() string {
@@ -141,7 +159,8 @@ func (ResponseError) EnumDescriptor() ([]byte, []int) {
 }
 
 type JoinRequest struct {
-	Member *Member `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Member    *Member `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Forwarded bool    `protobuf:"varint,2,opt,name=forwarded,proto3" json:"forwarded,omitempty"`
 }
 
 func (m *JoinRequest) Reset()         { *m = JoinRequest{} }
@@ -184,13 +203,22 @@ func (m *JoinRequest) GetMember() *Member {
 	return nil
 }
 
+func (m *JoinRequest) GetForwarded() bool {
+	if m != nil {
+		return m.Forwarded
+	}
+	return false
+}
+
 type JoinResponse struct {
-	Status    ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
-	Error     ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
-	Index     Index          `protobuf:"varint,3,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
-	Term      Term           `protobuf:"varint,4,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Timestamp time.Time      `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
-	Members   []*Member      `protobuf:"bytes,6,rep,name=members,proto3" json:"members,omitempty"`
+	Status      ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error       ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Index       Index          `protobuf:"varint,3,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	Term        Term           `protobuf:"varint,4,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Timestamp   time.Time      `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Members     []*Member      `protobuf:"bytes,6,rep,name=members,proto3" json:"members,omitempty"`
+	Leader      MemberID       `protobuf:"bytes,7,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
+	CommitIndex Index          `protobuf:"varint,8,opt,name=commit_index,json=commitIndex,proto3,casttype=Index" json:"commit_index,omitempty"`
 }
 
 func (m *JoinResponse) Reset()         { *m = JoinResponse{} }
@@ -268,6 +296,20 @@ func (m *JoinResponse) GetMembers() []*Member {
 	return nil
 }
 
+func (m *JoinResponse) GetLeader() MemberID {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+func (m *JoinResponse) GetCommitIndex() Index {
+	if m != nil {
+		return m.CommitIndex
+	}
+	return 0
+}
+
 type ConfigureRequest struct {
 	Term      Term      `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
 	Leader    MemberID  `protobuf:"bytes,2,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
@@ -397,9 +439,11 @@ func (m *ConfigureResponse) GetError() ResponseError {
 }
 
 type ReconfigureRequest struct {
-	Member *Member `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
-	Index  Index   `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
-	Term   Term    `protobuf:"varint,3,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Member            *Member        `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Index             Index          `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	Term              Term           `protobuf:"varint,3,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	ElectionTimeout   *time.Duration `protobuf:"bytes,4,opt,name=election_timeout,json=electionTimeout,proto3,stdduration" json:"election_timeout,omitempty"`
+	HeartbeatInterval *time.Duration `protobuf:"bytes,5,opt,name=heartbeat_interval,json=heartbeatInterval,proto3,stdduration" json:"heartbeat_interval,omitempty"`
 }
 
 func (m *ReconfigureRequest) Reset()         { *m = ReconfigureRequest{} }
@@ -456,6 +500,20 @@ func (m *ReconfigureRequest) GetTerm() Term {
 	return 0
 }
 
+func (m *ReconfigureRequest) GetElectionTimeout() *time.Duration {
+	if m != nil {
+		return m.ElectionTimeout
+	}
+	return nil
+}
+
+func (m *ReconfigureRequest) GetHeartbeatInterval() *time.Duration {
+	if m != nil {
+		return m.HeartbeatInterval
+	}
+	return nil
+}
+
 type ReconfigureResponse struct {
 	Status    ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
 	Error     ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
@@ -668,6 +726,238 @@ func (m *LeaveResponse) GetMembers() []*Member {
 	return nil
 }
 
+type SetReadOnlyRequest struct {
+	ReadOnly bool `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+}
+
+func (m *SetReadOnlyRequest) Reset()         { *m = SetReadOnlyRequest{} }
+func (m *SetReadOnlyRequest) String() string { return proto.CompactTextString(m) }
+func (*SetReadOnlyRequest) ProtoMessage()    {}
+func (*SetReadOnlyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{8}
+}
+func (m *SetReadOnlyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetReadOnlyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetReadOnlyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetReadOnlyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetReadOnlyRequest.Merge(m, src)
+}
+func (m *SetReadOnlyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetReadOnlyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetReadOnlyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetReadOnlyRequest proto.InternalMessageInfo
+
+func (m *SetReadOnlyRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+type SetReadOnlyResponse struct {
+	Status ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error  ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Index  Index          `protobuf:"varint,3,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	Term   Term           `protobuf:"varint,4,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+}
+
+func (m *SetReadOnlyResponse) Reset()         { *m = SetReadOnlyResponse{} }
+func (m *SetReadOnlyResponse) String() string { return proto.CompactTextString(m) }
+func (*SetReadOnlyResponse) ProtoMessage()    {}
+func (*SetReadOnlyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{9}
+}
+func (m *SetReadOnlyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetReadOnlyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetReadOnlyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetReadOnlyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetReadOnlyResponse.Merge(m, src)
+}
+func (m *SetReadOnlyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetReadOnlyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetReadOnlyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetReadOnlyResponse proto.InternalMessageInfo
+
+func (m *SetReadOnlyResponse) GetStatus() ResponseStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResponseStatus_OK
+}
+
+func (m *SetReadOnlyResponse) GetError() ResponseError {
+	if m != nil {
+		return m.Error
+	}
+	return ResponseError_NO_LEADER
+}
+
+func (m *SetReadOnlyResponse) GetIndex() Index {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SetReadOnlyResponse) GetTerm() Term {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+type FreezeElectionsRequest struct {
+	Duration time.Duration `protobuf:"bytes,1,opt,name=duration,proto3,stdduration" json:"duration"`
+}
+
+func (m *FreezeElectionsRequest) Reset()         { *m = FreezeElectionsRequest{} }
+func (m *FreezeElectionsRequest) String() string { return proto.CompactTextString(m) }
+func (*FreezeElectionsRequest) ProtoMessage()    {}
+func (*FreezeElectionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{10}
+}
+func (m *FreezeElectionsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FreezeElectionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FreezeElectionsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FreezeElectionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FreezeElectionsRequest.Merge(m, src)
+}
+func (m *FreezeElectionsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FreezeElectionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FreezeElectionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FreezeElectionsRequest proto.InternalMessageInfo
+
+func (m *FreezeElectionsRequest) GetDuration() time.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+type FreezeElectionsResponse struct {
+	Status  ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error   ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Index   Index          `protobuf:"varint,3,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	Term    Term           `protobuf:"varint,4,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Expires time.Time      `protobuf:"bytes,5,opt,name=expires,proto3,stdtime" json:"expires"`
+}
+
+func (m *FreezeElectionsResponse) Reset()         { *m = FreezeElectionsResponse{} }
+func (m *FreezeElectionsResponse) String() string { return proto.CompactTextString(m) }
+func (*FreezeElectionsResponse) ProtoMessage()    {}
+func (*FreezeElectionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{11}
+}
+func (m *FreezeElectionsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FreezeElectionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FreezeElectionsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FreezeElectionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FreezeElectionsResponse.Merge(m, src)
+}
+func (m *FreezeElectionsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *FreezeElectionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FreezeElectionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FreezeElectionsResponse proto.InternalMessageInfo
+
+func (m *FreezeElectionsResponse) GetStatus() ResponseStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResponseStatus_OK
+}
+
+func (m *FreezeElectionsResponse) GetError() ResponseError {
+	if m != nil {
+		return m.Error
+	}
+	return ResponseError_NO_LEADER
+}
+
+func (m *FreezeElectionsResponse) GetIndex() Index {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *FreezeElectionsResponse) GetTerm() Term {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *FreezeElectionsResponse) GetExpires() time.Time {
+	if m != nil {
+		return m.Expires
+	}
+	return time.Time{}
+}
+
 type PollRequest struct {
 	Term         Term     `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
 	Candidate    MemberID `protobuf:"bytes,2,opt,name=candidate,proto3,casttype=MemberID" json:"candidate,omitempty"`
@@ -679,7 +969,7 @@ func (m *PollRequest) Reset()         { *m = PollRequest{} }
 func (m *PollRequest) String() string { return proto.CompactTextString(m) }
 func (*PollRequest) ProtoMessage()    {}
 func (*PollRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{8}
+	return fileDescriptor_2ab16e79e6abb7aa, []int{12}
 }
 func (m *PollRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -737,17 +1027,18 @@ func (m *PollRequest) GetLastLogTerm() Term {
 }
 
 type PollResponse struct {
-	Status   ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
-	Error    ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
-	Term     Term           `protobuf:"varint,3,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Accepted bool           `protobuf:"varint,4,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Status     ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error      ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Term       Term           `protobuf:"varint,3,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Accepted   bool           `protobuf:"varint,4,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	RetryAfter time.Duration  `protobuf:"bytes,5,opt,name=retry_after,json=retryAfter,proto3,stdduration" json:"retry_after"`
 }
 
 func (m *PollResponse) Reset()         { *m = PollResponse{} }
 func (m *PollResponse) String() string { return proto.CompactTextString(m) }
 func (*PollResponse) ProtoMessage()    {}
 func (*PollResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{9}
+	return fileDescriptor_2ab16e79e6abb7aa, []int{13}
 }
 func (m *PollResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -804,18 +1095,26 @@ func (m *PollResponse) GetAccepted() bool {
 	return false
 }
 
+func (m *PollResponse) GetRetryAfter() time.Duration {
+	if m != nil {
+		return m.RetryAfter
+	}
+	return 0
+}
+
 type VoteRequest struct {
-	Term         Term     `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Candidate    MemberID `protobuf:"bytes,2,opt,name=candidate,proto3,casttype=MemberID" json:"candidate,omitempty"`
-	LastLogIndex Index    `protobuf:"varint,3,opt,name=last_log_index,json=lastLogIndex,proto3,casttype=Index" json:"last_log_index,omitempty"`
-	LastLogTerm  Term     `protobuf:"varint,4,opt,name=last_log_term,json=lastLogTerm,proto3,casttype=Term" json:"last_log_term,omitempty"`
+	Term               Term     `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Candidate          MemberID `protobuf:"bytes,2,opt,name=candidate,proto3,casttype=MemberID" json:"candidate,omitempty"`
+	LastLogIndex       Index    `protobuf:"varint,3,opt,name=last_log_index,json=lastLogIndex,proto3,casttype=Index" json:"last_log_index,omitempty"`
+	LastLogTerm        Term     `protobuf:"varint,4,opt,name=last_log_term,json=lastLogTerm,proto3,casttype=Term" json:"last_log_term,omitempty"`
+	ConfigurationIndex Index    `protobuf:"varint,5,opt,name=configuration_index,json=configurationIndex,proto3,casttype=Index" json:"configuration_index,omitempty"`
 }
 
 func (m *VoteRequest) Reset()         { *m = VoteRequest{} }
 func (m *VoteRequest) String() string { return proto.CompactTextString(m) }
 func (*VoteRequest) ProtoMessage()    {}
 func (*VoteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{10}
+	return fileDescriptor_2ab16e79e6abb7aa, []int{14}
 }
 func (m *VoteRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -872,18 +1171,26 @@ func (m *VoteRequest) GetLastLogTerm() Term {
 	return 0
 }
 
+func (m *VoteRequest) GetConfigurationIndex() Index {
+	if m != nil {
+		return m.ConfigurationIndex
+	}
+	return 0
+}
+
 type VoteResponse struct {
-	Status ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
-	Error  ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
-	Term   Term           `protobuf:"varint,3,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Voted  bool           `protobuf:"varint,4,opt,name=voted,proto3" json:"voted,omitempty"`
+	Status     ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error      ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Term       Term           `protobuf:"varint,3,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Voted      bool           `protobuf:"varint,4,opt,name=voted,proto3" json:"voted,omitempty"`
+	RetryAfter time.Duration  `protobuf:"bytes,5,opt,name=retry_after,json=retryAfter,proto3,stdduration" json:"retry_after"`
 }
 
 func (m *VoteResponse) Reset()         { *m = VoteResponse{} }
 func (m *VoteResponse) String() string { return proto.CompactTextString(m) }
 func (*VoteResponse) ProtoMessage()    {}
 func (*VoteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{11}
+	return fileDescriptor_2ab16e79e6abb7aa, []int{15}
 }
 func (m *VoteResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -940,15 +1247,23 @@ func (m *VoteResponse) GetVoted() bool {
 	return false
 }
 
+func (m *VoteResponse) GetRetryAfter() time.Duration {
+	if m != nil {
+		return m.RetryAfter
+	}
+	return 0
+}
+
 type TransferRequest struct {
-	Member MemberID `protobuf:"bytes,1,opt,name=member,proto3,casttype=MemberID" json:"member,omitempty"`
+	Member       MemberID `protobuf:"bytes,1,opt,name=member,proto3,casttype=MemberID" json:"member,omitempty"`
+	LastLogIndex Index    `protobuf:"varint,2,opt,name=last_log_index,json=lastLogIndex,proto3,casttype=Index" json:"last_log_index,omitempty"`
 }
 
 func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
 func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
 func (*TransferRequest) ProtoMessage()    {}
 func (*TransferRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{12}
+	return fileDescriptor_2ab16e79e6abb7aa, []int{16}
 }
 func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -984,6 +1299,13 @@ func (m *TransferRequest) GetMember() MemberID {
 	return ""
 }
 
+func (m *TransferRequest) GetLastLogIndex() Index {
+	if m != nil {
+		return m.LastLogIndex
+	}
+	return 0
+}
+
 type TransferResponse struct {
 	Status ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
 	Error  ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
@@ -993,7 +1315,7 @@ func (m *TransferResponse) Reset()         { *m = TransferResponse{} }
 func (m *TransferResponse) String() string { return proto.CompactTextString(m) }
 func (*TransferResponse) ProtoMessage()    {}
 func (*TransferResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{13}
+	return fileDescriptor_2ab16e79e6abb7aa, []int{17}
 }
 func (m *TransferResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1036,27 +1358,23 @@ func (m *TransferResponse) GetError() ResponseError {
 	return ResponseError_NO_LEADER
 }
 
-type AppendRequest struct {
-	Term         Term        `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Leader       MemberID    `protobuf:"bytes,2,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
-	PrevLogIndex Index       `protobuf:"varint,3,opt,name=prev_log_index,json=prevLogIndex,proto3,casttype=Index" json:"prev_log_index,omitempty"`
-	PrevLogTerm  Term        `protobuf:"varint,4,opt,name=prev_log_term,json=prevLogTerm,proto3,casttype=Term" json:"prev_log_term,omitempty"`
-	Entries      []*LogEntry `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
-	CommitIndex  Index       `protobuf:"varint,6,opt,name=commit_index,json=commitIndex,proto3,casttype=Index" json:"commit_index,omitempty"`
+type ConfirmLeadershipRequest struct {
+	Term   Term     `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Leader MemberID `protobuf:"bytes,2,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
 }
 
-func (m *AppendRequest) Reset()         { *m = AppendRequest{} }
-func (m *AppendRequest) String() string { return proto.CompactTextString(m) }
-func (*AppendRequest) ProtoMessage()    {}
-func (*AppendRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{14}
+func (m *ConfirmLeadershipRequest) Reset()         { *m = ConfirmLeadershipRequest{} }
+func (m *ConfirmLeadershipRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfirmLeadershipRequest) ProtoMessage()    {}
+func (*ConfirmLeadershipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{18}
 }
-func (m *AppendRequest) XXX_Unmarshal(b []byte) error {
+func (m *ConfirmLeadershipRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AppendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ConfirmLeadershipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AppendRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ConfirmLeadershipRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1066,80 +1384,51 @@ func (m *AppendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *AppendRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AppendRequest.Merge(m, src)
+func (m *ConfirmLeadershipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmLeadershipRequest.Merge(m, src)
 }
-func (m *AppendRequest) XXX_Size() int {
+func (m *ConfirmLeadershipRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *AppendRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AppendRequest.DiscardUnknown(m)
+func (m *ConfirmLeadershipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmLeadershipRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AppendRequest proto.InternalMessageInfo
+var xxx_messageInfo_ConfirmLeadershipRequest proto.InternalMessageInfo
 
-func (m *AppendRequest) GetTerm() Term {
+func (m *ConfirmLeadershipRequest) GetTerm() Term {
 	if m != nil {
 		return m.Term
 	}
 	return 0
 }
 
-func (m *AppendRequest) GetLeader() MemberID {
+func (m *ConfirmLeadershipRequest) GetLeader() MemberID {
 	if m != nil {
 		return m.Leader
 	}
 	return ""
 }
 
-func (m *AppendRequest) GetPrevLogIndex() Index {
-	if m != nil {
-		return m.PrevLogIndex
-	}
-	return 0
+type ConfirmLeadershipResponse struct {
+	Status    ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error     ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Term      Term           `protobuf:"varint,3,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Confirmed bool           `protobuf:"varint,4,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
 }
 
-func (m *AppendRequest) GetPrevLogTerm() Term {
-	if m != nil {
-		return m.PrevLogTerm
-	}
-	return 0
-}
-
-func (m *AppendRequest) GetEntries() []*LogEntry {
-	if m != nil {
-		return m.Entries
-	}
-	return nil
-}
-
-func (m *AppendRequest) GetCommitIndex() Index {
-	if m != nil {
-		return m.CommitIndex
-	}
-	return 0
-}
-
-type AppendResponse struct {
-	Status       ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
-	Error        ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
-	Term         Term           `protobuf:"varint,3,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Succeeded    bool           `protobuf:"varint,4,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
-	LastLogIndex Index          `protobuf:"varint,5,opt,name=last_log_index,json=lastLogIndex,proto3,casttype=Index" json:"last_log_index,omitempty"`
-}
-
-func (m *AppendResponse) Reset()         { *m = AppendResponse{} }
-func (m *AppendResponse) String() string { return proto.CompactTextString(m) }
-func (*AppendResponse) ProtoMessage()    {}
-func (*AppendResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{15}
+func (m *ConfirmLeadershipResponse) Reset()         { *m = ConfirmLeadershipResponse{} }
+func (m *ConfirmLeadershipResponse) String() string { return proto.CompactTextString(m) }
+func (*ConfirmLeadershipResponse) ProtoMessage()    {}
+func (*ConfirmLeadershipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{19}
 }
-func (m *AppendResponse) XXX_Unmarshal(b []byte) error {
+func (m *ConfirmLeadershipResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AppendResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ConfirmLeadershipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AppendResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ConfirmLeadershipResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1149,73 +1438,63 @@ func (m *AppendResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *AppendResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AppendResponse.Merge(m, src)
+func (m *ConfirmLeadershipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmLeadershipResponse.Merge(m, src)
 }
-func (m *AppendResponse) XXX_Size() int {
+func (m *ConfirmLeadershipResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *AppendResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AppendResponse.DiscardUnknown(m)
+func (m *ConfirmLeadershipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmLeadershipResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AppendResponse proto.InternalMessageInfo
+var xxx_messageInfo_ConfirmLeadershipResponse proto.InternalMessageInfo
 
-func (m *AppendResponse) GetStatus() ResponseStatus {
+func (m *ConfirmLeadershipResponse) GetStatus() ResponseStatus {
 	if m != nil {
 		return m.Status
 	}
 	return ResponseStatus_OK
 }
 
-func (m *AppendResponse) GetError() ResponseError {
+func (m *ConfirmLeadershipResponse) GetError() ResponseError {
 	if m != nil {
 		return m.Error
 	}
 	return ResponseError_NO_LEADER
 }
 
-func (m *AppendResponse) GetTerm() Term {
+func (m *ConfirmLeadershipResponse) GetTerm() Term {
 	if m != nil {
 		return m.Term
 	}
 	return 0
 }
 
-func (m *AppendResponse) GetSucceeded() bool {
+func (m *ConfirmLeadershipResponse) GetConfirmed() bool {
 	if m != nil {
-		return m.Succeeded
+		return m.Confirmed
 	}
 	return false
 }
 
-func (m *AppendResponse) GetLastLogIndex() Index {
-	if m != nil {
-		return m.LastLogIndex
-	}
-	return 0
-}
-
-type InstallRequest struct {
-	Term      Term      `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Leader    MemberID  `protobuf:"bytes,2,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
-	Index     Index     `protobuf:"varint,3,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
-	Timestamp time.Time `protobuf:"bytes,4,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
-	Data      []byte    `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+type HashRequest struct {
+	FirstIndex Index `protobuf:"varint,1,opt,name=first_index,json=firstIndex,proto3,casttype=Index" json:"first_index,omitempty"`
+	LastIndex  Index `protobuf:"varint,2,opt,name=last_index,json=lastIndex,proto3,casttype=Index" json:"last_index,omitempty"`
 }
 
-func (m *InstallRequest) Reset()         { *m = InstallRequest{} }
-func (m *InstallRequest) String() string { return proto.CompactTextString(m) }
-func (*InstallRequest) ProtoMessage()    {}
-func (*InstallRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{16}
+func (m *HashRequest) Reset()         { *m = HashRequest{} }
+func (m *HashRequest) String() string { return proto.CompactTextString(m) }
+func (*HashRequest) ProtoMessage()    {}
+func (*HashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{20}
 }
-func (m *InstallRequest) XXX_Unmarshal(b []byte) error {
+func (m *HashRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *InstallRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *HashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_InstallRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_HashRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1225,70 +1504,52 @@ func (m *InstallRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *InstallRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_InstallRequest.Merge(m, src)
+func (m *HashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashRequest.Merge(m, src)
 }
-func (m *InstallRequest) XXX_Size() int {
+func (m *HashRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *InstallRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_InstallRequest.DiscardUnknown(m)
+func (m *HashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_InstallRequest proto.InternalMessageInfo
+var xxx_messageInfo_HashRequest proto.InternalMessageInfo
 
-func (m *InstallRequest) GetTerm() Term {
+func (m *HashRequest) GetFirstIndex() Index {
 	if m != nil {
-		return m.Term
+		return m.FirstIndex
 	}
 	return 0
 }
 
-func (m *InstallRequest) GetLeader() MemberID {
-	if m != nil {
-		return m.Leader
-	}
-	return ""
-}
-
-func (m *InstallRequest) GetIndex() Index {
+func (m *HashRequest) GetLastIndex() Index {
 	if m != nil {
-		return m.Index
+		return m.LastIndex
 	}
 	return 0
 }
 
-func (m *InstallRequest) GetTimestamp() time.Time {
-	if m != nil {
-		return m.Timestamp
-	}
-	return time.Time{}
-}
-
-func (m *InstallRequest) GetData() []byte {
-	if m != nil {
-		return m.Data
-	}
-	return nil
-}
-
-type InstallResponse struct {
-	Status ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
-	Error  ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+type HashResponse struct {
+	Status  ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error   ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Message string         `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Term    Term           `protobuf:"varint,4,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Hash    uint64         `protobuf:"varint,5,opt,name=hash,proto3" json:"hash,omitempty"`
 }
 
-func (m *InstallResponse) Reset()         { *m = InstallResponse{} }
-func (m *InstallResponse) String() string { return proto.CompactTextString(m) }
-func (*InstallResponse) ProtoMessage()    {}
-func (*InstallResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{17}
+func (m *HashResponse) Reset()         { *m = HashResponse{} }
+func (m *HashResponse) String() string { return proto.CompactTextString(m) }
+func (*HashResponse) ProtoMessage()    {}
+func (*HashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{21}
 }
-func (m *InstallResponse) XXX_Unmarshal(b []byte) error {
+func (m *HashResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *InstallResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *HashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_InstallResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_HashResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1298,98 +1559,77 @@ func (m *InstallResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *InstallResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_InstallResponse.Merge(m, src)
+func (m *HashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashResponse.Merge(m, src)
 }
-func (m *InstallResponse) XXX_Size() int {
+func (m *HashResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *InstallResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_InstallResponse.DiscardUnknown(m)
+func (m *HashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_InstallResponse proto.InternalMessageInfo
+var xxx_messageInfo_HashResponse proto.InternalMessageInfo
 
-func (m *InstallResponse) GetStatus() ResponseStatus {
+func (m *HashResponse) GetStatus() ResponseStatus {
 	if m != nil {
 		return m.Status
 	}
 	return ResponseStatus_OK
 }
 
-func (m *InstallResponse) GetError() ResponseError {
+func (m *HashResponse) GetError() ResponseError {
 	if m != nil {
 		return m.Error
 	}
 	return ResponseError_NO_LEADER
 }
 
-type CommandRequest struct {
-	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+func (m *HashResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
 }
 
-func (m *CommandRequest) Reset()         { *m = CommandRequest{} }
-func (m *CommandRequest) String() string { return proto.CompactTextString(m) }
-func (*CommandRequest) ProtoMessage()    {}
-func (*CommandRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{18}
-}
-func (m *CommandRequest) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *CommandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_CommandRequest.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *HashResponse) GetTerm() Term {
+	if m != nil {
+		return m.Term
 	}
+	return 0
 }
-func (m *CommandRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandRequest.Merge(m, src)
-}
-func (m *CommandRequest) XXX_Size() int {
-	return m.Size()
-}
-func (m *CommandRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommandRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_CommandRequest proto.InternalMessageInfo
 
-func (m *CommandRequest) GetValue() []byte {
+func (m *HashResponse) GetHash() uint64 {
 	if m != nil {
-		return m.Value
+		return m.Hash
 	}
-	return nil
+	return 0
 }
 
-type CommandResponse struct {
-	Status  ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
-	Error   ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
-	Message string         `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Leader  MemberID       `protobuf:"bytes,4,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
-	Term    Term           `protobuf:"varint,5,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Members []MemberID     `protobuf:"bytes,6,rep,name=members,proto3,casttype=MemberID" json:"members,omitempty"`
-	Output  []byte         `protobuf:"bytes,7,opt,name=output,proto3" json:"output,omitempty"`
+type AppendRequest struct {
+	Term             Term        `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Leader           MemberID    `protobuf:"bytes,2,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
+	PrevLogIndex     Index       `protobuf:"varint,3,opt,name=prev_log_index,json=prevLogIndex,proto3,casttype=Index" json:"prev_log_index,omitempty"`
+	PrevLogTerm      Term        `protobuf:"varint,4,opt,name=prev_log_term,json=prevLogTerm,proto3,casttype=Term" json:"prev_log_term,omitempty"`
+	Entries          []*LogEntry `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	CommitIndex      Index       `protobuf:"varint,6,opt,name=commit_index,json=commitIndex,proto3,casttype=Index" json:"commit_index,omitempty"`
+	Timestamp        time.Time   `protobuf:"bytes,7,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	LeaderLastIndex  Index       `protobuf:"varint,8,opt,name=leader_last_index,json=leaderLastIndex,proto3,casttype=Index" json:"leader_last_index,omitempty"`
+	LeaderFirstIndex Index       `protobuf:"varint,9,opt,name=leader_first_index,json=leaderFirstIndex,proto3,casttype=Index" json:"leader_first_index,omitempty"`
 }
 
-func (m *CommandResponse) Reset()         { *m = CommandResponse{} }
-func (m *CommandResponse) String() string { return proto.CompactTextString(m) }
-func (*CommandResponse) ProtoMessage()    {}
-func (*CommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{19}
+func (m *AppendRequest) Reset()         { *m = AppendRequest{} }
+func (m *AppendRequest) String() string { return proto.CompactTextString(m) }
+func (*AppendRequest) ProtoMessage()    {}
+func (*AppendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{22}
 }
-func (m *CommandResponse) XXX_Unmarshal(b []byte) error {
+func (m *AppendRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CommandResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AppendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CommandResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AppendRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1399,84 +1639,105 @@ func (m *CommandResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *CommandResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommandResponse.Merge(m, src)
+func (m *AppendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppendRequest.Merge(m, src)
 }
-func (m *CommandResponse) XXX_Size() int {
+func (m *AppendRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CommandResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommandResponse.DiscardUnknown(m)
+func (m *AppendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppendRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommandResponse proto.InternalMessageInfo
+var xxx_messageInfo_AppendRequest proto.InternalMessageInfo
 
-func (m *CommandResponse) GetStatus() ResponseStatus {
+func (m *AppendRequest) GetTerm() Term {
 	if m != nil {
-		return m.Status
+		return m.Term
 	}
-	return ResponseStatus_OK
+	return 0
 }
 
-func (m *CommandResponse) GetError() ResponseError {
+func (m *AppendRequest) GetLeader() MemberID {
 	if m != nil {
-		return m.Error
+		return m.Leader
 	}
-	return ResponseError_NO_LEADER
+	return ""
 }
 
-func (m *CommandResponse) GetMessage() string {
+func (m *AppendRequest) GetPrevLogIndex() Index {
 	if m != nil {
-		return m.Message
+		return m.PrevLogIndex
 	}
-	return ""
+	return 0
 }
 
-func (m *CommandResponse) GetLeader() MemberID {
+func (m *AppendRequest) GetPrevLogTerm() Term {
 	if m != nil {
-		return m.Leader
+		return m.PrevLogTerm
 	}
-	return ""
+	return 0
 }
 
-func (m *CommandResponse) GetTerm() Term {
+func (m *AppendRequest) GetEntries() []*LogEntry {
 	if m != nil {
-		return m.Term
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *AppendRequest) GetCommitIndex() Index {
+	if m != nil {
+		return m.CommitIndex
 	}
 	return 0
 }
 
-func (m *CommandResponse) GetMembers() []MemberID {
+func (m *AppendRequest) GetTimestamp() time.Time {
 	if m != nil {
-		return m.Members
+		return m.Timestamp
 	}
-	return nil
+	return time.Time{}
 }
 
-func (m *CommandResponse) GetOutput() []byte {
+func (m *AppendRequest) GetLeaderLastIndex() Index {
 	if m != nil {
-		return m.Output
+		return m.LeaderLastIndex
 	}
-	return nil
+	return 0
 }
 
-type QueryRequest struct {
-	Value           []byte          `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	ReadConsistency ReadConsistency `protobuf:"varint,2,opt,name=read_consistency,json=readConsistency,proto3,enum=atomix.raft.protocol.ReadConsistency" json:"read_consistency,omitempty"`
+func (m *AppendRequest) GetLeaderFirstIndex() Index {
+	if m != nil {
+		return m.LeaderFirstIndex
+	}
+	return 0
 }
 
-func (m *QueryRequest) Reset()         { *m = QueryRequest{} }
-func (m *QueryRequest) String() string { return proto.CompactTextString(m) }
-func (*QueryRequest) ProtoMessage()    {}
-func (*QueryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{20}
+type AppendResponse struct {
+	Status            ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error             ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Term              Term           `protobuf:"varint,3,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Succeeded         bool           `protobuf:"varint,4,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	LastLogIndex      Index          `protobuf:"varint,5,opt,name=last_log_index,json=lastLogIndex,proto3,casttype=Index" json:"last_log_index,omitempty"`
+	Truncated         uint64         `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	LastLogTerm       Term           `protobuf:"varint,7,opt,name=last_log_term,json=lastLogTerm,proto3,casttype=Term" json:"last_log_term,omitempty"`
+	Timestamp         time.Time      `protobuf:"bytes,8,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	SnapshotRequested bool           `protobuf:"varint,9,opt,name=snapshot_requested,json=snapshotRequested,proto3" json:"snapshot_requested,omitempty"`
 }
-func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *AppendResponse) Reset()         { *m = AppendResponse{} }
+func (m *AppendResponse) String() string { return proto.CompactTextString(m) }
+func (*AppendResponse) ProtoMessage()    {}
+func (*AppendResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{23}
+}
+func (m *AppendResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AppendResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_QueryRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AppendResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1486,51 +1747,107 @@ func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *QueryRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryRequest.Merge(m, src)
+func (m *AppendResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppendResponse.Merge(m, src)
 }
-func (m *QueryRequest) XXX_Size() int {
+func (m *AppendResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *QueryRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryRequest.DiscardUnknown(m)
+func (m *AppendResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppendResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_QueryRequest proto.InternalMessageInfo
+var xxx_messageInfo_AppendResponse proto.InternalMessageInfo
 
-func (m *QueryRequest) GetValue() []byte {
+func (m *AppendResponse) GetStatus() ResponseStatus {
 	if m != nil {
-		return m.Value
+		return m.Status
 	}
-	return nil
+	return ResponseStatus_OK
 }
 
-func (m *QueryRequest) GetReadConsistency() ReadConsistency {
+func (m *AppendResponse) GetError() ResponseError {
 	if m != nil {
-		return m.ReadConsistency
+		return m.Error
 	}
-	return ReadConsistency_SEQUENTIAL
+	return ResponseError_NO_LEADER
 }
 
-type QueryResponse struct {
-	Status  ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
-	Error   ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
-	Message string         `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Output  []byte         `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`
+func (m *AppendResponse) GetTerm() Term {
+	if m != nil {
+		return m.Term
+	}
+	return 0
 }
 
-func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
-func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
-func (*QueryResponse) ProtoMessage()    {}
-func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab16e79e6abb7aa, []int{21}
+func (m *AppendResponse) GetSucceeded() bool {
+	if m != nil {
+		return m.Succeeded
+	}
+	return false
 }
-func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *AppendResponse) GetLastLogIndex() Index {
+	if m != nil {
+		return m.LastLogIndex
+	}
+	return 0
+}
+
+func (m *AppendResponse) GetTruncated() uint64 {
+	if m != nil {
+		return m.Truncated
+	}
+	return 0
+}
+
+func (m *AppendResponse) GetLastLogTerm() Term {
+	if m != nil {
+		return m.LastLogTerm
+	}
+	return 0
+}
+
+func (m *AppendResponse) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *AppendResponse) GetSnapshotRequested() bool {
+	if m != nil {
+		return m.SnapshotRequested
+	}
+	return false
+}
+
+type InstallRequest struct {
+	Term           Term          `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Leader         MemberID      `protobuf:"bytes,2,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
+	Index          Index         `protobuf:"varint,3,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	Timestamp      time.Time     `protobuf:"bytes,4,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Data           []byte        `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	SnapshotTerm   Term          `protobuf:"varint,6,opt,name=snapshot_term,json=snapshotTerm,proto3,casttype=Term" json:"snapshot_term,omitempty"`
+	Version        uint32        `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	Offset         uint64        `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"`
+	Checksum       uint32        `protobuf:"varint,9,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	ReadOnly       bool          `protobuf:"varint,10,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	ElectionFreeze time.Duration `protobuf:"bytes,11,opt,name=election_freeze,json=electionFreeze,proto3,stdduration" json:"election_freeze"`
+}
+
+func (m *InstallRequest) Reset()         { *m = InstallRequest{} }
+func (m *InstallRequest) String() string { return proto.CompactTextString(m) }
+func (*InstallRequest) ProtoMessage()    {}
+func (*InstallRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{24}
+}
+func (m *InstallRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *QueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *InstallRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_QueryResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_InstallRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1540,486 +1857,998 @@ func (m *QueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *QueryResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryResponse.Merge(m, src)
+func (m *InstallRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstallRequest.Merge(m, src)
 }
-func (m *QueryResponse) XXX_Size() int {
+func (m *InstallRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *QueryResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryResponse.DiscardUnknown(m)
+func (m *InstallRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstallRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_QueryResponse proto.InternalMessageInfo
+var xxx_messageInfo_InstallRequest proto.InternalMessageInfo
 
-func (m *QueryResponse) GetStatus() ResponseStatus {
+func (m *InstallRequest) GetTerm() Term {
 	if m != nil {
-		return m.Status
+		return m.Term
 	}
-	return ResponseStatus_OK
+	return 0
 }
 
-func (m *QueryResponse) GetError() ResponseError {
+func (m *InstallRequest) GetLeader() MemberID {
 	if m != nil {
-		return m.Error
+		return m.Leader
 	}
-	return ResponseError_NO_LEADER
+	return ""
 }
 
-func (m *QueryResponse) GetMessage() string {
+func (m *InstallRequest) GetIndex() Index {
 	if m != nil {
-		return m.Message
+		return m.Index
 	}
-	return ""
+	return 0
 }
 
-func (m *QueryResponse) GetOutput() []byte {
+func (m *InstallRequest) GetTimestamp() time.Time {
 	if m != nil {
-		return m.Output
+		return m.Timestamp
 	}
-	return nil
+	return time.Time{}
 }
 
-func init() {
-	proto.RegisterEnum("atomix.raft.protocol.ReadConsistency", ReadConsistency_name, ReadConsistency_value)
-	proto.RegisterEnum("atomix.raft.protocol.ResponseStatus", ResponseStatus_name, ResponseStatus_value)
-	proto.RegisterEnum("atomix.raft.protocol.ResponseError", ResponseError_name, ResponseError_value)
-	proto.RegisterType((*JoinRequest)(nil), "atomix.raft.protocol.JoinRequest")
-	proto.RegisterType((*JoinResponse)(nil), "atomix.raft.protocol.JoinResponse")
-	proto.RegisterType((*ConfigureRequest)(nil), "atomix.raft.protocol.ConfigureRequest")
-	proto.RegisterType((*ConfigureResponse)(nil), "atomix.raft.protocol.ConfigureResponse")
-	proto.RegisterType((*ReconfigureRequest)(nil), "atomix.raft.protocol.ReconfigureRequest")
-	proto.RegisterType((*ReconfigureResponse)(nil), "atomix.raft.protocol.ReconfigureResponse")
-	proto.RegisterType((*LeaveRequest)(nil), "atomix.raft.protocol.LeaveRequest")
-	proto.RegisterType((*LeaveResponse)(nil), "atomix.raft.protocol.LeaveResponse")
-	proto.RegisterType((*PollRequest)(nil), "atomix.raft.protocol.PollRequest")
-	proto.RegisterType((*PollResponse)(nil), "atomix.raft.protocol.PollResponse")
-	proto.RegisterType((*VoteRequest)(nil), "atomix.raft.protocol.VoteRequest")
-	proto.RegisterType((*VoteResponse)(nil), "atomix.raft.protocol.VoteResponse")
-	proto.RegisterType((*TransferRequest)(nil), "atomix.raft.protocol.TransferRequest")
-	proto.RegisterType((*TransferResponse)(nil), "atomix.raft.protocol.TransferResponse")
-	proto.RegisterType((*AppendRequest)(nil), "atomix.raft.protocol.AppendRequest")
-	proto.RegisterType((*AppendResponse)(nil), "atomix.raft.protocol.AppendResponse")
-	proto.RegisterType((*InstallRequest)(nil), "atomix.raft.protocol.InstallRequest")
-	proto.RegisterType((*InstallResponse)(nil), "atomix.raft.protocol.InstallResponse")
-	proto.RegisterType((*CommandRequest)(nil), "atomix.raft.protocol.CommandRequest")
-	proto.RegisterType((*CommandResponse)(nil), "atomix.raft.protocol.CommandResponse")
-	proto.RegisterType((*QueryRequest)(nil), "atomix.raft.protocol.QueryRequest")
-	proto.RegisterType((*QueryResponse)(nil), "atomix.raft.protocol.QueryResponse")
+func (m *InstallRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-func init() {
-	proto.RegisterFile("atomix/raft/protocol/protocol.proto", fileDescriptor_2ab16e79e6abb7aa)
+func (m *InstallRequest) GetSnapshotTerm() Term {
+	if m != nil {
+		return m.SnapshotTerm
+	}
+	return 0
 }
 
-var fileDescriptor_2ab16e79e6abb7aa = []byte{
-	// 1353 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0xf6, 0x38, 0xb6, 0x63, 0x1f, 0xff, 0x6d, 0xa7, 0xa1, 0x58, 0x56, 0x65, 0x97, 0x4d, 0x1a,
-	0x42, 0x54, 0x39, 0xa8, 0x20, 0x7e, 0x24, 0x6e, 0xd6, 0xce, 0xb6, 0xda, 0x76, 0xe3, 0x4d, 0xc7,
-	0x76, 0x11, 0x45, 0xc2, 0xda, 0xda, 0x13, 0xcb, 0x92, 0xbd, 0x6b, 0x76, 0xd7, 0x51, 0xfb, 0x04,
-	0x88, 0x9f, 0x8b, 0x5e, 0xf3, 0x04, 0x7d, 0x02, 0x84, 0xe0, 0x0a, 0xb8, 0x29, 0x17, 0x48, 0xbd,
-	0xe4, 0x02, 0x05, 0x48, 0x1f, 0x01, 0x09, 0xa1, 0x5e, 0xa1, 0xfd, 0xf5, 0xae, 0xd9, 0xb5, 0x4b,
-	0x8a, 0x94, 0x20, 0xf5, 0x6e, 0xe7, 0xcc, 0x77, 0x3e, 0x9f, 0xf9, 0xce, 0x99, 0x99, 0x33, 0x86,
-	0x75, 0xd9, 0x50, 0xc7, 0xc3, 0x7b, 0x3b, 0x9a, 0x7c, 0x60, 0xec, 0x4c, 0x34, 0xd5, 0x50, 0x7b,
-	0xea, 0xc8, 0xfb, 0xa8, 0x59, 0x1f, 0x78, 0xcd, 0x06, 0xd5, 0x4c, 0x50, 0xcd, 0x9d, 0x2b, 0xb3,
-	0xa1, 0xae, 0xbd, 0xd1, 0x54, 0x37, 0xa8, 0x66, 0xc3, 0xca, 0x95, 0x50, 0xcc, 0x48, 0x1d, 0x38,
-	0xf3, 0xd5, 0x81, 0xaa, 0x0e, 0x46, 0xd4, 0x9e, 0xba, 0x3b, 0x3d, 0xd8, 0x31, 0x86, 0x63, 0xaa,
-	0x1b, 0xf2, 0x78, 0xe2, 0x00, 0xd6, 0x06, 0xea, 0x40, 0xb5, 0x3e, 0x77, 0xcc, 0x2f, 0xdb, 0xca,
-	0x36, 0x20, 0x7b, 0x43, 0x1d, 0x2a, 0x84, 0x7e, 0x3c, 0xa5, 0xba, 0x81, 0xdf, 0x84, 0xd4, 0x98,
-	0x8e, 0xef, 0x52, 0xad, 0x84, 0x2e, 0xa1, 0xad, 0xec, 0xd5, 0x8b, 0xb5, 0xb0, 0x80, 0x6b, 0x7b,
-	0x16, 0x86, 0x38, 0x58, 0xf6, 0xfb, 0x38, 0xe4, 0x6c, 0x16, 0x7d, 0xa2, 0x2a, 0x3a, 0xc5, 0xef,
-	0x41, 0x4a, 0x37, 0x64, 0x63, 0xaa, 0x5b, 0x34, 0x85, 0xab, 0x1b, 0xe1, 0x34, 0x2e, 0xbe, 0x65,
-	0x61, 0x89, 0xe3, 0x83, 0xdf, 0x85, 0x24, 0xd5, 0x34, 0x55, 0x2b, 0xc5, 0x2d, 0xe7, 0xf5, 0xc5,
-	0xce, 0xbc, 0x09, 0x25, 0xb6, 0x07, 0xae, 0x42, 0x72, 0xa8, 0xf4, 0xe9, 0xbd, 0xd2, 0xca, 0x25,
-	0xb4, 0x95, 0xa8, 0x67, 0x9e, 0x1e, 0x55, 0x93, 0x82, 0x69, 0x20, 0xb6, 0x1d, 0x5f, 0x84, 0x84,
-	0x41, 0xb5, 0x71, 0x29, 0x61, 0xcd, 0xa7, 0x9f, 0x1e, 0x55, 0x13, 0x6d, 0xaa, 0x8d, 0x89, 0x65,
-	0xc5, 0x75, 0xc8, 0x78, 0xb2, 0x95, 0x92, 0x96, 0x02, 0xe5, 0x9a, 0x2d, 0x6c, 0xcd, 0x15, 0xb6,
-	0xd6, 0x76, 0x11, 0xf5, 0xf4, 0xa3, 0xa3, 0x6a, 0xec, 0xc1, 0xaf, 0x55, 0x44, 0x66, 0x6e, 0xf8,
-	0x2d, 0x58, 0xb5, 0x65, 0xd1, 0x4b, 0xa9, 0x4b, 0x2b, 0x4b, 0x35, 0x74, 0xc1, 0xec, 0x1f, 0x08,
-	0x98, 0x86, 0xaa, 0x1c, 0x0c, 0x07, 0x53, 0x8d, 0xba, 0xf9, 0x70, 0xc3, 0x45, 0xa1, 0xe1, 0x6e,
-	0x40, 0x6a, 0x44, 0xe5, 0x3e, 0xb5, 0x95, 0xca, 0xd4, 0x73, 0x4f, 0x8f, 0xaa, 0x69, 0x9b, 0x57,
-	0xd8, 0x25, 0xce, 0xdc, 0x72, 0x4d, 0x02, 0xab, 0x4e, 0x3c, 0xf7, 0xaa, 0x93, 0xff, 0x66, 0xd5,
-	0x5f, 0x20, 0x38, 0xe7, 0x5b, 0xf5, 0x29, 0xd7, 0x0f, 0xfb, 0x29, 0x02, 0x4c, 0x68, 0x6f, 0x3e,
-	0x0d, 0x27, 0xda, 0x16, 0x33, 0xe1, 0xe3, 0x4b, 0x8a, 0x71, 0x25, 0x2c, 0xbb, 0xec, 0x8f, 0x71,
-	0x38, 0x1f, 0x88, 0xe5, 0xc5, 0xe6, 0x3a, 0xf1, 0xe6, 0xda, 0x85, 0x9c, 0x48, 0xe5, 0xc3, 0xe7,
-	0x4b, 0x28, 0xfb, 0x43, 0x1c, 0xf2, 0x0e, 0xcd, 0x8b, 0x5c, 0x9c, 0x38, 0x17, 0x5f, 0x21, 0xc8,
-	0xee, 0xab, 0xa3, 0xd1, 0xb3, 0x9d, 0x71, 0xdb, 0x90, 0xe9, 0xc9, 0x4a, 0x7f, 0xd8, 0x97, 0x0d,
-	0x1a, 0x7a, 0xcc, 0xcd, 0xa6, 0xf1, 0x0e, 0x14, 0x46, 0xb2, 0x6e, 0x74, 0x47, 0xea, 0xa0, 0x1b,
-	0xa1, 0x4e, 0xce, 0x04, 0x88, 0xea, 0xc0, 0x1a, 0xe1, 0x2b, 0x90, 0xf7, 0x1c, 0x42, 0xd5, 0xca,
-	0x3a, 0x70, 0x73, 0xc0, 0x7e, 0x87, 0x20, 0x67, 0x07, 0x7e, 0xda, 0xd9, 0x5f, 0x78, 0x70, 0xe0,
-	0x32, 0xa4, 0xe5, 0x5e, 0x8f, 0x4e, 0x0c, 0xda, 0xb7, 0x16, 0x94, 0x26, 0xde, 0xd8, 0x12, 0xff,
-	0xb6, 0x6a, 0xd0, 0xff, 0x9d, 0xf8, 0xdf, 0x22, 0xc8, 0xd9, 0x81, 0x9f, 0x6d, 0xf1, 0xd7, 0x20,
-	0x79, 0xa8, 0xce, 0x94, 0xb7, 0x07, 0xec, 0xdb, 0x50, 0x6c, 0x6b, 0xb2, 0xa2, 0x1f, 0x50, 0xcd,
-	0x55, 0x7e, 0x23, 0x70, 0x04, 0xfd, 0xe3, 0xf2, 0x76, 0x8e, 0x9c, 0xcf, 0x11, 0x30, 0x33, 0xcf,
-	0xd3, 0xbe, 0x1e, 0xbf, 0x8c, 0x43, 0x9e, 0x9b, 0x4c, 0xa8, 0xd2, 0xff, 0x2f, 0x1b, 0x94, 0x1d,
-	0x28, 0x4c, 0x34, 0x7a, 0xb8, 0xb0, 0x72, 0x4c, 0x80, 0xbf, 0x72, 0x3c, 0x87, 0xf0, 0xca, 0x71,
-	0xe0, 0xe6, 0x00, 0xbf, 0x03, 0xab, 0x54, 0x31, 0xb4, 0x21, 0x75, 0x5b, 0x93, 0x4a, 0xf8, 0x8a,
-	0x45, 0x75, 0xc0, 0x2b, 0x86, 0x76, 0x9f, 0xb8, 0x70, 0x7c, 0x05, 0x72, 0x3d, 0x75, 0x3c, 0x1e,
-	0x1a, 0x4e, 0x58, 0xa9, 0xf9, 0xb0, 0xb2, 0xf6, 0xb4, 0x35, 0x60, 0xff, 0x44, 0x50, 0x70, 0xc5,
-	0x39, 0xdb, 0x35, 0x7a, 0x11, 0x32, 0xfa, 0xb4, 0xd7, 0xa3, 0xb4, 0xef, 0xd5, 0xe9, 0xcc, 0x10,
-	0xb2, 0x91, 0x93, 0x0b, 0x37, 0x32, 0xfb, 0x13, 0x82, 0x82, 0xa0, 0xe8, 0x86, 0xfc, 0xac, 0x67,
-	0xfa, 0x19, 0xea, 0x5b, 0x31, 0x24, 0xfa, 0xb2, 0x21, 0x5b, 0x4b, 0xcc, 0x11, 0xeb, 0x9b, 0xfd,
-	0x0c, 0x41, 0xd1, 0x5b, 0xcf, 0x69, 0x6f, 0xb9, 0x4d, 0x28, 0x34, 0xd4, 0xf1, 0x58, 0x9e, 0x6d,
-	0x39, 0xf3, 0x84, 0x91, 0x47, 0x53, 0x6a, 0x45, 0x92, 0x23, 0xf6, 0x80, 0x7d, 0x18, 0x87, 0xa2,
-	0x07, 0x3c, 0xed, 0xf2, 0x2b, 0x99, 0xad, 0x81, 0xae, 0xcb, 0x03, 0x6a, 0x25, 0x2f, 0x43, 0xdc,
-	0xa1, 0x2f, 0xf5, 0x89, 0x05, 0xa9, 0x77, 0xcb, 0x27, 0x19, 0x5a, 0x3e, 0x9b, 0xc1, 0xc6, 0x63,
-	0x9e, 0xc4, 0x9d, 0xc4, 0x17, 0x20, 0xa5, 0x4e, 0x8d, 0xc9, 0xd4, 0x28, 0xad, 0x5a, 0x4a, 0x39,
-	0x23, 0xf6, 0x10, 0x72, 0xb7, 0xa6, 0x54, 0xbb, 0xbf, 0x50, 0x50, 0xbc, 0x0f, 0x8c, 0x46, 0xe5,
-	0x7e, 0xb7, 0xa7, 0x2a, 0xfa, 0x50, 0x37, 0xa8, 0xd2, 0xbb, 0xef, 0x28, 0x71, 0x39, 0x4a, 0x09,
-	0xb9, 0xdf, 0x98, 0x81, 0x49, 0x51, 0x0b, 0x1a, 0xd8, 0x6f, 0x10, 0xe4, 0x9d, 0x1f, 0x3e, 0xbb,
-	0x09, 0x9a, 0x89, 0x96, 0xf0, 0x8b, 0xb6, 0x7d, 0x13, 0x8a, 0x73, 0x0b, 0xc4, 0x05, 0x80, 0x16,
-	0x7f, 0xab, 0xc3, 0x37, 0xdb, 0x02, 0x27, 0x32, 0x31, 0x7c, 0x01, 0xb0, 0x28, 0x34, 0x79, 0x8e,
-	0x08, 0x77, 0xb8, 0xba, 0xc8, 0x77, 0x45, 0x9e, 0x6b, 0xf1, 0x0c, 0xc2, 0x0c, 0xe4, 0xfc, 0x76,
-	0x26, 0xbe, 0xbd, 0x0e, 0x85, 0xe0, 0x9a, 0x70, 0x0a, 0xe2, 0xd2, 0x4d, 0x26, 0x86, 0x33, 0x90,
-	0xe4, 0x09, 0x91, 0x08, 0x83, 0xb6, 0x3f, 0x89, 0x43, 0x3e, 0x10, 0x3c, 0xce, 0x43, 0xa6, 0x29,
-	0x99, 0xb4, 0xbb, 0x3c, 0x61, 0x62, 0xf8, 0x1c, 0xe4, 0x6f, 0x75, 0x78, 0xf2, 0x41, 0xf7, 0x1a,
-	0x27, 0x88, 0x1d, 0x62, 0xfe, 0xd4, 0x79, 0x28, 0x36, 0xa4, 0xbd, 0x3d, 0xae, 0xb9, 0xeb, 0x19,
-	0xe3, 0xf8, 0x25, 0x38, 0xc7, 0xed, 0xef, 0x8b, 0x42, 0x83, 0x6b, 0x0b, 0x52, 0xb3, 0x6b, 0xf3,
-	0xaf, 0xe0, 0x12, 0xac, 0x09, 0xa2, 0xc8, 0x5f, 0xe7, 0xc4, 0xee, 0x1e, 0xbf, 0x57, 0xe7, 0x49,
-	0xb7, 0xd5, 0xe6, 0xda, 0x3c, 0x93, 0xc0, 0x18, 0x0a, 0x9d, 0xe6, 0xcd, 0xa6, 0xf4, 0x7e, 0xb3,
-	0xdb, 0x10, 0x05, 0xbe, 0xd9, 0x66, 0x92, 0x26, 0xb3, 0x6b, 0x6b, 0xf1, 0xad, 0x96, 0x20, 0x35,
-	0x99, 0x54, 0xd0, 0x48, 0x6e, 0x0b, 0x0d, 0x9e, 0x59, 0x35, 0xbd, 0x1b, 0xa2, 0xd4, 0xe2, 0x77,
-	0x3d, 0x60, 0xda, 0xb4, 0xed, 0x13, 0xa9, 0x2d, 0x35, 0x24, 0xd1, 0xf9, 0xfd, 0x0c, 0x7e, 0x19,
-	0xce, 0x37, 0xa4, 0xe6, 0x35, 0xe1, 0x7a, 0x87, 0xf8, 0x03, 0x03, 0x5c, 0x84, 0x6c, 0xa7, 0xc9,
-	0xdd, 0xe6, 0x04, 0xd1, 0x92, 0x2b, 0x7b, 0xf5, 0x97, 0x55, 0xc8, 0x12, 0xf9, 0xc0, 0x68, 0x51,
-	0xed, 0x70, 0xd8, 0xa3, 0x58, 0x82, 0xc4, 0x0d, 0x75, 0xa8, 0xe0, 0x57, 0xc2, 0x33, 0xee, 0xfb,
-	0x43, 0xa7, 0xcc, 0x2e, 0x82, 0xd8, 0xda, 0xb2, 0x31, 0x4c, 0x20, 0x69, 0xbd, 0x6b, 0x70, 0x04,
-	0xdc, 0xff, 0x76, 0x2a, 0xaf, 0x2f, 0xc4, 0x78, 0x9c, 0x1f, 0x41, 0xc6, 0x7b, 0xd8, 0xe3, 0xcd,
-	0x70, 0x9f, 0xf9, 0xff, 0x3b, 0xca, 0xaf, 0x2e, 0xc5, 0x79, 0xfc, 0x7d, 0xc8, 0xfa, 0x5e, 0xc7,
-	0x78, 0x2b, 0xaa, 0xfa, 0xe7, 0x1f, 0xf3, 0xe5, 0xd7, 0x9e, 0x01, 0xe9, 0xfd, 0x8a, 0x04, 0x09,
-	0xb3, 0xe5, 0x8f, 0x92, 0xda, 0xf7, 0x8e, 0x89, 0x92, 0xda, 0xff, 0x62, 0xb0, 0x09, 0xcd, 0x36,
-	0x36, 0x8a, 0xd0, 0xd7, 0x9b, 0x47, 0x11, 0xfa, 0xbb, 0x60, 0x36, 0x86, 0x3f, 0x84, 0xb4, 0xdb,
-	0x20, 0xe2, 0x88, 0x93, 0x69, 0xae, 0xf5, 0x2c, 0x6f, 0x2e, 0x83, 0x79, 0xe4, 0x1d, 0x48, 0xd9,
-	0x2d, 0x0d, 0x8e, 0xc8, 0x7a, 0xa0, 0x1b, 0x2c, 0x6f, 0x2c, 0x06, 0x79, 0xb4, 0x77, 0x60, 0xd5,
-	0xb9, 0x60, 0x71, 0x84, 0x4b, 0xb0, 0x9f, 0x28, 0x5f, 0x5e, 0x82, 0x72, 0x99, 0xb7, 0x90, 0xc9,
-	0xed, 0xdc, 0x83, 0x51, 0xdc, 0xc1, 0xfb, 0x34, 0x8a, 0x7b, 0xee, 0x32, 0x65, 0x63, 0xaf, 0x23,
-	0xdc, 0x86, 0xa4, 0x75, 0x80, 0x47, 0xed, 0x13, 0xff, 0xb5, 0x12, 0xb5, 0x4f, 0x02, 0x37, 0x80,
-	0xc9, 0x5a, 0xdf, 0xf8, 0xeb, 0xf7, 0x0a, 0x7a, 0x78, 0x5c, 0x41, 0x5f, 0x1f, 0x57, 0xd0, 0xa3,
-	0xe3, 0x0a, 0x7a, 0x7c, 0x5c, 0x41, 0xbf, 0x1d, 0x57, 0xd0, 0x83, 0x27, 0x95, 0xd8, 0xe3, 0x27,
-	0x95, 0xd8, 0xcf, 0x4f, 0x2a, 0xb1, 0xbb, 0x29, 0x8b, 0xe1, 0x8d, 0xbf, 0x03, 0x00, 0x00, 0xff,
-	0xff, 0xd2, 0x1c, 0x28, 0x01, 0x68, 0x16, 0x00, 0x00,
+func (m *InstallRequest) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
 }
 
-func (this *JoinRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *InstallRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
 	}
+	return 0
+}
 
-	that1, ok := that.(*JoinRequest)
-	if !ok {
-		that2, ok := that.(JoinRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+func (m *InstallRequest) GetChecksum() uint32 {
+	if m != nil {
+		return m.Checksum
 	}
-	if !this.Member.Equal(that1.Member) {
-		return false
+	return 0
+}
+
+func (m *InstallRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
 	}
-	return true
+	return false
 }
-func (this *JoinResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *InstallRequest) GetElectionFreeze() time.Duration {
+	if m != nil {
+		return m.ElectionFreeze
 	}
+	return 0
+}
 
-	that1, ok := that.(*JoinResponse)
-	if !ok {
-		that2, ok := that.(JoinResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type InstallResponse struct {
+	Status ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error  ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Offset uint64         `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (m *InstallResponse) Reset()         { *m = InstallResponse{} }
+func (m *InstallResponse) String() string { return proto.CompactTextString(m) }
+func (*InstallResponse) ProtoMessage()    {}
+func (*InstallResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{25}
+}
+func (m *InstallResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InstallResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InstallResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Status != that1.Status {
-		return false
-	}
-	if this.Error != that1.Error {
-		return false
+}
+func (m *InstallResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstallResponse.Merge(m, src)
+}
+func (m *InstallResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *InstallResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstallResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstallResponse proto.InternalMessageInfo
+
+func (m *InstallResponse) GetStatus() ResponseStatus {
+	if m != nil {
+		return m.Status
 	}
-	if this.Index != that1.Index {
-		return false
-	}
-	if this.Term != that1.Term {
-		return false
-	}
-	if !this.Timestamp.Equal(that1.Timestamp) {
-		return false
-	}
-	if len(this.Members) != len(that1.Members) {
-		return false
-	}
-	for i := range this.Members {
-		if !this.Members[i].Equal(that1.Members[i]) {
-			return false
-		}
+	return ResponseStatus_OK
+}
+
+func (m *InstallResponse) GetError() ResponseError {
+	if m != nil {
+		return m.Error
 	}
-	return true
+	return ResponseError_NO_LEADER
 }
-func (this *ConfigureRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *InstallResponse) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
 	}
+	return 0
+}
 
-	that1, ok := that.(*ConfigureRequest)
-	if !ok {
-		that2, ok := that.(ConfigureRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type CommandRequest struct {
+	Value    []byte            `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Timeout  *time.Duration    `protobuf:"bytes,2,opt,name=timeout,proto3,stdduration" json:"timeout,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *CommandRequest) Reset()         { *m = CommandRequest{} }
+func (m *CommandRequest) String() string { return proto.CompactTextString(m) }
+func (*CommandRequest) ProtoMessage()    {}
+func (*CommandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{26}
+}
+func (m *CommandRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommandRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Term != that1.Term {
-		return false
-	}
-	if this.Leader != that1.Leader {
-		return false
-	}
-	if this.Index != that1.Index {
-		return false
-	}
-	if !this.Timestamp.Equal(that1.Timestamp) {
-		return false
-	}
-	if len(this.Members) != len(that1.Members) {
-		return false
+}
+func (m *CommandRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandRequest.Merge(m, src)
+}
+func (m *CommandRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommandRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandRequest proto.InternalMessageInfo
+
+func (m *CommandRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	for i := range this.Members {
-		if !this.Members[i].Equal(that1.Members[i]) {
-			return false
-		}
+	return nil
+}
+
+func (m *CommandRequest) GetTimeout() *time.Duration {
+	if m != nil {
+		return m.Timeout
 	}
-	return true
+	return nil
 }
-func (this *ConfigureResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *CommandRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
 	}
+	return nil
+}
 
-	that1, ok := that.(*ConfigureResponse)
-	if !ok {
-		that2, ok := that.(ConfigureResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type CommandResponse struct {
+	Status             ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error              ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Message            string         `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Leader             MemberID       `protobuf:"bytes,4,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
+	Term               Term           `protobuf:"varint,5,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Members            []MemberID     `protobuf:"bytes,6,rep,name=members,proto3,casttype=MemberID" json:"members,omitempty"`
+	Output             []byte         `protobuf:"bytes,7,opt,name=output,proto3" json:"output,omitempty"`
+	Partial            bool           `protobuf:"varint,8,opt,name=partial,proto3" json:"partial,omitempty"`
+	ElectionInProgress bool           `protobuf:"varint,9,opt,name=election_in_progress,json=electionInProgress,proto3" json:"election_in_progress,omitempty"`
+}
+
+func (m *CommandResponse) Reset()         { *m = CommandResponse{} }
+func (m *CommandResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandResponse) ProtoMessage()    {}
+func (*CommandResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{27}
+}
+func (m *CommandResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommandResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommandResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Status != that1.Status {
-		return false
+}
+func (m *CommandResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandResponse.Merge(m, src)
+}
+func (m *CommandResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommandResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandResponse proto.InternalMessageInfo
+
+func (m *CommandResponse) GetStatus() ResponseStatus {
+	if m != nil {
+		return m.Status
 	}
-	if this.Error != that1.Error {
-		return false
+	return ResponseStatus_OK
+}
+
+func (m *CommandResponse) GetError() ResponseError {
+	if m != nil {
+		return m.Error
 	}
-	return true
+	return ResponseError_NO_LEADER
 }
-func (this *ReconfigureRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *CommandResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
+	return ""
+}
 
-	that1, ok := that.(*ReconfigureRequest)
-	if !ok {
-		that2, ok := that.(ReconfigureRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *CommandResponse) GetLeader() MemberID {
+	if m != nil {
+		return m.Leader
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return ""
+}
+
+func (m *CommandResponse) GetTerm() Term {
+	if m != nil {
+		return m.Term
 	}
-	if !this.Member.Equal(that1.Member) {
-		return false
+	return 0
+}
+
+func (m *CommandResponse) GetMembers() []MemberID {
+	if m != nil {
+		return m.Members
 	}
-	if this.Index != that1.Index {
-		return false
+	return nil
+}
+
+func (m *CommandResponse) GetOutput() []byte {
+	if m != nil {
+		return m.Output
 	}
-	if this.Term != that1.Term {
-		return false
+	return nil
+}
+
+func (m *CommandResponse) GetPartial() bool {
+	if m != nil {
+		return m.Partial
 	}
-	return true
+	return false
 }
-func (this *ReconfigureResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *CommandResponse) GetElectionInProgress() bool {
+	if m != nil {
+		return m.ElectionInProgress
 	}
+	return false
+}
 
-	that1, ok := that.(*ReconfigureResponse)
-	if !ok {
-		that2, ok := that.(ReconfigureResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type CommandBatchRequest struct {
+	Values [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (m *CommandBatchRequest) Reset()         { *m = CommandBatchRequest{} }
+func (m *CommandBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*CommandBatchRequest) ProtoMessage()    {}
+func (*CommandBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{28}
+}
+func (m *CommandBatchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommandBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommandBatchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Status != that1.Status {
-		return false
-	}
-	if this.Error != that1.Error {
-		return false
-	}
-	if this.Index != that1.Index {
-		return false
-	}
-	if this.Term != that1.Term {
-		return false
-	}
-	if !this.Timestamp.Equal(that1.Timestamp) {
-		return false
-	}
-	if len(this.Members) != len(that1.Members) {
-		return false
-	}
-	for i := range this.Members {
-		if !this.Members[i].Equal(that1.Members[i]) {
-			return false
-		}
-	}
-	return true
 }
-func (this *LeaveRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *CommandBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandBatchRequest.Merge(m, src)
+}
+func (m *CommandBatchRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommandBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandBatchRequest proto.InternalMessageInfo
+
+func (m *CommandBatchRequest) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
 	}
+	return nil
+}
 
-	that1, ok := that.(*LeaveRequest)
-	if !ok {
-		that2, ok := that.(LeaveRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type CommandBatchResponse struct {
+	Status  ResponseStatus   `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error   ResponseError    `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Message string           `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Leader  MemberID         `protobuf:"bytes,4,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
+	Term    Term             `protobuf:"varint,5,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Members []MemberID       `protobuf:"bytes,6,rep,name=members,proto3,casttype=MemberID" json:"members,omitempty"`
+	Results []*CommandResult `protobuf:"bytes,7,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (m *CommandBatchResponse) Reset()         { *m = CommandBatchResponse{} }
+func (m *CommandBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandBatchResponse) ProtoMessage()    {}
+func (*CommandBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{29}
+}
+func (m *CommandBatchResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommandBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommandBatchResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *CommandBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandBatchResponse.Merge(m, src)
+}
+func (m *CommandBatchResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommandBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandBatchResponse proto.InternalMessageInfo
+
+func (m *CommandBatchResponse) GetStatus() ResponseStatus {
+	if m != nil {
+		return m.Status
 	}
-	if !this.Member.Equal(that1.Member) {
-		return false
+	return ResponseStatus_OK
+}
+
+func (m *CommandBatchResponse) GetError() ResponseError {
+	if m != nil {
+		return m.Error
 	}
-	return true
+	return ResponseError_NO_LEADER
 }
-func (this *LeaveResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *CommandBatchResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
+	return ""
+}
 
-	that1, ok := that.(*LeaveResponse)
-	if !ok {
-		that2, ok := that.(LeaveResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *CommandBatchResponse) GetLeader() MemberID {
+	if m != nil {
+		return m.Leader
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return ""
+}
+
+func (m *CommandBatchResponse) GetTerm() Term {
+	if m != nil {
+		return m.Term
 	}
-	if this.Status != that1.Status {
-		return false
+	return 0
+}
+
+func (m *CommandBatchResponse) GetMembers() []MemberID {
+	if m != nil {
+		return m.Members
 	}
-	if this.Error != that1.Error {
-		return false
+	return nil
+}
+
+func (m *CommandBatchResponse) GetResults() []*CommandResult {
+	if m != nil {
+		return m.Results
 	}
-	if this.Index != that1.Index {
-		return false
+	return nil
+}
+
+type CommandResult struct {
+	Status  ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error   ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Message string         `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Index   Index          `protobuf:"varint,4,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	Output  []byte         `protobuf:"bytes,5,opt,name=output,proto3" json:"output,omitempty"`
+}
+
+func (m *CommandResult) Reset()         { *m = CommandResult{} }
+func (m *CommandResult) String() string { return proto.CompactTextString(m) }
+func (*CommandResult) ProtoMessage()    {}
+func (*CommandResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{30}
+}
+func (m *CommandResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommandResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommandResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if this.Term != that1.Term {
-		return false
+}
+func (m *CommandResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandResult.Merge(m, src)
+}
+func (m *CommandResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommandResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandResult proto.InternalMessageInfo
+
+func (m *CommandResult) GetStatus() ResponseStatus {
+	if m != nil {
+		return m.Status
 	}
-	if !this.Timestamp.Equal(that1.Timestamp) {
-		return false
+	return ResponseStatus_OK
+}
+
+func (m *CommandResult) GetError() ResponseError {
+	if m != nil {
+		return m.Error
 	}
-	if len(this.Members) != len(that1.Members) {
-		return false
+	return ResponseError_NO_LEADER
+}
+
+func (m *CommandResult) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	for i := range this.Members {
-		if !this.Members[i].Equal(that1.Members[i]) {
-			return false
-		}
+	return ""
+}
+
+func (m *CommandResult) GetIndex() Index {
+	if m != nil {
+		return m.Index
 	}
-	return true
+	return 0
 }
-func (this *PollRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *CommandResult) GetOutput() []byte {
+	if m != nil {
+		return m.Output
 	}
+	return nil
+}
 
-	that1, ok := that.(*PollRequest)
-	if !ok {
-		that2, ok := that.(PollRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type QueryRequest struct {
+	Value           []byte          `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	ReadConsistency ReadConsistency `protobuf:"varint,2,opt,name=read_consistency,json=readConsistency,proto3,enum=atomix.raft.protocol.ReadConsistency" json:"read_consistency,omitempty"`
+	SequenceNumber  uint64          `protobuf:"varint,3,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	MinAppliedIndex Index           `protobuf:"varint,4,opt,name=min_applied_index,json=minAppliedIndex,proto3,casttype=Index" json:"min_applied_index,omitempty"`
+}
+
+func (m *QueryRequest) Reset()         { *m = QueryRequest{} }
+func (m *QueryRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryRequest) ProtoMessage()    {}
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{31}
+}
+func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Term != that1.Term {
-		return false
-	}
-	if this.Candidate != that1.Candidate {
-		return false
-	}
-	if this.LastLogIndex != that1.LastLogIndex {
-		return false
+}
+func (m *QueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRequest.Merge(m, src)
+}
+func (m *QueryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryRequest proto.InternalMessageInfo
+
+func (m *QueryRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	if this.LastLogTerm != that1.LastLogTerm {
-		return false
+	return nil
+}
+
+func (m *QueryRequest) GetReadConsistency() ReadConsistency {
+	if m != nil {
+		return m.ReadConsistency
 	}
-	return true
+	return ReadConsistency_SEQUENTIAL
 }
-func (this *PollResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *QueryRequest) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
 	}
+	return 0
+}
 
-	that1, ok := that.(*PollResponse)
+func (m *QueryRequest) GetMinAppliedIndex() Index {
+	if m != nil {
+		return m.MinAppliedIndex
+	}
+	return 0
+}
+
+type QueryResponse struct {
+	Status         ResponseStatus `protobuf:"varint,1,opt,name=status,proto3,enum=atomix.raft.protocol.ResponseStatus" json:"status,omitempty"`
+	Error          ResponseError  `protobuf:"varint,2,opt,name=error,proto3,enum=atomix.raft.protocol.ResponseError" json:"error,omitempty"`
+	Message        string         `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Output         []byte         `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`
+	SequenceNumber uint64         `protobuf:"varint,5,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	ReadRepair     bool           `protobuf:"varint,6,opt,name=read_repair,json=readRepair,proto3" json:"read_repair,omitempty"`
+	StaleBy        Index          `protobuf:"varint,7,opt,name=stale_by,json=staleBy,proto3,casttype=Index" json:"stale_by,omitempty"`
+}
+
+func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
+func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryResponse) ProtoMessage()    {}
+func (*QueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{32}
+}
+func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryResponse.Merge(m, src)
+}
+func (m *QueryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryResponse proto.InternalMessageInfo
+
+func (m *QueryResponse) GetStatus() ResponseStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ResponseStatus_OK
+}
+
+func (m *QueryResponse) GetError() ResponseError {
+	if m != nil {
+		return m.Error
+	}
+	return ResponseError_NO_LEADER
+}
+
+func (m *QueryResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *QueryResponse) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *QueryResponse) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+func (m *QueryResponse) GetReadRepair() bool {
+	if m != nil {
+		return m.ReadRepair
+	}
+	return false
+}
+
+func (m *QueryResponse) GetStaleBy() Index {
+	if m != nil {
+		return m.StaleBy
+	}
+	return 0
+}
+
+type ProgressRequest struct {
+}
+
+func (m *ProgressRequest) Reset()         { *m = ProgressRequest{} }
+func (m *ProgressRequest) String() string { return proto.CompactTextString(m) }
+func (*ProgressRequest) ProtoMessage()    {}
+func (*ProgressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{33}
+}
+func (m *ProgressRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProgressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProgressRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProgressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProgressRequest.Merge(m, src)
+}
+func (m *ProgressRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProgressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProgressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProgressRequest proto.InternalMessageInfo
+
+type ProgressResponse struct {
+	CommitIndex  Index `protobuf:"varint,1,opt,name=commit_index,json=commitIndex,proto3,casttype=Index" json:"commit_index,omitempty"`
+	AppliedIndex Index `protobuf:"varint,2,opt,name=applied_index,json=appliedIndex,proto3,casttype=Index" json:"applied_index,omitempty"`
+	Lag          Index `protobuf:"varint,3,opt,name=lag,proto3,casttype=Index" json:"lag,omitempty"`
+}
+
+func (m *ProgressResponse) Reset()         { *m = ProgressResponse{} }
+func (m *ProgressResponse) String() string { return proto.CompactTextString(m) }
+func (*ProgressResponse) ProtoMessage()    {}
+func (*ProgressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab16e79e6abb7aa, []int{34}
+}
+func (m *ProgressResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProgressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProgressResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProgressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProgressResponse.Merge(m, src)
+}
+func (m *ProgressResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProgressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProgressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProgressResponse proto.InternalMessageInfo
+
+func (m *ProgressResponse) GetCommitIndex() Index {
+	if m != nil {
+		return m.CommitIndex
+	}
+	return 0
+}
+
+func (m *ProgressResponse) GetAppliedIndex() Index {
+	if m != nil {
+		return m.AppliedIndex
+	}
+	return 0
+}
+
+func (m *ProgressResponse) GetLag() Index {
+	if m != nil {
+		return m.Lag
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("atomix.raft.protocol.ReadConsistency", ReadConsistency_name, ReadConsistency_value)
+	proto.RegisterEnum("atomix.raft.protocol.ResponseStatus", ResponseStatus_name, ResponseStatus_value)
+	proto.RegisterEnum("atomix.raft.protocol.ResponseError", ResponseError_name, ResponseError_value)
+	proto.RegisterType((*JoinRequest)(nil), "atomix.raft.protocol.JoinRequest")
+	proto.RegisterType((*JoinResponse)(nil), "atomix.raft.protocol.JoinResponse")
+	proto.RegisterType((*ConfigureRequest)(nil), "atomix.raft.protocol.ConfigureRequest")
+	proto.RegisterType((*ConfigureResponse)(nil), "atomix.raft.protocol.ConfigureResponse")
+	proto.RegisterType((*ReconfigureRequest)(nil), "atomix.raft.protocol.ReconfigureRequest")
+	proto.RegisterType((*ReconfigureResponse)(nil), "atomix.raft.protocol.ReconfigureResponse")
+	proto.RegisterType((*LeaveRequest)(nil), "atomix.raft.protocol.LeaveRequest")
+	proto.RegisterType((*LeaveResponse)(nil), "atomix.raft.protocol.LeaveResponse")
+	proto.RegisterType((*SetReadOnlyRequest)(nil), "atomix.raft.protocol.SetReadOnlyRequest")
+	proto.RegisterType((*SetReadOnlyResponse)(nil), "atomix.raft.protocol.SetReadOnlyResponse")
+	proto.RegisterType((*FreezeElectionsRequest)(nil), "atomix.raft.protocol.FreezeElectionsRequest")
+	proto.RegisterType((*FreezeElectionsResponse)(nil), "atomix.raft.protocol.FreezeElectionsResponse")
+	proto.RegisterType((*PollRequest)(nil), "atomix.raft.protocol.PollRequest")
+	proto.RegisterType((*PollResponse)(nil), "atomix.raft.protocol.PollResponse")
+	proto.RegisterType((*VoteRequest)(nil), "atomix.raft.protocol.VoteRequest")
+	proto.RegisterType((*VoteResponse)(nil), "atomix.raft.protocol.VoteResponse")
+	proto.RegisterType((*TransferRequest)(nil), "atomix.raft.protocol.TransferRequest")
+	proto.RegisterType((*TransferResponse)(nil), "atomix.raft.protocol.TransferResponse")
+	proto.RegisterType((*ConfirmLeadershipRequest)(nil), "atomix.raft.protocol.ConfirmLeadershipRequest")
+	proto.RegisterType((*ConfirmLeadershipResponse)(nil), "atomix.raft.protocol.ConfirmLeadershipResponse")
+	proto.RegisterType((*HashRequest)(nil), "atomix.raft.protocol.HashRequest")
+	proto.RegisterType((*HashResponse)(nil), "atomix.raft.protocol.HashResponse")
+	proto.RegisterType((*AppendRequest)(nil), "atomix.raft.protocol.AppendRequest")
+	proto.RegisterType((*AppendResponse)(nil), "atomix.raft.protocol.AppendResponse")
+	proto.RegisterType((*InstallRequest)(nil), "atomix.raft.protocol.InstallRequest")
+	proto.RegisterType((*InstallResponse)(nil), "atomix.raft.protocol.InstallResponse")
+	proto.RegisterType((*CommandRequest)(nil), "atomix.raft.protocol.CommandRequest")
+	proto.RegisterMapType((map[string]string)(nil), "atomix.raft.protocol.CommandRequest.MetadataEntry")
+	proto.RegisterType((*CommandResponse)(nil), "atomix.raft.protocol.CommandResponse")
+	proto.RegisterType((*CommandBatchRequest)(nil), "atomix.raft.protocol.CommandBatchRequest")
+	proto.RegisterType((*CommandBatchResponse)(nil), "atomix.raft.protocol.CommandBatchResponse")
+	proto.RegisterType((*CommandResult)(nil), "atomix.raft.protocol.CommandResult")
+	proto.RegisterType((*QueryRequest)(nil), "atomix.raft.protocol.QueryRequest")
+	proto.RegisterType((*QueryResponse)(nil), "atomix.raft.protocol.QueryResponse")
+	proto.RegisterType((*ProgressRequest)(nil), "atomix.raft.protocol.ProgressRequest")
+	proto.RegisterType((*ProgressResponse)(nil), "atomix.raft.protocol.ProgressResponse")
+}
+
+func init() {
+	proto.RegisterFile("atomix/raft/protocol/protocol.proto", fileDescriptor_2ab16e79e6abb7aa)
+}
+
+var fileDescriptor_2ab16e79e6abb7aa = []byte{
+	// 2378 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4d, 0x8c, 0x1b, 0x49,
+	0xf5, 0x9f, 0xf6, 0xb7, 0x9f, 0xbf, 0x7a, 0x6a, 0x66, 0xb3, 0x4e, 0x6f, 0xfe, 0x9e, 0xfc, 0x7b,
+	0x26, 0xd9, 0xc9, 0x28, 0xf1, 0x2c, 0xe1, 0x6b, 0x77, 0xf9, 0x92, 0xc7, 0xd3, 0x49, 0x7a, 0xe3,
+	0xb1, 0x27, 0x65, 0x4f, 0x50, 0x82, 0xd8, 0x56, 0xc7, 0x2e, 0x7b, 0xac, 0xb5, 0xbb, 0x4d, 0x77,
+	0x7b, 0xc8, 0x70, 0x43, 0x1c, 0x38, 0x00, 0xd2, 0x72, 0x41, 0x9c, 0x91, 0x90, 0x10, 0x77, 0x10,
+	0x77, 0x84, 0xb4, 0x1c, 0x40, 0x91, 0x40, 0x08, 0x09, 0x29, 0x40, 0x72, 0xe7, 0x00, 0x1c, 0x50,
+	0x4e, 0xa8, 0xaa, 0x3f, 0xdc, 0xed, 0xcf, 0xd9, 0x6c, 0xc4, 0x4c, 0xa4, 0xdc, 0xba, 0xea, 0xfd,
+	0xde, 0xab, 0xaa, 0xf7, 0x5e, 0xbd, 0x7a, 0xf5, 0xaa, 0x61, 0x5d, 0xb5, 0xf4, 0x7e, 0xf7, 0xe1,
+	0xb6, 0xa1, 0xb6, 0xad, 0xed, 0x81, 0xa1, 0x5b, 0x7a, 0x53, 0xef, 0x79, 0x1f, 0x45, 0xf6, 0x81,
+	0x56, 0x6d, 0x50, 0x91, 0x82, 0x8a, 0x2e, 0x4d, 0x10, 0xa7, 0xb2, 0x36, 0x7b, 0x43, 0xd3, 0x22,
+	0x86, 0x0d, 0x13, 0x0a, 0x53, 0x31, 0x3d, 0xbd, 0xe3, 0xd2, 0x3b, 0xba, 0xde, 0xe9, 0x11, 0x9b,
+	0xf4, 0x60, 0xd8, 0xde, 0x6e, 0x0d, 0x0d, 0xd5, 0xea, 0xea, 0x9a, 0x43, 0x5f, 0x1b, 0xa7, 0x5b,
+	0xdd, 0x3e, 0x31, 0x2d, 0xb5, 0x3f, 0x70, 0x00, 0xab, 0x1d, 0xbd, 0xa3, 0xb3, 0xcf, 0x6d, 0xfa,
+	0x65, 0xf7, 0x8a, 0x2a, 0xa4, 0xde, 0xd3, 0xbb, 0x1a, 0x26, 0xdf, 0x18, 0x12, 0xd3, 0x42, 0x9f,
+	0x81, 0x58, 0x9f, 0xf4, 0x1f, 0x10, 0x23, 0xcf, 0x5d, 0xe4, 0x36, 0x53, 0xd7, 0x2f, 0x14, 0xa7,
+	0x2d, 0xa8, 0xb8, 0xc7, 0x30, 0xd8, 0xc1, 0xa2, 0x0b, 0x90, 0x6c, 0xeb, 0xc6, 0x37, 0x55, 0xa3,
+	0x45, 0x5a, 0xf9, 0xd0, 0x45, 0x6e, 0x33, 0x81, 0x47, 0x1d, 0xe2, 0x8f, 0xc2, 0x90, 0xb6, 0xc7,
+	0x30, 0x07, 0xba, 0x66, 0x12, 0xf4, 0x45, 0x88, 0x99, 0x96, 0x6a, 0x0d, 0x4d, 0x36, 0x48, 0xf6,
+	0xfa, 0xc6, 0xf4, 0x41, 0x5c, 0x7c, 0x9d, 0x61, 0xb1, 0xc3, 0x83, 0xde, 0x81, 0x28, 0x31, 0x0c,
+	0xdd, 0x60, 0x03, 0x65, 0xaf, 0xaf, 0xcf, 0x67, 0x96, 0x28, 0x14, 0xdb, 0x1c, 0x68, 0x0d, 0xa2,
+	0x5d, 0xad, 0x45, 0x1e, 0xe6, 0xc3, 0x17, 0xb9, 0xcd, 0xc8, 0x4e, 0xf2, 0xd9, 0xe3, 0xb5, 0xa8,
+	0x4c, 0x3b, 0xb0, 0xdd, 0x8f, 0x2e, 0x40, 0xc4, 0x22, 0x46, 0x3f, 0x1f, 0x61, 0xf4, 0xc4, 0xb3,
+	0xc7, 0x6b, 0x91, 0x06, 0x31, 0xfa, 0x98, 0xf5, 0xa2, 0x1d, 0x48, 0x7a, 0x4a, 0xcd, 0x47, 0x99,
+	0x7e, 0x84, 0xa2, 0xad, 0xf6, 0xa2, 0xab, 0xf6, 0x62, 0xc3, 0x45, 0xec, 0x24, 0x3e, 0x7a, 0xbc,
+	0xb6, 0xf4, 0xe1, 0x5f, 0xd7, 0x38, 0x3c, 0x62, 0x43, 0x9f, 0x83, 0xb8, 0xad, 0x34, 0x33, 0x1f,
+	0xbb, 0x18, 0x5e, 0xa8, 0x61, 0x17, 0x8c, 0x36, 0x20, 0xd6, 0x23, 0x6a, 0x8b, 0x18, 0xf9, 0xf8,
+	0x45, 0x6e, 0x33, 0xb9, 0x93, 0x7e, 0xf6, 0x78, 0x2d, 0x61, 0x83, 0xe4, 0x5d, 0xec, 0xd0, 0xd0,
+	0x55, 0x48, 0x37, 0xf5, 0x7e, 0xbf, 0x6b, 0x29, 0xf6, 0x3a, 0x13, 0xe3, 0xeb, 0x4c, 0xd9, 0x64,
+	0xd6, 0x10, 0xff, 0xc5, 0x01, 0x5f, 0xd6, 0xb5, 0x76, 0xb7, 0x33, 0x34, 0x88, 0xeb, 0x01, 0xae,
+	0x0a, 0xb8, 0xa9, 0x2a, 0x18, 0x4d, 0x23, 0x34, 0x67, 0x1a, 0x0b, 0xf5, 0x1c, 0xd0, 0x64, 0xe4,
+	0x13, 0x6b, 0x32, 0xfa, 0x31, 0x34, 0x29, 0x7e, 0x9f, 0x83, 0x65, 0xdf, 0xaa, 0x4f, 0xd9, 0x27,
+	0xc5, 0x9f, 0x86, 0x00, 0x61, 0xd2, 0x1c, 0x37, 0xc3, 0xf3, 0x6d, 0x44, 0x4f, 0xf1, 0xa1, 0x05,
+	0x0e, 0x1e, 0x9e, 0x6a, 0xdd, 0xf7, 0x80, 0x27, 0x3d, 0xd2, 0xa4, 0x51, 0x45, 0xa1, 0x8a, 0xd6,
+	0x87, 0x96, 0x63, 0x9d, 0xf3, 0x13, 0xd6, 0xd9, 0x75, 0xc2, 0xcf, 0x4e, 0xe4, 0xc7, 0xd4, 0x30,
+	0x39, 0x97, 0xb1, 0x61, 0xf3, 0xa1, 0x2a, 0xa0, 0x43, 0xa2, 0x1a, 0xd6, 0x03, 0xa2, 0x52, 0x6f,
+	0xb4, 0x88, 0x71, 0xa4, 0xf6, 0xf2, 0xd1, 0x93, 0x49, 0x5b, 0xf6, 0x58, 0x65, 0x87, 0x53, 0xfc,
+	0x6d, 0x08, 0x56, 0x02, 0x7a, 0x7a, 0x15, 0x4c, 0x9e, 0x37, 0x98, 0x88, 0xbb, 0x90, 0xae, 0x10,
+	0xf5, 0xe8, 0x93, 0x39, 0x9b, 0xf8, 0xeb, 0x10, 0x64, 0x1c, 0x31, 0xaf, 0x6c, 0xf1, 0xdc, 0xb6,
+	0xf8, 0x14, 0xa0, 0x3a, 0xb1, 0x30, 0x51, 0x5b, 0x35, 0xad, 0x77, 0xec, 0x5a, 0xe4, 0x0d, 0x48,
+	0x1a, 0x44, 0x6d, 0x29, 0xba, 0xd6, 0x3b, 0x66, 0xca, 0x4c, 0xe0, 0x84, 0xe1, 0x60, 0xc4, 0xdf,
+	0x71, 0xb0, 0x12, 0xe0, 0x79, 0xb9, 0xd5, 0x2f, 0xde, 0x83, 0x73, 0x37, 0x0c, 0x42, 0xbe, 0x45,
+	0x24, 0x27, 0x86, 0x98, 0xae, 0x1a, 0xbe, 0x02, 0x09, 0x37, 0xcd, 0xc9, 0x73, 0x8b, 0x42, 0x07,
+	0x33, 0x0b, 0x0b, 0x1f, 0x1e, 0x93, 0xf8, 0xc3, 0x10, 0xbc, 0x3e, 0x21, 0xfb, 0x25, 0xf7, 0xd6,
+	0x2f, 0x43, 0x9c, 0x3c, 0x1c, 0x74, 0x0d, 0x62, 0x7e, 0x2c, 0x5f, 0x75, 0x99, 0xc4, 0x5f, 0x72,
+	0x90, 0xda, 0xd7, 0x7b, 0xbd, 0x93, 0x9d, 0xf8, 0x5b, 0x90, 0x6c, 0xaa, 0x5a, 0xab, 0xdb, 0x52,
+	0x2d, 0x32, 0xf5, 0xd0, 0x1f, 0x91, 0xd1, 0x36, 0x64, 0x7b, 0xaa, 0x69, 0x29, 0x3d, 0xbd, 0xa3,
+	0xcc, 0x58, 0x61, 0x9a, 0x02, 0x2a, 0x7a, 0x87, 0xb5, 0xd0, 0x55, 0xc8, 0x78, 0x0c, 0x53, 0x57,
+	0x9c, 0x72, 0xe0, 0xb4, 0x21, 0x7e, 0x37, 0x04, 0x69, 0x7b, 0xe2, 0xa7, 0x6d, 0xc1, 0xf9, 0xc7,
+	0xa8, 0x00, 0x09, 0xb5, 0xd9, 0x24, 0x03, 0x8b, 0xb4, 0xd8, 0x82, 0x12, 0xd8, 0x6b, 0xa3, 0x5d,
+	0x48, 0x19, 0xc4, 0x32, 0x8e, 0x15, 0xb5, 0x6d, 0x11, 0x63, 0xf1, 0x79, 0x38, 0x72, 0x6a, 0x60,
+	0x7c, 0x25, 0xca, 0x26, 0xfe, 0x93, 0x83, 0xd4, 0x5d, 0xdd, 0x22, 0x2f, 0x9b, 0x09, 0xd1, 0xbb,
+	0xb0, 0xe2, 0x1e, 0xe1, 0x6c, 0x7d, 0xce, 0x18, 0xd1, 0xf1, 0x31, 0x50, 0x00, 0xc5, 0xfa, 0xc4,
+	0x6f, 0x87, 0x20, 0x6d, 0x2f, 0xfa, 0x6c, 0x9b, 0x7f, 0x15, 0xa2, 0x47, 0xfa, 0xc8, 0xf6, 0x76,
+	0xe3, 0x05, 0x19, 0xfe, 0x10, 0x72, 0x0d, 0x43, 0xd5, 0xcc, 0x36, 0x31, 0x5c, 0xdb, 0x6f, 0x04,
+	0x0e, 0xef, 0x89, 0x94, 0xdc, 0xa6, 0x4d, 0xb1, 0x6b, 0x68, 0xae, 0x5d, 0xc5, 0xef, 0x71, 0xc0,
+	0x8f, 0x86, 0x3a, 0xed, 0x2c, 0xf9, 0x7d, 0xc8, 0xb3, 0x9c, 0xdd, 0xe8, 0x57, 0xd8, 0x15, 0xc3,
+	0x3c, 0xec, 0x0e, 0x5e, 0xe0, 0x8d, 0x45, 0x7c, 0xc4, 0xc1, 0xf9, 0x29, 0x03, 0x9c, 0x6d, 0x47,
+	0xbb, 0x00, 0xc9, 0xa6, 0x3d, 0x67, 0xcf, 0xd9, 0x46, 0x1d, 0x62, 0x13, 0x52, 0xb7, 0x54, 0xf3,
+	0xd0, 0xd5, 0xd2, 0x16, 0xa4, 0xda, 0x5d, 0xc3, 0x74, 0x6f, 0x86, 0xdc, 0xb8, 0xf5, 0x81, 0x51,
+	0xd9, 0x37, 0xda, 0x04, 0xe8, 0xa9, 0x1e, 0x74, 0xc2, 0x51, 0x92, 0x94, 0x68, 0x7b, 0xc9, 0x1f,
+	0x38, 0x48, 0xdb, 0xa3, 0x9c, 0xb6, 0xaa, 0xf2, 0x34, 0xff, 0x32, 0x4d, 0xb5, 0x43, 0x98, 0xb6,
+	0x92, 0xd8, 0x6d, 0x2e, 0x38, 0x4d, 0x11, 0x44, 0x0e, 0x55, 0xf3, 0xd0, 0x0e, 0x41, 0x98, 0x7d,
+	0x8b, 0xbf, 0x0f, 0x43, 0xa6, 0x34, 0x18, 0x10, 0xad, 0xf5, 0x22, 0x6f, 0xc5, 0xdb, 0x90, 0x1d,
+	0x18, 0xe4, 0x68, 0x6e, 0x68, 0xa5, 0x00, 0x7f, 0x68, 0xf5, 0x18, 0xa6, 0x87, 0x56, 0x07, 0x4e,
+	0x1b, 0xe8, 0x6d, 0x88, 0x13, 0xcd, 0x32, 0xba, 0xc4, 0xbd, 0x0f, 0x17, 0xa6, 0x6b, 0xaf, 0xa2,
+	0x77, 0x24, 0xcd, 0x32, 0x8e, 0xb1, 0x0b, 0x9f, 0xa8, 0x1a, 0xc4, 0xe6, 0x55, 0x0d, 0x82, 0xc9,
+	0x72, 0xfc, 0xf9, 0x92, 0xe5, 0xcf, 0xc2, 0xb2, 0xad, 0x14, 0xc5, 0xe7, 0x67, 0x13, 0xc5, 0x8a,
+	0x9c, 0x8d, 0xa9, 0xb8, 0xde, 0x86, 0x3e, 0x0f, 0xc8, 0x61, 0xf3, 0xbb, 0x72, 0x72, 0x9c, 0x8f,
+	0xb7, 0x41, 0x37, 0x3c, 0x87, 0x16, 0x7f, 0x11, 0x86, 0xac, 0x6b, 0xd0, 0x33, 0xbf, 0xa7, 0xcd,
+	0x61, 0xb3, 0x49, 0x48, 0x6b, 0xb4, 0xa7, 0xbd, 0x8e, 0x29, 0x51, 0x3c, 0x3a, 0xff, 0x74, 0xbe,
+	0x00, 0x49, 0xcb, 0x18, 0x6a, 0x4d, 0x95, 0x9e, 0x47, 0xcc, 0xae, 0x78, 0xd4, 0x31, 0x79, 0x76,
+	0xc7, 0xe7, 0x9d, 0xdd, 0x01, 0xc3, 0x27, 0x9e, 0xcf, 0xf0, 0xd7, 0x00, 0x99, 0x9a, 0x3a, 0x30,
+	0x0f, 0x75, 0x4b, 0x31, 0xec, 0xbd, 0x45, 0x5a, 0xcc, 0x82, 0x09, 0xbc, 0xec, 0x52, 0xb0, 0x4b,
+	0x60, 0x76, 0x93, 0x35, 0xd3, 0x52, 0x7b, 0xbd, 0x17, 0xb9, 0x13, 0xff, 0x27, 0xf5, 0x29, 0x04,
+	0x91, 0x96, 0x6a, 0xa9, 0xcc, 0x42, 0x69, 0xcc, 0xbe, 0xd1, 0x35, 0xc8, 0x78, 0xcb, 0x67, 0xab,
+	0x88, 0x8d, 0xad, 0x22, 0xed, 0x92, 0x69, 0x8b, 0xc6, 0xb4, 0x23, 0x62, 0x98, 0xf4, 0xf6, 0x43,
+	0x2d, 0x93, 0xc1, 0x6e, 0x13, 0x9d, 0x83, 0x98, 0xde, 0x6e, 0x9b, 0xc4, 0xb2, 0x77, 0x0d, 0x76,
+	0x5a, 0x34, 0xf5, 0x6c, 0x1e, 0x92, 0xe6, 0x07, 0xe6, 0xb0, 0xcf, 0xb4, 0x9a, 0xc1, 0x5e, 0x3b,
+	0x78, 0xa7, 0x84, 0xe0, 0x9d, 0x12, 0x55, 0xc0, 0xab, 0xe0, 0x28, 0x6d, 0x76, 0x61, 0xca, 0xa7,
+	0x4e, 0x9e, 0xa2, 0x64, 0x5d, 0x5e, 0xfb, 0xae, 0x25, 0xfe, 0x84, 0x83, 0x9c, 0x67, 0xb7, 0xd3,
+	0xde, 0x70, 0x23, 0x5d, 0x85, 0xfd, 0xba, 0x12, 0xff, 0xc1, 0x41, 0xb6, 0xac, 0xf7, 0xfb, 0xea,
+	0x28, 0xcc, 0xd3, 0xd4, 0x4d, 0xed, 0x0d, 0x09, 0x9b, 0x62, 0x1a, 0xdb, 0x0d, 0xf4, 0x0e, 0xc4,
+	0xdd, 0x6a, 0x58, 0xe8, 0x64, 0xf5, 0x2b, 0x17, 0x8f, 0xaa, 0x90, 0xe8, 0x13, 0x4b, 0x65, 0x8e,
+	0x10, 0x66, 0x51, 0xf9, 0xfa, 0xf4, 0x99, 0x07, 0x27, 0x52, 0xdc, 0x73, 0x98, 0xec, 0x48, 0xed,
+	0xc9, 0x10, 0xbe, 0x00, 0x99, 0x00, 0x09, 0xf1, 0x10, 0xfe, 0x80, 0xd8, 0x25, 0x82, 0x24, 0xa6,
+	0x9f, 0xa3, 0x35, 0xb0, 0x1d, 0xe0, 0xac, 0xe1, 0xdd, 0xd0, 0xdb, 0x9c, 0xf8, 0xef, 0x10, 0xe4,
+	0xbc, 0x71, 0xce, 0xee, 0x79, 0x3d, 0xda, 0xc3, 0x91, 0x39, 0x7b, 0xd8, 0x8d, 0x03, 0xd1, 0xa9,
+	0x71, 0xe0, 0x72, 0xb0, 0x1a, 0x33, 0x2e, 0xc4, 0x25, 0x32, 0xdf, 0x18, 0x5a, 0x83, 0xa1, 0xc5,
+	0x36, 0x58, 0x1a, 0x3b, 0x2d, 0x3a, 0xbb, 0x81, 0x6a, 0x58, 0x5d, 0xb5, 0xc7, 0x36, 0x58, 0x02,
+	0xbb, 0x4d, 0xf4, 0x16, 0xac, 0x7a, 0x1b, 0xa5, 0xab, 0x29, 0x03, 0x43, 0xef, 0x18, 0xc4, 0x34,
+	0x9d, 0x18, 0x86, 0x5c, 0x9a, 0xac, 0xed, 0x3b, 0x14, 0xf1, 0x1a, 0xac, 0x38, 0x5a, 0xdf, 0x51,
+	0xad, 0xa6, 0x97, 0x90, 0x9d, 0x83, 0x18, 0x33, 0x0d, 0xd5, 0x7c, 0x98, 0x0e, 0x6d, 0xb7, 0xc4,
+	0x3f, 0x86, 0x60, 0x35, 0x88, 0x7f, 0x65, 0x2a, 0x6a, 0xaa, 0x2f, 0x41, 0xdc, 0x20, 0xe6, 0xb0,
+	0x67, 0x99, 0xf9, 0x38, 0xdb, 0x49, 0xeb, 0x0b, 0x76, 0x12, 0xc5, 0x62, 0x97, 0x47, 0xfc, 0x0b,
+	0x07, 0x99, 0x00, 0xe9, 0x2c, 0xea, 0xd3, 0x3b, 0x98, 0x22, 0x33, 0x0e, 0xa6, 0x91, 0xbf, 0x46,
+	0xfd, 0xfe, 0x2a, 0xfe, 0x89, 0x83, 0xf4, 0x9d, 0x21, 0x31, 0x8e, 0xe7, 0x47, 0xb2, 0x7d, 0xe0,
+	0xd9, 0x11, 0xd0, 0xd4, 0x35, 0xb3, 0x6b, 0x5a, 0x44, 0x6b, 0x1e, 0x3b, 0xf3, 0xbf, 0x34, 0x6b,
+	0xfe, 0x6a, 0xab, 0x3c, 0x02, 0xe3, 0x9c, 0x11, 0xec, 0x40, 0x6f, 0x42, 0xce, 0xa4, 0x43, 0x6a,
+	0x4d, 0xa2, 0x68, 0x43, 0x76, 0x0d, 0xb5, 0xa3, 0x6c, 0xd6, 0xed, 0xae, 0xb2, 0x5e, 0x9a, 0xf2,
+	0xf5, 0xbb, 0x9a, 0xa2, 0x0e, 0x06, 0xbd, 0x2e, 0x69, 0x29, 0x33, 0x96, 0x99, 0xeb, 0x77, 0xb5,
+	0x92, 0x0d, 0x61, 0x1d, 0xe2, 0xcf, 0x43, 0x90, 0x71, 0x16, 0x76, 0x76, 0xb7, 0xc1, 0xc8, 0x2a,
+	0x91, 0x40, 0x14, 0x99, 0xa2, 0x9c, 0xe8, 0x54, 0xe5, 0xac, 0xd1, 0xe2, 0x80, 0xda, 0x52, 0x0c,
+	0x32, 0x50, 0xbb, 0x06, 0xcb, 0x0a, 0x12, 0xf4, 0xde, 0xaf, 0xb6, 0x30, 0xeb, 0x41, 0x1b, 0x90,
+	0xa0, 0xa7, 0x29, 0x51, 0x1e, 0x1c, 0xe7, 0xe3, 0xe3, 0x4a, 0x8b, 0x33, 0xd2, 0xce, 0xb1, 0xb8,
+	0x0c, 0x39, 0x37, 0xea, 0x38, 0x7e, 0x20, 0xfe, 0x80, 0x03, 0x7e, 0xd4, 0xe7, 0xa8, 0x70, 0x3c,
+	0xe1, 0xe7, 0xe6, 0x26, 0xfc, 0x45, 0xc8, 0x04, 0xad, 0x36, 0x59, 0x39, 0x50, 0x7d, 0x26, 0x43,
+	0x6f, 0x40, 0xb8, 0xa7, 0x76, 0x26, 0x73, 0x2b, 0xda, 0xbb, 0x75, 0x1b, 0x72, 0x63, 0x3e, 0x85,
+	0xb2, 0x00, 0x75, 0xe9, 0xce, 0x81, 0x54, 0x6d, 0xc8, 0xa5, 0x0a, 0xbf, 0x84, 0xce, 0x01, 0xaa,
+	0xc8, 0x55, 0xa9, 0x84, 0xe5, 0xfb, 0xa5, 0x9d, 0x8a, 0xa4, 0x54, 0xa4, 0x52, 0x5d, 0xe2, 0x39,
+	0xc4, 0x43, 0xda, 0xdf, 0xcf, 0x87, 0xb6, 0xd6, 0x21, 0x1b, 0x34, 0x33, 0x8a, 0x41, 0xa8, 0x76,
+	0x9b, 0x5f, 0x42, 0x49, 0x88, 0x4a, 0x18, 0xd7, 0x30, 0xcf, 0x6d, 0x7d, 0x27, 0x0c, 0x99, 0x80,
+	0x3d, 0x51, 0x06, 0x92, 0xd5, 0x1a, 0x15, 0xbb, 0x2b, 0x61, 0x7e, 0x09, 0x2d, 0x43, 0xe6, 0xce,
+	0x81, 0x84, 0xef, 0x29, 0x37, 0x4a, 0x72, 0xe5, 0x00, 0xd3, 0xa1, 0x56, 0x20, 0x57, 0xae, 0xed,
+	0xed, 0x95, 0xaa, 0xbb, 0x5e, 0x67, 0x08, 0xbd, 0x06, 0xcb, 0xa5, 0xfd, 0xfd, 0x8a, 0x5c, 0x2e,
+	0x35, 0xe4, 0x5a, 0x55, 0xb1, 0xe5, 0x87, 0x51, 0x1e, 0x56, 0xe5, 0x4a, 0x45, 0xba, 0x59, 0xaa,
+	0x28, 0x7b, 0xd2, 0xde, 0x8e, 0x84, 0x95, 0x7a, 0xa3, 0xd4, 0x90, 0xf8, 0x08, 0x42, 0x90, 0x3d,
+	0xa8, 0xde, 0xae, 0xd6, 0xbe, 0x5a, 0x55, 0xca, 0x15, 0x59, 0xaa, 0x36, 0xf8, 0x28, 0x95, 0xec,
+	0xf6, 0xd5, 0xa5, 0x7a, 0x5d, 0xae, 0x55, 0xf9, 0x58, 0xb0, 0x13, 0xdf, 0x95, 0xcb, 0x12, 0x1f,
+	0xa7, 0xdc, 0xe5, 0x4a, 0xad, 0x2e, 0xed, 0x7a, 0xc0, 0x04, 0xed, 0xdb, 0xc7, 0xb5, 0x46, 0xad,
+	0x5c, 0xab, 0x38, 0xe3, 0x27, 0xd1, 0xeb, 0xb0, 0x52, 0xae, 0x55, 0x6f, 0xc8, 0x37, 0x0f, 0xb0,
+	0x7f, 0x62, 0x80, 0x72, 0x90, 0x3a, 0xa8, 0x96, 0xee, 0x96, 0xe4, 0x0a, 0x53, 0x57, 0x8a, 0xae,
+	0x1b, 0x4b, 0xa5, 0x5d, 0xa5, 0x56, 0xad, 0xdc, 0xe3, 0xd3, 0xe8, 0xff, 0xe0, 0x7c, 0x90, 0x51,
+	0xae, 0x2a, 0xfb, 0xb8, 0x76, 0x13, 0x4b, 0xf5, 0x3a, 0x9f, 0xb1, 0xb5, 0xd4, 0x50, 0x28, 0xc7,
+	0x3d, 0x3e, 0x4b, 0xb5, 0x7f, 0x50, 0x2d, 0x1d, 0x34, 0x6e, 0xd5, 0xb0, 0x7c, 0x5f, 0xda, 0xe5,
+	0x73, 0xe8, 0x3c, 0xbc, 0x26, 0x57, 0xcb, 0xb5, 0xbd, 0xfd, 0x52, 0x43, 0xa6, 0x76, 0xaa, 0x57,
+	0x4b, 0xfb, 0xf5, 0x5b, 0xb5, 0x06, 0xcf, 0x53, 0x30, 0x2e, 0x35, 0x24, 0xa5, 0x22, 0xef, 0xc9,
+	0x0d, 0x69, 0x97, 0x5f, 0xbe, 0xfe, 0x9b, 0x34, 0xa4, 0xb0, 0xda, 0xb6, 0xea, 0xc4, 0x38, 0xea,
+	0x36, 0x09, 0xaa, 0x41, 0x84, 0xfe, 0x13, 0x80, 0xfe, 0x7f, 0xfa, 0x06, 0xf4, 0xfd, 0x93, 0x20,
+	0x88, 0xf3, 0x20, 0xb6, 0x5d, 0xc5, 0x25, 0x84, 0x21, 0xca, 0x1e, 0xa3, 0xd0, 0x0c, 0xb8, 0xff,
+	0xc1, 0x4b, 0x58, 0x9f, 0x8b, 0xf1, 0x64, 0xbe, 0x0f, 0x49, 0xef, 0xa5, 0x18, 0x5d, 0x9e, 0x75,
+	0xdc, 0x04, 0x5f, 0x6e, 0x85, 0x37, 0x17, 0xe2, 0x3c, 0xf9, 0x2d, 0x48, 0xf9, 0x9e, 0x34, 0xd1,
+	0xe6, 0xac, 0x60, 0x34, 0xfe, 0x3a, 0x2c, 0x5c, 0x39, 0x01, 0xd2, 0x3f, 0x8a, 0xef, 0xb5, 0x68,
+	0xd6, 0x28, 0x93, 0x8f, 0x50, 0xc2, 0x95, 0x13, 0x20, 0xbd, 0x51, 0x06, 0x90, 0x1b, 0x7b, 0x68,
+	0x41, 0x57, 0xa7, 0xf3, 0x4f, 0x7f, 0xeb, 0x11, 0xae, 0x9d, 0x10, 0xed, 0x8d, 0x58, 0x83, 0x08,
+	0x7d, 0x0d, 0x98, 0xe5, 0x42, 0xbe, 0x27, 0x0e, 0x41, 0x9c, 0x07, 0xf1, 0x0b, 0xa4, 0xf5, 0xe5,
+	0x59, 0x02, 0x7d, 0x05, 0x77, 0x41, 0x9c, 0x07, 0xf1, 0x04, 0x7e, 0x0d, 0x12, 0x6e, 0x09, 0x15,
+	0xcd, 0x38, 0x60, 0xc7, 0xaa, 0xb9, 0xc2, 0xe5, 0x45, 0x30, 0x4f, 0xf8, 0x11, 0x2c, 0x4f, 0x54,
+	0x2c, 0x51, 0x71, 0x8e, 0xf3, 0x4d, 0xa9, 0x9d, 0x0a, 0xdb, 0x27, 0xc6, 0xfb, 0xb5, 0x44, 0x2b,
+	0x7e, 0xb3, 0xb4, 0xe4, 0xab, 0x39, 0x0a, 0xe2, 0x3c, 0x88, 0x27, 0xf0, 0x00, 0x62, 0x76, 0x6d,
+	0x06, 0xcd, 0xd8, 0x96, 0x81, 0x52, 0x9c, 0xb0, 0x31, 0x1f, 0xe4, 0x89, 0xbd, 0x0f, 0x71, 0xe7,
+	0x0a, 0x8a, 0x66, 0xb0, 0x04, 0x2b, 0x0b, 0xc2, 0xa5, 0x05, 0x28, 0x57, 0xf2, 0x26, 0x47, 0x65,
+	0x3b, 0xb9, 0xe4, 0x2c, 0xd9, 0xc1, 0xfb, 0x9c, 0x70, 0x69, 0x01, 0xca, 0x95, 0xfd, 0x16, 0x87,
+	0x3a, 0x90, 0xf6, 0xa7, 0xff, 0xe8, 0xca, 0x5c, 0x56, 0xff, 0x95, 0x42, 0xd8, 0x3a, 0x09, 0xd4,
+	0x53, 0x50, 0x03, 0xa2, 0x2c, 0xb3, 0x9a, 0x15, 0x31, 0xfd, 0xf9, 0xa4, 0xb0, 0x3e, 0x17, 0xe3,
+	0x9b, 0xfe, 0xd7, 0x21, 0xe1, 0xe6, 0x1b, 0xb3, 0x7c, 0x7e, 0x2c, 0x47, 0x11, 0x2e, 0x2f, 0x82,
+	0x8d, 0xc4, 0xef, 0x6c, 0xfc, 0xe7, 0xef, 0x05, 0xee, 0x67, 0x4f, 0x0a, 0xdc, 0xaf, 0x9e, 0x14,
+	0xb8, 0x8f, 0x9e, 0x14, 0xb8, 0x47, 0x4f, 0x0a, 0xdc, 0xdf, 0x9e, 0x14, 0xb8, 0x0f, 0x9f, 0x16,
+	0x96, 0x1e, 0x3d, 0x2d, 0x2c, 0xfd, 0xf9, 0x69, 0x61, 0xe9, 0x41, 0x8c, 0x09, 0xf9, 0xf4, 0x7f,
+	0x07, 0x00, 0xab, 0x80, 0xad, 0x7a, 0xb4, 0x27, 0x00, 0x00,
+}
+
+func (this *JoinRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*JoinRequest)
 	if !ok {
-		that2, ok := that.(PollResponse)
+		that2, ok := that.(JoinRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2031,28 +2860,22 @@ func (this *PollResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Status != that1.Status {
-		return false
-	}
-	if this.Error != that1.Error {
-		return false
-	}
-	if this.Term != that1.Term {
+	if !this.Member.Equal(that1.Member) {
 		return false
 	}
-	if this.Accepted != that1.Accepted {
+	if this.Forwarded != that1.Forwarded {
 		return false
 	}
 	return true
 }
-func (this *VoteRequest) Equal(that interface{}) bool {
+func (this *JoinResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*VoteRequest)
+	that1, ok := that.(*JoinResponse)
 	if !ok {
-		that2, ok := that.(VoteRequest)
+		that2, ok := that.(JoinResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2064,28 +2887,45 @@ func (this *VoteRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if this.Status != that1.Status {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
 	if this.Term != that1.Term {
 		return false
 	}
-	if this.Candidate != that1.Candidate {
+	if !this.Timestamp.Equal(that1.Timestamp) {
 		return false
 	}
-	if this.LastLogIndex != that1.LastLogIndex {
+	if len(this.Members) != len(that1.Members) {
 		return false
 	}
-	if this.LastLogTerm != that1.LastLogTerm {
+	for i := range this.Members {
+		if !this.Members[i].Equal(that1.Members[i]) {
+			return false
+		}
+	}
+	if this.Leader != that1.Leader {
+		return false
+	}
+	if this.CommitIndex != that1.CommitIndex {
 		return false
 	}
 	return true
 }
-func (this *VoteResponse) Equal(that interface{}) bool {
+func (this *ConfigureRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*VoteResponse)
+	that1, ok := that.(*ConfigureRequest)
 	if !ok {
-		that2, ok := that.(VoteResponse)
+		that2, ok := that.(ConfigureRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2097,28 +2937,36 @@ func (this *VoteResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Status != that1.Status {
+	if this.Term != that1.Term {
 		return false
 	}
-	if this.Error != that1.Error {
+	if this.Leader != that1.Leader {
 		return false
 	}
-	if this.Term != that1.Term {
+	if this.Index != that1.Index {
 		return false
 	}
-	if this.Voted != that1.Voted {
+	if !this.Timestamp.Equal(that1.Timestamp) {
+		return false
+	}
+	if len(this.Members) != len(that1.Members) {
 		return false
 	}
+	for i := range this.Members {
+		if !this.Members[i].Equal(that1.Members[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *TransferRequest) Equal(that interface{}) bool {
+func (this *ConfigureResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TransferRequest)
+	that1, ok := that.(*ConfigureResponse)
 	if !ok {
-		that2, ok := that.(TransferRequest)
+		that2, ok := that.(ConfigureResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2130,19 +2978,22 @@ func (this *TransferRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Member != that1.Member {
+	if this.Status != that1.Status {
+		return false
+	}
+	if this.Error != that1.Error {
 		return false
 	}
 	return true
 }
-func (this *TransferResponse) Equal(that interface{}) bool {
+func (this *ReconfigureRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TransferResponse)
+	that1, ok := that.(*ReconfigureRequest)
 	if !ok {
-		that2, ok := that.(TransferResponse)
+		that2, ok := that.(ReconfigureRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2154,22 +3005,43 @@ func (this *TransferResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Status != that1.Status {
+	if !this.Member.Equal(that1.Member) {
 		return false
 	}
-	if this.Error != that1.Error {
+	if this.Index != that1.Index {
+		return false
+	}
+	if this.Term != that1.Term {
+		return false
+	}
+	if this.ElectionTimeout != nil && that1.ElectionTimeout != nil {
+		if *this.ElectionTimeout != *that1.ElectionTimeout {
+			return false
+		}
+	} else if this.ElectionTimeout != nil {
+		return false
+	} else if that1.ElectionTimeout != nil {
+		return false
+	}
+	if this.HeartbeatInterval != nil && that1.HeartbeatInterval != nil {
+		if *this.HeartbeatInterval != *that1.HeartbeatInterval {
+			return false
+		}
+	} else if this.HeartbeatInterval != nil {
+		return false
+	} else if that1.HeartbeatInterval != nil {
 		return false
 	}
 	return true
 }
-func (this *AppendRequest) Equal(that interface{}) bool {
+func (this *ReconfigureResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*AppendRequest)
+	that1, ok := that.(*ReconfigureResponse)
 	if !ok {
-		that2, ok := that.(AppendRequest)
+		that2, ok := that.(ReconfigureResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2181,39 +3053,63 @@ func (this *AppendRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Term != that1.Term {
+	if this.Status != that1.Status {
 		return false
 	}
-	if this.Leader != that1.Leader {
+	if this.Error != that1.Error {
 		return false
 	}
-	if this.PrevLogIndex != that1.PrevLogIndex {
+	if this.Index != that1.Index {
 		return false
 	}
-	if this.PrevLogTerm != that1.PrevLogTerm {
+	if this.Term != that1.Term {
 		return false
 	}
-	if len(this.Entries) != len(that1.Entries) {
+	if !this.Timestamp.Equal(that1.Timestamp) {
 		return false
 	}
-	for i := range this.Entries {
-		if !this.Entries[i].Equal(that1.Entries[i]) {
+	if len(this.Members) != len(that1.Members) {
+		return false
+	}
+	for i := range this.Members {
+		if !this.Members[i].Equal(that1.Members[i]) {
 			return false
 		}
 	}
-	if this.CommitIndex != that1.CommitIndex {
+	return true
+}
+func (this *LeaveRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LeaveRequest)
+	if !ok {
+		that2, ok := that.(LeaveRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Member.Equal(that1.Member) {
 		return false
 	}
 	return true
 }
-func (this *AppendResponse) Equal(that interface{}) bool {
+func (this *LeaveResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*AppendResponse)
+	that1, ok := that.(*LeaveResponse)
 	if !ok {
-		that2, ok := that.(AppendResponse)
+		that2, ok := that.(LeaveResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2231,25 +3127,33 @@ func (this *AppendResponse) Equal(that interface{}) bool {
 	if this.Error != that1.Error {
 		return false
 	}
+	if this.Index != that1.Index {
+		return false
+	}
 	if this.Term != that1.Term {
 		return false
 	}
-	if this.Succeeded != that1.Succeeded {
+	if !this.Timestamp.Equal(that1.Timestamp) {
 		return false
 	}
-	if this.LastLogIndex != that1.LastLogIndex {
+	if len(this.Members) != len(that1.Members) {
 		return false
 	}
+	for i := range this.Members {
+		if !this.Members[i].Equal(that1.Members[i]) {
+			return false
+		}
+	}
 	return true
 }
-func (this *InstallRequest) Equal(that interface{}) bool {
+func (this *SetReadOnlyRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*InstallRequest)
+	that1, ok := that.(*SetReadOnlyRequest)
 	if !ok {
-		that2, ok := that.(InstallRequest)
+		that2, ok := that.(SetReadOnlyRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2261,31 +3165,76 @@ func (this *InstallRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Term != that1.Term {
+	if this.ReadOnly != that1.ReadOnly {
 		return false
 	}
-	if this.Leader != that1.Leader {
+	return true
+}
+func (this *SetReadOnlyResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SetReadOnlyResponse)
+	if !ok {
+		that2, ok := that.(SetReadOnlyResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Status != that1.Status {
+		return false
+	}
+	if this.Error != that1.Error {
 		return false
 	}
 	if this.Index != that1.Index {
 		return false
 	}
-	if !this.Timestamp.Equal(that1.Timestamp) {
+	if this.Term != that1.Term {
 		return false
 	}
-	if !bytes.Equal(this.Data, that1.Data) {
+	return true
+}
+func (this *FreezeElectionsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*FreezeElectionsRequest)
+	if !ok {
+		that2, ok := that.(FreezeElectionsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Duration != that1.Duration {
 		return false
 	}
 	return true
 }
-func (this *InstallResponse) Equal(that interface{}) bool {
+func (this *FreezeElectionsResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*InstallResponse)
+	that1, ok := that.(*FreezeElectionsResponse)
 	if !ok {
-		that2, ok := that.(InstallResponse)
+		that2, ok := that.(FreezeElectionsResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2303,16 +3252,25 @@ func (this *InstallResponse) Equal(that interface{}) bool {
 	if this.Error != that1.Error {
 		return false
 	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if this.Term != that1.Term {
+		return false
+	}
+	if !this.Expires.Equal(that1.Expires) {
+		return false
+	}
 	return true
 }
-func (this *CommandRequest) Equal(that interface{}) bool {
+func (this *PollRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*CommandRequest)
+	that1, ok := that.(*PollRequest)
 	if !ok {
-		that2, ok := that.(CommandRequest)
+		that2, ok := that.(PollRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2324,19 +3282,28 @@ func (this *CommandRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.Value, that1.Value) {
+	if this.Term != that1.Term {
+		return false
+	}
+	if this.Candidate != that1.Candidate {
+		return false
+	}
+	if this.LastLogIndex != that1.LastLogIndex {
+		return false
+	}
+	if this.LastLogTerm != that1.LastLogTerm {
 		return false
 	}
 	return true
 }
-func (this *CommandResponse) Equal(that interface{}) bool {
+func (this *PollResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*CommandResponse)
+	that1, ok := that.(*PollResponse)
 	if !ok {
-		that2, ok := that.(CommandResponse)
+		that2, ok := that.(PollResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2354,36 +3321,25 @@ func (this *CommandResponse) Equal(that interface{}) bool {
 	if this.Error != that1.Error {
 		return false
 	}
-	if this.Message != that1.Message {
-		return false
-	}
-	if this.Leader != that1.Leader {
-		return false
-	}
 	if this.Term != that1.Term {
 		return false
 	}
-	if len(this.Members) != len(that1.Members) {
+	if this.Accepted != that1.Accepted {
 		return false
 	}
-	for i := range this.Members {
-		if this.Members[i] != that1.Members[i] {
-			return false
-		}
-	}
-	if !bytes.Equal(this.Output, that1.Output) {
+	if this.RetryAfter != that1.RetryAfter {
 		return false
 	}
 	return true
 }
-func (this *QueryRequest) Equal(that interface{}) bool {
+func (this *VoteRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*QueryRequest)
+	that1, ok := that.(*VoteRequest)
 	if !ok {
-		that2, ok := that.(QueryRequest)
+		that2, ok := that.(VoteRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2395,22 +3351,31 @@ func (this *QueryRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.Value, that1.Value) {
+	if this.Term != that1.Term {
 		return false
 	}
-	if this.ReadConsistency != that1.ReadConsistency {
+	if this.Candidate != that1.Candidate {
+		return false
+	}
+	if this.LastLogIndex != that1.LastLogIndex {
+		return false
+	}
+	if this.LastLogTerm != that1.LastLogTerm {
+		return false
+	}
+	if this.ConfigurationIndex != that1.ConfigurationIndex {
 		return false
 	}
 	return true
 }
-func (this *QueryResponse) Equal(that interface{}) bool {
+func (this *VoteResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*QueryResponse)
+	that1, ok := that.(*VoteResponse)
 	if !ok {
-		that2, ok := that.(QueryResponse)
+		that2, ok := that.(VoteResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2428,2444 +3393,7119 @@ func (this *QueryResponse) Equal(that interface{}) bool {
 	if this.Error != that1.Error {
 		return false
 	}
-	if this.Message != that1.Message {
+	if this.Term != that1.Term {
 		return false
 	}
-	if !bytes.Equal(this.Output, that1.Output) {
+	if this.Voted != that1.Voted {
+		return false
+	}
+	if this.RetryAfter != that1.RetryAfter {
 		return false
 	}
 	return true
 }
+func (this *TransferRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+	that1, ok := that.(*TransferRequest)
+	if !ok {
+		that2, ok := that.(TransferRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Member != that1.Member {
+		return false
+	}
+	if this.LastLogIndex != that1.LastLogIndex {
+		return false
+	}
+	return true
+}
+func (this *TransferResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-// RaftServiceClient is the client API for RaftService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type RaftServiceClient interface {
-	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
-	Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error)
-	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error)
-	Reconfigure(ctx context.Context, in *ReconfigureRequest, opts ...grpc.CallOption) (*ReconfigureResponse, error)
-	Poll(ctx context.Context, in *PollRequest, opts ...grpc.CallOption) (*PollResponse, error)
-	Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error)
-	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
-	Append(ctx context.Context, in *AppendRequest, opts ...grpc.CallOption) (*AppendResponse, error)
-	Install(ctx context.Context, opts ...grpc.CallOption) (RaftService_InstallClient, error)
-	Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (RaftService_CommandClient, error)
-	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (RaftService_QueryClient, error)
-}
-
-type raftServiceClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewRaftServiceClient(cc *grpc.ClientConn) RaftServiceClient {
-	return &raftServiceClient{cc}
-}
-
-func (c *raftServiceClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
-	out := new(JoinResponse)
-	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Join", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*TransferResponse)
+	if !ok {
+		that2, ok := that.(TransferResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *raftServiceClient) Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error) {
-	out := new(LeaveResponse)
-	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Leave", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return out, nil
-}
-
-func (c *raftServiceClient) Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error) {
-	out := new(ConfigureResponse)
-	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Configure", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.Status != that1.Status {
+		return false
 	}
-	return out, nil
-}
-
-func (c *raftServiceClient) Reconfigure(ctx context.Context, in *ReconfigureRequest, opts ...grpc.CallOption) (*ReconfigureResponse, error) {
-	out := new(ReconfigureResponse)
-	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Reconfigure", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.Error != that1.Error {
+		return false
 	}
-	return out, nil
+	return true
 }
-
-func (c *raftServiceClient) Poll(ctx context.Context, in *PollRequest, opts ...grpc.CallOption) (*PollResponse, error) {
-	out := new(PollResponse)
-	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Poll", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *ConfirmLeadershipRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return out, nil
-}
 
-func (c *raftServiceClient) Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
-	out := new(VoteResponse)
-	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Vote", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*ConfirmLeadershipRequest)
+	if !ok {
+		that2, ok := that.(ConfirmLeadershipRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *raftServiceClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
-	out := new(TransferResponse)
-	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Transfer", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return out, nil
-}
-
-func (c *raftServiceClient) Append(ctx context.Context, in *AppendRequest, opts ...grpc.CallOption) (*AppendResponse, error) {
-	out := new(AppendResponse)
-	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Append", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.Term != that1.Term {
+		return false
 	}
-	return out, nil
-}
-
-func (c *raftServiceClient) Install(ctx context.Context, opts ...grpc.CallOption) (RaftService_InstallClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_RaftService_serviceDesc.Streams[0], "/atomix.raft.protocol.RaftService/Install", opts...)
-	if err != nil {
-		return nil, err
+	if this.Leader != that1.Leader {
+		return false
 	}
-	x := &raftServiceInstallClient{stream}
-	return x, nil
-}
-
-type RaftService_InstallClient interface {
-	Send(*InstallRequest) error
-	CloseAndRecv() (*InstallResponse, error)
-	grpc.ClientStream
-}
-
-type raftServiceInstallClient struct {
-	grpc.ClientStream
-}
-
-func (x *raftServiceInstallClient) Send(m *InstallRequest) error {
-	return x.ClientStream.SendMsg(m)
+	return true
 }
+func (this *ConfirmLeadershipResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (x *raftServiceInstallClient) CloseAndRecv() (*InstallResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	that1, ok := that.(*ConfirmLeadershipResponse)
+	if !ok {
+		that2, ok := that.(ConfirmLeadershipResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	m := new(InstallResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return m, nil
-}
-
-func (c *raftServiceClient) Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (RaftService_CommandClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_RaftService_serviceDesc.Streams[1], "/atomix.raft.protocol.RaftService/Command", opts...)
-	if err != nil {
-		return nil, err
+	if this.Status != that1.Status {
+		return false
 	}
-	x := &raftServiceCommandClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if this.Error != that1.Error {
+		return false
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	if this.Term != that1.Term {
+		return false
 	}
-	return x, nil
-}
-
-type RaftService_CommandClient interface {
-	Recv() (*CommandResponse, error)
-	grpc.ClientStream
-}
-
-type raftServiceCommandClient struct {
-	grpc.ClientStream
-}
-
-func (x *raftServiceCommandClient) Recv() (*CommandResponse, error) {
-	m := new(CommandResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	if this.Confirmed != that1.Confirmed {
+		return false
 	}
-	return m, nil
+	return true
 }
+func (this *HashRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (c *raftServiceClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (RaftService_QueryClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_RaftService_serviceDesc.Streams[2], "/atomix.raft.protocol.RaftService/Query", opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*HashRequest)
+	if !ok {
+		that2, ok := that.(HashRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	x := &raftServiceQueryClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	if this.FirstIndex != that1.FirstIndex {
+		return false
 	}
-	return x, nil
+	if this.LastIndex != that1.LastIndex {
+		return false
+	}
+	return true
 }
+func (this *HashResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-type RaftService_QueryClient interface {
-	Recv() (*QueryResponse, error)
-	grpc.ClientStream
+	that1, ok := that.(*HashResponse)
+	if !ok {
+		that2, ok := that.(HashResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Status != that1.Status {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	if this.Message != that1.Message {
+		return false
+	}
+	if this.Term != that1.Term {
+		return false
+	}
+	if this.Hash != that1.Hash {
+		return false
+	}
+	return true
 }
+func (this *AppendRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-type raftServiceQueryClient struct {
-	grpc.ClientStream
-}
-
-func (x *raftServiceQueryClient) Recv() (*QueryResponse, error) {
-	m := new(QueryResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
-
-// RaftServiceServer is the server API for RaftService service.
-type RaftServiceServer interface {
-	Join(context.Context, *JoinRequest) (*JoinResponse, error)
-	Leave(context.Context, *LeaveRequest) (*LeaveResponse, error)
-	Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error)
-	Reconfigure(context.Context, *ReconfigureRequest) (*ReconfigureResponse, error)
-	Poll(context.Context, *PollRequest) (*PollResponse, error)
-	Vote(context.Context, *VoteRequest) (*VoteResponse, error)
-	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
-	Append(context.Context, *AppendRequest) (*AppendResponse, error)
-	Install(RaftService_InstallServer) error
-	Command(*CommandRequest, RaftService_CommandServer) error
-	Query(*QueryRequest, RaftService_QueryServer) error
-}
-
-// UnimplementedRaftServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedRaftServiceServer struct {
-}
-
-func (*UnimplementedRaftServiceServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
-}
-func (*UnimplementedRaftServiceServer) Leave(ctx context.Context, req *LeaveRequest) (*LeaveResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
-}
-func (*UnimplementedRaftServiceServer) Configure(ctx context.Context, req *ConfigureRequest) (*ConfigureResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
-}
-func (*UnimplementedRaftServiceServer) Reconfigure(ctx context.Context, req *ReconfigureRequest) (*ReconfigureResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Reconfigure not implemented")
-}
-func (*UnimplementedRaftServiceServer) Poll(ctx context.Context, req *PollRequest) (*PollResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Poll not implemented")
-}
-func (*UnimplementedRaftServiceServer) Vote(ctx context.Context, req *VoteRequest) (*VoteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Vote not implemented")
-}
-func (*UnimplementedRaftServiceServer) Transfer(ctx context.Context, req *TransferRequest) (*TransferResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
-}
-func (*UnimplementedRaftServiceServer) Append(ctx context.Context, req *AppendRequest) (*AppendResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Append not implemented")
-}
-func (*UnimplementedRaftServiceServer) Install(srv RaftService_InstallServer) error {
-	return status.Errorf(codes.Unimplemented, "method Install not implemented")
-}
-func (*UnimplementedRaftServiceServer) Command(req *CommandRequest, srv RaftService_CommandServer) error {
-	return status.Errorf(codes.Unimplemented, "method Command not implemented")
-}
-func (*UnimplementedRaftServiceServer) Query(req *QueryRequest, srv RaftService_QueryServer) error {
-	return status.Errorf(codes.Unimplemented, "method Query not implemented")
-}
-
-func RegisterRaftServiceServer(s *grpc.Server, srv RaftServiceServer) {
-	s.RegisterService(&_RaftService_serviceDesc, srv)
-}
-
-func _RaftService_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JoinRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*AppendRequest)
+	if !ok {
+		that2, ok := that.(AppendRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(RaftServiceServer).Join(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.raft.protocol.RaftService/Join",
+	if this.Term != that1.Term {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftServiceServer).Join(ctx, req.(*JoinRequest))
+	if this.Leader != that1.Leader {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _RaftService_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LeaveRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if this.PrevLogIndex != that1.PrevLogIndex {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(RaftServiceServer).Leave(ctx, in)
+	if this.PrevLogTerm != that1.PrevLogTerm {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.raft.protocol.RaftService/Leave",
+	if len(this.Entries) != len(that1.Entries) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftServiceServer).Leave(ctx, req.(*LeaveRequest))
+	for i := range this.Entries {
+		if !this.Entries[i].Equal(that1.Entries[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _RaftService_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConfigureRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if this.CommitIndex != that1.CommitIndex {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(RaftServiceServer).Configure(ctx, in)
+	if !this.Timestamp.Equal(that1.Timestamp) {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.raft.protocol.RaftService/Configure",
+	if this.LeaderLastIndex != that1.LeaderLastIndex {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftServiceServer).Configure(ctx, req.(*ConfigureRequest))
+	if this.LeaderFirstIndex != that1.LeaderFirstIndex {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
-
-func _RaftService_Reconfigure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReconfigureRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *AppendResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if interceptor == nil {
-		return srv.(RaftServiceServer).Reconfigure(ctx, in)
+
+	that1, ok := that.(*AppendResponse)
+	if !ok {
+		that2, ok := that.(AppendResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.raft.protocol.RaftService/Reconfigure",
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftServiceServer).Reconfigure(ctx, req.(*ReconfigureRequest))
+	if this.Status != that1.Status {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _RaftService_Poll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PollRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if this.Error != that1.Error {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(RaftServiceServer).Poll(ctx, in)
+	if this.Term != that1.Term {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.raft.protocol.RaftService/Poll",
+	if this.Succeeded != that1.Succeeded {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftServiceServer).Poll(ctx, req.(*PollRequest))
+	if this.LastLogIndex != that1.LastLogIndex {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _RaftService_Vote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VoteRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if this.Truncated != that1.Truncated {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(RaftServiceServer).Vote(ctx, in)
+	if this.LastLogTerm != that1.LastLogTerm {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.raft.protocol.RaftService/Vote",
+	if !this.Timestamp.Equal(that1.Timestamp) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftServiceServer).Vote(ctx, req.(*VoteRequest))
+	if this.SnapshotRequested != that1.SnapshotRequested {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *InstallRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _RaftService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransferRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*InstallRequest)
+	if !ok {
+		that2, ok := that.(InstallRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(RaftServiceServer).Transfer(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.raft.protocol.RaftService/Transfer",
+	if this.Term != that1.Term {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftServiceServer).Transfer(ctx, req.(*TransferRequest))
+	if this.Leader != that1.Leader {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _RaftService_Append_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AppendRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if this.Index != that1.Index {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(RaftServiceServer).Append(ctx, in)
+	if !this.Timestamp.Equal(that1.Timestamp) {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.raft.protocol.RaftService/Append",
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftServiceServer).Append(ctx, req.(*AppendRequest))
+	if this.SnapshotTerm != that1.SnapshotTerm {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _RaftService_Install_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(RaftServiceServer).Install(&raftServiceInstallServer{stream})
-}
-
-type RaftService_InstallServer interface {
-	SendAndClose(*InstallResponse) error
-	Recv() (*InstallRequest, error)
-	grpc.ServerStream
-}
-
-type raftServiceInstallServer struct {
-	grpc.ServerStream
-}
-
-func (x *raftServiceInstallServer) SendAndClose(m *InstallResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func (x *raftServiceInstallServer) Recv() (*InstallRequest, error) {
-	m := new(InstallRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+	if this.Version != that1.Version {
+		return false
 	}
-	return m, nil
-}
-
-func _RaftService_Command_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(CommandRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	if this.Offset != that1.Offset {
+		return false
 	}
-	return srv.(RaftServiceServer).Command(m, &raftServiceCommandServer{stream})
-}
-
-type RaftService_CommandServer interface {
-	Send(*CommandResponse) error
-	grpc.ServerStream
-}
-
-type raftServiceCommandServer struct {
-	grpc.ServerStream
-}
-
-func (x *raftServiceCommandServer) Send(m *CommandResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _RaftService_Query_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(QueryRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	if this.Checksum != that1.Checksum {
+		return false
 	}
-	return srv.(RaftServiceServer).Query(m, &raftServiceQueryServer{stream})
-}
-
-type RaftService_QueryServer interface {
-	Send(*QueryResponse) error
-	grpc.ServerStream
-}
-
-type raftServiceQueryServer struct {
-	grpc.ServerStream
-}
-
-func (x *raftServiceQueryServer) Send(m *QueryResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-var _RaftService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "atomix.raft.protocol.RaftService",
-	HandlerType: (*RaftServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Join",
-			Handler:    _RaftService_Join_Handler,
-		},
-		{
-			MethodName: "Leave",
-			Handler:    _RaftService_Leave_Handler,
-		},
-		{
-			MethodName: "Configure",
-			Handler:    _RaftService_Configure_Handler,
-		},
-		{
-			MethodName: "Reconfigure",
-			Handler:    _RaftService_Reconfigure_Handler,
-		},
-		{
-			MethodName: "Poll",
-			Handler:    _RaftService_Poll_Handler,
-		},
-		{
-			MethodName: "Vote",
-			Handler:    _RaftService_Vote_Handler,
-		},
-		{
-			MethodName: "Transfer",
-			Handler:    _RaftService_Transfer_Handler,
-		},
-		{
-			MethodName: "Append",
-			Handler:    _RaftService_Append_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "Install",
-			Handler:       _RaftService_Install_Handler,
-			ClientStreams: true,
-		},
-		{
-			StreamName:    "Command",
-			Handler:       _RaftService_Command_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "Query",
-			Handler:       _RaftService_Query_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "atomix/raft/protocol/protocol.proto",
-}
-
-func (m *JoinRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.ReadOnly != that1.ReadOnly {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *JoinRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *JoinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Member != nil {
-		{
-			size, err := m.Member.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	if this.ElectionFreeze != that1.ElectionFreeze {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *JoinResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *InstallResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *JoinResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *JoinResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Members) > 0 {
-		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintProtocol(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x32
+	that1, ok := that.(*InstallResponse)
+	if !ok {
+		that2, ok := that.(InstallResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err2 != nil {
-		return 0, err2
-	}
-	i -= n2
-	i = encodeVarintProtocol(dAtA, i, uint64(n2))
-	i--
-	dAtA[i] = 0x2a
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x20
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x18
+	if this.Status != that1.Status {
+		return false
 	}
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
+	if this.Error != that1.Error {
+		return false
 	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+	if this.Offset != that1.Offset {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
+func (this *CommandRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ConfigureRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*CommandRequest)
+	if !ok {
+		that2, ok := that.(CommandRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ConfigureRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Value, that1.Value) {
+		return false
+	}
+	if this.Timeout != nil && that1.Timeout != nil {
+		if *this.Timeout != *that1.Timeout {
+			return false
+		}
+	} else if this.Timeout != nil {
+		return false
+	} else if that1.Timeout != nil {
+		return false
+	}
+	if len(this.Metadata) != len(that1.Metadata) {
+		return false
+	}
+	for i := range this.Metadata {
+		if this.Metadata[i] != that1.Metadata[i] {
+			return false
+		}
+	}
+	return true
 }
+func (this *CommandResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ConfigureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Members) > 0 {
-		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintProtocol(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x2a
+	that1, ok := that.(*CommandResponse)
+	if !ok {
+		that2, ok := that.(CommandResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err3 != nil {
-		return 0, err3
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	i -= n3
-	i = encodeVarintProtocol(dAtA, i, uint64(n3))
-	i--
-	dAtA[i] = 0x22
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x18
+	if this.Status != that1.Status {
+		return false
 	}
-	if len(m.Leader) > 0 {
-		i -= len(m.Leader)
-		copy(dAtA[i:], m.Leader)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
-		i--
-		dAtA[i] = 0x12
+	if this.Error != that1.Error {
+		return false
 	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x8
+	if this.Message != that1.Message {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ConfigureResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Leader != that1.Leader {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ConfigureResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ConfigureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
+	if this.Term != that1.Term {
+		return false
 	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+	if len(this.Members) != len(that1.Members) {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ReconfigureRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	for i := range this.Members {
+		if this.Members[i] != that1.Members[i] {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ReconfigureRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if !bytes.Equal(this.Output, that1.Output) {
+		return false
+	}
+	if this.Partial != that1.Partial {
+		return false
+	}
+	if this.ElectionInProgress != that1.ElectionInProgress {
+		return false
+	}
+	return true
 }
+func (this *CommandBatchRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ReconfigureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x18
+	that1, ok := that.(*CommandBatchRequest)
+	if !ok {
+		that2, ok := that.(CommandBatchRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Member != nil {
-		{
-			size, err := m.Member.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+	if len(this.Values) != len(that1.Values) {
+		return false
+	}
+	for i := range this.Values {
+		if !bytes.Equal(this.Values[i], that1.Values[i]) {
+			return false
 		}
-		i--
-		dAtA[i] = 0xa
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *ReconfigureResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *CommandBatchResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ReconfigureResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *ReconfigureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Members) > 0 {
-		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintProtocol(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x32
+	that1, ok := that.(*CommandBatchResponse)
+	if !ok {
+		that2, ok := that.(CommandBatchResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err5 != nil {
-		return 0, err5
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	i -= n5
-	i = encodeVarintProtocol(dAtA, i, uint64(n5))
-	i--
-	dAtA[i] = 0x2a
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x20
+	if this.Status != that1.Status {
+		return false
 	}
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x18
+	if this.Error != that1.Error {
+		return false
 	}
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
+	if this.Message != that1.Message {
+		return false
 	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+	if this.Leader != that1.Leader {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *LeaveRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Term != that1.Term {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LeaveRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *LeaveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Member != nil {
-		{
-			size, err := m.Member.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+	if len(this.Members) != len(that1.Members) {
+		return false
+	}
+	for i := range this.Members {
+		if this.Members[i] != that1.Members[i] {
+			return false
 		}
-		i--
-		dAtA[i] = 0xa
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *LeaveResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if len(this.Results) != len(that1.Results) {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LeaveResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	for i := range this.Results {
+		if !this.Results[i].Equal(that1.Results[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *CommandResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *LeaveResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Members) > 0 {
-		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintProtocol(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x32
+	that1, ok := that.(*CommandResult)
+	if !ok {
+		that2, ok := that.(CommandResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err7 != nil {
-		return 0, err7
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	i -= n7
-	i = encodeVarintProtocol(dAtA, i, uint64(n7))
-	i--
-	dAtA[i] = 0x2a
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x20
+	if this.Status != that1.Status {
+		return false
 	}
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x18
+	if this.Error != that1.Error {
+		return false
 	}
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
+	if this.Message != that1.Message {
+		return false
 	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+	if this.Index != that1.Index {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *PollRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.Output, that1.Output) {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PollRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return true
 }
+func (this *QueryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *PollRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.LastLogTerm != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogTerm))
-		i--
-		dAtA[i] = 0x20
+	that1, ok := that.(*QueryRequest)
+	if !ok {
+		that2, ok := that.(QueryRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.LastLogIndex != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogIndex))
-		i--
-		dAtA[i] = 0x18
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Candidate) > 0 {
-		i -= len(m.Candidate)
-		copy(dAtA[i:], m.Candidate)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Candidate)))
-		i--
-		dAtA[i] = 0x12
+	if !bytes.Equal(this.Value, that1.Value) {
+		return false
 	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x8
+	if this.ReadConsistency != that1.ReadConsistency {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *PollResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.SequenceNumber != that1.SequenceNumber {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PollResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if this.MinAppliedIndex != that1.MinAppliedIndex {
+		return false
+	}
+	return true
 }
+func (this *QueryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *PollResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Accepted {
-		i--
-		if m.Accepted {
-			dAtA[i] = 1
+	that1, ok := that.(*QueryResponse)
+	if !ok {
+		that2, ok := that.(QueryResponse)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i--
-		dAtA[i] = 0x20
 	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x18
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
+	if this.Status != that1.Status {
+		return false
 	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+	if this.Error != that1.Error {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *VoteRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Message != that1.Message {
+		return false
 	}
-	return dAtA[:n], nil
+	if !bytes.Equal(this.Output, that1.Output) {
+		return false
+	}
+	if this.SequenceNumber != that1.SequenceNumber {
+		return false
+	}
+	if this.ReadRepair != that1.ReadRepair {
+		return false
+	}
+	if this.StaleBy != that1.StaleBy {
+		return false
+	}
+	return true
 }
+func (this *ProgressRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *VoteRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	that1, ok := that.(*ProgressRequest)
+	if !ok {
+		that2, ok := that.(ProgressRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
 }
+func (this *ProgressResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *VoteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.LastLogTerm != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogTerm))
-		i--
-		dAtA[i] = 0x20
+	that1, ok := that.(*ProgressResponse)
+	if !ok {
+		that2, ok := that.(ProgressResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.LastLogIndex != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogIndex))
-		i--
-		dAtA[i] = 0x18
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Candidate) > 0 {
-		i -= len(m.Candidate)
-		copy(dAtA[i:], m.Candidate)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Candidate)))
-		i--
-		dAtA[i] = 0x12
+	if this.CommitIndex != that1.CommitIndex {
+		return false
 	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x8
+	if this.AppliedIndex != that1.AppliedIndex {
+		return false
 	}
-	return len(dAtA) - i, nil
+	if this.Lag != that1.Lag {
+		return false
+	}
+	return true
 }
 
-func (m *VoteResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RaftServiceClient is the client API for RaftService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RaftServiceClient interface {
+	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+	Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error)
+	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error)
+	Reconfigure(ctx context.Context, in *ReconfigureRequest, opts ...grpc.CallOption) (*ReconfigureResponse, error)
+	SetReadOnly(ctx context.Context, in *SetReadOnlyRequest, opts ...grpc.CallOption) (*SetReadOnlyResponse, error)
+	FreezeElections(ctx context.Context, in *FreezeElectionsRequest, opts ...grpc.CallOption) (*FreezeElectionsResponse, error)
+	Poll(ctx context.Context, in *PollRequest, opts ...grpc.CallOption) (*PollResponse, error)
+	Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	ConfirmLeadership(ctx context.Context, in *ConfirmLeadershipRequest, opts ...grpc.CallOption) (*ConfirmLeadershipResponse, error)
+	Hash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*HashResponse, error)
+	Append(ctx context.Context, in *AppendRequest, opts ...grpc.CallOption) (*AppendResponse, error)
+	Install(ctx context.Context, opts ...grpc.CallOption) (RaftService_InstallClient, error)
+	Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (RaftService_CommandClient, error)
+	CommandBatch(ctx context.Context, in *CommandBatchRequest, opts ...grpc.CallOption) (*CommandBatchResponse, error)
+	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (RaftService_QueryClient, error)
+	Progress(ctx context.Context, in *ProgressRequest, opts ...grpc.CallOption) (RaftService_ProgressClient, error)
+}
+
+type raftServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRaftServiceClient(cc *grpc.ClientConn) RaftServiceClient {
+	return &raftServiceClient{cc}
+}
+
+func (c *raftServiceClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
+	out := new(JoinResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Join", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *VoteResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error) {
+	out := new(LeaveResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *VoteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Voted {
-		i--
-		if m.Voted {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+func (c *raftServiceClient) Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error) {
+	out := new(ConfigureResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *TransferRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) Reconfigure(ctx context.Context, in *ReconfigureRequest, opts ...grpc.CallOption) (*ReconfigureResponse, error) {
+	out := new(ReconfigureResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Reconfigure", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *TransferRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) SetReadOnly(ctx context.Context, in *SetReadOnlyRequest, opts ...grpc.CallOption) (*SetReadOnlyResponse, error) {
+	out := new(SetReadOnlyResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/SetReadOnly", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *TransferRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Member) > 0 {
-		i -= len(m.Member)
-		copy(dAtA[i:], m.Member)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Member)))
-		i--
-		dAtA[i] = 0xa
+func (c *raftServiceClient) FreezeElections(ctx context.Context, in *FreezeElectionsRequest, opts ...grpc.CallOption) (*FreezeElectionsResponse, error) {
+	out := new(FreezeElectionsResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/FreezeElections", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *TransferResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) Poll(ctx context.Context, in *PollRequest, opts ...grpc.CallOption) (*PollResponse, error) {
+	out := new(PollResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Poll", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *TransferResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Vote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *TransferResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+func (c *raftServiceClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Transfer", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *AppendRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) ConfirmLeadership(ctx context.Context, in *ConfirmLeadershipRequest, opts ...grpc.CallOption) (*ConfirmLeadershipResponse, error) {
+	out := new(ConfirmLeadershipResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/ConfirmLeadership", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *AppendRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) Hash(ctx context.Context, in *HashRequest, opts ...grpc.CallOption) (*HashResponse, error) {
+	out := new(HashResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Hash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *AppendRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.CommitIndex != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.CommitIndex))
-		i--
-		dAtA[i] = 0x30
-	}
-	if len(m.Entries) > 0 {
-		for iNdEx := len(m.Entries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Entries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintProtocol(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x2a
-		}
-	}
-	if m.PrevLogTerm != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.PrevLogTerm))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.PrevLogIndex != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.PrevLogIndex))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Leader) > 0 {
-		i -= len(m.Leader)
-		copy(dAtA[i:], m.Leader)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x8
+func (c *raftServiceClient) Append(ctx context.Context, in *AppendRequest, opts ...grpc.CallOption) (*AppendResponse, error) {
+	out := new(AppendResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/Append", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *AppendResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) Install(ctx context.Context, opts ...grpc.CallOption) (RaftService_InstallClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RaftService_serviceDesc.Streams[0], "/atomix.raft.protocol.RaftService/Install", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &raftServiceInstallClient{stream}
+	return x, nil
 }
 
-func (m *AppendResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RaftService_InstallClient interface {
+	Send(*InstallRequest) error
+	CloseAndRecv() (*InstallResponse, error)
+	grpc.ClientStream
 }
 
-func (m *AppendResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.LastLogIndex != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogIndex))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.Succeeded {
-		i--
-		if m.Succeeded {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
+type raftServiceInstallClient struct {
+	grpc.ClientStream
+}
+
+func (x *raftServiceInstallClient) Send(m *InstallRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *raftServiceInstallClient) CloseAndRecv() (*InstallResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+	m := new(InstallResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return m, nil
 }
 
-func (m *InstallRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (RaftService_CommandClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RaftService_serviceDesc.Streams[1], "/atomix.raft.protocol.RaftService/Command", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &raftServiceCommandClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *InstallRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RaftService_CommandClient interface {
+	Recv() (*CommandResponse, error)
+	grpc.ClientStream
 }
 
-func (m *InstallRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err8 != nil {
-		return 0, err8
-	}
-	i -= n8
-	i = encodeVarintProtocol(dAtA, i, uint64(n8))
-	i--
-	dAtA[i] = 0x22
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Leader) > 0 {
-		i -= len(m.Leader)
-		copy(dAtA[i:], m.Leader)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
-		i--
-		dAtA[i] = 0x12
+type raftServiceCommandClient struct {
+	grpc.ClientStream
+}
+
+func (x *raftServiceCommandClient) Recv() (*CommandResponse, error) {
+	m := new(CommandResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x8
+	return m, nil
+}
+
+func (c *raftServiceClient) CommandBatch(ctx context.Context, in *CommandBatchRequest, opts ...grpc.CallOption) (*CommandBatchResponse, error) {
+	out := new(CommandBatchResponse)
+	err := c.cc.Invoke(ctx, "/atomix.raft.protocol.RaftService/CommandBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *InstallResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (RaftService_QueryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RaftService_serviceDesc.Streams[2], "/atomix.raft.protocol.RaftService/Query", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &raftServiceQueryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *InstallResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RaftService_QueryClient interface {
+	Recv() (*QueryResponse, error)
+	grpc.ClientStream
 }
 
-func (m *InstallResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+type raftServiceQueryClient struct {
+	grpc.ClientStream
+}
+
+func (x *raftServiceQueryClient) Recv() (*QueryResponse, error) {
+	m := new(QueryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return m, nil
 }
 
-func (m *CommandRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *raftServiceClient) Progress(ctx context.Context, in *ProgressRequest, opts ...grpc.CallOption) (RaftService_ProgressClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RaftService_serviceDesc.Streams[3], "/atomix.raft.protocol.RaftService/Progress", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &raftServiceProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *CommandRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RaftService_ProgressClient interface {
+	Recv() (*ProgressResponse, error)
+	grpc.ClientStream
 }
 
-func (m *CommandRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+type raftServiceProgressClient struct {
+	grpc.ClientStream
 }
 
-func (m *CommandResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func (x *raftServiceProgressClient) Recv() (*ProgressResponse, error) {
+	m := new(ProgressResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *CommandResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// RaftServiceServer is the server API for RaftService service.
+type RaftServiceServer interface {
+	Join(context.Context, *JoinRequest) (*JoinResponse, error)
+	Leave(context.Context, *LeaveRequest) (*LeaveResponse, error)
+	Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error)
+	Reconfigure(context.Context, *ReconfigureRequest) (*ReconfigureResponse, error)
+	SetReadOnly(context.Context, *SetReadOnlyRequest) (*SetReadOnlyResponse, error)
+	FreezeElections(context.Context, *FreezeElectionsRequest) (*FreezeElectionsResponse, error)
+	Poll(context.Context, *PollRequest) (*PollResponse, error)
+	Vote(context.Context, *VoteRequest) (*VoteResponse, error)
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	ConfirmLeadership(context.Context, *ConfirmLeadershipRequest) (*ConfirmLeadershipResponse, error)
+	Hash(context.Context, *HashRequest) (*HashResponse, error)
+	Append(context.Context, *AppendRequest) (*AppendResponse, error)
+	Install(RaftService_InstallServer) error
+	Command(*CommandRequest, RaftService_CommandServer) error
+	CommandBatch(context.Context, *CommandBatchRequest) (*CommandBatchResponse, error)
+	Query(*QueryRequest, RaftService_QueryServer) error
+	Progress(*ProgressRequest, RaftService_ProgressServer) error
 }
 
-func (m *CommandResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Output) > 0 {
-		i -= len(m.Output)
-		copy(dAtA[i:], m.Output)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Output)))
-		i--
-		dAtA[i] = 0x3a
+// UnimplementedRaftServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedRaftServiceServer struct {
+}
+
+func (*UnimplementedRaftServiceServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (*UnimplementedRaftServiceServer) Leave(ctx context.Context, req *LeaveRequest) (*LeaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (*UnimplementedRaftServiceServer) Configure(ctx context.Context, req *ConfigureRequest) (*ConfigureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (*UnimplementedRaftServiceServer) Reconfigure(ctx context.Context, req *ReconfigureRequest) (*ReconfigureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconfigure not implemented")
+}
+func (*UnimplementedRaftServiceServer) SetReadOnly(ctx context.Context, req *SetReadOnlyRequest) (*SetReadOnlyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReadOnly not implemented")
+}
+func (*UnimplementedRaftServiceServer) FreezeElections(ctx context.Context, req *FreezeElectionsRequest) (*FreezeElectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeElections not implemented")
+}
+func (*UnimplementedRaftServiceServer) Poll(ctx context.Context, req *PollRequest) (*PollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Poll not implemented")
+}
+func (*UnimplementedRaftServiceServer) Vote(ctx context.Context, req *VoteRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Vote not implemented")
+}
+func (*UnimplementedRaftServiceServer) Transfer(ctx context.Context, req *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
+func (*UnimplementedRaftServiceServer) ConfirmLeadership(ctx context.Context, req *ConfirmLeadershipRequest) (*ConfirmLeadershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmLeadership not implemented")
+}
+func (*UnimplementedRaftServiceServer) Hash(ctx context.Context, req *HashRequest) (*HashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Hash not implemented")
+}
+func (*UnimplementedRaftServiceServer) Append(ctx context.Context, req *AppendRequest) (*AppendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Append not implemented")
+}
+func (*UnimplementedRaftServiceServer) Install(srv RaftService_InstallServer) error {
+	return status.Errorf(codes.Unimplemented, "method Install not implemented")
+}
+func (*UnimplementedRaftServiceServer) Command(req *CommandRequest, srv RaftService_CommandServer) error {
+	return status.Errorf(codes.Unimplemented, "method Command not implemented")
+}
+func (*UnimplementedRaftServiceServer) CommandBatch(ctx context.Context, req *CommandBatchRequest) (*CommandBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommandBatch not implemented")
+}
+func (*UnimplementedRaftServiceServer) Query(req *QueryRequest, srv RaftService_QueryServer) error {
+	return status.Errorf(codes.Unimplemented, "method Query not implemented")
+}
+func (*UnimplementedRaftServiceServer) Progress(req *ProgressRequest, srv RaftService_ProgressServer) error {
+	return status.Errorf(codes.Unimplemented, "method Progress not implemented")
+}
+
+func RegisterRaftServiceServer(s *grpc.Server, srv RaftServiceServer) {
+	s.RegisterService(&_RaftService_serviceDesc, srv)
+}
+
+func _RaftService_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Members) > 0 {
-		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Members[iNdEx])
-			copy(dAtA[i:], m.Members[iNdEx])
-			i = encodeVarintProtocol(dAtA, i, uint64(len(m.Members[iNdEx])))
-			i--
-			dAtA[i] = 0x32
-		}
+	if interceptor == nil {
+		return srv.(RaftServiceServer).Join(ctx, in)
 	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x28
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/Join",
 	}
-	if len(m.Leader) > 0 {
-		i -= len(m.Leader)
-		copy(dAtA[i:], m.Leader)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
-		i--
-		dAtA[i] = 0x22
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).Join(ctx, req.(*JoinRequest))
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RaftService_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
+	if interceptor == nil {
+		return srv.(RaftServiceServer).Leave(ctx, in)
 	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/Leave",
 	}
-	return len(dAtA) - i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).Leave(ctx, req.(*LeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *QueryRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _RaftService_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *QueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.ReadConsistency != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.ReadConsistency))
-		i--
-		dAtA[i] = 0x10
+	if interceptor == nil {
+		return srv.(RaftServiceServer).Configure(ctx, in)
 	}
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0xa
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/Configure",
 	}
-	return len(dAtA) - i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).Configure(ctx, req.(*ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *QueryResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _RaftService_Reconfigure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconfigureRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(RaftServiceServer).Reconfigure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/Reconfigure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).Reconfigure(ctx, req.(*ReconfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _RaftService_SetReadOnly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReadOnlyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftServiceServer).SetReadOnly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/SetReadOnly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).SetReadOnly(ctx, req.(*SetReadOnlyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *QueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Output) > 0 {
-		i -= len(m.Output)
-		copy(dAtA[i:], m.Output)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Output)))
-		i--
-		dAtA[i] = 0x22
+func _RaftService_FreezeElections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeElectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	if interceptor == nil {
+		return srv.(RaftServiceServer).FreezeElections(ctx, in)
 	}
-	if m.Error != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
-		i--
-		dAtA[i] = 0x10
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/FreezeElections",
 	}
-	if m.Status != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).FreezeElections(ctx, req.(*FreezeElectionsRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func encodeVarintProtocol(dAtA []byte, offset int, v uint64) int {
-	offset -= sovProtocol(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func _RaftService_Poll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func NewPopulatedJoinRequest(r randyProtocol, easy bool) *JoinRequest {
-	this := &JoinRequest{}
-	if r.Intn(5) != 0 {
-		this.Member = NewPopulatedMember(r, easy)
+	if interceptor == nil {
+		return srv.(RaftServiceServer).Poll(ctx, in)
 	}
-	if !easy && r.Intn(10) != 0 {
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/Poll",
 	}
-	return this
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).Poll(ctx, req.(*PollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewPopulatedJoinResponse(r randyProtocol, easy bool) *JoinResponse {
-	this := &JoinResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	this.Index = Index(uint64(r.Uint32()))
-	this.Term = Term(uint64(r.Uint32()))
-	v1 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
-	this.Timestamp = *v1
-	if r.Intn(5) != 0 {
-		v2 := r.Intn(5)
-		this.Members = make([]*Member, v2)
-		for i := 0; i < v2; i++ {
-			this.Members[i] = NewPopulatedMember(r, easy)
-		}
+func _RaftService_Vote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if !easy && r.Intn(10) != 0 {
+	if interceptor == nil {
+		return srv.(RaftServiceServer).Vote(ctx, in)
 	}
-	return this
-}
-
-func NewPopulatedConfigureRequest(r randyProtocol, easy bool) *ConfigureRequest {
-	this := &ConfigureRequest{}
-	this.Term = Term(uint64(r.Uint32()))
-	this.Leader = MemberID(randStringProtocol(r))
-	this.Index = Index(uint64(r.Uint32()))
-	v3 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
-	this.Timestamp = *v3
-	if r.Intn(5) != 0 {
-		v4 := r.Intn(5)
-		this.Members = make([]*Member, v4)
-		for i := 0; i < v4; i++ {
-			this.Members[i] = NewPopulatedMember(r, easy)
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/Vote",
 	}
-	if !easy && r.Intn(10) != 0 {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).Vote(ctx, req.(*VoteRequest))
 	}
-	return this
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewPopulatedConfigureResponse(r randyProtocol, easy bool) *ConfigureResponse {
-	this := &ConfigureResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	if !easy && r.Intn(10) != 0 {
+func _RaftService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return this
-}
-
-func NewPopulatedReconfigureRequest(r randyProtocol, easy bool) *ReconfigureRequest {
-	this := &ReconfigureRequest{}
-	if r.Intn(5) != 0 {
-		this.Member = NewPopulatedMember(r, easy)
+	if interceptor == nil {
+		return srv.(RaftServiceServer).Transfer(ctx, in)
 	}
-	this.Index = Index(uint64(r.Uint32()))
-	this.Term = Term(uint64(r.Uint32()))
-	if !easy && r.Intn(10) != 0 {
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/Transfer",
 	}
-	return this
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewPopulatedReconfigureResponse(r randyProtocol, easy bool) *ReconfigureResponse {
-	this := &ReconfigureResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	this.Index = Index(uint64(r.Uint32()))
-	this.Term = Term(uint64(r.Uint32()))
-	v5 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
-	this.Timestamp = *v5
-	if r.Intn(5) != 0 {
-		v6 := r.Intn(5)
-		this.Members = make([]*Member, v6)
-		for i := 0; i < v6; i++ {
-			this.Members[i] = NewPopulatedMember(r, easy)
-		}
+func _RaftService_ConfirmLeadership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmLeadershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if !easy && r.Intn(10) != 0 {
+	if interceptor == nil {
+		return srv.(RaftServiceServer).ConfirmLeadership(ctx, in)
 	}
-	return this
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/ConfirmLeadership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).ConfirmLeadership(ctx, req.(*ConfirmLeadershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewPopulatedLeaveRequest(r randyProtocol, easy bool) *LeaveRequest {
-	this := &LeaveRequest{}
-	if r.Intn(5) != 0 {
-		this.Member = NewPopulatedMember(r, easy)
+func _RaftService_Hash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if !easy && r.Intn(10) != 0 {
+	if interceptor == nil {
+		return srv.(RaftServiceServer).Hash(ctx, in)
 	}
-	return this
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/Hash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).Hash(ctx, req.(*HashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewPopulatedLeaveResponse(r randyProtocol, easy bool) *LeaveResponse {
-	this := &LeaveResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	this.Index = Index(uint64(r.Uint32()))
-	this.Term = Term(uint64(r.Uint32()))
-	v7 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
-	this.Timestamp = *v7
-	if r.Intn(5) != 0 {
-		v8 := r.Intn(5)
-		this.Members = make([]*Member, v8)
-		for i := 0; i < v8; i++ {
-			this.Members[i] = NewPopulatedMember(r, easy)
-		}
+func _RaftService_Append_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if !easy && r.Intn(10) != 0 {
+	if interceptor == nil {
+		return srv.(RaftServiceServer).Append(ctx, in)
 	}
-	return this
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/Append",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).Append(ctx, req.(*AppendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewPopulatedPollRequest(r randyProtocol, easy bool) *PollRequest {
-	this := &PollRequest{}
-	this.Term = Term(uint64(r.Uint32()))
-	this.Candidate = MemberID(randStringProtocol(r))
-	this.LastLogIndex = Index(uint64(r.Uint32()))
-	this.LastLogTerm = Term(uint64(r.Uint32()))
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+func _RaftService_Install_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RaftServiceServer).Install(&raftServiceInstallServer{stream})
 }
 
-func NewPopulatedPollResponse(r randyProtocol, easy bool) *PollResponse {
-	this := &PollResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	this.Term = Term(uint64(r.Uint32()))
-	this.Accepted = bool(bool(r.Intn(2) == 0))
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+type RaftService_InstallServer interface {
+	SendAndClose(*InstallResponse) error
+	Recv() (*InstallRequest, error)
+	grpc.ServerStream
 }
 
-func NewPopulatedVoteRequest(r randyProtocol, easy bool) *VoteRequest {
-	this := &VoteRequest{}
-	this.Term = Term(uint64(r.Uint32()))
-	this.Candidate = MemberID(randStringProtocol(r))
-	this.LastLogIndex = Index(uint64(r.Uint32()))
-	this.LastLogTerm = Term(uint64(r.Uint32()))
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+type raftServiceInstallServer struct {
+	grpc.ServerStream
 }
 
-func NewPopulatedVoteResponse(r randyProtocol, easy bool) *VoteResponse {
-	this := &VoteResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	this.Term = Term(uint64(r.Uint32()))
-	this.Voted = bool(bool(r.Intn(2) == 0))
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+func (x *raftServiceInstallServer) SendAndClose(m *InstallResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func NewPopulatedTransferRequest(r randyProtocol, easy bool) *TransferRequest {
-	this := &TransferRequest{}
-	this.Member = MemberID(randStringProtocol(r))
-	if !easy && r.Intn(10) != 0 {
+func (x *raftServiceInstallServer) Recv() (*InstallRequest, error) {
+	m := new(InstallRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return this
+	return m, nil
 }
 
-func NewPopulatedTransferResponse(r randyProtocol, easy bool) *TransferResponse {
-	this := &TransferResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	if !easy && r.Intn(10) != 0 {
+func _RaftService_Command_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CommandRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return this
+	return srv.(RaftServiceServer).Command(m, &raftServiceCommandServer{stream})
 }
 
-func NewPopulatedAppendRequest(r randyProtocol, easy bool) *AppendRequest {
-	this := &AppendRequest{}
-	this.Term = Term(uint64(r.Uint32()))
-	this.Leader = MemberID(randStringProtocol(r))
-	this.PrevLogIndex = Index(uint64(r.Uint32()))
-	this.PrevLogTerm = Term(uint64(r.Uint32()))
-	if r.Intn(5) != 0 {
-		v9 := r.Intn(5)
-		this.Entries = make([]*LogEntry, v9)
-		for i := 0; i < v9; i++ {
-			this.Entries[i] = NewPopulatedLogEntry(r, easy)
-		}
-	}
-	this.CommitIndex = Index(uint64(r.Uint32()))
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+type RaftService_CommandServer interface {
+	Send(*CommandResponse) error
+	grpc.ServerStream
 }
 
-func NewPopulatedAppendResponse(r randyProtocol, easy bool) *AppendResponse {
-	this := &AppendResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	this.Term = Term(uint64(r.Uint32()))
-	this.Succeeded = bool(bool(r.Intn(2) == 0))
-	this.LastLogIndex = Index(uint64(r.Uint32()))
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+type raftServiceCommandServer struct {
+	grpc.ServerStream
 }
 
-func NewPopulatedInstallRequest(r randyProtocol, easy bool) *InstallRequest {
-	this := &InstallRequest{}
-	this.Term = Term(uint64(r.Uint32()))
-	this.Leader = MemberID(randStringProtocol(r))
-	this.Index = Index(uint64(r.Uint32()))
-	v10 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
-	this.Timestamp = *v10
-	v11 := r.Intn(100)
-	this.Data = make([]byte, v11)
-	for i := 0; i < v11; i++ {
-		this.Data[i] = byte(r.Intn(256))
+func (x *raftServiceCommandServer) Send(m *CommandResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RaftService_CommandBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommandBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if !easy && r.Intn(10) != 0 {
+	if interceptor == nil {
+		return srv.(RaftServiceServer).CommandBatch(ctx, in)
 	}
-	return this
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.raft.protocol.RaftService/CommandBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftServiceServer).CommandBatch(ctx, req.(*CommandBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewPopulatedInstallResponse(r randyProtocol, easy bool) *InstallResponse {
-	this := &InstallResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	if !easy && r.Intn(10) != 0 {
+func _RaftService_Query_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return this
+	return srv.(RaftServiceServer).Query(m, &raftServiceQueryServer{stream})
 }
 
-func NewPopulatedCommandRequest(r randyProtocol, easy bool) *CommandRequest {
-	this := &CommandRequest{}
-	v12 := r.Intn(100)
-	this.Value = make([]byte, v12)
-	for i := 0; i < v12; i++ {
-		this.Value[i] = byte(r.Intn(256))
-	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+type RaftService_QueryServer interface {
+	Send(*QueryResponse) error
+	grpc.ServerStream
 }
 
-func NewPopulatedCommandResponse(r randyProtocol, easy bool) *CommandResponse {
-	this := &CommandResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	this.Message = string(randStringProtocol(r))
-	this.Leader = MemberID(randStringProtocol(r))
-	this.Term = Term(uint64(r.Uint32()))
-	v13 := r.Intn(10)
-	this.Members = make([]MemberID, v13)
-	for i := 0; i < v13; i++ {
-		this.Members[i] = MemberID(randStringProtocol(r))
-	}
-	v14 := r.Intn(100)
-	this.Output = make([]byte, v14)
-	for i := 0; i < v14; i++ {
-		this.Output[i] = byte(r.Intn(256))
-	}
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+type raftServiceQueryServer struct {
+	grpc.ServerStream
 }
 
-func NewPopulatedQueryRequest(r randyProtocol, easy bool) *QueryRequest {
-	this := &QueryRequest{}
-	v15 := r.Intn(100)
-	this.Value = make([]byte, v15)
-	for i := 0; i < v15; i++ {
-		this.Value[i] = byte(r.Intn(256))
-	}
-	this.ReadConsistency = ReadConsistency([]int32{0, 1, 2}[r.Intn(3)])
-	if !easy && r.Intn(10) != 0 {
-	}
-	return this
+func (x *raftServiceQueryServer) Send(m *QueryResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func NewPopulatedQueryResponse(r randyProtocol, easy bool) *QueryResponse {
-	this := &QueryResponse{}
-	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
-	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}[r.Intn(12)])
-	this.Message = string(randStringProtocol(r))
-	v16 := r.Intn(100)
-	this.Output = make([]byte, v16)
-	for i := 0; i < v16; i++ {
-		this.Output[i] = byte(r.Intn(256))
-	}
-	if !easy && r.Intn(10) != 0 {
+func _RaftService_Progress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return this
+	return srv.(RaftServiceServer).Progress(m, &raftServiceProgressServer{stream})
 }
 
-type randyProtocol interface {
-	Float32() float32
-	Float64() float64
-	Int63() int64
-	Int31() int32
-	Uint32() uint32
-	Intn(n int) int
+type RaftService_ProgressServer interface {
+	Send(*ProgressResponse) error
+	grpc.ServerStream
 }
 
-func randUTF8RuneProtocol(r randyProtocol) rune {
-	ru := r.Intn(62)
-	if ru < 10 {
-		return rune(ru + 48)
-	} else if ru < 36 {
-		return rune(ru + 55)
-	}
-	return rune(ru + 61)
+type raftServiceProgressServer struct {
+	grpc.ServerStream
 }
-func randStringProtocol(r randyProtocol) string {
-	v17 := r.Intn(100)
-	tmps := make([]rune, v17)
-	for i := 0; i < v17; i++ {
-		tmps[i] = randUTF8RuneProtocol(r)
-	}
-	return string(tmps)
+
+func (x *raftServiceProgressServer) Send(m *ProgressResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
-func randUnrecognizedProtocol(r randyProtocol, maxFieldNumber int) (dAtA []byte) {
-	l := r.Intn(5)
-	for i := 0; i < l; i++ {
-		wire := r.Intn(4)
-		if wire == 3 {
-			wire = 5
-		}
-		fieldNumber := maxFieldNumber + r.Intn(100)
-		dAtA = randFieldProtocol(dAtA, r, fieldNumber, wire)
-	}
-	return dAtA
+
+var _RaftService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "atomix.raft.protocol.RaftService",
+	HandlerType: (*RaftServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Join",
+			Handler:    _RaftService_Join_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _RaftService_Leave_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _RaftService_Configure_Handler,
+		},
+		{
+			MethodName: "Reconfigure",
+			Handler:    _RaftService_Reconfigure_Handler,
+		},
+		{
+			MethodName: "SetReadOnly",
+			Handler:    _RaftService_SetReadOnly_Handler,
+		},
+		{
+			MethodName: "FreezeElections",
+			Handler:    _RaftService_FreezeElections_Handler,
+		},
+		{
+			MethodName: "Poll",
+			Handler:    _RaftService_Poll_Handler,
+		},
+		{
+			MethodName: "Vote",
+			Handler:    _RaftService_Vote_Handler,
+		},
+		{
+			MethodName: "Transfer",
+			Handler:    _RaftService_Transfer_Handler,
+		},
+		{
+			MethodName: "ConfirmLeadership",
+			Handler:    _RaftService_ConfirmLeadership_Handler,
+		},
+		{
+			MethodName: "Hash",
+			Handler:    _RaftService_Hash_Handler,
+		},
+		{
+			MethodName: "Append",
+			Handler:    _RaftService_Append_Handler,
+		},
+		{
+			MethodName: "CommandBatch",
+			Handler:    _RaftService_CommandBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Install",
+			Handler:       _RaftService_Install_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Command",
+			Handler:       _RaftService_Command_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Query",
+			Handler:       _RaftService_Query_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Progress",
+			Handler:       _RaftService_Progress_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "atomix/raft/protocol/protocol.proto",
 }
-func randFieldProtocol(dAtA []byte, r randyProtocol, fieldNumber int, wire int) []byte {
-	key := uint32(fieldNumber)<<3 | uint32(wire)
-	switch wire {
-	case 0:
-		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(key))
-		v18 := r.Int63()
-		if r.Intn(2) == 0 {
-			v18 *= -1
-		}
-		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(v18))
-	case 1:
-		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	case 2:
-		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(key))
-		ll := r.Intn(100)
-		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(ll))
-		for j := 0; j < ll; j++ {
-			dAtA = append(dAtA, byte(r.Intn(256)))
-		}
-	default:
-		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+
+func (m *JoinRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return dAtA
+	return dAtA[:n], nil
 }
-func encodeVarintPopulateProtocol(dAtA []byte, v uint64) []byte {
-	for v >= 1<<7 {
-		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
-		v >>= 7
-	}
-	dAtA = append(dAtA, uint8(v))
-	return dAtA
+
+func (m *JoinRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (m *JoinRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *JoinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
+	if m.Forwarded {
+		i--
+		if m.Forwarded {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.Member != nil {
-		l = m.Member.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+		{
+			size, err := m.Member.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *JoinResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
-	}
-	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
-	}
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
-	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovProtocol(uint64(l))
-	if len(m.Members) > 0 {
-		for _, e := range m.Members {
-			l = e.Size()
-			n += 1 + l + sovProtocol(uint64(l))
-		}
+func (m *JoinResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ConfigureRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *JoinResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JoinResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
-	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if m.CommitIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.CommitIndex))
+		i--
+		dAtA[i] = 0x40
 	}
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
+	if len(m.Leader) > 0 {
+		i -= len(m.Leader)
+		copy(dAtA[i:], m.Leader)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
+		i--
+		dAtA[i] = 0x3a
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovProtocol(uint64(l))
 	if len(m.Members) > 0 {
-		for _, e := range m.Members {
-			l = e.Size()
-			n += 1 + l + sovProtocol(uint64(l))
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
 		}
 	}
-	return n
-}
-
-func (m *ConfigureResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err2 != nil {
+		return 0, err2
 	}
-	var l int
-	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
+	i -= n2
+	i = encodeVarintProtocol(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x2a
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x18
 	}
 	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
 	}
-	return n
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ReconfigureRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ConfigureRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigureRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Member != nil {
-		l = m.Member.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err3 != nil {
+		return 0, err3
 	}
+	i -= n3
+	i = encodeVarintProtocol(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x22
 	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Leader) > 0 {
+		i -= len(m.Leader)
+		copy(dAtA[i:], m.Leader)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
+		i--
+		dAtA[i] = 0x12
 	}
 	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ReconfigureResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ConfigureResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigureResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
-	}
 	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
-	}
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovProtocol(uint64(l))
-	if len(m.Members) > 0 {
-		for _, e := range m.Members {
-			l = e.Size()
-			n += 1 + l + sovProtocol(uint64(l))
-		}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *LeaveRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Member != nil {
-		l = m.Member.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+func (m *ReconfigureRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *LeaveResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ReconfigureRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReconfigureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
+	if m.HeartbeatInterval != nil {
+		n4, err4 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.HeartbeatInterval, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatInterval):])
+		if err4 != nil {
+			return 0, err4
+		}
+		i -= n4
+		i = encodeVarintProtocol(dAtA, i, uint64(n4))
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
-	}
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
+	if m.ElectionTimeout != nil {
+		n5, err5 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.ElectionTimeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ElectionTimeout):])
+		if err5 != nil {
+			return 0, err5
+		}
+		i -= n5
+		i = encodeVarintProtocol(dAtA, i, uint64(n5))
+		i--
+		dAtA[i] = 0x22
 	}
 	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x18
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovProtocol(uint64(l))
-	if len(m.Members) > 0 {
-		for _, e := range m.Members {
-			l = e.Size()
-			n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Member != nil {
+		{
+			size, err := m.Member.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *PollRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ReconfigureResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ReconfigureResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReconfigureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err7 != nil {
+		return 0, err7
+	}
+	i -= n7
+	i = encodeVarintProtocol(dAtA, i, uint64(n7))
+	i--
+	dAtA[i] = 0x2a
 	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x20
 	}
-	l = len(m.Candidate)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.LastLogIndex != 0 {
-		n += 1 + sovProtocol(uint64(m.LastLogIndex))
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.LastLogTerm != 0 {
-		n += 1 + sovProtocol(uint64(m.LastLogTerm))
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *PollResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LeaveRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaveRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
-	}
-	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
-	}
-	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
-	}
-	if m.Accepted {
-		n += 2
+	if m.Member != nil {
+		{
+			size, err := m.Member.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *VoteRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LeaveResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaveResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaveResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	n9, err9 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err9 != nil {
+		return 0, err9
+	}
+	i -= n9
+	i = encodeVarintProtocol(dAtA, i, uint64(n9))
+	i--
+	dAtA[i] = 0x2a
 	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x20
 	}
-	l = len(m.Candidate)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.LastLogIndex != 0 {
-		n += 1 + sovProtocol(uint64(m.LastLogIndex))
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.LastLogTerm != 0 {
-		n += 1 + sovProtocol(uint64(m.LastLogTerm))
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *VoteResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SetReadOnlyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SetReadOnlyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetReadOnlyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
-	}
-	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
-	}
-	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
-	}
-	if m.Voted {
-		n += 2
+	if m.ReadOnly {
+		i--
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *TransferRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Member)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+func (m *SetReadOnlyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *TransferResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
-	}
-	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
-	}
-	return n
+func (m *SetReadOnlyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AppendRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *SetReadOnlyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x20
 	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.PrevLogIndex != 0 {
-		n += 1 + sovProtocol(uint64(m.PrevLogIndex))
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.PrevLogTerm != 0 {
-		n += 1 + sovProtocol(uint64(m.PrevLogTerm))
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
 	}
-	if len(m.Entries) > 0 {
-		for _, e := range m.Entries {
-			l = e.Size()
-			n += 1 + l + sovProtocol(uint64(l))
-		}
+	return len(dAtA) - i, nil
+}
+
+func (m *FreezeElectionsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	if m.CommitIndex != 0 {
-		n += 1 + sovProtocol(uint64(m.CommitIndex))
+	return dAtA[:n], nil
+}
+
+func (m *FreezeElectionsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FreezeElectionsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n10, err10 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Duration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Duration):])
+	if err10 != nil {
+		return 0, err10
 	}
-	return n
+	i -= n10
+	i = encodeVarintProtocol(dAtA, i, uint64(n10))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *AppendResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *FreezeElectionsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *FreezeElectionsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FreezeElectionsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
-	}
-	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
+	n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Expires, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Expires):])
+	if err11 != nil {
+		return 0, err11
 	}
+	i -= n11
+	i = encodeVarintProtocol(dAtA, i, uint64(n11))
+	i--
+	dAtA[i] = 0x2a
 	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x20
 	}
-	if m.Succeeded {
-		n += 2
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.LastLogIndex != 0 {
-		n += 1 + sovProtocol(uint64(m.LastLogIndex))
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
 	}
-	return n
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *InstallRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PollRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PollRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PollRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
+	if m.LastLogTerm != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogTerm))
+		i--
+		dAtA[i] = 0x20
 	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if m.LastLogIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogIndex))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
+	if len(m.Candidate) > 0 {
+		i -= len(m.Candidate)
+		copy(dAtA[i:], m.Candidate)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Candidate)))
+		i--
+		dAtA[i] = 0x12
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovProtocol(uint64(l))
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *InstallResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PollResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PollResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PollResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
+	n12, err12 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RetryAfter, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.RetryAfter):])
+	if err12 != nil {
+		return 0, err12
+	}
+	i -= n12
+	i = encodeVarintProtocol(dAtA, i, uint64(n12))
+	i--
+	dAtA[i] = 0x2a
+	if m.Accepted {
+		i--
+		if m.Accepted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x18
 	}
 	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
 	}
-	return n
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *CommandRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+func (m *VoteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *CommandResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *VoteRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VoteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
+	if m.ConfigurationIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.ConfigurationIndex))
+		i--
+		dAtA[i] = 0x28
 	}
-	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
+	if m.LastLogTerm != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogTerm))
+		i--
+		dAtA[i] = 0x20
 	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if m.LastLogIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogIndex))
+		i--
+		dAtA[i] = 0x18
 	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if len(m.Candidate) > 0 {
+		i -= len(m.Candidate)
+		copy(dAtA[i:], m.Candidate)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Candidate)))
+		i--
+		dAtA[i] = 0x12
 	}
 	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x8
 	}
-	if len(m.Members) > 0 {
-		for _, s := range m.Members {
-			l = len(s)
-			n += 1 + l + sovProtocol(uint64(l))
+	return len(dAtA) - i, nil
+}
+
+func (m *VoteResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VoteResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VoteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n13, err13 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.RetryAfter, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.RetryAfter):])
+	if err13 != nil {
+		return 0, err13
+	}
+	i -= n13
+	i = encodeVarintProtocol(dAtA, i, uint64(n13))
+	i--
+	dAtA[i] = 0x2a
+	if m.Voted {
+		i--
+		if m.Voted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x20
 	}
-	l = len(m.Output)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x18
 	}
-	return n
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *QueryRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *TransferRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if m.LastLogIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogIndex))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.ReadConsistency != 0 {
-		n += 1 + sovProtocol(uint64(m.ReadConsistency))
+	if len(m.Member) > 0 {
+		i -= len(m.Member)
+		copy(dAtA[i:], m.Member)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Member)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConfirmLeadershipRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfirmLeadershipRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfirmLeadershipRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Leader) > 0 {
+		i -= len(m.Leader)
+		copy(dAtA[i:], m.Leader)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConfirmLeadershipResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfirmLeadershipResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfirmLeadershipResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Confirmed {
+		i--
+		if m.Confirmed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HashRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HashRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HashRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LastIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.FirstIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.FirstIndex))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HashResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HashResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HashResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Hash != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Hash))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AppendRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppendRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AppendRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LeaderFirstIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LeaderFirstIndex))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.LeaderLastIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LeaderLastIndex))
+		i--
+		dAtA[i] = 0x40
+	}
+	n14, err14 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err14 != nil {
+		return 0, err14
+	}
+	i -= n14
+	i = encodeVarintProtocol(dAtA, i, uint64(n14))
+	i--
+	dAtA[i] = 0x3a
+	if m.CommitIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.CommitIndex))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Entries) > 0 {
+		for iNdEx := len(m.Entries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Entries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.PrevLogTerm != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.PrevLogTerm))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.PrevLogIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.PrevLogIndex))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Leader) > 0 {
+		i -= len(m.Leader)
+		copy(dAtA[i:], m.Leader)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AppendResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppendResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AppendResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SnapshotRequested {
+		i--
+		if m.SnapshotRequested {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	n15, err15 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err15 != nil {
+		return 0, err15
+	}
+	i -= n15
+	i = encodeVarintProtocol(dAtA, i, uint64(n15))
+	i--
+	dAtA[i] = 0x42
+	if m.LastLogTerm != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogTerm))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Truncated != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Truncated))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.LastLogIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.LastLogIndex))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Succeeded {
+		i--
+		if m.Succeeded {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InstallRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InstallRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InstallRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n16, err16 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ElectionFreeze, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.ElectionFreeze):])
+	if err16 != nil {
+		return 0, err16
+	}
+	i -= n16
+	i = encodeVarintProtocol(dAtA, i, uint64(n16))
+	i--
+	dAtA[i] = 0x5a
+	if m.ReadOnly {
+		i--
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Checksum != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Checksum))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Offset != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Version != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.SnapshotTerm != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.SnapshotTerm))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err17 != nil {
+		return 0, err17
+	}
+	i -= n17
+	i = encodeVarintProtocol(dAtA, i, uint64(n17))
+	i--
+	dAtA[i] = 0x22
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Leader) > 0 {
+		i -= len(m.Leader)
+		copy(dAtA[i:], m.Leader)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InstallResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InstallResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InstallResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Offset != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CommandRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommandRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommandRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Metadata) > 0 {
+		for k := range m.Metadata {
+			v := m.Metadata[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintProtocol(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintProtocol(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintProtocol(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Timeout != nil {
+		n18, err18 := github_com_gogo_protobuf_types.StdDurationMarshalTo(*m.Timeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Timeout):])
+		if err18 != nil {
+			return 0, err18
+		}
+		i -= n18
+		i = encodeVarintProtocol(dAtA, i, uint64(n18))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CommandResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommandResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommandResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ElectionInProgress {
+		i--
+		if m.ElectionInProgress {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Partial {
+		i--
+		if m.Partial {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.Output) > 0 {
+		i -= len(m.Output)
+		copy(dAtA[i:], m.Output)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Output)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Members[iNdEx])
+			copy(dAtA[i:], m.Members[iNdEx])
+			i = encodeVarintProtocol(dAtA, i, uint64(len(m.Members[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Leader) > 0 {
+		i -= len(m.Leader)
+		copy(dAtA[i:], m.Leader)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CommandBatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommandBatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommandBatchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Values[iNdEx])
+			copy(dAtA[i:], m.Values[iNdEx])
+			i = encodeVarintProtocol(dAtA, i, uint64(len(m.Values[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CommandBatchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommandBatchResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommandBatchResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Members[iNdEx])
+			copy(dAtA[i:], m.Members[iNdEx])
+			i = encodeVarintProtocol(dAtA, i, uint64(len(m.Members[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Leader) > 0 {
+		i -= len(m.Leader)
+		copy(dAtA[i:], m.Leader)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Leader)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CommandResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommandResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommandResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Output) > 0 {
+		i -= len(m.Output)
+		copy(dAtA[i:], m.Output)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Output)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MinAppliedIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MinAppliedIndex))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.SequenceNumber != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.SequenceNumber))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ReadConsistency != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.ReadConsistency))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.StaleBy != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.StaleBy))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ReadRepair {
+		i--
+		if m.ReadRepair {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.SequenceNumber != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.SequenceNumber))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Output) > 0 {
+		i -= len(m.Output)
+		copy(dAtA[i:], m.Output)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Output)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Error != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Status != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProgressRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProgressRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProgressRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ProgressResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProgressResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProgressResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Lag != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Lag))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.AppliedIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.AppliedIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.CommitIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.CommitIndex))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintProtocol(dAtA []byte, offset int, v uint64) int {
+	offset -= sovProtocol(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func NewPopulatedJoinRequest(r randyProtocol, easy bool) *JoinRequest {
+	this := &JoinRequest{}
+	if r.Intn(5) != 0 {
+		this.Member = NewPopulatedMember(r, easy)
+	}
+	this.Forwarded = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedJoinResponse(r randyProtocol, easy bool) *JoinResponse {
+	this := &JoinResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Index = Index(uint64(r.Uint32()))
+	this.Term = Term(uint64(r.Uint32()))
+	v1 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Timestamp = *v1
+	if r.Intn(5) != 0 {
+		v2 := r.Intn(5)
+		this.Members = make([]*Member, v2)
+		for i := 0; i < v2; i++ {
+			this.Members[i] = NewPopulatedMember(r, easy)
+		}
+	}
+	this.Leader = MemberID(randStringProtocol(r))
+	this.CommitIndex = Index(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedConfigureRequest(r randyProtocol, easy bool) *ConfigureRequest {
+	this := &ConfigureRequest{}
+	this.Term = Term(uint64(r.Uint32()))
+	this.Leader = MemberID(randStringProtocol(r))
+	this.Index = Index(uint64(r.Uint32()))
+	v3 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Timestamp = *v3
+	if r.Intn(5) != 0 {
+		v4 := r.Intn(5)
+		this.Members = make([]*Member, v4)
+		for i := 0; i < v4; i++ {
+			this.Members[i] = NewPopulatedMember(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedConfigureResponse(r randyProtocol, easy bool) *ConfigureResponse {
+	this := &ConfigureResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedReconfigureRequest(r randyProtocol, easy bool) *ReconfigureRequest {
+	this := &ReconfigureRequest{}
+	if r.Intn(5) != 0 {
+		this.Member = NewPopulatedMember(r, easy)
+	}
+	this.Index = Index(uint64(r.Uint32()))
+	this.Term = Term(uint64(r.Uint32()))
+	if r.Intn(5) != 0 {
+		this.ElectionTimeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.HeartbeatInterval = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedReconfigureResponse(r randyProtocol, easy bool) *ReconfigureResponse {
+	this := &ReconfigureResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Index = Index(uint64(r.Uint32()))
+	this.Term = Term(uint64(r.Uint32()))
+	v5 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Timestamp = *v5
+	if r.Intn(5) != 0 {
+		v6 := r.Intn(5)
+		this.Members = make([]*Member, v6)
+		for i := 0; i < v6; i++ {
+			this.Members[i] = NewPopulatedMember(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedLeaveRequest(r randyProtocol, easy bool) *LeaveRequest {
+	this := &LeaveRequest{}
+	if r.Intn(5) != 0 {
+		this.Member = NewPopulatedMember(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedLeaveResponse(r randyProtocol, easy bool) *LeaveResponse {
+	this := &LeaveResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Index = Index(uint64(r.Uint32()))
+	this.Term = Term(uint64(r.Uint32()))
+	v7 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Timestamp = *v7
+	if r.Intn(5) != 0 {
+		v8 := r.Intn(5)
+		this.Members = make([]*Member, v8)
+		for i := 0; i < v8; i++ {
+			this.Members[i] = NewPopulatedMember(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedSetReadOnlyRequest(r randyProtocol, easy bool) *SetReadOnlyRequest {
+	this := &SetReadOnlyRequest{}
+	this.ReadOnly = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedSetReadOnlyResponse(r randyProtocol, easy bool) *SetReadOnlyResponse {
+	this := &SetReadOnlyResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Index = Index(uint64(r.Uint32()))
+	this.Term = Term(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedFreezeElectionsRequest(r randyProtocol, easy bool) *FreezeElectionsRequest {
+	this := &FreezeElectionsRequest{}
+	v9 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.Duration = *v9
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedFreezeElectionsResponse(r randyProtocol, easy bool) *FreezeElectionsResponse {
+	this := &FreezeElectionsResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Index = Index(uint64(r.Uint32()))
+	this.Term = Term(uint64(r.Uint32()))
+	v10 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Expires = *v10
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPollRequest(r randyProtocol, easy bool) *PollRequest {
+	this := &PollRequest{}
+	this.Term = Term(uint64(r.Uint32()))
+	this.Candidate = MemberID(randStringProtocol(r))
+	this.LastLogIndex = Index(uint64(r.Uint32()))
+	this.LastLogTerm = Term(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedPollResponse(r randyProtocol, easy bool) *PollResponse {
+	this := &PollResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Term = Term(uint64(r.Uint32()))
+	this.Accepted = bool(bool(r.Intn(2) == 0))
+	v11 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.RetryAfter = *v11
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedVoteRequest(r randyProtocol, easy bool) *VoteRequest {
+	this := &VoteRequest{}
+	this.Term = Term(uint64(r.Uint32()))
+	this.Candidate = MemberID(randStringProtocol(r))
+	this.LastLogIndex = Index(uint64(r.Uint32()))
+	this.LastLogTerm = Term(uint64(r.Uint32()))
+	this.ConfigurationIndex = Index(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedVoteResponse(r randyProtocol, easy bool) *VoteResponse {
+	this := &VoteResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Term = Term(uint64(r.Uint32()))
+	this.Voted = bool(bool(r.Intn(2) == 0))
+	v12 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.RetryAfter = *v12
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedTransferRequest(r randyProtocol, easy bool) *TransferRequest {
+	this := &TransferRequest{}
+	this.Member = MemberID(randStringProtocol(r))
+	this.LastLogIndex = Index(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedTransferResponse(r randyProtocol, easy bool) *TransferResponse {
+	this := &TransferResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedConfirmLeadershipRequest(r randyProtocol, easy bool) *ConfirmLeadershipRequest {
+	this := &ConfirmLeadershipRequest{}
+	this.Term = Term(uint64(r.Uint32()))
+	this.Leader = MemberID(randStringProtocol(r))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedConfirmLeadershipResponse(r randyProtocol, easy bool) *ConfirmLeadershipResponse {
+	this := &ConfirmLeadershipResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Term = Term(uint64(r.Uint32()))
+	this.Confirmed = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedHashRequest(r randyProtocol, easy bool) *HashRequest {
+	this := &HashRequest{}
+	this.FirstIndex = Index(uint64(r.Uint32()))
+	this.LastIndex = Index(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedHashResponse(r randyProtocol, easy bool) *HashResponse {
+	this := &HashResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Message = string(randStringProtocol(r))
+	this.Term = Term(uint64(r.Uint32()))
+	this.Hash = uint64(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedAppendRequest(r randyProtocol, easy bool) *AppendRequest {
+	this := &AppendRequest{}
+	this.Term = Term(uint64(r.Uint32()))
+	this.Leader = MemberID(randStringProtocol(r))
+	this.PrevLogIndex = Index(uint64(r.Uint32()))
+	this.PrevLogTerm = Term(uint64(r.Uint32()))
+	if r.Intn(5) != 0 {
+		v13 := r.Intn(5)
+		this.Entries = make([]*LogEntry, v13)
+		for i := 0; i < v13; i++ {
+			this.Entries[i] = NewPopulatedLogEntry(r, easy)
+		}
+	}
+	this.CommitIndex = Index(uint64(r.Uint32()))
+	v14 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Timestamp = *v14
+	this.LeaderLastIndex = Index(uint64(r.Uint32()))
+	this.LeaderFirstIndex = Index(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedAppendResponse(r randyProtocol, easy bool) *AppendResponse {
+	this := &AppendResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Term = Term(uint64(r.Uint32()))
+	this.Succeeded = bool(bool(r.Intn(2) == 0))
+	this.LastLogIndex = Index(uint64(r.Uint32()))
+	this.Truncated = uint64(uint64(r.Uint32()))
+	this.LastLogTerm = Term(uint64(r.Uint32()))
+	v15 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Timestamp = *v15
+	this.SnapshotRequested = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedInstallRequest(r randyProtocol, easy bool) *InstallRequest {
+	this := &InstallRequest{}
+	this.Term = Term(uint64(r.Uint32()))
+	this.Leader = MemberID(randStringProtocol(r))
+	this.Index = Index(uint64(r.Uint32()))
+	v16 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Timestamp = *v16
+	v17 := r.Intn(100)
+	this.Data = make([]byte, v17)
+	for i := 0; i < v17; i++ {
+		this.Data[i] = byte(r.Intn(256))
+	}
+	this.SnapshotTerm = Term(uint64(r.Uint32()))
+	this.Version = uint32(r.Uint32())
+	this.Offset = uint64(uint64(r.Uint32()))
+	this.Checksum = uint32(r.Uint32())
+	this.ReadOnly = bool(bool(r.Intn(2) == 0))
+	v18 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.ElectionFreeze = *v18
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedInstallResponse(r randyProtocol, easy bool) *InstallResponse {
+	this := &InstallResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Offset = uint64(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedCommandRequest(r randyProtocol, easy bool) *CommandRequest {
+	this := &CommandRequest{}
+	v19 := r.Intn(100)
+	this.Value = make([]byte, v19)
+	for i := 0; i < v19; i++ {
+		this.Value[i] = byte(r.Intn(256))
+	}
+	if r.Intn(5) != 0 {
+		this.Timeout = github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		v20 := r.Intn(10)
+		this.Metadata = make(map[string]string)
+		for i := 0; i < v20; i++ {
+			this.Metadata[randStringProtocol(r)] = randStringProtocol(r)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedCommandResponse(r randyProtocol, easy bool) *CommandResponse {
+	this := &CommandResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Message = string(randStringProtocol(r))
+	this.Leader = MemberID(randStringProtocol(r))
+	this.Term = Term(uint64(r.Uint32()))
+	v21 := r.Intn(10)
+	this.Members = make([]MemberID, v21)
+	for i := 0; i < v21; i++ {
+		this.Members[i] = MemberID(randStringProtocol(r))
+	}
+	v22 := r.Intn(100)
+	this.Output = make([]byte, v22)
+	for i := 0; i < v22; i++ {
+		this.Output[i] = byte(r.Intn(256))
+	}
+	this.Partial = bool(bool(r.Intn(2) == 0))
+	this.ElectionInProgress = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedCommandBatchRequest(r randyProtocol, easy bool) *CommandBatchRequest {
+	this := &CommandBatchRequest{}
+	v23 := r.Intn(10)
+	this.Values = make([][]byte, v23)
+	for i := 0; i < v23; i++ {
+		v24 := r.Intn(100)
+		this.Values[i] = make([]byte, v24)
+		for j := 0; j < v24; j++ {
+			this.Values[i][j] = byte(r.Intn(256))
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedCommandBatchResponse(r randyProtocol, easy bool) *CommandBatchResponse {
+	this := &CommandBatchResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Message = string(randStringProtocol(r))
+	this.Leader = MemberID(randStringProtocol(r))
+	this.Term = Term(uint64(r.Uint32()))
+	v25 := r.Intn(10)
+	this.Members = make([]MemberID, v25)
+	for i := 0; i < v25; i++ {
+		this.Members[i] = MemberID(randStringProtocol(r))
+	}
+	if r.Intn(5) != 0 {
+		v26 := r.Intn(5)
+		this.Results = make([]*CommandResult, v26)
+		for i := 0; i < v26; i++ {
+			this.Results[i] = NewPopulatedCommandResult(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedCommandResult(r randyProtocol, easy bool) *CommandResult {
+	this := &CommandResult{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Message = string(randStringProtocol(r))
+	this.Index = Index(uint64(r.Uint32()))
+	v27 := r.Intn(100)
+	this.Output = make([]byte, v27)
+	for i := 0; i < v27; i++ {
+		this.Output[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedQueryRequest(r randyProtocol, easy bool) *QueryRequest {
+	this := &QueryRequest{}
+	v28 := r.Intn(100)
+	this.Value = make([]byte, v28)
+	for i := 0; i < v28; i++ {
+		this.Value[i] = byte(r.Intn(256))
+	}
+	this.ReadConsistency = ReadConsistency([]int32{0, 1, 2}[r.Intn(3)])
+	this.SequenceNumber = uint64(uint64(r.Uint32()))
+	this.MinAppliedIndex = Index(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedQueryResponse(r randyProtocol, easy bool) *QueryResponse {
+	this := &QueryResponse{}
+	this.Status = ResponseStatus([]int32{0, 1}[r.Intn(2)])
+	this.Error = ResponseError([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}[r.Intn(18)])
+	this.Message = string(randStringProtocol(r))
+	v29 := r.Intn(100)
+	this.Output = make([]byte, v29)
+	for i := 0; i < v29; i++ {
+		this.Output[i] = byte(r.Intn(256))
+	}
+	this.SequenceNumber = uint64(uint64(r.Uint32()))
+	this.ReadRepair = bool(bool(r.Intn(2) == 0))
+	this.StaleBy = Index(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedProgressRequest(r randyProtocol, easy bool) *ProgressRequest {
+	this := &ProgressRequest{}
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+func NewPopulatedProgressResponse(r randyProtocol, easy bool) *ProgressResponse {
+	this := &ProgressResponse{}
+	this.CommitIndex = Index(uint64(r.Uint32()))
+	this.AppliedIndex = Index(uint64(r.Uint32()))
+	this.Lag = Index(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+	}
+	return this
+}
+
+type randyProtocol interface {
+	Float32() float32
+	Float64() float64
+	Int63() int64
+	Int31() int32
+	Uint32() uint32
+	Intn(n int) int
+}
+
+func randUTF8RuneProtocol(r randyProtocol) rune {
+	ru := r.Intn(62)
+	if ru < 10 {
+		return rune(ru + 48)
+	} else if ru < 36 {
+		return rune(ru + 55)
+	}
+	return rune(ru + 61)
+}
+func randStringProtocol(r randyProtocol) string {
+	v30 := r.Intn(100)
+	tmps := make([]rune, v30)
+	for i := 0; i < v30; i++ {
+		tmps[i] = randUTF8RuneProtocol(r)
+	}
+	return string(tmps)
+}
+func randUnrecognizedProtocol(r randyProtocol, maxFieldNumber int) (dAtA []byte) {
+	l := r.Intn(5)
+	for i := 0; i < l; i++ {
+		wire := r.Intn(4)
+		if wire == 3 {
+			wire = 5
+		}
+		fieldNumber := maxFieldNumber + r.Intn(100)
+		dAtA = randFieldProtocol(dAtA, r, fieldNumber, wire)
+	}
+	return dAtA
+}
+func randFieldProtocol(dAtA []byte, r randyProtocol, fieldNumber int, wire int) []byte {
+	key := uint32(fieldNumber)<<3 | uint32(wire)
+	switch wire {
+	case 0:
+		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(key))
+		v31 := r.Int63()
+		if r.Intn(2) == 0 {
+			v31 *= -1
+		}
+		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(v31))
+	case 1:
+		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	case 2:
+		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(key))
+		ll := r.Intn(100)
+		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(ll))
+		for j := 0; j < ll; j++ {
+			dAtA = append(dAtA, byte(r.Intn(256)))
+		}
+	default:
+		dAtA = encodeVarintPopulateProtocol(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	}
+	return dAtA
+}
+func encodeVarintPopulateProtocol(dAtA []byte, v uint64) []byte {
+	for v >= 1<<7 {
+		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
+		v >>= 7
+	}
+	dAtA = append(dAtA, uint8(v))
+	return dAtA
+}
+func (m *JoinRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Member != nil {
+		l = m.Member.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Forwarded {
+		n += 2
+	}
+	return n
+}
+
+func (m *JoinResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovProtocol(uint64(l))
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.CommitIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.CommitIndex))
+	}
+	return n
+}
+
+func (m *ConfigureRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovProtocol(uint64(l))
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ConfigureResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	return n
+}
+
+func (m *ReconfigureRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Member != nil {
+		l = m.Member.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.ElectionTimeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.ElectionTimeout)
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.HeartbeatInterval != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.HeartbeatInterval)
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *ReconfigureResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovProtocol(uint64(l))
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LeaveRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Member != nil {
+		l = m.Member.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *LeaveResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovProtocol(uint64(l))
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetReadOnlyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ReadOnly {
+		n += 2
+	}
+	return n
+}
+
+func (m *SetReadOnlyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	return n
+}
+
+func (m *FreezeElectionsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Duration)
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *FreezeElectionsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Expires)
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *PollRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = len(m.Candidate)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.LastLogIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.LastLogIndex))
+	}
+	if m.LastLogTerm != 0 {
+		n += 1 + sovProtocol(uint64(m.LastLogTerm))
+	}
+	return n
+}
+
+func (m *PollResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.Accepted {
+		n += 2
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.RetryAfter)
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *VoteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = len(m.Candidate)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.LastLogIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.LastLogIndex))
+	}
+	if m.LastLogTerm != 0 {
+		n += 1 + sovProtocol(uint64(m.LastLogTerm))
+	}
+	if m.ConfigurationIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.ConfigurationIndex))
+	}
+	return n
+}
+
+func (m *VoteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.Voted {
+		n += 2
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.RetryAfter)
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *TransferRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Member)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.LastLogIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.LastLogIndex))
+	}
+	return n
+}
+
+func (m *TransferResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	return n
+}
+
+func (m *ConfirmLeadershipRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *ConfirmLeadershipResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.Confirmed {
+		n += 2
+	}
+	return n
+}
+
+func (m *HashRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FirstIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.FirstIndex))
+	}
+	if m.LastIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.LastIndex))
+	}
+	return n
+}
+
+func (m *HashResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.Hash != 0 {
+		n += 1 + sovProtocol(uint64(m.Hash))
+	}
+	return n
+}
+
+func (m *AppendRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.PrevLogIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.PrevLogIndex))
+	}
+	if m.PrevLogTerm != 0 {
+		n += 1 + sovProtocol(uint64(m.PrevLogTerm))
+	}
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	if m.CommitIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.CommitIndex))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.LeaderLastIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.LeaderLastIndex))
+	}
+	if m.LeaderFirstIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.LeaderFirstIndex))
+	}
+	return n
+}
+
+func (m *AppendResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.Succeeded {
+		n += 2
+	}
+	if m.LastLogIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.LastLogIndex))
+	}
+	if m.Truncated != 0 {
+		n += 1 + sovProtocol(uint64(m.Truncated))
+	}
+	if m.LastLogTerm != 0 {
+		n += 1 + sovProtocol(uint64(m.LastLogTerm))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.SnapshotRequested {
+		n += 2
+	}
+	return n
+}
+
+func (m *InstallRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovProtocol(uint64(l))
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.SnapshotTerm != 0 {
+		n += 1 + sovProtocol(uint64(m.SnapshotTerm))
+	}
+	if m.Version != 0 {
+		n += 1 + sovProtocol(uint64(m.Version))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovProtocol(uint64(m.Offset))
+	}
+	if m.Checksum != 0 {
+		n += 1 + sovProtocol(uint64(m.Checksum))
+	}
+	if m.ReadOnly {
+		n += 2
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.ElectionFreeze)
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *InstallResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovProtocol(uint64(m.Offset))
+	}
+	return n
+}
+
+func (m *CommandRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Timeout != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdDuration(*m.Timeout)
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if len(m.Metadata) > 0 {
+		for k, v := range m.Metadata {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovProtocol(uint64(len(k))) + 1 + len(v) + sovProtocol(uint64(len(v)))
+			n += mapEntrySize + 1 + sovProtocol(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *CommandResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if len(m.Members) > 0 {
+		for _, s := range m.Members {
+			l = len(s)
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	l = len(m.Output)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Partial {
+		n += 2
+	}
+	if m.ElectionInProgress {
+		n += 2
+	}
+	return n
+}
+
+func (m *CommandBatchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			l = len(b)
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CommandBatchResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if len(m.Members) > 0 {
+		for _, s := range m.Members {
+			l = len(s)
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CommandResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	l = len(m.Output)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.ReadConsistency != 0 {
+		n += 1 + sovProtocol(uint64(m.ReadConsistency))
+	}
+	if m.SequenceNumber != 0 {
+		n += 1 + sovProtocol(uint64(m.SequenceNumber))
+	}
+	if m.MinAppliedIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.MinAppliedIndex))
+	}
+	return n
+}
+
+func (m *QueryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovProtocol(uint64(m.Status))
+	}
+	if m.Error != 0 {
+		n += 1 + sovProtocol(uint64(m.Error))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	l = len(m.Output)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.SequenceNumber != 0 {
+		n += 1 + sovProtocol(uint64(m.SequenceNumber))
+	}
+	if m.ReadRepair {
+		n += 2
+	}
+	if m.StaleBy != 0 {
+		n += 1 + sovProtocol(uint64(m.StaleBy))
+	}
+	return n
+}
+
+func (m *ProgressRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ProgressResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CommitIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.CommitIndex))
+	}
+	if m.AppliedIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.AppliedIndex))
+	}
+	if m.Lag != 0 {
+		n += 1 + sovProtocol(uint64(m.Lag))
+	}
+	return n
+}
+
+func sovProtocol(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozProtocol(x uint64) (n int) {
+	return sovProtocol(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *JoinRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JoinRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JoinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Member == nil {
+				m.Member = &Member{}
+			}
+			if err := m.Member.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Forwarded", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Forwarded = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JoinResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JoinResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JoinResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= ResponseStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			m.Error = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Error |= ResponseError(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, &Member{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leader = MemberID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitIndex", wireType)
+			}
+			m.CommitIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitIndex |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigureRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigureRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leader = MemberID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, &Member{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigureResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigureResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= ResponseStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			m.Error = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Error |= ResponseError(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReconfigureRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReconfigureRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReconfigureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Member == nil {
+				m.Member = &Member{}
+			}
+			if err := m.Member.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElectionTimeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ElectionTimeout == nil {
+				m.ElectionTimeout = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.ElectionTimeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeartbeatInterval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HeartbeatInterval == nil {
+				m.HeartbeatInterval = new(time.Duration)
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.HeartbeatInterval, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReconfigureResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReconfigureResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReconfigureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= ResponseStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			m.Error = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Error |= ResponseError(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, &Member{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaveRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaveRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Member == nil {
+				m.Member = &Member{}
+			}
+			if err := m.Member.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaveResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= ResponseStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			m.Error = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Error |= ResponseError(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, &Member{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetReadOnlyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetReadOnlyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetReadOnlyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetReadOnlyResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetReadOnlyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetReadOnlyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= ResponseStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			m.Error = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Error |= ResponseError(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FreezeElectionsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FreezeElectionsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FreezeElectionsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Duration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FreezeElectionsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FreezeElectionsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FreezeElectionsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= ResponseStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			m.Error = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Error |= ResponseError(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expires", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Expires, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PollRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PollRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PollRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Candidate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Candidate = MemberID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastLogIndex", wireType)
+			}
+			m.LastLogIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastLogIndex |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastLogTerm", wireType)
+			}
+			m.LastLogTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastLogTerm |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PollResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PollResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PollResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= ResponseStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			m.Error = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Error |= ResponseError(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Accepted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Accepted = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryAfter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.RetryAfter, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VoteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VoteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VoteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Candidate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Candidate = MemberID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastLogIndex", wireType)
+			}
+			m.LastLogIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastLogIndex |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastLogTerm", wireType)
+			}
+			m.LastLogTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastLogTerm |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigurationIndex", wireType)
+			}
+			m.ConfigurationIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfigurationIndex |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VoteResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VoteResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VoteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= ResponseStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			m.Error = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Error |= ResponseError(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Voted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Voted = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryAfter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.RetryAfter, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *QueryResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Status != 0 {
-		n += 1 + sovProtocol(uint64(m.Status))
-	}
-	if m.Error != 0 {
-		n += 1 + sovProtocol(uint64(m.Error))
-	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	l = len(m.Output)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovProtocol(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozProtocol(x uint64) (n int) {
-	return sovProtocol(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *JoinRequest) Unmarshal(dAtA []byte) error {
+func (m *TransferRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4888,17 +10528,17 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JoinRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JoinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -4908,28 +10548,43 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Member == nil {
-				m.Member = &Member{}
+			m.Member = MemberID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastLogIndex", wireType)
 			}
-			if err := m.Member.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.LastLogIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastLogIndex |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4954,7 +10609,7 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JoinResponse) Unmarshal(dAtA []byte) error {
+func (m *TransferResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4977,10 +10632,10 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JoinResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JoinResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5021,26 +10676,60 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.Index = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Index |= Index(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if skippy < 0 {
+				return ErrInvalidLengthProtocol
 			}
-		case 4:
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfirmLeadershipRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfirmLeadershipRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfirmLeadershipRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
@@ -5059,44 +10748,11 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5106,25 +10762,23 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Members = append(m.Members, &Member{})
-			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Leader = MemberID(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5150,7 +10804,7 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigureRequest) Unmarshal(dAtA []byte) error {
+func (m *ConfirmLeadershipResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5173,17 +10827,17 @@ func (m *ConfigureRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigureRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConfirmLeadershipResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConfirmLeadershipResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			m.Term = 0
+			m.Status = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5193,16 +10847,16 @@ func (m *ConfigureRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
+				m.Status |= ResponseStatus(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var stringLen uint64
+			m.Error = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5212,48 +10866,16 @@ func (m *ConfigureRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Error |= ResponseError(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Leader = MemberID(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
-			}
-			m.Index = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Index |= Index(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			var msglen int
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5263,30 +10885,16 @@ func (m *ConfigureRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Term |= Term(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Confirmed", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5296,26 +10904,12 @@ func (m *ConfigureRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Members = append(m.Members, &Member{})
-			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Confirmed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -5340,7 +10934,7 @@ func (m *ConfigureRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigureResponse) Unmarshal(dAtA []byte) error {
+func (m *HashRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5363,17 +10957,17 @@ func (m *ConfigureResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigureResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: HashRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HashRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstIndex", wireType)
 			}
-			m.Status = 0
+			m.FirstIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5383,16 +10977,16 @@ func (m *ConfigureResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= ResponseStatus(b&0x7F) << shift
+				m.FirstIndex |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastIndex", wireType)
 			}
-			m.Error = 0
+			m.LastIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5402,7 +10996,7 @@ func (m *ConfigureResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Error |= ResponseError(b&0x7F) << shift
+				m.LastIndex |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5431,7 +11025,7 @@ func (m *ConfigureResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReconfigureRequest) Unmarshal(dAtA []byte) error {
+func (m *HashResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5454,17 +11048,55 @@ func (m *ReconfigureRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReconfigureRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: HashResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReconfigureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HashResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= ResponseStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			m.Error = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Error |= ResponseError(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5474,33 +11106,29 @@ func (m *ReconfigureRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Member == nil {
-				m.Member = &Member{}
-			}
-			if err := m.Member.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			m.Index = 0
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5510,16 +11138,16 @@ func (m *ReconfigureRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= Index(b&0x7F) << shift
+				m.Term |= Term(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			m.Term = 0
+			m.Hash = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5529,7 +11157,7 @@ func (m *ReconfigureRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
+				m.Hash |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5558,7 +11186,7 @@ func (m *ReconfigureRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReconfigureResponse) Unmarshal(dAtA []byte) error {
+func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5581,17 +11209,17 @@ func (m *ReconfigureResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReconfigureResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: AppendRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReconfigureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AppendRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			m.Status = 0
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5601,16 +11229,16 @@ func (m *ReconfigureResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= ResponseStatus(b&0x7F) << shift
+				m.Term |= Term(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
-			m.Error = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5620,16 +11248,29 @@ func (m *ReconfigureResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Error |= ResponseError(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leader = MemberID(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevLogIndex", wireType)
 			}
-			m.Index = 0
+			m.PrevLogIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5639,16 +11280,16 @@ func (m *ReconfigureResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= Index(b&0x7F) << shift
+				m.PrevLogIndex |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevLogTerm", wireType)
 			}
-			m.Term = 0
+			m.PrevLogTerm = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5658,14 +11299,14 @@ func (m *ReconfigureResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
+				m.PrevLogTerm |= Term(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5692,100 +11333,33 @@ func (m *ReconfigureResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+			m.Entries = append(m.Entries, &LogEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitIndex", wireType)
 			}
-			var msglen int
+			m.CommitIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
 				}
 				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Members = append(m.Members, &Member{})
-			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtocol(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LeaveRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtocol
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LeaveRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitIndex |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5812,13 +11386,48 @@ func (m *LeaveRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Member == nil {
-				m.Member = &Member{}
-			}
-			if err := m.Member.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderLastIndex", wireType)
+			}
+			m.LeaderLastIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderLastIndex |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderFirstIndex", wireType)
+			}
+			m.LeaderFirstIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderFirstIndex |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -5843,7 +11452,7 @@ func (m *LeaveRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
+func (m *AppendResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5866,10 +11475,10 @@ func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LeaveResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: AppendResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AppendResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5912,9 +11521,9 @@ func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
 			}
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			m.Index = 0
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5924,16 +11533,16 @@ func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= Index(b&0x7F) << shift
+				m.Term |= Term(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Succeeded", wireType)
 			}
-			m.Term = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5943,16 +11552,17 @@ func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Succeeded = bool(v != 0)
 		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastLogIndex", wireType)
 			}
-			var msglen int
+			m.LastLogIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5962,28 +11572,52 @@ func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.LastLogIndex |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthProtocol
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Truncated", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
+			m.Truncated = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Truncated |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastLogTerm", wireType)
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.LastLogTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastLogTerm |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 6:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6010,11 +11644,30 @@ func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Members = append(m.Members, &Member{})
-			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotRequested", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SnapshotRequested = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -6039,7 +11692,7 @@ func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PollRequest) Unmarshal(dAtA []byte) error {
+func (m *InstallRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6062,10 +11715,10 @@ func (m *PollRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PollRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: InstallRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PollRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InstallRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6089,7 +11742,7 @@ func (m *PollRequest) Unmarshal(dAtA []byte) error {
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Candidate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6117,13 +11770,13 @@ func (m *PollRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Candidate = MemberID(dAtA[iNdEx:postIndex])
+			m.Leader = MemberID(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastLogIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.LastLogIndex = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6133,16 +11786,16 @@ func (m *PollRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastLogIndex |= Index(b&0x7F) << shift
+				m.Index |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastLogTerm", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
 			}
-			m.LastLogTerm = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6152,69 +11805,83 @@ func (m *PollRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastLogTerm |= Term(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtocol(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PollResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtocol
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			if iNdEx >= l {
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PollResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PollResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotTerm", wireType)
+			}
+			m.SnapshotTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapshotTerm |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			m.Status = 0
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6224,16 +11891,16 @@ func (m *PollResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= ResponseStatus(b&0x7F) << shift
+				m.Version |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
 			}
-			m.Error = 0
+			m.Offset = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6243,16 +11910,16 @@ func (m *PollResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Error |= ResponseError(b&0x7F) << shift
+				m.Offset |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
 			}
-			m.Term = 0
+			m.Checksum = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6262,14 +11929,14 @@ func (m *PollResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
+				m.Checksum |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 10:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Accepted", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -6286,7 +11953,40 @@ func (m *PollResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Accepted = bool(v != 0)
+			m.ReadOnly = bool(v != 0)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElectionFreeze", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.ElectionFreeze, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -6311,7 +12011,7 @@ func (m *PollResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VoteRequest) Unmarshal(dAtA []byte) error {
+func (m *InstallResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6334,17 +12034,17 @@ func (m *VoteRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VoteRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: InstallResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VoteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InstallResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			m.Term = 0
+			m.Status = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6354,48 +12054,16 @@ func (m *VoteRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
+				m.Status |= ResponseStatus(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Candidate", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Candidate = MemberID(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastLogIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			m.LastLogIndex = 0
+			m.Error = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6405,16 +12073,16 @@ func (m *VoteRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastLogIndex |= Index(b&0x7F) << shift
+				m.Error |= ResponseError(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastLogTerm", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
 			}
-			m.LastLogTerm = 0
+			m.Offset = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6424,7 +12092,7 @@ func (m *VoteRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastLogTerm |= Term(b&0x7F) << shift
+				m.Offset |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6453,7 +12121,7 @@ func (m *VoteRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VoteResponse) Unmarshal(dAtA []byte) error {
+func (m *CommandRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6476,17 +12144,17 @@ func (m *VoteResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VoteResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: CommandRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VoteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CommandRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			m.Status = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6496,54 +12164,31 @@ func (m *VoteResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= ResponseStatus(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
 			}
-			m.Error = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Error |= ResponseError(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Term = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Voted", wireType)
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6553,70 +12198,33 @@ func (m *VoteResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Voted = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtocol(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *TransferRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtocol
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Timeout == nil {
+				m.Timeout = new(time.Duration)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(m.Timeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: TransferRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6626,23 +12234,118 @@ func (m *TransferRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Member = MemberID(dAtA[iNdEx:postIndex])
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProtocol
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowProtocol
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowProtocol
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipProtocol(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Metadata[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -6668,7 +12371,7 @@ func (m *TransferRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TransferResponse) Unmarshal(dAtA []byte) error {
+func (m *CommandResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6691,10 +12394,10 @@ func (m *TransferResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TransferResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: CommandResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CommandResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6735,64 +12438,11 @@ func (m *TransferResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtocol(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AppendRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtocol
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AppendRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AppendRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			m.Term = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6802,12 +12452,25 @@ func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
@@ -6839,11 +12502,11 @@ func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Leader = MemberID(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PrevLogIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			m.PrevLogIndex = 0
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6853,16 +12516,16 @@ func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PrevLogIndex |= Index(b&0x7F) << shift
+				m.Term |= Term(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PrevLogTerm", wireType)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
 			}
-			m.PrevLogTerm = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6872,16 +12535,29 @@ func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PrevLogTerm |= Term(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, MemberID(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Output", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6891,31 +12567,31 @@ func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Entries = append(m.Entries, &LogEntry{})
-			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Output = append(m.Output[:0], dAtA[iNdEx:postIndex]...)
+			if m.Output == nil {
+				m.Output = []byte{}
 			}
 			iNdEx = postIndex
-		case 6:
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CommitIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Partial", wireType)
 			}
-			m.CommitIndex = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -6925,11 +12601,32 @@ func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CommitIndex |= Index(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Partial = bool(v != 0)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElectionInProgress", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.ElectionInProgress = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -6954,7 +12651,7 @@ func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AppendResponse) Unmarshal(dAtA []byte) error {
+func (m *CommandBatchRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6970,101 +12667,24 @@ func (m *AppendResponse) Unmarshal(dAtA []byte) error {
 			b := dAtA[iNdEx]
 			iNdEx++
 			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AppendResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AppendResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
-			}
-			m.Status = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Status |= ResponseStatus(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
-			}
-			m.Error = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Error |= ResponseError(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
-			}
-			m.Term = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Succeeded", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Succeeded = bool(v != 0)
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastLogIndex", wireType)
+			if b < 0x80 {
+				break
 			}
-			m.LastLogIndex = 0
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommandBatchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommandBatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7074,11 +12694,24 @@ func (m *AppendResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastLogIndex |= Index(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, make([]byte, postIndex-iNdEx))
+			copy(m.Values[len(m.Values)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7103,7 +12736,7 @@ func (m *AppendResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InstallRequest) Unmarshal(dAtA []byte) error {
+func (m *CommandBatchResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7126,17 +12759,17 @@ func (m *InstallRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InstallRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CommandBatchResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InstallRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CommandBatchResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			m.Term = 0
+			m.Status = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7146,12 +12779,63 @@ func (m *InstallRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
+				m.Status |= ResponseStatus(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			m.Error = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Error |= ResponseError(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
@@ -7183,11 +12867,11 @@ func (m *InstallRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Leader = MemberID(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			m.Index = 0
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7197,16 +12881,16 @@ func (m *InstallRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= Index(b&0x7F) << shift
+				m.Term |= Term(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7216,30 +12900,29 @@ func (m *InstallRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Members = append(m.Members, MemberID(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 5:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7249,24 +12932,24 @@ func (m *InstallRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Results = append(m.Results, &CommandResult{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -7293,7 +12976,7 @@ func (m *InstallRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InstallResponse) Unmarshal(dAtA []byte) error {
+func (m *CommandResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7316,10 +12999,10 @@ func (m *InstallResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InstallResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: CommandResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InstallResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CommandResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7360,6 +13043,91 @@ func (m *InstallResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Output", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Output = append(m.Output[:0], dAtA[iNdEx:postIndex]...)
+			if m.Output == nil {
+				m.Output = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7384,7 +13152,7 @@ func (m *InstallResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CommandRequest) Unmarshal(dAtA []byte) error {
+func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7407,10 +13175,10 @@ func (m *CommandRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CommandRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueryRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CommandRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7427,26 +13195,83 @@ func (m *CommandRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadConsistency", wireType)
+			}
+			m.ReadConsistency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadConsistency |= ReadConsistency(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthProtocol
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNumber", wireType)
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
+			m.SequenceNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SequenceNumber |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinAppliedIndex", wireType)
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.MinAppliedIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinAppliedIndex |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7471,7 +13296,7 @@ func (m *CommandRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CommandResponse) Unmarshal(dAtA []byte) error {
+func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7494,10 +13319,10 @@ func (m *CommandResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CommandResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CommandResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7572,9 +13397,9 @@ func (m *CommandResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Output", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7584,29 +13409,31 @@ func (m *CommandResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Leader = MemberID(dAtA[iNdEx:postIndex])
+			m.Output = append(m.Output[:0], dAtA[iNdEx:postIndex]...)
+			if m.Output == nil {
+				m.Output = []byte{}
+			}
 			iNdEx = postIndex
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNumber", wireType)
 			}
-			m.Term = 0
+			m.SequenceNumber = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7616,16 +13443,16 @@ func (m *CommandResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
+				m.SequenceNumber |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadRepair", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7635,29 +13462,17 @@ func (m *CommandResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Members = append(m.Members, MemberID(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
+			m.ReadRepair = bool(v != 0)
 		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Output", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StaleBy", wireType)
 			}
-			var byteLen int
+			m.StaleBy = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7667,26 +13482,11 @@ func (m *CommandResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.StaleBy |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Output = append(m.Output[:0], dAtA[iNdEx:postIndex]...)
-			if m.Output == nil {
-				m.Output = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7711,7 +13511,7 @@ func (m *CommandResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryRequest) Unmarshal(dAtA []byte) error {
+func (m *ProgressRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7734,65 +13534,12 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProgressRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProgressRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadConsistency", wireType)
-			}
-			m.ReadConsistency = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ReadConsistency |= ReadConsistency(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7817,7 +13564,7 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryResponse) Unmarshal(dAtA []byte) error {
+func (m *ProgressResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7840,17 +13587,17 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProgressResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProgressResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitIndex", wireType)
 			}
-			m.Status = 0
+			m.CommitIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7860,16 +13607,16 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= ResponseStatus(b&0x7F) << shift
+				m.CommitIndex |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedIndex", wireType)
 			}
-			m.Error = 0
+			m.AppliedIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7879,48 +13626,16 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Error |= ResponseError(b&0x7F) << shift
+				m.AppliedIndex |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Message = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Output", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lag", wireType)
 			}
-			var byteLen int
+			m.Lag = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -7930,26 +13645,11 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Lag |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Output = append(m.Output[:0], dAtA[iNdEx:postIndex]...)
-			if m.Output == nil {
-				m.Output = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -7977,6 +13677,7 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 func skipProtocol(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
+	depth := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
@@ -8008,10 +13709,8 @@ func skipProtocol(dAtA []byte) (n int, err error) {
 					break
 				}
 			}
-			return iNdEx, nil
 		case 1:
 			iNdEx += 8
-			return iNdEx, nil
 		case 2:
 			var length int
 			for shift := uint(0); ; shift += 7 {
@@ -8032,55 +13731,30 @@ func skipProtocol(dAtA []byte) (n int, err error) {
 				return 0, ErrInvalidLengthProtocol
 			}
 			iNdEx += length
-			if iNdEx < 0 {
-				return 0, ErrInvalidLengthProtocol
-			}
-			return iNdEx, nil
 		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return 0, ErrIntOverflowProtocol
-					}
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipProtocol(dAtA[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
-				if iNdEx < 0 {
-					return 0, ErrInvalidLengthProtocol
-				}
-			}
-			return iNdEx, nil
+			depth++
 		case 4:
-			return iNdEx, nil
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupProtocol
+			}
+			depth--
 		case 5:
 			iNdEx += 4
-			return iNdEx, nil
 		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthProtocol
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
 	}
-	panic("unreachable")
+	return 0, io.ErrUnexpectedEOF
 }
 
 var (
-	ErrInvalidLengthProtocol = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowProtocol   = fmt.Errorf("proto: integer overflow")
+	ErrInvalidLengthProtocol        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowProtocol          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupProtocol = fmt.Errorf("proto: unexpected end of group")
 )
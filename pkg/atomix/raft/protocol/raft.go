@@ -19,7 +19,10 @@ import (
 	"fmt"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
+	"math/rand"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Status represents the status of a Raft server
@@ -37,22 +40,93 @@ const (
 )
 
 // NewRaft returns a new Raft protocol state struct
-func NewRaft(cluster Cluster, config *config.ProtocolConfig, protocol Client, roles map[RoleType]func(Raft) Role) Raft {
-	return newRaft(cluster, config, protocol, roles, newMemoryMetadataStore())
+func NewRaft(cluster Cluster, config *config.ProtocolConfig, protocol Client, roles map[RoleType]func(Raft) Role, opts ...Option) Raft {
+	return newRaft(cluster, config, protocol, roles, newMemoryMetadataStore(), opts...)
+}
+
+// Option is an option for constructing the Raft protocol state
+type Option func(*options)
+
+// options are the options for constructing the Raft protocol state
+type options struct {
+	logSink  util.LogSink
+	group    GroupID
+	metadata MetadataStore
+}
+
+// WithLogger returns an option that writes the protocol's and roles' logs to the given sink as structured
+// messages with member, role, and term fields. By default, logs are written to the global logrus logger.
+func WithLogger(sink util.LogSink) Option {
+	return func(options *options) {
+		options.logSink = sink
+	}
+}
+
+// WithMetadataStore returns an option that stores the term and vote in the given store. By default, the
+// term and vote are stored in memory and are lost when the process exits.
+func WithMetadataStore(store MetadataStore) Option {
+	return func(options *options) {
+		options.metadata = store
+	}
+}
+
+// WithGroup returns an option that identifies the Raft group to which the node belongs. The group is
+// added to the node's logs and metrics to distinguish it from other groups hosted in the same process.
+func WithGroup(group GroupID) Option {
+	return func(options *options) {
+		options.group = group
+	}
 }
 
 // newRaft returns a new Raft protocol state struct
-func newRaft(cluster Cluster, config *config.ProtocolConfig, protocol Client, roles map[RoleType]func(Raft) Role, store MetadataStore) Raft {
-	return &raft{
-		log:      util.NewNodeLogger(string(cluster.Member())),
-		config:   config,
-		protocol: protocol,
-		status:   StatusStopped,
-		watchers: make([]func(Event), 0),
-		roles:    roles,
-		cluster:  cluster,
-		metadata: store,
+func newRaft(cluster Cluster, config *config.ProtocolConfig, protocol Client, roles map[RoleType]func(Raft) Role, store MetadataStore, opts ...Option) Raft {
+	options := &options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.metadata != nil {
+		store = options.metadata
+	}
+	members := make([]*Member, 0, len(cluster.Members()))
+	for _, memberID := range cluster.Members() {
+		member := cluster.GetMember(memberID)
+		if config.IsWitness(string(memberID)) {
+			member = &Member{
+				MemberID: member.MemberID,
+				Type:     Member_WITNESS,
+				Updated:  member.Updated,
+			}
+		}
+		members = append(members, member)
 	}
+	metrics := newRaftMetrics(cluster.Member(), options.group, config)
+	if config.GetMetrics().GetMetadata() {
+		store = newMetricsMetadataStore(store, metrics)
+	}
+	r := &raft{
+		logSink:         options.logSink,
+		group:           options.group,
+		config:          config,
+		protocol:        protocol,
+		status:          StatusStopped,
+		watchers:        []func(Event){metrics.watch},
+		roles:           roles,
+		cluster:         cluster,
+		metadata:        store,
+		metrics:         metrics,
+		progress:        newProgress(),
+		clock:           newSystemClock(),
+		random:          newRandom(),
+		authorizers:     newAuthorizerChain(),
+		electionLimiter: newRateLimiter(),
+		roundTrips:      newRoundTripTracker(),
+		votes:           newMemoryVoteRecord(),
+		configuration: &Configuration{
+			Members: members,
+		},
+	}
+	r.log = r.NewLogger("")
+	return r
 }
 
 // MemberID is the ID of a Raft cluster member
@@ -67,6 +141,7 @@ type Term uint64
 // Raft is an interface for managing the state of the Raft consensus protocol
 type Raft interface {
 	Server
+	ProgressServer
 
 	// Init initializes the Raft state
 	Init()
@@ -74,6 +149,14 @@ type Raft interface {
 	// Watch watches the Raft protocol state for changes
 	Watch(func(Event))
 
+	// AuthorizeCommands adds an authorizer to the chain of authorizers invoked before commands are appended
+	// to the log. Commands are appended only if allowed by all the authorizers in the chain.
+	AuthorizeCommands(authorizer CommandAuthorizer)
+
+	// AuthorizeCommand invokes the chain of command authorizers for the given request, returning the error of
+	// the first authorizer to deny the command. The chain may be invoked without holding a lock on the Raft state.
+	AuthorizeCommand(request *CommandRequest) error
+
 	// Role is the current role
 	Role() RoleType
 
@@ -83,15 +166,59 @@ type Raft interface {
 	// Config returns the Raft protocol configuration
 	Config() *config.ProtocolConfig
 
+	// Metrics returns the Raft protocol metrics
+	Metrics() *RaftMetrics
+
+	// Clock returns the clock used to exchange wall-clock time with other members
+	Clock() Clock
+
+	// SetClock sets the clock used to exchange wall-clock time with other members.
+	// The clock must be set before the protocol is started.
+	SetClock(clock Clock)
+
+	// SetRandomSource sets the source of randomness used to randomize election timeouts. Seeding the source
+	// makes election timeouts reproducible, e.g. in tests. The source must be set before the protocol is started.
+	SetRandomSource(source rand.Source)
+
+	// RandomDuration returns a pseudo-random duration in [0, n) drawn from the protocol's source of randomness.
+	// Durations may be drawn without holding a lock on the Raft state.
+	RandomDuration(n time.Duration) time.Duration
+
+	// SetAuditSink sets the sink to which records of state transitions are passed.
+	// The sink must be set before the protocol is started.
+	SetAuditSink(sink AuditSink)
+
 	// Member returns the local member ID
 	Member() MemberID
 
-	// Members returns a list of all members in the Raft cluster
+	// Members returns a list of all members in the Raft cluster, including learners
 	Members() []MemberID
 
+	// VotingMembers returns the members that are voters in the latest configuration.
+	// Learners replicate the log but do not vote or count towards quorums.
+	VotingMembers() []MemberID
+
+	// IsVoter returns whether the given member is a voter in the latest configuration. During a joint
+	// configuration change, members that are voters in either the old or the new configuration are voters.
+	IsVoter(memberID MemberID) bool
+
+	// VoterSets returns the sets of voting members in the latest configuration. A quorum requires a majority of
+	// each set. A single set is returned unless a joint configuration change is in progress, in which case the
+	// voters of the new configuration and the voters of the old configuration are returned.
+	VoterSets() [][]MemberID
+
+	// IsWitness returns whether the given member is a witness in the latest configuration. Witnesses vote in
+	// elections and count towards quorums but are not sent commands and can't be elected leader.
+	IsWitness(memberID MemberID) bool
+
 	// GetMember returns a RaftMember by ID
 	GetMember(memberID MemberID) *Member
 
+	// NewLogger returns a logger for the given role, or for the protocol if the role is empty. If a log sink
+	// was provided with WithLogger, the logger writes structured messages with member, role, and term fields
+	// to the sink.
+	NewLogger(role RoleType) util.Logger
+
 	// Client returns the Raft messaging protocol
 	Protocol() Client
 
@@ -107,20 +234,146 @@ type Raft interface {
 	// SetLeader sets the current leader
 	SetLeader(leader *MemberID) error
 
+	// LastLeader returns the most recently known leader. Unlike the current leader, the last leader is
+	// retained when the term changes, so it may be returned while an election is in progress.
+	LastLeader() *MemberID
+
+	// LastElection returns the time at which the local member most recently learned of a new leader
+	LastElection() time.Time
+
 	// LastVotedFor returns the last member voted for by this node
 	LastVotedFor() *MemberID
 
 	// SetLastVotedFor sets the last member voted for by this node
 	SetLastVotedFor(memberID MemberID) error
 
+	// SetTermAndVote increases the term to the given term and votes for the given member in the new term,
+	// persisting the term and vote atomically in a single metadata store write
+	SetTermAndVote(term Term, memberID MemberID) error
+
 	// CommitIndex returns the current commit index
 	CommitIndex() Index
 
 	// SetCommitIndex sets the highest known commit index
 	SetCommitIndex(index Index)
 
-	// Commit sets the persisted commit index
-	Commit(index Index) Index
+	// Commit sets the persisted commit index, returning the previous commit index. The commit index never
+	// advances beyond the given index of the last entry in the local log: a greater index is clamped to the
+	// last index or discarded according to the configured commit overflow policy.
+	Commit(index Index, lastIndex Index) Index
+
+	// SetAppliedIndex sets the index of the last entry applied to the state machine.
+	// The applied index may be set without holding a lock on the Raft state.
+	SetAppliedIndex(index Index)
+
+	// AppliedIndex returns the index of the last entry applied to the state machine.
+	// The applied index may be read without holding a lock on the Raft state.
+	AppliedIndex() Index
+
+	// SetLag sets the number of entries by which the local log trails the leader's log, as estimated from
+	// the leader's last log index. The lag may be set without holding a lock on the Raft state.
+	SetLag(lag Index)
+
+	// Lag returns the number of entries by which the local log trails the leader's log.
+	// The lag may be read without holding a lock on the Raft state.
+	Lag() Index
+
+	// Heartbeat records a successful heartbeat carrying the leader's commit index. Followers record a heartbeat
+	// for each append request from the leader they accept, and the leader records a heartbeat each time a quorum
+	// acknowledges it. Heartbeats may be recorded without holding a lock on the Raft state.
+	Heartbeat(leaderCommitIndex Index)
+
+	// Health returns the health of the node, including whether it's part of a quorum, its commit lag behind
+	// the leader, and the time since its last successful heartbeat. The caller must hold a read lock.
+	Health() Health
+
+	// ApplyStalled returns whether the state machine has failed to apply committed entries within
+	// the configured apply stall timeout. If no stall timeout is configured, the apply loop is never
+	// considered stalled.
+	ApplyStalled() bool
+
+	// QuorumIndex returns the highest index known by the leader to be replicated to a quorum. The quorum
+	// index may exceed the commit index when entries from prior terms have not yet been committed.
+	QuorumIndex() Index
+
+	// SetQuorumIndex sets the highest index known by the leader to be replicated to a quorum
+	SetQuorumIndex(index Index)
+
+	// Configuration returns the committed configuration and the pending configuration, if any
+	Configuration() (*Configuration, *Configuration)
+
+	// SetConfiguration sets the pending configuration appended to the log.
+	// The configuration becomes the committed configuration once its index is committed. A nil
+	// configuration discards the pending configuration.
+	SetConfiguration(configuration *Configuration)
+
+	// IsReadOnly returns whether the cluster is in read-only mode.
+	// The read-only mode takes effect once it's appended to the log, before it is committed.
+	IsReadOnly() bool
+
+	// ReadOnlyMode returns the committed read-only mode, ignoring any pending mode
+	ReadOnlyMode() bool
+
+	// SetReadOnlyMode sets the read-only mode appended to the log at the given index
+	SetReadOnlyMode(index Index, readOnly bool)
+
+	// DiscardReadOnlyMode discards a pending read-only mode appended after the given index
+	DiscardReadOnlyMode(index Index)
+
+	// ElectionsFrozen returns whether elections are frozen cluster-wide.
+	// The election freeze takes effect once it's committed and lifts automatically once it expires.
+	ElectionsFrozen() bool
+
+	// ElectionFreeze returns the time remaining until the committed election freeze expires
+	ElectionFreeze() time.Duration
+
+	// SetElectionFreeze sets the election freeze appended to the log at the given index. The freeze expires
+	// the given duration after it's committed by the local member, measured by the local clock, and is capped
+	// at the configured maximum election freeze. A zero duration lifts the freeze.
+	SetElectionFreeze(index Index, duration time.Duration)
+
+	// DiscardElectionFreeze discards a pending election freeze appended after the given index
+	DiscardElectionFreeze(index Index)
+
+	// SetTiming sets the election timeout and heartbeat interval appended to the log at the given index.
+	// The timing replaces the configured timing once it's committed.
+	SetTiming(index Index, electionTimeout time.Duration, heartbeatInterval time.Duration)
+
+	// ConfiguredElectionTimeout returns the committed election timeout, or the configured election timeout
+	// if no timing change has been committed. The timeout may be read without holding a lock on the Raft state.
+	ConfiguredElectionTimeout() time.Duration
+
+	// HeartbeatInterval returns the committed heartbeat interval, or the configured heartbeat interval
+	// if no timing change has been committed. The interval may be read without holding a lock on the Raft state.
+	HeartbeatInterval() time.Duration
+
+	// DiscardTiming discards a pending timing change appended after the given index
+	DiscardTiming(index Index)
+
+	// ElectionBackoff records a poll or vote request from the given member and returns the time the member
+	// must wait before it may make another request if it has exceeded the configured election rate limit,
+	// or 0 if the request is allowed. The backoff may be computed without holding a lock on the Raft state.
+	ElectionBackoff(member MemberID) time.Duration
+
+	// ObserveRoundTrip records the round trip time of a heartbeat, poll, or vote request to the given member.
+	// Round trips may be recorded without holding a lock on the Raft state.
+	ObserveRoundTrip(member MemberID, rtt time.Duration)
+
+	// ElectionTimeout returns the base election timeout. If adaptive election timeouts are enabled, the timeout
+	// is a multiple of the maximum round trip time recently observed to any member, bounded by the configured
+	// election timeout and the max adaptive election timeout. Otherwise, the configured election timeout is
+	// returned. The timeout may be computed without holding a lock on the Raft state.
+	ElectionTimeout() time.Duration
+
+	// ReportTruncation notifies watchers that the given follower truncated the given number of entries from its log
+	ReportTruncation(member MemberID, truncated uint64)
+
+	// IsConsistent returns whether the local log is believed to be consistent with the leader's log.
+	// A member whose log has been found to diverge from the leader's log must not serve stale reads.
+	IsConsistent() bool
+
+	// SetConsistent sets whether the local log is believed to be consistent with the leader's log
+	SetConsistent(consistent bool)
 
 	// WriteLock acquires a write lock on the state
 	WriteLock()
@@ -137,7 +390,12 @@ type Raft interface {
 	// SetRole sets the protocol's current role
 	SetRole(role RoleType)
 
-	// Close closes the Raft state
+	// TransitionRole transitions to the given role if the transition was requested in the current term
+	// Transitions requested in an older term are discarded, and false is returned. The caller must hold the write lock.
+	TransitionRole(role RoleType, term Term) bool
+
+	// Close stops the current role and closes the Raft state. Once closed, role transitions are discarded
+	// and the term and vote can no longer be changed.
 	Close() error
 }
 
@@ -148,6 +406,10 @@ type Event struct {
 	Role   RoleType
 	Term   Term
 	Leader *MemberID
+	// Member is the follower that truncated its log for truncation events
+	Member MemberID
+	// Truncated is the number of entries truncated from the follower's log for truncation events
+	Truncated uint64
 }
 
 // EventType is a Raft protocol state change event type
@@ -165,6 +427,9 @@ const (
 
 	// EventTypeLeader is a leader change event
 	EventTypeLeader EventType = "Leader"
+
+	// EventTypeTruncation is a follower log truncation event
+	EventTypeTruncation EventType = "Truncation"
 )
 
 // RoleType is the name of a role
@@ -199,50 +464,128 @@ type Role interface {
 
 // raft is the default implementation of the Raft protocol state
 type raft struct {
+	// electionTimeout and heartbeatInterval are the committed timing in nanoseconds, or 0 if no timing
+	// change has been committed, and must be accessed atomically
+	electionTimeout   int64
+	heartbeatInterval int64
+
+	// logTerm mirrors the current term for loggers and must be accessed atomically
+	logTerm          uint64
 	log              util.Logger
+	logSink          util.LogSink
+	group            GroupID
 	status           Status
 	config           *config.ProtocolConfig
 	protocol         Client
 	metadata         MetadataStore
+	votes            VoteRecord
+	metrics          *RaftMetrics
+	clock            Clock
+	random           *random
+	auditSink        AuditSink
 	watchers         []func(Event)
+	authorizers      *authorizerChain
+	electionLimiter  *rateLimiter
+	roundTrips       *roundTripTracker
 	roles            map[RoleType]func(Raft) Role
 	role             Role
+	transitioning    bool
+	pendingRole      *roleTransition
+	closed           bool
 	term             Term
 	leader           *MemberID
+	lastLeader       *MemberID
+	lastElection     time.Time
 	lastVotedFor     *MemberID
 	firstCommitIndex *Index
 	commitIndex      Index
+	quorumIndex      Index
+	progress         *progress
+	configuration    *Configuration
+	pending          *Configuration
+	readOnly         bool
+	pendingReadOnly  *readOnlyMode
+	freeze           time.Time
+	pendingFreeze    *electionFreeze
+	pendingTiming    *timingChange
+	inconsistent     bool
 	cluster          Cluster
+	lockTime         time.Time
 	mu               sync.RWMutex
 }
 
 func (r *raft) Init() {
 	term := r.metadata.LoadTerm()
 	if term != nil {
-		r.term = *term
+		r.setTerm(*term)
 	}
 	r.lastVotedFor = r.metadata.LoadVote()
+	r.readOnly = r.metadata.LoadReadOnly()
+	if r.config.GetVerifyVotes() {
+		r.restoreVote()
+	}
 	r.setStatus(StatusRunning)
 	r.SetRole(RoleFollower)
 }
 
+// restoreVote cross-checks the term and vote loaded from the metadata store against the vote record,
+// restoring the recorded vote if the metadata store lost or changed the vote
+func (r *raft) restoreVote() {
+	if lastTerm := r.votes.LastTerm(); lastTerm > r.term {
+		r.log.Warn("Loaded term %d is behind the last voted term %d; restoring the recorded vote", r.term, lastTerm)
+		r.setTerm(lastTerm)
+		r.lastVotedFor = r.votes.LoadVote(lastTerm)
+		if err := r.metadata.StoreTermAndVote(r.term, r.lastVotedFor); err != nil {
+			r.log.Error("Failed to store the restored term and vote", err)
+		}
+	} else if vote := r.votes.LoadVote(r.term); vote != nil && (r.lastVotedFor == nil || *r.lastVotedFor != *vote) {
+		r.log.Warn("Loaded vote %v does not match the vote for %s recorded in term %d; restoring the recorded vote", r.lastVotedFor, *vote, r.term)
+		r.lastVotedFor = vote
+		if err := r.metadata.StoreVote(vote); err != nil {
+			r.log.Error("Failed to store the restored vote", err)
+		}
+	}
+}
+
 func (r *raft) Watch(watcher func(Event)) {
 	r.watchers = append(r.watchers, watcher)
 }
 
+func (r *raft) AuthorizeCommands(authorizer CommandAuthorizer) {
+	r.authorizers.add(authorizer)
+}
+
+func (r *raft) AuthorizeCommand(request *CommandRequest) error {
+	return r.authorizers.authorize(request)
+}
+
 func (r *raft) notify(eventType EventType) {
-	event := Event{
+	r.publish(r.newEvent(eventType))
+}
+
+func (r *raft) newEvent(eventType EventType) Event {
+	return Event{
 		Type:   eventType,
 		Status: r.status,
 		Role:   r.Role(),
 		Term:   r.term,
 		Leader: r.leader,
 	}
+}
+
+func (r *raft) publish(event Event) {
 	for _, watcher := range r.watchers {
 		watcher(event)
 	}
 }
 
+func (r *raft) ReportTruncation(member MemberID, truncated uint64) {
+	event := r.newEvent(EventTypeTruncation)
+	event.Member = member
+	event.Truncated = truncated
+	r.publish(event)
+}
+
 func (r *raft) Status() Status {
 	return r.status
 }
@@ -260,6 +603,43 @@ func (r *raft) Config() *config.ProtocolConfig {
 	return r.config
 }
 
+func (r *raft) Metrics() *RaftMetrics {
+	return r.metrics
+}
+
+func (r *raft) Clock() Clock {
+	return r.clock
+}
+
+func (r *raft) SetClock(clock Clock) {
+	r.clock = clock
+}
+
+func (r *raft) SetRandomSource(source rand.Source) {
+	r.random = newRandomWithSource(source)
+}
+
+func (r *raft) RandomDuration(n time.Duration) time.Duration {
+	return r.random.duration(n)
+}
+
+func (r *raft) SetAuditSink(sink AuditSink) {
+	r.auditSink = sink
+}
+
+// audit passes a record of a state transition to the audit sink if one is set
+func (r *raft) audit(record AuditRecord) {
+	if r.auditSink == nil {
+		return
+	}
+	record.Time = time.Now()
+	record.Member = r.Member()
+	record.Term = r.term
+	if err := r.auditSink.Record(record); err != nil {
+		r.log.Error("Failed to record %s transition", record.Type, err)
+	}
+}
+
 func (r *raft) Protocol() Client {
 	return r.protocol
 }
@@ -272,6 +652,75 @@ func (r *raft) Members() []MemberID {
 	return r.cluster.Members()
 }
 
+func (r *raft) VotingMembers() []MemberID {
+	members := make([]MemberID, 0, len(r.cluster.Members()))
+	for _, member := range r.cluster.Members() {
+		if r.IsVoter(member) {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func (r *raft) IsVoter(memberID MemberID) bool {
+	// Members are voters in the latest configuration appended to the log, whether or not it's committed.
+	configuration := r.latestConfiguration()
+	if configuration == nil {
+		return true
+	}
+	return isVoter(configuration.Members, memberID) || isVoter(configuration.OldMembers, memberID)
+}
+
+func (r *raft) VoterSets() [][]MemberID {
+	configuration := r.latestConfiguration()
+	if configuration == nil || len(configuration.OldMembers) == 0 {
+		return [][]MemberID{r.VotingMembers()}
+	}
+	return [][]MemberID{getVoters(configuration.Members), getVoters(configuration.OldMembers)}
+}
+
+func (r *raft) IsWitness(memberID MemberID) bool {
+	configuration := r.latestConfiguration()
+	if configuration == nil {
+		return false
+	}
+	for _, member := range configuration.Members {
+		if member.MemberID == memberID {
+			return member.Type == Member_WITNESS
+		}
+	}
+	return false
+}
+
+// latestConfiguration returns the latest configuration appended to the log, whether or not it's committed
+func (r *raft) latestConfiguration() *Configuration {
+	if r.pending != nil {
+		return r.pending
+	}
+	return r.configuration
+}
+
+// isVoter returns whether the given member is a voter in the given members
+func isVoter(members []*Member, memberID MemberID) bool {
+	for _, member := range members {
+		if member.MemberID == memberID {
+			return member.IsVoter()
+		}
+	}
+	return false
+}
+
+// getVoters returns the IDs of the voters in the given members
+func getVoters(members []*Member) []MemberID {
+	voters := make([]MemberID, 0, len(members))
+	for _, member := range members {
+		if member.IsVoter() {
+			voters = append(voters, member.MemberID)
+		}
+	}
+	return voters
+}
+
 func (r *raft) GetMember(memberID MemberID) *Member {
 	return r.cluster.GetMember(memberID)
 }
@@ -285,35 +734,86 @@ func (r *raft) Term() Term {
 }
 
 func (r *raft) SetTerm(term Term) error {
-	if term < r.term {
+	if r.closed {
+		return fmt.Errorf("cannot update term to %d: state is closed", term)
+	} else if term < r.term {
 		return fmt.Errorf("cannot decrease term %d to %d", r.term, term)
 	} else if term > r.term {
-		r.term = term
+		// Persist the term and clear the vote in a single write before updating the in-memory state. If the
+		// store fails, the term is not updated so the member cannot act in a term it may forget after a crash,
+		// and a crash cannot persist the new term with the vote cast in the previous term.
+		if err := r.metadata.StoreTermAndVote(term, nil); err != nil {
+			return fmt.Errorf("cannot update term to %d: %s", term, err)
+		}
+		r.setTerm(term)
 		r.leader = nil
 		r.lastVotedFor = nil
-		r.metadata.StoreTerm(term)
-		r.metadata.StoreVote(r.lastVotedFor)
 		r.notify(EventTypeTerm)
+		r.audit(AuditRecord{Type: AuditRecordTerm})
 	}
 	return nil
 }
 
+// setTerm sets the current term
+func (r *raft) setTerm(term Term) {
+	r.term = term
+	atomic.StoreUint64(&r.logTerm, uint64(term))
+}
+
+func (r *raft) NewLogger(role RoleType) util.Logger {
+	if r.logSink == nil {
+		node := string(r.cluster.Member())
+		if r.group != "" {
+			node = fmt.Sprintf("%s/%s", node, r.group)
+		}
+		if role == "" {
+			return util.NewNodeLogger(node)
+		}
+		return util.NewRoleLogger(node, string(role))
+	}
+	fields := util.Fields{
+		util.MemberField: string(r.cluster.Member()),
+		util.TermField: func() interface{} {
+			return atomic.LoadUint64(&r.logTerm)
+		},
+	}
+	if r.group != "" {
+		fields[util.GroupField] = string(r.group)
+	}
+	if role != "" {
+		fields[util.RoleField] = string(role)
+	}
+	return util.NewSinkLogger(r.logSink, fields)
+}
+
 func (r *raft) Leader() *MemberID {
 	return r.leader
 }
 
+func (r *raft) LastLeader() *MemberID {
+	return r.lastLeader
+}
+
+func (r *raft) LastElection() time.Time {
+	return r.lastElection
+}
+
 func (r *raft) SetLeader(leader *MemberID) error {
 	if r.leader == nil && leader != nil {
 		// If the leader is being set for the first time, verify it's a member of the cluster configuration
 		if r.GetMember(*leader) != nil {
 			r.leader = leader
+			r.lastLeader = leader
+			r.lastElection = time.Now()
 			r.notify(EventTypeLeader)
+			r.audit(AuditRecord{Type: AuditRecordLeader, Leader: leader})
 		} else {
 			return fmt.Errorf("unknown member %+v", leader)
 		}
 	} else if r.leader != nil && leader == nil {
 		r.leader = nil
 		r.notify(EventTypeLeader)
+		r.audit(AuditRecord{Type: AuditRecordLeader})
 	} else if r.leader != nil && leader != nil && r.leader != leader {
 		return fmt.Errorf("cannot change leader %+v to %+v", r.leader, leader)
 	}
@@ -325,6 +825,10 @@ func (r *raft) LastVotedFor() *MemberID {
 }
 
 func (r *raft) SetLastVotedFor(memberID MemberID) error {
+	if r.closed {
+		return fmt.Errorf("cannot vote for %s: state is closed", memberID)
+	}
+
 	// If we've already voted for another candidate in this term then the last voted for candidate cannot be overridden.
 	if r.lastVotedFor != nil && *r.lastVotedFor != memberID {
 		return fmt.Errorf("already voted for %+v", r.lastVotedFor)
@@ -335,8 +839,52 @@ func (r *raft) SetLastVotedFor(memberID MemberID) error {
 		return fmt.Errorf("unknown candidate %s", memberID)
 	}
 
+	// If votes are verified, record the vote before storing it to ensure only one vote is cast in the term.
+	if r.config.GetVerifyVotes() {
+		if err := r.votes.RecordVote(r.term, memberID); err != nil {
+			return err
+		}
+	}
+
+	// Persist the vote before updating the in-memory state to ensure a vote is never granted unless it's
+	// durably stored.
+	if err := r.metadata.StoreVote(&memberID); err != nil {
+		return fmt.Errorf("cannot vote for %s: %s", memberID, err)
+	}
 	r.lastVotedFor = &memberID
-	r.metadata.StoreVote(&memberID)
+	r.audit(AuditRecord{Type: AuditRecordVote, Candidate: &memberID})
+	r.log.Debug("Voted for %+v", memberID)
+	return nil
+}
+
+func (r *raft) SetTermAndVote(term Term, memberID MemberID) error {
+	if r.closed {
+		return fmt.Errorf("cannot update term to %d and vote for %s: state is closed", term, memberID)
+	} else if term <= r.term {
+		return fmt.Errorf("cannot increase term %d to %d", r.term, term)
+	}
+
+	// Verify the candidate is a member of the cluster.
+	if r.GetMember(memberID) == nil {
+		return fmt.Errorf("unknown candidate %s", memberID)
+	}
+
+	// If votes are verified, record the vote before storing it to ensure only one vote is cast in the term.
+	if r.config.GetVerifyVotes() {
+		if err := r.votes.RecordVote(term, memberID); err != nil {
+			return err
+		}
+	}
+
+	if err := r.metadata.StoreTermAndVote(term, &memberID); err != nil {
+		return fmt.Errorf("cannot update term to %d and vote for %s: %s", term, memberID, err)
+	}
+	r.setTerm(term)
+	r.leader = nil
+	r.lastVotedFor = &memberID
+	r.notify(EventTypeTerm)
+	r.audit(AuditRecord{Type: AuditRecordTerm})
+	r.audit(AuditRecord{Type: AuditRecordVote, Candidate: &memberID})
 	r.log.Debug("Voted for %+v", memberID)
 	return nil
 }
@@ -351,10 +899,38 @@ func (r *raft) SetCommitIndex(index Index) {
 	}
 }
 
-func (r *raft) Commit(index Index) Index {
+func (r *raft) Commit(index Index, lastIndex Index) Index {
 	prevIndex := r.commitIndex
+	if index > lastIndex {
+		if r.config.GetCommitOverflowPolicy() == config.CommitOverflowPolicy_DISCARD {
+			r.log.Warn("Discarded commit index %d: index is greater than the last log index %d", index, lastIndex)
+			return prevIndex
+		}
+		r.log.Warn("Clamped commit index %d to the last log index %d", index, lastIndex)
+		index = lastIndex
+	}
 	if index > prevIndex {
 		r.commitIndex = index
+		r.progress.setCommitIndex(index)
+		r.metrics.SetCommitIndex(index)
+		if r.pending != nil && r.pending.Index <= index {
+			r.log.Debug("Committed configuration %d", r.pending.Index)
+			r.configuration = r.pending
+			r.pending = nil
+			r.audit(AuditRecord{Type: AuditRecordConfiguration, Configuration: r.configuration, Committed: true})
+		}
+		if r.pendingReadOnly != nil && r.pendingReadOnly.index <= index {
+			r.log.Debug("Committed read-only mode %t at %d", r.pendingReadOnly.readOnly, r.pendingReadOnly.index)
+			r.commitReadOnly(r.pendingReadOnly.readOnly)
+		}
+		if r.pendingFreeze != nil && r.pendingFreeze.index <= index {
+			r.log.Debug("Committed election freeze for %s at %d", r.pendingFreeze.duration, r.pendingFreeze.index)
+			r.commitFreeze(r.pendingFreeze.duration)
+		}
+		if r.pendingTiming != nil && r.pendingTiming.index <= index {
+			r.applyTiming(r.pendingTiming)
+			r.pendingTiming = nil
+		}
 		if r.firstCommitIndex != nil && index >= *r.firstCommitIndex {
 			r.setStatus(StatusReady)
 		}
@@ -362,16 +938,280 @@ func (r *raft) Commit(index Index) Index {
 	return prevIndex
 }
 
+func (r *raft) QuorumIndex() Index {
+	return r.quorumIndex
+}
+
+func (r *raft) SetQuorumIndex(index Index) {
+	r.quorumIndex = index
+}
+
+func (r *raft) SetAppliedIndex(index Index) {
+	r.progress.setAppliedIndex(index)
+}
+
+func (r *raft) AppliedIndex() Index {
+	return r.progress.get().AppliedIndex
+}
+
+func (r *raft) SetLag(lag Index) {
+	r.progress.setLag(lag)
+}
+
+func (r *raft) Lag() Index {
+	return r.progress.get().Lag
+}
+
+func (r *raft) ApplyStalled() bool {
+	timeout := r.config.GetApply().GetStallTimeout()
+	if timeout == nil || *timeout <= 0 {
+		return false
+	}
+	return r.progress.stalled(*timeout)
+}
+
+func (r *raft) Progress(ctx context.Context, request *ProgressRequest, ch chan<- *ProgressResponse) error {
+	r.progress.watch(ctx, ch)
+	return nil
+}
+
+func (r *raft) Configuration() (*Configuration, *Configuration) {
+	return r.configuration, r.pending
+}
+
+func (r *raft) SetConfiguration(configuration *Configuration) {
+	if configuration != nil && configuration.Index <= r.commitIndex {
+		r.configuration = configuration
+		r.pending = nil
+		r.audit(AuditRecord{Type: AuditRecordConfiguration, Configuration: configuration, Committed: true})
+	} else {
+		r.pending = configuration
+		if configuration != nil {
+			r.audit(AuditRecord{Type: AuditRecordConfiguration, Configuration: configuration})
+		}
+	}
+}
+
+// readOnlyMode is a read-only mode change appended to the log
+type readOnlyMode struct {
+	index    Index
+	readOnly bool
+}
+
+func (r *raft) IsReadOnly() bool {
+	if r.pendingReadOnly != nil {
+		return r.pendingReadOnly.readOnly
+	}
+	return r.readOnly
+}
+
+func (r *raft) ReadOnlyMode() bool {
+	return r.readOnly
+}
+
+func (r *raft) SetReadOnlyMode(index Index, readOnly bool) {
+	if index <= r.commitIndex {
+		r.commitReadOnly(readOnly)
+	} else {
+		r.pendingReadOnly = &readOnlyMode{
+			index:    index,
+			readOnly: readOnly,
+		}
+	}
+}
+
+// commitReadOnly sets the committed read-only mode, persisting the mode to the metadata store to restore it
+// on restart, since the entry that changed the mode may be compacted
+func (r *raft) commitReadOnly(readOnly bool) {
+	r.readOnly = readOnly
+	r.pendingReadOnly = nil
+	if err := r.metadata.StoreReadOnly(readOnly); err != nil {
+		r.log.Error("Failed to store the read-only mode", err)
+	}
+}
+
+func (r *raft) DiscardReadOnlyMode(index Index) {
+	if r.pendingReadOnly != nil && r.pendingReadOnly.index > index {
+		r.pendingReadOnly = nil
+	}
+}
+
+// electionFreeze is an election freeze appended to the log
+type electionFreeze struct {
+	index    Index
+	duration time.Duration
+}
+
+func (r *raft) ElectionsFrozen() bool {
+	return time.Now().Before(r.freeze)
+}
+
+func (r *raft) ElectionFreeze() time.Duration {
+	if remaining := time.Until(r.freeze); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+func (r *raft) SetElectionFreeze(index Index, duration time.Duration) {
+	if index <= r.commitIndex {
+		r.commitFreeze(duration)
+	} else {
+		r.pendingFreeze = &electionFreeze{
+			index:    index,
+			duration: duration,
+		}
+	}
+}
+
+// commitFreeze sets the local expiration of the committed election freeze. Each member computes the expiration
+// from its own clock, so the freeze does not depend on the clocks of members being synchronized.
+func (r *raft) commitFreeze(duration time.Duration) {
+	r.pendingFreeze = nil
+	if duration <= 0 {
+		r.freeze = time.Time{}
+		return
+	}
+	if maxDuration := r.config.GetMaxElectionFreezeOrDefault(); duration > maxDuration {
+		duration = maxDuration
+	}
+	r.freeze = time.Now().Add(duration)
+}
+
+func (r *raft) DiscardElectionFreeze(index Index) {
+	if r.pendingFreeze != nil && r.pendingFreeze.index > index {
+		r.pendingFreeze = nil
+	}
+}
+
+// timingChange is a change to the election timeout and heartbeat interval appended to the log
+type timingChange struct {
+	index             Index
+	electionTimeout   time.Duration
+	heartbeatInterval time.Duration
+}
+
+func (r *raft) SetTiming(index Index, electionTimeout time.Duration, heartbeatInterval time.Duration) {
+	timing := &timingChange{
+		index:             index,
+		electionTimeout:   electionTimeout,
+		heartbeatInterval: heartbeatInterval,
+	}
+	if index <= r.commitIndex {
+		r.applyTiming(timing)
+		r.pendingTiming = nil
+	} else {
+		r.pendingTiming = timing
+	}
+}
+
+func (r *raft) DiscardTiming(index Index) {
+	if r.pendingTiming != nil && r.pendingTiming.index > index {
+		r.pendingTiming = nil
+	}
+}
+
+// applyTiming replaces the configured election timeout and heartbeat interval with the given timing.
+// The timing is stored separately from the shared configuration so it may be read without the lock.
+// Roles read the timing from the configuration, so the timing takes effect the next time a timer is reset.
+func (r *raft) applyTiming(timing *timingChange) {
+	r.log.Info("Committed election timeout %s and heartbeat interval %s at %d", timing.electionTimeout, timing.heartbeatInterval, timing.index)
+	atomic.StoreInt64(&r.electionTimeout, int64(timing.electionTimeout))
+	atomic.StoreInt64(&r.heartbeatInterval, int64(timing.heartbeatInterval))
+}
+
+func (r *raft) ConfiguredElectionTimeout() time.Duration {
+	if timeout := atomic.LoadInt64(&r.electionTimeout); timeout > 0 {
+		return time.Duration(timeout)
+	}
+	return r.config.GetElectionTimeoutOrDefault()
+}
+
+func (r *raft) HeartbeatInterval() time.Duration {
+	if interval := atomic.LoadInt64(&r.heartbeatInterval); interval > 0 {
+		return time.Duration(interval)
+	}
+	return r.config.GetHeartbeatIntervalOrDefault()
+}
+
+func (r *raft) ElectionBackoff(member MemberID) time.Duration {
+	limit := r.config.GetElectionRateLimit()
+	if limit.GetMaxRequests() == 0 {
+		return 0
+	}
+	interval := r.ConfiguredElectionTimeout()
+	if limit.GetInterval() != nil {
+		interval = *limit.GetInterval()
+	}
+	return r.electionLimiter.acquire(member, limit.GetMaxRequests(), interval, time.Now())
+}
+
+func (r *raft) ObserveRoundTrip(member MemberID, rtt time.Duration) {
+	adaptive := r.config.GetAdaptiveElectionTimeout()
+	if adaptive.GetRttMultiplier() == 0 {
+		return
+	}
+	r.roundTrips.observe(member, rtt, adaptive.GetWindowOrDefault())
+}
+
+func (r *raft) ElectionTimeout() time.Duration {
+	electionTimeout := r.ConfiguredElectionTimeout()
+	multiplier := r.config.GetAdaptiveElectionTimeout().GetRttMultiplier()
+	if multiplier == 0 {
+		return electionTimeout
+	}
+	timeout := r.roundTrips.max() * time.Duration(multiplier)
+	if timeout < electionTimeout {
+		return electionTimeout
+	}
+	if maxTimeout := r.config.GetMaxAdaptiveElectionTimeoutOrDefault(); timeout > maxTimeout {
+		return maxTimeout
+	}
+	return timeout
+}
+
+func (r *raft) IsConsistent() bool {
+	return !r.inconsistent
+}
+
+func (r *raft) SetConsistent(consistent bool) {
+	r.inconsistent = !consistent
+}
+
+// Lock modes reported by lock metrics
+const (
+	lockModeRead  = "read"
+	lockModeWrite = "write"
+)
+
 func (r *raft) WriteLock() {
+	if !r.metrics.locks {
+		r.mu.Lock()
+		return
+	}
+	start := time.Now()
 	r.mu.Lock()
+	r.lockTime = time.Now()
+	r.metrics.ObserveLockWait(lockModeWrite, r.Role(), r.lockTime.Sub(start))
 }
 
 func (r *raft) WriteUnlock() {
+	if r.metrics.locks {
+		r.metrics.ObserveLockHold(lockModeWrite, r.Role(), time.Since(r.lockTime))
+	}
 	r.mu.Unlock()
 }
 
+// ReadLock acquires a read lock on the Raft state. Only the time spent waiting for read locks is recorded
+// by lock metrics since read locks are held concurrently.
 func (r *raft) ReadLock() {
+	if !r.metrics.locks {
+		r.mu.RLock()
+		return
+	}
+	start := time.Now()
 	r.mu.RLock()
+	r.metrics.ObserveLockWait(lockModeRead, r.Role(), time.Since(start))
 }
 
 func (r *raft) ReadUnlock() {
@@ -385,7 +1225,64 @@ func (r *raft) Role() RoleType {
 	return r.role.Type()
 }
 
+// roleTransition is a role transition requested while another transition is in progress
+type roleTransition struct {
+	role RoleType
+	term Term
+}
+
 func (r *raft) SetRole(roleType RoleType) {
+	r.TransitionRole(roleType, r.term)
+}
+
+func (r *raft) TransitionRole(roleType RoleType, term Term) bool {
+	// If the Raft state has been closed, discard the transition
+	if r.closed {
+		r.log.Debug("Discarding transition to %s: state is closed", roleType)
+		return false
+	}
+
+	// If the transition was requested in an older term, discard it
+	if term < r.term {
+		r.log.Debug("Discarding transition to %s requested in term %d: current term is %d", roleType, term, r.term)
+		return false
+	}
+
+	// If a role is being stopped or started, defer the transition until the current transition is complete.
+	// If multiple transitions are requested, the transition in the highest term wins.
+	if r.transitioning {
+		if r.pendingRole == nil || term >= r.pendingRole.term {
+			r.pendingRole = &roleTransition{
+				role: roleType,
+				term: term,
+			}
+		}
+		return true
+	}
+
+	r.transitioning = true
+	defer func() {
+		r.transitioning = false
+	}()
+
+	r.setRole(roleType)
+	for r.pendingRole != nil {
+		transition := r.pendingRole
+		r.pendingRole = nil
+		if transition.term < r.term {
+			r.log.Debug("Discarding transition to %s requested in term %d: current term is %d", transition.role, transition.term, r.term)
+			continue
+		}
+		if r.closed {
+			r.log.Debug("Discarding transition to %s: state is closed", transition.role)
+			break
+		}
+		r.setRole(transition.role)
+	}
+	return true
+}
+
+func (r *raft) setRole(roleType RoleType) {
 	// Get the role factory function
 	roleFunc, ok := r.roles[roleType]
 	if !ok {
@@ -409,6 +1306,7 @@ func (r *raft) SetRole(roleType RoleType) {
 	// Create and start the new role
 	role := roleFunc(r)
 	r.role = role
+	r.audit(AuditRecord{Type: AuditRecordRole, Role: role.Type()})
 	if err := role.Start(); err != nil {
 		r.log.Error("Failed to start %s role", role.Type(), err)
 	}
@@ -428,7 +1326,11 @@ func (r *raft) Poll(ctx context.Context, request *PollRequest) (*PollResponse, e
 }
 
 func (r *raft) Vote(ctx context.Context, request *VoteRequest) (*VoteResponse, error) {
-	return r.getRole().Vote(ctx, request)
+	response, err := r.getRole().Vote(ctx, request)
+	if err == nil && response.Status == ResponseStatus_OK {
+		r.metrics.ObserveVote(response.Voted)
+	}
+	return response, err
 }
 
 func (r *raft) Append(ctx context.Context, request *AppendRequest) (*AppendResponse, error) {
@@ -443,6 +1345,10 @@ func (r *raft) Command(request *CommandRequest, ch chan<- *CommandStreamResponse
 	return r.getRole().Command(request, ch)
 }
 
+func (r *raft) CommandBatch(ctx context.Context, request *CommandBatchRequest) (*CommandBatchResponse, error) {
+	return r.getRole().CommandBatch(ctx, request)
+}
+
 func (r *raft) Query(request *QueryRequest, ch chan<- *QueryStreamResponse) error {
 	return r.getRole().Query(request, ch)
 }
@@ -463,11 +1369,43 @@ func (r *raft) Reconfigure(ctx context.Context, request *ReconfigureRequest) (*R
 	return r.getRole().Reconfigure(ctx, request)
 }
 
+func (r *raft) SetReadOnly(ctx context.Context, request *SetReadOnlyRequest) (*SetReadOnlyResponse, error) {
+	return r.getRole().SetReadOnly(ctx, request)
+}
+
+func (r *raft) FreezeElections(ctx context.Context, request *FreezeElectionsRequest) (*FreezeElectionsResponse, error) {
+	return r.getRole().FreezeElections(ctx, request)
+}
+
 func (r *raft) Transfer(ctx context.Context, request *TransferRequest) (*TransferResponse, error) {
 	return r.getRole().Transfer(ctx, request)
 }
 
+func (r *raft) ConfirmLeadership(ctx context.Context, request *ConfirmLeadershipRequest) (*ConfirmLeadershipResponse, error) {
+	return r.getRole().ConfirmLeadership(ctx, request)
+}
+
+func (r *raft) Hash(ctx context.Context, request *HashRequest) (*HashResponse, error) {
+	return r.getRole().Hash(ctx, request)
+}
+
 func (r *raft) Close() error {
+	r.WriteLock()
+	defer r.WriteUnlock()
+	if r.closed {
+		return nil
+	}
+
+	// Stop the current role and discard pending transitions to ensure goroutines started by the role,
+	// e.g. an in-progress election, do not start a new role or write to the metadata store once it's closed.
+	r.closed = true
+	r.pendingRole = nil
+	if r.role != nil {
+		r.log.Info("Stopping %s", r.role.Type())
+		if err := r.role.Stop(); err != nil {
+			r.log.Error("Failed to stop %s role", r.role.Type(), err)
+		}
+	}
 	r.setStatus(StatusStopped)
 	return r.metadata.Close()
 }
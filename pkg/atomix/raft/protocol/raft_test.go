@@ -16,9 +16,14 @@ package protocol
 
 import (
 	"context"
+	"errors"
 	atomix "github.com/atomix/go-framework/pkg/atomix/cluster"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"sync"
 	"testing"
 	"time"
 )
@@ -50,7 +55,7 @@ func TestRaftProtocol(t *testing.T) {
 	store := newMemoryMetadataStore()
 	electionTimeout := 10 * time.Second
 	roles := make(map[RoleType]func(Raft) Role)
-	raft := newRaft(NewCluster(cluster), &config.ProtocolConfig{ElectionTimeout: &electionTimeout}, &unimplementedClient{}, roles, store)
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{ElectionTimeout: &electionTimeout}, &unimplementedClient{}, roles, store)
 	assert.Equal(t, StatusStopped, raft.Status())
 	statusCh := make(chan Status, 1)
 	raft.Watch(func(event Event) {
@@ -122,18 +127,18 @@ func TestRaftProtocol(t *testing.T) {
 	// Verify that the state changes once the initial commit index is reached
 	assert.Equal(t, Index(0), raft.CommitIndex())
 	assert.Equal(t, StatusRunning, raft.Status())
-	assert.Equal(t, Index(0), raft.Commit(Index(5))) // Commit a change before setting the first commit index
+	assert.Equal(t, Index(0), raft.Commit(Index(5), Index(5))) // Commit a change before setting the first commit index
 	assert.Equal(t, Index(5), raft.CommitIndex())
 	assert.Equal(t, StatusRunning, raft.Status())
 	raft.SetCommitIndex(Index(10)) // Set the first commit index to 10
 	assert.Equal(t, StatusRunning, raft.Status())
-	raft.SetCommitIndex(Index(50))                   // Ensure the first commit index is idempotent
-	assert.Equal(t, Index(5), raft.Commit(Index(9))) // Ensure a commit lower than the first index does not change the node's state
+	raft.SetCommitIndex(Index(50))                             // Ensure the first commit index is idempotent
+	assert.Equal(t, Index(5), raft.Commit(Index(9), Index(9))) // Ensure a commit lower than the first index does not change the node's state
 	assert.Equal(t, StatusRunning, raft.Status())
-	assert.Equal(t, Index(9), raft.Commit(Index(10))) // Commit the first commit index
+	assert.Equal(t, Index(9), raft.Commit(Index(10), Index(10))) // Commit the first commit index
 	assert.Equal(t, StatusReady, raft.Status())
 	assert.Equal(t, StatusReady, <-statusCh)
-	assert.Equal(t, Index(10), raft.Commit(Index(3))) // Ensure the commit index cannot be decreased
+	assert.Equal(t, Index(10), raft.Commit(Index(3), Index(3))) // Ensure the commit index cannot be decreased
 	assert.Equal(t, Index(10), raft.CommitIndex())
 
 	// Increment the term and vote for later tests
@@ -156,7 +161,7 @@ func TestRaftProtocol(t *testing.T) {
 			return leader
 		},
 	}
-	raft = newRaft(NewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, roles, store)
+	raft = newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, roles, store)
 	assert.Equal(t, StatusStopped, raft.Status())
 	raft.WriteLock()
 	raft.Init()
@@ -191,6 +196,329 @@ func TestRaftProtocol(t *testing.T) {
 	raft.WriteUnlock()
 }
 
+func TestRaftConfiguration(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "bar",
+			},
+		},
+	}
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+	configuration, pending := raft.Configuration()
+	assert.Equal(t, Index(0), configuration.Index)
+	assert.Len(t, configuration.Members, 2)
+	assert.Nil(t, pending)
+
+	// Verify a configuration appended to the log is pending until committed
+	raft.SetConfiguration(&Configuration{
+		Index:   2,
+		Members: []*Member{{MemberID: "foo"}},
+	})
+	configuration, pending = raft.Configuration()
+	assert.Equal(t, Index(0), configuration.Index)
+	assert.Equal(t, Index(2), pending.Index)
+
+	raft.Commit(1, 1)
+	configuration, pending = raft.Configuration()
+	assert.Equal(t, Index(0), configuration.Index)
+	assert.Equal(t, Index(2), pending.Index)
+
+	raft.Commit(2, 2)
+	configuration, pending = raft.Configuration()
+	assert.Equal(t, Index(2), configuration.Index)
+	assert.Len(t, configuration.Members, 1)
+	assert.Nil(t, pending)
+
+	// Verify a pending configuration can be discarded
+	raft.SetConfiguration(&Configuration{
+		Index: 3,
+	})
+	raft.SetConfiguration(nil)
+	configuration, pending = raft.Configuration()
+	assert.Equal(t, Index(2), configuration.Index)
+	assert.Nil(t, pending)
+}
+
+func TestRaftReadOnlyMode(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+	assert.False(t, raft.IsReadOnly())
+
+	// Verify the read-only mode takes effect once appended to the log
+	raft.SetReadOnlyMode(2, true)
+	assert.True(t, raft.IsReadOnly())
+	raft.Commit(2, 2)
+	assert.True(t, raft.IsReadOnly())
+
+	// Verify a pending read-only mode reverts to the committed mode when it's truncated from the log
+	raft.SetReadOnlyMode(3, false)
+	assert.False(t, raft.IsReadOnly())
+	raft.DiscardReadOnlyMode(3)
+	assert.False(t, raft.IsReadOnly())
+	raft.DiscardReadOnlyMode(2)
+	assert.True(t, raft.IsReadOnly())
+}
+
+func TestRaftElectionFreeze(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	maxElectionFreeze := time.Minute
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{MaxElectionFreeze: &maxElectionFreeze}, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+	assert.False(t, raft.ElectionsFrozen())
+
+	// Verify the election freeze takes effect once committed and expires relative to the local commit
+	raft.SetElectionFreeze(2, time.Minute)
+	assert.False(t, raft.ElectionsFrozen())
+	assert.Equal(t, time.Duration(0), raft.ElectionFreeze())
+	start := time.Now()
+	raft.Commit(2, 2)
+	assert.True(t, raft.ElectionsFrozen())
+	assert.True(t, raft.ElectionFreeze() > time.Minute-time.Since(start)-time.Second)
+
+	// Verify a pending freeze is discarded when it's truncated from the log
+	raft.SetElectionFreeze(3, 0)
+	raft.DiscardElectionFreeze(2)
+	raft.Commit(3, 3)
+	assert.True(t, raft.ElectionsFrozen())
+
+	// Verify a committed freeze with a zero duration lifts the freeze
+	raft.SetElectionFreeze(3, 0)
+	assert.False(t, raft.ElectionsFrozen())
+	assert.Equal(t, time.Duration(0), raft.ElectionFreeze())
+
+	// Verify the freeze is capped at the local maximum election freeze
+	raft.SetElectionFreeze(3, time.Hour)
+	assert.True(t, raft.ElectionsFrozen())
+	assert.True(t, raft.ElectionFreeze() <= maxElectionFreeze)
+
+	// Verify the freeze lifts once it expires
+	raft.SetElectionFreeze(3, 100*time.Millisecond)
+	assert.True(t, raft.ElectionsFrozen())
+	time.Sleep(200 * time.Millisecond)
+	assert.False(t, raft.ElectionsFrozen())
+}
+
+func TestRaftTiming(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	electionTimeout := time.Second
+	heartbeatInterval := 100 * time.Millisecond
+	config := &config.ProtocolConfig{
+		ElectionTimeout:   &electionTimeout,
+		HeartbeatInterval: &heartbeatInterval,
+	}
+	raft := newRaft(mustNewCluster(cluster), config, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+	assert.Equal(t, electionTimeout, raft.ConfiguredElectionTimeout())
+	assert.Equal(t, heartbeatInterval, raft.HeartbeatInterval())
+
+	// Verify the timing takes effect once committed without modifying the shared configuration
+	raft.SetTiming(2, 2*time.Second, 200*time.Millisecond)
+	assert.Equal(t, electionTimeout, raft.ConfiguredElectionTimeout())
+	raft.Commit(2, 2)
+	assert.Equal(t, 2*time.Second, raft.ConfiguredElectionTimeout())
+	assert.Equal(t, 200*time.Millisecond, raft.HeartbeatInterval())
+	assert.Equal(t, 2*time.Second, raft.ElectionTimeout())
+	assert.Equal(t, electionTimeout, config.GetElectionTimeoutOrDefault())
+	assert.Equal(t, heartbeatInterval, config.GetHeartbeatIntervalOrDefault())
+
+	// Verify a pending timing change is discarded when it's truncated from the log
+	raft.SetTiming(3, 3*time.Second, 300*time.Millisecond)
+	raft.DiscardTiming(2)
+	raft.Commit(3, 3)
+	assert.Equal(t, 2*time.Second, raft.ConfiguredElectionTimeout())
+}
+
+func TestRaftAdaptiveElectionTimeout(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	electionTimeout := time.Second
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{ElectionTimeout: &electionTimeout}, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+
+	// Verify round trip times are ignored unless adaptive election timeouts are enabled
+	raft.ObserveRoundTrip("bar", time.Second)
+	assert.Equal(t, electionTimeout, raft.ElectionTimeout())
+
+	maxTimeout := 4 * time.Second
+	raft.Config().AdaptiveElectionTimeout = &config.AdaptiveTimeoutConfig{
+		RttMultiplier: 10,
+		MaxTimeout:    &maxTimeout,
+		Window:        2,
+	}
+
+	// Verify the configured election timeout is a lower bound
+	assert.Equal(t, electionTimeout, raft.ElectionTimeout())
+	raft.ObserveRoundTrip("bar", 50*time.Millisecond)
+	assert.Equal(t, electionTimeout, raft.ElectionTimeout())
+
+	// Verify a higher round trip time lengthens the election timeout
+	raft.ObserveRoundTrip("baz", 200*time.Millisecond)
+	assert.Equal(t, 2*time.Second, raft.ElectionTimeout())
+
+	// Verify the election timeout is capped at the max adaptive election timeout
+	raft.ObserveRoundTrip("bar", time.Second)
+	assert.Equal(t, maxTimeout, raft.ElectionTimeout())
+
+	// Verify the election timeout shortens once the slow round trips are evicted
+	raft.ObserveRoundTrip("bar", 10*time.Millisecond)
+	raft.ObserveRoundTrip("bar", 10*time.Millisecond)
+	raft.ObserveRoundTrip("baz", 10*time.Millisecond)
+	raft.ObserveRoundTrip("baz", 10*time.Millisecond)
+	assert.Equal(t, electionTimeout, raft.ElectionTimeout())
+}
+
+func TestRaftCommitOverflow(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	protocolConfig := &config.ProtocolConfig{}
+	raft := newRaft(mustNewCluster(cluster), protocolConfig, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+
+	// Verify a commit index beyond the last log index is clamped to the last index by default
+	assert.Equal(t, Index(0), raft.Commit(10, 5))
+	assert.Equal(t, Index(5), raft.CommitIndex())
+
+	// Verify a pending configuration beyond the last log index is not committed by a clamped commit
+	raft.SetConfiguration(&Configuration{
+		Index:   8,
+		Members: []*Member{{MemberID: "foo"}},
+	})
+	assert.Equal(t, Index(5), raft.Commit(10, 7))
+	assert.Equal(t, Index(7), raft.CommitIndex())
+	_, pending := raft.Configuration()
+	assert.NotNil(t, pending)
+
+	// Verify a commit index beyond the last log index is discarded by the discard policy
+	protocolConfig.CommitOverflowPolicy = config.CommitOverflowPolicy_DISCARD
+	assert.Equal(t, Index(7), raft.Commit(10, 9))
+	assert.Equal(t, Index(7), raft.CommitIndex())
+	_, pending = raft.Configuration()
+	assert.NotNil(t, pending)
+
+	// Verify a commit index within the log advances normally
+	assert.Equal(t, Index(7), raft.Commit(9, 9))
+	assert.Equal(t, Index(9), raft.CommitIndex())
+	_, pending = raft.Configuration()
+	assert.Nil(t, pending)
+}
+
+func TestRaftTransitionRole(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	roles := map[RoleType]func(Raft) Role{
+		RoleFollower: func(r Raft) Role {
+			return &followerRole{&testRole{}}
+		},
+		RoleCandidate: func(r Raft) Role {
+			// Request a transition to leader while the candidate is starting
+			return &startFuncRole{
+				roleType: RoleCandidate,
+				start: func() {
+					assert.True(t, r.TransitionRole(RoleLeader, r.Term()))
+					assert.Equal(t, RoleCandidate, r.Role())
+				},
+			}
+		},
+		RoleLeader: func(r Raft) Role {
+			return &leaderRole{&testRole{}}
+		},
+	}
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, roles, newMemoryMetadataStore())
+	raft.WriteLock()
+	raft.Init()
+	raft.WriteUnlock()
+	assert.Equal(t, RoleFollower, raft.Role())
+
+	// Verify a transition requested in an older term is discarded
+	raft.WriteLock()
+	assert.NoError(t, raft.SetTerm(Term(2)))
+	assert.False(t, raft.TransitionRole(RoleLeader, Term(1)))
+	assert.Equal(t, RoleFollower, raft.Role())
+	assert.True(t, raft.TransitionRole(RoleLeader, Term(2)))
+	assert.Equal(t, RoleLeader, raft.Role())
+	raft.WriteUnlock()
+
+	// Verify concurrent transitions always resolve to the transition requested in the highest term
+	for i := 0; i < 100; i++ {
+		raft.WriteLock()
+		raft.SetRole(RoleFollower)
+		term := raft.Term()
+		raft.WriteUnlock()
+
+		wg := &sync.WaitGroup{}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			raft.WriteLock()
+			defer raft.WriteUnlock()
+			raft.TransitionRole(RoleLeader, term)
+		}()
+		go func() {
+			defer wg.Done()
+			raft.WriteLock()
+			defer raft.WriteUnlock()
+			assert.NoError(t, raft.SetTerm(term+1))
+			assert.True(t, raft.TransitionRole(RoleFollower, term+1))
+		}()
+		wg.Wait()
+		assert.Equal(t, RoleFollower, raft.Role())
+		assert.Equal(t, term+1, raft.Term())
+	}
+
+	// Verify a transition requested while a role is starting is applied once the role has started
+	var events []RoleType
+	raft.Watch(func(event Event) {
+		if event.Type == EventTypeRole {
+			events = append(events, event.Role)
+		}
+	})
+	raft.WriteLock()
+	assert.True(t, raft.TransitionRole(RoleCandidate, raft.Term()))
+	assert.Equal(t, RoleLeader, raft.Role())
+	raft.WriteUnlock()
+	assert.Equal(t, []RoleType{RoleCandidate, RoleLeader}, events)
+}
+
 type testRole struct {
 	Role
 	appended bool
@@ -224,3 +552,521 @@ type leaderRole struct {
 func (r *leaderRole) Type() RoleType {
 	return RoleLeader
 }
+
+// startFuncRole is a role that calls a function when started and, if set, when stopped
+type startFuncRole struct {
+	Role
+	roleType RoleType
+	start    func()
+	stop     func()
+}
+
+func (r *startFuncRole) Type() RoleType {
+	return r.roleType
+}
+
+func (r *startFuncRole) Start() error {
+	r.start()
+	return nil
+}
+
+func (r *startFuncRole) Stop() error {
+	if r.stop != nil {
+		r.stop()
+	}
+	return nil
+}
+
+// countingMetadataStore is a MetadataStore that counts writes to the store
+type countingMetadataStore struct {
+	*memoryMetadataStore
+	writes int
+}
+
+func (s *countingMetadataStore) StoreTerm(term Term) error {
+	s.writes++
+	return s.memoryMetadataStore.StoreTerm(term)
+}
+
+func (s *countingMetadataStore) StoreVote(vote *MemberID) error {
+	s.writes++
+	return s.memoryMetadataStore.StoreVote(vote)
+}
+
+func (s *countingMetadataStore) StoreTermAndVote(term Term, vote *MemberID) error {
+	s.writes++
+	return s.memoryMetadataStore.StoreTermAndVote(term, vote)
+}
+
+// failingMetadataStore is a MetadataStore that fails writes to the store while failing is set
+type failingMetadataStore struct {
+	MetadataStore
+	failing bool
+}
+
+func (s *failingMetadataStore) StoreTerm(term Term) error {
+	if s.failing {
+		return errors.New("store failed")
+	}
+	return s.MetadataStore.StoreTerm(term)
+}
+
+func (s *failingMetadataStore) StoreVote(vote *MemberID) error {
+	if s.failing {
+		return errors.New("store failed")
+	}
+	return s.MetadataStore.StoreVote(vote)
+}
+
+func (s *failingMetadataStore) StoreTermAndVote(term Term, vote *MemberID) error {
+	if s.failing {
+		return errors.New("store failed")
+	}
+	return s.MetadataStore.StoreTermAndVote(term, vote)
+}
+
+func TestRaftClose(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "bar",
+			},
+		},
+	}
+	stopped := false
+	roles := map[RoleType]func(Raft) Role{
+		RoleFollower: func(r Raft) Role {
+			return &followerRole{&testRole{}}
+		},
+		RoleCandidate: func(r Raft) Role {
+			return &startFuncRole{
+				roleType: RoleCandidate,
+				start:    func() {},
+				stop: func() {
+					stopped = true
+				},
+			}
+		},
+		RoleLeader: func(r Raft) Role {
+			return &leaderRole{&testRole{}}
+		},
+	}
+	store := &countingMetadataStore{memoryMetadataStore: newMemoryMetadataStore().(*memoryMetadataStore)}
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, roles, store)
+	raft.WriteLock()
+	raft.Init()
+	assert.NoError(t, raft.SetTerm(Term(1)))
+	raft.SetRole(RoleCandidate)
+	raft.WriteUnlock()
+	writes := store.writes
+
+	// Verify the current role is stopped when the state is closed
+	assert.NoError(t, raft.Close())
+	assert.True(t, stopped)
+	assert.Equal(t, StatusStopped, raft.Status())
+
+	// Verify in-flight election goroutines cannot start a new role or update the term and vote once closed
+	raft.WriteLock()
+	assert.False(t, raft.TransitionRole(RoleLeader, Term(1)))
+	assert.Equal(t, RoleCandidate, raft.Role())
+	assert.Error(t, raft.SetTerm(Term(2)))
+	assert.Error(t, raft.SetLastVotedFor("foo"))
+	assert.Error(t, raft.SetTermAndVote(Term(2), "foo"))
+	raft.WriteUnlock()
+	assert.Equal(t, Term(1), raft.Term())
+	assert.Equal(t, writes, store.writes)
+
+	// Verify closing the state again is a no-op
+	assert.NoError(t, raft.Close())
+}
+
+func TestRaftSetTermAndVote(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "bar",
+			},
+		},
+	}
+	store := &countingMetadataStore{memoryMetadataStore: &memoryMetadataStore{}}
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), store)
+	foo := MemberID("foo")
+
+	// Verify incrementing the term stores the term and clears the vote in a single write, and voting
+	// separately writes to the store again
+	assert.NoError(t, raft.SetTerm(1))
+	assert.Equal(t, 1, store.writes)
+	assert.NoError(t, raft.SetLastVotedFor(foo))
+	assert.Equal(t, 2, store.writes)
+
+	// Verify the term and vote are written together in a single write
+	store.writes = 0
+	assert.NoError(t, raft.SetTermAndVote(2, foo))
+	assert.Equal(t, 1, store.writes)
+	assert.Equal(t, Term(2), raft.Term())
+	assert.Equal(t, &foo, raft.LastVotedFor())
+	assert.Nil(t, raft.Leader())
+	assert.Equal(t, Term(2), *store.LoadTerm())
+	assert.Equal(t, foo, *store.LoadVote())
+
+	// Verify the term cannot be decreased or reused and the vote must be for a member
+	assert.Error(t, raft.SetTermAndVote(2, "bar"))
+	assert.Error(t, raft.SetTermAndVote(3, "baz"))
+	assert.Equal(t, 1, store.writes)
+	assert.Equal(t, Term(2), *store.LoadTerm())
+	assert.Equal(t, foo, *store.LoadVote())
+}
+
+func TestRaftVerifyVotes(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "bar",
+			},
+			"baz": {
+				ID: "baz",
+			},
+		},
+	}
+	roles := map[RoleType]func(Raft) Role{
+		RoleFollower: func(r Raft) Role {
+			return &followerRole{&testRole{}}
+		},
+	}
+	votes := newMemoryVoteRecord()
+	newTestRaft := func(store MetadataStore) *raft {
+		state := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{VerifyVotes: true}, &unimplementedClient{}, roles, store).(*raft)
+		state.votes = votes
+		state.WriteLock()
+		state.Init()
+		state.WriteUnlock()
+		return state
+	}
+
+	// Vote for a candidate in term 1 and another in term 2
+	state := newTestRaft(newMemoryMetadataStore())
+	state.WriteLock()
+	assert.NoError(t, state.SetTermAndVote(Term(1), "bar"))
+	assert.NoError(t, state.SetTerm(Term(2)))
+	assert.NoError(t, state.SetLastVotedFor("baz"))
+	state.WriteUnlock()
+	assert.NoError(t, state.Close())
+
+	// Verify a restart with a lost metadata store restores the last recorded term and vote
+	state = newTestRaft(newMemoryMetadataStore())
+	state.WriteLock()
+	assert.Equal(t, Term(2), state.Term())
+	assert.Equal(t, MemberID("baz"), *state.LastVotedFor())
+	state.WriteUnlock()
+	assert.NoError(t, state.Close())
+
+	// Verify a restart with a corrupted vote restores the recorded vote
+	store := newMemoryMetadataStore()
+	store.StoreTerm(Term(2))
+	state = newTestRaft(store)
+	state.WriteLock()
+	assert.Equal(t, Term(2), state.Term())
+	assert.Equal(t, MemberID("baz"), *state.LastVotedFor())
+	state.WriteUnlock()
+	assert.Equal(t, MemberID("baz"), *store.LoadVote())
+	assert.NoError(t, state.Close())
+
+	// Verify a second vote can't be cast in a term even if the metadata store loses the vote
+	store = newMemoryMetadataStore()
+	store.StoreTerm(Term(2))
+	state = newRaft(mustNewCluster(cluster), &config.ProtocolConfig{VerifyVotes: true}, &unimplementedClient{}, roles, store).(*raft)
+	state.votes = votes
+	state.WriteLock()
+	state.term = Term(2)
+	assert.Error(t, state.SetLastVotedFor("bar"))
+	assert.Nil(t, state.LastVotedFor())
+	assert.NoError(t, state.SetLastVotedFor("baz"))
+	assert.Error(t, state.SetTermAndVote(Term(1), "bar"))
+	assert.NoError(t, state.SetTermAndVote(Term(3), "bar"))
+	state.WriteUnlock()
+	assert.Equal(t, MemberID("bar"), *votes.LoadVote(Term(3)))
+}
+
+func TestRaftAuthorizeCommands(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore())
+
+	// Verify commands are allowed if no authorizers are added
+	request := &CommandRequest{
+		Metadata: map[string]string{
+			"tenant": "foo",
+		},
+	}
+	assert.NoError(t, raft.AuthorizeCommand(request))
+
+	// Verify authorizers are invoked in order until a command is denied
+	calls := make([]string, 0)
+	raft.AuthorizeCommands(func(request *CommandRequest) error {
+		calls = append(calls, "first")
+		return nil
+	})
+	raft.AuthorizeCommands(func(request *CommandRequest) error {
+		calls = append(calls, "second")
+		if request.Metadata["tenant"] != "foo" {
+			return errors.New("unknown tenant")
+		}
+		return nil
+	})
+	raft.AuthorizeCommands(func(request *CommandRequest) error {
+		calls = append(calls, "third")
+		return nil
+	})
+	assert.NoError(t, raft.AuthorizeCommand(request))
+	assert.Equal(t, []string{"first", "second", "third"}, calls)
+
+	calls = calls[:0]
+	request.Metadata["tenant"] = "bar"
+	assert.EqualError(t, raft.AuthorizeCommand(request), "unknown tenant")
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
+// testLogSink is a LogSink that records logged messages
+type testLogSink struct {
+	messages []testLogMessage
+	mu       sync.Mutex
+}
+
+type testLogMessage struct {
+	level   util.Level
+	message string
+	fields  util.Fields
+}
+
+func (s *testLogSink) Log(level util.Level, message string, fields util.Fields) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, testLogMessage{level: level, message: message, fields: fields})
+}
+
+func (s *testLogSink) last() testLogMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.messages[len(s.messages)-1]
+}
+
+func TestRaftLogger(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	sink := &testLogSink{}
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), newMemoryMetadataStore(), WithLogger(sink))
+
+	// Verify role loggers write structured messages to the sink
+	log := raft.NewLogger(RoleLeader)
+	log.Warn("Hello %s", "world")
+	message := sink.last()
+	assert.Equal(t, util.WarnLevel, message.level)
+	assert.Equal(t, "Hello world", message.message)
+	assert.Equal(t, "foo", message.fields[util.MemberField])
+	assert.Equal(t, "Leader", message.fields[util.RoleField])
+	assert.Equal(t, uint64(0), message.fields[util.TermField])
+
+	// Verify the term field reflects the current term
+	assert.NoError(t, raft.SetTerm(3))
+	log.Request("AppendRequest", &AppendRequest{})
+	message = sink.last()
+	assert.Equal(t, util.TraceLevel, message.level)
+	assert.Equal(t, uint64(3), message.fields[util.TermField])
+	assert.Equal(t, "AppendRequest", message.fields[util.MessageTypeField])
+
+	// Verify the protocol's own logs are written to the sink without a role
+	assert.NoError(t, raft.SetTermAndVote(4, "foo"))
+	message = sink.last()
+	assert.Equal(t, util.DebugLevel, message.level)
+	assert.Equal(t, uint64(4), message.fields[util.TermField])
+	_, ok := message.fields[util.RoleField]
+	assert.False(t, ok)
+}
+
+func TestRaftVoteDurability(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "bar",
+			},
+			"baz": {
+				ID: "baz",
+			},
+		},
+	}
+	dir, err := ioutil.TempDir("", "raft-metadata")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	roles := map[RoleType]func(Raft) Role{
+		RoleFollower: func(r Raft) Role {
+			return &followerRole{&testRole{}}
+		},
+	}
+	newTestRaft := func() *raft {
+		store, err := NewFileMetadataStore(dir)
+		assert.NoError(t, err)
+		state := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, roles, newMemoryMetadataStore(), WithMetadataStore(store)).(*raft)
+		state.WriteLock()
+		state.Init()
+		state.WriteUnlock()
+		return state
+	}
+
+	// Grant a vote and then crash without closing the state
+	state := newTestRaft()
+	state.WriteLock()
+	assert.NoError(t, state.SetTerm(Term(1)))
+	assert.NoError(t, state.SetLastVotedFor("bar"))
+	state.WriteUnlock()
+
+	// Verify the vote granted before the crash is restored after a restart and the member cannot vote
+	// for another candidate in the same term
+	state = newTestRaft()
+	state.WriteLock()
+	assert.Equal(t, Term(1), state.Term())
+	assert.Equal(t, MemberID("bar"), *state.LastVotedFor())
+	assert.Error(t, state.SetLastVotedFor("baz"))
+	assert.NoError(t, state.SetTermAndVote(Term(2), "foo"))
+	state.WriteUnlock()
+
+	// Verify a term and vote written together survive a crash
+	state = newTestRaft()
+	state.WriteLock()
+	assert.Equal(t, Term(2), state.Term())
+	assert.Equal(t, MemberID("foo"), *state.LastVotedFor())
+	assert.NoError(t, state.SetTerm(Term(3)))
+	state.WriteUnlock()
+
+	// Verify a term increment clears the vote across a crash
+	state = newTestRaft()
+	state.WriteLock()
+	assert.Equal(t, Term(3), state.Term())
+	assert.Nil(t, state.LastVotedFor())
+	state.WriteUnlock()
+	assert.NoError(t, state.Close())
+}
+
+func TestRaftReadOnlyModeDurability(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+		},
+	}
+	dir, err := ioutil.TempDir("", "raft-metadata")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	roles := map[RoleType]func(Raft) Role{
+		RoleFollower: func(r Raft) Role {
+			return &followerRole{&testRole{}}
+		},
+	}
+	newTestRaft := func() *raft {
+		store, err := NewFileMetadataStore(dir)
+		assert.NoError(t, err)
+		state := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, roles, newMemoryMetadataStore(), WithMetadataStore(store)).(*raft)
+		state.WriteLock()
+		state.Init()
+		state.WriteUnlock()
+		return state
+	}
+
+	// Verify a pending read-only mode is not restored after a restart
+	state := newTestRaft()
+	state.WriteLock()
+	assert.NoError(t, state.SetTermAndVote(Term(1), "foo"))
+	state.SetReadOnlyMode(2, true)
+	assert.True(t, state.IsReadOnly())
+	state.WriteUnlock()
+
+	state = newTestRaft()
+	state.WriteLock()
+	assert.False(t, state.IsReadOnly())
+
+	// Verify a committed read-only mode is restored after a restart along with the term and vote
+	state.SetReadOnlyMode(2, true)
+	state.Commit(2, 2)
+	assert.True(t, state.ReadOnlyMode())
+	state.WriteUnlock()
+
+	state = newTestRaft()
+	state.WriteLock()
+	assert.True(t, state.IsReadOnly())
+	assert.Equal(t, Term(1), state.Term())
+	assert.Equal(t, MemberID("foo"), *state.LastVotedFor())
+
+	// Verify a committed switch back to read-write mode is restored after a restart
+	state.SetReadOnlyMode(0, false)
+	state.WriteUnlock()
+
+	state = newTestRaft()
+	state.WriteLock()
+	assert.False(t, state.IsReadOnly())
+	state.WriteUnlock()
+	assert.NoError(t, state.Close())
+}
+
+func TestRaftVoteStoreFailure(t *testing.T) {
+	cluster := atomix.Cluster{
+		MemberID: "foo",
+		Members: map[string]atomix.Member{
+			"foo": {
+				ID: "foo",
+			},
+			"bar": {
+				ID: "bar",
+			},
+		},
+	}
+	store := &failingMetadataStore{MetadataStore: newMemoryMetadataStore()}
+	raft := newRaft(mustNewCluster(cluster), &config.ProtocolConfig{}, &unimplementedClient{}, make(map[RoleType]func(Raft) Role), store)
+	assert.NoError(t, raft.SetTerm(1))
+
+	// Verify the term and vote are not updated unless they're stored
+	store.failing = true
+	assert.Error(t, raft.SetLastVotedFor("bar"))
+	assert.Nil(t, raft.LastVotedFor())
+	assert.Error(t, raft.SetTerm(2))
+	assert.Equal(t, Term(1), raft.Term())
+	assert.Error(t, raft.SetTermAndVote(2, "foo"))
+	assert.Equal(t, Term(1), raft.Term())
+	assert.Nil(t, raft.LastVotedFor())
+
+	// Verify the vote is granted once the store recovers
+	store.failing = false
+	assert.NoError(t, raft.SetLastVotedFor("bar"))
+	assert.Equal(t, MemberID("bar"), *raft.LastVotedFor())
+	assert.Equal(t, MemberID("bar"), *store.LoadVote())
+}
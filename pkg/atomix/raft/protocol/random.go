@@ -0,0 +1,49 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// newRandom returns a new random backed by a source seeded with the current time
+func newRandom() *random {
+	return newRandomWithSource(rand.NewSource(time.Now().UnixNano()))
+}
+
+// newRandomWithSource returns a new random backed by the given source
+func newRandomWithSource(source rand.Source) *random {
+	return &random{
+		rand: rand.New(source),
+	}
+}
+
+// random is a source of pseudo-random durations that is safe for concurrent use
+type random struct {
+	rand *rand.Rand
+	mu   sync.Mutex
+}
+
+// duration returns a pseudo-random duration in [0, n). If n is not positive, 0 is returned.
+func (r *random) duration(n time.Duration) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return time.Duration(r.rand.Int63n(int64(n)))
+}
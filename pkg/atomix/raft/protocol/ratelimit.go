@@ -0,0 +1,60 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"sync"
+	"time"
+)
+
+// newRateLimiter returns a new per-member rate limiter
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		windows: make(map[MemberID]*rateWindow),
+	}
+}
+
+// rateLimiter limits the rate of requests from each member using fixed windows
+type rateLimiter struct {
+	windows map[MemberID]*rateWindow
+	mu      sync.Mutex
+}
+
+// rateWindow is the number of requests received from a member since the start of the window
+type rateWindow struct {
+	start time.Time
+	count uint32
+}
+
+// acquire records a request from the given member at the given time. If the member has already made
+// the maximum number of requests within the interval, the request is denied and the time remaining
+// until the member's next window starts is returned. Otherwise, 0 is returned.
+func (l *rateLimiter) acquire(member MemberID, maxRequests uint32, interval time.Duration, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	window, ok := l.windows[member]
+	if !ok || now.Sub(window.start) >= interval {
+		l.windows[member] = &rateWindow{
+			start: now,
+			count: 1,
+		}
+		return 0
+	}
+	if window.count >= maxRequests {
+		return window.start.Add(interval).Sub(now)
+	}
+	window.count++
+	return 0
+}
@@ -0,0 +1,40 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter()
+	now := time.Now()
+
+	// Verify requests are allowed up to the maximum within the interval
+	assert.Equal(t, time.Duration(0), limiter.acquire("foo", 2, time.Second, now))
+	assert.Equal(t, time.Duration(0), limiter.acquire("foo", 2, time.Second, now.Add(100*time.Millisecond)))
+
+	// Verify excess requests are denied until the next window starts
+	assert.Equal(t, 800*time.Millisecond, limiter.acquire("foo", 2, time.Second, now.Add(200*time.Millisecond)))
+	assert.Equal(t, 100*time.Millisecond, limiter.acquire("foo", 2, time.Second, now.Add(900*time.Millisecond)))
+
+	// Verify requests from other members are limited independently
+	assert.Equal(t, time.Duration(0), limiter.acquire("bar", 2, time.Second, now.Add(900*time.Millisecond)))
+
+	// Verify requests are allowed once the interval has elapsed
+	assert.Equal(t, time.Duration(0), limiter.acquire("foo", 2, time.Second, now.Add(time.Second)))
+}
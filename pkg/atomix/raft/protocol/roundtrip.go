@@ -0,0 +1,75 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"sync"
+	"time"
+)
+
+// newRoundTripTracker returns a new per-member round trip time tracker
+func newRoundTripTracker() *roundTripTracker {
+	return &roundTripTracker{
+		samples: make(map[MemberID]*roundTripSamples),
+	}
+}
+
+// roundTripTracker tracks the most recent round trip times observed for requests to each member
+type roundTripTracker struct {
+	samples map[MemberID]*roundTripSamples
+	mu      sync.Mutex
+}
+
+// roundTripSamples is a ring buffer of the round trip times most recently observed for a member
+type roundTripSamples struct {
+	times []time.Duration
+	next  int
+}
+
+// observe records a round trip time for the given member, retaining up to window samples for the member
+func (t *roundTripTracker) observe(member MemberID, rtt time.Duration, window int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	samples, ok := t.samples[member]
+	if !ok {
+		samples = &roundTripSamples{}
+		t.samples[member] = samples
+	}
+	if len(samples.times) > window {
+		samples.times = samples.times[len(samples.times)-window:]
+		samples.next = 0
+	}
+	if len(samples.times) < window {
+		samples.times = append(samples.times, rtt)
+	} else {
+		samples.times[samples.next] = rtt
+		samples.next = (samples.next + 1) % window
+	}
+}
+
+// max returns the maximum round trip time retained for any member, or 0 if no round trips were observed
+func (t *roundTripTracker) max() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var max time.Duration
+	for _, samples := range t.samples {
+		for _, rtt := range samples.times {
+			if rtt > max {
+				max = rtt
+			}
+		}
+	}
+	return max
+}
@@ -0,0 +1,43 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRoundTripTracker(t *testing.T) {
+	tracker := newRoundTripTracker()
+	assert.Equal(t, time.Duration(0), tracker.max())
+
+	// Verify the maximum round trip time is tracked across members
+	tracker.observe("foo", 10*time.Millisecond, 3)
+	tracker.observe("bar", 30*time.Millisecond, 3)
+	tracker.observe("foo", 20*time.Millisecond, 3)
+	assert.Equal(t, 30*time.Millisecond, tracker.max())
+
+	// Verify old round trip times are evicted once the window is full
+	tracker.observe("bar", 5*time.Millisecond, 3)
+	tracker.observe("bar", 5*time.Millisecond, 3)
+	assert.Equal(t, 30*time.Millisecond, tracker.max())
+	tracker.observe("bar", 5*time.Millisecond, 3)
+	assert.Equal(t, 20*time.Millisecond, tracker.max())
+
+	// Verify the window can be shrunk
+	tracker.observe("foo", 1*time.Millisecond, 1)
+	assert.Equal(t, 5*time.Millisecond, tracker.max())
+}
@@ -0,0 +1,80 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"fmt"
+	"sync"
+)
+
+// newMemoryVoteRecord creates a new in-memory vote record
+func newMemoryVoteRecord() VoteRecord {
+	return &memoryVoteRecord{
+		votes: make(map[Term]MemberID),
+	}
+}
+
+// VoteRecord durably records the vote cast in each term. Votes are recorded independently of the
+// metadata store to ensure a second vote is never cast in a term, even if the metadata store is lost
+// or corrupted.
+type VoteRecord interface {
+	// RecordVote records a vote for the given candidate in the given term. If a vote for another
+	// candidate was already recorded in the term, the vote is not recorded and an error is returned.
+	RecordVote(term Term, candidate MemberID) error
+
+	// LoadVote loads the vote recorded in the given term, or nil if no vote was recorded in the term
+	LoadVote(term Term) *MemberID
+
+	// LastTerm returns the highest term in which a vote was recorded
+	LastTerm() Term
+}
+
+// memoryVoteRecord implements VoteRecord in memory
+type memoryVoteRecord struct {
+	votes    map[Term]MemberID
+	lastTerm Term
+	mu       sync.Mutex
+}
+
+func (r *memoryVoteRecord) RecordVote(term Term, candidate MemberID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if vote, ok := r.votes[term]; ok {
+		if vote != candidate {
+			return fmt.Errorf("already voted for %s in term %d", vote, term)
+		}
+		return nil
+	}
+	r.votes[term] = candidate
+	if term > r.lastTerm {
+		r.lastTerm = term
+	}
+	return nil
+}
+
+func (r *memoryVoteRecord) LoadVote(term Term) *MemberID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if vote, ok := r.votes[term]; ok {
+		return &vote
+	}
+	return nil
+}
+
+func (r *memoryVoteRecord) LastTerm() Term {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastTerm
+}
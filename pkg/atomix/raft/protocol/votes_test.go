@@ -0,0 +1,37 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMemoryVoteRecord(t *testing.T) {
+	votes := newMemoryVoteRecord()
+	assert.Equal(t, Term(0), votes.LastTerm())
+	assert.Nil(t, votes.LoadVote(Term(1)))
+
+	// Verify a vote can be recorded once per term
+	assert.NoError(t, votes.RecordVote(Term(2), "foo"))
+	assert.NoError(t, votes.RecordVote(Term(2), "foo"))
+	assert.Error(t, votes.RecordVote(Term(2), "bar"))
+	assert.Equal(t, MemberID("foo"), *votes.LoadVote(Term(2)))
+
+	// Verify votes in earlier terms do not lower the last term
+	assert.NoError(t, votes.RecordVote(Term(1), "bar"))
+	assert.Equal(t, Term(2), votes.LastTerm())
+	assert.Equal(t, MemberID("bar"), *votes.LoadVote(Term(1)))
+}
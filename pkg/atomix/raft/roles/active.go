@@ -20,6 +20,7 @@ import (
 	"github.com/atomix/raft-replica/pkg/atomix/raft/state"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
+	"time"
 )
 
 func newActiveRole(raft raft.Raft, state state.Manager, store store.Store, log util.Logger) *ActiveRole {
@@ -33,6 +34,136 @@ type ActiveRole struct {
 	*PassiveRole
 }
 
+// randomElectionTimeout returns a random election timeout between the election timeout and the election
+// timeout lengthened by the configured election jitter, clamped to the configured max election timeout.
+// If the election timeout was lengthened by observed round trip times, the max election timeout is
+// lengthened by the same amount. Members with a lower election priority than other voting members are
+// further delayed in proportion to their priority.
+func (r *ActiveRole) randomElectionTimeout() time.Duration {
+	electionTimeout := r.raft.ElectionTimeout()
+	jitter := time.Duration(float64(electionTimeout) * float64(r.raft.Config().GetElectionJitterOrDefault()))
+	timeout := electionTimeout + r.raft.RandomDuration(jitter)
+	maxTimeout := r.raft.Config().GetMaxElectionTimeoutOrDefault()
+	if configTimeout := r.raft.ConfiguredElectionTimeout(); electionTimeout > configTimeout {
+		maxTimeout += electionTimeout - configTimeout
+	}
+	if timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+	return timeout + r.priorityDelay(electionTimeout)
+}
+
+// priorityDelay returns the time by which the local member delays elections so that voting members with
+// a higher election priority time out first. The highest priority voters are not delayed and the lowest
+// priority voters are delayed by a full election timeout, allowing them to win an election once higher
+// priority members are unavailable.
+func (r *ActiveRole) priorityDelay(electionTimeout time.Duration) time.Duration {
+	config := r.raft.Config()
+	if len(config.GetPriorities()) == 0 {
+		return 0
+	}
+	priority := config.GetPriority(string(r.raft.Member()))
+	minPriority, maxPriority := priority, priority
+	for _, member := range r.raft.VotingMembers() {
+		memberPriority := config.GetPriority(string(member))
+		if memberPriority < minPriority {
+			minPriority = memberPriority
+		}
+		if memberPriority > maxPriority {
+			maxPriority = memberPriority
+		}
+	}
+	if maxPriority == minPriority {
+		return 0
+	}
+	return time.Duration(float64(electionTimeout) * float64(maxPriority-priority) / float64(maxPriority-minPriority))
+}
+
+// isStabilizing returns whether the local member learned of a new leader within the configured leader
+// stabilization period, during which priority-based leadership reclamation is suppressed
+func (r *ActiveRole) isStabilizing() bool {
+	period := r.raft.Config().GetLeaderStabilizationPeriod()
+	if period == nil {
+		return false
+	}
+	lastElection := r.raft.LastElection()
+	return !lastElection.IsZero() && time.Since(lastElection) < *period
+}
+
+// hasPriority returns whether the given member has a higher election priority than the local member.
+// Priorities are ignored while leadership is stabilizing after an election.
+func (r *ActiveRole) hasPriority(member raft.MemberID) bool {
+	if r.isStabilizing() {
+		return false
+	}
+	config := r.raft.Config()
+	return config.GetPriority(string(member)) > config.GetPriority(string(r.raft.Member()))
+}
+
+// hasStickyLeader returns whether the local member knows of a leader other than the given candidate
+// and polls should be rejected in favor of that leader
+func (r *ActiveRole) hasStickyLeader(candidate raft.MemberID) bool {
+	if r.raft.Config().GetDisableStickyPolls() {
+		return false
+	}
+	leader := r.raft.Leader()
+	return leader != nil && *leader != candidate
+}
+
+// isConfigurationStale returns whether the given configuration index is older than the locally committed
+// configuration when vote configuration checks are enabled
+func (r *ActiveRole) isConfigurationStale(index raft.Index) bool {
+	if !r.raft.Config().GetCheckVoteConfiguration() {
+		return false
+	}
+	configuration, _ := r.raft.Configuration()
+	return configuration != nil && index < configuration.Index
+}
+
+// isPreferredOver returns whether the local member should be elected in preference to the given candidate.
+// When member priorities are configured and no leader is known, a member with a higher priority whose log is
+// at least as up-to-date as the candidate's log is preferred to minimize split votes at cold start.
+func (r *ActiveRole) isPreferredOver(candidate raft.MemberID, lastIndex raft.Index, lastTerm raft.Term) bool {
+	config := r.raft.Config()
+	if r.raft.Leader() != nil || config.GetPriority(string(r.raft.Member())) <= config.GetPriority(string(candidate)) {
+		return false
+	}
+
+	// A member whose apply loop is stalled will not campaign for leadership, so it must not hold up the election
+	if r.raft.ApplyStalled() {
+		return false
+	}
+
+	// Do not reclaim leadership until leadership has stabilized after the most recent election
+	if r.isStabilizing() {
+		r.log.Debug("Deferring priority to %s during leader stabilization period", candidate)
+		return false
+	}
+
+	lastEntry := r.store.Writer().LastEntry()
+	if lastEntry == nil {
+		return lastIndex == 0
+	}
+	return lastEntry.Entry.Term > lastTerm || (lastEntry.Entry.Term == lastTerm && lastEntry.Index >= lastIndex)
+}
+
+// ConfirmLeadership handles a leadership confirmation request
+func (r *ActiveRole) ConfirmLeadership(ctx context.Context, request *raft.ConfirmLeadershipRequest) (*raft.ConfirmLeadershipResponse, error) {
+	r.log.Request("ConfirmLeadershipRequest", request)
+	r.raft.WriteLock()
+	defer r.raft.WriteUnlock()
+
+	// If the request indicates a term that is greater than the current term or no leader is known for
+	// the current term, assign that term and leader to the current context and transition to follower.
+	if r.updateTermAndLeader(request.Term, &request.Leader) {
+		defer r.raft.SetRole(raft.RoleFollower)
+	}
+
+	response := r.handleConfirmLeadership(request)
+	_ = r.log.Response("ConfirmLeadershipResponse", response, nil)
+	return response, nil
+}
+
 // Append handles an append request
 func (r *ActiveRole) Append(ctx context.Context, request *raft.AppendRequest) (*raft.AppendResponse, error) {
 	r.log.Request("AppendRequest", request)
@@ -56,9 +187,20 @@ func (r *ActiveRole) Append(ctx context.Context, request *raft.AppendRequest) (*
 func (r *ActiveRole) Poll(ctx context.Context, request *raft.PollRequest) (*raft.PollResponse, error) {
 	r.log.Request("PollRequest", request)
 
-	// Acquire a write lock to update the leader and term.
+	// If the candidate has exceeded the election rate limit, reject the poll before it can update the term.
+	r.raft.ReadLock()
+	response := r.checkPollRateLimit(request)
+	r.raft.ReadUnlock()
+	if response != nil {
+		return response, nil
+	}
+
+	// Acquire a write lock to update the leader and term. If the poll will be rejected in favor of
+	// a known leader, don't adopt the candidate's term so the poll can't disrupt the current leader.
 	r.raft.WriteLock()
-	r.updateTermAndLeader(request.Term, nil)
+	if !r.hasStickyLeader(request.Candidate) {
+		r.updateTermAndLeader(request.Term, nil)
+	}
 	r.raft.WriteUnlock()
 
 	// Acquire a read lock to vote for the follower.
@@ -69,6 +211,25 @@ func (r *ActiveRole) Poll(ctx context.Context, request *raft.PollRequest) (*raft
 	return response, err
 }
 
+// checkPollRateLimit returns a rate limited response if the candidate has exceeded the configured election
+// rate limit, or nil if the poll is allowed. The caller must hold a lock on the Raft state.
+func (r *ActiveRole) checkPollRateLimit(request *raft.PollRequest) *raft.PollResponse {
+	backoff := r.raft.ElectionBackoff(request.Candidate)
+	if backoff == 0 {
+		return nil
+	}
+	r.log.Debug("Rejected %v: candidate exceeded the election rate limit", request)
+	response := &raft.PollResponse{
+		Status:     raft.ResponseStatus_ERROR,
+		Error:      raft.ResponseError_RATE_LIMITED,
+		Term:       r.raft.Term(),
+		Accepted:   false,
+		RetryAfter: backoff,
+	}
+	_ = r.log.Response("PollResponse", response, nil)
+	return response
+}
+
 // handlePoll handles a poll request
 func (r *ActiveRole) handlePoll(ctx context.Context, request *raft.PollRequest) (*raft.PollResponse, error) {
 	// If the request term is not as great as the current context term then don't
@@ -81,6 +242,22 @@ func (r *ActiveRole) handlePoll(ctx context.Context, request *raft.PollRequest)
 			Term:     r.raft.Term(),
 			Accepted: false,
 		}, nil
+	} else if r.hasStickyLeader(request.Candidate) {
+		// If the local member still has a leader then reject the poll to prevent a
+		// partitioned member from passing pre-vote against a healthy majority.
+		r.log.Debug("Rejected %v: leader already exists", request)
+		return &raft.PollResponse{
+			Status:   raft.ResponseStatus_OK,
+			Term:     r.raft.Term(),
+			Accepted: false,
+		}, nil
+	} else if r.isPreferredOver(request.Candidate, request.LastLogIndex, request.LastLogTerm) {
+		r.log.Debug("Rejected %v: local member has a higher priority than the candidate", request)
+		return &raft.PollResponse{
+			Status:   raft.ResponseStatus_OK,
+			Term:     r.raft.Term(),
+			Accepted: false,
+		}, nil
 	} else if r.isLogUpToDate(request.LastLogIndex, request.LastLogTerm, request) {
 		return &raft.PollResponse{
 			Status:   raft.ResponseStatus_OK,
@@ -137,6 +314,16 @@ func (r *ActiveRole) Vote(ctx context.Context, request *raft.VoteRequest) (*raft
 	r.raft.WriteLock()
 	defer r.raft.WriteUnlock()
 
+	// If the role is stopping, decline to vote.
+	if response := r.checkVoteActive(); response != nil {
+		return response, nil
+	}
+
+	// If the candidate has exceeded the election rate limit, reject the vote before it can update the term.
+	if response := r.checkVoteRateLimit(request); response != nil {
+		return response, nil
+	}
+
 	// If the request indicates a term that is greater than the current term then
 	// assign that term and leader to the current context.
 	if r.updateTermAndLeader(request.Term, nil) {
@@ -148,6 +335,43 @@ func (r *ActiveRole) Vote(ctx context.Context, request *raft.VoteRequest) (*raft
 	return response, err
 }
 
+// checkVoteActive returns an error response if the role has been stopped, or nil if the role is active.
+// A stopping member declines to vote without updating its term or vote record to avoid influencing an
+// election in which it won't participate. The caller must hold a write lock on the Raft state.
+func (r *ActiveRole) checkVoteActive() *raft.VoteResponse {
+	if r.active {
+		return nil
+	}
+	r.log.Debug("Declining to vote: member is shutting down")
+	response := &raft.VoteResponse{
+		Status: raft.ResponseStatus_ERROR,
+		Error:  raft.ResponseError_UNAVAILABLE,
+		Term:   r.raft.Term(),
+		Voted:  false,
+	}
+	_ = r.log.Response("VoteResponse", response, nil)
+	return response
+}
+
+// checkVoteRateLimit returns a rate limited response if the candidate has exceeded the configured election
+// rate limit, or nil if the vote is allowed. The caller must hold a lock on the Raft state.
+func (r *ActiveRole) checkVoteRateLimit(request *raft.VoteRequest) *raft.VoteResponse {
+	backoff := r.raft.ElectionBackoff(request.Candidate)
+	if backoff == 0 {
+		return nil
+	}
+	r.log.Debug("Rejected %v: candidate exceeded the election rate limit", request)
+	response := &raft.VoteResponse{
+		Status:     raft.ResponseStatus_ERROR,
+		Error:      raft.ResponseError_RATE_LIMITED,
+		Term:       r.raft.Term(),
+		Voted:      false,
+		RetryAfter: backoff,
+	}
+	_ = r.log.Response("VoteResponse", response, nil)
+	return response
+}
+
 // handleVote handles a vote request
 func (r *ActiveRole) handleVote(ctx context.Context, request *raft.VoteRequest) (*raft.VoteResponse, error) {
 	if request.Term < r.raft.Term() {
@@ -160,6 +384,15 @@ func (r *ActiveRole) handleVote(ctx context.Context, request *raft.VoteRequest)
 			Term:   r.raft.Term(),
 			Voted:  false,
 		}, nil
+	} else if request.Term > r.raft.Term() {
+		// If the request term is greater than the current term, the term could not be persisted. Don't
+		// vote in a term the local member may forget after a crash.
+		r.log.Debug("Rejected %+v: failed to store the candidate's term", request)
+		return &raft.VoteResponse{
+			Status: raft.ResponseStatus_OK,
+			Term:   r.raft.Term(),
+			Voted:  false,
+		}, nil
 	} else if r.raft.Leader() != nil {
 		// If a leader was already determined for this term then reject the request.
 		r.log.Debug("Rejected %+v: leader already exists", request)
@@ -177,6 +410,15 @@ func (r *ActiveRole) handleVote(ctx context.Context, request *raft.VoteRequest)
 			Term:   r.raft.Term(),
 			Voted:  false,
 		}, nil
+	} else if r.isConfigurationStale(request.ConfigurationIndex) {
+		// If the candidate's configuration is older than the committed configuration, the candidate
+		// missed a membership change and must not become the leader.
+		r.log.Debug("Rejected %+v: candidate's configuration is older than the committed configuration", request)
+		return &raft.VoteResponse{
+			Status: raft.ResponseStatus_OK,
+			Term:   r.raft.Term(),
+			Voted:  false,
+		}, nil
 	} else if r.raft.LastVotedFor() == nil {
 		// If no vote has been cast, check the log and cast a vote if necessary.
 		if r.isLogUpToDate(request.LastLogIndex, request.LastLogTerm, request) {
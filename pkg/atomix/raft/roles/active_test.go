@@ -16,11 +16,13 @@ package roles
 
 import (
 	"context"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/protocol/mock"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
 	"testing"
 	"time"
 )
@@ -100,7 +102,22 @@ func TestActivePoll(t *testing.T) {
 	assert.False(t, response.Accepted)
 	assert.Equal(t, raft.Term(2), response.Term)
 
+	// Test rejecting a poll while a leader is known
+	response, err = role.Poll(context.TODO(), &raft.PollRequest{
+		Term:         3,
+		Candidate:    "baz",
+		LastLogIndex: 10,
+		LastLogTerm:  2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.False(t, response.Accepted)
+	assert.Equal(t, raft.Term(2), response.Term)
+	assert.Equal(t, raft.Term(2), role.raft.Term())
+	assert.Equal(t, &bar, role.raft.Leader())
+
 	// Test that the node votes if there are no entries in its log
+	assert.NoError(t, role.raft.SetLeader(nil))
 	response, err = role.Poll(context.TODO(), &raft.PollRequest{
 		Term:         2,
 		Candidate:    "baz",
@@ -358,3 +375,197 @@ func TestActiveVote(t *testing.T) {
 	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
 	assert.False(t, response.Voted)
 }
+
+func TestActiveVoteStaleConfiguration(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	protocol.Config().CheckVoteConfiguration = true
+	role := newActiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	// Commit a configuration at index 5
+	role.raft.WriteLock()
+	role.raft.Commit(5, 5)
+	role.raft.SetConfiguration(&raft.Configuration{
+		Index: 5,
+		Term:  1,
+		Members: []*raft.Member{
+			{MemberID: "foo", Type: raft.Member_ACTIVE},
+			{MemberID: "bar", Type: raft.Member_ACTIVE},
+			{MemberID: "baz", Type: raft.Member_ACTIVE},
+		},
+	})
+	role.raft.WriteUnlock()
+
+	// Test that the node rejects a candidate that missed the committed configuration change
+	response, err := role.Vote(context.TODO(), &raft.VoteRequest{
+		Term:               1,
+		Candidate:          "bar",
+		ConfigurationIndex: 4,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.False(t, response.Voted)
+
+	// Test that the node votes for a candidate with the committed configuration
+	response, err = role.Vote(context.TODO(), &raft.VoteRequest{
+		Term:               1,
+		Candidate:          "bar",
+		ConfigurationIndex: 5,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.True(t, response.Voted)
+}
+
+func TestActiveMaxElectionTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newActiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	electionTimeout := protocol.Config().GetElectionTimeoutOrDefault()
+	maxElectionTimeout := electionTimeout + electionTimeout/4
+	protocol.Config().MaxElectionTimeout = &maxElectionTimeout
+	for i := 0; i < 1000; i++ {
+		timeout := role.randomElectionTimeout()
+		assert.True(t, timeout >= electionTimeout)
+		assert.True(t, timeout <= maxElectionTimeout)
+	}
+}
+
+func TestActiveElectionJitter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newActiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	// Verify the election timeout is randomized within the configured jitter
+	electionTimeout := protocol.Config().GetElectionTimeoutOrDefault()
+	protocol.Config().ElectionJitter = .5
+	for i := 0; i < 1000; i++ {
+		timeout := role.randomElectionTimeout()
+		assert.True(t, timeout >= electionTimeout)
+		assert.True(t, timeout < electionTimeout+electionTimeout/2)
+	}
+
+	// Verify election timeouts drawn from sources with the same seed are identical
+	timeouts := make([]time.Duration, 10)
+	protocol.SetRandomSource(rand.NewSource(1))
+	for i := range timeouts {
+		timeouts[i] = role.randomElectionTimeout()
+	}
+	protocol.SetRandomSource(rand.NewSource(1))
+	for i := range timeouts {
+		assert.Equal(t, timeouts[i], role.randomElectionTimeout())
+	}
+}
+
+func TestActiveElectionPriority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newActiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	electionTimeout := protocol.Config().GetElectionTimeoutOrDefault()
+	maxElectionTimeout := protocol.Config().GetMaxElectionTimeoutOrDefault()
+	assertTimeout := func(delay time.Duration) {
+		for i := 0; i < 1000; i++ {
+			timeout := role.randomElectionTimeout()
+			assert.True(t, timeout >= electionTimeout+delay)
+			assert.True(t, timeout <= maxElectionTimeout+delay)
+		}
+	}
+
+	// Verify the highest priority member is not delayed
+	protocol.Config().Priorities = map[string]int32{
+		"foo": 2,
+		"bar": 1,
+	}
+	assertTimeout(0)
+
+	// Verify lower priority members are delayed in proportion to their priority
+	protocol.Config().Priorities = map[string]int32{
+		"foo": 1,
+		"bar": 2,
+	}
+	assertTimeout(electionTimeout / 2)
+
+	// Verify the lowest priority member is delayed by at most one election timeout
+	protocol.Config().Priorities = map[string]int32{
+		"bar": 2,
+		"baz": 1,
+	}
+	assertTimeout(electionTimeout)
+}
+
+func TestActiveAdaptiveElectionTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newActiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	electionTimeout := protocol.Config().GetElectionTimeoutOrDefault()
+	maxElectionTimeout := electionTimeout + electionTimeout/4
+	maxAdaptiveTimeout := electionTimeout * 3
+	protocol.Config().MaxElectionTimeout = &maxElectionTimeout
+	protocol.Config().AdaptiveElectionTimeout = &config.AdaptiveTimeoutConfig{
+		RttMultiplier: 2,
+		MaxTimeout:    &maxAdaptiveTimeout,
+	}
+
+	// Verify slow round trips lengthen the randomized election timeout, preserving the randomization range
+	protocol.ObserveRoundTrip("bar", electionTimeout)
+	for i := 0; i < 1000; i++ {
+		timeout := role.randomElectionTimeout()
+		assert.True(t, timeout >= electionTimeout*2)
+		assert.True(t, timeout <= maxElectionTimeout+electionTimeout)
+	}
+
+	// Verify the randomized election timeout is bounded by the max adaptive timeout
+	protocol.ObserveRoundTrip("bar", electionTimeout*10)
+	for i := 0; i < 1000; i++ {
+		timeout := role.randomElectionTimeout()
+		assert.True(t, timeout >= maxAdaptiveTimeout)
+		assert.True(t, timeout <= maxElectionTimeout+maxAdaptiveTimeout-electionTimeout)
+	}
+}
+
+func TestActivePollStabilizationPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	protocol, sm, stores := newTestState(client)
+	stabilizationPeriod := 500 * time.Millisecond
+	protocol.Config().Priorities = map[string]int32{
+		"foo": 2,
+		"bar": 1,
+	}
+	protocol.Config().LeaderStabilizationPeriod = &stabilizationPeriod
+	role := newActiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	// Learn of a newly elected lower priority leader, then lose it
+	bar := raft.MemberID("bar")
+	assert.NoError(t, role.raft.SetTerm(1))
+	assert.NoError(t, role.raft.SetLeader(&bar))
+	assert.NoError(t, role.raft.SetTerm(2))
+	assert.Nil(t, role.raft.Leader())
+
+	// Verify the preferred member does not reclaim leadership during the stabilization period
+	response, err := role.Poll(context.TODO(), &raft.PollRequest{
+		Term:         2,
+		Candidate:    "bar",
+		LastLogIndex: 0,
+		LastLogTerm:  0,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.True(t, response.Accepted)
+
+	// Verify the preferred member rejects lower priority candidates once the stabilization period has elapsed
+	time.Sleep(time.Until(role.raft.LastElection().Add(stabilizationPeriod)))
+	response, err = role.Poll(context.TODO(), &raft.PollRequest{
+		Term:         2,
+		Candidate:    "bar",
+		LastLogIndex: 0,
+		LastLogTerm:  0,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.False(t, response.Accepted)
+}
@@ -24,21 +24,31 @@ import (
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store/log"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store/snapshot"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
+	"hash/crc32"
 	"io"
+	"io/ioutil"
 	"math"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// errNotLeader is returned when the leader steps down before an entry is committed
+var errNotLeader = errors.New("leader stepped down")
+
 // newAppender returns a new appender
 func newAppender(state raft.Raft, sm state.Manager, store store.Store, log util.Logger) *raftAppender {
 	commitCh := make(chan memberCommit)
 	failCh := make(chan time.Time)
 	members := make(map[raft.MemberID]*memberAppender)
+	voters := make(map[raft.MemberID]bool)
 	for _, memberID := range state.Members() {
 		if memberID != state.Member() {
 			members[memberID] = newMemberAppender(state, sm, store, log, state.GetMember(memberID), commitCh, failCh)
+			if state.IsVoter(memberID) {
+				voters[memberID] = true
+			}
 		}
 	}
 	appender := &raftAppender{
@@ -47,15 +57,22 @@ func newAppender(state raft.Raft, sm state.Manager, store store.Store, log util.
 		store:            store,
 		log:              log,
 		members:          members,
+		voters:           voters,
+		voterSets:        state.VoterSets(),
 		commitIndexes:    make(map[raft.MemberID]raft.Index),
+		matchCh:          make(chan struct{}),
 		commitTimes:      make(map[raft.MemberID]time.Time),
+		clockSkews:       make(map[raft.MemberID]time.Duration),
 		heartbeatFutures: list.New(),
 		commitChannels:   make(map[raft.Index]chan bool),
 		commitFutures:    make(map[raft.Index]func()),
 		commitCh:         commitCh,
 		failCh:           failCh,
 		lastQuorumTime:   time.Now(),
-		stopped:          make(chan bool),
+		done:             make(chan struct{}),
+	}
+	for _, member := range members {
+		member.appender = appender
 	}
 	return appender
 }
@@ -67,15 +84,21 @@ type raftAppender struct {
 	store            store.Store
 	log              util.Logger
 	members          map[raft.MemberID]*memberAppender
+	voters           map[raft.MemberID]bool
+	voterSets        [][]raft.MemberID
 	commitIndexes    map[raft.MemberID]raft.Index
+	matchCh          chan struct{}
 	commitTimes      map[raft.MemberID]time.Time
+	clockSkews       map[raft.MemberID]time.Duration
 	heartbeatFutures *list.List
 	commitChannels   map[raft.Index]chan bool
 	commitFutures    map[raft.Index]func()
 	commitCh         chan memberCommit
 	failCh           chan time.Time
-	stopped          chan bool
+	done             chan struct{}
 	lastQuorumTime   time.Time
+	termIndex        raft.Index
+	quiet            bool
 	mu               sync.Mutex
 }
 
@@ -84,26 +107,130 @@ func (a *raftAppender) start() {
 	for _, member := range a.members {
 		go member.start()
 	}
+	if a.raft.Config().GetCheckQuorum() {
+		go a.checkQuorum()
+	}
 	a.processCommits()
 }
 
+// updateVoters updates the followers counted towards quorums from the latest configuration.
+// The caller must hold a lock on the Raft state.
+func (a *raftAppender) updateVoters() {
+	voters := make(map[raft.MemberID]bool)
+	for member := range a.members {
+		if a.raft.IsVoter(member) {
+			voters[member] = true
+		}
+	}
+	voterSets := a.raft.VoterSets()
+	a.mu.Lock()
+	a.voters = voters
+	a.voterSets = voterSets
+	a.mu.Unlock()
+}
+
+// quorumValue returns the greatest value reached by a majority of each voter set, given the value reached by
+// each follower. The leader is counted as having reached any value in the sets it belongs to. During a joint
+// configuration change, a value is only reached by a quorum once it's reached by majorities of both the old
+// and the new configurations.
+func (a *raftAppender) quorumValue(value func(raft.MemberID) int64) int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	quorumValue := int64(math.MaxInt64)
+	for _, voters := range a.voterSets {
+		if len(voters) == 0 {
+			continue
+		}
+		values := make([]int64, len(voters))
+		for i, voter := range voters {
+			if voter == a.raft.Member() {
+				values[i] = math.MaxInt64
+			} else {
+				values[i] = value(voter)
+			}
+		}
+		sort.Slice(values, func(i, j int) bool {
+			return values[i] > values[j]
+		})
+		if majorityValue := values[len(voters)/2]; majorityValue < quorumValue {
+			quorumValue = majorityValue
+		}
+	}
+	return quorumValue
+}
+
+// votingMembers returns the followers that are counted towards quorums. Learners are sent entries
+// and heartbeats but are not counted towards quorums.
+func (a *raftAppender) votingMembers() []raft.MemberID {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	members := make([]raft.MemberID, 0, len(a.voters))
+	for member := range a.voters {
+		members = append(members, member)
+	}
+	return members
+}
+
+// isVoter returns whether the given follower is counted towards quorums
+func (a *raftAppender) isVoter(member raft.MemberID) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.voters[member]
+}
+
 // heartbeat sends a heartbeat to a majority of followers
 func (a *raftAppender) heartbeat() error {
-	// If there are no members to send the entry to, immediately return.
-	if len(a.members) == 0 {
+	// If there are no voting members to send the heartbeat to, the leader is a quorum of itself.
+	voters := a.votingMembers()
+	if len(voters) == 0 {
 		return nil
 	}
 
+	// If leadership confirmations are enabled, confirm leadership with a dedicated request rather than an append.
+	if a.raft.Config().GetReadIndex().GetConfirmLeadership() {
+		return a.confirmLeadership(voters)
+	}
+
 	future := newHeartbeatFuture()
 
-	// Acquire a lock to add the future to the heartbeat futures.
+	// Acquire a lock to add the future to the heartbeat futures. If the appender has been stopped,
+	// leadership can no longer be verified.
 	a.mu.Lock()
+	select {
+	case <-a.done:
+		a.mu.Unlock()
+		return errors.New("failed to verify quorum: leader stepped down")
+	default:
+	}
 	a.heartbeatFutures.PushBack(future)
 	a.mu.Unlock()
 
-	// Iterate through member appenders and add the future time to the heartbeat channels.
-	for _, member := range a.members {
-		member.heartbeatCh <- future.time
+	// If local zone followers are preferred and can form a quorum with the leader, send the heartbeat
+	// to local zone followers first and wait for them to confirm the quorum before including remote followers.
+	local, remote := a.zoneMembers()
+	if a.raft.Config().GetReadIndex().GetPreferLocalZone() && len(local)+1 > (len(voters)+1)/2 && len(remote) > 0 {
+		for _, member := range local {
+			a.sendHeartbeat(member, future)
+		}
+		timer := time.NewTimer(a.raft.Config().GetLocalZoneWaitOrDefault())
+		select {
+		case _, ok := <-future.ch:
+			timer.Stop()
+			if ok {
+				return nil
+			}
+			return errors.New("failed to verify quorum")
+		case <-timer.C:
+			a.log.Debug("Local zone followers did not confirm quorum; sending heartbeat to remote followers")
+		}
+		for _, member := range remote {
+			a.sendHeartbeat(member, future)
+		}
+	} else {
+		// Iterate through member appenders and add the future time to the heartbeat channels.
+		for _, member := range a.members {
+			a.sendHeartbeat(member, future)
+		}
 	}
 	_, ok := <-future.ch
 	if ok {
@@ -112,37 +239,148 @@ func (a *raftAppender) heartbeat() error {
 	return errors.New("failed to verify quorum")
 }
 
-// commit replicates the given entry to followers and returns once the entry is committed
-func (a *raftAppender) commit(entry *log.Entry, f func()) error {
-	// If there are no members to send the entry to, immediately commit it.
-	if len(a.members) == 0 {
-		a.raft.WriteLock()
-		a.raft.SetCommitIndex(entry.Index)
-		a.raft.Commit(entry.Index)
-		if f != nil {
-			f()
+// confirmLeadership sends a lightweight leadership confirmation request to the given voting followers,
+// returning once a majority of the voting members has acknowledged the leader for the current term
+func (a *raftAppender) confirmLeadership(voters []raft.MemberID) error {
+	a.raft.ReadLock()
+	request := &raft.ConfirmLeadershipRequest{
+		Term:   a.raft.Term(),
+		Leader: a.raft.Member(),
+	}
+	a.raft.ReadUnlock()
+
+	// Followers that do not respond within the election timeout are counted as failing to confirm leadership.
+	ctx, cancel := context.WithTimeout(context.Background(), a.raft.ConfiguredElectionTimeout())
+	defer cancel()
+
+	type confirmation struct {
+		member   *memberAppender
+		response *raft.ConfirmLeadershipResponse
+	}
+	confirmations := make(chan confirmation, len(voters))
+	for _, id := range voters {
+		go func(id raft.MemberID, member *memberAppender) {
+			a.log.Send("ConfirmLeadershipRequest", request)
+			response, err := a.raft.Protocol().ConfirmLeadership(ctx, request, id)
+			if err != nil {
+				a.log.ErrorFrom("ConfirmLeadershipRequest", err, id)
+			} else {
+				a.log.Receive("ConfirmLeadershipResponse", response)
+			}
+			confirmations <- confirmation{member: member, response: response}
+		}(id, a.members[id])
+	}
+
+	// Count the leader's own confirmation towards the quorum.
+	quorum := (len(voters)+1)/2 + 1
+	confirmed := 1
+	for received := 0; received < len(voters); received++ {
+		select {
+		case confirmation := <-confirmations:
+			response := confirmation.response
+			if response == nil || response.Status != raft.ResponseStatus_OK {
+				continue
+			}
+			if confirmation.member.stepDownIfNewerTerm(response.Term) {
+				return errors.New("failed to verify quorum: leader stepped down")
+			}
+			if response.Confirmed {
+				confirmed++
+				if confirmed >= quorum {
+					return nil
+				}
+			}
+		case <-a.done:
+			return errors.New("failed to verify quorum: leader stepped down")
 		}
-		a.raft.WriteUnlock()
-		return nil
 	}
+	return errors.New("failed to verify quorum")
+}
 
-	// Acquire a write lock on the appender and add the channel to commitFutures.
+// sendHeartbeat passes the given heartbeat to the given member appender unless the appender is stopped
+func (a *raftAppender) sendHeartbeat(member *memberAppender, future heartbeatFuture) {
+	select {
+	case member.heartbeatCh <- future.time:
+	case <-a.done:
+	}
+}
+
+// zoneMembers returns the member appenders in the leader's zone and the member appenders in other zones.
+// If the leader is not assigned a zone, all members are considered remote.
+func (a *raftAppender) zoneMembers() ([]*memberAppender, []*memberAppender) {
+	config := a.raft.Config()
+	zone := config.GetZone(string(a.raft.Member()))
+	local := make([]*memberAppender, 0, len(a.members))
+	remote := make([]*memberAppender, 0, len(a.members))
+	for id, member := range a.members {
+		if zone != "" && config.GetZone(string(id)) == zone {
+			local = append(local, member)
+		} else {
+			remote = append(remote, member)
+		}
+	}
+	return local, remote
+}
+
+// register registers the given entry to be completed once committed, returning a channel on which the result of
+// the commit is sent. If a function is provided, it's called once the entry is committed, in the order in which
+// entries are committed. Entries must be registered while holding the write lock under which they were appended
+// to the log: once the lock is released, the entry may be replicated along with earlier entries and committed
+// before it's registered, in which case it would never be completed.
+func (a *raftAppender) register(entry *log.Entry, f func()) <-chan bool {
+	// The channel is buffered to ensure commits are not blocked while the entry is pushed to members
+	// that are applying backpressure.
+	ch := make(chan bool, 1)
 	a.mu.Lock()
-	ch := make(chan bool)
+	if a.termIndex == 0 && entry.Entry.Term == a.raft.Term() {
+		a.termIndex = entry.Index
+	}
 	a.commitChannels[entry.Index] = ch
 	if f != nil {
 		a.commitFutures[entry.Index] = f
 	}
 	a.mu.Unlock()
+	return ch
+}
+
+// commit replicates the given registered entry to followers and returns once the entry is committed
+func (a *raftAppender) commit(entry *log.Entry, ch <-chan bool) error {
+	if len(a.votingMembers()) == 0 {
+		// If there are no voting members to send the entry to, immediately commit it along with any entries
+		// preceding it that have not yet been committed. The entry is still replicated to learners.
+		a.raft.WriteLock()
+		if entry.Index > a.raft.QuorumIndex() {
+			a.raft.SetQuorumIndex(entry.Index)
+		}
+		for i := a.raft.CommitIndex() + 1; i <= entry.Index; i++ {
+			a.commitIndex(i)
+		}
+		a.raft.WriteUnlock()
+	}
 
-	// Push the entry onto the channel for each member appender
+	// Push the entry onto the channel for each member appender. Members that are applying backpressure
+	// are pushed the entry last to avoid delaying replication to the members that can form a quorum.
+	var blocked []*memberAppender
 	for _, member := range a.members {
-		member.entryCh <- entry
+		select {
+		case member.entryCh <- entry:
+		default:
+			blocked = append(blocked, member)
+		}
+	}
+	for _, member := range blocked {
+		select {
+		case member.entryCh <- entry:
+		case <-a.done:
+		}
 	}
 
-	// Wait for the commit channel.
+	// Wait for the commit channel. The channel is closed if the leader steps down before the entry is committed.
 	succeeded, ok := <-ch
-	if ok && succeeded {
+	if !ok {
+		return errNotLeader
+	}
+	if succeeded {
 		return nil
 	}
 	return errors.New("failed to commit entry")
@@ -153,10 +391,14 @@ func (a *raftAppender) processCommits() {
 	for {
 		select {
 		case commit := <-a.commitCh:
-			a.commitMember(commit.member, commit.index, commit.time)
+			if commit.reset {
+				a.resetMember(commit.member, commit.index)
+			} else {
+				a.commitMember(commit.member, commit.index, commit.time)
+			}
 		case failTime := <-a.failCh:
 			a.failTime(failTime)
-		case <-a.stopped:
+		case <-a.done:
 			return
 		}
 	}
@@ -170,32 +412,50 @@ func (a *raftAppender) commitMember(member *memberAppender, index raft.Index, ti
 	a.commitMemberTime(member.member.MemberID, time)
 }
 
+// resetMember lowers the recorded match index for a member whose log diverged from the leader's log
+// to ensure entries the member no longer stores are not counted towards the commit index
+func (a *raftAppender) resetMember(member *memberAppender, index raft.Index) {
+	if index < a.commitIndexes[member.member.MemberID] {
+		a.log.Debug("Reset commit index for %s to %d", member.member.MemberID, index)
+		a.mu.Lock()
+		a.commitIndexes[member.member.MemberID] = index
+		a.mu.Unlock()
+	}
+}
+
 func (a *raftAppender) commitMemberIndex(member raft.MemberID, index raft.Index) {
 	prevIndex := a.commitIndexes[member]
 	if index > prevIndex {
+		// Notify goroutines awaiting the member's match index of the change.
+		a.mu.Lock()
 		a.commitIndexes[member] = index
+		close(a.matchCh)
+		a.matchCh = make(chan struct{})
+		a.mu.Unlock()
 
-		indexes := make([]raft.Index, len(a.members))
-		i := 0
-		for _, index := range a.commitIndexes {
-			indexes[i] = index
-			i++
+		// Only voting members are counted towards the quorum index. Entries are committed without
+		// waiting for learners.
+		if len(a.votingMembers()) == 0 {
+			return
 		}
-		sort.Slice(indexes, func(i, j int) bool {
-			return indexes[i] < indexes[j]
-		})
-
-		commitIndex := indexes[len(a.members)/2]
+		quorumIndex := raft.Index(a.quorumValue(func(voter raft.MemberID) int64 {
+			return int64(a.commitIndexes[voter])
+		}))
 		a.raft.ReadLock()
-		if commitIndex > a.raft.CommitIndex() {
+		if quorumIndex != a.raft.QuorumIndex() || quorumIndex > a.raft.CommitIndex() {
 			a.raft.ReadUnlock()
 			a.raft.WriteLock()
-			if commitIndex > a.raft.CommitIndex() {
-				for i := a.raft.CommitIndex() + 1; i <= commitIndex; i++ {
+			a.raft.SetQuorumIndex(quorumIndex)
+
+			// Only entries from the leader's current term are committed by counting replicas. Entries
+			// from prior terms are committed once an entry from the current term is committed.
+			if quorumIndex > a.raft.CommitIndex() && a.isCurrentTerm(quorumIndex) {
+				a.raft.Metrics().AddCommitted(quorumIndex - a.raft.CommitIndex())
+				for i := a.raft.CommitIndex() + 1; i <= quorumIndex; i++ {
 					a.commitIndex(i)
 				}
 				a.raft.WriteUnlock()
-				a.log.Trace("Committed entries up to %d", commitIndex)
+				a.log.Trace("Committed entries up to %d", quorumIndex)
 			} else {
 				a.raft.WriteUnlock()
 			}
@@ -205,12 +465,25 @@ func (a *raftAppender) commitMemberIndex(member raft.MemberID, index raft.Index)
 	}
 }
 
+// isCurrentTerm returns whether the entry at the given index was appended in the leader's current term. The
+// leader only appends entries in its own term, so every entry from the first registered entry of the term on
+// is from the current term.
+func (a *raftAppender) isCurrentTerm(index raft.Index) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.termIndex != 0 && index >= a.termIndex
+}
+
 func (a *raftAppender) commitIndex(index raft.Index) {
 	// Update the server commit index.
 	a.raft.SetCommitIndex(index)
-	a.raft.Commit(index)
+	a.raft.Commit(index, a.store.Writer().LastIndex())
+	if a.raft.CommitIndex() < index {
+		return
+	}
 
-	// Acquire a lock on the appender and complete the commit channels and futures.
+	// Acquire a lock on the appender and complete the commit channels and futures. A commit may complete
+	// a range of entries, each of which may have a waiter.
 	a.mu.Lock()
 	ch, ok := a.commitChannels[index]
 	if ok {
@@ -225,23 +498,20 @@ func (a *raftAppender) commitIndex(index raft.Index) {
 	a.mu.Unlock()
 }
 
-func (a *raftAppender) commitMemberTime(member raft.MemberID, time time.Time) {
+func (a *raftAppender) commitMemberTime(member raft.MemberID, nextTime time.Time) {
 	prevTime := a.commitTimes[member]
-	nextTime := time
 	if nextTime.UnixNano() > prevTime.UnixNano() {
+		a.mu.Lock()
 		a.commitTimes[member] = nextTime
+		a.mu.Unlock()
 
-		times := make([]int64, len(a.members))
-		i := 0
-		for _, t := range a.commitTimes {
-			times[i] = t.UnixNano()
-			i++
+		// Only voting members are counted towards the quorum that confirms heartbeats.
+		if len(a.votingMembers()) == 0 {
+			return
 		}
-		sort.Slice(times, func(i, j int) bool {
-			return times[i] < times[j]
+		commitTime := a.quorumValue(func(voter raft.MemberID) int64 {
+			return a.commitTimes[voter].UnixNano()
 		})
-
-		commitTime := times[len(a.members)/2]
 		a.mu.Lock()
 		for commitFuture := a.heartbeatFutures.Front(); commitFuture != nil && commitFuture.Value.(heartbeatFuture).time.UnixNano() < commitTime; commitFuture = a.heartbeatFutures.Front() {
 			ch := commitFuture.Value.(heartbeatFuture).ch
@@ -252,12 +522,172 @@ func (a *raftAppender) commitMemberTime(member raft.MemberID, time time.Time) {
 		a.mu.Unlock()
 
 		// Update the last time a quorum of the cluster was reached
-		a.lastQuorumTime = time
+		a.mu.Lock()
+		quorumTime := time.Unix(0, commitTime)
+		reached := quorumTime.After(a.lastQuorumTime)
+		if reached {
+			a.lastQuorumTime = quorumTime
+		}
+		a.mu.Unlock()
+
+		// Record the heartbeat for health checks when a quorum acknowledges the leader
+		if reached {
+			a.raft.ReadLock()
+			commitIndex := a.raft.CommitIndex()
+			a.raft.ReadUnlock()
+			a.raft.Heartbeat(commitIndex)
+		}
 	}
 }
 
+// matchIndex returns the highest index known to be replicated to the given member
+func (a *raftAppender) matchIndex(member raft.MemberID) raft.Index {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.commitIndexes[member]
+}
+
+// awaitMatchIndex blocks until the given index is known to be replicated to the given member, returning
+// an error if the context is cancelled or the appender is stopped first
+func (a *raftAppender) awaitMatchIndex(ctx context.Context, member raft.MemberID, index raft.Index) error {
+	for {
+		a.mu.Lock()
+		if a.commitIndexes[member] >= index {
+			a.mu.Unlock()
+			return nil
+		}
+		ch := a.matchCh
+		a.mu.Unlock()
+
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-a.done:
+			return errors.New("leader stepped down")
+		}
+	}
+}
+
+// isReachable returns whether the given member responded to the leader within the election timeout
+func (a *raftAppender) isReachable(member raft.MemberID) bool {
+	if member == a.raft.Member() {
+		return true
+	}
+	a.mu.Lock()
+	commitTime, ok := a.commitTimes[member]
+	a.mu.Unlock()
+	return ok && time.Since(commitTime) < a.raft.ConfiguredElectionTimeout()
+}
+
+// maxClockDrift returns the maximum clock skew tolerated between the leader and followers. If no drift
+// bound is configured, clocks are not exchanged with followers.
+func (a *raftAppender) maxClockDrift() time.Duration {
+	drift := a.raft.Config().GetMaxClockDrift()
+	if drift == nil {
+		return 0
+	}
+	return *drift
+}
+
+// updateClockSkew records the estimated skew between the leader's clock and the given member's clock,
+// warning when the skew exceeds the configured drift bound
+func (a *raftAppender) updateClockSkew(member raft.MemberID, skew time.Duration) {
+	maxDrift := a.maxClockDrift()
+	a.mu.Lock()
+	prevSkew := a.clockSkews[member]
+	a.clockSkews[member] = skew
+	a.mu.Unlock()
+	if exceedsDrift(skew, maxDrift) && !exceedsDrift(prevSkew, maxDrift) {
+		a.log.Warn("Clock skew with %s of %s exceeds the maximum drift of %s; lease reads are disabled", member, skew, maxDrift)
+	} else if !exceedsDrift(skew, maxDrift) && exceedsDrift(prevSkew, maxDrift) {
+		a.log.Debug("Clock skew with %s of %s is within the maximum drift of %s", member, skew, maxDrift)
+	}
+}
+
+// isClockSkewed returns whether the clock of any member is skewed beyond the configured drift bound
+func (a *raftAppender) isClockSkewed() bool {
+	maxDrift := a.maxClockDrift()
+	if maxDrift == 0 {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, skew := range a.clockSkews {
+		if exceedsDrift(skew, maxDrift) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLeaseValid returns whether the leader's lease can be trusted to serve reads. The lease is invalid if
+// clocks are skewed or, if a heartbeat ratio is configured, if the leader has not received a successful
+// response within the election timeout from at least the configured fraction of voting followers.
+func (a *raftAppender) isLeaseValid() bool {
+	if a.isClockSkewed() {
+		a.log.Debug("Clocks are skewed; lease is not valid")
+		return false
+	}
+
+	ratio := a.raft.Config().GetLeaseHeartbeatRatio()
+	voters := a.votingMembers()
+	if ratio == 0 || len(voters) == 0 {
+		return true
+	}
+
+	reachable := 0
+	for _, member := range voters {
+		if a.isReachable(member) {
+			reachable++
+		}
+	}
+	if float32(reachable) < ratio*float32(len(voters)) {
+		a.log.Debug("Heard from %d/%d voting followers within the election timeout; lease is not valid", reachable, len(voters))
+		return false
+	}
+	return true
+}
+
+// exceedsDrift returns whether the given clock skew exceeds the given drift bound in either direction
+func exceedsDrift(skew time.Duration, maxDrift time.Duration) bool {
+	return skew > maxDrift || skew < -maxDrift
+}
+
+// needsMember returns whether the given member is needed to form a quorum with the leader. A member is
+// needed if the leader cannot form a quorum with the members whose send buffers are not full.
+func (a *raftAppender) needsMember(member *memberAppender) bool {
+	// Learners are never needed to form a quorum.
+	if !a.isVoter(member.member.MemberID) {
+		return false
+	}
+	voters := a.votingMembers()
+	available := 1
+	for _, voter := range voters {
+		if other := a.members[voter]; other != member && !other.isBufferFull() {
+			available++
+		}
+	}
+	return available < (len(voters)+1)/2+1
+}
+
+// setQuiet sets whether the appender is quiet. A quiet appender continues replicating entries but
+// does not step down when it fails to reach a quorum, e.g. while leadership is being transferred.
+func (a *raftAppender) setQuiet(quiet bool) {
+	a.mu.Lock()
+	a.quiet = quiet
+	a.mu.Unlock()
+}
+
 func (a *raftAppender) failTime(failTime time.Time) {
-	if failTime.Sub(a.lastQuorumTime) > a.raft.Config().GetElectionTimeoutOrDefault()*2 {
+	a.mu.Lock()
+	quiet := a.quiet
+	lastQuorumTime := a.lastQuorumTime
+	a.mu.Unlock()
+	if quiet || len(a.votingMembers()) == 0 {
+		return
+	}
+	if failTime.Sub(lastQuorumTime) > a.raft.ConfiguredElectionTimeout()*2 {
 		a.log.Warn("Suspected network partition; stepping down")
 		_ = a.raft.SetLeader(nil)
 		a.raft.WriteLock()
@@ -266,13 +696,63 @@ func (a *raftAppender) failTime(failTime time.Time) {
 	}
 }
 
+// checkQuorum periodically verifies the leader has heard from a quorum of the cluster within the
+// election timeout and steps down if it has not. Single node clusters never fail the check.
+func (a *raftAppender) checkQuorum() {
+	ticker := time.NewTicker(a.raft.HeartbeatInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !a.isQuorumReachable() {
+				a.log.Warn("Failed to reach a quorum within the election timeout; stepping down")
+				_ = a.raft.SetLeader(nil)
+				a.raft.WriteLock()
+				a.raft.SetRole(raft.RoleFollower)
+				a.raft.WriteUnlock()
+				return
+			}
+		case <-a.done:
+			return
+		}
+	}
+}
+
+// isQuorumReachable returns whether the leader has heard from a quorum of the cluster within the
+// election timeout
+func (a *raftAppender) isQuorumReachable() bool {
+	if len(a.raft.Members()) == 1 || len(a.votingMembers()) == 0 {
+		return true
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.quiet || time.Since(a.lastQuorumTime) <= a.raft.ConfiguredElectionTimeout()
+}
+
 func (a *raftAppender) stop() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
+
+	// Fail pending heartbeats to unblock queries awaiting confirmation of the leader's quorum.
+	close(a.done)
+	for future := a.heartbeatFutures.Front(); future != nil; future = a.heartbeatFutures.Front() {
+		close(future.Value.(heartbeatFuture).ch)
+		a.heartbeatFutures.Remove(future)
+	}
+
+	// Fail entries awaiting commit to unblock commands pending on the leader that stepped down.
+	for index, ch := range a.commitChannels {
+		close(ch)
+		delete(a.commitChannels, index)
+	}
+	for index := range a.commitFutures {
+		delete(a.commitFutures, index)
+	}
+	// Stop the appender's goroutines without waiting for them to exit, since they may be blocked
+	// acquiring the lock held by the caller.
 	for _, member := range a.members {
 		member.stop()
 	}
-	a.stopped <- true
 }
 
 // newHeartbeatFuture returns a new heartbeatFuture
@@ -294,16 +774,18 @@ type memberCommit struct {
 	member *memberAppender
 	index  raft.Index
 	time   time.Time
+	// reset indicates the member's log diverged and its match index was lowered to index
+	reset bool
 }
 
 const (
 	minBackoffFailureCount = 3
 	maxHeartbeatWait       = 1 * time.Minute
 	maxBatchSize           = 1024 * 1024
+	snapshotVersion        = snapshot.Version
 )
 
 func newMemberAppender(state raft.Raft, sm state.Manager, store store.Store, logger util.Logger, member *raft.Member, commitCh chan<- memberCommit, failCh chan<- time.Time) *memberAppender {
-	ticker := time.NewTicker(state.Config().GetElectionTimeoutOrDefault() / 2)
 	reader := store.Log().OpenReader(0)
 	return &memberAppender{
 		raft:        state,
@@ -312,6 +794,7 @@ func newMemberAppender(state raft.Raft, sm state.Manager, store store.Store, log
 		log:         logger,
 		member:      member,
 		nextIndex:   reader.LastIndex() + 1,
+		lastIndex:   uint64(reader.LastIndex()),
 		entryCh:     make(chan *log.Entry),
 		appendCh:    make(chan bool),
 		commitCh:    commitCh,
@@ -319,14 +802,24 @@ func newMemberAppender(state raft.Raft, sm state.Manager, store store.Store, log
 		heartbeatCh: make(chan time.Time),
 		stopped:     make(chan bool),
 		reader:      reader,
-		tickTicker:  ticker,
-		tickCh:      ticker.C,
+		tickTimer:   time.NewTimer(heartbeatInterval(state)),
 		queue:       list.New(),
 	}
 }
 
+// heartbeatInterval returns the interval at which heartbeats are sent to an idle member. The interval is
+// capped at half the election timeout so a member cannot time out between heartbeats.
+func heartbeatInterval(state raft.Raft) time.Duration {
+	interval := state.HeartbeatInterval()
+	if maxInterval := state.ConfiguredElectionTimeout() / 2; interval > maxInterval {
+		return maxInterval
+	}
+	return interval
+}
+
 // memberAppender handles replication to a member
 type memberAppender struct {
+	appender         *raftAppender
 	raft             raft.Raft
 	sm               state.Manager
 	store            store.Store
@@ -334,10 +827,21 @@ type memberAppender struct {
 	member           *raft.Member
 	active           bool
 	snapshotIndex    raft.Index
+	rejectedIndex    raft.Index
+	snapshotRequest  bool
 	prevTerm         raft.Term
 	nextIndex        raft.Index
 	matchIndex       raft.Index
-	appending        bool
+	sendIndex        raft.Index
+	sendTerm         raft.Term
+	inflight         int
+	pending          bool
+	installing       int32
+	installIndex     raft.Index
+	installOffset    uint64
+	installFailures  int
+	installFailTime  time.Time
+	heartbeating     int32
 	failureCount     int
 	firstFailureTime time.Time
 	entryCh          chan *log.Entry
@@ -345,12 +849,18 @@ type memberAppender struct {
 	commitCh         chan<- memberCommit
 	failCh           chan<- time.Time
 	heartbeatCh      chan time.Time
-	tickCh           <-chan time.Time
-	tickTicker       *time.Ticker
+	tickTimer        *time.Timer
 	stopped          chan bool
 	reader           log.Reader
 	queue            *list.List
+	held             *log.Entry
+	entries          []*raft.LogEntry
 	mu               sync.Mutex
+	// progressMu guards the member's replication progress, which is shared by pipelined append requests
+	progressMu sync.Mutex
+	// lastIndex is the index of the last entry in the leader's log, updated atomically as entries are
+	// received by the member's event loop
+	lastIndex uint64
 }
 
 // start starts sending append requests to the member
@@ -361,32 +871,69 @@ func (a *memberAppender) start() {
 
 func (a *memberAppender) processEvents() {
 	for {
-		select {
-		case entry := <-a.entryCh:
-			if a.failureCount == 0 {
+		// If an entry is held because the member's send buffer was full, buffer the entry once there's
+		// room or drop it once the member is no longer needed to form a quorum. Entries that aren't
+		// buffered are read from the log once the member catches up.
+		if a.held != nil {
+			if !a.isBufferFull() {
 				a.mu.Lock()
-				a.queue.PushBack(entry)
+				a.queue.PushBack(a.held)
 				a.mu.Unlock()
+				a.held = nil
+			} else if !a.appender.needsMember(a) {
+				a.held = nil
+			}
+		}
+
+		// Stop accepting entries while an entry is held to apply backpressure to the leader.
+		entryCh := a.entryCh
+		if a.held != nil {
+			entryCh = nil
+		}
+
+		select {
+		case entry := <-entryCh:
+			atomic.StoreUint64(&a.lastIndex, uint64(entry.Index))
+			if a.failureCount == 0 {
+				if !a.isBufferFull() {
+					a.mu.Lock()
+					a.queue.PushBack(entry)
+					a.mu.Unlock()
+				} else if a.appender != nil && a.appender.needsMember(a) {
+					a.held = entry
+				}
 			}
-			if !a.appending {
-				a.appending = true
-				go a.append()
+			if a.inflight == 0 {
+				a.startAppend()
+			} else if a.canPipeline() {
+				a.startPipeline()
 			}
 		case hasEntries := <-a.appendCh:
-			a.appending = false
+			// If entries remain once all in-flight requests have completed, start a new append. While other
+			// requests are in flight, remaining entries are pipelined to the member if pipelining is enabled.
+			a.inflight--
 			if hasEntries {
-				a.appending = true
-				go a.append()
+				a.pending = true
+			}
+			if a.inflight == 0 {
+				if a.pending {
+					a.startAppend()
+				}
+			} else if hasEntries && a.canPipeline() {
+				a.startPipeline()
 			}
 		case <-a.heartbeatCh:
-			if !a.appending {
-				a.appending = true
-				go a.append()
+			if a.inflight == 0 {
+				a.startAppend()
+			} else if a.isInstalling() {
+				go a.sendInstallHeartbeat()
 			}
-		case <-a.tickCh:
-			if !a.appending {
-				a.appending = true
-				go a.append()
+		case <-a.tickTimer.C:
+			a.tickTimer.Reset(heartbeatInterval(a.raft))
+			if a.inflight == 0 {
+				a.startAppend()
+			} else if a.isInstalling() {
+				go a.sendInstallHeartbeat()
 			}
 		case <-a.stopped:
 			return
@@ -394,34 +941,114 @@ func (a *memberAppender) processEvents() {
 	}
 }
 
+// startAppend starts an append to the member once no requests are in flight
+func (a *memberAppender) startAppend() {
+	a.inflight++
+	a.pending = false
+	a.resetTick()
+	go a.append()
+}
+
+// startPipeline starts an append of the entries following those in flight to the member
+func (a *memberAppender) startPipeline() {
+	a.inflight++
+	a.resetTick()
+	go a.pipeline()
+}
+
+// resetTick suppresses the next heartbeat to the member by restarting the heartbeat timer. Every append
+// request resets the member's election timer, so a heartbeat is only needed once the member has been idle
+// for a full heartbeat interval.
+func (a *memberAppender) resetTick() {
+	if !a.tickTimer.Stop() {
+		select {
+		case <-a.tickTimer.C:
+		default:
+		}
+	}
+	a.tickTimer.Reset(heartbeatInterval(a.raft))
+}
+
+// canPipeline returns whether another append request can be sent to the member before the
+// responses to the requests in flight are received
+func (a *memberAppender) canPipeline() bool {
+	return a.inflight < a.raft.Config().GetMaxInflightAppendsOrDefault() && atomic.LoadInt32(&a.installing) == 0
+}
+
 func (a *memberAppender) append() {
+	a.progressMu.Lock()
+	request := a.prepareAppend()
+	a.progressMu.Unlock()
+	if request != nil {
+		a.sendAppendRequest(request)
+	}
+}
+
+// pipeline sends the entries following those already sent to the member without awaiting
+// the responses to the requests in flight
+func (a *memberAppender) pipeline() {
+	a.progressMu.Lock()
+	request := a.nextPipelinedRequest()
+	a.progressMu.Unlock()
+	if request != nil {
+		a.sendAppendRequest(request)
+	} else {
+		a.pause()
+	}
+}
+
+// prepareAppend returns the next append request to send to the member, or nil if the member
+// is backing off or a snapshot was replicated to the member instead
+func (a *memberAppender) prepareAppend() *raft.AppendRequest {
 	if a.failureCount > minBackoffFailureCount {
 		timeSinceFailure := float64(time.Since(a.firstFailureTime))
-		electionTimeout := a.raft.Config().GetElectionTimeoutOrDefault()
+		electionTimeout := a.raft.ConfiguredElectionTimeout()
 		failureCount := a.failureCount - minBackoffFailureCount
 		heartbeatWaitTime := math.Min(float64(failureCount*failureCount)*float64(electionTimeout.Nanoseconds()), float64(maxHeartbeatWait))
 		if timeSinceFailure > heartbeatWaitTime {
-			a.sendAppendRequest(a.nextAppendRequest())
-		} else {
-			a.pause()
+			return a.nextAppendRequest()
 		}
+		a.pause()
 	} else {
 		// TODO: The snapshot store needs concurrency control when accessing the snapshots for replication.
 		snapshot := a.store.Snapshot().CurrentSnapshot()
-		if snapshot != nil && a.snapshotIndex < snapshot.Index() && snapshot.Index() >= a.nextIndex {
-			a.log.Debug("Replicating snapshot %d to %s", snapshot.Index(), a.member.MemberID)
-			a.sendInstallRequests(snapshot)
+		if snapshot != nil && snapshot.Index() >= a.nextIndex && (a.snapshotRequest || a.snapshotIndex < snapshot.Index() && !a.canReplicateEntries(snapshot)) {
+			if a.isInstallBackoff() {
+				// If a recent install was aborted, wait for the backoff to expire before retrying the install,
+				// continuing to send heartbeats to the member in the meantime.
+				if !a.raft.Config().GetDisableInstallHeartbeats() {
+					go a.sendInstallHeartbeat()
+				}
+				a.pause()
+			} else {
+				a.log.Debug("Replicating snapshot %d to %s", snapshot.Index(), a.member.MemberID)
+				a.snapshotRequest = false
+				a.sendInstallRequests(snapshot)
+			}
 		} else {
-			a.sendAppendRequest(a.nextAppendRequest())
+			return a.nextAppendRequest()
 		}
 	}
+	return nil
 }
 
-// stop stops sending append requests to the member
+// isBufferFull returns whether the number of entries buffered for the member has reached the configured limit
+func (a *memberAppender) isBufferFull() bool {
+	bufferSize := int(a.raft.Config().GetFollowerBufferSize())
+	if bufferSize == 0 {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.queue.Len() >= bufferSize
+}
+
+// stop stops sending append requests to the member. Goroutines blocked sending events from the member
+// appender are released once it's stopped.
 func (a *memberAppender) stop() {
 	a.active = false
-	a.tickTicker.Stop()
-	a.stopped <- true
+	a.tickTimer.Stop()
+	close(a.stopped)
 }
 
 func (a *memberAppender) succeed() {
@@ -433,29 +1060,46 @@ func (a *memberAppender) fail(time time.Time) {
 		a.firstFailureTime = time
 	}
 	a.failureCount++
-	a.failCh <- time
+	select {
+	case a.failCh <- time:
+	case <-a.stopped:
+	}
 }
 
 func (a *memberAppender) requeue() {
 	a.raft.ReadLock()
 	hasEntries := a.reader.LastIndex() >= a.nextIndex
 	a.raft.ReadUnlock()
-	a.appendCh <- hasEntries
+	a.sendAppend(hasEntries)
 }
 
 func (a *memberAppender) pause() {
-	a.appendCh <- false
+	a.sendAppend(false)
 }
 
-func (a *memberAppender) newInstallRequest(snapshot snapshot.Snapshot, bytes []byte) *raft.InstallRequest {
+// sendAppend notifies the member appender's event loop that an append completed unless the member appender is stopped
+func (a *memberAppender) sendAppend(hasEntries bool) {
+	select {
+	case a.appendCh <- hasEntries:
+	case <-a.stopped:
+	}
+}
+
+func (a *memberAppender) newInstallRequest(snapshot snapshot.Snapshot, bytes []byte, offset uint64) *raft.InstallRequest {
 	a.raft.ReadLock()
 	defer a.raft.ReadUnlock()
 	return &raft.InstallRequest{
-		Term:      a.raft.Term(),
-		Leader:    a.raft.Member(),
-		Index:     snapshot.Index(),
-		Timestamp: snapshot.Timestamp(),
-		Data:      bytes,
+		Term:           a.raft.Term(),
+		Leader:         a.raft.Member(),
+		Index:          snapshot.Index(),
+		SnapshotTerm:   snapshot.Term(),
+		Timestamp:      snapshot.Timestamp(),
+		Data:           bytes,
+		Version:        snapshotVersion,
+		Offset:         offset,
+		Checksum:       crc32.ChecksumIEEE(bytes),
+		ReadOnly:       a.raft.ReadOnlyMode(),
+		ElectionFreeze: a.raft.ElectionFreeze(),
 	}
 }
 
@@ -463,8 +1107,23 @@ func (a *memberAppender) sendInstallRequests(snapshot snapshot.Snapshot) {
 	// Start the append to the member.
 	startTime := time.Now()
 
-	ctx, cancel := context.WithTimeout(context.Background(), a.raft.Config().GetElectionTimeoutOrDefault())
+	// Mark the member as installing to allow heartbeats to be sent while the snapshot is streamed.
+	atomic.StoreInt32(&a.installing, 1)
+	defer atomic.StoreInt32(&a.installing, 0)
+
+	// If an install timeout is configured, abort the install if the member does not accept the snapshot
+	// within the timeout to free the member's appender to retry the install later.
+	timeout := a.raft.ConfiguredElectionTimeout()
+	installTimeout := a.installTimeout()
+	if installTimeout > 0 {
+		timeout = installTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
+	var timeoutCh <-chan struct{}
+	if installTimeout > 0 {
+		timeoutCh = ctx.Done()
+	}
 
 	stream, future, err := a.raft.Protocol().Install(ctx, a.member.MemberID)
 	if err != nil {
@@ -473,28 +1132,61 @@ func (a *memberAppender) sendInstallRequests(snapshot snapshot.Snapshot) {
 		return
 	}
 
+	// If the member acknowledged part of the snapshot before rejecting the previous install, resume the
+	// install from the offset acknowledged by the member.
+	if a.installIndex != snapshot.Index() {
+		a.installIndex = snapshot.Index()
+		a.installOffset = 0
+	}
+	offset := a.installOffset
+
 	reader := snapshot.Reader()
 	defer func() {
 		_ = reader.Close()
 	}()
+	if _, err := io.CopyN(ioutil.Discard, reader, int64(offset)); err != nil {
+		a.log.Warn("Failed to read snapshot", err)
+		a.installOffset = 0
+		a.requeue()
+		return
+	}
+
+	var response *raft.InstallStreamResponse
 	bytes := make([]byte, maxBatchSize)
-	for {
+	for response == nil {
 		n, err := reader.Read(bytes)
 		if err == io.EOF {
 			break
 		} else if err != nil {
 			a.log.Warn("Failed to read snapshot", err)
+			a.installOffset = 0
 			a.requeue()
 			return
 		}
 
-		request := a.newInstallRequest(snapshot, bytes[:n])
+		// Copy the chunk since the request may be in flight while the next chunk is read.
+		request := a.newInstallRequest(snapshot, append([]byte(nil), bytes[:n]...), offset)
 		a.log.SendTo("InstallRequest", request, a.member.MemberID)
-		stream <- request
+		select {
+		case stream <- request:
+			offset += uint64(n)
+		case response = <-future:
+			// The member rejected the install before the snapshot was streamed.
+		case <-timeoutCh:
+			a.abortInstall(snapshot, installTimeout)
+			return
+		}
 	}
 	close(stream)
 
-	response := <-future
+	if response == nil {
+		select {
+		case response = <-future:
+		case <-timeoutCh:
+			a.abortInstall(snapshot, installTimeout)
+			return
+		}
+	}
 	if response.Failed() {
 		a.log.ErrorFrom("InstallRequest", response.Error, a.member.MemberID)
 		a.handleInstallError(snapshot, err, startTime)
@@ -508,12 +1200,125 @@ func (a *memberAppender) sendInstallRequests(snapshot snapshot.Snapshot) {
 	}
 }
 
+// installTimeout returns the configured install timeout or 0 if installs are not aborted
+func (a *memberAppender) installTimeout() time.Duration {
+	if timeout := a.raft.Config().GetInstallTimeout(); timeout != nil {
+		return *timeout
+	}
+	return 0
+}
+
+// abortInstall aborts an install that was not accepted by the member within the install timeout.
+// The install is retried from the start of the snapshot once the backoff following the failure expires.
+func (a *memberAppender) abortInstall(snapshot snapshot.Snapshot, timeout time.Duration) {
+	a.log.Warn("Aborting install of snapshot %d to %s: no response within %s", snapshot.Index(), a.member.MemberID, timeout)
+	a.installOffset = 0
+	a.installFailures++
+	a.installFailTime = a.raft.Clock().Now()
+	a.requeue()
+}
+
+// isInstallBackoff returns whether the install to the member is backing off after aborted installs.
+// The backoff doubles with each consecutive aborted install up to the max heartbeat wait.
+func (a *memberAppender) isInstallBackoff() bool {
+	if a.installFailures == 0 {
+		return false
+	}
+	backoff := a.installTimeout()
+	for i := 1; i < a.installFailures && backoff < maxHeartbeatWait; i++ {
+		backoff *= 2
+	}
+	if backoff > maxHeartbeatWait {
+		backoff = maxHeartbeatWait
+	}
+	return a.raft.Clock().Now().Sub(a.installFailTime) < backoff
+}
+
+// isInstalling returns whether a snapshot is being installed on the member and heartbeats
+// should be sent to the member during the install
+func (a *memberAppender) isInstalling() bool {
+	return !a.raft.Config().GetDisableInstallHeartbeats() && atomic.LoadInt32(&a.installing) == 1
+}
+
+// sendInstallHeartbeat sends an empty append request to verify the leader's term with a member
+// that is installing a snapshot. Heartbeats sent during an install do not update the member's
+// progress; they only resolve pending heartbeats so queries are not blocked on the snapshot.
+func (a *memberAppender) sendInstallHeartbeat() {
+	// Only allow a single heartbeat to be in flight at a time.
+	if !atomic.CompareAndSwapInt32(&a.heartbeating, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&a.heartbeating, 0)
+
+	startTime := time.Now()
+
+	a.raft.ReadLock()
+	request := &raft.AppendRequest{
+		Term:            a.raft.Term(),
+		Leader:          a.raft.Member(),
+		CommitIndex:     a.raft.CommitIndex(),
+		LeaderLastIndex: a.store.Writer().LastIndex(),
+	}
+	a.raft.ReadUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.raft.ConfiguredElectionTimeout())
+	defer cancel()
+
+	a.log.SendTo("AppendRequest", request, a.member.MemberID)
+	response, err := a.raft.Protocol().Append(ctx, request, a.member.MemberID)
+	if err != nil {
+		a.log.ErrorFrom("AppendRequest", err, a.member.MemberID)
+		return
+	}
+	a.log.ReceiveFrom("AppendResponse", response, a.member.MemberID)
+	a.raft.ObserveRoundTrip(a.member.MemberID, time.Since(startTime))
+	if response.Status != raft.ResponseStatus_OK {
+		return
+	}
+
+	if response.Succeeded {
+		// Send a commit event with no index to the parent appender to confirm the heartbeat.
+		a.sendCommit(memberCommit{
+			member: a,
+			time:   startTime,
+		})
+	} else {
+		// If the response term is greater than the local server's term, transition back to follower.
+		a.raft.ReadLock()
+		if response.Term > a.raft.Term() {
+			a.raft.ReadUnlock()
+			a.raft.WriteLock()
+			defer a.raft.WriteUnlock()
+			if response.Term > a.raft.Term() {
+				_ = a.raft.SetTerm(response.Term)
+				_ = a.raft.SetLeader(nil)
+				a.raft.SetRole(raft.RoleFollower)
+			}
+			return
+		}
+		a.raft.ReadUnlock()
+	}
+}
+
 func (a *memberAppender) handleInstallResponse(snapshot snapshot.Snapshot, response *raft.InstallResponse, startTime time.Time) {
 	// Reset the member failure count to allow entries to be sent to the member.
 	a.succeed()
 
-	// Update the snapshot index
+	// Update the snapshot index and reset the install backoff
 	a.snapshotIndex = snapshot.Index()
+	a.installOffset = 0
+	a.installFailures = 0
+
+	// The member's log now includes every entry up to the snapshot index, so resume replication from the
+	// entry following the snapshot. The entries preceding it may have been compacted from the leader's log.
+	if snapshot.Index() >= a.nextIndex {
+		a.nextIndex = snapshot.Index() + 1
+		a.prevTerm = snapshot.Term()
+		a.resetSendIndex()
+	}
+	if snapshot.Index() > a.matchIndex {
+		a.matchIndex = snapshot.Index()
+	}
 
 	// Send a commit event to the parent appender.
 	a.commit(startTime)
@@ -523,12 +1328,46 @@ func (a *memberAppender) handleInstallResponse(snapshot snapshot.Snapshot, respo
 }
 
 func (a *memberAppender) handleInstallFailure(snapshot snapshot.Snapshot, response *raft.InstallResponse, startTime time.Time) {
-	// In the event of an install response error, simply do nothing and await the next heartbeat.
+	// If the member does not support the snapshot format, fall back to replicating the log to the
+	// member if the entries following the member's next index have not been compacted.
+	if response.Error == raft.ResponseError_INCOMPATIBLE_SNAPSHOT {
+		a.log.Warn("Member %s rejected snapshot %d: incompatible snapshot format version %d", a.member.MemberID, snapshot.Index(), snapshotVersion)
+		a.rejectedIndex = snapshot.Index()
+		if a.canReplicateEntries(snapshot) {
+			a.requeue()
+			return
+		}
+	}
+
+	// If the member rejected a chunk of the snapshot, e.g. because the chunk was corrupted, resume the install
+	// from the offset of the bytes received by the member.
+	if response.Error == raft.ResponseError_PROTOCOL_ERROR {
+		a.log.Warn("Member %s rejected snapshot %d at offset %d", a.member.MemberID, snapshot.Index(), response.Offset)
+		a.installOffset = response.Offset
+		a.requeue()
+		return
+	}
+
+	// In the event of an install response error, release the member and await the next heartbeat.
 	// This prevents infinite loops when installation fails.
+	a.installOffset = 0
+	a.pause()
+}
+
+// canReplicateEntries returns whether the member rejected the given snapshot and can instead
+// be caught up by replicating the entries from its next index
+func (a *memberAppender) canReplicateEntries(snapshot snapshot.Snapshot) bool {
+	if a.rejectedIndex != snapshot.Index() {
+		return false
+	}
+	a.raft.ReadLock()
+	defer a.raft.ReadUnlock()
+	return a.reader.FirstIndex() <= a.nextIndex
 }
 
 func (a *memberAppender) handleInstallError(snapshot snapshot.Snapshot, err error, startTime time.Time) {
 	a.log.Debug("Failed to install %s: %s", a.member.MemberID, err)
+	a.installOffset = 0
 	a.fail(startTime)
 	a.requeue()
 }
@@ -541,61 +1380,148 @@ func (a *memberAppender) nextAppendRequest() *raft.AppendRequest {
 	// helps avoid doing expensive work until we can ascertain the member is back up.
 	a.raft.ReadLock()
 	defer a.raft.ReadUnlock()
+	a.pruneEntries()
 	if a.failureCount > 0 || a.nextIndex > a.reader.LastIndex() {
 		return a.emptyAppendRequest()
 	}
 	return a.entriesAppendRequest()
 }
 
+// pruneEntries removes entries the member has already received from the member's send buffer.
+// Entries may be buffered after the member has caught up, and stale entries would otherwise
+// count against the buffer limit indefinitely.
+func (a *memberAppender) pruneEntries() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry := a.queue.Front()
+	for entry != nil {
+		next := entry.Next()
+		if entry.Value.(*log.Entry).Index < a.nextIndex {
+			a.queue.Remove(entry)
+		}
+		entry = next
+	}
+}
+
 func (a *memberAppender) emptyAppendRequest() *raft.AppendRequest {
 	prevIndex := a.nextIndex - 1
-	if a.prevTerm == 0 && prevIndex >= a.reader.FirstIndex() {
-		a.reader.Reset(prevIndex)
-		a.prevTerm = a.reader.NextEntry().Entry.Term
+	if a.prevTerm == 0 {
+		a.prevTerm = a.prevEntryTerm(prevIndex)
 	}
+	a.sendIndex = a.nextIndex
+	a.sendTerm = a.prevTerm
 	return &raft.AppendRequest{
-		Term:         a.raft.Term(),
-		Leader:       a.raft.Member(),
-		PrevLogIndex: a.nextIndex - 1,
-		PrevLogTerm:  a.prevTerm,
-		CommitIndex:  a.raft.CommitIndex(),
+		Term:             a.raft.Term(),
+		Leader:           a.raft.Member(),
+		PrevLogIndex:     a.nextIndex - 1,
+		PrevLogTerm:      a.prevTerm,
+		CommitIndex:      a.raft.CommitIndex(),
+		LeaderLastIndex:  a.store.Writer().LastIndex(),
+		LeaderFirstIndex: a.reader.FirstIndex(),
 	}
 }
 
+// prevEntryTerm returns the term of the entry preceding an append request. If the entry has been compacted
+// from the log and is the last entry included in the current snapshot, the snapshot's term is returned so the
+// follower can still verify the entries following the snapshot. Zero is returned if the term is unknown.
+// The caller must hold the read lock.
+func (a *memberAppender) prevEntryTerm(index raft.Index) raft.Term {
+	if index >= a.reader.FirstIndex() {
+		a.reader.Reset(index)
+		return a.reader.NextEntry().Entry.Term
+	}
+	if snapshot := a.store.Snapshot().CurrentSnapshot(); snapshot != nil && snapshot.Index() == index {
+		return snapshot.Term()
+	}
+	return 0
+}
+
 func (a *memberAppender) entriesAppendRequest() *raft.AppendRequest {
 	prevIndex := a.nextIndex - 1
-	if a.prevTerm == 0 && prevIndex >= a.reader.FirstIndex() {
-		a.reader.Reset(prevIndex)
-		a.prevTerm = a.reader.NextEntry().Entry.Term
+	if a.prevTerm == 0 {
+		a.prevTerm = a.prevEntryTerm(prevIndex)
 	}
-	request := &raft.AppendRequest{
-		Term:         a.raft.Term(),
-		Leader:       a.raft.Member(),
-		PrevLogIndex: a.nextIndex - 1,
-		PrevLogTerm:  a.prevTerm,
-		CommitIndex:  a.raft.CommitIndex(),
+
+	// Reuse the member's entries buffer to avoid allocating a new slice for each request. The buffer
+	// is only reused once the prior request has completed.
+	request := a.newEntriesRequest(a.nextIndex, a.prevTerm, a.entries[:0])
+	a.entries = request.Entries
+	return request
+}
+
+// newWitnessEntry returns the given entry as it's sent to a witness, with the payload of commands and queries removed
+func newWitnessEntry(entry *raft.LogEntry) *raft.LogEntry {
+	switch entry.Entry.(type) {
+	case *raft.LogEntry_Command, *raft.LogEntry_Query:
+		return &raft.LogEntry{
+			Term:      entry.Term,
+			Timestamp: entry.Timestamp,
+		}
 	}
+	return entry
+}
 
-	entriesList := list.New()
+// nextPipelinedRequest returns a request for the entries following the last entry sent to the member, or
+// nil if the member's progress is unknown or all entries have been sent. Requests are only pipelined once
+// the member has acknowledged its next index to avoid sending entries that the member will reject.
+func (a *memberAppender) nextPipelinedRequest() *raft.AppendRequest {
+	a.raft.ReadLock()
+	defer a.raft.ReadUnlock()
+	if a.failureCount > 0 || atomic.LoadInt32(&a.installing) == 1 || a.matchIndex+1 != a.nextIndex {
+		return nil
+	}
+	if a.sendIndex < a.nextIndex {
+		a.sendIndex = a.nextIndex
+		a.sendTerm = a.prevTerm
+	}
+	if a.sendIndex > a.reader.LastIndex() {
+		return nil
+	}
+
+	prevIndex := a.sendIndex - 1
+	if a.sendTerm == 0 {
+		a.sendTerm = a.prevEntryTerm(prevIndex)
+	}
+
+	// Pipelined requests are in flight concurrently with other requests and can't reuse the entries buffer.
+	return a.newEntriesRequest(a.sendIndex, a.sendTerm, nil)
+}
+
+// newEntriesRequest returns a request for a batch of entries starting at the given index. The size of the
+// batch is limited by the configured maximum number of entries and the maximum batch size in bytes.
+func (a *memberAppender) newEntriesRequest(nextIndex raft.Index, prevTerm raft.Term, entries []*raft.LogEntry) *raft.AppendRequest {
+	request := &raft.AppendRequest{
+		Term:             a.raft.Term(),
+		Leader:           a.raft.Member(),
+		PrevLogIndex:     nextIndex - 1,
+		PrevLogTerm:      prevTerm,
+		CommitIndex:      a.raft.CommitIndex(),
+		LeaderLastIndex:  a.store.Writer().LastIndex(),
+		LeaderFirstIndex: a.reader.FirstIndex(),
+	}
 
 	// Build a list of entries starting at the nextIndex, using the cache if possible.
+	maxEntries := int(a.raft.Config().GetMaxAppendBatchSize())
 	size := 0
-	nextIndex := a.nextIndex
 	for nextIndex <= a.reader.LastIndex() {
+		if maxEntries > 0 && len(entries) >= maxEntries {
+			break
+		}
+
 		// First, try to get the entry from the cache.
 		a.mu.Lock()
 		entry := a.queue.Front()
 		if entry != nil {
 			indexed := entry.Value.(*log.Entry)
 			if indexed.Index == nextIndex {
-				entriesList.PushBack(indexed.Entry)
+				entries = append(entries, indexed.Entry)
 				a.queue.Remove(entry)
+				a.mu.Unlock()
 				size += indexed.Entry.XXX_Size()
 				nextIndex++
 				if size >= maxBatchSize {
 					break
 				}
-				a.mu.Unlock()
 				continue
 			} else if indexed.Index < nextIndex {
 				a.queue.Remove(entry)
@@ -609,7 +1535,7 @@ func (a *memberAppender) entriesAppendRequest() *raft.AppendRequest {
 		a.reader.Reset(nextIndex)
 		indexed := a.reader.NextEntry()
 		if indexed != nil {
-			entriesList.PushBack(indexed.Entry)
+			entries = append(entries, indexed.Entry)
 			size += indexed.Entry.XXX_Size()
 			nextIndex++
 			if size >= maxBatchSize {
@@ -620,12 +1546,20 @@ func (a *memberAppender) entriesAppendRequest() *raft.AppendRequest {
 		}
 	}
 
-	// Convert the linked list into a slice
-	entries := make([]*raft.LogEntry, 0, entriesList.Len())
-	entry := entriesList.Front()
-	for entry != nil {
-		entries = append(entries, entry.Value.(*raft.LogEntry))
-		entry = entry.Next()
+	// Witnesses are sent only the metadata of commands and queries, which is sufficient to track the progress of
+	// the log and vote in elections.
+	if a.raft.IsWitness(a.member.MemberID) {
+		for i, entry := range entries {
+			entries[i] = newWitnessEntry(entry)
+		}
+	}
+
+	// Record the last entry sent to allow subsequent requests to be pipelined after the entries in this request.
+	a.sendIndex = nextIndex
+	if len(entries) > 0 {
+		a.sendTerm = entries[len(entries)-1].Term
+	} else {
+		a.sendTerm = prevTerm
 	}
 
 	// Add the entries to the request builder and return the request.
@@ -637,14 +1571,34 @@ func (a *memberAppender) sendAppendRequest(request *raft.AppendRequest) {
 	// Start the append to the member.
 	startTime := time.Now()
 
-	ctx, cancel := context.WithTimeout(context.Background(), a.raft.Config().GetElectionTimeoutOrDefault())
+	ctx, cancel := context.WithTimeout(context.Background(), a.raft.ConfiguredElectionTimeout())
 	defer cancel()
 
+	// If a drift bound is configured, include the leader's wall-clock time to exchange clocks with the member.
+	clock := a.raft.Clock()
+	if a.appender.maxClockDrift() > 0 {
+		request.Timestamp = clock.Now()
+	}
+
 	a.log.SendTo("AppendRequest", request, a.member.MemberID)
 	response, err := a.raft.Protocol().Append(ctx, request, a.member.MemberID)
+
+	// Responses to pipelined requests may be received concurrently and out of order. Hold the progress lock
+	// while handling the response to update the member's progress.
+	a.progressMu.Lock()
+	defer a.progressMu.Unlock()
 	if err == nil {
 		a.log.ReceiveFrom("AppendResponse", response, a.member.MemberID)
+
+		// Only heartbeats are used to measure round trip times since appending entries incurs disk latency.
+		if len(request.Entries) == 0 {
+			a.raft.ObserveRoundTrip(a.member.MemberID, time.Since(startTime))
+		}
+		if !request.Timestamp.IsZero() && !response.Timestamp.IsZero() {
+			a.updateClockSkew(request.Timestamp, clock.Now(), response.Timestamp)
+		}
 		if response.Status == raft.ResponseStatus_OK {
+			a.raft.Metrics().ObserveAppend(a.member.MemberID, time.Since(startTime))
 			a.handleAppendResponse(request, response, startTime)
 		} else {
 			a.handleAppendFailure(request, response, startTime)
@@ -655,12 +1609,29 @@ func (a *memberAppender) sendAppendRequest(request *raft.AppendRequest) {
 	}
 }
 
+// updateClockSkew estimates the skew between the leader's clock and the member's clock from the time at
+// which the member received a request. The member is assumed to have received the request halfway through
+// the round trip, so the estimate is accurate to within half the round trip time.
+func (a *memberAppender) updateClockSkew(sendTime, receiveTime, memberTime time.Time) {
+	roundTrip := receiveTime.Sub(sendTime)
+	skew := memberTime.Sub(sendTime.Add(roundTrip / 2))
+	a.appender.updateClockSkew(a.member.MemberID, skew)
+}
+
 func (a *memberAppender) commit(time time.Time) {
 	// Send a commit event to the parent appender.
-	a.commitCh <- memberCommit{
+	a.sendCommit(memberCommit{
 		member: a,
 		index:  a.matchIndex,
 		time:   time,
+	})
+}
+
+// sendCommit sends the given commit event to the parent appender unless the member appender is stopped
+func (a *memberAppender) sendCommit(commit memberCommit) {
+	select {
+	case a.commitCh <- commit:
+	case <-a.stopped:
 	}
 }
 
@@ -668,57 +1639,220 @@ func (a *memberAppender) handleAppendResponse(request *raft.AppendRequest, respo
 	// Reset the member failure count to avoid empty heartbeats.
 	a.succeed()
 
-	// If replication succeeded then trigger commit futures.
-	if response.Succeeded {
+	// If the member's progress changed since the request was sent, the response describes the member's
+	// log at an earlier point and must not be used to rewind the member's progress.
+	if a.isStale(request) {
+		a.handleStaleAppendResponse(request, response, startTime)
+		return
+	}
+
+	// If the follower truncated entries from its log, log the truncation and alert watchers
+	// if the number of truncated entries exceeds the configured threshold.
+	if response.Truncated > 0 {
+		a.log.Warn("%s truncated %d entries from its log", a.member.MemberID, response.Truncated)
+		if threshold := a.raft.Config().GetTruncationAlertThreshold(); threshold > 0 && response.Truncated > threshold {
+			a.raft.ReadLock()
+			a.raft.ReportTruncation(a.member.MemberID, response.Truncated)
+			a.raft.ReadUnlock()
+		}
+	}
+
+	// If replication succeeded but the member's log diverged from the entries it previously acknowledged,
+	// reset the member's progress and probe for the last matching entry.
+	if response.Succeeded && a.isDiverged(request, response) {
+		a.resetMatchIndex(0)
+		a.nextIndex = response.LastLogIndex
+		if a.nextIndex == 0 {
+			a.nextIndex = 1
+		}
+		a.prevTerm = 0
+		a.resetSendIndex()
+	} else if response.Succeeded {
 		// If the replica returned a valid match index then update the existing match index.
 		a.matchIndex = response.LastLogIndex
 		a.nextIndex = a.matchIndex + 1
 
-		// If entries were sent to the follower, update the previous entry term to the term of the
-		// last entry in the follower's log.
-		if len(request.Entries) > 0 {
-			a.prevTerm = request.Entries[response.LastLogIndex-request.PrevLogIndex-1].Term
-		}
+		// Update the previous entry term to the term of the last entry in the follower's log. The follower
+		// may have persisted only some of the entries in the request.
+		a.prevTerm = getRequestEntryTerm(request, response.LastLogIndex)
 
 		// Send a commit event to the parent appender.
 		a.commit(startTime)
 	} else {
-		// If the request was rejected, use a double checked lock to compare the response term to the
-		// server's term. If the term is greater than the local server's term, transition back to follower.
-		a.raft.ReadLock()
-		if response.Term > a.raft.Term() {
-			a.raft.ReadUnlock()
-			a.raft.WriteLock()
-			defer a.raft.WriteUnlock()
-			if response.Term > a.raft.Term() {
-				// If we've received a greater term, update the term and transition back to follower.
-				_ = a.raft.SetTerm(response.Term)
-				_ = a.raft.SetLeader(nil)
-				a.raft.SetRole(raft.RoleFollower)
-			}
+		// If the request was rejected, compare the response term to the server's term. If the term is
+		// greater than the local server's term, transition back to follower.
+		if a.stepDownIfNewerTerm(response.Term) {
 			return
 		}
 
-		a.raft.ReadUnlock()
-
 		// If the request was rejected, the follower should have provided the correct last index in their log.
 		// This helps us converge on the matchIndex faster than by simply decrementing nextIndex one index at a time.
 		// Reset the matchIndex and nextIndex according to the response.
 		if response.LastLogIndex < a.matchIndex {
-			a.matchIndex = response.LastLogIndex
-			a.log.Trace("Reset match index for %s to %d", a.member.MemberID, a.matchIndex)
+			a.resetMatchIndex(response.LastLogIndex)
 		}
 		if response.LastLogIndex+1 != a.nextIndex {
 			a.nextIndex = response.LastLogIndex + 1
 			a.log.Trace("Reset next index for %s to %d", a.member.MemberID, a.nextIndex)
 			a.prevTerm = 0
 		}
+
+		// Entries pipelined after the rejected request will be rejected as well. Resend them from the next index.
+		a.resetSendIndex()
+
+		// If the member requested a snapshot, install the current snapshot even if it was previously installed
+		// on the member, since the member may have lost its state.
+		if response.SnapshotRequested {
+			a.log.Debug("%s requested a snapshot", a.member.MemberID)
+			a.snapshotRequest = true
+		}
 	}
 
+	// Update the member's lag behind the leader's log.
+	a.updateLag()
+
 	// Notify the appender that the next index can be appended.
 	a.requeue()
 }
 
+// isStale returns whether the given request was sent before the member's progress last changed, e.g. a
+// response to a retried or pipelined request received after the member's next index was advanced or reset.
+// Requests are matched to the member's progress by the index and term at which they were sent.
+func (a *memberAppender) isStale(request *raft.AppendRequest) bool {
+	if request.PrevLogIndex+1 != a.nextIndex {
+		a.log.Trace("Received stale AppendResponse from %s for index %d; next index is %d", a.member.MemberID, request.PrevLogIndex+1, a.nextIndex)
+		return true
+	}
+	a.raft.ReadLock()
+	defer a.raft.ReadUnlock()
+	return request.Term < a.raft.Term()
+}
+
+// handleStaleAppendResponse handles a response to a stale append request. Stale responses may only
+// advance the member's progress. Rejections and acknowledgements of indexes the member has already
+// acknowledged are ignored rather than rewinding the member's next index.
+func (a *memberAppender) handleStaleAppendResponse(request *raft.AppendRequest, response *raft.AppendResponse, startTime time.Time) {
+	if !response.Succeeded {
+		if a.stepDownIfNewerTerm(response.Term) {
+			return
+		}
+
+		// A pipelined request may be rejected if it was received by the member before an earlier request.
+		// Resend the entries following the member's next index to fill the gap in the member's log.
+		a.resetSendIndex()
+	} else if response.LastLogIndex > a.matchIndex && !a.isDiverged(request, response) {
+		a.matchIndex = response.LastLogIndex
+		if a.matchIndex >= a.nextIndex {
+			a.nextIndex = a.matchIndex + 1
+			a.prevTerm = getRequestEntryTerm(request, response.LastLogIndex)
+		}
+		a.commit(startTime)
+		a.updateLag()
+	}
+	a.requeue()
+}
+
+// resetSendIndex rewinds the index from which entries are pipelined to the member's next index
+func (a *memberAppender) resetSendIndex() {
+	a.sendIndex = a.nextIndex
+	a.sendTerm = a.prevTerm
+}
+
+// stepDownIfNewerTerm transitions the leader back to follower if the given term is greater than the
+// local server's term, returning whether the leader stepped down
+func (a *memberAppender) stepDownIfNewerTerm(term raft.Term) bool {
+	// Use a double checked lock to compare the term to the server's term.
+	a.raft.ReadLock()
+	if term <= a.raft.Term() {
+		a.raft.ReadUnlock()
+		return false
+	}
+	a.raft.ReadUnlock()
+
+	a.raft.WriteLock()
+	defer a.raft.WriteUnlock()
+	if term > a.raft.Term() {
+		// If we've received a greater term, update the term and transition back to follower.
+		_ = a.raft.SetTerm(term)
+		_ = a.raft.SetLeader(nil)
+		a.raft.SetRole(raft.RoleFollower)
+	}
+	return true
+}
+
+// isDiverged returns whether a successful append response indicates the member's log no longer
+// contains the entries it previously acknowledged, e.g. if the member rejoined with a divergent log
+func (a *memberAppender) isDiverged(request *raft.AppendRequest, response *raft.AppendResponse) bool {
+	if response.LastLogIndex < a.matchIndex {
+		a.log.Warn("%s reported last index %d below its match index %d", a.member.MemberID, response.LastLogIndex, a.matchIndex)
+		return true
+	}
+
+	// Compare the term of the member's last entry to the leader's term for the same entry. The term is
+	// determined from the request if possible, otherwise it's read from the leader's log.
+	if response.LastLogTerm == 0 {
+		return false
+	}
+	term := getRequestEntryTerm(request, response.LastLogIndex)
+	if term == 0 {
+		term = a.entryTerm(response.LastLogIndex)
+	}
+	if term != 0 && term != response.LastLogTerm {
+		a.log.Warn("%s reported term %d for entry %d, but the leader's entry has term %d", a.member.MemberID, response.LastLogTerm, response.LastLogIndex, term)
+		return true
+	}
+	return false
+}
+
+// getRequestEntryTerm returns the term of the entry at the given index as sent in the given request, or 0 if
+// the request does not include the entry's term
+func getRequestEntryTerm(request *raft.AppendRequest, index raft.Index) raft.Term {
+	if index > request.PrevLogIndex && index <= request.PrevLogIndex+raft.Index(len(request.Entries)) {
+		return request.Entries[index-request.PrevLogIndex-1].Term
+	} else if index == request.PrevLogIndex {
+		return request.PrevLogTerm
+	}
+	return 0
+}
+
+// entryTerm returns the term of the entry at the given index in the leader's log, or 0 if the entry is not present.
+// The term is read with the member's reader, which is guarded by the member's progress lock.
+func (a *memberAppender) entryTerm(index raft.Index) raft.Term {
+	a.raft.ReadLock()
+	defer a.raft.ReadUnlock()
+	if index < a.reader.FirstIndex() || index > a.reader.LastIndex() {
+		return 0
+	}
+	a.reader.Reset(index)
+	if entry := a.reader.NextEntry(); entry != nil && entry.Index == index {
+		return entry.Entry.Term
+	}
+	return 0
+}
+
+// resetMatchIndex lowers the member's match index and notifies the parent appender so entries the
+// member no longer stores are not counted towards the commit index
+func (a *memberAppender) resetMatchIndex(index raft.Index) {
+	a.matchIndex = index
+	a.log.Trace("Reset match index for %s to %d", a.member.MemberID, a.matchIndex)
+	a.sendCommit(memberCommit{
+		member: a,
+		index:  index,
+		reset:  true,
+	})
+}
+
+// updateLag updates the number of entries by which the member trails the leader's log. The leader's last
+// index is read from the index last received by the member's event loop rather than from the member's
+// reader, which is not safe for use concurrently with the leader's appends.
+func (a *memberAppender) updateLag() {
+	var lag raft.Index
+	if lastIndex := raft.Index(atomic.LoadUint64(&a.lastIndex)); lastIndex > a.matchIndex {
+		lag = lastIndex - a.matchIndex
+	}
+	a.raft.Metrics().SetFollowerLag(a.member.MemberID, lag)
+}
+
 func (a *memberAppender) handleAppendFailure(request *raft.AppendRequest, response *raft.AppendResponse, startTime time.Time) {
 	a.fail(startTime)
 	a.requeue()
@@ -19,25 +19,41 @@ import (
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/state"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
-	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
-	"math"
-	"math/rand"
 	"time"
 )
 
 // newCandidateRole returns a new candidate role
 func newCandidateRole(protocol raft.Raft, state state.Manager, store store.Store) raft.Role {
-	log := util.NewRoleLogger(string(protocol.Member()), string(raft.RoleCandidate))
+	log := protocol.NewLogger(raft.RoleCandidate)
+	ctx, cancel := context.WithCancel(context.Background())
 	return &CandidateRole{
 		ActiveRole: newActiveRole(protocol, state, store, log),
+		stopped:    make(chan struct{}),
+		ctx:        ctx,
+		cancel:     cancel,
 	}
 }
 
+// vote is the outcome of a vote request sent to a member in an election round
+type vote struct {
+	term     raft.Term
+	member   raft.MemberID
+	response *raft.VoteResponse
+	err      error
+}
+
 // CandidateRole implements a Raft candidate
 type CandidateRole struct {
 	*ActiveRole
 	electionTimer   *time.Timer
 	electionExpired chan bool
+	votes           chan vote
+	stopped         chan struct{}
+	ctx             context.Context
+	cancel          context.CancelFunc
+	voterSets       [][]raft.MemberID
+	accepted        map[raft.MemberID]bool
+	rejected        map[raft.MemberID]bool
 }
 
 // Type is the role type
@@ -47,13 +63,24 @@ func (r *CandidateRole) Type() raft.RoleType {
 
 // Start starts the candidate
 func (r *CandidateRole) Start() error {
-	// If there are no other members in the cluster, immediately transition to leader.
-	if len(r.raft.Members()) == 1 {
-		r.log.Debug("Single node cluster; skipping election")
+	// Witnesses vote in elections but can't be elected leader since they don't store the log's commands.
+	if r.raft.IsWitness(r.raft.Member()) {
+		r.log.Debug("Member is a witness; refusing to run an election")
+		r.raft.SetRole(raft.RoleFollower)
+		return nil
+	}
+
+	// If there are no other voting members in the cluster, immediately transition to leader.
+	if len(r.raft.VotingMembers()) == 1 && r.raft.IsVoter(r.raft.Member()) {
+		r.log.Debug("Single voter cluster; skipping election")
 		r.raft.SetRole(raft.RoleLeader)
 		return nil
 	}
 	_ = r.ActiveRole.Start()
+
+	// Votes from all election rounds are passed through a single buffered channel to one vote counter.
+	r.votes = make(chan vote, len(r.raft.Members()))
+	go r.countVotes()
 	go r.sendVoteRequests()
 	return nil
 }
@@ -63,6 +90,13 @@ func (r *CandidateRole) Stop() error {
 	if r.electionTimer != nil && r.electionTimer.Stop() {
 		r.electionExpired <- true
 	}
+	r.electionTimer = nil
+	if r.active {
+		close(r.stopped)
+	}
+
+	// Cancel in-flight poll and vote requests to ensure election goroutines terminate promptly.
+	r.cancel()
 	return r.ActiveRole.Stop()
 }
 
@@ -72,6 +106,16 @@ func (r *CandidateRole) Vote(ctx context.Context, request *raft.VoteRequest) (*r
 	r.raft.WriteLock()
 	defer r.raft.WriteUnlock()
 
+	// If the role is stopping, decline to vote.
+	if response := r.checkVoteActive(); response != nil {
+		return response, nil
+	}
+
+	// If the candidate has exceeded the election rate limit, reject the vote before it can update the term.
+	if response := r.checkVoteRateLimit(request); response != nil {
+		return response, nil
+	}
+
 	// If the request indicates a term that is greater than the current term then
 	// assign that term and leader to the current context and step down as a candidate.
 	if r.updateTermAndLeader(request.Term, nil) {
@@ -110,30 +154,143 @@ func (r *CandidateRole) resetElectionTimeout() {
 		return
 	}
 
-	// Set the election timeout in a semi-random fashion with the random range
-	// being election timeout and 2 * election timeout.
-	timeout := r.raft.Config().GetElectionTimeoutOrDefault() + time.Duration(rand.Int63n(int64(r.raft.Config().GetElectionTimeoutOrDefault())))
-	r.electionTimer = time.NewTimer(timeout)
-	electionCh := r.electionTimer.C
+	// Set the election timeout in a semi-random fashion with the random range being the election timeout
+	// and the election timeout lengthened by the election jitter, capped at the max election timeout.
+	timeout := r.randomElectionTimeout()
+	electionTimer := time.NewTimer(timeout)
+	r.electionTimer = electionTimer
+	electionCh := electionTimer.C
 	r.electionExpired = make(chan bool, 1)
 	expiredCh := r.electionExpired
 	go func() {
 		select {
 		case <-electionCh:
-			r.raft.ReadLock()
+			r.raft.WriteLock()
+			// Disarm the timer unless it has already been replaced by a newer timer.
+			if r.electionTimer == electionTimer {
+				r.electionTimer = nil
+			}
 			if r.active {
-				// When the election times out, clear the previous majority vote
-				// check and restart the election.
-				r.log.Debug("Election round for term %d expired: not enough votes received within the election timeout; restarting election", r.raft.Term())
-				go r.sendVoteRequests()
+				r.raft.Metrics().IncElectionTimeouts(raft.RoleCandidate)
+
+				// When the election times out, poll the cluster before restarting the election to avoid
+				// incrementing the term while the candidate is unable to win an election, e.g. if partitioned.
+				r.log.Debug("Election round for term %d expired: not enough votes received within the election timeout; polling members", r.raft.Term())
+				go r.sendPollRequests()
 			}
-			r.raft.ReadUnlock()
+			r.raft.WriteUnlock()
 		case <-expiredCh:
 			return
 		}
 	}()
 }
 
+// sendPollRequests polls members to determine whether the candidate could win an election in the next term.
+// If a quorum of members would vote for the candidate, a new election round is started. Otherwise, the election
+// timer is reset without incrementing the term.
+func (r *CandidateRole) sendPollRequests() {
+	r.raft.ReadLock()
+	if !r.active {
+		r.raft.ReadUnlock()
+		return
+	}
+	term := r.raft.Term()
+	lastEntry := r.store.Writer().LastEntry()
+	votingMembers := r.raft.VotingMembers()
+	voterSets := r.raft.VoterSets()
+	r.raft.ReadUnlock()
+
+	var lastIndex raft.Index
+	var lastTerm raft.Term
+	if lastEntry != nil {
+		lastIndex = lastEntry.Index
+		lastTerm = lastEntry.Entry.Term
+	}
+
+	request := &raft.PollRequest{
+		Term:         term,
+		Candidate:    r.raft.Member(),
+		LastLogIndex: lastIndex,
+		LastLogTerm:  lastTerm,
+	}
+
+	// Members that do not respond within the election timeout are counted as rejecting the poll.
+	ctx, cancel := context.WithTimeout(r.ctx, r.raft.ConfiguredElectionTimeout())
+	defer cancel()
+
+	r.log.Debug("Polling members %v", votingMembers)
+	type poll struct {
+		member   raft.MemberID
+		response *raft.PollResponse
+	}
+	responses := make(chan poll, len(votingMembers))
+	for _, member := range votingMembers {
+		if member == request.Candidate {
+			continue
+		}
+		go func(member raft.MemberID) {
+			r.log.Send("PollRequest", request)
+			startTime := time.Now()
+			response, err := r.raft.Protocol().Poll(ctx, request, member)
+			if err != nil {
+				r.log.Warn("Poll request failed", err)
+				responses <- poll{member: member}
+			} else {
+				r.log.Receive("PollResponse", response)
+				r.raft.ObserveRoundTrip(member, time.Since(startTime))
+				responses <- poll{member: member, response: response}
+			}
+		}(member)
+	}
+
+	// Vote for yourself!
+	accepted := map[raft.MemberID]bool{request.Candidate: true}
+	rejected := make(map[raft.MemberID]bool)
+	for !isQuorum(voterSets, accepted) && !isRejected(voterSets, rejected) && len(accepted)+len(rejected) < len(votingMembers) {
+		poll := <-responses
+		response := poll.response
+		if response == nil || response.Status != raft.ResponseStatus_OK {
+			rejected[poll.member] = true
+			continue
+		}
+
+		// If the response term is greater than the candidate's term, step down.
+		if response.Term > term {
+			r.raft.WriteLock()
+			if r.active && response.Term > r.raft.Term() {
+				r.log.Debug("Received greater term; transitioning back to follower")
+				_ = r.raft.SetTerm(response.Term)
+				r.raft.SetRole(raft.RoleFollower)
+			}
+			r.raft.WriteUnlock()
+			return
+		}
+
+		if response.Accepted {
+			accepted[poll.member] = true
+		} else {
+			rejected[poll.member] = true
+		}
+	}
+
+	r.raft.WriteLock()
+	defer r.raft.WriteUnlock()
+	if !r.active || r.raft.Term() != term {
+		return
+	}
+
+	// If a quorum of the cluster would vote for the candidate, start a new election round.
+	if isQuorum(voterSets, accepted) {
+		r.log.Debug("Received %d/%d pre-votes; restarting election", len(accepted), len(votingMembers))
+		go r.sendVoteRequests()
+		return
+	}
+
+	// Otherwise, wait for another election timeout before polling the cluster again.
+	r.log.Debug("Received %d/%d pre-votes; resetting election timeout", len(accepted), len(votingMembers))
+	r.resetElectionTimeout()
+}
+
 // sendVoteRequests sends vote requests to peers
 func (r *CandidateRole) sendVoteRequests() {
 	r.raft.WriteLock()
@@ -145,128 +302,252 @@ func (r *CandidateRole) sendVoteRequests() {
 		return
 	}
 
-	// Reset the election timeout.
-	r.resetElectionTimeout()
+	// If the local state machine has stopped applying committed entries, refuse to campaign until
+	// the stall clears to allow a healthy member to be elected.
+	if r.raft.ApplyStalled() {
+		r.log.Warn("Apply loop stalled; abandoning election")
+		defer r.raft.WriteUnlock()
+		r.raft.SetRole(raft.RoleFollower)
+		return
+	}
 
-	// When the election timer is reset, increment the current term and
-	// restart the election.
-	member := r.raft.Member()
-	if err := r.raft.SetTerm(r.raft.Term() + 1); err != nil {
-		r.log.Error("Failed to increment term", err)
+	// If elections are frozen cluster-wide, don't campaign for leadership until the freeze is lifted.
+	if r.raft.ElectionsFrozen() {
+		r.log.Debug("Elections are frozen; abandoning election")
 		defer r.raft.WriteUnlock()
 		r.raft.SetRole(raft.RoleFollower)
 		return
 	}
-	if err := r.raft.SetLastVotedFor(member); err != nil {
-		r.log.Error("Failed to vote for self", err)
+
+	// Learners do not vote and cannot be elected leader.
+	if !r.raft.IsVoter(r.raft.Member()) {
+		r.log.Debug("Member is a learner; abandoning election")
 		defer r.raft.WriteUnlock()
 		r.raft.SetRole(raft.RoleFollower)
 		return
 	}
-	term := r.raft.Term()
-	r.raft.WriteUnlock()
 
-	// Create a quorum that will track the number of nodes that have responded to the poll request.
-	votingMembers := r.raft.Members()
+	// Reset the election timeout.
+	r.resetElectionTimeout()
 
-	// Compute the quorum and create a goroutine to count votes
-	votes := make(chan bool, len(votingMembers))
-	quorum := int(math.Floor(float64(len(votingMembers))/2.0) + 1)
-	go func() {
-		voteCount := 0
-		rejectCount := 0
-		for vote := range votes {
-			r.raft.WriteLock()
-			if !r.active || r.raft.Term() != term {
-				r.raft.WriteUnlock()
-				return
-			}
-			if vote {
-				// If no other leader has been discovered and a quorum of votes was received, transition to leader.
-				voteCount++
-				if r.raft.Leader() == nil && voteCount == quorum {
-					r.log.Debug("Won election with %d/%d votes; transitioning to leader", voteCount, len(votingMembers))
-					r.raft.SetRole(raft.RoleLeader)
-					r.raft.WriteUnlock()
-					return
-				}
-				r.raft.WriteUnlock()
-			} else {
-				// If a quorum of vote requests were rejected, transition back to follower.
-				rejectCount++
-				if rejectCount == quorum {
-					r.log.Debug("Lost election with %d/%d votes rejected; transitioning back to follower", rejectCount, len(votingMembers))
-					r.raft.SetRole(raft.RoleFollower)
-					r.raft.WriteUnlock()
-					return
-				}
-				r.raft.WriteUnlock()
-			}
+	// When the election timer is reset, increment the current term and
+	// restart the election.
+	member := r.raft.Member()
+	if r.raft.Config().BatchElectionMetadata {
+		// Persist the term increment and the vote for self in a single metadata store write.
+		if err := r.raft.SetTermAndVote(r.raft.Term()+1, member); err != nil {
+			r.log.Error("Failed to increment term and vote for self", err)
+			defer r.raft.WriteUnlock()
+			r.raft.SetRole(raft.RoleFollower)
+			return
 		}
-	}()
+	} else {
+		if err := r.raft.SetTerm(r.raft.Term() + 1); err != nil {
+			r.log.Error("Failed to increment term", err)
+			defer r.raft.WriteUnlock()
+			r.raft.SetRole(raft.RoleFollower)
+			return
+		}
+		if err := r.raft.SetLastVotedFor(member); err != nil {
+			r.log.Error("Failed to vote for self", err)
+			defer r.raft.WriteUnlock()
+			r.raft.SetRole(raft.RoleFollower)
+			return
+		}
+	}
+	term := r.raft.Term()
+	r.raft.Metrics().IncElections()
+
+	// Vote for yourself!
+	r.accepted = map[raft.MemberID]bool{member: true}
+	r.rejected = make(map[raft.MemberID]bool)
 
-	// First, load the last log entry to get its term. We load the entry
+	// Compute the quorum for the round from the voting members only. Learners are not sent vote requests.
+	// During a joint configuration change, the candidate must win a majority of both the old and the new
+	// configurations. Votes for the round are counted by the candidate's vote counter.
+	votingMembers := r.raft.VotingMembers()
+	r.voterSets = r.raft.VoterSets()
+
+	// Load the last log entry to get its term. We load the entry
 	// by its index since the index is required by the protocol.
-	r.raft.ReadLock()
 	lastEntry := r.store.Writer().LastEntry()
-	r.raft.ReadUnlock()
+	configurationIndex := r.lastConfigurationIndex()
+	r.raft.WriteUnlock()
+
 	var lastIndex raft.Index
+	var lastTerm raft.Term
 	if lastEntry != nil {
 		lastIndex = lastEntry.Index
+		lastTerm = lastEntry.Entry.Term
 	}
 
-	var lastTerm raft.Term
-	if lastEntry != nil {
-		lastTerm = lastEntry.Entry.Term
+	// The request is identical for every member, so create it once and share it between the vote requests.
+	request := &raft.VoteRequest{
+		Term:               term,
+		Candidate:          member,
+		LastLogIndex:       lastIndex,
+		LastLogTerm:        lastTerm,
+		ConfigurationIndex: configurationIndex,
 	}
 
 	r.log.Debug("Requesting votes for term %d", term)
 
-	// Once we got the last log term, iterate through each current member
-	// of the cluster and request a vote from each.
+	// Iterate through each current member of the cluster and request a vote from each.
 	for _, member := range votingMembers {
-		// Vote for yourself!
-		if member == r.raft.Member() {
-			votes <- true
-			continue
+		if member != request.Candidate {
+			go r.requestVote(request, member)
 		}
+	}
+}
 
-		go func(member raft.MemberID) {
-			r.log.Debug("Requesting vote from %s for term %d", member, term)
-			request := &raft.VoteRequest{
-				Term:         term,
-				Candidate:    r.raft.Member(),
-				LastLogIndex: lastIndex,
-				LastLogTerm:  lastTerm,
-			}
+// requestVote sends the given vote request to a member and passes the outcome to the vote counter
+func (r *CandidateRole) requestVote(request *raft.VoteRequest, member raft.MemberID) {
+	r.log.Debug("Requesting vote from %s for term %d", member, request.Term)
+	r.log.Send("VoteRequest", request)
+	// Members that do not respond within the election timeout are counted as rejecting the vote.
+	ctx, cancel := context.WithTimeout(r.ctx, r.raft.ConfiguredElectionTimeout())
+	defer cancel()
+	startTime := time.Now()
+	response, err := r.raft.Protocol().Vote(ctx, request, member)
+	if err == nil {
+		r.log.Receive("VoteResponse", response)
+		r.raft.ObserveRoundTrip(member, time.Since(startTime))
+	}
+	select {
+	case r.votes <- vote{term: request.Term, member: member, response: response, err: err}:
+	case <-r.stopped:
+	}
+}
 
-			r.log.Send("VoteRequest", request)
-			response, err := r.raft.Protocol().Vote(context.Background(), request, member)
-			if err != nil {
-				votes <- false
-				r.log.Warn("Failed to request vote from %s", member, err)
-			} else {
-				r.log.Receive("VoteResponse", response)
-				r.raft.WriteLock()
-				if response.Term > request.Term {
-					r.log.Debug("Received greater term from %s; transitioning back to follower", member)
-					_ = r.raft.SetTerm(response.Term)
-					r.raft.SetRole(raft.RoleFollower)
-					r.raft.WriteUnlock()
-					close(votes)
-					return
-				} else if !response.Voted {
-					r.log.Debug("Received rejected vote from %s", member)
-					votes <- false
-				} else if response.Term != r.raft.Term() {
-					r.log.Debug("Received successful vote for a different term from %s", member)
-					votes <- false
-				} else {
-					r.log.Debug("Received successful vote from %s", member)
-					votes <- true
+// countVotes counts the votes received in each election round until the candidate is stopped
+func (r *CandidateRole) countVotes() {
+	for {
+		select {
+		case vote := <-r.votes:
+			// Count the vote along with any further votes that are already buffered
+			// to avoid acquiring the write lock once per vote in large clusters.
+			r.raft.WriteLock()
+			r.countVote(vote)
+			for drained := false; !drained && r.active; {
+				select {
+				case vote := <-r.votes:
+					r.countVote(vote)
+				default:
+					drained = true
 				}
-				r.raft.WriteUnlock()
 			}
-		}(member)
+			r.raft.WriteUnlock()
+		case <-r.stopped:
+			return
+		}
+	}
+}
+
+// countVote counts a vote towards the current election round
+// The caller must hold the write lock.
+func (r *CandidateRole) countVote(vote vote) {
+	if !r.active {
+		r.log.Debug("Discarding vote from %s; candidate is no longer active", vote.member)
+		return
+	}
+
+	if vote.err != nil {
+		r.log.Warn("Failed to request vote from %s", vote.member, vote.err)
+		if r.raft.Term() == vote.term {
+			r.rejectVote(vote.member)
+		}
+		return
+	}
+
+	response := vote.response
+	if response.Term > r.raft.Term() {
+		r.log.Debug("Received greater term from %s; transitioning back to follower", vote.member)
+		_ = r.raft.SetTerm(response.Term)
+		r.raft.SetRole(raft.RoleFollower)
+	} else if r.raft.Term() != vote.term {
+		// If the election round was superseded while the request was in flight, e.g. the election
+		// timed out and a new round was started, discard the response. The response must not be
+		// counted towards the new round, and a term in the response greater than the request
+		// term may be the term of the new round rather than a newer leader's term.
+		r.log.Debug("Discarding vote from %s for superseded term %d", vote.member, vote.term)
+	} else if !response.Voted {
+		r.log.Debug("Received rejected vote from %s", vote.member)
+		r.rejectVote(vote.member)
+	} else if response.Term != vote.term {
+		r.log.Debug("Received successful vote for a different term from %s", vote.member)
+		r.rejectVote(vote.member)
+	} else {
+		r.log.Debug("Received successful vote from %s", vote.member)
+		r.acceptVote(vote.member)
+	}
+}
+
+// acceptVote counts an accepted vote towards the current election round
+func (r *CandidateRole) acceptVote(member raft.MemberID) {
+	// If no other leader has been discovered and a quorum of votes was received, transition to leader.
+	// Votes are only counted once the round has been decided to avoid transitioning twice.
+	if isQuorum(r.voterSets, r.accepted) || isRejected(r.voterSets, r.rejected) {
+		return
+	}
+	r.accepted[member] = true
+	if r.raft.Leader() == nil && isQuorum(r.voterSets, r.accepted) {
+		r.log.Debug("Won election with %d/%d votes; transitioning to leader", len(r.accepted), len(r.raft.VotingMembers()))
+		r.raft.SetRole(raft.RoleLeader)
+	}
+}
+
+// rejectVote counts a rejected vote towards the current election round
+func (r *CandidateRole) rejectVote(member raft.MemberID) {
+	// If a quorum of vote requests were rejected, transition back to follower.
+	if isQuorum(r.voterSets, r.accepted) || isRejected(r.voterSets, r.rejected) {
+		return
+	}
+	r.rejected[member] = true
+	if isRejected(r.voterSets, r.rejected) {
+		r.log.Debug("Lost election with %d/%d votes rejected; transitioning back to follower", len(r.rejected), len(r.raft.VotingMembers()))
+		r.raft.SetRole(raft.RoleFollower)
+	}
+}
+
+// isQuorum returns whether the given members form a majority of every voter set
+func isQuorum(voterSets [][]raft.MemberID, members map[raft.MemberID]bool) bool {
+	for _, voters := range voterSets {
+		if !isMajority(voters, members) {
+			return false
+		}
+	}
+	return true
+}
+
+// isRejected returns whether the given members form a majority of any voter set, preventing a quorum
+func isRejected(voterSets [][]raft.MemberID, members map[raft.MemberID]bool) bool {
+	for _, voters := range voterSets {
+		if isMajority(voters, members) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMajority returns whether the given members form a majority of the given voters
+func isMajority(voters []raft.MemberID, members map[raft.MemberID]bool) bool {
+	count := 0
+	for _, voter := range voters {
+		if members[voter] {
+			count++
+		}
+	}
+	return count >= len(voters)/2+1
+}
+
+// lastConfigurationIndex returns the index of the latest configuration known to the candidate,
+// including any pending configuration change
+func (r *CandidateRole) lastConfigurationIndex() raft.Index {
+	configuration, pending := r.raft.Configuration()
+	if pending != nil {
+		return pending.Index
+	} else if configuration != nil {
+		return configuration.Index
 	}
+	return 0
 }
@@ -19,6 +19,7 @@ import (
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/protocol/mock"
 	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -28,6 +29,7 @@ func TestCandidateVote(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
 	delayFailVote(client, 5*time.Second).AnyTimes()
+	acceptPoll(client).AnyTimes()
 
 	role := newTestRole(client, newCandidateRole, mockFollower(ctrl), mockLeader(ctrl)).(*CandidateRole)
 	assert.NoError(t, role.raft.SetTerm(1))
@@ -80,6 +82,7 @@ func TestCandidateVote(t *testing.T) {
 func TestCandidateVoteQuorum(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
+	rejectPoll(client).AnyTimes()
 	acceptVote(client)
 	rejectVote(client).AnyTimes()
 
@@ -87,23 +90,122 @@ func TestCandidateVoteQuorum(t *testing.T) {
 	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
 	assert.NoError(t, role.Start())
 	assert.Equal(t, raft.RoleLeader, awaitRole(role.raft, raft.RoleLeader))
+	accepted, _ := role.voteTally()
+	assert.Equal(t, 2, accepted)
+}
+
+func TestCandidateLearnerQuorum(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	rejectPoll(client).AnyTimes()
+
+	// Vote requests are sent only to voting members. A vote request to a learner fails the test.
+	client.EXPECT().
+		Vote(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("member-1"))).
+		Return(&raft.VoteResponse{Status: raft.ResponseStatus_OK, Term: 2, Voted: true}, nil)
+	client.EXPECT().
+		Vote(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("member-2"))).
+		Return(&raft.VoteResponse{Status: raft.ResponseStatus_OK, Term: 2, Voted: false}, nil).
+		AnyTimes()
+
+	protocol, sm, stores := newLargeTestState(client, 5, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	setLearners(protocol, "member-3", "member-4")
+	assert.Len(t, protocol.VotingMembers(), 3)
+	assert.True(t, protocol.IsVoter("member-0"))
+	assert.False(t, protocol.IsVoter("member-3"))
+
+	// Verify the candidate is elected by a quorum of the voting members
+	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
+	assert.NoError(t, role.raft.SetTerm(1))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.RoleLeader, awaitRole(role.raft, raft.RoleLeader))
+	accepted, _ := role.voteTally()
+	assert.Equal(t, 2, accepted)
+}
+
+func TestCandidateJointQuorum(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	rejectPoll(client).AnyTimes()
+
+	// A majority of all the voters accept the vote, but only the old configuration's voters.
+	for _, member := range []raft.MemberID{"member-1", "member-2"} {
+		client.EXPECT().
+			Vote(gomock.Any(), gomock.Any(), gomock.Eq(member)).
+			Return(&raft.VoteResponse{Status: raft.ResponseStatus_OK, Term: 2, Voted: true}, nil).
+			AnyTimes()
+	}
+	for _, member := range []raft.MemberID{"member-3", "member-4"} {
+		client.EXPECT().
+			Vote(gomock.Any(), gomock.Any(), gomock.Eq(member)).
+			Return(&raft.VoteResponse{Status: raft.ResponseStatus_OK, Term: 2, Voted: false}, nil).
+			AnyTimes()
+	}
+
+	protocol, sm, stores := newLargeTestState(client, 5, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	protocol.SetConfiguration(&raft.Configuration{
+		Members: []*raft.Member{
+			{MemberID: "member-0", Type: raft.Member_ACTIVE},
+			{MemberID: "member-3", Type: raft.Member_ACTIVE},
+			{MemberID: "member-4", Type: raft.Member_ACTIVE},
+		},
+		OldMembers: []*raft.Member{
+			{MemberID: "member-0", Type: raft.Member_ACTIVE},
+			{MemberID: "member-1", Type: raft.Member_ACTIVE},
+			{MemberID: "member-2", Type: raft.Member_ACTIVE},
+		},
+	})
+	assert.Len(t, protocol.VotingMembers(), 5)
+	assert.Len(t, protocol.VoterSets(), 2)
+
+	// Verify the candidate is not elected without a majority of the new configuration
+	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
+	assert.NoError(t, role.raft.SetTerm(1))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+	_, rejected := role.voteTally()
+	assert.Equal(t, 2, rejected)
+}
+
+func TestCandidateWitness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Verify a witness counts as a voter but never requests votes
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	setWitnesses(protocol, protocol.Member())
+	assert.True(t, protocol.IsVoter(protocol.Member()))
+	assert.True(t, protocol.IsWitness(protocol.Member()))
+	assert.Len(t, protocol.VotingMembers(), 3)
+
+	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
+	assert.NoError(t, role.raft.SetTerm(1))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Term(1), role.raft.Term())
+	role.raft.ReadUnlock()
 }
 
 func TestCandidateVoteFail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
+	rejectPoll(client).AnyTimes()
 	rejectVote(client).AnyTimes()
 
 	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
 	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
 	assert.NoError(t, role.Start())
 	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+	_, rejected := role.voteTally()
+	assert.Equal(t, 2, rejected)
 }
 
 func TestCandidateVoteTimeout(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
 	delayFailVote(client, 5*time.Second).AnyTimes()
+	acceptPoll(client).AnyTimes()
 
 	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
 	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
@@ -116,11 +218,325 @@ func TestCandidateVoteTimeout(t *testing.T) {
 	assert.Nil(t, role.raft.Leader())
 	assert.Equal(t, role.raft.Member(), *role.raft.LastVotedFor())
 	role.raft.ReadUnlock()
+	assert.True(t, isElectionTimerArmed(role))
 
-	// Verify that the term is incremented and the candidate votes for itself again
+	// Verify that the term is incremented and the candidate votes for itself again once the poll is accepted
 	assert.Equal(t, raft.Term(2), awaitTerm(role.raft, raft.Term(2)))
 	assert.Equal(t, raft.RoleType(""), role.raft.Role())
 	assert.Equal(t, raft.Term(2), role.raft.Term())
 	assert.Nil(t, role.raft.Leader())
 	assert.Equal(t, role.raft.Member(), *role.raft.LastVotedFor())
 }
+
+func TestCandidatePollTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	delayFailVote(client, 5*time.Second).AnyTimes()
+	polls := make(chan *raft.PollRequest, 10)
+	client.EXPECT().
+		Poll(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.PollRequest, member raft.MemberID) (*raft.PollResponse, error) {
+			polls <- request
+			return &raft.PollResponse{
+				Status:   raft.ResponseStatus_OK,
+				Term:     request.Term,
+				Accepted: false,
+			}, nil
+		}).AnyTimes()
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Term(1), awaitTerm(role.raft, raft.Term(1)))
+
+	// Verify the candidate polls the cluster once the election round expires
+	request := <-polls
+	assert.Equal(t, raft.Term(1), request.Term)
+	assert.Equal(t, role.raft.Member(), request.Candidate)
+	<-polls
+
+	// Verify the term is not incremented if the poll is rejected and the election timer is reset
+	assert.True(t, awaitElectionTimerArmed(role))
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Term(1), role.raft.Term())
+	role.raft.ReadUnlock()
+
+	// Verify the candidate polls the cluster again at the same term once the election timer expires
+	request = <-polls
+	assert.Equal(t, raft.Term(1), request.Term)
+	role.raft.WriteLock()
+	assert.NoError(t, role.Stop())
+	role.raft.WriteUnlock()
+}
+
+func TestCandidateStopDuringElection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block vote requests until they're cancelled
+	requested := make(chan raft.MemberID, 2)
+	cancelled := make(chan raft.MemberID, 2)
+	client.EXPECT().
+		Vote(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.VoteRequest, member raft.MemberID) (*raft.VoteResponse, error) {
+			requested <- member
+			<-ctx.Done()
+			cancelled <- member
+			return nil, ctx.Err()
+		}).Times(2)
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
+	assert.NoError(t, role.raft.SetTerm(1))
+	assert.NoError(t, role.Start())
+	<-requested
+	<-requested
+
+	// Verify stopping the candidate during an election cancels the in-flight vote requests
+	role.raft.WriteLock()
+	assert.NoError(t, role.Stop())
+	role.raft.WriteUnlock()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			assert.Fail(t, "vote request was not cancelled")
+		}
+	}
+	assert.Equal(t, raft.Term(2), role.raft.Term())
+}
+
+func TestCandidateVoteDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	rejectPoll(client).AnyTimes()
+
+	// Block vote requests until their deadline expires
+	deadlines := make(chan bool, 2)
+	client.EXPECT().
+		Vote(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.VoteRequest, member raft.MemberID) (*raft.VoteResponse, error) {
+			_, ok := ctx.Deadline()
+			deadlines <- ok
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).Times(2)
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	electionTimeout := 100 * time.Millisecond
+	protocol.Config().ElectionTimeout = &electionTimeout
+	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
+	assert.NoError(t, role.raft.SetTerm(1))
+	assert.NoError(t, role.Start())
+	assert.True(t, <-deadlines)
+	assert.True(t, <-deadlines)
+
+	// Verify the unresponsive members are counted as rejecting the vote once the deadline passes
+	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+	_, rejected := role.voteTally()
+	assert.Equal(t, 2, rejected)
+}
+
+func TestCandidateVoteConfigurationIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	rejectPoll(client).AnyTimes()
+	indexes := make(chan raft.Index, 2)
+	client.EXPECT().
+		Vote(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.VoteRequest, member raft.MemberID) (*raft.VoteResponse, error) {
+			indexes <- request.ConfigurationIndex
+			return &raft.VoteResponse{
+				Status: raft.ResponseStatus_OK,
+				Term:   request.Term,
+				Voted:  false,
+			}, nil
+		}).Times(2)
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	protocol.SetConfiguration(&raft.Configuration{
+		Index: 3,
+		Term:  1,
+		Members: []*raft.Member{
+			{MemberID: "foo", Type: raft.Member_ACTIVE},
+			{MemberID: "bar", Type: raft.Member_ACTIVE},
+			{MemberID: "baz", Type: raft.Member_ACTIVE},
+		},
+	})
+
+	// Verify the candidate's latest configuration index is included in vote requests
+	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(3), <-indexes)
+	assert.Equal(t, raft.Index(3), <-indexes)
+	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+}
+
+func TestCandidateElectionFreeze(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	rejectPoll(client).AnyTimes()
+
+	// Verify the candidate steps down without requesting votes or incrementing the term while elections are frozen
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	protocol.WriteLock()
+	protocol.SetElectionFreeze(0, time.Minute)
+	protocol.WriteUnlock()
+	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Term(0), role.raft.Term())
+	assert.Nil(t, role.raft.LastVotedFor())
+	role.raft.ReadUnlock()
+}
+
+func TestCandidateBatchElectionMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	delayFailVote(client, 5*time.Second).AnyTimes()
+	acceptPoll(client).AnyTimes()
+
+	role := newTestRole(client, newCandidateRole, mockFollower(ctrl), mockLeader(ctrl)).(*CandidateRole)
+	role.raft.Config().BatchElectionMetadata = true
+	assert.NoError(t, role.raft.SetTerm(1))
+	assert.NoError(t, role.Start())
+	awaitTerm(role.raft, raft.Term(2))
+
+	// Verify the candidate incremented the term and voted for itself
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Term(2), role.raft.Term())
+	assert.Equal(t, role.raft.Member(), *role.raft.LastVotedFor())
+	role.raft.ReadUnlock()
+
+	response, err := role.Vote(context.TODO(), &raft.VoteRequest{
+		Term:         raft.Term(2),
+		Candidate:    raft.MemberID("bar"),
+		LastLogIndex: 1,
+		LastLogTerm:  1,
+	})
+	assert.NoError(t, err)
+	assert.False(t, response.Voted)
+	assert.Equal(t, raft.Term(2), response.Term)
+}
+
+func TestCandidateSupersededVotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	rejectPoll(client).AnyTimes()
+
+	// Hold vote requests until a response is provided by the test
+	type pendingVote struct {
+		member   raft.MemberID
+		request  *raft.VoteRequest
+		response chan *raft.VoteResponse
+	}
+	votes := make(chan pendingVote, 10)
+	client.EXPECT().
+		Vote(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.VoteRequest, member raft.MemberID) (*raft.VoteResponse, error) {
+			vote := pendingVote{member: member, request: request, response: make(chan *raft.VoteResponse)}
+			votes <- vote
+			return <-vote.response, nil
+		}).AnyTimes()
+	awaitVotes := func(term raft.Term) map[raft.MemberID]pendingVote {
+		pending := make(map[raft.MemberID]pendingVote)
+		for len(pending) < 2 {
+			vote := <-votes
+			assert.Equal(t, term, vote.request.Term)
+			pending[vote.member] = vote
+		}
+		return pending
+	}
+
+	// Start an election for the first term
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
+	assert.NoError(t, role.Start())
+	superseded := awaitVotes(raft.Term(1))
+
+	// Start a new election round while votes for the first term are still in flight
+	role.sendVoteRequests()
+	current := awaitVotes(raft.Term(2))
+
+	// Respond to the superseded requests with a grant for the prior term and a rejection from a member
+	// that has already seen the new round's term
+	superseded["bar"].response <- &raft.VoteResponse{
+		Status: raft.ResponseStatus_OK,
+		Term:   raft.Term(1),
+		Voted:  true,
+	}
+	superseded["baz"].response <- &raft.VoteResponse{
+		Status: raft.ResponseStatus_OK,
+		Term:   raft.Term(2),
+		Voted:  false,
+	}
+
+	// Verify the superseded responses do not contribute to the current round or end the election
+	time.Sleep(100 * time.Millisecond)
+	accepted, rejected := role.voteTally()
+	assert.Equal(t, 1, accepted)
+	assert.Equal(t, 0, rejected)
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Term(2), role.raft.Term())
+	assert.NotEqual(t, raft.RoleFollower, role.raft.Role())
+	role.raft.ReadUnlock()
+
+	// Verify a grant for the current round wins the election
+	current["bar"].response <- &raft.VoteResponse{
+		Status: raft.ResponseStatus_OK,
+		Term:   raft.Term(2),
+		Voted:  true,
+	}
+	assert.Equal(t, raft.RoleLeader, awaitRole(role.raft, raft.RoleLeader))
+	accepted, _ = role.voteTally()
+	assert.Equal(t, 2, accepted)
+	current["baz"].response <- &raft.VoteResponse{
+		Status: raft.ResponseStatus_OK,
+		Term:   raft.Term(2),
+		Voted:  false,
+	}
+}
+
+// voteClient is a raft.Client that grants every vote request without the overhead of a mock
+type voteClient struct {
+	*mock.MockClient
+}
+
+func (c *voteClient) Vote(ctx context.Context, request *raft.VoteRequest, member raft.MemberID) (*raft.VoteResponse, error) {
+	return &raft.VoteResponse{
+		Status: raft.ResponseStatus_OK,
+		Term:   request.Term,
+		Voted:  true,
+	}, nil
+}
+
+func BenchmarkCandidateElection(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	client := &voteClient{MockClient: mock.NewMockClient(ctrl)}
+
+	// Silence per-vote logging so the benchmark measures the election path itself
+	level := logrus.GetLevel()
+	logrus.SetLevel(logrus.InfoLevel)
+	defer logrus.SetLevel(level)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		protocol, sm, stores := newLargeTestState(client, 31, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+		role := newCandidateRole(protocol, sm, stores).(*CandidateRole)
+		b.StartTimer()
+		if err := role.Start(); err != nil {
+			b.Fatal(err)
+		}
+		awaitRole(role.raft, raft.RoleLeader)
+		b.StopTimer()
+
+		// Stop the candidate to disarm its election timer before starting the next election
+		role.raft.WriteLock()
+		_ = role.Stop()
+		role.raft.WriteUnlock()
+		b.StartTimer()
+	}
+}
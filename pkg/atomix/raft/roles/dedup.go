@@ -0,0 +1,201 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	"container/list"
+	"github.com/atomix/go-framework/pkg/atomix/service"
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/gogo/protobuf/proto"
+	"sync"
+	"time"
+)
+
+// dedupEntryOverhead is the estimated size in bytes of a session's entry in the dedup cache, excluding its output
+const dedupEntryOverhead = 64
+
+// newDedupCache returns a new command dedup cache
+func newDedupCache() *dedupCache {
+	return &dedupCache{
+		sessions: make(map[uint64]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// dedupCache caches the output of the last command applied for each session so the leader can respond to a
+// client's retry of the command without appending a duplicate entry to the log. The cache is bounded by a
+// memory budget: once the budget is exceeded, the least recently used sessions are evicted. Sessions that have
+// not been used within the expiration are also evicted. The retry of a command whose session has been evicted
+// is not deduplicated by the cache; it's appended to the log and handled by the state machine's sessions.
+type dedupCache struct {
+	sessions map[uint64]*list.Element
+	// lru is the order in which sessions were last used, from least to most recently used
+	lru *list.List
+	// size is the estimated size in bytes of all the sessions in the cache
+	size uint64
+	mu   sync.Mutex
+}
+
+// dedupEntry is the output of the last command cached for a session
+type dedupEntry struct {
+	session  uint64
+	sequence uint64
+	output   []byte
+	// time is the time the session was last used
+	time time.Time
+}
+
+// sizeOf returns the estimated size in bytes of the given entry
+func (e *dedupEntry) sizeOf() uint64 {
+	return dedupEntryOverhead + uint64(len(e.output))
+}
+
+// get returns the cached output of the given command if it's the last command cached for the session
+func (c *dedupCache) get(session uint64, sequence uint64, now time.Time, expiration time.Duration) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.sessions[session]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*dedupEntry)
+	if expiration > 0 && now.Sub(entry.time) > expiration {
+		c.remove(element)
+		return nil, false
+	}
+	if entry.sequence != sequence {
+		return nil, false
+	}
+	entry.time = now
+	c.lru.MoveToBack(element)
+	return entry.output, true
+}
+
+// put caches the output of the given command as the last command for the session, then evicts expired
+// sessions and the least recently used sessions until the cache is within the given budget
+func (c *dedupCache) put(session uint64, sequence uint64, output []byte, now time.Time, maxBytes uint64, expiration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.sessions[session]; ok {
+		entry := element.Value.(*dedupEntry)
+		if sequence < entry.sequence {
+			return
+		}
+		c.size -= entry.sizeOf()
+		entry.sequence = sequence
+		entry.output = output
+		entry.time = now
+		c.size += entry.sizeOf()
+		c.lru.MoveToBack(element)
+	} else {
+		entry := &dedupEntry{
+			session:  session,
+			sequence: sequence,
+			output:   output,
+			time:     now,
+		}
+		c.sessions[session] = c.lru.PushBack(entry)
+		c.size += entry.sizeOf()
+	}
+	c.evict(now, maxBytes, expiration)
+}
+
+// evict evicts the least recently used sessions while the cache exceeds the given budget or the least
+// recently used session has expired
+func (c *dedupCache) evict(now time.Time, maxBytes uint64, expiration time.Duration) {
+	for element := c.lru.Front(); element != nil; element = c.lru.Front() {
+		entry := element.Value.(*dedupEntry)
+		if (maxBytes == 0 || c.size <= maxBytes) && (expiration <= 0 || now.Sub(entry.time) <= expiration) {
+			return
+		}
+		c.remove(element)
+	}
+}
+
+// remove removes the given session from the cache
+func (c *dedupCache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*dedupEntry)
+	delete(c.sessions, entry.session)
+	c.size -= entry.sizeOf()
+}
+
+// getCommandSequence returns the session ID and sequence number of the given command, or false if the
+// command is not a session command
+func getCommandSequence(value []byte) (uint64, uint64, bool) {
+	serviceRequest := &service.ServiceRequest{}
+	if err := proto.Unmarshal(value, serviceRequest); err != nil {
+		return 0, 0, false
+	}
+	sessionRequest := &service.SessionRequest{}
+	if err := proto.Unmarshal(serviceRequest.GetCommand(), sessionRequest); err != nil {
+		return 0, 0, false
+	}
+	command, ok := sessionRequest.Request.(*service.SessionRequest_Command)
+	if !ok {
+		return 0, 0, false
+	}
+	context := command.Command.GetContext()
+	if context.GetSessionID() == 0 || context.GetSequenceNumber() == 0 {
+		return 0, 0, false
+	}
+	return context.GetSessionID(), context.GetSequenceNumber(), true
+}
+
+// newDedupStream returns a stream that writes to the given stream and, once closed, calls the given function
+// with the command's output if the command produced a single successful result
+func newDedupStream(stream streams.WriteStream, f func([]byte)) streams.WriteStream {
+	return &dedupStream{
+		stream: stream,
+		f:      f,
+	}
+}
+
+// dedupStream is a command stream that records the command's output for the dedup cache
+type dedupStream struct {
+	stream    streams.WriteStream
+	f         func([]byte)
+	results   int
+	output    []byte
+	succeeded bool
+}
+
+func (s *dedupStream) Send(result streams.Result) {
+	s.results++
+	s.output, s.succeeded = result.Value.([]byte)
+	s.succeeded = s.succeeded && result.Succeeded()
+	s.stream.Send(result)
+}
+
+func (s *dedupStream) Result(value interface{}, err error) {
+	s.Send(streams.Result{
+		Value: value,
+		Error: err,
+	})
+}
+
+func (s *dedupStream) Value(value interface{}) {
+	s.Result(value, nil)
+}
+
+func (s *dedupStream) Error(err error) {
+	s.Result(nil, err)
+}
+
+func (s *dedupStream) Close() {
+	s.stream.Close()
+	if s.results == 1 && s.succeeded {
+		s.f(s.output)
+	}
+}
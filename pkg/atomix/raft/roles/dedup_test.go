@@ -0,0 +1,118 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	"errors"
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDedupCommandSequence(t *testing.T) {
+	session, sequence, ok := getCommandSequence(newSetRequest("Set", 3, 2))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), session)
+	assert.Equal(t, uint64(2), sequence)
+	_, _, ok = getCommandSequence(newOpenSessionRequest())
+	assert.False(t, ok)
+	_, _, ok = getCommandSequence([]byte("garbage"))
+	assert.False(t, ok)
+}
+
+func TestDedupCacheBudget(t *testing.T) {
+	cache := newDedupCache()
+	now := time.Now()
+	output := make([]byte, 100)
+	maxBytes := uint64(100 * (dedupEntryOverhead + len(output)))
+
+	// Verify the cache stays within its budget as many sessions are cached
+	for session := uint64(1); session <= 10000; session++ {
+		cache.put(session, 1, output, now, maxBytes, 0)
+		assert.True(t, cache.size <= maxBytes)
+
+		// Keep the first session in use so it's never the least recently used session
+		_, ok := cache.get(1, 1, now, 0)
+		assert.True(t, ok)
+	}
+	assert.Len(t, cache.sessions, 100)
+	assert.Equal(t, cache.lru.Len(), len(cache.sessions))
+
+	// Verify the least recently used sessions were evicted
+	_, ok := cache.get(2, 1, now, 0)
+	assert.False(t, ok)
+	_, ok = cache.get(10000, 1, now, 0)
+	assert.True(t, ok)
+
+	// Verify only the last command for a session is cached
+	cache.put(10000, 2, []byte("bar"), now, maxBytes, 0)
+	_, ok = cache.get(10000, 1, now, 0)
+	assert.False(t, ok)
+	value, ok := cache.get(10000, 2, now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("bar"), value)
+	cache.put(10000, 1, []byte("foo"), now, maxBytes, 0)
+	value, ok = cache.get(10000, 2, now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("bar"), value)
+	assert.True(t, cache.size <= maxBytes)
+}
+
+func TestDedupCacheExpiration(t *testing.T) {
+	cache := newDedupCache()
+	now := time.Now()
+	expiration := time.Minute
+
+	cache.put(1, 1, []byte("foo"), now, 0, expiration)
+	cache.put(2, 1, []byte("bar"), now.Add(30*time.Second), 0, expiration)
+
+	// Verify sessions that have not been used within the expiration are evicted
+	cache.put(3, 1, []byte("baz"), now.Add(70*time.Second), 0, expiration)
+	_, ok := cache.get(1, 1, now.Add(70*time.Second), expiration)
+	assert.False(t, ok)
+	_, ok = cache.get(2, 1, now.Add(70*time.Second), expiration)
+	assert.True(t, ok)
+	_, ok = cache.get(3, 1, now.Add(140*time.Second), expiration)
+	assert.False(t, ok)
+	assert.Len(t, cache.sessions, 1)
+	assert.Equal(t, uint64(dedupEntryOverhead+3), cache.size)
+}
+
+func TestDedupStream(t *testing.T) {
+	var cached [][]byte
+	cache := func(value []byte) {
+		cached = append(cached, value)
+	}
+
+	// Verify the output of a command with a single successful result is cached once the stream is closed
+	ch := make(chan streams.Result, 3)
+	stream := newDedupStream(streams.NewChannelStream(ch), cache)
+	stream.Value([]byte("foo"))
+	assert.Empty(t, cached)
+	stream.Close()
+	assert.Equal(t, [][]byte{[]byte("foo")}, cached)
+	assert.Equal(t, []byte("foo"), (<-ch).Value)
+
+	// Verify failed and streaming commands are not cached
+	stream = newDedupStream(streams.NewChannelStream(make(chan streams.Result, 3)), cache)
+	stream.Error(errors.New("error"))
+	stream.Close()
+	stream = newDedupStream(streams.NewChannelStream(make(chan streams.Result, 3)), cache)
+	stream.Value([]byte("bar"))
+	stream.Value([]byte("baz"))
+	stream.Close()
+	assert.Len(t, cached, 1)
+}
@@ -0,0 +1,77 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
+	"sync"
+	"time"
+)
+
+// newLogFlusher returns a new log flusher
+func newLogFlusher(raft raft.Raft, store store.Store) *logFlusher {
+	return &logFlusher{
+		raft:  raft,
+		store: store,
+	}
+}
+
+// logFlusher flushes entries appended by the follower to durable storage. If a write batching window is
+// configured, entries appended by multiple appends within the window are flushed together.
+type logFlusher struct {
+	raft  raft.Raft
+	store store.Store
+	// waiters is the set of channels awaiting the next scheduled flush
+	waiters []chan error
+	mu      sync.Mutex
+}
+
+// flush flushes the entries appended to the log, returning once the entries are durable.
+// The caller must hold a write lock on the Raft state, which is released while awaiting a batched flush.
+func (f *logFlusher) flush() error {
+	window := f.raft.Config().GetAppendBatchWindow()
+	if window == nil || *window <= 0 {
+		return f.store.Writer().Flush()
+	}
+
+	ch := make(chan error, 1)
+	f.mu.Lock()
+	f.waiters = append(f.waiters, ch)
+	if len(f.waiters) == 1 {
+		time.AfterFunc(*window, f.flushBatch)
+	}
+	f.mu.Unlock()
+
+	// Release the write lock to allow further appends to be batched with this append.
+	f.raft.WriteUnlock()
+	err := <-ch
+	f.raft.WriteLock()
+	return err
+}
+
+// flushBatch flushes the log and completes all the waiters registered before the flush
+func (f *logFlusher) flushBatch() {
+	f.raft.WriteLock()
+	f.mu.Lock()
+	waiters := f.waiters
+	f.waiters = nil
+	f.mu.Unlock()
+	err := f.store.Writer().Flush()
+	f.raft.WriteUnlock()
+	for _, ch := range waiters {
+		ch <- err
+	}
+}
@@ -19,17 +19,18 @@ import (
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/state"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
-	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
-	"math"
-	"math/rand"
 	"time"
 )
 
 // newFollowerRole returns a new follower role
 func newFollowerRole(protocol raft.Raft, state state.Manager, store store.Store) raft.Role {
-	log := util.NewRoleLogger(string(protocol.Member()), string(raft.RoleFollower))
+	log := protocol.NewLogger(raft.RoleFollower)
+	ctx, cancel := context.WithCancel(context.Background())
 	return &FollowerRole{
 		ActiveRole: newActiveRole(protocol, state, store, log),
+		probeStop:  make(chan struct{}),
+		ctx:        ctx,
+		cancel:     cancel,
 	}
 }
 
@@ -38,6 +39,9 @@ type FollowerRole struct {
 	*ActiveRole
 	heartbeatTimer *time.Timer
 	heartbeatStop  chan bool
+	probeStop      chan struct{}
+	ctx            context.Context
+	cancel         context.CancelFunc
 }
 
 // Type is the role type
@@ -47,14 +51,36 @@ func (r *FollowerRole) Type() raft.RoleType {
 
 // Start starts the follower
 func (r *FollowerRole) Start() error {
-	// If there are no other members in the cluster, immediately transition to candidate to increment the term.
-	if len(r.raft.Members()) == 1 {
-		r.log.Debug("Single node cluster; starting election")
+	// If there are no other voting members in the cluster, immediately transition to candidate to increment the term.
+	if len(r.raft.VotingMembers()) == 1 && r.raft.IsVoter(r.raft.Member()) && !r.raft.IsWitness(r.raft.Member()) {
+		// If the single node fast path is enabled, skip the election entirely and transition directly to
+		// leader in a new term. The leader remains the leader until membership grows beyond one voter.
+		if r.raft.Config().GetSingleNode() {
+			return r.startSingleNode()
+		}
+		r.log.Debug("Single voter cluster; starting election")
 		r.raft.SetRole(raft.RoleCandidate)
 		return nil
 	}
 	_ = r.ActiveRole.Start()
 	go r.resetHeartbeatTimeout()
+	if interval := r.raft.Config().GetConsistencyProbe().GetInterval(); interval != nil && *interval > 0 {
+		go r.runConsistencyProbe(*interval)
+	}
+	return nil
+}
+
+// startSingleNode increments the term and votes for the local member in a single metadata store write, then
+// transitions directly to leader without starting an election. The leader commits entries exactly as it would
+// in any other cluster with a single voter, so a later configuration change adding voters requires no transition.
+func (r *FollowerRole) startSingleNode() error {
+	if err := r.raft.SetTermAndVote(r.raft.Term()+1, r.raft.Member()); err != nil {
+		r.log.Error("Failed to increment term and vote for self", err)
+		r.raft.SetRole(raft.RoleCandidate)
+		return nil
+	}
+	r.log.Debug("Single node cluster; transitioning directly to leader in term %d", r.raft.Term())
+	r.raft.SetRole(raft.RoleLeader)
 	return nil
 }
 
@@ -63,9 +89,79 @@ func (r *FollowerRole) Stop() error {
 	if r.heartbeatTimer != nil && r.heartbeatTimer.Stop() {
 		r.heartbeatStop <- true
 	}
+	r.heartbeatTimer = nil
+	close(r.probeStop)
+
+	// Cancel in-flight poll requests to ensure pre-vote goroutines terminate promptly.
+	r.cancel()
 	return r.ActiveRole.Stop()
 }
 
+// runConsistencyProbe periodically probes the consistency of the local log with the leader's log until the follower is stopped
+func (r *FollowerRole) runConsistencyProbe(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.probeConsistency()
+		case <-r.probeStop:
+			return
+		}
+	}
+}
+
+// probeConsistency compares a hash of the most recently committed range of the local log with a hash of the
+// same range of the leader's log. If the hashes differ, the local log has silently diverged from the leader's
+// log and the follower is marked inconsistent, disabling stale reads from the follower.
+func (r *FollowerRole) probeConsistency() {
+	r.raft.ReadLock()
+	leader := r.raft.Leader()
+	consistent := r.raft.IsConsistent()
+	lastIndex := r.raft.CommitIndex()
+	if writerIndex := r.store.Writer().LastIndex(); writerIndex < lastIndex {
+		lastIndex = writerIndex
+	}
+	// Witnesses store only the metadata of commands, so their logs can't be compared with the leader's log.
+	witness := r.raft.IsWitness(r.raft.Member())
+	r.raft.ReadUnlock()
+	if leader == nil || !consistent || lastIndex == 0 || witness {
+		return
+	}
+
+	firstIndex := raft.Index(1)
+	if rangeSize := raft.Index(r.raft.Config().GetConsistencyProbe().GetRangeSizeOrDefault()); lastIndex > rangeSize {
+		firstIndex = lastIndex - rangeSize + 1
+	}
+	hash, err := r.hashLog(firstIndex, lastIndex)
+	if err != nil {
+		r.log.Debug("Skipping consistency probe: %v", err)
+		return
+	}
+
+	request := &raft.HashRequest{
+		FirstIndex: firstIndex,
+		LastIndex:  lastIndex,
+	}
+	r.log.Send("HashRequest", request)
+	response, err := r.raft.Protocol().Hash(context.Background(), request, *leader)
+	if err != nil {
+		r.log.Warn("Hash request failed", err)
+		return
+	}
+	r.log.Receive("HashResponse", response)
+	if response.Status != raft.ResponseStatus_OK {
+		return
+	}
+
+	if response.Hash != hash {
+		r.log.Error("Log diverged from leader %s in range %d-%d; disabling stale reads", *leader, firstIndex, lastIndex)
+		r.raft.WriteLock()
+		r.raft.SetConsistent(false)
+		r.raft.WriteUnlock()
+	}
+}
+
 // resetHeartbeatTimeout resets the follower's heartbeat timeout
 func (r *FollowerRole) resetHeartbeatTimeout() {
 	r.raft.WriteLock()
@@ -76,23 +172,40 @@ func (r *FollowerRole) resetHeartbeatTimeout() {
 		r.heartbeatStop <- true
 	}
 
-	// Set the election timeout in a semi-random fashion with the random range
-	// being election timeout and 2 * election timeout.
-	timeout := r.raft.Config().GetElectionTimeoutOrDefault() + time.Duration(rand.Int63n(int64(r.raft.Config().GetElectionTimeoutOrDefault())))
-	r.heartbeatTimer = time.NewTimer(timeout)
+	// Set the election timeout in a semi-random fashion with the random range being the election timeout
+	// and the election timeout lengthened by the election jitter, capped at the max election timeout.
+	timeout := r.randomElectionTimeout()
+	heartbeatTimer := time.NewTimer(timeout)
+	r.heartbeatTimer = heartbeatTimer
 	heartbeatStop := make(chan bool, 1)
 	r.heartbeatStop = heartbeatStop
-	heartbeatCh := r.heartbeatTimer.C
+	heartbeatCh := heartbeatTimer.C
 	go func() {
 		select {
 		case <-heartbeatCh:
 			r.raft.WriteLock()
+			// Disarm the timer unless it has already been replaced by a newer timer.
+			if r.heartbeatTimer == heartbeatTimer {
+				r.heartbeatTimer = nil
+			}
 			if r.active {
+				r.raft.Metrics().IncElectionTimeouts(raft.RoleFollower)
 				if err := r.raft.SetLeader(nil); err != nil {
 					r.log.Error("Failed to update leader", err)
 				}
 				r.log.Debug("Heartbeat timed out in %d milliseconds", timeout/time.Millisecond)
-				go r.sendPollRequests()
+				if r.raft.ElectionsFrozen() {
+					r.log.Debug("Elections are frozen; skipping election")
+					go r.resetHeartbeatTimeout()
+				} else if !r.raft.IsVoter(r.raft.Member()) {
+					r.log.Debug("Member is a learner; skipping election")
+					go r.resetHeartbeatTimeout()
+				} else if r.raft.IsWitness(r.raft.Member()) {
+					r.log.Debug("Member is a witness; skipping election")
+					go r.resetHeartbeatTimeout()
+				} else {
+					go r.sendPollRequests()
+				}
 			}
 			r.raft.WriteUnlock()
 		case <-heartbeatStop:
@@ -101,17 +214,25 @@ func (r *FollowerRole) resetHeartbeatTimeout() {
 	}()
 }
 
+// pollVote is a response to a PollRequest from a member
+type pollVote struct {
+	member   raft.MemberID
+	accepted bool
+	// deferred indicates the poll was rejected by a member with a higher priority
+	deferred bool
+}
+
 // sendPollRequests sends PollRequests to all members of the cluster
 func (r *FollowerRole) sendPollRequests() {
 	// Set a new timer within which other nodes must respond in order for this node to transition to candidate.
-	timeoutTimer := time.NewTimer(r.raft.Config().GetElectionTimeoutOrDefault())
+	timeoutTimer := time.NewTimer(r.raft.ConfiguredElectionTimeout())
 	timeoutExpired := make(chan bool, 1)
 	go func() {
 		select {
 		case <-timeoutTimer.C:
 			r.raft.ReadLock()
 			if r.active {
-				r.log.Debug("Failed to poll a majority of the cluster in %d", r.raft.Config().GetElectionTimeoutOrDefault())
+				r.log.Debug("Failed to poll a majority of the cluster in %d", r.raft.ConfiguredElectionTimeout())
 				go r.resetHeartbeatTimeout()
 			}
 			r.raft.ReadUnlock()
@@ -120,39 +241,65 @@ func (r *FollowerRole) sendPollRequests() {
 		}
 	}()
 
-	// Create a quorum that will track the number of nodes that have responded to the poll request.
-	votingMembers := r.raft.Members()
-	votes := make(chan bool, len(votingMembers))
-	quorum := int(math.Floor(float64(len(votingMembers))/2.0) + 1)
+	// Record the term in which the poll was started. If the term changes before a quorum is reached,
+	// the transition to candidate is discarded.
+	r.raft.ReadLock()
+	pollTerm := r.raft.Term()
+	votingMembers := r.raft.VotingMembers()
+	voterSets := r.raft.VoterSets()
+	r.raft.ReadUnlock()
+
+	// Track the members that have responded to the poll request. During a joint configuration change,
+	// a quorum requires a majority of both the old and the new configurations.
+	votes := make(chan pollVote, len(votingMembers))
+
+	// Count the members with a higher priority than the local member. The election is not started
+	// until all higher priority members have responded to the poll.
+	priorityCount := 0
+	for _, member := range votingMembers {
+		if r.hasPriority(member) {
+			priorityCount++
+		}
+	}
+
 	go func() {
-		acceptCount := 0
-		rejectCount := 0
+		accepted := make(map[raft.MemberID]bool)
+		rejected := make(map[raft.MemberID]bool)
 		for vote := range votes {
 			r.raft.WriteLock()
 			if !r.active {
 				r.raft.WriteUnlock()
 				return
 			}
-			if vote {
-				// If no leader has been discovered and the quorum was reached, transition to candidate.
-				acceptCount++
-				if r.raft.Leader() == nil && acceptCount == quorum {
-					r.log.Debug("Received %d/%d pre-votes; transitioning to candidate", acceptCount, len(votingMembers))
-					r.raft.SetRole(raft.RoleCandidate)
-					r.raft.WriteUnlock()
-					return
-				}
+			if r.hasPriority(vote.member) {
+				priorityCount--
+			}
+			if vote.accepted {
+				accepted[vote.member] = true
+			} else if vote.deferred {
+				// If a member with a higher priority rejected the poll, defer the election to that member.
+				r.log.Debug("Received rejected pre-vote from higher priority member %s; resetting heartbeat timeout", vote.member)
 				r.raft.WriteUnlock()
+				go r.resetHeartbeatTimeout()
+				return
 			} else {
-				rejectCount++
-				if rejectCount == quorum {
-					r.log.Debug("Received %d/%d rejected pre-votes; resetting heartbeat timeout", rejectCount, len(votingMembers))
+				rejected[vote.member] = true
+				if isRejected(voterSets, rejected) {
+					r.log.Debug("Received %d/%d rejected pre-votes; resetting heartbeat timeout", len(rejected), len(votingMembers))
 					r.raft.WriteUnlock()
 					go r.resetHeartbeatTimeout()
 					return
 				}
+			}
+
+			// If no leader has been discovered and the quorum was reached, transition to candidate.
+			if r.raft.Leader() == nil && isQuorum(voterSets, accepted) && priorityCount == 0 {
+				r.log.Debug("Received %d/%d pre-votes; transitioning to candidate", len(accepted), len(votingMembers))
+				r.raft.TransitionRole(raft.RoleCandidate, pollTerm)
 				r.raft.WriteUnlock()
+				return
 			}
+			r.raft.WriteUnlock()
 		}
 
 		// If not enough votes were received, reset the heartbeat timeout.
@@ -181,7 +328,7 @@ func (r *FollowerRole) sendPollRequests() {
 	for _, member := range votingMembers {
 		// Vote for yourself!
 		if member == r.raft.Member() {
-			votes <- true
+			votes <- pollVote{member: member, accepted: true}
 			continue
 		}
 
@@ -198,12 +345,14 @@ func (r *FollowerRole) sendPollRequests() {
 			}
 
 			r.log.Send("PollRequest", request)
-			response, err := r.raft.Protocol().Poll(context.Background(), request, member)
+			startTime := time.Now()
+			response, err := r.raft.Protocol().Poll(r.ctx, request, member)
 			if err != nil {
-				votes <- false
+				votes <- pollVote{member: member}
 				r.log.Warn("Poll request failed", err)
 			} else {
 				r.log.Receive("PollResponse", response)
+				r.raft.ObserveRoundTrip(member, time.Since(startTime))
 
 				// If the response term is greater than the term we send, use a double checked lock
 				// to increment the term.
@@ -217,13 +366,13 @@ func (r *FollowerRole) sendPollRequests() {
 
 				if !response.Accepted {
 					r.log.Debug("Received rejected poll from %s", member)
-					votes <- false
+					votes <- pollVote{member: member, deferred: r.hasPriority(member)}
 				} else if response.Term != request.Term {
 					r.log.Debug("Received accepted poll for a different term from %s", member)
-					votes <- false
+					votes <- pollVote{member: member}
 				} else {
 					r.log.Debug("Received accepted poll from %s", member)
-					votes <- true
+					votes <- pollVote{member: member, accepted: true}
 				}
 			}
 		}(member)
@@ -251,6 +400,118 @@ func (r *FollowerRole) Append(ctx context.Context, request *raft.AppendRequest)
 	return response, err
 }
 
+// ConfirmLeadership handles a leadership confirmation request
+func (r *FollowerRole) ConfirmLeadership(ctx context.Context, request *raft.ConfirmLeadershipRequest) (*raft.ConfirmLeadershipResponse, error) {
+	response, err := r.ActiveRole.ConfirmLeadership(ctx, request)
+	if response != nil && response.Confirmed {
+		r.resetHeartbeatTimeout()
+	}
+	return response, err
+}
+
+// Command handles a command request.
+// If command forwarding is enabled, the command is proxied to the leader and its responses are streamed
+// back to the client. Otherwise, the client is redirected to the leader.
+func (r *FollowerRole) Command(request *raft.CommandRequest, ch chan<- *raft.CommandStreamResponse) error {
+	if !r.raft.Config().ForwardCommands {
+		return r.PassiveRole.Command(request, ch)
+	}
+
+	r.log.Request("CommandRequest", request)
+	r.raft.ReadLock()
+	leader := r.raft.Leader()
+	lastLeader := r.raft.LastLeader()
+	term := r.raft.Term()
+	r.raft.ReadUnlock()
+
+	// If no leader is known, fail the command with the last known leader as a hint for the client to retry
+	if leader == nil {
+		defer close(ch)
+		response := &raft.CommandResponse{
+			Status:             raft.ResponseStatus_ERROR,
+			Error:              raft.ResponseError_ILLEGAL_MEMBER_STATE,
+			Message:            "no leader is known",
+			Term:               term,
+			ElectionInProgress: true,
+		}
+		if lastLeader != nil {
+			response.Leader = *lastLeader
+		}
+		_ = r.log.Response("CommandResponse", response, nil)
+		ch <- raft.NewCommandStreamResponse(response, nil)
+		return nil
+	}
+	return r.forwardCommand(request, *leader, term, ch)
+}
+
+// forwardCommand forwards a command request to the leader
+func (r *FollowerRole) forwardCommand(request *raft.CommandRequest, leader raft.MemberID, term raft.Term, ch chan<- *raft.CommandStreamResponse) error {
+	defer close(ch)
+
+	r.log.Trace("Forwarding %v", request)
+	stream, err := r.raft.Protocol().Command(context.Background(), request, leader)
+	if err != nil {
+		// If the leader cannot be reached, fall back to redirecting the client to the leader
+		r.log.Debug("Failed to forward command to %s: %s", leader, err)
+		response := &raft.CommandResponse{
+			Status:  raft.ResponseStatus_ERROR,
+			Error:   raft.ResponseError_ILLEGAL_MEMBER_STATE,
+			Message: err.Error(),
+			Leader:  leader,
+			Term:    term,
+		}
+		_ = r.log.Response("CommandResponse", response, nil)
+		ch <- raft.NewCommandStreamResponse(response, nil)
+		return nil
+	}
+
+	for response := range stream {
+		_ = r.log.Response("CommandResponse", response.Response, response.Error)
+		ch <- response
+	}
+	return nil
+}
+
+// Transfer handles a transfer request.
+// A transfer request sent by the leader to this member causes the member to start an election immediately.
+func (r *FollowerRole) Transfer(ctx context.Context, request *raft.TransferRequest) (*raft.TransferResponse, error) {
+	r.log.Request("TransferRequest", request)
+	r.raft.WriteLock()
+	defer r.raft.WriteUnlock()
+	if request.Member != r.raft.Member() || !r.active || !r.raft.IsVoter(r.raft.Member()) || r.raft.IsWitness(r.raft.Member()) {
+		response := &raft.TransferResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+		}
+		_ = r.log.Response("TransferResponse", response, nil)
+		return response, nil
+	}
+
+	// If the follower's log has fallen behind the leader's log since the leader verified it was caught up,
+	// reject the transfer. The follower may not be able to win the election.
+	if lastIndex := r.store.Writer().LastIndex(); lastIndex < request.LastLogIndex {
+		r.log.Debug("Rejected %v: last index (%d) is less than the leader's last index (%d)", request, lastIndex, request.LastLogIndex)
+		response := &raft.TransferResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+		}
+		_ = r.log.Response("TransferResponse", response, nil)
+		return response, nil
+	}
+
+	// Start an election immediately without waiting for the randomized election timeout.
+	r.log.Debug("Received leadership transfer; transitioning to candidate")
+	if err := r.raft.SetLeader(nil); err != nil {
+		r.log.Error("Failed to update leader", err)
+	}
+	defer r.raft.SetRole(raft.RoleCandidate)
+	response := &raft.TransferResponse{
+		Status: raft.ResponseStatus_OK,
+	}
+	_ = r.log.Response("TransferResponse", response, nil)
+	return response, nil
+}
+
 // Vote handles a vote request
 func (r *FollowerRole) Vote(ctx context.Context, request *raft.VoteRequest) (*raft.VoteResponse, error) {
 	r.log.Request("VoteRequest", request)
@@ -258,11 +519,21 @@ func (r *FollowerRole) Vote(ctx context.Context, request *raft.VoteRequest) (*ra
 	// Vote requests can modify the server's vote record, so we need to hold a write lock while handling the request.
 	r.raft.WriteLock()
 
+	// If the role is stopping, decline to vote.
+	if response := r.checkVoteActive(); response != nil {
+		r.raft.WriteUnlock()
+		return response, nil
+	}
+
+	// If the candidate has exceeded the election rate limit, reject the vote before it can update the term.
+	if response := r.checkVoteRateLimit(request); response != nil {
+		r.raft.WriteUnlock()
+		return response, nil
+	}
+
 	// If the request indicates a term that is greater than the current term then
 	// assign that term and leader to the current context.
-	if r.updateTermAndLeader(request.Term, nil) {
-		defer r.raft.SetRole(raft.RoleFollower)
-	}
+	r.updateTermAndLeader(request.Term, nil)
 
 	// Handle the vote request and then release the lock
 	response, err := r.ActiveRole.handleVote(ctx, request)
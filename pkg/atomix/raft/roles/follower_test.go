@@ -15,6 +15,8 @@
 package roles
 
 import (
+	"context"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/protocol/mock"
 	"github.com/golang/mock/gomock"
@@ -44,7 +46,10 @@ func TestFollowerPollQuorum(t *testing.T) {
 func TestFollowerPollFail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
-	rejectPoll(client).AnyTimes()
+	polls := make(chan raft.MemberID, 100)
+	rejectPoll(client).Do(func(ctx context.Context, request *raft.PollRequest, member raft.MemberID) {
+		polls <- member
+	}).AnyTimes()
 	acceptVote(client).AnyTimes()
 	failAppend(client).AnyTimes()
 
@@ -56,8 +61,16 @@ func TestFollowerPollFail(t *testing.T) {
 	assert.Nil(t, role.raft.Leader())
 	role.raft.ReadUnlock()
 
-	time.Sleep(5 * time.Second)
-	assert.Equal(t, raft.RoleType(""), role.raft.Role())
+	// Wait for two rounds of polls to be rejected and verify the election timer is rearmed each time
+	for i := 0; i < 4; i++ {
+		<-polls
+	}
+	assert.True(t, awaitElectionTimerArmed(role))
+
+	role.raft.WriteLock()
+	assert.NoError(t, role.Stop())
+	role.raft.WriteUnlock()
+	assert.False(t, isElectionTimerArmed(role))
 }
 
 func TestFollowerPollRestart(t *testing.T) {
@@ -76,3 +89,467 @@ func TestFollowerPollRestart(t *testing.T) {
 
 	assert.Equal(t, raft.RoleCandidate, awaitRole(role.raft, raft.RoleCandidate))
 }
+
+func TestFollowerElectionFreeze(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	polls := make(chan raft.MemberID, 100)
+	acceptPoll(client).Do(func(ctx context.Context, request *raft.PollRequest, member raft.MemberID) {
+		polls <- member
+	}).AnyTimes()
+
+	// Freeze elections for longer than the election timeout
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	freeze := 3 * protocol.Config().GetElectionTimeoutOrDefault()
+	expires := time.Now().Add(freeze)
+	protocol.WriteLock()
+	protocol.SetElectionFreeze(0, freeze)
+	protocol.WriteUnlock()
+
+	// Verify the follower doesn't poll the cluster while elections are frozen
+	role := newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	assert.NoError(t, role.Start())
+	select {
+	case <-polls:
+		assert.Fail(t, "follower polled the cluster while elections were frozen")
+	case <-time.After(time.Until(expires) - 100*time.Millisecond):
+	}
+	assert.True(t, awaitElectionTimerArmed(role))
+
+	// Verify the follower starts an election once the freeze expires
+	assert.Equal(t, raft.RoleCandidate, awaitRole(role.raft, raft.RoleCandidate))
+	assert.False(t, time.Now().Before(expires))
+}
+
+func TestFollowerPriorityColdStart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	electionTimeout := 200 * time.Millisecond
+	heartbeatInterval := 50 * time.Millisecond
+	config := &config.ProtocolConfig{
+		ElectionTimeout:   &electionTimeout,
+		HeartbeatInterval: &heartbeatInterval,
+		Priorities: map[string]int32{
+			"foo":  1,
+			"bar":  2,
+			"baz":  3,
+			"qux":  4,
+			"quux": 5,
+		},
+	}
+	rafts := newTestCluster(ctrl, config, "foo", "bar", "baz", "qux", "quux")
+
+	// Count the number of times any member transitions to candidate
+	elections := make(chan raft.MemberID, 100)
+	for member, r := range rafts {
+		r.Watch(func(member raft.MemberID) func(raft.Event) {
+			return func(event raft.Event) {
+				if event.Type == raft.EventTypeRole && event.Role == raft.RoleCandidate {
+					elections <- member
+				}
+			}
+		}(member))
+	}
+
+	// Start all members simultaneously and wait for the highest priority member to be elected
+	for _, r := range rafts {
+		go r.Init()
+	}
+	leader := raft.MemberID("quux")
+	assert.Equal(t, raft.RoleLeader, awaitRole(rafts[leader], raft.RoleLeader))
+	assert.Equal(t, &leader, awaitLeader(rafts["foo"], &leader))
+
+	// Only the highest priority member should have proceeded to an election
+	assert.Equal(t, leader, <-elections)
+	assert.Len(t, elections, 0)
+	rafts[leader].ReadLock()
+	assert.Equal(t, raft.Term(1), rafts[leader].Term())
+	rafts[leader].ReadUnlock()
+}
+
+func TestFollowerRejectDisruptivePoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	electionTimeout := 200 * time.Millisecond
+	heartbeatInterval := 50 * time.Millisecond
+	config := &config.ProtocolConfig{
+		ElectionTimeout:   &electionTimeout,
+		HeartbeatInterval: &heartbeatInterval,
+		Priorities: map[string]int32{
+			"foo": 1,
+			"bar": 2,
+			"baz": 3,
+		},
+	}
+	rafts := newTestCluster(ctrl, config, "foo", "bar", "baz")
+	for _, r := range rafts {
+		go r.Init()
+	}
+	leader := raft.MemberID("baz")
+	assert.Equal(t, raft.RoleLeader, awaitRole(rafts[leader], raft.RoleLeader))
+	assert.Equal(t, &leader, awaitLeader(rafts["bar"], &leader))
+
+	rafts["bar"].ReadLock()
+	term := rafts["bar"].Term()
+	rafts["bar"].ReadUnlock()
+
+	// A disruptive member polling with a higher term and an up-to-date log should be rejected by a follower of a healthy leader
+	response, err := rafts["bar"].Poll(context.TODO(), &raft.PollRequest{
+		Term:         term + 10,
+		Candidate:    "foo",
+		LastLogIndex: 100,
+		LastLogTerm:  term + 10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.False(t, response.Accepted)
+	assert.Equal(t, term, response.Term)
+
+	// The poll should not disrupt the follower's term or leader
+	rafts["bar"].ReadLock()
+	assert.Equal(t, term, rafts["bar"].Term())
+	assert.Equal(t, &leader, rafts["bar"].Leader())
+	rafts["bar"].ReadUnlock()
+}
+
+func TestFollowerTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	role := newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	assert.NoError(t, role.Start())
+
+	// Verify a transfer to another member is rejected
+	response, err := role.Transfer(context.TODO(), &raft.TransferRequest{Member: "bar"})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+
+	// Verify a transfer is rejected if the local log is behind the leader's log
+	response, err = role.Transfer(context.TODO(), &raft.TransferRequest{Member: "foo", LastLogIndex: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.RoleFollower, role.Type())
+
+	// Verify a transfer to the local member immediately starts an election
+	response, err = role.Transfer(context.TODO(), &raft.TransferRequest{Member: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Equal(t, raft.RoleCandidate, role.raft.Role())
+
+	role.raft.WriteLock()
+	assert.NoError(t, role.Stop())
+	role.raft.WriteUnlock()
+	ctrl.Finish()
+}
+
+func TestFollowerConfirmLeadership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	role := newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
+	assert.NoError(t, role.Start())
+
+	// Verify leadership is confirmed for a leader in the current term
+	response, err := role.ConfirmLeadership(context.TODO(), &raft.ConfirmLeadershipRequest{Term: raft.Term(2), Leader: "bar"})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.True(t, response.Confirmed)
+	assert.Equal(t, raft.MemberID("bar"), *role.raft.Leader())
+
+	// Verify leadership is not confirmed for a leader in a prior term
+	response, err = role.ConfirmLeadership(context.TODO(), &raft.ConfirmLeadershipRequest{Term: raft.Term(1), Leader: "baz"})
+	assert.NoError(t, err)
+	assert.False(t, response.Confirmed)
+	assert.Equal(t, raft.Term(2), response.Term)
+
+	// Verify leadership is not confirmed for a member that is not the known leader
+	response, err = role.ConfirmLeadership(context.TODO(), &raft.ConfirmLeadershipRequest{Term: raft.Term(2), Leader: "baz"})
+	assert.NoError(t, err)
+	assert.False(t, response.Confirmed)
+
+	// Verify a leader in a greater term is adopted and confirmed
+	response, err = role.ConfirmLeadership(context.TODO(), &raft.ConfirmLeadershipRequest{Term: raft.Term(3), Leader: "baz"})
+	assert.NoError(t, err)
+	assert.True(t, response.Confirmed)
+	assert.Equal(t, raft.Term(3), role.raft.Term())
+	assert.Equal(t, raft.MemberID("baz"), *role.raft.Leader())
+}
+
+func TestFollowerLearner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	setLearners(protocol, "foo")
+	electionTimeout := 50 * time.Millisecond
+	protocol.Config().ElectionTimeout = &electionTimeout
+	role := newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	assert.NoError(t, role.Start())
+
+	// Verify the learner does not poll for an election when the heartbeat times out. A poll request fails the test.
+	time.Sleep(5 * electionTimeout)
+	assert.Equal(t, raft.Term(0), role.raft.Term())
+
+	// Verify leadership cannot be transferred to the learner
+	response, err := role.Transfer(context.TODO(), &raft.TransferRequest{Member: "foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_ILLEGAL_MEMBER_STATE, response.Error)
+}
+
+func TestFollowerConsistencyProbe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	role := newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	entries := make([]*raft.LogEntry, 3)
+	for i := range entries {
+		entries[i] = &raft.LogEntry{
+			Term:      1,
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+	}
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:        1,
+		Leader:      "bar",
+		Entries:     entries,
+		CommitIndex: 3,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.StatusReady, role.raft.Status())
+
+	hash, err := role.hashLog(1, 3)
+	assert.NoError(t, err)
+	leaderHash := hash
+	client.EXPECT().
+		Hash(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("bar"))).
+		DoAndReturn(func(ctx context.Context, request *raft.HashRequest, member raft.MemberID) (*raft.HashResponse, error) {
+			assert.Equal(t, raft.Index(1), request.FirstIndex)
+			assert.Equal(t, raft.Index(3), request.LastIndex)
+			return &raft.HashResponse{
+				Status: raft.ResponseStatus_OK,
+				Term:   1,
+				Hash:   leaderHash,
+			}, nil
+		}).AnyTimes()
+
+	// Verify the follower remains consistent when its log matches the leader's log
+	role.probeConsistency()
+	assert.True(t, role.raft.IsConsistent())
+
+	// Inject a divergence between the follower's log and the leader's log
+	leaderHash = hash + 1
+	role.probeConsistency()
+	assert.False(t, role.raft.IsConsistent())
+
+	// Verify stale reads are forwarded to the leader once the follower is inconsistent
+	expectQuery(client).
+		Do(func(ctx context.Context, request *raft.QueryRequest, member raft.MemberID) {
+			assert.Equal(t, raft.MemberID("bar"), member)
+		})
+	ch := make(chan *raft.QueryStreamResponse, 1)
+	assert.NoError(t, role.Query(&raft.QueryRequest{ReadConsistency: raft.ReadConsistency_SEQUENTIAL}, ch))
+	queryResponse := <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, queryResponse.Response.Status)
+
+	role.raft.WriteLock()
+	assert.NoError(t, role.Stop())
+	role.raft.WriteUnlock()
+	ctrl.Finish()
+}
+
+func TestFollowerVoteStopping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	role := newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	role.raft.WriteLock()
+	assert.NoError(t, role.Stop())
+	role.raft.WriteUnlock()
+
+	// Verify a stopping follower declines to vote without adopting the candidate's term or recording a vote
+	response, err := role.Vote(context.TODO(), &raft.VoteRequest{
+		Term:         raft.Term(2),
+		Candidate:    raft.MemberID("bar"),
+		LastLogIndex: raft.Index(0),
+		LastLogTerm:  raft.Term(0),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_UNAVAILABLE, response.Error)
+	assert.False(t, response.Voted)
+	assert.Equal(t, raft.Term(1), response.Term)
+
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Term(1), role.raft.Term())
+	assert.Nil(t, role.raft.LastVotedFor())
+	role.raft.ReadUnlock()
+}
+
+func TestFollowerElectionRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	interval := 500 * time.Millisecond
+	protocol.Config().ElectionRateLimit = &config.RateLimitConfig{
+		MaxRequests: 3,
+		Interval:    &interval,
+	}
+	role := newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	role.active = true
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+
+	poll := func(candidate raft.MemberID, term raft.Term) *raft.PollResponse {
+		response, err := role.Poll(context.TODO(), &raft.PollRequest{
+			Term:      term,
+			Candidate: candidate,
+		})
+		assert.NoError(t, err)
+		return response
+	}
+
+	// Verify polls from a member are accepted up to the rate limit
+	for i := 0; i < 3; i++ {
+		response := poll("bar", raft.Term(1))
+		assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+		assert.True(t, response.Accepted)
+	}
+
+	// Verify excess polls and votes from a flapping member are rejected with a backoff hint without updating the term
+	response := poll("bar", raft.Term(5))
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_RATE_LIMITED, response.Error)
+	assert.False(t, response.Accepted)
+	assert.Equal(t, raft.Term(1), response.Term)
+	assert.True(t, response.RetryAfter > 0)
+	assert.True(t, response.RetryAfter <= interval)
+
+	voteResponse, err := role.Vote(context.TODO(), &raft.VoteRequest{
+		Term:      raft.Term(5),
+		Candidate: "bar",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, voteResponse.Status)
+	assert.Equal(t, raft.ResponseError_RATE_LIMITED, voteResponse.Error)
+	assert.False(t, voteResponse.Voted)
+	assert.True(t, voteResponse.RetryAfter > 0)
+
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Term(1), role.raft.Term())
+	assert.Nil(t, role.raft.LastVotedFor())
+	role.raft.ReadUnlock()
+
+	// Verify requests from a stable member are unaffected
+	response = poll("baz", raft.Term(1))
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.True(t, response.Accepted)
+
+	voteResponse, err = role.Vote(context.TODO(), &raft.VoteRequest{
+		Term:      raft.Term(2),
+		Candidate: "baz",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, voteResponse.Status)
+	assert.True(t, voteResponse.Voted)
+
+	// Verify the flapping member's requests are accepted again once it has backed off
+	time.Sleep(interval)
+	response = poll("bar", raft.Term(2))
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+}
+
+func TestFollowerForwardCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	forwarded := make(chan raft.MemberID, 1)
+	client.EXPECT().Command(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.CommandRequest, member raft.MemberID) (<-chan *raft.CommandStreamResponse, error) {
+			forwarded <- member
+			ch := make(chan *raft.CommandStreamResponse, 1)
+			ch <- raft.NewCommandStreamResponse(&raft.CommandResponse{
+				Status: raft.ResponseStatus_OK,
+				Output: request.Value,
+			}, nil)
+			close(ch)
+			return ch, nil
+		}).AnyTimes()
+
+	protocol, sm, stores := newTestState(client)
+	role := newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.raft.SetLeader(&role.raft.Members()[1]))
+
+	command := func() *raft.CommandResponse {
+		ch := make(chan *raft.CommandStreamResponse, 1)
+		assert.NoError(t, role.Command(&raft.CommandRequest{Value: []byte("foo")}, ch))
+		response := <-ch
+		assert.True(t, response.Succeeded())
+		_, ok := <-ch
+		assert.False(t, ok)
+		return response.Response
+	}
+
+	// Verify the client is redirected to the leader when forwarding is disabled
+	response := command()
+	assert.Equal(t, raft.ResponseError_ILLEGAL_MEMBER_STATE, response.Error)
+	assert.Equal(t, role.raft.Members()[1], response.Leader)
+	assert.Len(t, forwarded, 0)
+
+	// Verify the command is forwarded to the leader when forwarding is enabled
+	role.raft.Config().ForwardCommands = true
+	response = command()
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Equal(t, []byte("foo"), response.Output)
+	assert.Equal(t, role.raft.Members()[1], <-forwarded)
+
+	// Verify a retriable error is returned with the last leader as a hint when no leader is known
+	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
+	response = command()
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_ILLEGAL_MEMBER_STATE, response.Error)
+	assert.Equal(t, role.raft.Members()[1], response.Leader)
+	assert.True(t, response.ElectionInProgress)
+	assert.Len(t, forwarded, 0)
+}
+
+func TestFollowerSingleNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Verify a single voter transitions to candidate to run an election by default
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	setLearners(protocol, "bar", "baz")
+	role := newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.RoleCandidate, role.raft.Role())
+	assert.Equal(t, raft.Term(0), role.raft.Term())
+
+	// Verify a single voter transitions directly to leader in a new term when the fast path is enabled
+	protocol, sm, stores = newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	protocol.Config().SingleNode = true
+	setLearners(protocol, "bar", "baz")
+	role = newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.RoleLeader, role.raft.Role())
+	assert.Equal(t, raft.Term(1), role.raft.Term())
+	assert.Equal(t, raft.MemberID("foo"), *role.raft.LastVotedFor())
+
+	// Verify the fast path is not taken once the cluster has more than one voter
+	protocol, sm, stores = newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	protocol.Config().SingleNode = true
+	setLearners(protocol, "baz")
+	role = newFollowerRole(protocol, sm, stores).(*FollowerRole)
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Term(0), role.raft.Term())
+	assert.NoError(t, role.Stop())
+}
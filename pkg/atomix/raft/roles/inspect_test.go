@@ -0,0 +1,97 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"time"
+)
+
+// Inspection methods expose role internals to tests. They're defined in a test file so they're not
+// part of the production surface, and must be called without holding the Raft lock.
+
+// electionTimerInspector is implemented by roles that arm an election timer
+type electionTimerInspector interface {
+	electionTimerArmed() bool
+}
+
+// isElectionTimerArmed returns whether the given role's election timer is armed
+func isElectionTimerArmed(role raft.Role) bool {
+	if inspector, ok := role.(electionTimerInspector); ok {
+		return inspector.electionTimerArmed()
+	}
+	return false
+}
+
+// awaitElectionTimerArmed blocks until the given role's election timer is armed
+func awaitElectionTimerArmed(role raft.Role) bool {
+	for !isElectionTimerArmed(role) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	return true
+}
+
+// electionTimerArmed returns whether the follower's heartbeat timer is armed
+func (r *FollowerRole) electionTimerArmed() bool {
+	r.raft.ReadLock()
+	defer r.raft.ReadUnlock()
+	return r.heartbeatTimer != nil
+}
+
+// electionTimerArmed returns whether the candidate's election timer is armed
+func (r *CandidateRole) electionTimerArmed() bool {
+	r.raft.ReadLock()
+	defer r.raft.ReadUnlock()
+	return r.electionTimer != nil
+}
+
+// voteTally returns the number of votes accepted and rejected in the candidate's current election round
+func (r *CandidateRole) voteTally() (int, int) {
+	r.raft.ReadLock()
+	defer r.raft.ReadUnlock()
+	return len(r.accepted), len(r.rejected)
+}
+
+// awaitReadRepair blocks until a read is awaiting read repair
+func (r *PassiveRole) awaitReadRepair() {
+	for {
+		r.repairMu.Lock()
+		waiting := len(r.repairWaiters)
+		r.repairMu.Unlock()
+		if waiting > 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// pendingCommands returns the number of entries appended by the leader that are awaiting commitment
+func (r *LeaderRole) pendingCommands() int {
+	r.appender.mu.Lock()
+	defer r.appender.mu.Unlock()
+	return len(r.appender.commitChannels)
+}
+
+// bufferedEntries returns the number of entries buffered by the leader awaiting send to the given follower,
+// including any entry held while the follower's buffer is full
+func (r *LeaderRole) bufferedEntries(member raft.MemberID) int {
+	appender := r.appender.members[member]
+	appender.mu.Lock()
+	defer appender.mu.Unlock()
+	if appender.held != nil {
+		return appender.queue.Len() + 1
+	}
+	return appender.queue.Len()
+}
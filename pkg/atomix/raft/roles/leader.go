@@ -16,21 +16,27 @@ package roles
 
 import (
 	"context"
+	"fmt"
 	"github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/state"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store/log"
-	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
+	"sync"
 	"time"
 )
 
 // newLeaderRole returns a new leader role
 func newLeaderRole(protocol raft.Raft, state state.Manager, store store.Store) raft.Role {
-	log := util.NewRoleLogger(string(protocol.Member()), string(raft.RoleLeader))
+	log := protocol.NewLogger(raft.RoleLeader)
 	return &LeaderRole{
 		ActiveRole: newActiveRole(protocol, state, store, log),
 		appender:   newAppender(protocol, state, store, log),
+		proposals:  newProposalQueue(),
+		proposalCh: make(chan struct{}, 1),
+		stopped:    make(chan struct{}),
+		dedup:      newDedupCache(),
 	}
 }
 
@@ -39,6 +45,19 @@ type LeaderRole struct {
 	*ActiveRole
 	appender  *raftAppender
 	initIndex raft.Index
+	// transferring is the member to which leadership is being transferred, if any
+	transferring *raft.MemberID
+	// commandStreams is the number of command streams currently open on the leader
+	commandStreams int
+	// proposals is the queue of commands waiting to be appended when fair proposals or proposal batching
+	// are enabled
+	proposals  *proposalQueue
+	proposalCh chan struct{}
+	stopped    chan struct{}
+	// joinMu serializes joins so concurrent joins change the configuration one at a time
+	joinMu sync.Mutex
+	// dedup caches the output of the last command applied for each session when a dedup budget is configured
+	dedup *dedupCache
 }
 
 // Type is the role type
@@ -51,15 +70,188 @@ func (r *LeaderRole) Start() error {
 	r.setLeadership()
 	go r.startAppender()
 	go r.commitInitializeEntry()
+	if r.queueProposals() {
+		go r.processProposals()
+	}
+	if r.raft.Config().GetApply().GetStallTimeout() != nil {
+		go r.monitorApply()
+	}
+	if timeout := r.raft.Config().GetInitializeTimeout(); timeout != nil {
+		go r.monitorInitialize(*timeout)
+	}
 	return r.ActiveRole.Start()
 }
 
+// monitorInitialize steps down if the entry from the leader's term is not committed within the given
+// timeout of the election, e.g. because the leader cannot reach a quorum, allowing a new leader to be elected
+func (r *LeaderRole) monitorInitialize(timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		r.raft.WriteLock()
+		defer r.raft.WriteUnlock()
+		select {
+		case <-r.stopped:
+			return
+		default:
+		}
+		if r.initIndex > 0 && r.raft.CommitIndex() >= r.initIndex {
+			return
+		}
+		r.log.Warn("Failed to commit entry from leader's term within %s; stepping down", timeout)
+		if err := r.raft.SetLeader(nil); err != nil {
+			r.log.Error("Failed to unset leader", err)
+		}
+		r.raft.SetRole(raft.RoleFollower)
+	case <-r.stopped:
+	}
+}
+
+// monitorApply steps down if the local state machine stops applying committed entries, allowing
+// a healthy follower to take over leadership
+func (r *LeaderRole) monitorApply() {
+	ticker := time.NewTicker(r.raft.HeartbeatInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !r.raft.ApplyStalled() {
+				continue
+			}
+			r.raft.WriteLock()
+			select {
+			case <-r.stopped:
+				r.raft.WriteUnlock()
+				return
+			default:
+			}
+			r.log.Warn("Apply loop stalled; stepping down")
+			r.raft.SetRole(raft.RoleFollower)
+			r.raft.WriteUnlock()
+			return
+		case <-r.stopped:
+			return
+		}
+	}
+}
+
+// queueProposals returns whether commands are queued to be appended by the proposal processor
+func (r *LeaderRole) queueProposals() bool {
+	return r.raft.Config().GetFairProposals() || r.raft.Config().GetProposalBatchInterval() != nil
+}
+
+// processProposals appends queued proposals to the log in the order scheduled by the proposal queue.
+// If proposal batching is enabled, urgent proposals are appended immediately along with the proposals
+// preceding them in their sessions, and all other proposals are appended in batches once per batch interval.
+func (r *LeaderRole) processProposals() {
+	interval := r.raft.Config().GetProposalBatchInterval()
+	var flushTimer *time.Timer
+	var flushCh <-chan time.Time
+	for {
+		select {
+		case <-r.proposalCh:
+			if interval == nil {
+				if !r.appendProposals(r.proposals.pop) {
+					return
+				}
+				continue
+			}
+			if !r.appendProposals(r.proposals.popUrgent) {
+				return
+			}
+			// If proposals remain in the queue, schedule the next batch to be flushed.
+			if flushCh == nil && r.proposals.len() > 0 {
+				flushTimer = time.NewTimer(*interval)
+				flushCh = flushTimer.C
+			}
+		case <-flushCh:
+			flushCh = nil
+			if !r.appendProposals(r.proposals.pop) {
+				return
+			}
+		case <-r.stopped:
+			if flushTimer != nil {
+				flushTimer.Stop()
+			}
+			return
+		}
+	}
+}
+
+// appendProposals appends the proposals returned by the given function to the log as a single batch,
+// returning false if the leader has been stopped
+func (r *LeaderRole) appendProposals(next func() *proposal) bool {
+	r.raft.WriteLock()
+	defer r.raft.WriteUnlock()
+	select {
+	case <-r.stopped:
+		return false
+	default:
+	}
+	for p := next(); p != nil; p = next() {
+		p.entry.Term = r.raft.Term()
+		p.entry.Timestamp = time.Now()
+		indexed := r.store.Writer().Append(p.entry)
+		var f func()
+		if p.apply != nil {
+			f = p.apply(indexed)
+		}
+		p.commitCh = r.appender.register(indexed, f)
+		p.ch <- indexed
+	}
+	return true
+}
+
+// propose enqueues the given command to be appended to the log, returning the appended entry and the channel
+// on which its commit is completed, or nil if the leader stepped down before the command was appended. The
+// given function is called with the appended entry to get the function to call once the entry is committed.
+func (r *LeaderRole) propose(request *raft.CommandRequest, apply func(*log.Entry) func()) (*log.Entry, <-chan bool) {
+	p := newProposal(request)
+	p.apply = apply
+
+	// If fair proposals are disabled, proposals are appended in the order in which they're received.
+	if !r.raft.Config().GetFairProposals() {
+		p.session = 0
+	}
+
+	// If the client's deadline is near, append the proposal without waiting for the next batch.
+	threshold := r.raft.Config().GetUrgentProposalTimeout()
+	if threshold != nil && request.Timeout != nil && *request.Timeout <= *threshold {
+		p.urgent = true
+	}
+	r.proposals.push(p)
+	select {
+	case r.proposalCh <- struct{}{}:
+	default:
+	}
+
+	select {
+	case indexed := <-p.ch:
+		return indexed, p.commitCh
+	case <-r.stopped:
+		// Proposals are only appended while holding the write lock, so once the lock is acquired
+		// the proposal has either already been appended or will never be appended.
+		r.raft.ReadLock()
+		defer r.raft.ReadUnlock()
+		select {
+		case indexed := <-p.ch:
+			return indexed, p.commitCh
+		default:
+			return nil, nil
+		}
+	}
+}
+
 // setLeadership sets the leader as the current leader
 func (r *LeaderRole) setLeadership() {
 	member := r.raft.Member()
 	if err := r.raft.SetLeader(&member); err != nil {
 		r.log.Error("Failed to set leadership", err)
 	}
+
+	// A leader never trails its own log, so clear any lag recorded while following another leader.
+	r.raft.SetLag(0)
 }
 
 // startAppender starts the appender goroutines
@@ -67,39 +259,780 @@ func (r *LeaderRole) startAppender() {
 	r.appender.start()
 }
 
-// commitInitializeEntry commits a no-op entry for the leader
-func (r *LeaderRole) commitInitializeEntry() {
-	r.raft.WriteLock()
+// commitInitializeEntry commits a no-op entry for the leader
+func (r *LeaderRole) commitInitializeEntry() {
+	r.raft.WriteLock()
+
+	// Create and append an InitializeEntry.
+	entry := &raft.LogEntry{
+		Term:      r.raft.Term(),
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_Initialize{
+			Initialize: &raft.InitializeEntry{},
+		},
+	}
+	indexed := r.store.Writer().Append(entry)
+	ch := r.appender.register(indexed, nil)
+	r.initIndex = indexed.Index
+	r.raft.WriteUnlock()
+
+	// The Raft protocol dictates that leaders cannot commit entries from previous terms until
+	// at least one entry from their current term has been stored on a majority of servers. Thus,
+	// we force entries to be appended up to the leader's no-op entry. The LeaderAppender will ensure
+	// that the commitIndex is not increased until the no-op entry is committed.
+	err := r.appender.commit(indexed, ch)
+	if err != nil {
+		r.log.Debug("Failed to commit entry from leader's term; transitioning to follower")
+		r.raft.WriteLock()
+		defer r.raft.WriteUnlock()
+		if err := r.raft.SetLeader(nil); err != nil {
+			r.log.Error("Failed to unset leader", err)
+		}
+		r.raft.SetRole(raft.RoleFollower)
+	} else {
+		r.state.ApplyEntry(indexed, nil)
+		r.completeJointConfiguration()
+	}
+}
+
+// Join handles a join request.
+// A member joining as a voter is first added as a learner so it can catch up with the leader's log via
+// snapshots and appends without affecting the availability of the cluster, and it's promoted to a voter
+// once it has caught up. Joins are serialized so that concurrent joins cannot race to change the
+// configuration. The response carries the resulting members and commit index so the joining member can
+// configure itself.
+func (r *LeaderRole) Join(ctx context.Context, request *raft.JoinRequest) (*raft.JoinResponse, error) {
+	r.log.Request("JoinRequest", request)
+	if request.Member == nil {
+		response := &raft.JoinResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_PROTOCOL_ERROR,
+		}
+		_ = r.log.Response("JoinResponse", response, nil)
+		return response, nil
+	}
+
+	r.joinMu.Lock()
+	defer r.joinMu.Unlock()
+
+	configuration, responseErr, ok := r.join(ctx, request.Member)
+	if !ok {
+		response := &raft.JoinResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  responseErr,
+			Leader: r.raft.Member(),
+		}
+		_ = r.log.Response("JoinResponse", response, nil)
+		return response, nil
+	}
+
+	r.raft.ReadLock()
+	commitIndex := r.raft.CommitIndex()
+	r.raft.ReadUnlock()
+	response := &raft.JoinResponse{
+		Status:      raft.ResponseStatus_OK,
+		Index:       configuration.Index,
+		Term:        configuration.Term,
+		Timestamp:   *configuration.Timestamp,
+		Members:     configuration.Members,
+		Leader:      r.raft.Member(),
+		CommitIndex: commitIndex,
+	}
+	_ = r.log.Response("JoinResponse", response, nil)
+	return response, nil
+}
+
+// join adds the given member to the configuration, adding a new voter as a learner until it has caught up
+func (r *LeaderRole) join(ctx context.Context, member *raft.Member) (*raft.Configuration, raft.ResponseError, bool) {
+	r.raft.ReadLock()
+	configuration, pending := r.raft.Configuration()
+	r.raft.ReadUnlock()
+
+	// If the member has already joined with the requested type, the join is complete. This allows a
+	// member to retry a join for which it failed to receive a response.
+	current := findMember(configuration, member.MemberID)
+	if pending == nil && current != nil && current.Type == member.Type && configuration.Timestamp != nil {
+		return configuration, 0, true
+	}
+
+	// Members that are not promoted to a voter by the join are added directly.
+	if !isPromotion(configuration, member) {
+		return r.changeConfiguration(func(configuration *raft.Configuration) ([]*raft.Member, error) {
+			return replaceMember(configuration, member), nil
+		})
+	}
+
+	// A voter must catch up before it's promoted, so the leader must be able to replicate to it.
+	if r.raft.GetMember(member.MemberID) == nil {
+		r.log.Debug("Rejected join: member %s has no known address", member.MemberID)
+		return nil, raft.ResponseError_CONFIGURATION_ERROR, false
+	}
+
+	// Add a new member as a learner. The configuration that will result from the promotion is checked
+	// up front to avoid adding learners that cannot be promoted.
+	if current == nil {
+		_, responseErr, ok := r.changeConfiguration(func(configuration *raft.Configuration) ([]*raft.Member, error) {
+			if err := r.checkEvenCluster(configuration, replaceMember(configuration, member)); err != nil {
+				return nil, err
+			}
+			learner := &raft.Member{
+				MemberID: member.MemberID,
+				Type:     raft.Member_PASSIVE,
+				Updated:  member.Updated,
+			}
+			return replaceMember(configuration, learner), nil
+		})
+		if !ok {
+			return nil, responseErr, false
+		}
+	}
+
+	// Wait for the learner to catch up to within the catch-up threshold of the commit index.
+	r.raft.ReadLock()
+	commitIndex := r.raft.CommitIndex()
+	r.raft.ReadUnlock()
+	threshold := raft.Index(r.raft.Config().GetLearnerCatchUpThreshold())
+	if threshold < commitIndex {
+		r.log.Debug("Waiting for %s to catch up to %d", member.MemberID, commitIndex-threshold)
+		if err := r.appender.awaitMatchIndex(ctx, member.MemberID, commitIndex-threshold); err != nil {
+			r.log.Debug("Failed to catch up %s: %s", member.MemberID, err)
+			return nil, raft.ResponseError_UNAVAILABLE, false
+		}
+	}
+
+	// Promote the learner to a voter.
+	return r.changeConfiguration(func(configuration *raft.Configuration) ([]*raft.Member, error) {
+		if err := r.checkPromotion(configuration, member); err != nil {
+			return nil, err
+		}
+		return replaceMember(configuration, member), nil
+	})
+}
+
+// Leave handles a leave request
+func (r *LeaderRole) Leave(ctx context.Context, request *raft.LeaveRequest) (*raft.LeaveResponse, error) {
+	r.log.Request("LeaveRequest", request)
+	if request.Member == nil {
+		response := &raft.LeaveResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_PROTOCOL_ERROR,
+		}
+		_ = r.log.Response("LeaveResponse", response, nil)
+		return response, nil
+	}
+
+	configuration, responseErr, ok := r.changeConfiguration(func(configuration *raft.Configuration) ([]*raft.Member, error) {
+		members := make([]*raft.Member, 0, len(configuration.Members))
+		for _, member := range configuration.Members {
+			if member.MemberID != request.Member.MemberID {
+				members = append(members, member)
+			}
+		}
+		if len(members) == len(configuration.Members) {
+			return nil, fmt.Errorf("member %s is not a member of the configuration", request.Member.MemberID)
+		}
+		return members, nil
+	})
+	if !ok {
+		response := &raft.LeaveResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  responseErr,
+		}
+		_ = r.log.Response("LeaveResponse", response, nil)
+		return response, nil
+	}
+
+	response := &raft.LeaveResponse{
+		Status:    raft.ResponseStatus_OK,
+		Index:     configuration.Index,
+		Term:      configuration.Term,
+		Timestamp: *configuration.Timestamp,
+		Members:   configuration.Members,
+	}
+	_ = r.log.Response("LeaveResponse", response, nil)
+	return response, nil
+}
+
+// Reconfigure handles a reconfigure request
+func (r *LeaderRole) Reconfigure(ctx context.Context, request *raft.ReconfigureRequest) (*raft.ReconfigureResponse, error) {
+	r.log.Request("ReconfigureRequest", request)
+
+	// A reconfiguration changes either a member or the cluster-wide timing, but not both.
+	if request.ElectionTimeout != nil || request.HeartbeatInterval != nil {
+		if request.Member != nil {
+			response := &raft.ReconfigureResponse{
+				Status: raft.ResponseStatus_ERROR,
+				Error:  raft.ResponseError_PROTOCOL_ERROR,
+			}
+			_ = r.log.Response("ReconfigureResponse", response, nil)
+			return response, nil
+		}
+		response := r.reconfigureTiming(request)
+		_ = r.log.Response("ReconfigureResponse", response, nil)
+		return response, nil
+	}
+
+	if request.Member == nil {
+		response := &raft.ReconfigureResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_PROTOCOL_ERROR,
+		}
+		_ = r.log.Response("ReconfigureResponse", response, nil)
+		return response, nil
+	}
+
+	configuration, responseErr, ok := r.changeConfiguration(func(configuration *raft.Configuration) ([]*raft.Member, error) {
+		if err := r.checkPromotion(configuration, request.Member); err != nil {
+			return nil, err
+		}
+		return replaceMember(configuration, request.Member), nil
+	})
+	if !ok {
+		response := &raft.ReconfigureResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  responseErr,
+		}
+		_ = r.log.Response("ReconfigureResponse", response, nil)
+		return response, nil
+	}
+
+	response := &raft.ReconfigureResponse{
+		Status:    raft.ResponseStatus_OK,
+		Index:     configuration.Index,
+		Term:      configuration.Term,
+		Timestamp: *configuration.Timestamp,
+		Members:   configuration.Members,
+	}
+	_ = r.log.Response("ReconfigureResponse", response, nil)
+	return response, nil
+}
+
+// reconfigureTiming changes the cluster-wide election timeout and heartbeat interval. The timing is appended
+// to the log and replaces the configured timing on each member once it's committed, so all members agree on
+// the timing in effect without a restart. Timing that is not set in the request is unchanged.
+func (r *LeaderRole) reconfigureTiming(request *raft.ReconfigureRequest) *raft.ReconfigureResponse {
+	// Acquire the write lock to append the timing change to the log.
+	r.raft.WriteLock()
+
+	// Validate the configuration with the new timing applied before appending it to the log.
+	config := *r.raft.Config()
+	electionTimeout := r.raft.ConfiguredElectionTimeout()
+	heartbeatInterval := r.raft.HeartbeatInterval()
+	config.ElectionTimeout = &electionTimeout
+	config.HeartbeatInterval = &heartbeatInterval
+	if request.ElectionTimeout != nil {
+		config.ElectionTimeout = request.ElectionTimeout
+	}
+	if request.HeartbeatInterval != nil {
+		config.HeartbeatInterval = request.HeartbeatInterval
+	}
+	if err := config.Validate(); err != nil {
+		r.raft.WriteUnlock()
+		r.log.Warn("Rejected timing change: %s", err)
+		return &raft.ReconfigureResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_CONFIGURATION_ERROR,
+		}
+	}
+
+	entry := &raft.LogEntry{
+		Term:      r.raft.Term(),
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_Timing{
+			Timing: &raft.TimingEntry{
+				ElectionTimeout:   config.GetElectionTimeoutOrDefault(),
+				HeartbeatInterval: config.GetHeartbeatIntervalOrDefault(),
+			},
+		},
+	}
+	indexed := r.store.Writer().Append(entry)
+	r.raft.SetTiming(indexed.Index, config.GetElectionTimeoutOrDefault(), config.GetHeartbeatIntervalOrDefault())
+	ch := r.appender.register(indexed, func() {
+		r.state.ApplyEntry(indexed, nil)
+	})
+	r.raft.WriteUnlock()
+
+	// Commit the timing change and apply it to the state machine.
+	if err := r.appender.commit(indexed, ch); err != nil {
+		return &raft.ReconfigureResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_PROTOCOL_ERROR,
+		}
+	}
+	return &raft.ReconfigureResponse{
+		Status:    raft.ResponseStatus_OK,
+		Index:     indexed.Index,
+		Term:      entry.Term,
+		Timestamp: entry.Timestamp,
+	}
+}
+
+// changeConfiguration appends the members computed by the given function from the current configuration to
+// the log as a new configuration and waits for the configuration to be committed. Only a single configuration
+// change may be in progress at a time: if a configuration change has been appended but not yet committed, or
+// a committed joint configuration has not yet been completed, the change is rejected with
+// CONFIGURATION_IN_PROGRESS. If the given function returns an error, the change
+// is rejected with CONFIGURATION_ERROR.
+func (r *LeaderRole) changeConfiguration(change func(*raft.Configuration) ([]*raft.Member, error)) (*raft.Configuration, raft.ResponseError, bool) {
+	// Acquire the write lock to append the configuration change to the log.
+	r.raft.WriteLock()
+	configuration, pending := r.raft.Configuration()
+	if pending != nil {
+		r.raft.WriteUnlock()
+		r.log.Debug("Rejected configuration change: configuration change %d is in progress", pending.Index)
+		return nil, raft.ResponseError_CONFIGURATION_IN_PROGRESS, false
+	}
+	if configuration != nil && len(configuration.OldMembers) > 0 {
+		r.raft.WriteUnlock()
+		r.log.Debug("Rejected configuration change: joint configuration %d has not been completed", configuration.Index)
+		return nil, raft.ResponseError_CONFIGURATION_IN_PROGRESS, false
+	}
+
+	members, err := change(configuration)
+	if err != nil {
+		r.raft.WriteUnlock()
+		r.log.Debug("Rejected configuration change: %s", err)
+		return nil, raft.ResponseError_CONFIGURATION_ERROR, false
+	}
+	if err := r.checkEvenCluster(configuration, members); err != nil {
+		r.raft.WriteUnlock()
+		r.log.Warn("Rejected configuration change: %s", err)
+		return nil, raft.ResponseError_CONFIGURATION_ERROR, false
+	}
+
+	// If joint consensus is enabled and the change adds or removes voters, transition through a joint
+	// configuration in which quorums require majorities of both the old and the new voters. This prevents
+	// the old and new configurations from electing separate leaders while the change is replicated.
+	if r.raft.Config().GetJointConsensus() && configuration != nil && votersChanged(configuration.Members, members) {
+		joint, indexed, ch := r.appendConfiguration(members, configuration.Members)
+		r.raft.WriteUnlock()
+		r.log.Debug("Committing joint configuration %d", joint.Index)
+		if err := r.appender.commit(indexed, ch); err != nil {
+			return nil, raft.ResponseError_PROTOCOL_ERROR, false
+		}
+		r.raft.WriteLock()
+		if !r.active {
+			r.raft.WriteUnlock()
+			return nil, raft.ResponseError_PROTOCOL_ERROR, false
+		}
+	}
+
+	configuration, indexed, ch := r.appendConfiguration(members, nil)
+	r.raft.WriteUnlock()
+
+	// Commit the configuration change and apply it to the state machine.
+	if err := r.appender.commit(indexed, ch); err != nil {
+		return nil, raft.ResponseError_PROTOCOL_ERROR, false
+	}
+	return configuration, 0, true
+}
+
+// completeJointConfiguration completes a joint configuration change committed by a prior leader by
+// committing the new configuration
+func (r *LeaderRole) completeJointConfiguration() {
+	r.raft.WriteLock()
+	configuration, pending := r.raft.Configuration()
+	if !r.active || pending != nil || configuration == nil || len(configuration.OldMembers) == 0 {
+		r.raft.WriteUnlock()
+		return
+	}
+	r.log.Debug("Completing joint configuration %d", configuration.Index)
+	_, indexed, ch := r.appendConfiguration(configuration.Members, nil)
+	r.raft.WriteUnlock()
+	if err := r.appender.commit(indexed, ch); err != nil {
+		r.log.Debug("Failed to complete joint configuration %d", configuration.Index)
+	}
+}
+
+// appendConfiguration appends a configuration entry with the given members to the log and returns the
+// configuration along with a channel to await its commitment. If old members are provided, the entry is a
+// joint configuration. The caller must hold the write lock.
+func (r *LeaderRole) appendConfiguration(members []*raft.Member, oldMembers []*raft.Member) (*raft.Configuration, *log.Entry, <-chan bool) {
+	entry := &raft.LogEntry{
+		Term:      r.raft.Term(),
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_Configuration{
+			Configuration: &raft.ConfigurationEntry{
+				Members:    members,
+				OldMembers: oldMembers,
+			},
+		},
+	}
+	indexed := r.store.Writer().Append(entry)
+	configuration := &raft.Configuration{
+		Index:      indexed.Index,
+		Term:       entry.Term,
+		Timestamp:  &entry.Timestamp,
+		Members:    members,
+		OldMembers: oldMembers,
+	}
+	r.raft.SetConfiguration(configuration)
+	r.appender.updateVoters()
+	ch := r.appender.register(indexed, func() {
+		r.state.ApplyEntry(indexed, nil)
+	})
+	return configuration, indexed, ch
+}
+
+// votersChanged returns whether the voting members differ between the given members
+func votersChanged(members []*raft.Member, newMembers []*raft.Member) bool {
+	voters := make(map[raft.MemberID]bool)
+	for _, member := range members {
+		if member.IsVoter() {
+			voters[member.MemberID] = true
+		}
+	}
+	count := 0
+	for _, member := range newMembers {
+		if member.IsVoter() {
+			if !voters[member.MemberID] {
+				return true
+			}
+			count++
+		}
+	}
+	return count != len(voters)
+}
+
+// checkEvenCluster applies the configured even cluster policy to a configuration change that changes the
+// number of voting members to an even number. Even-sized clusters tolerate no more failures than the next
+// smaller odd-sized cluster. An error is returned if the change is rejected by the policy.
+func (r *LeaderRole) checkEvenCluster(configuration *raft.Configuration, members []*raft.Member) error {
+	policy := r.raft.Config().GetEvenClusterPolicy()
+	if policy == config.EvenClusterPolicy_ALLOW {
+		return nil
+	}
+	voters := countVoters(members)
+	if voters%2 != 0 || (configuration != nil && voters == countVoters(configuration.Members)) {
+		return nil
+	}
+	if policy == config.EvenClusterPolicy_REJECT {
+		return fmt.Errorf("configuration change would result in an even number of voting members (%d)", voters)
+	}
+	r.log.Warn("Configuration change results in an even number of voting members (%d)", voters)
+	return nil
+}
+
+// countVoters returns the number of voting members in the given members
+func countVoters(members []*raft.Member) int {
+	voters := 0
+	for _, member := range members {
+		if member.IsVoter() {
+			voters++
+		}
+	}
+	return voters
+}
+
+// replaceMember returns the members of the given configuration with the given member replaced,
+// adding the member if it does not exist
+func replaceMember(configuration *raft.Configuration, member *raft.Member) []*raft.Member {
+	members := make([]*raft.Member, 0, len(configuration.Members)+1)
+	found := false
+	for _, current := range configuration.Members {
+		if current.MemberID == member.MemberID {
+			members = append(members, member)
+			found = true
+		} else {
+			members = append(members, current)
+		}
+	}
+	if !found {
+		members = append(members, member)
+	}
+	return members
+}
+
+// findMember returns the member with the given ID in the configuration, or nil if it's not a member
+func findMember(configuration *raft.Configuration, id raft.MemberID) *raft.Member {
+	for _, member := range configuration.Members {
+		if member.MemberID == id {
+			return member
+		}
+	}
+	return nil
+}
+
+// isPromotion returns whether the given member is promoted to a voting member by a reconfiguration
+func isPromotion(configuration *raft.Configuration, member *raft.Member) bool {
+	if member.Type != raft.Member_ACTIVE {
+		return false
+	}
+	for _, current := range configuration.Members {
+		if current.MemberID == member.MemberID {
+			return current.Type != raft.Member_ACTIVE
+		}
+	}
+	return true
+}
+
+// checkPromotion returns an error if the given member cannot be promoted to a voting member
+func (r *LeaderRole) checkPromotion(configuration *raft.Configuration, member *raft.Member) error {
+	if !isPromotion(configuration, member) {
+		return nil
+	}
+	// If promotions are gated on the stability of the cluster, reject the promotion of a member to
+	// a voting member while a voter is unreachable.
+	if r.raft.Config().GetPromotionPolicy() == config.PromotionPolicy_STABLE {
+		if err := r.checkPromotionStability(configuration); err != nil {
+			return fmt.Errorf("rejected promotion of %s: %s", member.MemberID, err)
+		}
+	}
+	// If promotions are gated on learners catching up, reject the promotion of a member to a voting
+	// member until it has replicated the log to within the configured threshold of the commit index.
+	// Members must join as learners to catch up before they can be promoted.
+	if err := r.checkPromotionCatchUp(member.MemberID); err != nil {
+		return fmt.Errorf("rejected promotion of %s: %s", member.MemberID, err)
+	}
+	return nil
+}
+
+// checkPromotionStability returns an error if the cluster is not stable enough to promote a member
+func (r *LeaderRole) checkPromotionStability(configuration *raft.Configuration) error {
+	for _, member := range configuration.Members {
+		if member.IsVoter() && !r.appender.isReachable(member.MemberID) {
+			return fmt.Errorf("voting member %s is unreachable", member.MemberID)
+		}
+	}
+	return nil
+}
+
+// checkPromotionCatchUp returns an error if a learner catch-up threshold is configured and the given member has not
+// replicated the log to within the threshold of the commit index
+func (r *LeaderRole) checkPromotionCatchUp(member raft.MemberID) error {
+	threshold := raft.Index(r.raft.Config().GetLearnerCatchUpThreshold())
+	if threshold == 0 {
+		return nil
+	}
+	commitIndex := r.raft.CommitIndex()
+	matchIndex := r.appender.matchIndex(member)
+	if matchIndex+threshold < commitIndex {
+		return fmt.Errorf("member's match index %d is not within %d of the commit index %d", matchIndex, threshold, commitIndex)
+	}
+	return nil
+}
+
+// SetReadOnly handles a set read-only request
+func (r *LeaderRole) SetReadOnly(ctx context.Context, request *raft.SetReadOnlyRequest) (*raft.SetReadOnlyResponse, error) {
+	r.log.Request("SetReadOnlyRequest", request)
+
+	// Acquire the write lock to append the read-only mode change to the log.
+	r.raft.WriteLock()
+	entry := &raft.LogEntry{
+		Term:      r.raft.Term(),
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_ReadOnly{
+			ReadOnly: &raft.ReadOnlyEntry{
+				ReadOnly: request.ReadOnly,
+			},
+		},
+	}
+	indexed := r.store.Writer().Append(entry)
+	r.raft.SetReadOnlyMode(indexed.Index, request.ReadOnly)
+	ch := r.appender.register(indexed, func() {
+		r.state.ApplyEntry(indexed, nil)
+	})
+	r.raft.WriteUnlock()
+
+	// Commit the read-only mode change and apply it to the state machine.
+	if err := r.appender.commit(indexed, ch); err != nil {
+		response := &raft.SetReadOnlyResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_PROTOCOL_ERROR,
+		}
+		_ = r.log.Response("SetReadOnlyResponse", response, nil)
+		return response, nil
+	}
+
+	response := &raft.SetReadOnlyResponse{
+		Status: raft.ResponseStatus_OK,
+		Index:  indexed.Index,
+		Term:   entry.Term,
+	}
+	_ = r.log.Response("SetReadOnlyResponse", response, nil)
+	return response, nil
+}
+
+// FreezeElections handles an election freeze request
+func (r *LeaderRole) FreezeElections(ctx context.Context, request *raft.FreezeElectionsRequest) (*raft.FreezeElectionsResponse, error) {
+	r.log.Request("FreezeElectionsRequest", request)
 
-	// Create and append an InitializeEntry.
+	// Limit the duration of the freeze to ensure elections resume if the freeze is never lifted.
+	// A zero duration lifts the freeze. The duration is replicated rather than the expiration time,
+	// and each member computes the expiration from its own clock once the freeze is committed.
+	var duration time.Duration
+	if request.Duration > 0 {
+		duration = request.Duration
+		if maxDuration := r.raft.Config().GetMaxElectionFreezeOrDefault(); duration > maxDuration {
+			duration = maxDuration
+		}
+	}
+
+	// Acquire the write lock to append the election freeze to the log.
+	r.raft.WriteLock()
 	entry := &raft.LogEntry{
 		Term:      r.raft.Term(),
 		Timestamp: time.Now(),
-		Entry: &raft.LogEntry_Initialize{
-			Initialize: &raft.InitializeEntry{},
+		Entry: &raft.LogEntry_Freeze{
+			Freeze: &raft.FreezeEntry{
+				Duration: duration,
+			},
 		},
 	}
 	indexed := r.store.Writer().Append(entry)
+	r.raft.SetElectionFreeze(indexed.Index, duration)
+	ch := r.appender.register(indexed, func() {
+		r.state.ApplyEntry(indexed, nil)
+	})
 	r.raft.WriteUnlock()
 
-	r.initIndex = indexed.Index
+	// Commit the election freeze and apply it to the state machine.
+	if err := r.appender.commit(indexed, ch); err != nil {
+		response := &raft.FreezeElectionsResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_PROTOCOL_ERROR,
+		}
+		_ = r.log.Response("FreezeElectionsResponse", response, nil)
+		return response, nil
+	}
 
-	// The Raft protocol dictates that leaders cannot commit entries from previous terms until
-	// at least one entry from their current term has been stored on a majority of servers. Thus,
-	// we force entries to be appended up to the leader's no-op entry. The LeaderAppender will ensure
-	// that the commitIndex is not increased until the no-op entry is committed.
-	err := r.appender.commit(indexed, nil)
-	if err != nil {
-		r.log.Debug("Failed to commit entry from leader's term; transitioning to follower")
-		r.raft.WriteLock()
-		defer r.raft.WriteUnlock()
-		if err := r.raft.SetLeader(nil); err != nil {
-			r.log.Error("Failed to unset leader", err)
+	// Report the expiration of the freeze as computed by the leader once committed.
+	var expires time.Time
+	r.raft.ReadLock()
+	if r.raft.ElectionsFrozen() {
+		expires = time.Now().Add(r.raft.ElectionFreeze())
+	}
+	r.raft.ReadUnlock()
+	response := &raft.FreezeElectionsResponse{
+		Status:  raft.ResponseStatus_OK,
+		Index:   indexed.Index,
+		Term:    entry.Term,
+		Expires: expires,
+	}
+	_ = r.log.Response("FreezeElectionsResponse", response, nil)
+	return response, nil
+}
+
+// Transfer handles a transfer request.
+// Once the leader sends the transfer request to the target, the leader enters a quiet state in which it
+// stops accepting commands and does not step down for failing to reach a quorum. The target's vote request
+// for the next term causes the leader to step down as usual. If the target does not take over within the
+// transfer timeout, the transfer is aborted and the leader resumes normal operation.
+func (r *LeaderRole) Transfer(ctx context.Context, request *raft.TransferRequest) (*raft.TransferResponse, error) {
+	r.log.Request("TransferRequest", request)
+	r.raft.WriteLock()
+	if r.transferring != nil || request.Member == r.raft.Member() || r.raft.GetMember(request.Member) == nil || !r.raft.IsVoter(request.Member) || r.raft.IsWitness(request.Member) {
+		r.raft.WriteUnlock()
+		response := &raft.TransferResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
 		}
-		r.raft.SetRole(raft.RoleFollower)
+		_ = r.log.Response("TransferResponse", response, nil)
+		return response, nil
+	}
+	member := request.Member
+	r.transferring = &member
+	r.appender.setQuiet(true)
+	r.raft.WriteUnlock()
+
+	timer := time.NewTimer(r.raft.Config().GetTransferTimeoutOrDefault())
+	defer timer.Stop()
+
+	// New commands are rejected while leadership is being transferred. Wait for the target to replicate
+	// the leader's log before triggering an election to ensure the target can win it. If the target is
+	// unable to catch up within the transfer timeout, the transfer is aborted.
+	r.log.Debug("Transferring leadership to %s", member)
+	request, err := r.awaitTransferCatchUp(ctx, member)
+	if err != nil {
+		r.log.Debug("Aborting transfer: %s failed to catch up", member, err)
+		return r.abortTransfer(), nil
+	}
+
+	r.log.Send("TransferRequest", request)
+	response, err := r.raft.Protocol().Transfer(ctx, request, member)
+	if err != nil {
+		r.log.Warn("Transfer request failed", err)
 	} else {
-		r.state.ApplyEntry(indexed, nil)
+		r.log.Receive("TransferResponse", response)
+		if response.Status == raft.ResponseStatus_OK {
+			select {
+			case <-r.stopped:
+				response := &raft.TransferResponse{
+					Status: raft.ResponseStatus_OK,
+				}
+				_ = r.log.Response("TransferResponse", response, nil)
+				return response, nil
+			case <-timer.C:
+				r.log.Debug("Transfer to %s timed out", member)
+			case <-ctx.Done():
+			}
+		}
+	}
+
+	// If the transfer failed, abort the transfer and resume normal operation.
+	return r.abortTransfer(), nil
+}
+
+// awaitTransferCatchUp waits until the target of a leadership transfer has replicated the leader's log,
+// returning the transfer request to send to the target once it's caught up
+func (r *LeaderRole) awaitTransferCatchUp(ctx context.Context, member raft.MemberID) (*raft.TransferRequest, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.raft.Config().GetTransferTimeoutOrDefault())
+	defer cancel()
+	for {
+		// Entries other than commands may still be appended during the transfer, so read the last index
+		// again once the target has caught up to verify it's still up to date.
+		r.raft.ReadLock()
+		lastIndex := r.store.Writer().LastIndex()
+		r.raft.ReadUnlock()
+		if r.appender.matchIndex(member) >= lastIndex {
+			return &raft.TransferRequest{
+				Member:       member,
+				LastLogIndex: lastIndex,
+			}, nil
+		}
+		if err := r.appender.awaitMatchIndex(ctx, member, lastIndex); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// abortTransfer aborts a leadership transfer and resumes normal operation
+func (r *LeaderRole) abortTransfer() *raft.TransferResponse {
+	r.raft.WriteLock()
+	r.transferring = nil
+	r.appender.setQuiet(false)
+	r.raft.WriteUnlock()
+	response := &raft.TransferResponse{
+		Status: raft.ResponseStatus_ERROR,
+		Error:  raft.ResponseError_PROTOCOL_ERROR,
+	}
+	_ = r.log.Response("TransferResponse", response, nil)
+	return response
+}
+
+// ConfirmLeadership handles a leadership confirmation request
+func (r *LeaderRole) ConfirmLeadership(ctx context.Context, request *raft.ConfirmLeadershipRequest) (*raft.ConfirmLeadershipResponse, error) {
+	r.log.Request("ConfirmLeadershipRequest", request)
+	r.raft.WriteLock()
+	defer r.raft.WriteUnlock()
+
+	// If the request indicates a term that is greater than the current term then
+	// assign that term and leader to the current context and step down as leader.
+	if r.updateTermAndLeader(request.Term, &request.Leader) {
+		r.log.Debug("Received greater term")
+		defer r.raft.SetRole(raft.RoleFollower)
+		response := r.handleConfirmLeadership(request)
+		_ = r.log.Response("ConfirmLeadershipResponse", response, nil)
+		return response, nil
+	}
+
+	response := &raft.ConfirmLeadershipResponse{
+		Status:    raft.ResponseStatus_OK,
+		Term:      r.raft.Term(),
+		Confirmed: false,
 	}
+	_ = r.log.Response("ConfirmLeadershipResponse", response, nil)
+	return response, nil
 }
 
 // Poll handles a poll request
@@ -121,6 +1054,17 @@ func (r *LeaderRole) Vote(ctx context.Context, request *raft.VoteRequest) (*raft
 	r.log.Request("VoteRequest", request)
 	r.raft.WriteLock()
 	defer r.raft.WriteUnlock()
+
+	// If the role is stopping, decline to vote.
+	if response := r.checkVoteActive(); response != nil {
+		return response, nil
+	}
+
+	// If the candidate has exceeded the election rate limit, reject the vote before it can update the term.
+	if response := r.checkVoteRateLimit(request); response != nil {
+		return response, nil
+	}
+
 	if r.updateTermAndLeader(request.Term, nil) {
 		r.log.Debug("Received greater term")
 		defer r.raft.SetRole(raft.RoleFollower)
@@ -138,6 +1082,47 @@ func (r *LeaderRole) Vote(ctx context.Context, request *raft.VoteRequest) (*raft
 	return response, nil
 }
 
+// Hash handles a log hash request.
+// The leader hashes the requested range of committed entries for comparison with a follower's log.
+func (r *LeaderRole) Hash(ctx context.Context, request *raft.HashRequest) (*raft.HashResponse, error) {
+	r.log.Request("HashRequest", request)
+	r.raft.ReadLock()
+	term := r.raft.Term()
+	commitIndex := r.raft.CommitIndex()
+	r.raft.ReadUnlock()
+
+	if request.FirstIndex == 0 || request.FirstIndex > request.LastIndex || request.LastIndex > commitIndex {
+		response := &raft.HashResponse{
+			Status:  raft.ResponseStatus_ERROR,
+			Error:   raft.ResponseError_PROTOCOL_ERROR,
+			Message: fmt.Sprintf("invalid range %d-%d", request.FirstIndex, request.LastIndex),
+			Term:    term,
+		}
+		_ = r.log.Response("HashResponse", response, nil)
+		return response, nil
+	}
+
+	hash, err := r.hashLog(request.FirstIndex, request.LastIndex)
+	if err != nil {
+		response := &raft.HashResponse{
+			Status:  raft.ResponseStatus_ERROR,
+			Error:   raft.ResponseError_PROTOCOL_ERROR,
+			Message: err.Error(),
+			Term:    term,
+		}
+		_ = r.log.Response("HashResponse", response, nil)
+		return response, nil
+	}
+
+	response := &raft.HashResponse{
+		Status: raft.ResponseStatus_OK,
+		Term:   term,
+		Hash:   hash,
+	}
+	_ = r.log.Response("HashResponse", response, nil)
+	return response, nil
+}
+
 // Append handles an append request
 func (r *LeaderRole) Append(ctx context.Context, request *raft.AppendRequest) (*raft.AppendResponse, error) {
 	r.log.Request("AppendRequest", request)
@@ -170,34 +1155,156 @@ func (r *LeaderRole) Command(request *raft.CommandRequest, responseCh chan<- *ra
 	r.log.Request("CommandRequest", request)
 	defer close(responseCh)
 
+	// Authorize the command before acquiring the write lock. Denied commands are rejected without
+	// touching the log.
+	if err := r.raft.AuthorizeCommand(request); err != nil {
+		r.log.Debug("Command denied: %s", err)
+		response := &raft.CommandResponse{
+			Status:  raft.ResponseStatus_ERROR,
+			Error:   raft.ResponseError_UNAUTHORIZED,
+			Message: err.Error(),
+		}
+		_ = r.log.Response("CommandResponse", response, nil)
+		responseCh <- raft.NewCommandStreamResponse(response, nil)
+		return nil
+	}
+
+	// If the command is a retry of the last command cached for its session, respond with the cached output
+	// rather than appending a duplicate entry to the log.
+	dedup := r.raft.Config().GetDedup()
+	session, sequence, dedupable := getCommandSequence(request.Value)
+	dedupable = dedupable && dedup.GetMaxBytes() > 0
+	var expiration time.Duration
+	if dedup.GetExpiration() != nil {
+		expiration = *dedup.GetExpiration()
+	}
+	if dedupable {
+		if output, ok := r.dedup.get(session, sequence, r.raft.Clock().Now(), expiration); ok {
+			r.log.Debug("Deduplicated command %d for session %d", sequence, session)
+			r.sendCommandResponse(r.newCommandOutputResponse(stream.Result{Value: output}), responseCh)
+			return nil
+		}
+	}
+
 	// Acquire the write lock to write the entry to the log.
 	r.raft.WriteLock()
 
-	entry := &raft.LogEntry{
-		Term:      r.raft.Term(),
-		Timestamp: time.Now(),
-		Entry: &raft.LogEntry_Command{
-			Command: &raft.CommandEntry{
-				Value: request.Value,
-			},
-		},
+	// If the maximum number of concurrent command streams is open, reject the command.
+	// Clients may retry the command once another stream has been released.
+	maxStreams := int(r.raft.Config().GetMaxCommandStreams())
+	if maxStreams > 0 && r.commandStreams >= maxStreams {
+		r.raft.WriteUnlock()
+		response := &raft.CommandResponse{
+			Status:  raft.ResponseStatus_ERROR,
+			Error:   raft.ResponseError_UNAVAILABLE,
+			Message: "too many concurrent command streams",
+		}
+		_ = r.log.Response("CommandResponse", response, nil)
+		responseCh <- raft.NewCommandStreamResponse(response, nil)
+		return nil
 	}
-	indexed := r.store.Writer().Append(entry)
+	r.commandStreams++
+	defer func() {
+		r.raft.WriteLock()
+		r.commandStreams--
+		r.raft.WriteUnlock()
+	}()
 
-	// Release the write lock immediately after appending the entry to ensure the appenders
-	// can acquire a read lock for the log.
-	r.raft.WriteUnlock()
+	// If leadership is being transferred, reject the command.
+	if r.transferring != nil {
+		r.raft.WriteUnlock()
+		response := &raft.CommandResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+		}
+		_ = r.log.Response("CommandResponse", response, nil)
+		responseCh <- raft.NewCommandStreamResponse(response, nil)
+		return nil
+	}
+
+	// If the cluster is in read-only mode, reject the command.
+	if r.raft.IsReadOnly() {
+		r.raft.WriteUnlock()
+		response := &raft.CommandResponse{
+			Status:  raft.ResponseStatus_ERROR,
+			Error:   raft.ResponseError_READ_ONLY,
+			Message: "cluster is in read-only mode",
+		}
+		_ = r.log.Response("CommandResponse", response, nil)
+		responseCh <- raft.NewCommandStreamResponse(response, nil)
+		return nil
+	}
 
 	// Create a function to apply the entry to the state machine once committed.
 	// This is done in a function to ensure entries are applied in the order in which they
 	// are committed by the appender.
 	outputCh := make(chan stream.Result)
-	f := func() {
-		r.state.ApplyEntry(indexed, stream.NewChannelStream(outputCh))
+	apply := func(indexed *log.Entry) func() {
+		return func() {
+			output := stream.NewChannelStream(outputCh)
+			if dedupable {
+				output = newDedupStream(output, func(value []byte) {
+					r.dedup.put(session, sequence, value, r.raft.Clock().Now(), dedup.GetMaxBytes(), expiration)
+				})
+			}
+			r.state.ApplyEntry(indexed, output)
+		}
+	}
+
+	var indexed *log.Entry
+	var ch <-chan bool
+	if r.queueProposals() {
+		// If fair proposals or proposal batching are enabled, release the write lock and enqueue
+		// the command to be appended once it's scheduled.
+		r.raft.WriteUnlock()
+		indexed, ch = r.propose(request, apply)
+		if indexed == nil {
+			leader, term := r.leaderHint()
+			response := &raft.CommandResponse{
+				Status: raft.ResponseStatus_ERROR,
+				Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+				Leader: leader,
+				Term:   term,
+			}
+			_ = r.log.Response("CommandResponse", response, nil)
+			responseCh <- raft.NewCommandStreamResponse(response, nil)
+			return nil
+		}
+	} else {
+		entry := &raft.LogEntry{
+			Term:      r.raft.Term(),
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Command{
+				Command: &raft.CommandEntry{
+					Value: request.Value,
+				},
+			},
+		}
+		indexed = r.store.Writer().Append(entry)
+
+		// Register the entry with the appender before releasing the write lock to ensure the entry is
+		// completed if it's committed in a batch with entries appended by other commands.
+		ch = r.appender.register(indexed, apply(indexed))
+
+		// Release the write lock immediately after appending the entry to ensure the appenders
+		// can acquire a read lock for the log.
+		r.raft.WriteUnlock()
 	}
 
-	// Pass the apply function to the appender to be called when the change is committed.
-	if err := r.appender.commit(indexed, f); err != nil {
+	// Wait for the appender to commit the entry and apply it to the state machine. If the leader steps down
+	// before the entry is committed, fail the command with a retryable error pointing to the new leader.
+	if err := r.appender.commit(indexed, ch); err == errNotLeader {
+		leader, term := r.leaderHint()
+		response := &raft.CommandResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+			Leader: leader,
+			Term:   term,
+		}
+		_ = r.log.Response("CommandResponse", response, nil)
+		responseCh <- raft.NewCommandStreamResponse(response, nil)
+		return nil
+	} else if err != nil {
 		response := &raft.CommandResponse{
 			Status: raft.ResponseStatus_ERROR,
 			Error:  raft.ResponseError_PROTOCOL_ERROR,
@@ -207,33 +1314,247 @@ func (r *LeaderRole) Command(request *raft.CommandRequest, responseCh chan<- *ra
 		return nil
 	}
 
+	// If a command stream buffer is configured, buffer the output to prevent a slow client from blocking
+	// the state machine.
+	if size := int(r.raft.Config().GetCommandStreamBuffer()); size > 0 {
+		r.streamCommandOutput(outputCh, responseCh, size)
+		return nil
+	}
 	for output := range outputCh {
-		var status raft.ResponseStatus
-		var err raft.ResponseError
-		var message string
-		if output.Succeeded() {
-			status = raft.ResponseStatus_OK
-		} else {
-			status = raft.ResponseStatus_ERROR
-			err = raft.ResponseError_APPLICATION_ERROR
-			message = output.Error.Error()
+		r.sendCommandResponse(r.newCommandOutputResponse(output), responseCh)
+	}
+	return nil
+}
+
+// newCommandOutputResponse returns a command response for the given state machine output
+func (r *LeaderRole) newCommandOutputResponse(output stream.Result) *raft.CommandResponse {
+	var status raft.ResponseStatus
+	var err raft.ResponseError
+	var message string
+	var value []byte
+	if output.Succeeded() {
+		status = raft.ResponseStatus_OK
+		if b, ok := output.Value.([]byte); ok {
+			value = b
 		}
+	} else {
+		status = raft.ResponseStatus_ERROR
+		err = raft.ResponseError_APPLICATION_ERROR
+		message = output.Error.Error()
+	}
 
-		r.raft.ReadLock()
-		response := &raft.CommandResponse{
-			Status:  status,
-			Error:   err,
-			Message: message,
-			Leader:  r.raft.Member(),
-			Term:    r.raft.Term(),
-			Members: r.raft.Members(),
-			Output:  output.Value.([]byte),
+	r.raft.ReadLock()
+	defer r.raft.ReadUnlock()
+	return &raft.CommandResponse{
+		Status:  status,
+		Error:   err,
+		Message: message,
+		Leader:  r.raft.Member(),
+		Term:    r.raft.Term(),
+		Members: r.raft.Members(),
+		Output:  value,
+	}
+}
+
+// streamCommandOutput sends the state machine output to the given channel through a buffer of the given size.
+// The output is always consumed from the state machine without blocking on the client. If the client falls
+// behind by more than the buffer size, the remaining output is discarded and the stream is failed with an
+// UNAVAILABLE error rather than stalling the state machine.
+func (r *LeaderRole) streamCommandOutput(outputCh <-chan stream.Result, responseCh chan<- *raft.CommandStreamResponse, size int) {
+	bufferCh := make(chan *raft.CommandResponse, size)
+	overflowCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		for response := range bufferCh {
+			select {
+			case <-overflowCh:
+				continue
+			default:
+			}
+			r.sendCommandResponse(response, responseCh)
+		}
+		select {
+		case <-overflowCh:
+			response := &raft.CommandResponse{
+				Status:  raft.ResponseStatus_ERROR,
+				Error:   raft.ResponseError_UNAVAILABLE,
+				Message: fmt.Sprintf("command stream exceeded the high-water mark of %d buffered responses", size),
+			}
+			_ = r.log.Response("CommandResponse", response, nil)
+			responseCh <- raft.NewCommandStreamResponse(response, nil)
+		default:
+		}
+	}()
+
+	overflowed := false
+	for output := range outputCh {
+		if overflowed {
+			continue
+		}
+		select {
+		case bufferCh <- r.newCommandOutputResponse(output):
+		default:
+			r.log.Warn("Command stream exceeded the high-water mark of %d buffered responses; dropping the stream", size)
+			overflowed = true
+			close(overflowCh)
 		}
-		r.raft.ReadUnlock()
-		_ = r.log.Response("CommandResponse", response, nil)
-		responseCh <- raft.NewCommandStreamResponse(response, nil)
 	}
-	return nil
+	close(bufferCh)
+	<-doneCh
+}
+
+// CommandBatch handles a command batch request. The commands in the batch are appended to the log as a
+// contiguous batch in the order in which they're listed in the request, and the result of each command
+// is returned in the same order. A command that fails when applied to the state machine does not fail
+// the rest of the batch.
+func (r *LeaderRole) CommandBatch(ctx context.Context, request *raft.CommandBatchRequest) (*raft.CommandBatchResponse, error) {
+	r.log.Request("CommandBatchRequest", request)
+
+	// Authorize all the commands before appending any of them to ensure the batch is appended atomically.
+	for _, value := range request.Values {
+		if err := r.raft.AuthorizeCommand(&raft.CommandRequest{Value: value}); err != nil {
+			r.log.Debug("Command denied: %s", err)
+			response := &raft.CommandBatchResponse{
+				Status:  raft.ResponseStatus_ERROR,
+				Error:   raft.ResponseError_UNAUTHORIZED,
+				Message: err.Error(),
+			}
+			_ = r.log.Response("CommandBatchResponse", response, nil)
+			return response, nil
+		}
+	}
+
+	// Acquire the write lock to write the entries to the log.
+	r.raft.WriteLock()
+
+	// If leadership is being transferred, reject the batch.
+	if r.transferring != nil {
+		r.raft.WriteUnlock()
+		response := &raft.CommandBatchResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+		}
+		_ = r.log.Response("CommandBatchResponse", response, nil)
+		return response, nil
+	}
+
+	// If the cluster is in read-only mode, reject the batch.
+	if r.raft.IsReadOnly() {
+		r.raft.WriteUnlock()
+		response := &raft.CommandBatchResponse{
+			Status:  raft.ResponseStatus_ERROR,
+			Error:   raft.ResponseError_READ_ONLY,
+			Message: "cluster is in read-only mode",
+		}
+		_ = r.log.Response("CommandBatchResponse", response, nil)
+		return response, nil
+	}
+
+	// Append all the entries under the same write lock to ensure no other entries are interleaved with the batch.
+	// Proposals are bypassed since queued proposals may be appended between the entries in the batch.
+	entries := make([]*log.Entry, len(request.Values))
+	outputChs := make([]chan stream.Result, len(request.Values))
+	var ch <-chan bool
+	term := r.raft.Term()
+	timestamp := time.Now()
+	for i, value := range request.Values {
+		entry := &raft.LogEntry{
+			Term:      term,
+			Timestamp: timestamp,
+			Entry: &raft.LogEntry_Command{
+				Command: &raft.CommandEntry{
+					Value: value,
+				},
+			},
+		}
+		indexed := r.store.Writer().Append(entry)
+		outputCh := make(chan stream.Result)
+		ch = r.appender.register(indexed, func() {
+			r.state.ApplyEntry(indexed, stream.NewChannelStream(outputCh))
+		})
+		entries[i] = indexed
+		outputChs[i] = outputCh
+	}
+	r.raft.WriteUnlock()
+
+	// Wait for the appender to commit the last entry in the batch, which commits all the entries preceding it.
+	if len(entries) > 0 {
+		if err := r.appender.commit(entries[len(entries)-1], ch); err == errNotLeader {
+			leader, term := r.leaderHint()
+			response := &raft.CommandBatchResponse{
+				Status: raft.ResponseStatus_ERROR,
+				Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+				Leader: leader,
+				Term:   term,
+			}
+			_ = r.log.Response("CommandBatchResponse", response, nil)
+			return response, nil
+		} else if err != nil {
+			response := &raft.CommandBatchResponse{
+				Status: raft.ResponseStatus_ERROR,
+				Error:  raft.ResponseError_PROTOCOL_ERROR,
+			}
+			_ = r.log.Response("CommandBatchResponse", response, nil)
+			return response, nil
+		}
+	}
+
+	// Collect the result of each command in the order in which the commands were applied.
+	results := make([]*raft.CommandResult, len(entries))
+	for i, outputCh := range outputChs {
+		result := &raft.CommandResult{
+			Status: raft.ResponseStatus_OK,
+			Index:  entries[i].Index,
+		}
+		if output, ok := <-outputCh; ok {
+			if output.Succeeded() {
+				result.Output, _ = output.Value.([]byte)
+			} else {
+				result.Status = raft.ResponseStatus_ERROR
+				result.Error = raft.ResponseError_APPLICATION_ERROR
+				result.Message = output.Error.Error()
+			}
+
+			// Batched commands return a single result. Discard any further output to avoid blocking the state machine.
+			go func(outputCh <-chan stream.Result) {
+				for range outputCh {
+				}
+			}(outputCh)
+		}
+		results[i] = result
+	}
+
+	r.raft.ReadLock()
+	response := &raft.CommandBatchResponse{
+		Status:  raft.ResponseStatus_OK,
+		Leader:  r.raft.Member(),
+		Term:    r.raft.Term(),
+		Members: r.raft.Members(),
+		Results: results,
+	}
+	r.raft.ReadUnlock()
+	_ = r.log.Response("CommandBatchResponse", response, nil)
+	return response, nil
+}
+
+// sendCommandResponse sends the given command response on the given channel. If the response output
+// exceeds the configured result size limit, the output is streamed in chunks no larger than the limit.
+// All but the last chunk are marked partial, and clients concatenate the chunks to reconstruct the output.
+func (r *LeaderRole) sendCommandResponse(response *raft.CommandResponse, responseCh chan<- *raft.CommandStreamResponse) {
+	maxSize := int(r.raft.Config().GetMaxCommandResultSize())
+	output := response.Output
+	for maxSize > 0 && len(output) > maxSize {
+		chunk := *response
+		chunk.Output = output[:maxSize]
+		chunk.Partial = true
+		_ = r.log.Response("CommandResponse", &chunk, nil)
+		responseCh <- raft.NewCommandStreamResponse(&chunk, nil)
+		output = output[maxSize:]
+	}
+	response.Output = output
+	_ = r.log.Response("CommandResponse", response, nil)
+	responseCh <- raft.NewCommandStreamResponse(response, nil)
 }
 
 // Query handles a query request
@@ -241,9 +1562,30 @@ func (r *LeaderRole) Query(request *raft.QueryRequest, responseCh chan<- *raft.Q
 	r.log.Request("QueryRequest", request)
 	defer close(responseCh)
 
+	// If the client has observed an index the state machine has not yet applied, wait for the index to be applied.
+	if response := r.checkMinAppliedIndex(request); response != nil {
+		_ = r.log.Response("QueryResponse", response, nil)
+		responseCh <- raft.NewQueryStreamResponse(response, nil)
+		return nil
+	}
+
 	// Acquire a read lock before creating the entry.
 	r.raft.ReadLock()
 
+	// In strict mode, verify the leader has committed the no-op entry from its term before serving
+	// linearizable reads. The readiness of the leader should be gated before reads reach this point.
+	if request.ReadConsistency != raft.ReadConsistency_SEQUENTIAL && !r.isReadReady() {
+		r.raft.ReadUnlock()
+		response := &raft.QueryResponse{
+			Status:  raft.ResponseStatus_ERROR,
+			Error:   raft.ResponseError_NOT_READY,
+			Message: "leader has not committed an entry from its term",
+		}
+		_ = r.log.Response("QueryResponse", response, nil)
+		responseCh <- raft.NewQueryStreamResponse(response, nil)
+		return nil
+	}
+
 	// Create the entry to apply to the state machine.
 	entry := &log.Entry{
 		Index: r.store.Writer().LastIndex(),
@@ -273,8 +1615,28 @@ func (r *LeaderRole) Query(request *raft.QueryRequest, responseCh chan<- *raft.Q
 	}
 }
 
+// isReadReady returns whether the leader may serve linearizable reads. If strict reads are enabled, reads may
+// only be served once the leader's no-op entry has been committed. The caller must hold a lock on the Raft state.
+func (r *LeaderRole) isReadReady() bool {
+	if !r.raft.Config().StrictReads {
+		return true
+	}
+	if r.initIndex == 0 || r.raft.CommitIndex() < r.initIndex {
+		r.log.Warn("Rejected linearizable read: leader's no-op entry %d is not committed", r.initIndex)
+		return false
+	}
+	return true
+}
+
 // queryLinearizable performs a linearizable query
 func (r *LeaderRole) queryLinearizable(entry *log.Entry, responseCh chan<- *raft.QueryStreamResponse) error {
+	// Send a heartbeat to a majority of the cluster to verify leadership before applying the query.
+	// The query is applied after all entries committed before it was received, so once leadership
+	// is confirmed the query cannot observe state older than the read index.
+	if err := r.appender.heartbeat(); err != nil {
+		return r.log.Response("QueryResponse", nil, err)
+	}
+
 	// Create a result channel
 	ch := make(chan stream.Result)
 
@@ -282,11 +1644,19 @@ func (r *LeaderRole) queryLinearizable(entry *log.Entry, responseCh chan<- *raft
 	r.state.ApplyEntry(entry, stream.NewChannelStream(ch))
 
 	// Iterate through results and translate them into QueryResponses.
+	verified := true
 	for result := range ch {
-		// Send a heartbeat to a majority of the cluster to verify leadership.
-		if err := r.appender.heartbeat(); err != nil {
-			return r.log.Response("QueryResponse", nil, err)
+		// Verify leadership again before sending each later result of a streaming query.
+		if !verified {
+			if err := r.appender.heartbeat(); err != nil {
+				go func() {
+					for range ch {
+					}
+				}()
+				return r.log.Response("QueryResponse", nil, err)
+			}
 		}
+		verified = false
 		if result.Succeeded() {
 			response := &raft.QueryResponse{
 				Status: raft.ResponseStatus_OK,
@@ -308,6 +1678,12 @@ func (r *LeaderRole) queryLinearizable(entry *log.Entry, responseCh chan<- *raft
 
 // queryLinearizableLease performs a lease query
 func (r *LeaderRole) queryLinearizableLease(entry *log.Entry, responseCh chan<- *raft.QueryStreamResponse) error {
+	// Leases are only safe while clocks are synchronized and the leader has recently heard from enough
+	// followers. If the lease is not valid, verify leadership with a quorum instead.
+	if !r.appender.isLeaseValid() {
+		r.log.Debug("Lease is not valid; falling back to a linearizable query")
+		return r.queryLinearizable(entry, responseCh)
+	}
 	return r.applyQuery(entry, responseCh)
 }
 
@@ -316,8 +1692,20 @@ func (r *LeaderRole) querySequential(entry *log.Entry, responseCh chan<- *raft.Q
 	return r.applyQuery(entry, responseCh)
 }
 
+// leaderHint returns the leader to which clients should retry commands that could not be completed
+// because this member stepped down, along with the current term. The leader is empty if unknown.
+func (r *LeaderRole) leaderHint() (raft.MemberID, raft.Term) {
+	r.raft.ReadLock()
+	defer r.raft.ReadUnlock()
+	if leader := r.raft.Leader(); leader != nil && *leader != r.raft.Member() {
+		return *leader, r.raft.Term()
+	}
+	return "", r.raft.Term()
+}
+
 // stepDown unsets the leader
 func (r *LeaderRole) stepDown() {
+	r.raft.SetQuorumIndex(0)
 	if r.raft.Leader() != nil && *r.raft.Leader() == r.raft.Member() {
 		if err := r.raft.SetLeader(nil); err != nil {
 			r.log.Error("Failed to step down", err)
@@ -327,7 +1715,8 @@ func (r *LeaderRole) stepDown() {
 
 // Stop stops the leader
 func (r *LeaderRole) Stop() error {
+	close(r.stopped)
 	r.appender.stop()
 	r.stepDown()
-	return nil
+	return r.ActiveRole.Stop()
 }
@@ -16,12 +16,20 @@ package roles
 
 import (
 	"context"
+	"errors"
 	"github.com/atomix/go-framework/pkg/atomix/service"
+	"github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/protocol/mock"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store/log"
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"hash/crc32"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -45,6 +53,7 @@ func TestLeaderInit(t *testing.T) {
 	assert.NotNil(t, entry.Entry.GetInitialize())
 
 	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+	assert.Equal(t, 0, role.pendingCommands())
 }
 
 func TestLeaderInitStepDown(t *testing.T) {
@@ -58,6 +67,62 @@ func TestLeaderInitStepDown(t *testing.T) {
 	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
 }
 
+func TestLeaderInitTimeoutStepDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block append requests to prevent the leader's no-op entry from being committed
+	blocked := make(chan struct{})
+	defer close(blocked)
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if len(request.Entries) > 0 {
+				<-blocked
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	initializeTimeout := 200 * time.Millisecond
+	role.raft.Config().InitializeTimeout = &initializeTimeout
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
+
+	// Verify the leader yields leadership once the no-op entry fails to commit within the timeout
+	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(0), role.raft.CommitIndex())
+	assert.Nil(t, role.raft.Leader())
+	role.raft.ReadUnlock()
+}
+
+func TestLeaderInitTimeoutCommitted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	initializeTimeout := 200 * time.Millisecond
+	role.raft.Config().InitializeTimeout = &initializeTimeout
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify the leader retains leadership after the timeout once the no-op entry is committed
+	time.Sleep(2 * initializeTimeout)
+	role.raft.ReadLock()
+	assert.NotEqual(t, raft.RoleFollower, role.raft.Role())
+	assert.Equal(t, role.raft.Member(), *role.raft.Leader())
+	role.raft.ReadUnlock()
+}
+
 func TestLeaderCommitStepDown(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
@@ -72,7 +137,132 @@ func TestLeaderCommitStepDown(t *testing.T) {
 	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
 }
 
-func TestLeaderPoll(t *testing.T) {
+func TestLeaderCheckQuorum(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block append requests once the leader's no-op entry has been committed to partition the leader
+	var partitioned int32
+	blocked := make(chan struct{})
+	defer close(blocked)
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if atomic.LoadInt32(&partitioned) == 1 {
+				<-blocked
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	electionTimeout := 200 * time.Millisecond
+	role.raft.Config().ElectionTimeout = &electionTimeout
+	role.raft.Config().CheckQuorum = true
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify the leader retains leadership while it can reach a quorum
+	time.Sleep(2 * electionTimeout)
+	role.raft.ReadLock()
+	assert.Equal(t, role.raft.Member(), *role.raft.Leader())
+	role.raft.ReadUnlock()
+
+	// Verify the leader steps down once it fails to hear from a quorum within the election timeout
+	atomic.StoreInt32(&partitioned, 1)
+	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+	role.raft.ReadLock()
+	assert.Nil(t, role.raft.Leader())
+	role.raft.ReadUnlock()
+}
+
+func TestLeaderCheckQuorumSingleNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	role := newLeaderRole(newLargeTestState(client, 1, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	electionTimeout := 100 * time.Millisecond
+	role.raft.Config().ElectionTimeout = &electionTimeout
+	role.raft.Config().CheckQuorum = true
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify a single node leader never fails the quorum check
+	time.Sleep(3 * electionTimeout)
+	role.raft.ReadLock()
+	assert.NotEqual(t, raft.RoleFollower, role.raft.Role())
+	assert.Equal(t, role.raft.Member(), *role.raft.Leader())
+	role.raft.ReadUnlock()
+}
+
+func TestLeaderApplyStallStepDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	electionTimeout := 200 * time.Millisecond
+	heartbeatInterval := 50 * time.Millisecond
+	stallTimeout := 500 * time.Millisecond
+	config := &config.ProtocolConfig{
+		ElectionTimeout:   &electionTimeout,
+		HeartbeatInterval: &heartbeatInterval,
+		Apply: &config.ApplyConfig{
+			StallTimeout: &stallTimeout,
+		},
+		Priorities: map[string]int32{
+			"foo": 1,
+			"bar": 2,
+			"baz": 3,
+		},
+	}
+	rafts, states := newTestClusterStates(ctrl, config, "foo", "bar", "baz")
+
+	// Report applied indexes for each member, blocking the highest priority member's apply loop
+	stallCh := make(chan struct{})
+	for member, state := range states {
+		state.Watch(func(member raft.MemberID, r raft.Raft) func(raft.Index) {
+			return func(index raft.Index) {
+				if member == "baz" {
+					<-stallCh
+				}
+				r.SetAppliedIndex(index)
+			}
+		}(member, rafts[member]))
+	}
+
+	leaders := make(chan raft.MemberID, 10)
+	for member, r := range rafts {
+		r.Watch(func(member raft.MemberID) func(raft.Event) {
+			return func(event raft.Event) {
+				if event.Type == raft.EventTypeRole && event.Role == raft.RoleLeader {
+					leaders <- member
+				}
+			}
+		}(member))
+	}
+	for _, r := range rafts {
+		go r.Init()
+	}
+
+	// Verify the highest priority member is elected and steps down once its apply loop stalls
+	assert.Equal(t, raft.MemberID("baz"), <-leaders)
+	assert.Equal(t, raft.RoleFollower, awaitRole(rafts["baz"], raft.RoleFollower))
+
+	// Verify a healthy member takes over and the stalled member is not re-elected
+	assert.NotEqual(t, raft.MemberID("baz"), <-leaders)
+	select {
+	case member := <-leaders:
+		assert.Fail(t, "unexpected election", member)
+	case <-time.After(stallTimeout):
+	}
+	assert.Equal(t, raft.RoleFollower, rafts["baz"].Role())
+	close(stallCh)
+}
+
+func TestLeaderFollowerLag(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
 	succeedAppend(client).AnyTimes()
@@ -80,18 +270,94 @@ func TestLeaderPoll(t *testing.T) {
 	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
 	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
 	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
 
-	response, err := role.Poll(context.TODO(), &raft.PollRequest{
-		Term:         raft.Term(1),
-		Candidate:    role.raft.Members()[1],
-		LastLogIndex: 0,
-		LastLogTerm:  0,
-	})
-	assert.NoError(t, err)
-	assert.False(t, response.Accepted)
+	// Verify the leader reports the lag of each follower once the followers have caught up
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(role.raft.Metrics())
+	followers := make(map[string]float64)
+	for len(followers) < 2 {
+		families, err := registry.Gather()
+		assert.NoError(t, err)
+		for _, family := range families {
+			if family.GetName() == "raft_leader_follower_lag" {
+				for _, metric := range family.Metric {
+					for _, label := range metric.Label {
+						if label.GetName() == "follower" {
+							followers[label.GetValue()] = metric.Gauge.GetValue()
+						}
+					}
+				}
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, float64(0), followers["bar"])
+	assert.Equal(t, float64(0), followers["baz"])
 }
 
-func TestLeaderVote(t *testing.T) {
+// newZoneConfig assigns foo and bar to the local zone and baz to a remote zone
+func newZoneConfig(role *LeaderRole, localZoneWait time.Duration) {
+	role.raft.Config().Zones = map[string]string{
+		"foo": "local",
+		"bar": "local",
+		"baz": "remote",
+	}
+	role.raft.Config().ReadIndex = &config.ReadIndexConfig{
+		PreferLocalZone: true,
+		LocalZoneWait:   &localZoneWait,
+	}
+}
+
+func TestLeaderLocalZoneReadIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppendTo(client, "bar").AnyTimes()
+	remoteDelay := 500 * time.Millisecond
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("baz"))).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			time.Sleep(remoteDelay)
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	newZoneConfig(role, time.Minute)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify the read index is confirmed by the local zone quorum without waiting for the remote follower
+	start := time.Now()
+	assert.NoError(t, role.appender.heartbeat())
+	assert.True(t, time.Since(start) < remoteDelay)
+}
+
+func TestLeaderLocalZoneReadIndexFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("bar"))).
+		Return(nil, errors.New("AppendRequest failed")).
+		AnyTimes()
+	succeedAppendTo(client, "baz").AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	newZoneConfig(role, 50*time.Millisecond)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify the read index is confirmed by the remote follower once the local zone wait expires
+	assert.NoError(t, role.appender.heartbeat())
+}
+
+func TestLeaderHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
 	succeedAppend(client).AnyTimes()
@@ -99,217 +365,3101 @@ func TestLeaderVote(t *testing.T) {
 	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
 	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
 	assert.NoError(t, role.Start())
-	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
 
-	response, err := role.Vote(context.TODO(), &raft.VoteRequest{
-		Term:         raft.Term(2),
-		Candidate:    role.raft.Members()[1],
-		LastLogIndex: 0,
-		LastLogTerm:  0,
-	})
+	// Verify the leader hashes a committed range of its log
+	hash, err := role.hashLog(1, 1)
 	assert.NoError(t, err)
-	assert.False(t, response.Voted)
-	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
-	assert.Equal(t, raft.Term(2), awaitTerm(role.raft, raft.Term(2)))
+	response, err := role.Hash(context.TODO(), &raft.HashRequest{FirstIndex: 1, LastIndex: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Equal(t, hash, response.Hash)
 
-	role = newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	// Verify the leader rejects a range that has not been committed
+	response, err = role.Hash(context.TODO(), &raft.HashRequest{FirstIndex: 1, LastIndex: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+}
+
+func TestLeaderTruncationAlert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppendTo(client, "baz").AnyTimes()
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("bar"))).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+				Truncated:    10,
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.Config().TruncationAlertThreshold = 5
+	events := make(chan raft.Event, 100)
+	role.raft.Watch(func(event raft.Event) {
+		if event.Type == raft.EventTypeTruncation {
+			select {
+			case events <- event:
+			default:
+			}
+		}
+	})
 	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
 	assert.NoError(t, role.Start())
-	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
 
-	response, err = role.Vote(context.TODO(), &raft.VoteRequest{
-		Term:         raft.Term(2),
-		Candidate:    role.raft.Members()[1],
+	// Verify a truncation above the threshold emits an event identifying the follower
+	event := <-events
+	assert.Equal(t, raft.MemberID("bar"), event.Member)
+	assert.Equal(t, uint64(10), event.Truncated)
+}
+
+// startTestAppender processes commits for the given leader without sending append requests to its members,
+// returning a function that waits for pending commits to be processed and returns the commit index
+func startTestAppender(role *LeaderRole) func() raft.Index {
+	appender := role.appender
+	go appender.processCommits()
+	var member *memberAppender
+	for _, member = range appender.members {
+		member.active = true
+		go func(ch chan bool) {
+			for range ch {
+			}
+		}(member.appendCh)
+	}
+	return func() raft.Index {
+		appender.commitCh <- memberCommit{member: member}
+		role.raft.ReadLock()
+		defer role.raft.ReadUnlock()
+		return role.raft.CommitIndex()
+	}
+}
+
+func TestLeaderQuorumIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	role := newLeaderRole(newTestState(mock.NewMockClient(ctrl))).(*LeaderRole)
+
+	// Append entries from a prior term to the leader's log
+	newEntry := func(term raft.Term) *raft.LogEntry {
+		return &raft.LogEntry{
+			Term:      term,
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+	}
+	entries := []*raft.LogEntry{newEntry(1), newEntry(1), newEntry(1)}
+	for _, entry := range entries {
+		role.store.Writer().Append(entry)
+	}
+	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
+	syncCommits := startTestAppender(role)
+
+	// Replicating entries from a prior term to a quorum advances the quorum index but not the commit index
+	bar := role.appender.members["bar"]
+	bar.handleAppendResponse(&raft.AppendRequest{Term: 2, Leader: "foo", Entries: entries}, &raft.AppendResponse{
+		Status:       raft.ResponseStatus_OK,
+		Term:         2,
+		Succeeded:    true,
+		LastLogIndex: 3,
+		LastLogTerm:  1,
+	}, time.Now())
+	assert.Equal(t, raft.Index(0), syncCommits())
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(3), role.raft.QuorumIndex())
+	role.raft.ReadUnlock()
+
+	// Once the leader's no-op entry is replicated to a quorum, all prior entries are committed
+	noop := newEntry(2)
+	role.appender.register(role.store.Writer().Append(noop), nil)
+	bar.handleAppendResponse(&raft.AppendRequest{Term: 2, Leader: "foo", PrevLogIndex: 3, PrevLogTerm: 1, Entries: []*raft.LogEntry{noop}}, &raft.AppendResponse{
+		Status:       raft.ResponseStatus_OK,
+		Term:         2,
+		Succeeded:    true,
+		LastLogIndex: 4,
+		LastLogTerm:  2,
+	}, time.Now())
+	assert.Equal(t, raft.Index(4), syncCommits())
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(4), role.raft.QuorumIndex())
+	role.raft.ReadUnlock()
+}
+
+func TestLeaderDivergentFollower(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	role := newLeaderRole(newTestMembersState(mock.NewMockClient(ctrl), "foo", "bar", "baz", "qux", "quux")).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+
+	entries := make([]*raft.LogEntry, 3)
+	for i := range entries {
+		entries[i] = &raft.LogEntry{
+			Term:      raft.Term(1),
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+		role.appender.register(role.store.Writer().Append(entries[i]), nil)
+	}
+
+	appender := role.appender
+	syncCommits := startTestAppender(role)
+
+	request := &raft.AppendRequest{
+		Term:    raft.Term(1),
+		Leader:  "foo",
+		Entries: entries,
+	}
+	succeeded := func(index raft.Index, term raft.Term) *raft.AppendResponse {
+		return &raft.AppendResponse{
+			Status:       raft.ResponseStatus_OK,
+			Term:         raft.Term(1),
+			Succeeded:    true,
+			LastLogIndex: index,
+			LastLogTerm:  term,
+		}
+	}
+
+	// A single follower acknowledging the entries is not a quorum
+	bar := appender.members["bar"]
+	bar.handleAppendResponse(request, succeeded(3, 1), time.Now())
+	assert.Equal(t, raft.Index(3), bar.matchIndex)
+	assert.Equal(t, raft.Index(0), syncCommits())
+
+	// The follower rejoins with a divergent entry at its last acknowledged index
+	heartbeat := &raft.AppendRequest{
+		Term:         raft.Term(1),
+		Leader:       "foo",
+		PrevLogIndex: 3,
+	}
+	bar.handleAppendResponse(heartbeat, succeeded(3, 2), time.Now())
+	assert.Equal(t, raft.Index(0), bar.matchIndex)
+	assert.Equal(t, raft.Index(3), bar.nextIndex)
+
+	// The divergent follower's stale acknowledgement must not count towards a quorum
+	appender.members["baz"].handleAppendResponse(request, succeeded(3, 1), time.Now())
+	assert.Equal(t, raft.Index(0), syncCommits())
+
+	// Once the follower's log is repaired the entries can be committed
+	bar.handleAppendResponse(request, succeeded(3, 1), time.Now())
+	assert.Equal(t, raft.Index(3), syncCommits())
+
+	// A follower reporting a last index below its match index is reset as well
+	qux := appender.members["qux"]
+	qux.handleAppendResponse(request, succeeded(3, 1), time.Now())
+	qux.handleAppendResponse(&raft.AppendRequest{Term: raft.Term(1), Leader: "foo", PrevLogIndex: 3, PrevLogTerm: 1}, &raft.AppendResponse{
+		Status:       raft.ResponseStatus_OK,
+		Term:         raft.Term(1),
+		Succeeded:    false,
 		LastLogIndex: 1,
 		LastLogTerm:  1,
-	})
+	}, time.Now())
+	assert.Equal(t, raft.Index(1), qux.matchIndex)
+	syncCommits()
+	assert.Equal(t, raft.Index(1), appender.commitIndexes["qux"])
+}
+
+func TestLeaderTransferTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	transferTimeout := 100 * time.Millisecond
+	role.raft.Config().TransferTimeout = &transferTimeout
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify the leader is quiet once the transfer request has been sent to the target
+	client.EXPECT().
+		Transfer(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("bar"))).
+		DoAndReturn(func(ctx context.Context, request *raft.TransferRequest, member raft.MemberID) (*raft.TransferResponse, error) {
+			ch := make(chan *raft.CommandStreamResponse, 1)
+			assert.NoError(t, role.Command(&raft.CommandRequest{}, ch))
+			response := <-ch
+			assert.Equal(t, raft.ResponseStatus_ERROR, response.Response.Status)
+			assert.Equal(t, raft.ResponseError_ILLEGAL_MEMBER_STATE, response.Response.Error)
+
+			role.appender.failTime(time.Now().Add(time.Hour))
+			assert.Equal(t, raft.MemberID("foo"), *role.raft.Leader())
+			return &raft.TransferResponse{
+				Status: raft.ResponseStatus_OK,
+			}, nil
+		}).Times(2)
+
+	// Verify a second transfer cannot be started while a transfer is in progress
+	transferCh := make(chan *raft.TransferResponse)
+	go func() {
+		response, err := role.Transfer(context.TODO(), &raft.TransferRequest{Member: "bar"})
+		assert.NoError(t, err)
+		transferCh <- response
+	}()
+	for {
+		role.raft.ReadLock()
+		transferring := role.transferring
+		role.raft.ReadUnlock()
+		if transferring != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	response, err := role.Transfer(context.TODO(), &raft.TransferRequest{Member: "baz"})
 	assert.NoError(t, err)
-	assert.True(t, response.Voted)
-	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
-	assert.Equal(t, raft.Term(2), awaitTerm(role.raft, raft.Term(2)))
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+
+	// Verify the transfer is aborted and the leader reverts to normal if the target does not take over
+	response = <-transferCh
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.MemberID("foo"), *role.raft.Leader())
+	role.raft.ReadLock()
+	assert.Nil(t, role.transferring)
+	role.raft.ReadUnlock()
+	assert.False(t, role.appender.quiet)
+
+	// Verify a new transfer is sent to the target once the prior transfer has been aborted
+	response, err = role.Transfer(context.TODO(), &raft.TransferRequest{Member: "bar"})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+}
+
+func TestLeaderTransferCatchUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppendTo(client, "baz").AnyTimes()
+
+	// Fail appends to the target until it's released to simulate a target that has fallen behind
+	var caughtUp int32
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("bar"))).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if atomic.LoadInt32(&caughtUp) == 0 {
+				return nil, errors.New("unavailable")
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	transferTimeout := 200 * time.Millisecond
+	role.raft.Config().TransferTimeout = &transferTimeout
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify the transfer is aborted without triggering an election if the target does not catch up.
+	// A transfer request sent to the target fails the test.
+	response, err := role.Transfer(context.TODO(), &raft.TransferRequest{Member: "bar"})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	role.raft.ReadLock()
+	assert.Nil(t, role.transferring)
+	role.raft.ReadUnlock()
+	assert.False(t, role.appender.quiet)
+
+	// Verify the election is triggered once the target has caught up with the leader's log
+	client.EXPECT().
+		Transfer(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("bar"))).
+		DoAndReturn(func(ctx context.Context, request *raft.TransferRequest, member raft.MemberID) (*raft.TransferResponse, error) {
+			assert.Equal(t, raft.Index(1), request.LastLogIndex)
+			assert.True(t, role.appender.matchIndex("bar") >= request.LastLogIndex)
+			return &raft.TransferResponse{
+				Status: raft.ResponseStatus_ERROR,
+				Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+			}, nil
+		})
+	atomic.StoreInt32(&caughtUp, 1)
+	response, err = role.Transfer(context.TODO(), &raft.TransferRequest{Member: "bar"})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	role.raft.ReadLock()
+	assert.Nil(t, role.transferring)
+	role.raft.ReadUnlock()
+}
+
+func TestLeaderStaleAppendResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	role := newLeaderRole(newTestState(mock.NewMockClient(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+
+	entries := make([]*raft.LogEntry, 5)
+	for i := range entries {
+		entries[i] = &raft.LogEntry{
+			Term:      raft.Term(1),
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+		role.store.Writer().Append(entries[i])
+	}
+
+	startTestAppender(role)
+	bar := role.appender.members["bar"]
+	newRequest := func(prevIndex raft.Index, lastIndex raft.Index) *raft.AppendRequest {
+		return &raft.AppendRequest{
+			Term:         raft.Term(1),
+			Leader:       "foo",
+			PrevLogIndex: prevIndex,
+			Entries:      entries[prevIndex:lastIndex],
+		}
+	}
+	newResponse := func(succeeded bool, index raft.Index) *raft.AppendResponse {
+		return &raft.AppendResponse{
+			Status:       raft.ResponseStatus_OK,
+			Term:         raft.Term(1),
+			Succeeded:    succeeded,
+			LastLogIndex: index,
+			LastLogTerm:  raft.Term(1),
+		}
+	}
+
+	// Replicate the first three entries to the follower
+	first := newRequest(0, 3)
+	bar.handleAppendResponse(first, newResponse(true, 3), time.Now())
+	assert.Equal(t, raft.Index(3), bar.matchIndex)
+	assert.Equal(t, raft.Index(4), bar.nextIndex)
+
+	// Verify a late rejection of a retried request does not rewind the next index
+	bar.handleAppendResponse(first, newResponse(false, 0), time.Now())
+	assert.Equal(t, raft.Index(3), bar.matchIndex)
+	assert.Equal(t, raft.Index(4), bar.nextIndex)
+
+	// Verify a late acknowledgement of fewer entries does not reset the follower's progress
+	bar.handleAppendResponse(newRequest(0, 1), newResponse(true, 1), time.Now())
+	assert.Equal(t, raft.Index(3), bar.matchIndex)
+	assert.Equal(t, raft.Index(4), bar.nextIndex)
+
+	// Verify a late acknowledgement of more entries still advances the follower's progress
+	bar.handleAppendResponse(newRequest(1, 5), newResponse(true, 5), time.Now())
+	assert.Equal(t, raft.Index(5), bar.matchIndex)
+	assert.Equal(t, raft.Index(6), bar.nextIndex)
+	assert.Equal(t, raft.Term(1), bar.prevTerm)
+
+	// Verify responses to current requests are still used to rewind the next index
+	bar.handleAppendResponse(newRequest(5, 5), newResponse(false, 4), time.Now())
+	assert.Equal(t, raft.Index(4), bar.matchIndex)
+	assert.Equal(t, raft.Index(5), bar.nextIndex)
+}
+
+func TestLeaderPartialAppend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	role := newLeaderRole(newTestMembersState(mock.NewMockClient(ctrl), "foo", "bar", "baz")).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
+
+	entries := make([]*raft.LogEntry, 4)
+	for i := range entries {
+		entries[i] = &raft.LogEntry{
+			Term:      raft.Term(i/2 + 1),
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+		role.store.Writer().Append(entries[i])
+	}
+
+	syncCommits := startTestAppender(role)
+	bar := role.appender.members["bar"]
+	request := &raft.AppendRequest{
+		Term:    raft.Term(2),
+		Leader:  "foo",
+		Entries: entries,
+	}
+
+	// Verify a partially persisted batch only advances the match index to the last persisted entry
+	bar.handleAppendResponse(request, &raft.AppendResponse{
+		Status:       raft.ResponseStatus_OK,
+		Term:         raft.Term(2),
+		Succeeded:    true,
+		LastLogIndex: 3,
+		LastLogTerm:  raft.Term(2),
+	}, time.Now())
+	assert.Equal(t, raft.Index(3), bar.matchIndex)
+	assert.Equal(t, raft.Index(4), bar.nextIndex)
+	assert.Equal(t, raft.Term(2), bar.prevTerm)
+	syncCommits()
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(3), role.raft.QuorumIndex())
+	role.raft.ReadUnlock()
+
+	// Verify the next request resumes from the first entry that was not persisted
+	next := bar.nextAppendRequest()
+	assert.Equal(t, raft.Index(3), next.PrevLogIndex)
+	assert.Equal(t, raft.Term(2), next.PrevLogTerm)
+	assert.Len(t, next.Entries, 1)
+}
+
+func TestLeaderPipelinedRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	role := newLeaderRole(newTestMembersState(mock.NewMockClient(ctrl), "foo", "bar", "baz")).(*LeaderRole)
+	role.raft.Config().MaxAppendBatchSize = 2
+	role.raft.Config().MaxInflightAppends = 4
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+
+	entries := make([]*raft.LogEntry, 7)
+	for i := range entries {
+		entries[i] = &raft.LogEntry{
+			Term:      raft.Term(1),
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+		role.store.Writer().Append(entries[i])
+	}
+
+	startTestAppender(role)
+	bar := role.appender.members["bar"]
+	response := func(succeeded bool, index raft.Index) *raft.AppendResponse {
+		return &raft.AppendResponse{
+			Status:       raft.ResponseStatus_OK,
+			Term:         raft.Term(1),
+			Succeeded:    succeeded,
+			LastLogIndex: index,
+			LastLogTerm:  raft.Term(1),
+		}
+	}
+
+	// Verify requests are not pipelined while the leader is probing for the follower's next index
+	bar.handleAppendResponse(&raft.AppendRequest{Term: raft.Term(1), Leader: "foo"}, response(false, 5), time.Now())
+	assert.Nil(t, bar.nextPipelinedRequest())
+	bar.handleAppendResponse(&raft.AppendRequest{Term: raft.Term(1), Leader: "foo", PrevLogIndex: 5, PrevLogTerm: 1}, response(false, 1), time.Now())
+	assert.Nil(t, bar.nextPipelinedRequest())
+	bar.handleAppendResponse(&raft.AppendRequest{Term: raft.Term(1), Leader: "foo", PrevLogIndex: 1, PrevLogTerm: 1}, response(true, 1), time.Now())
+
+	// Verify batches are limited to the configured number of entries and pipelined after the last entry sent
+	first := bar.nextAppendRequest()
+	assert.Equal(t, raft.Index(1), first.PrevLogIndex)
+	assert.Len(t, first.Entries, 2)
+	second := bar.nextPipelinedRequest()
+	assert.Equal(t, raft.Index(3), second.PrevLogIndex)
+	assert.Equal(t, raft.Term(1), second.PrevLogTerm)
+	assert.Len(t, second.Entries, 2)
+	third := bar.nextPipelinedRequest()
+	assert.Equal(t, raft.Index(5), third.PrevLogIndex)
+	assert.Len(t, third.Entries, 2)
+	assert.Nil(t, bar.nextPipelinedRequest())
+
+	// Verify responses received out of order advance the follower's progress without rewinding it
+	bar.handleAppendResponse(second, response(true, 5), time.Now())
+	assert.Equal(t, raft.Index(5), bar.matchIndex)
+	assert.Equal(t, raft.Index(6), bar.nextIndex)
+	bar.handleAppendResponse(first, response(true, 3), time.Now())
+	assert.Equal(t, raft.Index(5), bar.matchIndex)
+	assert.Equal(t, raft.Index(6), bar.nextIndex)
+
+	// Verify a pipelined request rejected because it was received out of order is resent from the next index
+	bar.handleAppendResponse(third, response(false, 5), time.Now())
+	assert.Equal(t, raft.Index(5), bar.matchIndex)
+	assert.Equal(t, raft.Index(6), bar.nextIndex)
+	resent := bar.nextPipelinedRequest()
+	assert.Equal(t, raft.Index(5), resent.PrevLogIndex)
+	assert.Len(t, resent.Entries, 2)
+	bar.handleAppendResponse(resent, response(true, 7), time.Now())
+	assert.Equal(t, raft.Index(7), bar.matchIndex)
+	assert.Equal(t, raft.Index(8), bar.nextIndex)
+	assert.Nil(t, bar.nextPipelinedRequest())
+}
+
+func TestLeaderAppendPipelining(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppendTo(client, "baz").AnyTimes()
+
+	// Simulate a follower that rejects entries received out of order, blocking requests
+	// containing entries until released to allow requests to accumulate in flight
+	release := make(chan struct{})
+	var gated int32
+	var mu sync.Mutex
+	var lastIndex raft.Index
+	var inflight, maxInflight int32
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("bar"))).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			assert.True(t, len(request.Entries) <= 2)
+			if len(request.Entries) > 0 && atomic.LoadInt32(&gated) == 1 {
+				count := atomic.AddInt32(&inflight, 1)
+				defer atomic.AddInt32(&inflight, -1)
+				for {
+					max := atomic.LoadInt32(&maxInflight)
+					if count <= max || atomic.CompareAndSwapInt32(&maxInflight, max, count) {
+						break
+					}
+				}
+				<-release
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if request.PrevLogIndex > lastIndex {
+				return &raft.AppendResponse{
+					Status:       raft.ResponseStatus_OK,
+					Term:         request.Term,
+					Succeeded:    false,
+					LastLogIndex: lastIndex,
+				}, nil
+			}
+			if index := request.PrevLogIndex + raft.Index(len(request.Entries)); index > lastIndex {
+				lastIndex = index
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: lastIndex,
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.Config().MaxAppendBatchSize = 2
+	role.raft.Config().MaxInflightAppends = 3
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+	for role.appender.matchIndex("bar") < raft.Index(1) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Append entries while the follower is slow
+	atomic.StoreInt32(&gated, 1)
+	for i := 0; i < 20; i++ {
+		role.raft.WriteLock()
+		indexed := role.store.Writer().Append(&raft.LogEntry{
+			Term:      role.raft.Term(),
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		})
+		ch := role.appender.register(indexed, nil)
+		role.raft.WriteUnlock()
+		go func() {
+			_ = role.appender.commit(indexed, ch)
+		}()
+	}
+
+	// Verify multiple requests are sent to the follower without awaiting responses, up to the configured limit
+	for atomic.LoadInt32(&inflight) < 3 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&maxInflight))
+
+	// Verify the follower catches up with the leader's log once the requests complete
+	close(release)
+	for role.appender.matchIndex("bar") < raft.Index(21) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	assert.Equal(t, raft.Index(21), lastIndex)
+	mu.Unlock()
+}
+
+func TestLeaderClockSkew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	clocks := map[raft.MemberID]*testClock{
+		"bar": {},
+		"baz": {},
+	}
+	timestamps := make(chan time.Time, 10)
+	client.EXPECT().Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			timestamps <- request.Timestamp
+			response := &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex,
+			}
+			if !request.Timestamp.IsZero() {
+				response.Timestamp = clocks[member].Now()
+			}
+			return response, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client)).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	startTestAppender(role)
+	heartbeat := func(member raft.MemberID) {
+		appender := role.appender.members[member]
+		appender.sendAppendRequest(appender.nextAppendRequest())
+	}
+
+	// Verify clocks are not exchanged if no drift bound is configured
+	clocks["bar"].setOffset(time.Hour)
+	heartbeat("bar")
+	assert.True(t, (<-timestamps).IsZero())
+	assert.False(t, role.appender.isClockSkewed())
+
+	// Verify clocks within the drift bound are not considered skewed
+	maxDrift := time.Second
+	role.raft.Config().MaxClockDrift = &maxDrift
+	clocks["bar"].setOffset(0)
+	heartbeat("bar")
+	heartbeat("baz")
+	assert.False(t, (<-timestamps).IsZero())
+	assert.False(t, (<-timestamps).IsZero())
+	assert.False(t, role.appender.isClockSkewed())
+
+	// Verify a clock running ahead of the leader's clock is detected
+	clocks["bar"].setOffset(time.Minute)
+	heartbeat("bar")
+	<-timestamps
+	assert.True(t, role.appender.isClockSkewed())
+
+	// Verify the skew is cleared once the follower's clock is corrected
+	clocks["bar"].setOffset(0)
+	heartbeat("bar")
+	<-timestamps
+	assert.False(t, role.appender.isClockSkewed())
+
+	// Verify a clock running behind the leader's clock is detected
+	clocks["baz"].setOffset(-time.Minute)
+	heartbeat("baz")
+	<-timestamps
+	assert.True(t, role.appender.isClockSkewed())
+
+	// Verify a skewed leader clock is detected
+	clocks["baz"].setOffset(0)
+	role.raft.SetClock(&testClock{offset: time.Minute})
+	heartbeat("baz")
+	<-timestamps
+	assert.True(t, role.appender.isClockSkewed())
+}
+
+func TestLeaderLeaseHeartbeatRatio(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	role := newLeaderRole(newTestState(client)).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	expire := func(member raft.MemberID) {
+		role.appender.mu.Lock()
+		role.appender.commitTimes[member] = time.Now().Add(-time.Hour)
+		role.appender.mu.Unlock()
+	}
+
+	// Verify the lease is valid without heartbeats if no ratio is configured
+	assert.True(t, role.appender.isLeaseValid())
+
+	// Verify the lease is invalid until all followers have responded if the ratio is 1
+	role.raft.Config().LeaseHeartbeatRatio = 1
+	assert.False(t, role.appender.isLeaseValid())
+	role.appender.commitMemberTime("bar", time.Now())
+	assert.False(t, role.appender.isLeaseValid())
+	role.appender.commitMemberTime("baz", time.Now())
+	assert.True(t, role.appender.isLeaseValid())
+
+	// Verify the lease is invalidated once a follower has not responded within the election timeout
+	expire("baz")
+	assert.False(t, role.appender.isLeaseValid())
+
+	// Verify a lower ratio tolerates followers that have not recently responded
+	role.raft.Config().LeaseHeartbeatRatio = .5
+	assert.True(t, role.appender.isLeaseValid())
+	expire("bar")
+	assert.False(t, role.appender.isLeaseValid())
+}
+
+func TestLeaderReadOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	electionTimeout := 200 * time.Millisecond
+	heartbeatInterval := 50 * time.Millisecond
+	config := &config.ProtocolConfig{
+		ElectionTimeout:   &electionTimeout,
+		HeartbeatInterval: &heartbeatInterval,
+		Priorities: map[string]int32{
+			"foo": 1,
+		},
+	}
+	rafts := newTestCluster(ctrl, config, "foo", "bar", "baz")
+	for _, r := range rafts {
+		go r.Init()
+	}
+	leader := raft.MemberID("foo")
+	assert.Equal(t, raft.RoleLeader, awaitRole(rafts[leader], raft.RoleLeader))
+	assert.Equal(t, &leader, awaitLeader(rafts["bar"], &leader))
+	assert.Equal(t, &leader, awaitLeader(rafts["baz"], &leader))
+
+	// Open a session with which to read from the cluster
+	commandCh := make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, rafts[leader].Command(&raft.CommandRequest{Value: newOpenSessionRequest()}, commandCh))
+	commandResponse := <-commandCh
+	assert.Equal(t, raft.ResponseStatus_OK, commandResponse.Response.Status)
+	sessionID := getSessionID(commandResponse.Response.Output)
+
+	// Verify the read-only mode is rejected by followers
+	response, err := rafts["bar"].SetReadOnly(context.TODO(), &raft.SetReadOnlyRequest{ReadOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+
+	// Verify the read-only mode is replicated to all members
+	response, err = rafts[leader].SetReadOnly(context.TODO(), &raft.SetReadOnlyRequest{ReadOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	for _, r := range rafts {
+		awaitCommit(r, response.Index)
+		r.ReadLock()
+		assert.True(t, r.IsReadOnly())
+		r.ReadUnlock()
+	}
+
+	// Verify writes are rejected by all members while reads are still served
+	for member, r := range rafts {
+		commandCh := make(chan *raft.CommandStreamResponse, 1)
+		assert.NoError(t, r.Command(&raft.CommandRequest{Value: newSetRequest("Set", sessionID, 1)}, commandCh))
+		commandResponse := <-commandCh
+		assert.Equal(t, raft.ResponseStatus_ERROR, commandResponse.Response.Status)
+		if member == leader {
+			assert.Equal(t, raft.ResponseError_READ_ONLY, commandResponse.Response.Error)
+		}
+
+		queryCh := make(chan *raft.QueryStreamResponse, 1)
+		query := &raft.QueryRequest{
+			Value:           newGetRequest("Get", sessionID, 0),
+			ReadConsistency: raft.ReadConsistency_LINEARIZABLE,
+		}
+		assert.NoError(t, r.Query(query, queryCh))
+		queryResponse := <-queryCh
+		assert.True(t, queryResponse.Succeeded())
+		assert.Equal(t, raft.ResponseStatus_OK, queryResponse.Response.Status)
+	}
+
+	// Verify the read-only mode can be disabled
+	response, err = rafts[leader].SetReadOnly(context.TODO(), &raft.SetReadOnlyRequest{ReadOnly: false})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	for _, r := range rafts {
+		awaitCommit(r, response.Index)
+		r.ReadLock()
+		assert.False(t, r.IsReadOnly())
+		r.ReadUnlock()
+	}
+}
+
+func TestLeaderFreezeElections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	electionTimeout := 200 * time.Millisecond
+	heartbeatInterval := 50 * time.Millisecond
+	maxElectionFreeze := time.Minute
+	config := &config.ProtocolConfig{
+		ElectionTimeout:   &electionTimeout,
+		HeartbeatInterval: &heartbeatInterval,
+		MaxElectionFreeze: &maxElectionFreeze,
+		Priorities: map[string]int32{
+			"foo": 1,
+		},
+	}
+	rafts := newTestCluster(ctrl, config, "foo", "bar", "baz")
+	for _, r := range rafts {
+		go r.Init()
+	}
+	leader := raft.MemberID("foo")
+	assert.Equal(t, raft.RoleLeader, awaitRole(rafts[leader], raft.RoleLeader))
+	assert.Equal(t, &leader, awaitLeader(rafts["bar"], &leader))
+	assert.Equal(t, &leader, awaitLeader(rafts["baz"], &leader))
+
+	// Verify election freezes are rejected by followers
+	response, err := rafts["bar"].FreezeElections(context.TODO(), &raft.FreezeElectionsRequest{Duration: time.Minute})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+
+	// Verify the freeze is clamped to the maximum duration and replicated to all members
+	start := time.Now()
+	response, err = rafts[leader].FreezeElections(context.TODO(), &raft.FreezeElectionsRequest{Duration: time.Hour})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.False(t, response.Expires.After(time.Now().Add(maxElectionFreeze)))
+	assert.True(t, response.Expires.After(start.Add(maxElectionFreeze/2)))
+	for _, r := range rafts {
+		awaitCommit(r, response.Index)
+		r.ReadLock()
+		assert.True(t, r.ElectionsFrozen())
+		r.ReadUnlock()
+	}
+
+	// Verify the freeze can be lifted early
+	response, err = rafts[leader].FreezeElections(context.TODO(), &raft.FreezeElectionsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	for _, r := range rafts {
+		awaitCommit(r, response.Index)
+		r.ReadLock()
+		assert.False(t, r.ElectionsFrozen())
+		r.ReadUnlock()
+	}
+}
+
+func TestLeaderReconfigureTiming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	electionTimeout := 200 * time.Millisecond
+	heartbeatInterval := 50 * time.Millisecond
+	config := &config.ProtocolConfig{
+		ElectionTimeout:   &electionTimeout,
+		HeartbeatInterval: &heartbeatInterval,
+		Priorities: map[string]int32{
+			"foo": 1,
+		},
+	}
+	rafts := newTestCluster(ctrl, config, "foo", "bar", "baz")
+	for _, r := range rafts {
+		go r.Init()
+	}
+	leader := raft.MemberID("foo")
+	assert.Equal(t, raft.RoleLeader, awaitRole(rafts[leader], raft.RoleLeader))
+	assert.Equal(t, &leader, awaitLeader(rafts["bar"], &leader))
+	assert.Equal(t, &leader, awaitLeader(rafts["baz"], &leader))
+
+	// Verify timing changes are rejected by followers
+	newElectionTimeout := 400 * time.Millisecond
+	response, err := rafts["bar"].Reconfigure(context.TODO(), &raft.ReconfigureRequest{ElectionTimeout: &newElectionTimeout})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+
+	// Verify an election timeout that does not exceed the heartbeat interval is rejected
+	invalidElectionTimeout := heartbeatInterval
+	response, err = rafts[leader].Reconfigure(context.TODO(), &raft.ReconfigureRequest{ElectionTimeout: &invalidElectionTimeout})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_ERROR, response.Error)
+
+	// Verify a request cannot change both a member and the timing
+	response, err = rafts[leader].Reconfigure(context.TODO(), &raft.ReconfigureRequest{
+		Member:          &raft.Member{MemberID: "bar", Type: raft.Member_ACTIVE},
+		ElectionTimeout: &newElectionTimeout,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_PROTOCOL_ERROR, response.Error)
+
+	// Verify the timing change is replicated and applied by all members
+	response, err = rafts[leader].Reconfigure(context.TODO(), &raft.ReconfigureRequest{ElectionTimeout: &newElectionTimeout})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	for _, r := range rafts {
+		awaitCommit(r, response.Index)
+		assert.Equal(t, newElectionTimeout, r.ConfiguredElectionTimeout())
+		assert.Equal(t, heartbeatInterval, r.HeartbeatInterval())
+	}
+
+	// Verify the committed timing does not modify the shared configuration
+	assert.Equal(t, electionTimeout, config.GetElectionTimeoutOrDefault())
+}
+
+func TestLeaderReconfigure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block the replication of configuration changes until released
+	release := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			for _, entry := range request.Entries {
+				if entry.GetConfiguration() != nil {
+					<-release
+				}
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	role.raft.ReadLock()
+	configuration, pending := role.raft.Configuration()
+	role.raft.ReadUnlock()
+	assert.Len(t, configuration.Members, 3)
+	assert.Nil(t, pending)
+
+	responseCh := make(chan *raft.ReconfigureResponse, 1)
+	go func() {
+		response, err := role.Reconfigure(context.TODO(), &raft.ReconfigureRequest{
+			Member: &raft.Member{
+				MemberID: "bar",
+				Type:     raft.Member_PASSIVE,
+			},
+		})
+		assert.NoError(t, err)
+		responseCh <- response
+	}()
+
+	// Verify both the committed and pending configurations are reported while the change is uncommitted
+	awaitIndex(role.raft, role.store.Log(), raft.Index(2))
+	role.raft.ReadLock()
+	configuration, pending = role.raft.Configuration()
+	role.raft.ReadUnlock()
+	assert.Equal(t, raft.Index(0), configuration.Index)
+	for _, member := range configuration.Members {
+		assert.Equal(t, raft.Member_ACTIVE, member.Type)
+	}
+	assert.NotNil(t, pending)
+	assert.Equal(t, raft.Index(2), pending.Index)
+	assert.Len(t, pending.Members, 3)
+
+	// Verify only the new configuration remains once committed
+	close(release)
+	response := <-responseCh
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Equal(t, raft.Index(2), response.Index)
+	role.raft.ReadLock()
+	configuration, pending = role.raft.Configuration()
+	role.raft.ReadUnlock()
+	assert.Nil(t, pending)
+	assert.Equal(t, raft.Index(2), configuration.Index)
+	for _, member := range configuration.Members {
+		if member.MemberID == "bar" {
+			assert.Equal(t, raft.Member_PASSIVE, member.Type)
+		} else {
+			assert.Equal(t, raft.Member_ACTIVE, member.Type)
+		}
+	}
+}
+
+func TestLeaderJointReconfigure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block the replication of configuration changes until released
+	release := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			for _, entry := range request.Entries {
+				if entry.GetConfiguration() != nil {
+					<-release
+				}
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.Config().JointConsensus = true
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	responseCh := make(chan *raft.ReconfigureResponse, 1)
+	go func() {
+		response, err := role.Reconfigure(context.TODO(), &raft.ReconfigureRequest{
+			Member: &raft.Member{
+				MemberID: "bar",
+				Type:     raft.Member_PASSIVE,
+			},
+		})
+		assert.NoError(t, err)
+		responseCh <- response
+	}()
+
+	// Verify the joint configuration is appended first and requires majorities of both configurations
+	awaitIndex(role.raft, role.store.Log(), raft.Index(2))
+	role.raft.ReadLock()
+	_, pending := role.raft.Configuration()
+	assert.NotNil(t, pending)
+	assert.Equal(t, raft.Index(2), pending.Index)
+	assert.Len(t, pending.OldMembers, 3)
+	assert.True(t, role.raft.IsVoter("bar"))
+	assert.Len(t, role.raft.VoterSets(), 2)
+	role.raft.ReadUnlock()
+
+	// Verify the new configuration is committed once the joint configuration is committed
+	close(release)
+	response := <-responseCh
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Equal(t, raft.Index(3), response.Index)
+	role.raft.ReadLock()
+	configuration, pending := role.raft.Configuration()
+	assert.Nil(t, pending)
+	assert.Equal(t, raft.Index(3), configuration.Index)
+	assert.Len(t, configuration.OldMembers, 0)
+	assert.False(t, role.raft.IsVoter("bar"))
+	assert.Len(t, role.raft.VoterSets(), 1)
+	role.raft.ReadUnlock()
+}
+
+func TestLeaderWitnessAppend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Record the commands sent to each member
+	commands := make(chan *raft.LogEntry, 10)
+	witnessEntries := make(chan *raft.LogEntry, 10)
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			for _, entry := range request.Entries {
+				if member == "bar" {
+					if entry.GetInitialize() == nil {
+						witnessEntries <- entry
+					}
+				} else if entry.GetCommand() != nil {
+					commands <- entry
+				}
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	setWitnesses(role.raft, "bar")
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	go func() {
+		_ = role.Command(&raft.CommandRequest{Value: newOpenSessionRequest()}, make(chan *raft.CommandStreamResponse, 1))
+	}()
+
+	// Verify the command is sent to the data member and only its metadata is sent to the witness
+	command := <-commands
+	assert.NotNil(t, command.GetCommand())
+	entry := <-witnessEntries
+	assert.Nil(t, entry.Entry)
+	assert.Equal(t, command.Term, entry.Term)
+	assert.Equal(t, raft.Index(2), awaitCommit(role.raft, raft.Index(2)))
+}
+
+func TestLeaderStablePromotion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block the replication of configuration changes while blocking is enabled and fail
+	// requests to bar while bar is down
+	var blocking, barDown int32
+	release := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if member == "bar" && atomic.LoadInt32(&barDown) == 1 {
+				return nil, errors.New("unavailable")
+			}
+			for _, entry := range request.Entries {
+				if entry.GetConfiguration() != nil && atomic.LoadInt32(&blocking) == 1 {
+					<-release
+				}
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.Config().PromotionPolicy = config.PromotionPolicy_STABLE
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	reconfigure := func(memberType raft.Member_Type) *raft.ReconfigureResponse {
+		response, err := role.Reconfigure(context.TODO(), &raft.ReconfigureRequest{
+			Member: &raft.Member{
+				MemberID: "baz",
+				Type:     memberType,
+			},
+		})
+		assert.NoError(t, err)
+		return response
+	}
+
+	// Demote baz to a learner
+	response := reconfigure(raft.Member_PROMOTABLE)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+
+	// Verify the learner is not promoted while another configuration change is pending
+	atomic.StoreInt32(&blocking, 1)
+	responseCh := make(chan *raft.ReconfigureResponse, 1)
+	go func() {
+		responseCh <- reconfigure(raft.Member_PROMOTABLE)
+	}()
+	awaitIndex(role.raft, role.store.Log(), raft.Index(3))
+	response = reconfigure(raft.Member_ACTIVE)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_IN_PROGRESS, response.Error)
+
+	// Verify the learner is promoted once the configuration change is committed
+	close(release)
+	response = <-responseCh
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	response = reconfigure(raft.Member_ACTIVE)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+
+	// Demote baz again and verify it is not promoted while bar is unreachable
+	response = reconfigure(raft.Member_PROMOTABLE)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	atomic.StoreInt32(&barDown, 1)
+	for role.appender.isReachable("bar") {
+		time.Sleep(10 * time.Millisecond)
+	}
+	response = reconfigure(raft.Member_ACTIVE)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_ERROR, response.Error)
+
+	role.raft.ReadLock()
+	configuration, pending := role.raft.Configuration()
+	role.raft.ReadUnlock()
+	assert.Nil(t, pending)
+	for _, member := range configuration.Members {
+		if member.MemberID == "baz" {
+			assert.Equal(t, raft.Member_PROMOTABLE, member.Type)
+		}
+	}
+}
+
+func TestLeaderLearners(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Fail appends to one of the voting members and to the learners until they've caught up
+	var caughtUp int32
+	appended := make(chan raft.MemberID, 100)
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			select {
+			case appended <- member:
+			default:
+			}
+			if member == "member-2" || (member != "member-1" && atomic.LoadInt32(&caughtUp) == 0) {
+				return nil, errors.New("unavailable")
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	protocol, sm, stores := newLargeTestState(client, 5, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	setLearners(protocol, "member-3", "member-4")
+	role := newLeaderRole(protocol, sm, stores).(*LeaderRole)
+	role.raft.Config().LearnerCatchUpThreshold = 1
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+
+	// Verify entries are committed by a quorum of the voting members without the learners
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify entries are replicated to the learners
+	for member := range appended {
+		if member == "member-3" {
+			break
+		}
+	}
+
+	reconfigure := func() *raft.ReconfigureResponse {
+		response, err := role.Reconfigure(context.TODO(), &raft.ReconfigureRequest{
+			Member: &raft.Member{
+				MemberID: "member-3",
+				Type:     raft.Member_ACTIVE,
+			},
+		})
+		assert.NoError(t, err)
+		return response
+	}
+
+	// Verify the learner is not promoted until it has caught up to within the threshold of the commit index
+	readOnlyResponse, err := role.SetReadOnly(context.TODO(), &raft.SetReadOnlyRequest{ReadOnly: false})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, readOnlyResponse.Status)
+	response := reconfigure()
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_ERROR, response.Error)
+
+	// Verify the learner is promoted to a voter once it has caught up
+	atomic.StoreInt32(&caughtUp, 1)
+	for role.appender.matchIndex("member-3") < raft.Index(2) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	response = reconfigure()
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	role.raft.ReadLock()
+	assert.True(t, role.raft.IsVoter("member-3"))
+	assert.Len(t, role.raft.VotingMembers(), 4)
+	role.raft.ReadUnlock()
+}
+
+func TestLeaderConcurrentReconfigure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block the replication of configuration changes while blocking is enabled
+	var blocking int32
+	release := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			for _, entry := range request.Entries {
+				if entry.GetConfiguration() != nil && atomic.LoadInt32(&blocking) == 1 {
+					<-release
+				}
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	atomic.StoreInt32(&blocking, 1)
+	responseCh := make(chan *raft.ReconfigureResponse, 1)
+	go func() {
+		response, err := role.Reconfigure(context.TODO(), &raft.ReconfigureRequest{
+			Member: &raft.Member{
+				MemberID: "bar",
+				Type:     raft.Member_PASSIVE,
+			},
+		})
+		assert.NoError(t, err)
+		responseCh <- response
+	}()
+	awaitIndex(role.raft, role.store.Log(), raft.Index(2))
+
+	// Verify concurrent membership changes are rejected while the first change is uncommitted
+	reconfigureResponse, err := role.Reconfigure(context.TODO(), &raft.ReconfigureRequest{
+		Member: &raft.Member{
+			MemberID: "baz",
+			Type:     raft.Member_PASSIVE,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, reconfigureResponse.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_IN_PROGRESS, reconfigureResponse.Error)
+
+	joinResponse, err := role.Join(context.TODO(), &raft.JoinRequest{
+		Member: &raft.Member{
+			MemberID: "qux",
+			Type:     raft.Member_PASSIVE,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, joinResponse.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_IN_PROGRESS, joinResponse.Error)
+
+	leaveResponse, err := role.Leave(context.TODO(), &raft.LeaveRequest{
+		Member: &raft.Member{
+			MemberID: "baz",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, leaveResponse.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_IN_PROGRESS, leaveResponse.Error)
+	assert.Equal(t, raft.Index(2), role.store.Writer().LastIndex())
+
+	// Verify the first change is committed and subsequent changes are accepted
+	atomic.StoreInt32(&blocking, 0)
+	close(release)
+	response := <-responseCh
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Equal(t, raft.Index(2), response.Index)
+
+	joinResponse, err = role.Join(context.TODO(), &raft.JoinRequest{
+		Member: &raft.Member{
+			MemberID: "qux",
+			Type:     raft.Member_PASSIVE,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, joinResponse.Status)
+	assert.Equal(t, raft.Index(3), joinResponse.Index)
+	assert.Len(t, joinResponse.Members, 4)
+
+	leaveResponse, err = role.Leave(context.TODO(), &raft.LeaveRequest{
+		Member: &raft.Member{
+			MemberID: "qux",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, leaveResponse.Status)
+	assert.Equal(t, raft.Index(4), leaveResponse.Index)
+	assert.Len(t, leaveResponse.Members, 3)
+
+	// Verify a member that is not in the configuration cannot leave
+	leaveResponse, err = role.Leave(context.TODO(), &raft.LeaveRequest{
+		Member: &raft.Member{
+			MemberID: "qux",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, leaveResponse.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_ERROR, leaveResponse.Error)
+}
+
+func TestLeaderConcurrentJointReconfigure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block replication until released
+	release := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			<-release
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.Config().JointConsensus = true
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+
+	// Commit a joint configuration removing baz that has not yet been completed
+	role.raft.WriteLock()
+	role.raft.SetConfiguration(&raft.Configuration{
+		Members: []*raft.Member{
+			{MemberID: "foo", Type: raft.Member_ACTIVE},
+			{MemberID: "bar", Type: raft.Member_ACTIVE},
+		},
+		OldMembers: []*raft.Member{
+			{MemberID: "foo", Type: raft.Member_ACTIVE},
+			{MemberID: "bar", Type: raft.Member_ACTIVE},
+			{MemberID: "baz", Type: raft.Member_ACTIVE},
+		},
+	})
+	role.raft.WriteUnlock()
+	assert.NoError(t, role.Start())
+
+	// Verify concurrent membership changes are rejected while the joint configuration is active
+	reconfigureResponse, err := role.Reconfigure(context.TODO(), &raft.ReconfigureRequest{
+		Member: &raft.Member{
+			MemberID: "bar",
+			Type:     raft.Member_PASSIVE,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, reconfigureResponse.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_IN_PROGRESS, reconfigureResponse.Error)
+
+	leaveResponse, err := role.Leave(context.TODO(), &raft.LeaveRequest{
+		Member: &raft.Member{
+			MemberID: "bar",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, leaveResponse.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_IN_PROGRESS, leaveResponse.Error)
+
+	role.raft.ReadLock()
+	configuration, _ := role.raft.Configuration()
+	assert.Len(t, configuration.OldMembers, 3)
+	role.raft.ReadUnlock()
+
+	// Verify the joint configuration is completed and subsequent changes are accepted
+	close(release)
+	assert.Equal(t, raft.Index(2), awaitCommit(role.raft, raft.Index(2)))
+	role.raft.ReadLock()
+	configuration, pending := role.raft.Configuration()
+	assert.Nil(t, pending)
+	assert.Len(t, configuration.Members, 2)
+	assert.Len(t, configuration.OldMembers, 0)
+	role.raft.ReadUnlock()
+
+	reconfigureResponse, err = role.Reconfigure(context.TODO(), &raft.ReconfigureRequest{
+		Member: &raft.Member{
+			MemberID: "bar",
+			Type:     raft.Member_PASSIVE,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, reconfigureResponse.Status)
+}
+
+func TestLeaderEvenClusterPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.Config().EvenClusterPolicy = config.EvenClusterPolicy_REJECT
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify changes resulting in an even number of voting members are rejected
+	joinResponse, err := role.Join(context.TODO(), &raft.JoinRequest{
+		Member: &raft.Member{
+			MemberID: "qux",
+			Type:     raft.Member_ACTIVE,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, joinResponse.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_ERROR, joinResponse.Error)
+
+	leaveResponse, err := role.Leave(context.TODO(), &raft.LeaveRequest{
+		Member: &raft.Member{
+			MemberID: "baz",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, leaveResponse.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_ERROR, leaveResponse.Error)
+	assert.Equal(t, raft.Index(1), role.store.Writer().LastIndex())
+
+	// Verify changes that don't change the number of voting members are accepted
+	joinResponse, err = role.Join(context.TODO(), &raft.JoinRequest{
+		Member: &raft.Member{
+			MemberID: "qux",
+			Type:     raft.Member_PASSIVE,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, joinResponse.Status)
+
+	// Verify changes resulting in an even number of voting members are accepted with a warning
+	role.raft.Config().EvenClusterPolicy = config.EvenClusterPolicy_WARN
+	reconfigureResponse, err := role.Reconfigure(context.TODO(), &raft.ReconfigureRequest{
+		Member: &raft.Member{
+			MemberID: "qux",
+			Type:     raft.Member_ACTIVE,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, reconfigureResponse.Status)
+	assert.Len(t, reconfigureResponse.Members, 4)
+}
+
+func TestLeaderJoin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block replication to the joining members until released
+	release := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if member == "qux" || member == "quux" {
+				select {
+				case <-release:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	// Start with a configuration that excludes the joining members
+	role := newLeaderRole(newTestMembersState(client, "foo", "bar", "baz", "qux", "quux")).(*LeaderRole)
+	role.raft.SetConfiguration(&raft.Configuration{
+		Members: []*raft.Member{
+			{MemberID: "foo", Type: raft.Member_ACTIVE},
+			{MemberID: "bar", Type: raft.Member_ACTIVE},
+			{MemberID: "baz", Type: raft.Member_ACTIVE},
+		},
+	})
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	join := func(member raft.MemberID) <-chan *raft.JoinResponse {
+		ch := make(chan *raft.JoinResponse, 1)
+		go func() {
+			response, err := role.Join(context.TODO(), &raft.JoinRequest{
+				Member: &raft.Member{
+					MemberID: member,
+					Type:     raft.Member_ACTIVE,
+				},
+			})
+			assert.NoError(t, err)
+			ch <- response
+		}()
+		return ch
+	}
+
+	// Verify voters that cannot be replicated to are rejected
+	response := <-join("corge")
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_CONFIGURATION_ERROR, response.Error)
+	assert.Equal(t, raft.Index(1), role.store.Writer().LastIndex())
+
+	// Verify the joining member is added as a learner until it has caught up
+	quxCh := join("qux")
+	assert.Equal(t, raft.Index(2), awaitCommit(role.raft, raft.Index(2)))
+	role.raft.ReadLock()
+	configuration, _ := role.raft.Configuration()
+	role.raft.ReadUnlock()
+	assert.Equal(t, raft.Member_PASSIVE, findMember(configuration, "qux").Type)
+
+	// Verify a concurrent join waits for the first join to complete rather than failing
+	quuxCh := join("quux")
+	select {
+	case response := <-quuxCh:
+		t.Fatalf("unexpected join response %v", response)
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, raft.Index(2), role.store.Writer().LastIndex())
+
+	// Verify both members are promoted to voters once they've caught up
+	close(release)
+	response = <-quxCh
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Equal(t, raft.Index(3), response.Index)
+	assert.Equal(t, role.raft.Member(), response.Leader)
+	assert.True(t, response.CommitIndex >= response.Index)
+	assert.Len(t, response.Members, 4)
+
+	response = <-quuxCh
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Equal(t, raft.Index(5), response.Index)
+	assert.True(t, response.CommitIndex >= response.Index)
+	assert.Len(t, response.Members, 5)
+	for _, member := range response.Members {
+		assert.Equal(t, raft.Member_ACTIVE, member.Type)
+	}
+
+	// Verify a member that has already joined can retry its join without changing the configuration
+	response = <-join("qux")
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Equal(t, raft.Index(5), response.Index)
+	assert.Equal(t, raft.Index(5), role.store.Writer().LastIndex())
+}
+
+func TestLeaderPoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+
+	response, err := role.Poll(context.TODO(), &raft.PollRequest{
+		Term:         raft.Term(1),
+		Candidate:    role.raft.Members()[1],
+		LastLogIndex: 0,
+		LastLogTerm:  0,
+	})
+	assert.NoError(t, err)
+	assert.False(t, response.Accepted)
+}
+
+func TestLeaderVote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
+
+	response, err := role.Vote(context.TODO(), &raft.VoteRequest{
+		Term:         raft.Term(2),
+		Candidate:    role.raft.Members()[1],
+		LastLogIndex: 0,
+		LastLogTerm:  0,
+	})
+	assert.NoError(t, err)
+	assert.False(t, response.Voted)
+	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+	assert.Equal(t, raft.Term(2), awaitTerm(role.raft, raft.Term(2)))
+
+	role = newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
+
+	response, err = role.Vote(context.TODO(), &raft.VoteRequest{
+		Term:         raft.Term(2),
+		Candidate:    role.raft.Members()[1],
+		LastLogIndex: 1,
+		LastLogTerm:  1,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Voted)
+	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+	assert.Equal(t, raft.Term(2), awaitTerm(role.raft, raft.Term(2)))
+}
+
+func TestLeaderVoteStopping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	awaitCommit(role.raft, raft.Index(1))
+	role.raft.WriteLock()
+	assert.NoError(t, role.Stop())
+	role.raft.WriteUnlock()
+
+	// Verify a stopping leader declines to vote without adopting the candidate's term
+	response, err := role.Vote(context.TODO(), &raft.VoteRequest{
+		Term:         raft.Term(2),
+		Candidate:    raft.MemberID("bar"),
+		LastLogIndex: raft.Index(1),
+		LastLogTerm:  raft.Term(1),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_UNAVAILABLE, response.Error)
+	assert.False(t, response.Voted)
+
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Term(1), role.raft.Term())
+	assert.Nil(t, role.raft.LastVotedFor())
+	role.raft.ReadUnlock()
+}
+
+func TestLeaderAppendPriorTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
+	assert.NoError(t, role.Start())
+
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         raft.Term(1),
+		Leader:       raft.MemberID("bar"),
+		PrevLogIndex: 1,
+		PrevLogTerm:  1,
+		Entries:      []*raft.LogEntry{},
+		CommitIndex:  0,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.False(t, response.Succeeded)
+}
+
+func TestLeaderAppendGreaterTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
+
+	leader := raft.MemberID("bar")
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         raft.Term(2),
+		Leader:       leader,
+		PrevLogIndex: 1,
+		PrevLogTerm:  1,
+		Entries:      []*raft.LogEntry{},
+		CommitIndex:  1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
+	assert.Equal(t, raft.Term(2), awaitTerm(role.raft, raft.Term(2)))
+	assert.Equal(t, &leader, awaitLeader(role.raft, &leader))
+}
+
+func TestLeaderSendSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Expect a single install request per node
+	succeedInstallTo(client, raft.MemberID("bar"))
+	succeedInstallTo(client, raft.MemberID("baz"))
+
+	// Expect a single append request per node
+	succeedAppendTo(client, raft.MemberID("bar"))
+	succeedAppendTo(client, raft.MemberID("baz"))
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+
+	// Reset the log to index 100 and add some entries
+	role.store.Log().Writer().Reset(raft.Index(100))
+	role.store.Log().Writer().Append(&raft.LogEntry{
+		Term:      raft.Term(1),
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_Initialize{
+			Initialize: &raft.InitializeEntry{},
+		},
+	})
+	role.store.Log().Writer().Append(&raft.LogEntry{
+		Term:      raft.Term(2),
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_Initialize{
+			Initialize: &raft.InitializeEntry{},
+		},
+	})
+
+	// Add a snapshot to the log at index 100
+	snapshot := role.store.Snapshot().NewSnapshot(raft.Index(100), raft.Term(1), time.Now())
+	writer := snapshot.Writer()
+	_, _ = writer.Write([]byte("abc"))
+	writer.Close()
+
+	assert.NoError(t, role.raft.SetTerm(raft.Term(3)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(102), awaitCommit(role.raft, raft.Index(102)))
+
+	// Verify that the init entry was written at index 102
+	role.raft.ReadLock()
+	reader := role.store.Log().OpenReader(102)
+	entry := reader.NextEntry()
+	assert.Equal(t, raft.Index(102), entry.Index)
+	assert.Equal(t, raft.Term(3), entry.Entry.Term)
+	role.raft.ReadUnlock()
+
+	assert.Equal(t, raft.Index(102), awaitCommit(role.raft, raft.Index(102)))
+}
+
+func TestLeaderResumeSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+	succeedInstallTo(client, raft.MemberID("baz")).AnyTimes()
+
+	// Reject the first install to bar with the offset of the bytes received by bar, then verify the install
+	// is resumed from the offset
+	installs := make(chan []*raft.InstallRequest, 2)
+	var attempts int32
+	client.EXPECT().
+		Install(gomock.Any(), raft.MemberID("bar")).
+		DoAndReturn(func(ctx context.Context, member raft.MemberID) (chan<- *raft.InstallRequest, <-chan *raft.InstallStreamResponse, error) {
+			requestCh := make(chan *raft.InstallRequest)
+			responseCh := make(chan *raft.InstallStreamResponse, 1)
+			go func() {
+				requests := make([]*raft.InstallRequest, 0)
+				for request := range requestCh {
+					requests = append(requests, request)
+				}
+				status, offset := raft.ResponseStatus_OK, uint64(0)
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					status, offset = raft.ResponseStatus_ERROR, uint64(3)
+				}
+				installs <- requests
+				responseCh <- raft.NewInstallStreamResponse(&raft.InstallResponse{
+					Status: status,
+					Error:  raft.ResponseError_PROTOCOL_ERROR,
+					Offset: offset,
+				}, nil)
+			}()
+			return requestCh, responseCh, nil
+		}).Times(2)
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.store.Log().Writer().Reset(raft.Index(101))
+	snapshot := role.store.Snapshot().NewSnapshot(raft.Index(100), raft.Term(1), time.Now())
+	writer := snapshot.Writer()
+	_, _ = writer.Write([]byte("abcdef"))
+	writer.Close()
+
+	assert.NoError(t, role.raft.SetTerm(raft.Term(3)))
+	assert.NoError(t, role.Start())
+
+	requests := <-installs
+	assert.Len(t, requests, 1)
+	assert.Equal(t, uint64(0), requests[0].Offset)
+	assert.Equal(t, "abcdef", string(requests[0].Data))
+	assert.Equal(t, crc32.ChecksumIEEE([]byte("abcdef")), requests[0].Checksum)
+
+	requests = <-installs
+	assert.Len(t, requests, 1)
+	assert.Equal(t, uint64(3), requests[0].Offset)
+	assert.Equal(t, "def", string(requests[0].Data))
+	assert.Equal(t, crc32.ChecksumIEEE([]byte("def")), requests[0].Checksum)
+	assert.Equal(t, raft.Index(101), awaitCommit(role.raft, raft.Index(101)))
+}
+
+func TestLeaderCompactedPrevTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedInstallTo(client, raft.MemberID("bar")).AnyTimes()
+	succeedInstallTo(client, raft.MemberID("baz")).AnyTimes()
+
+	// Verify the term of the last entry included in the snapshot is sent for the compacted previous entry
+	// once followers have installed the snapshot
+	var verified int32
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if request.PrevLogIndex == raft.Index(101) {
+				assert.Equal(t, raft.Term(2), request.PrevLogTerm)
+				atomic.StoreInt32(&verified, 1)
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+
+	// Reset the log to index 100, add some entries, and compact the log up to a snapshot at index 101
+	role.store.Log().Writer().Reset(raft.Index(100))
+	for _, term := range []raft.Term{1, 2} {
+		role.store.Log().Writer().Append(&raft.LogEntry{
+			Term:      term,
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		})
+	}
+	snapshot := role.store.Snapshot().NewSnapshot(raft.Index(101), raft.Term(2), time.Now())
+	writer := snapshot.Writer()
+	_, _ = writer.Write([]byte("abc"))
+	writer.Close()
+	role.store.Log().Writer().Compact(raft.Index(101))
+
+	assert.NoError(t, role.raft.SetTerm(raft.Term(3)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(102), awaitCommit(role.raft, raft.Index(102)))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&verified))
+}
+
+func TestLeaderSnapshotRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedInstallTo(client, raft.MemberID("baz")).AnyTimes()
+	succeedAppendTo(client, raft.MemberID("baz")).AnyTimes()
+
+	// Count the snapshots installed on bar
+	installCh := make(chan struct{}, 2)
+	client.EXPECT().
+		Install(gomock.Any(), raft.MemberID("bar")).
+		DoAndReturn(func(ctx context.Context, member raft.MemberID) (chan<- *raft.InstallRequest, <-chan *raft.InstallStreamResponse, error) {
+			requestCh := make(chan *raft.InstallRequest)
+			responseCh := make(chan *raft.InstallStreamResponse)
+			go func() {
+				for range requestCh {
+				}
+				installCh <- struct{}{}
+				responseCh <- raft.NewInstallStreamResponse(&raft.InstallResponse{
+					Status: raft.ResponseStatus_OK,
+				}, nil)
+			}()
+			return requestCh, responseCh, nil
+		}).Times(2)
+
+	// Reject the first append to bar, then request a snapshot after the snapshot has been installed
+	// once to simulate bar losing its state
+	var mu sync.Mutex
+	appends := 0
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), raft.MemberID("bar")).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			appends++
+			switch {
+			case appends == 1:
+				return &raft.AppendResponse{
+					Status: raft.ResponseStatus_OK,
+					Term:   request.Term,
+				}, nil
+			case appends == 2:
+				assert.Equal(t, raft.Index(101), request.LeaderFirstIndex)
+				return &raft.AppendResponse{
+					Status:            raft.ResponseStatus_OK,
+					Term:              request.Term,
+					SnapshotRequested: true,
+				}, nil
+			default:
+				return &raft.AppendResponse{
+					Status:       raft.ResponseStatus_OK,
+					Term:         request.Term,
+					Succeeded:    true,
+					LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+				}, nil
+			}
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+
+	// Reset the log to index 101 and add a snapshot at index 100
+	role.store.Log().Writer().Reset(raft.Index(101))
+	role.store.Log().Writer().Append(&raft.LogEntry{
+		Term:      raft.Term(1),
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_Initialize{
+			Initialize: &raft.InitializeEntry{},
+		},
+	})
+	snapshot := role.store.Snapshot().NewSnapshot(raft.Index(100), raft.Term(1), time.Now())
+	writer := snapshot.Writer()
+	_, _ = writer.Write([]byte("abc"))
+	writer.Close()
+
+	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
+	assert.NoError(t, role.Start())
+
+	// Verify the snapshot is installed again once bar requests it
+	for i := 0; i < 2; i++ {
+		select {
+		case <-installCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("snapshot was not installed")
+		}
+	}
+	assert.Equal(t, raft.Index(102), awaitCommit(role.raft, raft.Index(102)))
+}
+
+func TestLeaderQueryDuringInstall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block the snapshot install to bar until the test completes and fail all requests to baz
+	installCh := make(chan struct{})
+	releaseCh := make(chan struct{})
+	defer close(releaseCh)
+	client.EXPECT().
+		Install(gomock.Any(), raft.MemberID("bar")).
+		DoAndReturn(func(ctx context.Context, member raft.MemberID) (chan<- *raft.InstallRequest, <-chan *raft.InstallStreamResponse, error) {
+			requestCh := make(chan *raft.InstallRequest)
+			responseCh := make(chan *raft.InstallStreamResponse)
+			go func() {
+				close(installCh)
+				for range requestCh {
+				}
+				<-releaseCh
+				responseCh <- raft.NewInstallStreamResponse(&raft.InstallResponse{
+					Status: raft.ResponseStatus_OK,
+				}, nil)
+			}()
+			return requestCh, responseCh, nil
+		})
+	succeedAppendTo(client, raft.MemberID("bar")).AnyTimes()
+	client.EXPECT().
+		Install(gomock.Any(), raft.MemberID("baz")).
+		Return(nil, nil, errors.New("unavailable")).
+		AnyTimes()
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), raft.MemberID("baz")).
+		Return(nil, errors.New("unavailable")).
+		AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+
+	// Add a large snapshot to be replicated to followers
+	role.store.Log().Writer().Reset(raft.Index(100))
+	snapshot := role.store.Snapshot().NewSnapshot(raft.Index(100), raft.Term(1), time.Now())
+	writer := snapshot.Writer()
+	_, _ = writer.Write(make([]byte, 10*maxBatchSize))
+	writer.Close()
+
+	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
+	assert.NoError(t, role.Start())
+	<-installCh
+
+	// Verify a linearizable query is not blocked by the snapshot install
+	query := &raft.QueryRequest{
+		Value:           newGetRequest("Get", 1, 0),
+		ReadConsistency: raft.ReadConsistency_LINEARIZABLE,
+	}
+	queryCh := make(chan *raft.QueryStreamResponse, 1)
+	startTime := time.Now()
+	go func() {
+		assert.NoError(t, role.Query(query, queryCh))
+	}()
+	select {
+	case response := <-queryCh:
+		assert.True(t, response.Succeeded())
+		assert.True(t, time.Since(startTime) < role.raft.ConfiguredElectionTimeout())
+	case <-time.After(role.raft.ConfiguredElectionTimeout()):
+		assert.Fail(t, "query blocked by snapshot install")
+	}
+
+	role.raft.WriteLock()
+	assert.NoError(t, role.Stop())
+	role.raft.WriteUnlock()
+}
+
+func TestLeaderInstallTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Never respond to the first two installs to bar and accept the third install
+	var installs int32
+	installCh := make(chan time.Time, 3)
+	requests := make(chan *raft.InstallRequest, 10)
+	client.EXPECT().
+		Install(gomock.Any(), raft.MemberID("bar")).
+		DoAndReturn(func(ctx context.Context, member raft.MemberID) (chan<- *raft.InstallRequest, <-chan *raft.InstallStreamResponse, error) {
+			installCh <- time.Now()
+			respond := atomic.AddInt32(&installs, 1) > 2
+			requestCh := make(chan *raft.InstallRequest)
+			responseCh := make(chan *raft.InstallStreamResponse)
+			go func() {
+				for {
+					select {
+					case request, ok := <-requestCh:
+						if ok {
+							requests <- request
+						} else if respond {
+							responseCh <- raft.NewInstallStreamResponse(&raft.InstallResponse{
+								Status: raft.ResponseStatus_OK,
+							}, nil)
+							return
+						} else {
+							requestCh = nil
+						}
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+			return requestCh, responseCh, nil
+		}).Times(3)
+	succeedAppendTo(client, raft.MemberID("bar")).AnyTimes()
+	succeedInstallTo(client, raft.MemberID("baz"))
+	succeedAppendTo(client, raft.MemberID("baz")).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	timeout := 200 * time.Millisecond
+	role.raft.Config().InstallTimeout = &timeout
+
+	role.store.Log().Writer().Reset(raft.Index(100))
+	snapshot := role.store.Snapshot().NewSnapshot(raft.Index(100), raft.Term(1), time.Now())
+	writer := snapshot.Writer()
+	_, _ = writer.Write([]byte("abc"))
+	writer.Close()
+
+	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
+	assert.NoError(t, role.Start())
+
+	// Verify the entries are committed while the install to bar is blocked
+	assert.Equal(t, raft.Index(100), awaitCommit(role.raft, raft.Index(100)))
+
+	// Verify the installs are aborted after the timeout and retried with an increasing backoff
+	first := <-installCh
+	second := <-installCh
+	assert.True(t, second.Sub(first) >= 2*timeout)
+	third := <-installCh
+	assert.True(t, third.Sub(second) >= 3*timeout)
+
+	// Verify the install is not retried once it succeeds
+	select {
+	case <-installCh:
+		assert.Fail(t, "install retried after success")
+	case <-time.After(4 * timeout):
+	}
+
+	// Verify each aborted install is retried from the start of the snapshot since bar never acknowledged
+	// the bytes it received
+	assert.Len(t, requests, 3)
+	for len(requests) > 0 {
+		request := <-requests
+		assert.Equal(t, uint64(0), request.Offset)
+		assert.Equal(t, "abc", string(request.Data))
+	}
+}
+
+func TestLeaderFollowerBuffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block append requests to each follower while the follower is slow
+	slow := map[raft.MemberID]chan struct{}{
+		"bar": make(chan struct{}),
+		"baz": make(chan struct{}),
+	}
+	var slowMu sync.RWMutex
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			slowMu.RLock()
+			ch := slow[member]
+			slowMu.RUnlock()
+			if ch != nil && len(request.Entries) > 0 {
+				<-ch
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+	release := func(member raft.MemberID) {
+		slowMu.Lock()
+		close(slow[member])
+		slow[member] = nil
+		slowMu.Unlock()
+	}
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.Config().FollowerBufferSize = 4
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
+
+	// Append entries to be committed while both followers are slow
+	errCh := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		role.raft.WriteLock()
+		indexed := role.store.Writer().Append(&raft.LogEntry{
+			Term:      role.raft.Term(),
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		})
+		ch := role.appender.register(indexed, nil)
+		role.raft.WriteUnlock()
+		go func() {
+			errCh <- role.appender.commit(indexed, ch)
+		}()
+	}
+
+	// Verify the followers' buffers are bounded and the leader stops sending entries to the followers
+	// once their buffers are full since both are needed to form a quorum. Each follower buffers up to
+	// four entries, and the leader holds one more entry for the follower blocking the proposals.
+	var blocked, other raft.MemberID
+	for blocked == "" {
+		if role.bufferedEntries("bar") == 5 && role.bufferedEntries("baz") >= 4 {
+			blocked, other = "bar", "baz"
+		} else if role.bufferedEntries("baz") == 5 && role.bufferedEntries("bar") >= 4 {
+			blocked, other = "baz", "bar"
+		} else {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// Use an already replicated entry to probe the appender since it's discarded once sent
+	entry := &log.Entry{
+		Index: raft.Index(1),
+	}
+	select {
+	case role.appender.members[blocked].entryCh <- entry:
+		assert.Fail(t, "slow follower accepted entries beyond its buffer")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, 5, role.bufferedEntries(blocked))
+	assert.True(t, role.bufferedEntries(other) <= 5)
+
+	// Once the other follower catches up, verify the blocked follower is no longer needed to form
+	// a quorum and accepts entries without buffering them
+	release(other)
+	select {
+	case role.appender.members[blocked].entryCh <- entry:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "slow follower did not accept entries")
+	}
+	assert.Equal(t, 4, role.bufferedEntries(blocked))
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, <-errCh)
+	}
+	assert.Equal(t, raft.Index(21), awaitCommit(role.raft, raft.Index(21)))
+	release(blocked)
+}
+
+func TestLeaderSlowFollowerCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppendTo(client, "bar").AnyTimes()
+
+	// Block append requests to the slow follower until the test completes
+	blocked := make(chan struct{})
+	defer close(blocked)
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("baz"))).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if len(request.Entries) > 0 {
+				<-blocked
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.Config().FollowerBufferSize = 2
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Append entries beyond the slow follower's buffer
+	errCh := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		role.raft.WriteLock()
+		indexed := role.store.Writer().Append(&raft.LogEntry{
+			Term:      role.raft.Term(),
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		})
+		ch := role.appender.register(indexed, nil)
+		role.raft.WriteUnlock()
+		go func() {
+			errCh <- role.appender.commit(indexed, ch)
+		}()
+	}
+
+	// Verify the entries are committed once acknowledged by the quorum without waiting on the slow follower
+	for i := 0; i < 10; i++ {
+		select {
+		case err := <-errCh:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			assert.Fail(t, "commit delayed by slow follower")
+			return
+		}
+	}
+	assert.Equal(t, raft.Index(11), awaitCommit(role.raft, raft.Index(11)))
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(11), role.raft.QuorumIndex())
+	role.raft.ReadUnlock()
+}
+
+func TestLeaderQuorumCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppendTo(client, "member-1").AnyTimes()
+	succeedAppendTo(client, "member-2").AnyTimes()
+
+	// Block append requests to a minority of the followers until the test completes
+	blocked := make(chan struct{})
+	defer close(blocked)
+	for _, member := range []raft.MemberID{"member-3", "member-4"} {
+		client.EXPECT().
+			Append(gomock.Any(), gomock.Any(), gomock.Eq(member)).
+			DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+				<-blocked
+				return nil, errors.New("AppendRequest failed")
+			}).AnyTimes()
+	}
+
+	role := newLeaderRole(newLargeTestState(client, 5, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+
+	// Verify the entry is committed once the quorum-th follower acknowledges it
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+}
+
+func TestLeaderBatchCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block append requests until all the entries have been appended
+	blocked := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if len(request.Entries) > 0 {
+				<-blocked
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
+
+	// Append a batch of entries from different clients, registering a waiter for each entry
+	var mu sync.Mutex
+	applied := make([]raft.Index, 0, 5)
+	entries := make([]*log.Entry, 0, 5)
+	channels := make([]<-chan bool, 0, 5)
+	for i := 0; i < 5; i++ {
+		role.raft.WriteLock()
+		indexed := role.store.Writer().Append(&raft.LogEntry{
+			Term:      role.raft.Term(),
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		})
+		channels = append(channels, role.appender.register(indexed, func() {
+			mu.Lock()
+			applied = append(applied, indexed.Index)
+			mu.Unlock()
+		}))
+		role.raft.WriteUnlock()
+		entries = append(entries, indexed)
+	}
+
+	// Commit only the last entry and verify every waiter in the batch is completed in index order
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- role.appender.commit(entries[len(entries)-1], channels[len(channels)-1])
+	}()
+	close(blocked)
+	assert.NoError(t, <-errCh)
+	for i, ch := range channels[:len(channels)-1] {
+		select {
+		case succeeded := <-ch:
+			assert.True(t, succeeded)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "entry was not completed", "index %d", entries[i].Index)
+		}
+	}
+	mu.Lock()
+	assert.Equal(t, []raft.Index{2, 3, 4, 5, 6}, applied)
+	mu.Unlock()
+	assert.Equal(t, 0, role.pendingCommands())
+}
+
+func TestLeaderStepDownPendingCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block append requests once the leader's no-op entry has been committed to keep commands pending
+	var blocking int32
+	blocked := make(chan struct{})
+	defer close(blocked)
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if atomic.LoadInt32(&blocking) == 1 {
+				<-blocked
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+	atomic.StoreInt32(&blocking, 1)
+
+	// Submit commands and a command batch that cannot be committed
+	responseChs := make([]chan *raft.CommandStreamResponse, 3)
+	for i := range responseChs {
+		responseChs[i] = make(chan *raft.CommandStreamResponse, 1)
+		go func(ch chan *raft.CommandStreamResponse) {
+			assert.NoError(t, role.Command(&raft.CommandRequest{Value: []byte("foo")}, ch))
+		}(responseChs[i])
+	}
+	batchCh := make(chan *raft.CommandBatchResponse, 1)
+	go func() {
+		response, err := role.CommandBatch(context.TODO(), &raft.CommandBatchRequest{Values: [][]byte{[]byte("bar"), []byte("baz")}})
+		assert.NoError(t, err)
+		batchCh <- response
+	}()
+	awaitIndex(role.raft, role.store.Log(), raft.Index(6))
+
+	// Step down after learning of a new leader in a higher term
+	leader := raft.MemberID("bar")
+	role.raft.WriteLock()
+	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
+	assert.NoError(t, role.raft.SetLeader(&leader))
+	assert.NoError(t, role.Stop())
+	role.raft.WriteUnlock()
+
+	// Verify all pending commands are failed with a retryable error pointing to the new leader
+	for _, ch := range responseChs {
+		response := <-ch
+		assert.Equal(t, raft.ResponseStatus_ERROR, response.Response.Status)
+		assert.Equal(t, raft.ResponseError_ILLEGAL_MEMBER_STATE, response.Response.Error)
+		assert.Equal(t, leader, response.Response.Leader)
+		assert.Equal(t, raft.Term(2), response.Response.Term)
+	}
+	response := <-batchCh
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_ILLEGAL_MEMBER_STATE, response.Error)
+	assert.Equal(t, leader, response.Leader)
+	assert.Equal(t, raft.Term(2), response.Term)
+}
+
+func TestLeaderConcurrentCommands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block append requests until all the commands have been appended
+	blocked := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if len(request.Entries) > 0 {
+				<-blocked
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
+
+	// Submit commands from several clients concurrently and verify they all complete once committed
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ch := make(chan *raft.CommandStreamResponse, 1)
+			assert.NoError(t, role.Command(&raft.CommandRequest{Value: newOpenSessionRequest()}, ch))
+			response := <-ch
+			assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+		}()
+	}
+	awaitIndex(role.raft, role.store.Log(), raft.Index(6))
+	close(blocked)
+	wg.Wait()
+	assert.Equal(t, raft.Index(6), awaitCommit(role.raft, raft.Index(6)))
+}
+
+func TestLeaderCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+
+	request := &raft.CommandRequest{
+		Value: newOpenSessionRequest(),
+	}
+	ch := make(chan *raft.CommandStreamResponse, 1)
+	err := role.Command(request, ch)
+	assert.NoError(t, err)
+	response := <-ch
+	assert.True(t, response.Succeeded())
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	sessionID := getSessionID(response.Response.Output)
+
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(2), role.raft.CommitIndex())
+	role.raft.ReadUnlock()
+
+	request = &raft.CommandRequest{
+		Value: newSetRequest("Set", sessionID, 1),
+	}
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	err = role.Command(request, ch)
+	assert.NoError(t, err)
+	response = <-ch
+	assert.True(t, response.Succeeded())
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(3), role.raft.CommitIndex())
+	role.raft.ReadUnlock()
+
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	request = &raft.CommandRequest{
+		Value: newSetRequest("SetStream", sessionID, 2),
+	}
+	ch = make(chan *raft.CommandStreamResponse, 3)
+	err = role.Command(request, ch)
+	assert.NoError(t, err)
+	response = <-ch
+	assert.True(t, response.Succeeded())
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	response = <-ch
+	assert.True(t, response.Succeeded())
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	response = <-ch
+	assert.True(t, response.Succeeded())
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(4), role.raft.CommitIndex())
+	role.raft.ReadUnlock()
+
+	_, ok = <-ch
+	assert.False(t, ok)
+
+	request = &raft.CommandRequest{
+		Value: newSetRequest("SetError", sessionID, 3),
+	}
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	err = role.Command(request, ch)
+	assert.NoError(t, err)
+	response = <-ch
+	assert.True(t, response.Succeeded())
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Response.Status)
+
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(5), role.raft.CommitIndex())
+	role.raft.ReadUnlock()
+
+	_, ok = <-ch
+	assert.False(t, ok)
+}
+
+func TestLeaderCommandDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	deadline := 100 * time.Millisecond
+	role.raft.Config().Apply = &config.ApplyConfig{
+		Deadline: &deadline,
+	}
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+
+	ch := make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{
+		Value: newOpenSessionRequest(),
+	}, ch))
+	response := <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	sessionID := getSessionID(response.Response.Output)
+
+	// Verify a command that runs past the apply deadline is failed without a response value
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{
+		Value: newSetRequest("SetSlow", sessionID, 1),
+	}, ch))
+	response = <-ch
+	assert.True(t, response.Succeeded())
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Response.Status)
+	assert.Equal(t, raft.ResponseError_APPLICATION_ERROR, response.Response.Error)
+	assert.Nil(t, response.Response.Output)
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	// Verify the leader continues to apply commands once the slow entry completes
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{
+		Value: newSetRequest("Set", sessionID, 2),
+	}, ch))
+	response = <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+}
+
+func TestLeaderCommandDedup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.Config().Dedup = &config.DedupConfig{
+		MaxBytes: 1024 * 1024,
+	}
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+
+	ch := make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{
+		Value: newOpenSessionRequest(),
+	}, ch))
+	response := <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	sessionID := getSessionID(response.Response.Output)
+
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{
+		Value: newSetRequest("Set", sessionID, 1),
+	}, ch))
+	response = <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	output := response.Response.Output
+	role.raft.ReadLock()
+	lastIndex := role.store.Writer().LastIndex()
+	role.raft.ReadUnlock()
+
+	// Verify a retry of the session's last command is answered from the cache without appending an entry
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{
+		Value: newSetRequest("Set", sessionID, 1),
+	}, ch))
+	response = <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	assert.Equal(t, output, response.Response.Output)
+	role.raft.ReadLock()
+	assert.Equal(t, lastIndex, role.store.Writer().LastIndex())
+	role.raft.ReadUnlock()
+
+	// Verify a retry of an earlier command is appended to the log once the session's next command is cached
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{
+		Value: newSetRequest("Set", sessionID, 2),
+	}, ch))
+	response = <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{
+		Value: newSetRequest("Set", sessionID, 1),
+	}, ch))
+	response = <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	role.raft.ReadLock()
+	assert.Equal(t, lastIndex+2, role.store.Writer().LastIndex())
+	role.raft.ReadUnlock()
+}
+
+func TestLeaderCommandAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.AuthorizeCommands(func(request *raft.CommandRequest) error {
+		if request.Metadata["tenant"] != "foo" {
+			return errors.New("tenant is not authorized")
+		}
+		return nil
+	})
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify a denied command is rejected without appending an entry to the log
+	ch := make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{
+		Value: newOpenSessionRequest(),
+		Metadata: map[string]string{
+			"tenant": "bar",
+		},
+	}, ch))
+	response := <-ch
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Response.Status)
+	assert.Equal(t, raft.ResponseError_UNAUTHORIZED, response.Response.Error)
+	assert.Equal(t, "tenant is not authorized", response.Response.Message)
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(1), role.store.Writer().LastIndex())
+	role.raft.ReadUnlock()
+
+	// Verify an allowed command is appended and applied
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{
+		Value: newOpenSessionRequest(),
+		Metadata: map[string]string{
+			"tenant": "foo",
+		},
+	}, ch))
+	response = <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	assert.Equal(t, uint64(2), getSessionID(response.Response.Output))
+}
+
+func TestLeaderCommandBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Submit a batch in which the second command fails when applied to an unknown session
+	response, err := role.CommandBatch(context.TODO(), &raft.CommandBatchRequest{
+		Values: [][]byte{
+			newOpenSessionRequest(),
+			newSetRequest("Set", 100, 1),
+			newOpenSessionRequest(),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Len(t, response.Results, 3)
+
+	// Verify the commands were appended contiguously in order
+	for i, result := range response.Results {
+		assert.Equal(t, raft.Index(i+2), result.Index)
+	}
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(4), role.raft.CommitIndex())
+	role.raft.ReadUnlock()
+
+	// Verify the failure of the second command is reported without failing the rest of the batch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Results[0].Status)
+	assert.Equal(t, uint64(2), getSessionID(response.Results[0].Output))
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Results[1].Status)
+	assert.Equal(t, raft.ResponseError_APPLICATION_ERROR, response.Results[1].Error)
+	assert.NotEmpty(t, response.Results[1].Message)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Results[2].Status)
+	assert.Equal(t, uint64(4), getSessionID(response.Results[2].Output))
+
+	// Verify a batch is rejected in its entirety while the cluster is in read-only mode
+	role.raft.WriteLock()
+	role.raft.SetReadOnlyMode(role.raft.CommitIndex(), true)
+	role.raft.WriteUnlock()
+	response, err = role.CommandBatch(context.TODO(), &raft.CommandBatchRequest{
+		Values: [][]byte{newOpenSessionRequest()},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_READ_ONLY, response.Error)
+	assert.Equal(t, raft.Index(4), role.store.Writer().LastIndex())
+}
+
+func TestLeaderMaxCommandStreams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	electionTimeout := 200 * time.Millisecond
+	heartbeatInterval := 50 * time.Millisecond
+	config := &config.ProtocolConfig{
+		ElectionTimeout:   &electionTimeout,
+		HeartbeatInterval: &heartbeatInterval,
+		Priorities: map[string]int32{
+			"foo": 1,
+		},
+		MaxCommandStreams: 1,
+	}
+	rafts := newTestCluster(ctrl, config, "foo", "bar", "baz")
+	for _, r := range rafts {
+		go r.Init()
+	}
+	leader := raft.MemberID("foo")
+	assert.Equal(t, raft.RoleLeader, awaitRole(rafts[leader], raft.RoleLeader))
+
+	ch := make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, rafts[leader].Command(&raft.CommandRequest{Value: newOpenSessionRequest()}, ch))
+	response := <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	sessionID := getSessionID(response.Response.Output)
+
+	// Hold a command stream open by not consuming all of its responses
+	streamCh := make(chan *raft.CommandStreamResponse)
+	go func() {
+		_ = rafts[leader].Command(&raft.CommandRequest{Value: newSetRequest("SetStream", sessionID, 1)}, streamCh)
+	}()
+	response = <-streamCh
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+
+	// Verify additional streams are rejected with a retryable error while the stream is open
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, rafts[leader].Command(&raft.CommandRequest{Value: newSetRequest("Set", sessionID, 2)}, ch))
+	response = <-ch
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Response.Status)
+	assert.Equal(t, raft.ResponseError_UNAVAILABLE, response.Response.Error)
+
+	// Release the open stream and verify a new stream is admitted
+	for range streamCh {
+	}
+	ch = make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, rafts[leader].Command(&raft.CommandRequest{Value: newSetRequest("Set", sessionID, 2)}, ch))
+	response = <-ch
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
 }
 
-func TestLeaderAppendPriorTerm(t *testing.T) {
+func TestLeaderFairProposals(t *testing.T) {
 	ctrl := gomock.NewController(t)
-	client := mock.NewMockClient(ctrl)
-	succeedAppend(client).AnyTimes()
-
-	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
-	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
-	assert.NoError(t, role.Start())
-
-	response, err := role.Append(context.TODO(), &raft.AppendRequest{
-		Term:         raft.Term(1),
-		Leader:       raft.MemberID("bar"),
-		PrevLogIndex: 1,
-		PrevLogTerm:  1,
-		Entries:      []*raft.LogEntry{},
-		CommitIndex:  0,
-	})
-	assert.NoError(t, err)
-	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
-	assert.False(t, response.Succeeded)
+	electionTimeout := 200 * time.Millisecond
+	heartbeatInterval := 50 * time.Millisecond
+	config := &config.ProtocolConfig{
+		ElectionTimeout:   &electionTimeout,
+		HeartbeatInterval: &heartbeatInterval,
+		Priorities: map[string]int32{
+			"foo": 1,
+		},
+		FairProposals: true,
+	}
+	rafts := newTestCluster(ctrl, config, "foo", "bar", "baz")
+	for _, r := range rafts {
+		go r.Init()
+	}
+	leader := raft.MemberID("foo")
+	assert.Equal(t, raft.RoleLeader, awaitRole(rafts[leader], raft.RoleLeader))
+
+	openSession := func() uint64 {
+		ch := make(chan *raft.CommandStreamResponse, 1)
+		assert.NoError(t, rafts[leader].Command(&raft.CommandRequest{Value: newOpenSessionRequest()}, ch))
+		response := <-ch
+		assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+		return getSessionID(response.Response.Output)
+	}
+	noisy := openSession()
+	quiet := openSession()
+
+	// Submit a burst of commands from the noisy session concurrently with a command from the quiet session
+	wg := &sync.WaitGroup{}
+	command := func(sessionID uint64, commandID uint64) {
+		defer wg.Done()
+		ch := make(chan *raft.CommandStreamResponse, 1)
+		assert.NoError(t, rafts[leader].Command(&raft.CommandRequest{Value: newSetRequest("Set", sessionID, commandID)}, ch))
+		response := <-ch
+		assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	}
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go command(noisy, uint64(i))
+	}
+	wg.Add(1)
+	go command(quiet, 1)
+	wg.Wait()
 }
 
-func TestLeaderAppendGreaterTerm(t *testing.T) {
+func TestLeaderUrgentProposals(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
 	succeedAppend(client).AnyTimes()
 
 	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	batchInterval := 500 * time.Millisecond
+	urgentTimeout := 100 * time.Millisecond
+	role.raft.Config().ProposalBatchInterval = &batchInterval
+	role.raft.Config().UrgentProposalTimeout = &urgentTimeout
 	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
 	assert.NoError(t, role.Start())
-	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
+	awaitCommit(role.raft, raft.Index(1))
+
+	command := func(request *raft.CommandRequest) <-chan *raft.CommandStreamResponse {
+		ch := make(chan *raft.CommandStreamResponse, 1)
+		go func() {
+			assert.NoError(t, role.Command(request, ch))
+		}()
+		return ch
+	}
 
-	leader := raft.MemberID("bar")
-	response, err := role.Append(context.TODO(), &raft.AppendRequest{
-		Term:         raft.Term(2),
-		Leader:       leader,
-		PrevLogIndex: 1,
-		PrevLogTerm:  1,
-		Entries:      []*raft.LogEntry{},
-		CommitIndex:  1,
-	})
-	assert.NoError(t, err)
-	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
-	assert.True(t, response.Succeeded)
-	assert.Equal(t, raft.RoleFollower, awaitRole(role.raft, raft.RoleFollower))
-	assert.Equal(t, raft.Term(2), awaitTerm(role.raft, raft.Term(2)))
-	assert.Equal(t, &leader, awaitLeader(role.raft, &leader))
+	// Submit a command with a distant deadline and verify it's held to be batched
+	relaxedTimeout := time.Minute
+	relaxed := command(&raft.CommandRequest{Value: newOpenSessionRequest(), Timeout: &relaxedTimeout})
+	for role.proposals.len() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Submit a command with a near deadline and verify it's appended ahead of the batched command.
+	// Sessions are identified by the index of the entry that opened them.
+	start := time.Now()
+	nearTimeout := 50 * time.Millisecond
+	urgent := command(&raft.CommandRequest{Value: newOpenSessionRequest(), Timeout: &nearTimeout})
+	response := <-urgent
+	assert.True(t, time.Since(start) < batchInterval)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	assert.Equal(t, uint64(2), getSessionID(response.Response.Output))
+	assert.Equal(t, 1, role.proposals.len())
+
+	// Verify the batched command is flushed once the batch interval elapses
+	response = <-relaxed
+	assert.True(t, time.Since(start) >= batchInterval/2)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	assert.Equal(t, uint64(3), getSessionID(response.Response.Output))
+	assert.Equal(t, 0, role.proposals.len())
 }
 
-func TestLeaderSendSnapshot(t *testing.T) {
+func TestLeaderStrictReads(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
 
-	// Expect a single install request per node
-	succeedInstallTo(client, raft.MemberID("bar"))
-	succeedInstallTo(client, raft.MemberID("baz"))
-
-	// Expect a single append request per node
-	succeedAppendTo(client, raft.MemberID("bar"))
-	succeedAppendTo(client, raft.MemberID("baz"))
+	// Block append requests to prevent the leader's no-op entry from being committed
+	blocked := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if len(request.Entries) > 0 {
+				<-blocked
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
 
 	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
-
-	// Reset the log to index 100 and add some entries
-	role.store.Log().Writer().Reset(raft.Index(100))
-	role.store.Log().Writer().Append(&raft.LogEntry{
-		Term:      raft.Term(1),
-		Timestamp: time.Now(),
-		Entry: &raft.LogEntry_Initialize{
-			Initialize: &raft.InitializeEntry{},
-		},
-	})
-	role.store.Log().Writer().Append(&raft.LogEntry{
-		Term:      raft.Term(2),
-		Timestamp: time.Now(),
-		Entry: &raft.LogEntry_Initialize{
-			Initialize: &raft.InitializeEntry{},
-		},
-	})
-
-	// Add a snapshot to the log at index 100
-	snapshot := role.store.Snapshot().NewSnapshot(raft.Index(100), time.Now())
-	writer := snapshot.Writer()
-	_, _ = writer.Write([]byte("abc"))
-	writer.Close()
-
-	assert.NoError(t, role.raft.SetTerm(raft.Term(3)))
+	role.raft.Config().StrictReads = true
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
 	assert.NoError(t, role.Start())
-	assert.Equal(t, raft.Index(102), awaitCommit(role.raft, raft.Index(102)))
+	awaitIndex(role.raft, role.store.Log(), raft.Index(1))
 
-	// Verify that the init entry was written at index 102
+	// Verify linearizable reads are rejected before the no-op entry is committed
+	for _, consistency := range []raft.ReadConsistency{raft.ReadConsistency_LINEARIZABLE, raft.ReadConsistency_LINEARIZABLE_LEASE} {
+		ch := make(chan *raft.QueryStreamResponse, 1)
+		assert.NoError(t, role.Query(&raft.QueryRequest{Value: newGetRequest("Get", 1, 1), ReadConsistency: consistency}, ch))
+		response := <-ch
+		assert.Equal(t, raft.ResponseStatus_ERROR, response.Response.Status)
+		assert.Equal(t, raft.ResponseError_NOT_READY, response.Response.Error)
+	}
+
+	// Verify reads are permitted once the no-op entry is committed
+	close(blocked)
+	awaitCommit(role.raft, raft.Index(1))
 	role.raft.ReadLock()
-	reader := role.store.Log().OpenReader(102)
-	entry := reader.NextEntry()
-	assert.Equal(t, raft.Index(102), entry.Index)
-	assert.Equal(t, raft.Term(3), entry.Entry.Term)
+	assert.True(t, role.isReadReady())
 	role.raft.ReadUnlock()
-
-	assert.Equal(t, raft.Index(102), awaitCommit(role.raft, raft.Index(102)))
 }
 
-func TestLeaderCommand(t *testing.T) {
+func TestLeaderQueryMinAppliedIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
 	succeedAppend(client).AnyTimes()
 
 	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	minAppliedIndexTimeout := 200 * time.Millisecond
+	role.raft.Config().MinAppliedIndexTimeout = &minAppliedIndexTimeout
 	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
 	assert.NoError(t, role.Start())
+	role.raft.SetAppliedIndex(raft.Index(1))
 
-	request := &raft.CommandRequest{
-		Value: newOpenSessionRequest(),
+	// Verify a query requiring an applied index is not failed immediately and proceeds once the index is applied
+	request := &raft.QueryRequest{
+		Value:           newGetRequest("Get", 1, 1),
+		ReadConsistency: raft.ReadConsistency_SEQUENTIAL,
+		MinAppliedIndex: raft.Index(5),
 	}
-	ch := make(chan *raft.CommandStreamResponse, 1)
-	err := role.Command(request, ch)
-	assert.NoError(t, err)
+	responseCh := make(chan *raft.QueryResponse, 1)
+	go func() {
+		responseCh <- role.checkMinAppliedIndex(request)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-responseCh:
+		assert.Fail(t, "query did not wait for the minimum applied index")
+	default:
+	}
+	role.raft.SetAppliedIndex(raft.Index(5))
+	assert.Nil(t, <-responseCh)
+
+	// Verify a query requiring an index that is not applied within the timeout fails
+	request.MinAppliedIndex = raft.Index(10)
+	ch := make(chan *raft.QueryStreamResponse, 1)
+	start := time.Now()
+	assert.NoError(t, role.Query(request, ch))
 	response := <-ch
-	assert.True(t, response.Succeeded())
-	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
-	sessionID := getSessionID(response.Response.Output)
-
-	role.raft.ReadLock()
-	assert.Equal(t, raft.Index(2), role.raft.CommitIndex())
-	role.raft.ReadUnlock()
+	assert.True(t, time.Since(start) >= minAppliedIndexTimeout)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Response.Status)
+	assert.Equal(t, raft.ResponseError_NOT_READY, response.Response.Error)
+}
 
-	request = &raft.CommandRequest{
-		Value: newSetRequest("Set", sessionID, 1),
-	}
-	ch = make(chan *raft.CommandStreamResponse, 1)
-	err = role.Command(request, ch)
-	assert.NoError(t, err)
-	response = <-ch
-	assert.True(t, response.Succeeded())
-	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+func TestLeaderQueryStepDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
 
-	role.raft.ReadLock()
-	assert.Equal(t, raft.Index(3), role.raft.CommitIndex())
-	role.raft.ReadUnlock()
+	// Block appends to simulate a leader that has been partitioned from its followers
+	release := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			<-release
+			return nil, errors.New("unavailable")
+		}).AnyTimes()
+	defer close(release)
 
-	_, ok := <-ch
-	assert.False(t, ok)
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
 
-	request = &raft.CommandRequest{
-		Value: newSetRequest("SetStream", sessionID, 2),
+	// Verify a linearizable query is not served until leadership is confirmed by a quorum
+	queryCh := make(chan *raft.QueryStreamResponse, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- role.Query(&raft.QueryRequest{
+			Value:           newGetRequest("Get", 1, 0),
+			ReadConsistency: raft.ReadConsistency_LINEARIZABLE,
+		}, queryCh)
+	}()
+	select {
+	case <-queryCh:
+		assert.Fail(t, "query served without confirming leadership")
+	case <-time.After(100 * time.Millisecond):
 	}
-	ch = make(chan *raft.CommandStreamResponse, 3)
-	err = role.Command(request, ch)
-	assert.NoError(t, err)
-	response = <-ch
-	assert.True(t, response.Succeeded())
-	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
-	response = <-ch
-	assert.True(t, response.Succeeded())
-	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
-	response = <-ch
-	assert.True(t, response.Succeeded())
-	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
 
-	role.raft.ReadLock()
-	assert.Equal(t, raft.Index(4), role.raft.CommitIndex())
-	role.raft.ReadUnlock()
+	// Verify the query fails once the leader steps down
+	role.raft.WriteLock()
+	assert.NoError(t, role.Stop())
+	role.raft.WriteUnlock()
+	assert.Error(t, <-errCh)
+	_, ok := <-queryCh
+	assert.False(t, ok)
 
-	_, ok = <-ch
+	// Verify queries received after the leader has stepped down are not served
+	queryCh = make(chan *raft.QueryStreamResponse, 1)
+	assert.Error(t, role.Query(&raft.QueryRequest{
+		Value:           newGetRequest("Get", 1, 0),
+		ReadConsistency: raft.ReadConsistency_LINEARIZABLE,
+	}, queryCh))
+	_, ok = <-queryCh
 	assert.False(t, ok)
+}
 
-	request = &raft.CommandRequest{
-		Value: newSetRequest("SetError", sessionID, 3),
+func TestLeaderConfirmLeadership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	// Confirm leadership from one follower to form a quorum with the leader
+	client.EXPECT().
+		ConfirmLeadership(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("bar"))).
+		DoAndReturn(func(ctx context.Context, request *raft.ConfirmLeadershipRequest, member raft.MemberID) (*raft.ConfirmLeadershipResponse, error) {
+			assert.Equal(t, raft.MemberID("foo"), request.Leader)
+			return &raft.ConfirmLeadershipResponse{
+				Status:    raft.ResponseStatus_OK,
+				Term:      request.Term,
+				Confirmed: true,
+			}, nil
+		}).MinTimes(1)
+	client.EXPECT().
+		ConfirmLeadership(gomock.Any(), gomock.Any(), gomock.Eq(raft.MemberID("baz"))).
+		Return(nil, errors.New("ConfirmLeadershipRequest failed")).
+		AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	role.raft.Config().ReadIndex = &config.ReadIndexConfig{
+		ConfirmLeadership: true,
 	}
-	ch = make(chan *raft.CommandStreamResponse, 1)
-	err = role.Command(request, ch)
-	assert.NoError(t, err)
-	response = <-ch
-	assert.True(t, response.Succeeded())
-	assert.Equal(t, raft.ResponseStatus_ERROR, response.Response.Status)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
 
-	role.raft.ReadLock()
-	assert.Equal(t, raft.Index(5), role.raft.CommitIndex())
-	role.raft.ReadUnlock()
+	commandCh := make(chan *raft.CommandStreamResponse, 1)
+	assert.NoError(t, role.Command(&raft.CommandRequest{Value: newOpenSessionRequest()}, commandCh))
+	commandResponse := <-commandCh
+	assert.True(t, commandResponse.Succeeded())
+	sessionID := getSessionID(commandResponse.Response.Output)
 
-	_, ok = <-ch
-	assert.False(t, ok)
+	// Verify a linearizable query is served once leadership is confirmed
+	ch := make(chan *raft.QueryStreamResponse, 1)
+	assert.NoError(t, role.Query(&raft.QueryRequest{
+		Value:           newGetRequest("Get", sessionID, 0),
+		ReadConsistency: raft.ReadConsistency_LINEARIZABLE,
+	}, ch))
+	response := <-ch
+	assert.NotNil(t, response)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
 }
 
 func TestLeaderQuery(t *testing.T) {
@@ -438,6 +3588,50 @@ func TestLeaderQuery(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestLeaderCommandResultSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+
+	// Verify a result larger than the configured limit is streamed in multiple chunks
+	role.raft.Config().MaxCommandResultSize = 2
+	ch := make(chan *raft.CommandStreamResponse, 100)
+	assert.NoError(t, role.Command(&raft.CommandRequest{Value: newOpenSessionRequest()}, ch))
+	var output []byte
+	chunks := 0
+	for response := range ch {
+		assert.True(t, response.Succeeded())
+		assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+		assert.True(t, len(response.Response.Output) <= 2)
+		output = append(output, response.Response.Output...)
+		chunks++
+		if !response.Response.Partial {
+			break
+		}
+	}
+	assert.True(t, chunks > 1)
+	sessionID := getSessionID(output)
+	assert.NotEqual(t, uint64(0), sessionID)
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	// Verify a result within the configured limit is returned inline
+	role.raft.Config().MaxCommandResultSize = uint32(len(output))
+	ch = make(chan *raft.CommandStreamResponse, 100)
+	assert.NoError(t, role.Command(&raft.CommandRequest{Value: newOpenSessionRequest()}, ch))
+	response := <-ch
+	assert.True(t, response.Succeeded())
+	assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+	assert.False(t, response.Response.Partial)
+	assert.NotEqual(t, uint64(0), getSessionID(response.Response.Output))
+	_, ok = <-ch
+	assert.False(t, ok)
+}
+
 func newOpenSessionRequest() []byte {
 	timeout := 30 * time.Second
 	bytes, _ := proto.Marshal(&service.SessionRequest{
@@ -525,3 +3719,210 @@ func newQueryRequest(bytes []byte) []byte {
 	})
 	return bytes
 }
+
+func TestLeaderHeartbeatSuppression(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Record the times at which heartbeats and commands are sent to a follower
+	heartbeats := make(chan time.Time, 100)
+	commands := make(chan time.Time, 100)
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if member == "bar" {
+				if len(request.Entries) == 0 {
+					heartbeats <- time.Now()
+				} else if request.Entries[0].GetCommand() != nil {
+					commands <- time.Now()
+				}
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	heartbeatInterval := 200 * time.Millisecond
+	protocol.Config().HeartbeatInterval = &heartbeatInterval
+	role := newLeaderRole(protocol, sm, stores).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify heartbeats are sent to an idle follower at the heartbeat interval rather than the election timeout
+	<-heartbeats
+	first := <-heartbeats
+	second := <-heartbeats
+	assert.InDelta(t, float64(heartbeatInterval), float64(second.Sub(first)), float64(heartbeatInterval/4))
+
+	// Submit a command halfway through the heartbeat interval and verify the next heartbeat is delayed
+	// until the follower has been idle for a full heartbeat interval
+	time.Sleep(heartbeatInterval / 2)
+	go func() {
+		_ = role.Command(&raft.CommandRequest{Value: newOpenSessionRequest()}, make(chan *raft.CommandStreamResponse, 1))
+	}()
+	command := <-commands
+	for {
+		heartbeat := <-heartbeats
+		if heartbeat.After(command) {
+			assert.True(t, heartbeat.Sub(command) >= heartbeatInterval*3/4)
+			assert.True(t, heartbeat.Sub(command) <= heartbeatInterval*2)
+			break
+		}
+	}
+}
+
+func TestLeaderHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	succeedAppend(client).AnyTimes()
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	role.raft.WriteLock()
+	role.raft.SetRole(raft.RoleLeader)
+	role.raft.WriteUnlock()
+	assert.NoError(t, role.Start())
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Verify the leader reports itself as part of a quorum once a quorum acknowledges it
+	for {
+		role.raft.ReadLock()
+		health := role.raft.Health()
+		role.raft.ReadUnlock()
+		if health.Quorum {
+			assert.Equal(t, raft.RoleLeader, health.Role)
+			assert.Equal(t, health.CommitIndex, health.LeaderCommitIndex)
+			assert.Equal(t, raft.Index(0), health.CommitLag)
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestLeaderSingleNodeGrowth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	// Block appends to bar until the test allows bar to respond
+	allow := make(chan struct{})
+	client.EXPECT().
+		Append(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+			if member == "bar" {
+				select {
+				case <-allow:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return &raft.AppendResponse{
+				Status:       raft.ResponseStatus_OK,
+				Term:         request.Term,
+				Succeeded:    true,
+				LastLogIndex: request.PrevLogIndex + raft.Index(len(request.Entries)),
+			}, nil
+		}).AnyTimes()
+
+	protocol, sm, stores := newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))
+	protocol.Config().SingleNode = true
+	setLearners(protocol, "bar", "baz")
+	role := newLeaderRole(protocol, sm, stores).(*LeaderRole)
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.Start())
+
+	// Verify the single voter commits entries without acknowledgement from learners
+	assert.Equal(t, raft.Index(1), awaitCommit(role.raft, raft.Index(1)))
+
+	// Promote bar to a voter and verify entries are no longer committed until bar acknowledges them
+	role.raft.WriteLock()
+	_, indexed, ch := role.appendConfiguration([]*raft.Member{
+		{MemberID: "foo", Type: raft.Member_ACTIVE},
+		{MemberID: "bar", Type: raft.Member_ACTIVE},
+		{MemberID: "baz", Type: raft.Member_PASSIVE},
+	}, nil)
+	role.raft.WriteUnlock()
+	committed := make(chan error, 1)
+	go func() {
+		committed <- role.appender.commit(indexed, ch)
+	}()
+
+	select {
+	case <-committed:
+		t.Fatal("configuration committed without acknowledgement from the new voter")
+	case <-time.After(250 * time.Millisecond):
+	}
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(1), role.raft.CommitIndex())
+	role.raft.ReadUnlock()
+
+	close(allow)
+	assert.NoError(t, <-committed)
+	assert.Equal(t, indexed.Index, awaitCommit(role.raft, indexed.Index))
+}
+
+func TestLeaderCommandStreamBackpressure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+
+	role := newLeaderRole(newTestState(client, mockFollower(ctrl), mockCandidate(ctrl), mockLeader(ctrl))).(*LeaderRole)
+
+	// Stream output to a client that is not consuming responses
+	outputCh := make(chan stream.Result)
+	responseCh := make(chan *raft.CommandStreamResponse)
+	go func() {
+		role.streamCommandOutput(outputCh, responseCh, 2)
+		close(responseCh)
+	}()
+
+	// Verify the state machine output is consumed without blocking on the slow client
+	written := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			outputCh <- stream.Result{Value: []byte{byte(i)}}
+		}
+		close(outputCh)
+		close(written)
+	}()
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+		t.Fatal("state machine output blocked by a slow client")
+	}
+
+	// Verify any response already in flight is delivered and the stream is then dropped with an error
+	var responses []*raft.CommandResponse
+	for response := range responseCh {
+		responses = append(responses, response.Response)
+	}
+	assert.True(t, len(responses) > 0 && len(responses) <= 2)
+	for _, response := range responses[:len(responses)-1] {
+		assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	}
+	assert.Equal(t, raft.ResponseStatus_ERROR, responses[len(responses)-1].Status)
+	assert.Equal(t, raft.ResponseError_UNAVAILABLE, responses[len(responses)-1].Error)
+
+	// Verify a client receives all the output when the output does not exceed the high-water mark
+	outputCh = make(chan stream.Result)
+	responseCh = make(chan *raft.CommandStreamResponse)
+	go func() {
+		role.streamCommandOutput(outputCh, responseCh, 10)
+		close(responseCh)
+	}()
+	go func() {
+		for i := 0; i < 10; i++ {
+			outputCh <- stream.Result{Value: []byte{byte(i)}}
+		}
+		close(outputCh)
+	}()
+	count := 0
+	for response := range responseCh {
+		assert.Equal(t, raft.ResponseStatus_OK, response.Response.Status)
+		count++
+	}
+	assert.Equal(t, 10, count)
+}
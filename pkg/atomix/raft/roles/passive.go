@@ -23,21 +23,28 @@ import (
 	"github.com/atomix/raft-replica/pkg/atomix/raft/state"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store/log"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store/snapshot"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
-	"io"
+	"hash/crc32"
+	"hash/fnv"
 	"math"
+	"sync"
 	"time"
 )
 
 func newPassiveRole(raft raft.Raft, state state.Manager, store store.Store, log util.Logger) *PassiveRole {
 	return &PassiveRole{
 		raftRole: newRaftRole(raft, state, store, log),
+		flusher:  newLogFlusher(raft, store),
 	}
 }
 
 // PassiveRole implements a Raft follower
 type PassiveRole struct {
 	*raftRole
+	flusher       *logFlusher
+	repairWaiters []chan raft.Index
+	repairMu      sync.Mutex
 }
 
 // updateTermAndLeader updates the current term and leader if necessary
@@ -59,20 +66,76 @@ func (r *PassiveRole) updateTermAndLeader(term raft.Term, leader *raft.MemberID)
 // Append handles an append request
 func (r *PassiveRole) Append(ctx context.Context, request *raft.AppendRequest) (*raft.AppendResponse, error) {
 	r.log.Request("AppendRequest", request)
+
+	// Record the time at which the request was received to allow the leader to detect clock skew.
+	receiveTime := r.raft.Clock().Now()
+
 	r.raft.WriteLock()
 	defer r.raft.WriteUnlock()
 	r.updateTermAndLeader(request.Term, &request.Leader)
 	response, err := r.handleAppend(ctx, request)
+
+	// Flush appended entries to durable storage before acknowledging the append.
+	if response != nil && response.Succeeded && len(request.Entries) > 0 {
+		term := r.raft.Term()
+		if err := r.flusher.flush(); err != nil {
+			r.log.Error("Failed to flush entries", err)
+			_ = r.log.Response("AppendResponse", nil, err)
+			return nil, err
+		}
+
+		// If the term changed while awaiting a batched flush, the appended entries may have been replaced
+		// by a newer leader, so the append can't be acknowledged.
+		if r.raft.Term() != term {
+			r.log.Debug("Rejected %v: term changed while flushing entries", request)
+			response = r.failAppend(r.store.Writer().LastIndex())
+		}
+	}
+
+	// Update the estimated lag of the local log behind the leader's log.
+	if request.LeaderLastIndex > 0 && request.Term == r.raft.Term() {
+		r.updateLag(request.LeaderLastIndex)
+	}
+
+	// If the leader included its wall-clock time in the request, return the local time in the response.
+	if response != nil && !request.Timestamp.IsZero() {
+		response.Timestamp = receiveTime
+	}
+
+	// Notify reads awaiting the leader's commit index that the append succeeded and record the heartbeat.
+	if response != nil && response.Succeeded {
+		r.notifyReadRepair(request.CommitIndex)
+		if request.Term == r.raft.Term() {
+			r.raft.Heartbeat(request.CommitIndex)
+		}
+	}
 	_ = r.log.Response("AppendResponse", response, err)
 	return response, err
 }
 
+// updateLag updates the number of entries by which the local log trails the given last index of the leader's log
+func (r *PassiveRole) updateLag(leaderLastIndex raft.Index) {
+	var lag raft.Index
+	if lastIndex := r.store.Writer().LastIndex(); leaderLastIndex > lastIndex {
+		lag = leaderLastIndex - lastIndex
+	}
+	r.raft.SetLag(lag)
+}
+
 // handleAppend is a generic method for handling an AppendRequest
 func (r *PassiveRole) handleAppend(ctx context.Context, request *raft.AppendRequest) (*raft.AppendResponse, error) {
 	if response := r.checkTerm(request); response != nil {
 		return response, nil
 	}
 
+	if response := r.checkEntries(request); response != nil {
+		return response, nil
+	}
+
+	if response := r.checkSnapshotRequired(request); response != nil {
+		return response, nil
+	}
+
 	if response := r.checkPreviousEntry(request); response != nil {
 		return response, nil
 	}
@@ -88,6 +151,61 @@ func (r *PassiveRole) checkTerm(request *raft.AppendRequest) *raft.AppendRespons
 	return nil
 }
 
+// checkEntries validates the sequence of entries in the given request, rejecting the entire request if the
+// sequence is corrupt. Entry indices are implied by the previous log index, so an index sequence that wraps
+// around to zero or extends beyond the leader's last index indicates zero, duplicate or non-monotonic indices.
+func (r *PassiveRole) checkEntries(request *raft.AppendRequest) *raft.AppendResponse {
+	if len(request.Entries) == 0 {
+		return nil
+	}
+
+	// If the implied indices overflow, the sequence includes a zero index and is not monotonic.
+	if uint64(request.PrevLogIndex) > math.MaxUint64-uint64(len(request.Entries)) {
+		r.log.Warn("Rejected %v: entry indices following previous index (%d) overflow", request, request.PrevLogIndex)
+		return r.failAppend(r.store.Writer().LastIndex())
+	}
+
+	// If the leader's last index is known, the request cannot contain more entries than the leader's log.
+	lastIndex := request.PrevLogIndex + raft.Index(len(request.Entries))
+	if request.LeaderLastIndex > 0 && lastIndex > request.LeaderLastIndex {
+		r.log.Warn("Rejected %v: last entry index (%d) is greater than the leader's last index (%d)", request, lastIndex, request.LeaderLastIndex)
+		return r.failAppend(r.store.Writer().LastIndex())
+	}
+
+	// Entry terms must be non-zero, non-decreasing within the request and no greater than the leader's term.
+	var term raft.Term
+	for i, entry := range request.Entries {
+		index := request.PrevLogIndex + raft.Index(i) + 1
+		if entry == nil {
+			r.log.Warn("Rejected %v: entry %d is missing", request, index)
+			return r.failAppend(r.store.Writer().LastIndex())
+		}
+		if entry.Term == 0 || entry.Term < term || entry.Term > request.Term {
+			r.log.Warn("Rejected %v: entry %d term (%d) is out of sequence", request, index, entry.Term)
+			return r.failAppend(r.store.Writer().LastIndex())
+		}
+		term = entry.Term
+	}
+	return nil
+}
+
+// checkSnapshotRequired rejects the request and requests a snapshot from the leader if snapshot requests are
+// enabled and the leader's log no longer contains the entries following the local log. A member that is too
+// far behind to catch up via appends requests the snapshot rather than waiting for the leader to notice.
+func (r *PassiveRole) checkSnapshotRequired(request *raft.AppendRequest) *raft.AppendResponse {
+	if !r.raft.Config().GetRequestSnapshots() || request.LeaderFirstIndex == 0 {
+		return nil
+	}
+	lastIndex := r.store.Writer().LastIndex()
+	if request.LeaderFirstIndex > lastIndex+1 {
+		r.log.Debug("Rejected %v: leader's first index (%d) is greater than the local log's next index (%d); requesting snapshot", request, request.LeaderFirstIndex, lastIndex+1)
+		response := r.failAppend(lastIndex)
+		response.SnapshotRequested = true
+		return response
+	}
+	return nil
+}
+
 // checkPreviousEntry compares the given request to the previous entry in the log
 func (r *PassiveRole) checkPreviousEntry(request *raft.AppendRequest) *raft.AppendResponse {
 	writer := r.store.Writer()
@@ -98,6 +216,12 @@ func (r *PassiveRole) checkPreviousEntry(request *raft.AppendRequest) *raft.Appe
 	// log at the previous log index. It's possible that the leader can send a non-zero previous log index
 	// with a zero term in the event the leader has compacted its logs and is sending the first entry.
 	if request.PrevLogTerm != 0 {
+		// If the previous entry has been compacted from the local log, it was committed before it was
+		// compacted and therefore matches the leader's log.
+		if request.PrevLogIndex < reader.FirstIndex() {
+			return nil
+		}
+
 		// Get the last entry written to the log.
 		lastEntry := writer.LastEntry()
 
@@ -125,11 +249,17 @@ func (r *PassiveRole) checkPreviousEntry(request *raft.AppendRequest) *raft.Appe
 
 				// Read the previous entry and validate that the term matches the request previous log term.
 				if request.PrevLogTerm != previousEntry.Entry.Term {
+					if response := r.checkCommittedConflict(request.PrevLogIndex, previousEntry.Entry.Term, request.PrevLogTerm); response != nil {
+						return response
+					}
 					r.log.Debug("Rejected %v: Previous entry term (%d) does not match local log's term for the same entry (%d)", request, request.PrevLogTerm, previousEntry.Entry.Term)
 					return r.failAppend(request.PrevLogIndex - 1)
 				}
 				// If the previous log term doesn't equal the last entry term, fail the append, sending the prior entry.
 			} else if request.PrevLogTerm != lastEntry.Entry.Term {
+				if response := r.checkCommittedConflict(request.PrevLogIndex, lastEntry.Entry.Term, request.PrevLogTerm); response != nil {
+					return response
+				}
 				r.log.Debug("Rejected %v: Previous entry term (%d) does not equal the local log's last term (%d)", request, request.PrevLogTerm, lastEntry.Entry.Term)
 				return r.failAppend(request.PrevLogIndex - 1)
 			}
@@ -146,15 +276,12 @@ func (r *PassiveRole) checkPreviousEntry(request *raft.AppendRequest) *raft.Appe
 
 // appendEntries appends entries from the given request to the log
 func (r *PassiveRole) appendEntries(request *raft.AppendRequest) (*raft.AppendResponse, error) {
-	// Compute the last entry index from the previous log index and request entry count.
-	lastEntryIndex := request.PrevLogIndex + raft.Index(len(request.Entries))
-
-	// Ensure the commitIndex is not increased beyond the index of the last entry in the request.
-	commitIndex := raft.Index(math.Max(float64(r.raft.CommitIndex()), math.Min(float64(request.CommitIndex), float64(lastEntryIndex))))
-
 	// Track the last log index while entries are appended.
 	index := request.PrevLogIndex
 
+	// Track the number of entries truncated from the log.
+	var truncated uint64
+
 	if len(request.Entries) > 0 {
 		writer := r.store.Writer()
 		reader := r.store.Reader()
@@ -166,8 +293,27 @@ func (r *PassiveRole) appendEntries(request *raft.AppendRequest) (*raft.AppendRe
 			writer.Reset(request.PrevLogIndex + 1)
 		}
 
+		// Track the size of the entries persisted. If the size exceeds the configured limit, stop
+		// persisting entries and report the last persisted index to the leader. At least one entry
+		// is always persisted to ensure progress.
+		maxSize := int(r.raft.Config().GetMaxAppendSize())
+		size := 0
+
+		// Entries preceding the first index in the log have been compacted. Compacted entries were committed
+		// and therefore match the leader's entries, so they're skipped rather than appended.
+		firstIndex := reader.FirstIndex()
+
 		// Iterate through entries and append them.
 		for _, entry := range request.Entries {
+			if index+1 < firstIndex {
+				index++
+				continue
+			}
+			if maxSize > 0 && size >= maxSize {
+				r.log.Debug("Persisted %d of %d entries; append size limit reached", index-request.PrevLogIndex, len(request.Entries))
+				break
+			}
+			size += entry.XXX_Size()
 			index++
 
 			// Get the last entry written to the log by the writer.
@@ -189,19 +335,25 @@ func (r *PassiveRole) appendEntries(request *raft.AppendRequest) (*raft.AppendRe
 					}
 
 					// If the existing entry term doesn't match the leader's term for the same entry, truncate
-					// the log and append the leader's entry.
+					// the log and append the leader's entry. Committed entries are never truncated.
 					if existingEntry.Entry.Term != entry.Term {
-						writer.Truncate(index - 1)
-						writer.Append(entry)
+						if response := r.checkCommittedConflict(index, existingEntry.Entry.Term, entry.Term); response != nil {
+							return response, nil
+						}
+						truncated += r.truncateLog(index - 1)
+						r.appendEntry(entry)
 					}
 					// If the last written entry is equal to the append entry index, we don't need
 					// to read the entry from disk and can just compare the last entry in the writer.
 				} else if lastEntry.Index == index {
 					// If the last entry term doesn't match the leader's term for the same entry, truncate
-					// the log and append the leader's entry.
+					// the log and append the leader's entry. Committed entries are never truncated.
 					if lastEntry.Entry.Term != entry.Term {
-						writer.Truncate(index - 1)
-						indexed := writer.Append(entry)
+						if response := r.checkCommittedConflict(index, lastEntry.Entry.Term, entry.Term); response != nil {
+							return response, nil
+						}
+						truncated += r.truncateLog(index - 1)
+						indexed := r.appendEntry(entry)
 						r.log.Trace("Appended %v", indexed)
 					}
 					// Otherwise, this entry is being appended at the end of the log.
@@ -212,40 +364,121 @@ func (r *PassiveRole) appendEntries(request *raft.AppendRequest) (*raft.AppendRe
 					}
 
 					// Append the entry and log a message.
-					indexed := writer.Append(entry)
+					indexed := r.appendEntry(entry)
 					r.log.Trace("Appended %v", indexed)
 				}
 				// Otherwise, if the last entry is null just append the entry and log a message.
 			} else {
-				indexed := writer.Append(entry)
+				indexed := r.appendEntry(entry)
 				r.log.Trace("Appended %v", indexed)
 			}
 		}
 	}
 
+	// Ensure the commitIndex is not increased beyond the index of the last entry persisted from the request.
+	commitIndex := raft.Index(math.Max(float64(r.raft.CommitIndex()), math.Min(float64(request.CommitIndex), float64(index))))
+
 	// Update the context commit and global indices.
 	r.raft.SetCommitIndex(request.CommitIndex)
-	prevCommitIndex := r.raft.Commit(commitIndex)
-	if commitIndex > prevCommitIndex {
+	prevCommitIndex := r.raft.Commit(commitIndex, r.store.Writer().LastIndex())
+	if commitIndex = r.raft.CommitIndex(); commitIndex > prevCommitIndex {
 		r.log.Trace("Committed entries up to index %d", commitIndex)
 
-		// If the commitIndex was for entries that were replicated in a prior request, ensure they're applied
-		// to the state machine.
-		if commitIndex <= request.PrevLogIndex {
-			r.state.ApplyIndex(request.PrevLogIndex)
-		}
+		// Apply all newly committed entries to the state machine. Committed entries have already been
+		// written to the log, so the state machine reads them from the log in a single batch.
+		r.state.ApplyIndex(commitIndex)
+	}
 
-		// Iterate through entries in the request and apply committed entries to the state machine.
-		for i := 0; request.PrevLogIndex+raft.Index(i)+1 <= commitIndex && i < len(request.Entries); i++ {
-			r.state.ApplyEntry(&log.Entry{
-				Index: request.PrevLogIndex + raft.Index(i) + 1,
-				Entry: request.Entries[i],
-			}, nil)
-		}
+	// Return a successful append response, reporting the last persisted index and the number of entries
+	// truncated to the leader.
+	if truncated > 0 {
+		r.log.Warn("Truncated %d entries from the log", truncated)
+	}
+	response := r.succeedAppend(index)
+	response.Truncated = truncated
+	return response, nil
+}
+
+// appendEntry appends the given entry to the log, tracking the entry as the pending configuration,
+// read-only mode, or election freeze if it's a configuration, read-only mode, or election freeze change
+func (r *PassiveRole) appendEntry(entry *raft.LogEntry) *log.Entry {
+	indexed := r.store.Writer().Append(entry)
+	switch e := entry.Entry.(type) {
+	case *raft.LogEntry_Configuration:
+		r.raft.SetConfiguration(&raft.Configuration{
+			Index:      indexed.Index,
+			Term:       entry.Term,
+			Timestamp:  &entry.Timestamp,
+			Members:    e.Configuration.Members,
+			OldMembers: e.Configuration.OldMembers,
+		})
+	case *raft.LogEntry_ReadOnly:
+		r.raft.SetReadOnlyMode(indexed.Index, e.ReadOnly.ReadOnly)
+	case *raft.LogEntry_Freeze:
+		r.raft.SetElectionFreeze(indexed.Index, e.Freeze.Duration)
+	case *raft.LogEntry_Timing:
+		r.raft.SetTiming(indexed.Index, e.Timing.ElectionTimeout, e.Timing.HeartbeatInterval)
+	}
+	return indexed
+}
+
+// checkCommittedConflict returns an error response if the local entry at the given index is committed but
+// conflicts with the leader's entry. Only uncommitted entries, e.g. entries appended by a former leader
+// that were never replicated to a quorum, may be truncated. A conflicting committed entry indicates the
+// logs have diverged, so the append is rejected rather than truncating entries already applied to the
+// state machine, and the member stops serving stale reads.
+func (r *PassiveRole) checkCommittedConflict(index raft.Index, localTerm raft.Term, leaderTerm raft.Term) *raft.AppendResponse {
+	if index > r.raft.CommitIndex() {
+		return nil
+	}
+	r.log.Error("Committed entry %d with term %d conflicts with the leader's term %d; rejecting append", index, localTerm, leaderTerm)
+	r.raft.SetConsistent(false)
+	return &raft.AppendResponse{
+		Status: raft.ResponseStatus_ERROR,
+		Error:  raft.ResponseError_PROTOCOL_ERROR,
+		Term:   r.raft.Term(),
 	}
+}
+
+// truncateLog truncates the log to the given index, discarding the pending configuration, read-only
+// mode, election freeze, and timing change if they were truncated, and returns the number of entries truncated
+func (r *PassiveRole) truncateLog(index raft.Index) uint64 {
+	var truncated uint64
+	if lastIndex := r.store.Writer().LastIndex(); lastIndex > index {
+		truncated = uint64(lastIndex - index)
+	}
+	r.store.Writer().Truncate(index)
+	if _, pending := r.raft.Configuration(); pending != nil && pending.Index > index {
+		r.raft.SetConfiguration(nil)
+	}
+	r.raft.DiscardReadOnlyMode(index)
+	r.raft.DiscardElectionFreeze(index)
+	r.raft.DiscardTiming(index)
+	return truncated
+}
+
+// hashLog computes a hash of the entries in the given range of the log
+func (r *PassiveRole) hashLog(firstIndex raft.Index, lastIndex raft.Index) (uint64, error) {
+	reader := r.store.Log().OpenReader(firstIndex)
+	defer reader.Close()
+	if firstIndex < reader.FirstIndex() {
+		return 0, fmt.Errorf("entry %d has been compacted", firstIndex)
+	}
+	reader.Reset(firstIndex)
 
-	// Return a successful append response.
-	return r.succeedAppend(index), nil
+	hash := fnv.New64a()
+	for index := firstIndex; index <= lastIndex; index++ {
+		entry := reader.NextEntry()
+		if entry == nil || entry.Index != index {
+			return 0, fmt.Errorf("entry %d is missing from the log", index)
+		}
+		bytes, err := entry.Entry.Marshal()
+		if err != nil {
+			return 0, err
+		}
+		_, _ = hash.Write(bytes)
+	}
+	return hash.Sum64(), nil
 }
 
 // failAppend returns a failed AppendResponse
@@ -265,15 +498,61 @@ func (r *PassiveRole) completeAppend(succeeded bool, lastIndex raft.Index) *raft
 		Term:         r.raft.Term(),
 		Succeeded:    succeeded,
 		LastLogIndex: lastIndex,
+		LastLogTerm:  r.entryTerm(lastIndex),
+	}
+}
+
+// entryTerm returns the term of the entry at the given index in the local log, or 0 if the entry is not present
+func (r *PassiveRole) entryTerm(index raft.Index) raft.Term {
+	if index == 0 {
+		return 0
+	}
+	if lastEntry := r.store.Writer().LastEntry(); lastEntry != nil && lastEntry.Index == index {
+		return lastEntry.Entry.Term
+	}
+	reader := r.store.Reader()
+	if index < reader.FirstIndex() || index > reader.LastIndex() {
+		return 0
+	}
+	reader.Reset(index)
+	if entry := reader.NextEntry(); entry != nil && entry.Index == index {
+		return entry.Entry.Term
+	}
+	return 0
+}
+
+// ConfirmLeadership handles a leadership confirmation request
+func (r *PassiveRole) ConfirmLeadership(ctx context.Context, request *raft.ConfirmLeadershipRequest) (*raft.ConfirmLeadershipResponse, error) {
+	r.log.Request("ConfirmLeadershipRequest", request)
+	r.raft.WriteLock()
+	defer r.raft.WriteUnlock()
+	r.updateTermAndLeader(request.Term, &request.Leader)
+	response := r.handleConfirmLeadership(request)
+	_ = r.log.Response("ConfirmLeadershipResponse", response, nil)
+	return response, nil
+}
+
+// handleConfirmLeadership acknowledges the requesting leader if it's the known leader for the current term.
+// The caller must hold a write lock on the Raft state.
+func (r *PassiveRole) handleConfirmLeadership(request *raft.ConfirmLeadershipRequest) *raft.ConfirmLeadershipResponse {
+	leader := r.raft.Leader()
+	return &raft.ConfirmLeadershipResponse{
+		Status:    raft.ResponseStatus_OK,
+		Term:      r.raft.Term(),
+		Confirmed: request.Term == r.raft.Term() && leader != nil && *leader == request.Leader,
 	}
 }
 
 // Install handles an install request
 func (r *PassiveRole) Install(ch <-chan *raft.InstallStreamRequest) (*raft.InstallResponse, error) {
-	var writer io.WriteCloser
+	var pending snapshot.PendingSnapshot
+	var readOnly bool
+	var freeze time.Duration
+	skip := false
 	for message := range ch {
+		// If the stream is broken, the received chunks remain pending to allow the leader to resume the
+		// install from the last received offset.
 		if message.Failed() {
-			writer.Close()
 			_ = r.log.Response("InstallResponse", nil, message.Error)
 			return nil, message.Error
 		}
@@ -289,6 +568,7 @@ func (r *PassiveRole) Install(ch <-chan *raft.InstallStreamRequest) (*raft.Insta
 
 		// If the request is for a lesser term, reject the request.
 		if request.Term < r.raft.Term() {
+			r.raft.WriteUnlock()
 			response := &raft.InstallResponse{
 				Status: raft.ResponseStatus_ERROR,
 				Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
@@ -297,24 +577,65 @@ func (r *PassiveRole) Install(ch <-chan *raft.InstallStreamRequest) (*raft.Insta
 			return response, nil
 		}
 
-		if writer == nil {
-			snapshot := r.store.Snapshot().NewSnapshot(request.Index, request.Timestamp)
-			writer = snapshot.Writer()
+		// If the state machine has already applied the snapshot index, the install is redundant, e.g. a retry
+		// of an install that was completed. Skip the snapshot rather than replacing the current snapshot with
+		// an older one, but consume the remainder of the stream before responding.
+		if pending == nil && !skip && request.Index <= r.raft.AppliedIndex() {
+			r.log.Debug("Skipping snapshot %d: already applied up to %d", request.Index, r.raft.AppliedIndex())
+			skip = true
+		}
+		if skip {
+			r.raft.WriteUnlock()
+			continue
 		}
 
-		_, err := writer.Write(request.Data)
-		r.raft.WriteUnlock()
-		if err != nil {
+		// If the snapshot was written in a format this member does not understand, e.g. by a newer leader during
+		// a rolling upgrade, reject the install before creating the snapshot to avoid corrupting the local state.
+		if pending == nil && !snapshot.IsSupportedVersion(request.Version) {
+			r.raft.WriteUnlock()
+			r.log.Warn("Rejecting snapshot %d: unsupported snapshot format version %d", request.Index, request.Version)
 			response := &raft.InstallResponse{
 				Status: raft.ResponseStatus_ERROR,
-				Error:  raft.ResponseError_PROTOCOL_ERROR,
+				Error:  raft.ResponseError_INCOMPATIBLE_SNAPSHOT,
 			}
 			_ = r.log.Response("InstallResponse", response, nil)
 			return response, nil
 		}
+
+		// The first chunk in the stream positions the install at the chunk's offset, resuming a pending
+		// snapshot at the same index. Chunks following the first chunk are appended contiguously.
+		offset := request.Offset
+		if pending == nil {
+			pending = r.store.Snapshot().PendingSnapshot(request.Index, request.SnapshotTerm, request.Timestamp)
+		} else {
+			offset = pending.Offset()
+		}
+
+		// If the chunk is corrupt or begins beyond the received bytes, reject the install with the offset
+		// of the received bytes to allow the leader to resume the install from that offset.
+		// A zero checksum denotes a chunk sent by a leader that predates chunk checksums.
+		if request.Checksum != 0 && crc32.ChecksumIEEE(request.Data) != request.Checksum {
+			r.log.Warn("Rejecting snapshot %d: chunk at offset %d failed checksum verification", request.Index, offset)
+			return r.failInstall(pending), nil
+		}
+		if err := pending.WriteAt(request.Data, offset); err != nil {
+			r.log.Warn("Rejecting snapshot %d: %s", request.Index, err)
+			return r.failInstall(pending), nil
+		}
+		readOnly = request.ReadOnly
+		freeze = request.ElectionFreeze
+		r.raft.WriteUnlock()
 	}
 
-	writer.Close()
+	// Commit the snapshot and restore the read-only mode and election freeze committed by the leader, since
+	// the entries that changed them may have been compacted into the snapshot.
+	if pending != nil {
+		r.raft.WriteLock()
+		pending.Commit()
+		r.raft.SetReadOnlyMode(pending.Index(), readOnly)
+		r.raft.SetElectionFreeze(pending.Index(), freeze)
+		r.raft.WriteUnlock()
+	}
 	response := &raft.InstallResponse{
 		Status: raft.ResponseStatus_OK,
 	}
@@ -322,6 +643,66 @@ func (r *PassiveRole) Install(ch <-chan *raft.InstallStreamRequest) (*raft.Insta
 	return response, nil
 }
 
+// failInstall releases the write lock and returns a response rejecting the install of the pending snapshot
+// with the offset from which the install can be resumed
+func (r *PassiveRole) failInstall(pending snapshot.PendingSnapshot) *raft.InstallResponse {
+	response := &raft.InstallResponse{
+		Status: raft.ResponseStatus_ERROR,
+		Error:  raft.ResponseError_PROTOCOL_ERROR,
+		Offset: pending.Offset(),
+	}
+	r.raft.WriteUnlock()
+	_ = r.log.Response("InstallResponse", response, nil)
+	return response
+}
+
+// Join handles a join request.
+// Only the leader can add a member to the cluster, so the request is forwarded to the leader. A request that
+// has already been forwarded is not forwarded again to prevent requests from looping between members that
+// disagree about the leader; instead, the joining member is redirected to the leader known to this member.
+func (r *PassiveRole) Join(ctx context.Context, request *raft.JoinRequest) (*raft.JoinResponse, error) {
+	r.log.Request("JoinRequest", request)
+	r.raft.ReadLock()
+	leader := r.raft.Leader()
+	if leader == nil {
+		leader = r.raft.LastLeader()
+	}
+	term := r.raft.Term()
+	r.raft.ReadUnlock()
+
+	if leader == nil || request.Forwarded {
+		response := &raft.JoinResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+			Term:   term,
+		}
+		if leader == nil {
+			response.Error = raft.ResponseError_NO_LEADER
+		} else {
+			response.Leader = *leader
+		}
+		_ = r.log.Response("JoinResponse", response, nil)
+		return response, nil
+	}
+
+	r.log.Trace("Forwarding %v", request)
+	forward := *request
+	forward.Forwarded = true
+	response, err := r.raft.Protocol().Join(ctx, &forward, *leader)
+	if err != nil {
+		// If the leader cannot be reached, fall back to redirecting the joining member to the leader
+		r.log.Debug("Failed to forward join to %s: %s", *leader, err)
+		response = &raft.JoinResponse{
+			Status: raft.ResponseStatus_ERROR,
+			Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+			Term:   term,
+			Leader: *leader,
+		}
+	}
+	_ = r.log.Response("JoinResponse", response, nil)
+	return response, nil
+}
+
 // Command handles a command request
 func (r *PassiveRole) Command(request *raft.CommandRequest, ch chan<- *raft.CommandStreamResponse) error {
 	defer close(ch)
@@ -329,20 +710,46 @@ func (r *PassiveRole) Command(request *raft.CommandRequest, ch chan<- *raft.Comm
 	r.log.Request("CommandRequest", request)
 	r.raft.ReadLock()
 	leader := raft.MemberID("")
+	electionInProgress := false
 	if r.raft.Leader() != nil {
 		leader = *r.raft.Leader()
+	} else if r.raft.Config().ReportLastLeader && r.raft.LastLeader() != nil {
+		// If no leader is known for the current term, report the last known leader flagged as stale
+		// to allow the client to optimistically retry the prior leader, which may win re-election.
+		leader = *r.raft.LastLeader()
+		electionInProgress = true
 	}
 
 	response := &raft.CommandResponse{
+		Status:             raft.ResponseStatus_ERROR,
+		Error:              raft.ResponseError_ILLEGAL_MEMBER_STATE,
+		Leader:             leader,
+		Term:               r.raft.Term(),
+		ElectionInProgress: electionInProgress,
+	}
+	r.raft.ReadUnlock()
+	_ = r.log.Response("CommandResponse", response, nil)
+	ch <- raft.NewCommandStreamResponse(response, nil)
+	return nil
+}
+
+// CommandBatch handles a command batch request
+func (r *PassiveRole) CommandBatch(ctx context.Context, request *raft.CommandBatchRequest) (*raft.CommandBatchResponse, error) {
+	r.log.Request("CommandBatchRequest", request)
+	r.raft.ReadLock()
+	leader := raft.MemberID("")
+	if r.raft.Leader() != nil {
+		leader = *r.raft.Leader()
+	}
+	response := &raft.CommandBatchResponse{
 		Status: raft.ResponseStatus_ERROR,
 		Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
 		Leader: leader,
 		Term:   r.raft.Term(),
 	}
 	r.raft.ReadUnlock()
-	_ = r.log.Response("CommandResponse", response, nil)
-	ch <- raft.NewCommandStreamResponse(response, nil)
-	return nil
+	_ = r.log.Response("CommandBatchResponse", response, nil)
+	return response, nil
 }
 
 // Query handles a query request
@@ -350,6 +757,17 @@ func (r *PassiveRole) Query(request *raft.QueryRequest, ch chan<- *raft.QueryStr
 	defer close(ch)
 
 	r.log.Request("QueryRequest", request)
+
+	// If the client has observed an index the state machine has not yet applied, wait for the index to be
+	// applied before performing a sequential read. Other reads wait for the index on the leader.
+	if request.ReadConsistency == raft.ReadConsistency_SEQUENTIAL {
+		if response := r.checkMinAppliedIndex(request); response != nil {
+			_ = r.log.Response("QueryResponse", response, nil)
+			ch <- raft.NewQueryStreamResponse(response, nil)
+			return nil
+		}
+	}
+
 	r.raft.ReadLock()
 	leader := r.raft.Leader()
 
@@ -362,6 +780,20 @@ func (r *PassiveRole) Query(request *raft.QueryRequest, ch chan<- *raft.QueryStr
 		return r.forwardQuery(request, leader, ch)
 	}
 
+	// Witnesses don't store the commands applied to the state machine. Forward the query to the leader.
+	if r.raft.IsWitness(r.raft.Member()) {
+		r.raft.ReadUnlock()
+		r.log.Trace("Member is a witness, forwarding query to leader")
+		return r.forwardQuery(request, leader, ch)
+	}
+
+	// If the local log has diverged from the leader's log, stale reads cannot be trusted. Forward the query to the leader.
+	if !r.raft.IsConsistent() {
+		r.raft.ReadUnlock()
+		r.log.Trace("Log inconsistent with the leader, forwarding query to leader")
+		return r.forwardQuery(request, leader, ch)
+	}
+
 	// If the session's consistency level is SEQUENTIAL, handle the request here, otherwise forward it.
 	if request.ReadConsistency == raft.ReadConsistency_SEQUENTIAL {
 		// If the commit index is not in the log then we've fallen too far behind the leader to perform a local query.
@@ -388,12 +820,66 @@ func (r *PassiveRole) Query(request *raft.QueryRequest, ch chan<- *raft.QueryStr
 		// Release the read lock before applying the entry.
 		r.raft.ReadUnlock()
 
-		return r.applyQuery(entry, ch)
+		if err := r.applyQuery(entry, ch); err != nil {
+			return err
+		}
+		r.sendReadRepair(entry.Index, ch)
+		return nil
 	}
 	r.raft.ReadUnlock()
 	return r.forwardQuery(request, leader, ch)
 }
 
+// sendReadRepair waits for the next append from the leader after a stale read was served at the given index.
+// If the leader's commit index was ahead of the read, a read repair hint is sent to the client indicating
+// the number of entries by which the read may have been stale, allowing the client to re-read. The query
+// stream remains open until the next append is received or the configured read repair window expires.
+func (r *PassiveRole) sendReadRepair(index raft.Index, ch chan<- *raft.QueryStreamResponse) {
+	window := r.raft.Config().GetReadRepairWindow()
+	if window == nil || *window == 0 {
+		return
+	}
+
+	waiter := make(chan raft.Index, 1)
+	r.repairMu.Lock()
+	r.repairWaiters = append(r.repairWaiters, waiter)
+	r.repairMu.Unlock()
+
+	timer := time.NewTimer(*window)
+	defer timer.Stop()
+	select {
+	case commitIndex := <-waiter:
+		if commitIndex > index {
+			response := &raft.QueryResponse{
+				Status:     raft.ResponseStatus_OK,
+				ReadRepair: true,
+				StaleBy:    commitIndex - index,
+			}
+			_ = r.log.Response("QueryResponse", response, nil)
+			ch <- raft.NewQueryStreamResponse(response, nil)
+		}
+	case <-timer.C:
+		r.repairMu.Lock()
+		for i, w := range r.repairWaiters {
+			if w == waiter {
+				r.repairWaiters = append(r.repairWaiters[:i], r.repairWaiters[i+1:]...)
+				break
+			}
+		}
+		r.repairMu.Unlock()
+	}
+}
+
+// notifyReadRepair notifies reads awaiting read repair of the leader's commit index
+func (r *PassiveRole) notifyReadRepair(commitIndex raft.Index) {
+	r.repairMu.Lock()
+	defer r.repairMu.Unlock()
+	for _, waiter := range r.repairWaiters {
+		waiter <- commitIndex
+	}
+	r.repairWaiters = nil
+}
+
 // applyQuery applies a query to the state machine
 func (r *PassiveRole) applyQuery(entry *log.Entry, responseCh chan<- *raft.QueryStreamResponse) error {
 	// Create a result channel
@@ -16,13 +16,20 @@ package roles
 
 import (
 	"context"
+	"errors"
 	"github.com/atomix/go-framework/pkg/atomix/service"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/protocol/mock"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store/log"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store/snapshot"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"hash/crc32"
+	"math"
+	"sync"
 	"testing"
 	"time"
 )
@@ -236,6 +243,97 @@ func TestPassiveCommand(t *testing.T) {
 	assert.Equal(t, role.raft.Members()[1], response.Response.Leader)
 }
 
+func TestPassiveCommandLastLeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	assert.NoError(t, role.raft.SetLeader(&role.raft.Members()[1]))
+
+	command := func() *raft.CommandResponse {
+		ch := make(chan *raft.CommandStreamResponse, 1)
+		assert.NoError(t, role.Command(&raft.CommandRequest{}, ch))
+		response := <-ch
+		assert.True(t, response.Succeeded())
+		assert.Equal(t, raft.ResponseStatus_ERROR, response.Response.Status)
+		assert.Equal(t, raft.ResponseError_ILLEGAL_MEMBER_STATE, response.Response.Error)
+		return response.Response
+	}
+
+	// Start an election and verify the last leader is not reported by default
+	assert.NoError(t, role.raft.SetTerm(raft.Term(2)))
+	response := command()
+	assert.Equal(t, raft.MemberID(""), response.Leader)
+	assert.False(t, response.ElectionInProgress)
+
+	// Verify the last leader is reported as stale during the election when enabled
+	role.raft.Config().ReportLastLeader = true
+	response = command()
+	assert.Equal(t, raft.Term(2), response.Term)
+	assert.Equal(t, role.raft.Members()[1], response.Leader)
+	assert.True(t, response.ElectionInProgress)
+
+	// Verify the new leader is reported once the election completes
+	assert.NoError(t, role.raft.SetLeader(&role.raft.Members()[2]))
+	response = command()
+	assert.Equal(t, role.raft.Members()[2], response.Leader)
+	assert.False(t, response.ElectionInProgress)
+}
+
+func TestPassiveJoin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	protocol, sm, stores := newTestState(client)
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	member := &raft.Member{
+		MemberID: "qux",
+		Type:     raft.Member_ACTIVE,
+	}
+
+	// Verify joins are rejected when no leader is known
+	response, err := role.Join(context.TODO(), &raft.JoinRequest{Member: member})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_NO_LEADER, response.Error)
+
+	// Verify joins are forwarded to the leader
+	leader := role.raft.Members()[1]
+	assert.NoError(t, role.raft.SetLeader(&leader))
+	client.EXPECT().
+		Join(gomock.Any(), gomock.Any(), leader).
+		DoAndReturn(func(ctx context.Context, request *raft.JoinRequest, member raft.MemberID) (*raft.JoinResponse, error) {
+			assert.True(t, request.Forwarded)
+			return &raft.JoinResponse{
+				Status:      raft.ResponseStatus_OK,
+				Leader:      leader,
+				CommitIndex: raft.Index(10),
+			}, nil
+		})
+	response, err = role.Join(context.TODO(), &raft.JoinRequest{Member: member})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.Equal(t, leader, response.Leader)
+	assert.Equal(t, raft.Index(10), response.CommitIndex)
+
+	// Verify the joining member is redirected to the leader if the leader cannot be reached
+	client.EXPECT().
+		Join(gomock.Any(), gomock.Any(), leader).
+		Return(nil, errors.New("JoinRequest failed"))
+	response, err = role.Join(context.TODO(), &raft.JoinRequest{Member: member})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_ILLEGAL_MEMBER_STATE, response.Error)
+	assert.Equal(t, leader, response.Leader)
+
+	// Verify forwarded joins are redirected rather than forwarded again
+	response, err = role.Join(context.TODO(), &raft.JoinRequest{Member: member, Forwarded: true})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_ILLEGAL_MEMBER_STATE, response.Error)
+	assert.Equal(t, leader, response.Leader)
+}
+
 func TestPassiveQuery(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock.NewMockClient(ctrl)
@@ -277,7 +375,7 @@ func TestPassiveQuery(t *testing.T) {
 		},
 	})
 	role.raft.SetCommitIndex(raft.Index(1))
-	role.raft.Commit(raft.Index(1))
+	role.raft.Commit(raft.Index(1), raft.Index(1))
 	ch = make(chan *raft.QueryStreamResponse, 1)
 	err = role.Query(&raft.QueryRequest{
 		Value:           bytes,
@@ -352,3 +450,969 @@ func TestPassiveInstall(t *testing.T) {
 	assert.Equal(t, "abc", string(bytes))
 	role.raft.ReadUnlock()
 }
+
+func TestPassiveInstallReadOnlyAndFreeze(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	expectQuery(client).AnyTimes()
+	protocol, sm, stores := newTestState(client)
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	leader := raft.MemberID("bar")
+	assert.NoError(t, role.raft.SetLeader(&leader))
+	assert.False(t, role.raft.IsReadOnly())
+
+	ch := make(chan *raft.InstallStreamRequest, 1)
+	ch <- raft.NewInstallStreamRequest(&raft.InstallRequest{
+		Term:           raft.Term(1),
+		Leader:         *role.raft.Leader(),
+		Index:          raft.Index(10),
+		Timestamp:      time.Now(),
+		Data:           []byte("a"),
+		ReadOnly:       true,
+		ElectionFreeze: time.Minute,
+	}, nil)
+	close(ch)
+
+	response, err := role.Install(ch)
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+
+	// Verify the read-only mode and election freeze carried by the snapshot take effect once the snapshot
+	// index is committed
+	role.raft.WriteLock()
+	assert.True(t, role.raft.IsReadOnly())
+	assert.False(t, role.raft.ElectionsFrozen())
+	role.raft.Commit(raft.Index(10), raft.Index(10))
+	assert.True(t, role.raft.ReadOnlyMode())
+	assert.True(t, role.raft.ElectionsFrozen())
+	role.raft.WriteUnlock()
+}
+
+func TestPassiveInstallResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	expectQuery(client).AnyTimes()
+	protocol, sm, stores := newTestState(client)
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	leader := raft.MemberID("bar")
+	assert.NoError(t, role.raft.SetLeader(&leader))
+
+	timestamp := time.Now()
+	newRequest := func(offset uint64, data string) *raft.InstallStreamRequest {
+		return raft.NewInstallStreamRequest(&raft.InstallRequest{
+			Term:      raft.Term(1),
+			Leader:    leader,
+			Index:     raft.Index(10),
+			Timestamp: timestamp,
+			Data:      []byte(data),
+			Offset:    offset,
+			Checksum:  crc32.ChecksumIEEE([]byte(data)),
+		}, nil)
+	}
+
+	// Break the stream after the first chunk
+	ch := make(chan *raft.InstallStreamRequest, 2)
+	ch <- newRequest(0, "ab")
+	ch <- raft.NewInstallStreamRequest(nil, errors.New("stream broken"))
+	close(ch)
+	_, err := role.Install(ch)
+	assert.Error(t, err)
+	assert.Nil(t, role.store.Snapshot().CurrentSnapshot())
+
+	// Verify an install beyond the received bytes is rejected with the offset of the received bytes
+	ch = make(chan *raft.InstallStreamRequest, 1)
+	ch <- newRequest(4, "ef")
+	close(ch)
+	response, err := role.Install(ch)
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_PROTOCOL_ERROR, response.Error)
+	assert.Equal(t, uint64(2), response.Offset)
+
+	// Verify a corrupt chunk is rejected with the offset of the received bytes
+	corrupt := newRequest(2, "cd")
+	corrupt.Request.Data = []byte("cx")
+	ch = make(chan *raft.InstallStreamRequest, 1)
+	ch <- corrupt
+	close(ch)
+	response, err = role.Install(ch)
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseError_PROTOCOL_ERROR, response.Error)
+	assert.Equal(t, uint64(2), response.Offset)
+
+	// Resume the install from the received offset
+	ch = make(chan *raft.InstallStreamRequest, 2)
+	ch <- newRequest(2, "cd")
+	ch <- newRequest(4, "ef")
+	close(ch)
+	response, err = role.Install(ch)
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+
+	role.raft.ReadLock()
+	snapshot := role.store.Snapshot().CurrentSnapshot()
+	assert.Equal(t, raft.Index(10), snapshot.Index())
+	assert.NoError(t, snapshot.Verify())
+	bytes := make([]byte, 6)
+	_, _ = snapshot.Reader().Read(bytes)
+	assert.Equal(t, "abcdef", string(bytes))
+	role.raft.ReadUnlock()
+}
+
+func TestPassiveInstallAlreadyApplied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	expectQuery(client).AnyTimes()
+	protocol, sm, stores := newTestState(client)
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	leader := raft.MemberID("bar")
+	assert.NoError(t, role.raft.SetLeader(&leader))
+
+	install := func(index raft.Index, data string) *raft.InstallResponse {
+		ch := make(chan *raft.InstallStreamRequest, len(data))
+		for _, b := range []byte(data) {
+			ch <- raft.NewInstallStreamRequest(&raft.InstallRequest{
+				Term:      raft.Term(1),
+				Leader:    leader,
+				Index:     index,
+				Timestamp: time.Now(),
+				Data:      []byte{b},
+			}, nil)
+		}
+		close(ch)
+		response, err := role.Install(ch)
+		assert.NoError(t, err)
+		return response
+	}
+
+	// Install a snapshot and apply entries beyond the snapshot index
+	assert.Equal(t, raft.ResponseStatus_OK, install(raft.Index(20), "abc").Status)
+	role.raft.SetAppliedIndex(raft.Index(25))
+
+	// Verify installs of snapshots at or below the applied index succeed without replacing the current snapshot
+	assert.Equal(t, raft.ResponseStatus_OK, install(raft.Index(10), "xyz").Status)
+	assert.Equal(t, raft.ResponseStatus_OK, install(raft.Index(25), "xyz").Status)
+
+	role.raft.ReadLock()
+	snapshot := role.store.Snapshot().CurrentSnapshot()
+	assert.Equal(t, raft.Index(20), snapshot.Index())
+	reader := snapshot.Reader()
+	bytes := make([]byte, 3)
+	_, _ = reader.Read(bytes)
+	assert.Equal(t, "abc", string(bytes))
+	role.raft.ReadUnlock()
+
+	// Verify a snapshot beyond the applied index is installed
+	assert.Equal(t, raft.ResponseStatus_OK, install(raft.Index(30), "def").Status)
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(30), role.store.Snapshot().CurrentSnapshot().Index())
+	role.raft.ReadUnlock()
+}
+
+func TestPassiveInstallIncompatibleVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock.NewMockClient(ctrl)
+	expectQuery(client).AnyTimes()
+	protocol, sm, stores := newTestState(client)
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+	assert.NoError(t, role.raft.SetTerm(raft.Term(1)))
+	leader := raft.MemberID("bar")
+	assert.NoError(t, role.raft.SetLeader(&leader))
+
+	install := func(index raft.Index, version uint32, data string) *raft.InstallResponse {
+		ch := make(chan *raft.InstallStreamRequest, len(data))
+		for _, b := range []byte(data) {
+			ch <- raft.NewInstallStreamRequest(&raft.InstallRequest{
+				Term:      raft.Term(1),
+				Leader:    leader,
+				Index:     index,
+				Timestamp: time.Now(),
+				Data:      []byte{b},
+				Version:   version,
+			}, nil)
+		}
+		close(ch)
+		response, err := role.Install(ch)
+		assert.NoError(t, err)
+		return response
+	}
+
+	// Install a snapshot in the current format
+	assert.Equal(t, raft.ResponseStatus_OK, install(raft.Index(10), snapshot.Version, "abc").Status)
+
+	// Verify a snapshot in an unknown future format is rejected
+	response := install(raft.Index(20), snapshot.Version+1, "xyz")
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.ResponseError_INCOMPATIBLE_SNAPSHOT, response.Error)
+
+	// Verify the rejected snapshot did not replace or modify the current snapshot
+	role.raft.ReadLock()
+	current := role.store.Snapshot().CurrentSnapshot()
+	assert.Equal(t, raft.Index(10), current.Index())
+	assert.NoError(t, current.Verify())
+	reader := current.Reader()
+	bytes := make([]byte, 3)
+	_, _ = reader.Read(bytes)
+	assert.Equal(t, "abc", string(bytes))
+	assert.Equal(t, raft.Term(1), role.raft.Term())
+	role.raft.ReadUnlock()
+
+	// Verify snapshots sent by leaders that predate snapshot versioning are installed
+	assert.Equal(t, raft.ResponseStatus_OK, install(raft.Index(30), 0, "def").Status)
+	role.raft.ReadLock()
+	assert.Equal(t, raft.Index(30), role.store.Snapshot().CurrentSnapshot().Index())
+	role.raft.ReadUnlock()
+}
+
+func TestPassiveAppendLag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	newEntry := func() *raft.LogEntry {
+		return &raft.LogEntry{
+			Term:      1,
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+	}
+
+	// Verify the lag is computed from the leader's last index and the entries appended
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:            1,
+		Leader:          "bar",
+		Entries:         []*raft.LogEntry{newEntry(), newEntry(), newEntry()},
+		CommitIndex:     2,
+		LeaderLastIndex: 10,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.Index(7), role.raft.Lag())
+
+	// Verify a heartbeat updates the lag as the leader's log grows
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:            1,
+		Leader:          "bar",
+		PrevLogIndex:    3,
+		PrevLogTerm:     1,
+		CommitIndex:     3,
+		LeaderLastIndex: 12,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.Index(9), role.raft.Lag())
+
+	// Verify a rejected append from a lagging follower still reports the lag
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:            1,
+		Leader:          "bar",
+		PrevLogIndex:    11,
+		PrevLogTerm:     1,
+		Entries:         []*raft.LogEntry{newEntry()},
+		CommitIndex:     11,
+		LeaderLastIndex: 12,
+	})
+	assert.NoError(t, err)
+	assert.False(t, response.Succeeded)
+	assert.Equal(t, raft.Index(9), role.raft.Lag())
+
+	// Verify the lag is cleared once the follower catches up
+	entries := make([]*raft.LogEntry, 9)
+	for i := range entries {
+		entries[i] = newEntry()
+	}
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:            1,
+		Leader:          "bar",
+		PrevLogIndex:    3,
+		PrevLogTerm:     1,
+		Entries:         entries,
+		CommitIndex:     12,
+		LeaderLastIndex: 12,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.Index(0), role.raft.Lag())
+}
+
+func TestPassiveAppendHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	role.raft.ReadLock()
+	assert.False(t, role.raft.Health().Quorum)
+	role.raft.ReadUnlock()
+
+	// Verify an accepted append request records a heartbeat carrying the leader's commit index
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:   1,
+		Leader: "bar",
+		Entries: []*raft.LogEntry{
+			{
+				Term:      1,
+				Timestamp: time.Now(),
+				Entry: &raft.LogEntry_Initialize{
+					Initialize: &raft.InitializeEntry{},
+				},
+			},
+		},
+		CommitIndex: 3,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+
+	role.raft.ReadLock()
+	health := role.raft.Health()
+	role.raft.ReadUnlock()
+	assert.True(t, health.Quorum)
+	assert.Equal(t, raft.Index(3), health.LeaderCommitIndex)
+	assert.Equal(t, health.LeaderCommitIndex-health.CommitIndex, health.CommitLag)
+	assert.False(t, health.LastHeartbeat.IsZero())
+}
+
+func TestPassiveAppendEntrySequence(t *testing.T) {
+	newEntry := func(term raft.Term) *raft.LogEntry {
+		return &raft.LogEntry{
+			Term:      term,
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		request   *raft.AppendRequest
+		succeeded bool
+	}{
+		{
+			name: "valid",
+			request: &raft.AppendRequest{
+				PrevLogIndex:    0,
+				Entries:         []*raft.LogEntry{newEntry(1), newEntry(2)},
+				LeaderLastIndex: 2,
+			},
+			succeeded: true,
+		},
+		{
+			name: "zero index",
+			request: &raft.AppendRequest{
+				PrevLogIndex: math.MaxUint64,
+				Entries:      []*raft.LogEntry{newEntry(2)},
+			},
+		},
+		{
+			name: "non-monotonic index",
+			request: &raft.AppendRequest{
+				PrevLogIndex: math.MaxUint64 - 1,
+				Entries:      []*raft.LogEntry{newEntry(2), newEntry(2)},
+			},
+		},
+		{
+			name: "duplicate index",
+			request: &raft.AppendRequest{
+				PrevLogIndex:    0,
+				Entries:         []*raft.LogEntry{newEntry(2), newEntry(2)},
+				LeaderLastIndex: 1,
+			},
+		},
+		{
+			name: "missing entry",
+			request: &raft.AppendRequest{
+				PrevLogIndex: 0,
+				Entries:      []*raft.LogEntry{newEntry(2), nil},
+			},
+		},
+		{
+			name: "zero term",
+			request: &raft.AppendRequest{
+				PrevLogIndex: 0,
+				Entries:      []*raft.LogEntry{newEntry(0)},
+			},
+		},
+		{
+			name: "decreasing term",
+			request: &raft.AppendRequest{
+				PrevLogIndex: 0,
+				Entries:      []*raft.LogEntry{newEntry(2), newEntry(1)},
+			},
+		},
+		{
+			name: "future term",
+			request: &raft.AppendRequest{
+				PrevLogIndex: 0,
+				Entries:      []*raft.LogEntry{newEntry(2), newEntry(3)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+			role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+			test.request.Term = 2
+			test.request.Leader = "bar"
+			response, err := role.Append(context.TODO(), test.request)
+			assert.NoError(t, err)
+			assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+			assert.Equal(t, test.succeeded, response.Succeeded)
+
+			// Verify no entries are appended from a rejected request
+			if !test.succeeded {
+				assert.Equal(t, raft.Index(0), response.LastLogIndex)
+				assert.Nil(t, stores.Writer().LastEntry())
+			}
+		})
+	}
+}
+
+func TestPassiveAppendRequestSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	newRequest := func() *raft.AppendRequest {
+		return &raft.AppendRequest{
+			Term:         1,
+			Leader:       "bar",
+			PrevLogIndex: 100,
+			Entries: []*raft.LogEntry{
+				{
+					Term:      1,
+					Timestamp: time.Now(),
+					Entry: &raft.LogEntry_Initialize{
+						Initialize: &raft.InitializeEntry{},
+					},
+				},
+			},
+			CommitIndex:      101,
+			LeaderLastIndex:  101,
+			LeaderFirstIndex: 101,
+		}
+	}
+
+	// Verify a far-behind member requests a snapshot once the leader has compacted the entries it needs
+	role.raft.Config().RequestSnapshots = true
+	response, err := role.Append(context.TODO(), newRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.False(t, response.Succeeded)
+	assert.True(t, response.SnapshotRequested)
+	assert.Equal(t, raft.Index(0), response.LastLogIndex)
+	assert.Equal(t, raft.Index(0), role.store.Writer().LastIndex())
+
+	// Verify snapshots are not requested once the member's log follows the leader's first index
+	role.store.Writer().Reset(101)
+	request := newRequest()
+	request.PrevLogIndex = 100
+	response, err = role.Append(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.False(t, response.SnapshotRequested)
+	assert.Equal(t, raft.Index(101), role.store.Writer().LastIndex())
+
+	// Verify snapshots are not requested when snapshot requests are disabled
+	role.raft.Config().RequestSnapshots = false
+	role.store.Writer().Reset(1)
+	response, err = role.Append(context.TODO(), newRequest())
+	assert.NoError(t, err)
+	assert.False(t, response.SnapshotRequested)
+}
+
+func TestPassiveAppendTruncated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	newEntry := func(term raft.Term) *raft.LogEntry {
+		return &raft.LogEntry{
+			Term:      term,
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+	}
+
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:    1,
+		Leader:  "bar",
+		Entries: []*raft.LogEntry{newEntry(1), newEntry(1), newEntry(1)},
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, uint64(0), response.Truncated)
+
+	// Verify the number of entries truncated by a divergent append is reported to the leader
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         2,
+		Leader:       "baz",
+		PrevLogIndex: 1,
+		PrevLogTerm:  1,
+		Entries:      []*raft.LogEntry{newEntry(2)},
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.Index(2), response.LastLogIndex)
+	assert.Equal(t, raft.Term(2), response.LastLogTerm)
+	assert.Equal(t, uint64(2), response.Truncated)
+	assert.Equal(t, raft.Index(2), role.store.Writer().LastIndex())
+
+	// Verify the term of a prior entry is reported for a heartbeat
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         2,
+		Leader:       "baz",
+		PrevLogIndex: 1,
+		PrevLogTerm:  1,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.Index(1), response.LastLogIndex)
+	assert.Equal(t, raft.Term(1), response.LastLogTerm)
+}
+
+func TestPassiveAppendCompacted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	newEntry := func(term raft.Term) *raft.LogEntry {
+		return &raft.LogEntry{
+			Term:      term,
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+	}
+
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:        1,
+		Leader:      "bar",
+		Entries:     []*raft.LogEntry{newEntry(1), newEntry(1), newEntry(1)},
+		CommitIndex: 3,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	role.store.Writer().Compact(raft.Index(2))
+
+	// Verify entries preceding the first index in the log are skipped when the previous entry was compacted
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         1,
+		Leader:       "bar",
+		PrevLogIndex: 1,
+		PrevLogTerm:  1,
+		Entries:      []*raft.LogEntry{newEntry(1), newEntry(1), newEntry(1)},
+		CommitIndex:  3,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.Index(4), response.LastLogIndex)
+	assert.Equal(t, uint64(0), response.Truncated)
+	assert.Equal(t, raft.Index(3), role.store.Reader().FirstIndex())
+	assert.Equal(t, raft.Index(4), role.store.Writer().LastIndex())
+
+	// Verify entries can be appended once the entire log has been compacted
+	role.store.Writer().Compact(raft.Index(4))
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         1,
+		Leader:       "bar",
+		PrevLogIndex: 4,
+		PrevLogTerm:  1,
+		Entries:      []*raft.LogEntry{newEntry(1)},
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.Index(5), response.LastLogIndex)
+	assert.Equal(t, raft.Index(5), role.store.Writer().LastIndex())
+}
+
+func TestPassiveAppendClock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	clock := &testClock{offset: time.Hour}
+	protocol.SetClock(clock)
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	// Verify the local time is not returned if the leader did not include its time in the request
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:   1,
+		Leader: "bar",
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.True(t, response.Timestamp.IsZero())
+
+	// Verify the local time at which the request was received is returned to the leader
+	before := clock.Now()
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:      1,
+		Leader:    "bar",
+		Timestamp: time.Now(),
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.False(t, response.Timestamp.Before(before))
+	assert.False(t, response.Timestamp.After(clock.Now()))
+}
+
+func TestPassiveAppendPartial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	timestamp := time.Now()
+	entries := make([]*raft.LogEntry, 5)
+	for i := range entries {
+		entries[i] = &raft.LogEntry{
+			Term:      1,
+			Timestamp: timestamp,
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+	}
+
+	// Limit the size of entries persisted from a single request to two entries
+	protocol.Config().MaxAppendSize = uint32(entries[0].Size() * 2)
+
+	// Verify only the entries within the size limit are persisted and acknowledged
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:        1,
+		Leader:      "bar",
+		Entries:     entries,
+		CommitIndex: 5,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.Index(2), response.LastLogIndex)
+	assert.Equal(t, raft.Term(1), response.LastLogTerm)
+	assert.Equal(t, raft.Index(2), role.store.Writer().LastIndex())
+
+	// Verify entries that were not persisted are not committed
+	protocol.ReadLock()
+	assert.Equal(t, raft.Index(2), protocol.CommitIndex())
+	protocol.ReadUnlock()
+
+	// Verify the remaining entries are persisted once resent by the leader
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         1,
+		Leader:       "bar",
+		PrevLogIndex: 2,
+		PrevLogTerm:  1,
+		Entries:      entries[2:],
+		CommitIndex:  5,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.Index(4), response.LastLogIndex)
+	assert.Equal(t, raft.Index(4), role.store.Writer().LastIndex())
+
+	// Verify at least one entry is persisted even if it exceeds the size limit
+	protocol.Config().MaxAppendSize = 1
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         1,
+		Leader:       "bar",
+		PrevLogIndex: 4,
+		PrevLogTerm:  1,
+		Entries:      entries[4:],
+		CommitIndex:  5,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, raft.Index(5), response.LastLogIndex)
+	assert.Equal(t, raft.Index(5), role.store.Writer().LastIndex())
+}
+
+func TestPassiveAppendFormerLeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+	newEntry := func(term raft.Term) *raft.LogEntry {
+		return &raft.LogEntry{
+			Term:      term,
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+	}
+
+	// Replicate and commit entries from the first term
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:        1,
+		Leader:      "bar",
+		Entries:     []*raft.LogEntry{newEntry(1), newEntry(1), newEntry(1)},
+		CommitIndex: 3,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+
+	// Append entries the member wrote while it was the leader that were never replicated to a quorum
+	protocol.WriteLock()
+	assert.NoError(t, protocol.SetTerm(2))
+	role.store.Writer().Append(newEntry(2))
+	role.store.Writer().Append(newEntry(2))
+	protocol.WriteUnlock()
+
+	// Verify the new leader's heartbeat is rejected since the former leader's entries conflict with the new leader's log
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         3,
+		Leader:       "baz",
+		PrevLogIndex: 4,
+		PrevLogTerm:  3,
+		CommitIndex:  3,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_OK, response.Status)
+	assert.False(t, response.Succeeded)
+	assert.Equal(t, raft.Index(3), response.LastLogIndex)
+
+	// Verify the uncommitted entries are truncated and replaced by the new leader's entries
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         3,
+		Leader:       "baz",
+		PrevLogIndex: 3,
+		PrevLogTerm:  1,
+		Entries:      []*raft.LogEntry{newEntry(3)},
+		CommitIndex:  4,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	assert.Equal(t, uint64(2), response.Truncated)
+	assert.Equal(t, raft.Index(4), response.LastLogIndex)
+	assert.Equal(t, raft.Term(3), response.LastLogTerm)
+	assert.Equal(t, raft.Index(4), role.store.Writer().LastIndex())
+	protocol.ReadLock()
+	assert.Equal(t, raft.Index(4), protocol.CommitIndex())
+	assert.True(t, protocol.IsConsistent())
+	protocol.ReadUnlock()
+
+	// Verify committed entries are never truncated by a conflicting append
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         4,
+		Leader:       "bar",
+		PrevLogIndex: 1,
+		PrevLogTerm:  1,
+		Entries:      []*raft.LogEntry{newEntry(4)},
+		CommitIndex:  4,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.Index(4), role.store.Writer().LastIndex())
+	protocol.ReadLock()
+	assert.False(t, protocol.IsConsistent())
+	protocol.ReadUnlock()
+
+	// Verify a conflicting previous entry that is committed is rejected as well
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         4,
+		Leader:       "bar",
+		PrevLogIndex: 2,
+		PrevLogTerm:  4,
+		CommitIndex:  4,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, response.Status)
+	assert.Equal(t, raft.Index(4), role.store.Writer().LastIndex())
+}
+
+func TestPassiveQueryReadRepair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+	role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+	window := time.Minute
+	protocol.Config().ReadRepairWindow = &window
+
+	newEntry := func() *raft.LogEntry {
+		return &raft.LogEntry{
+			Term:      1,
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Initialize{
+				Initialize: &raft.InitializeEntry{},
+			},
+		}
+	}
+	response, err := role.Append(context.TODO(), &raft.AppendRequest{
+		Term:        1,
+		Leader:      "bar",
+		Entries:     []*raft.LogEntry{newEntry(), newEntry()},
+		CommitIndex: 2,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+
+	bytes, _ := proto.Marshal(&service.ServiceRequest{
+		Request: &service.ServiceRequest_Metadata{
+			Metadata: &service.MetadataRequest{},
+		},
+	})
+	query := func() chan *raft.QueryStreamResponse {
+		ch := make(chan *raft.QueryStreamResponse, 10)
+		go func() {
+			assert.NoError(t, role.Query(&raft.QueryRequest{
+				Value:           bytes,
+				ReadConsistency: raft.ReadConsistency_SEQUENTIAL,
+			}, ch))
+		}()
+		return ch
+	}
+
+	// Serve a stale read just before the follower learns the leader has committed many more entries
+	ch := query()
+	queryResponse := <-ch
+	assert.True(t, queryResponse.Succeeded())
+	assert.Equal(t, raft.ResponseStatus_OK, queryResponse.Response.Status)
+	assert.False(t, queryResponse.Response.ReadRepair)
+	role.awaitReadRepair()
+
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         1,
+		Leader:       "bar",
+		PrevLogIndex: 2,
+		PrevLogTerm:  1,
+		CommitIndex:  100,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+
+	// Verify the read is flagged as stale by the number of entries the follower was behind
+	queryResponse = <-ch
+	assert.True(t, queryResponse.Succeeded())
+	assert.True(t, queryResponse.Response.ReadRepair)
+	assert.Equal(t, raft.Index(98), queryResponse.Response.StaleBy)
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	// Catch up with the leader
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         1,
+		Leader:       "bar",
+		PrevLogIndex: 2,
+		PrevLogTerm:  1,
+		Entries:      []*raft.LogEntry{newEntry()},
+		CommitIndex:  3,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+
+	// Verify a read that was not stale is not flagged
+	ch = query()
+	queryResponse = <-ch
+	assert.True(t, queryResponse.Succeeded())
+	assert.False(t, queryResponse.Response.ReadRepair)
+	role.awaitReadRepair()
+
+	response, err = role.Append(context.TODO(), &raft.AppendRequest{
+		Term:         1,
+		Leader:       "bar",
+		PrevLogIndex: 3,
+		PrevLogTerm:  1,
+		CommitIndex:  3,
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Succeeded)
+	_, ok = <-ch
+	assert.False(t, ok)
+}
+
+// flushingStore is a store that records flushes of the log
+type flushingStore struct {
+	store.Store
+	writer *flushingWriter
+}
+
+func (s *flushingStore) Writer() log.Writer {
+	return s.writer
+}
+
+// flushingWriter is a log writer that simulates slow flushes and records the index up to which entries are durable
+type flushingWriter struct {
+	log.Writer
+	flushes      int
+	flushedIndex raft.Index
+	mu           sync.Mutex
+}
+
+func (w *flushingWriter) Flush() error {
+	time.Sleep(10 * time.Millisecond)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flushes++
+	w.flushedIndex = w.LastIndex()
+	return w.Writer.Flush()
+}
+
+func (w *flushingWriter) stats() (int, raft.Index) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flushes, w.flushedIndex
+}
+
+func TestPassiveAppendBatchWindow(t *testing.T) {
+	// appendPipelined sends pipelined appends of a single entry each to a passive member,
+	// returning the number of flushes performed
+	appendPipelined := func(window *time.Duration) int {
+		ctrl := gomock.NewController(t)
+		protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
+		protocol.Config().AppendBatchWindow = window
+		writer := &flushingWriter{Writer: stores.Writer()}
+		stores = &flushingStore{Store: stores, writer: writer}
+		role := newPassiveRole(protocol, sm, stores, util.NewNodeLogger(string(protocol.Member())))
+
+		wg := &sync.WaitGroup{}
+		for i := 1; i <= 10; i++ {
+			request := &raft.AppendRequest{
+				Term:         1,
+				Leader:       "bar",
+				PrevLogIndex: raft.Index(i - 1),
+				Entries: []*raft.LogEntry{
+					{
+						Term:      1,
+						Timestamp: time.Now(),
+						Entry: &raft.LogEntry_Initialize{
+							Initialize: &raft.InitializeEntry{},
+						},
+					},
+				},
+			}
+			if i > 1 {
+				request.PrevLogTerm = 1
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				response, err := role.Append(context.TODO(), request)
+				assert.NoError(t, err)
+				assert.True(t, response.Succeeded)
+
+				// Verify the append is only acknowledged once its entries are durable
+				_, flushedIndex := writer.stats()
+				assert.True(t, flushedIndex >= response.LastLogIndex)
+			}()
+
+			// Wait for the entry to be appended before sending the next append
+			awaitIndex(role.raft, role.store.Log(), raft.Index(i))
+		}
+		wg.Wait()
+		flushes, _ := writer.stats()
+		return flushes
+	}
+
+	// Verify each append is flushed individually without a batching window
+	assert.Equal(t, 10, appendPipelined(nil))
+
+	// Verify appends within the batching window are flushed together
+	window := 50 * time.Millisecond
+	assert.True(t, appendPipelined(&window) < 10)
+}
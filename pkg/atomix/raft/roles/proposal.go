@@ -0,0 +1,201 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	"container/list"
+	"github.com/atomix/go-framework/pkg/atomix/service"
+	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store/log"
+	"github.com/gogo/protobuf/proto"
+	"sync"
+)
+
+// proposal is a command waiting to be appended to the leader's log
+type proposal struct {
+	session uint64
+	entry   *raft.LogEntry
+	ch      chan *log.Entry
+	// urgent indicates the client's deadline is near and the proposal should be appended without batching
+	urgent bool
+	// apply returns the function to call once the appended entry is committed
+	apply func(*log.Entry) func()
+	// commitCh is the channel on which the commit of the appended entry is completed
+	commitCh <-chan bool
+}
+
+// newProposal returns a new proposal for the given command request
+func newProposal(request *raft.CommandRequest) *proposal {
+	return &proposal{
+		session: getProposalSession(request.Value),
+		entry: &raft.LogEntry{
+			Entry: &raft.LogEntry_Command{
+				Command: &raft.CommandEntry{
+					Value: request.Value,
+				},
+			},
+		},
+		ch: make(chan *log.Entry, 1),
+	}
+}
+
+// getProposalSession returns the session ID for the given command, or 0 if the command is not a session command
+func getProposalSession(value []byte) uint64 {
+	serviceRequest := &service.ServiceRequest{}
+	if err := proto.Unmarshal(value, serviceRequest); err != nil {
+		return 0
+	}
+	sessionRequest := &service.SessionRequest{}
+	if err := proto.Unmarshal(serviceRequest.GetCommand(), sessionRequest); err != nil {
+		return 0
+	}
+	switch r := sessionRequest.Request.(type) {
+	case *service.SessionRequest_Command:
+		return r.Command.GetContext().GetSessionID()
+	case *service.SessionRequest_KeepAlive:
+		return r.KeepAlive.GetSessionID()
+	case *service.SessionRequest_CloseSession:
+		return r.CloseSession.GetSessionID()
+	}
+	return 0
+}
+
+// newProposalQueue returns a new fair proposal queue
+func newProposalQueue() *proposalQueue {
+	return &proposalQueue{
+		sessions: make(map[uint64]*sessionProposals),
+		schedule: list.New(),
+		urgent:   list.New(),
+	}
+}
+
+// proposalQueue is a queue of proposals that schedules sessions round-robin so a single session
+// can't monopolize the leader's log, while preserving the order of proposals within each session.
+// Sessions with urgent proposals are scheduled ahead of all other sessions. An urgent proposal is
+// never reordered ahead of proposals pushed before it by the same session: the session's earlier
+// proposals are scheduled along with it. Proposals outside of a session are not ordered, so urgent
+// proposals outside of a session are scheduled ahead of relaxed proposals outside of a session.
+type proposalQueue struct {
+	// sessions is the pending proposals for each session
+	sessions map[uint64]*sessionProposals
+	// schedule is the order in which sessions with pending proposals are scheduled
+	schedule *list.List
+	// urgent is the order in which sessions with pending urgent proposals are scheduled
+	urgent *list.List
+	mu     sync.Mutex
+}
+
+// sessionProposals is the pending proposals for a session
+type sessionProposals struct {
+	proposals *list.List
+	// urgent is the number of pending urgent proposals
+	urgent int
+	// lastUrgent is the last urgent proposal pushed ahead of relaxed proposals outside of a session
+	lastUrgent *list.Element
+	// scheduled is the session's element in the queue's schedule
+	scheduled *list.Element
+	// urgentScheduled is the session's element in the queue's urgent schedule, if the session has urgent proposals
+	urgentScheduled *list.Element
+}
+
+// push adds a proposal to the queue
+func (q *proposalQueue) push(p *proposal) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	session, ok := q.sessions[p.session]
+	if !ok {
+		session = &sessionProposals{
+			proposals: list.New(),
+			scheduled: q.schedule.PushBack(p.session),
+		}
+		q.sessions[p.session] = session
+	}
+	if p.urgent && p.session == 0 {
+		if session.lastUrgent != nil {
+			session.lastUrgent = session.proposals.InsertAfter(p, session.lastUrgent)
+		} else {
+			session.lastUrgent = session.proposals.PushFront(p)
+		}
+	} else {
+		session.proposals.PushBack(p)
+	}
+	if p.urgent {
+		session.urgent++
+		if session.urgentScheduled == nil {
+			session.urgentScheduled = q.urgent.PushBack(p.session)
+		}
+	}
+}
+
+// popUrgent removes the next proposal of a session with urgent proposals from the queue, returning nil if no
+// urgent proposal is queued. The proposal may be a relaxed proposal that precedes an urgent proposal in its session.
+func (q *proposalQueue) popUrgent() *proposal {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.popFrom(q.urgent)
+}
+
+// pop removes the next proposal from the queue, returning nil if the queue is empty
+func (q *proposalQueue) pop() *proposal {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if p := q.popFrom(q.urgent); p != nil {
+		return p
+	}
+	return q.popFrom(q.schedule)
+}
+
+// popFrom removes the next proposal of the session at the front of the given schedule, moving the session
+// to the back of the schedules in which it remains. The caller must hold the queue's lock.
+func (q *proposalQueue) popFrom(schedule *list.List) *proposal {
+	next := schedule.Front()
+	if next == nil {
+		return nil
+	}
+	session := q.sessions[next.Value.(uint64)]
+	p := session.proposals.Remove(session.proposals.Front()).(*proposal)
+	if p.urgent {
+		session.urgent--
+		if session.urgent == 0 {
+			session.lastUrgent = nil
+		}
+	}
+	if session.urgentScheduled != nil {
+		if session.urgent == 0 {
+			q.urgent.Remove(session.urgentScheduled)
+			session.urgentScheduled = nil
+		} else {
+			q.urgent.MoveToBack(session.urgentScheduled)
+		}
+	}
+	if session.proposals.Len() == 0 {
+		q.schedule.Remove(session.scheduled)
+		delete(q.sessions, p.session)
+	} else {
+		q.schedule.MoveToBack(session.scheduled)
+	}
+	return p
+}
+
+// len returns the number of proposals in the queue
+func (q *proposalQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := 0
+	for _, session := range q.sessions {
+		n += session.proposals.Len()
+	}
+	return n
+}
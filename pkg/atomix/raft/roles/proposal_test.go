@@ -0,0 +1,124 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roles
+
+import (
+	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestProposalSession(t *testing.T) {
+	assert.Equal(t, uint64(0), getProposalSession(newOpenSessionRequest()))
+	assert.Equal(t, uint64(3), getProposalSession(newSetRequest("Set", 3, 1)))
+	assert.Equal(t, uint64(0), getProposalSession([]byte("garbage")))
+}
+
+func TestProposalQueueFairness(t *testing.T) {
+	queue := newProposalQueue()
+	assert.Nil(t, queue.pop())
+
+	// Enqueue a burst of proposals from a noisy session before proposals from quieter sessions
+	for i := 1; i <= 10; i++ {
+		queue.push(newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 1, uint64(i))}))
+	}
+	queue.push(newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 2, 1)}))
+	queue.push(newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 2, 2)}))
+	queue.push(newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 3, 1)}))
+
+	// Verify sessions are scheduled round-robin so the quieter sessions aren't starved
+	sessions := make([]uint64, 0)
+	values := make(map[uint64][][]byte)
+	for p := queue.pop(); p != nil; p = queue.pop() {
+		sessions = append(sessions, p.session)
+		values[p.session] = append(values[p.session], p.entry.GetCommand().Value)
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 1, 2, 1, 1, 1, 1, 1, 1, 1, 1}, sessions)
+
+	// Verify the order of proposals within each session is preserved
+	for i := 1; i <= 10; i++ {
+		assert.Equal(t, newSetRequest("Set", 1, uint64(i)), values[1][i-1])
+	}
+	assert.Equal(t, newSetRequest("Set", 2, 1), values[2][0])
+	assert.Equal(t, newSetRequest("Set", 2, 2), values[2][1])
+}
+
+func TestProposalQueueUrgent(t *testing.T) {
+	queue := newProposalQueue()
+	for i := 1; i <= 3; i++ {
+		queue.push(newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 1, uint64(i))}))
+	}
+	assert.Nil(t, queue.popUrgent())
+
+	// Enqueue urgent proposals behind the relaxed proposals
+	for i := 4; i <= 5; i++ {
+		p := newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 2, uint64(i))})
+		p.urgent = true
+		queue.push(p)
+	}
+	assert.Equal(t, 5, queue.len())
+
+	// Verify urgent proposals are scheduled ahead of relaxed proposals in the order they were pushed
+	assert.Equal(t, newSetRequest("Set", 2, 4), queue.popUrgent().entry.GetCommand().Value)
+	assert.Equal(t, newSetRequest("Set", 2, 5), queue.pop().entry.GetCommand().Value)
+	assert.Nil(t, queue.popUrgent())
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, newSetRequest("Set", 1, uint64(i)), queue.pop().entry.GetCommand().Value)
+	}
+	assert.Nil(t, queue.pop())
+	assert.Equal(t, 0, queue.len())
+}
+
+func TestProposalQueueUrgentSessionOrder(t *testing.T) {
+	queue := newProposalQueue()
+
+	// Enqueue an urgent proposal behind relaxed proposals from the same session and another session
+	queue.push(newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 1, 1)}))
+	queue.push(newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 2, 1)}))
+	queue.push(newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 1, 2)}))
+	p := newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 1, 3)})
+	p.urgent = true
+	queue.push(p)
+	queue.push(newProposal(&raft.CommandRequest{Value: newSetRequest("Set", 1, 4)}))
+
+	// Verify the urgent session is scheduled ahead of other sessions without reordering the session's proposals
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, newSetRequest("Set", 1, uint64(i)), queue.popUrgent().entry.GetCommand().Value)
+	}
+	assert.Nil(t, queue.popUrgent())
+	assert.Equal(t, 2, queue.len())
+	assert.Equal(t, newSetRequest("Set", 2, 1), queue.pop().entry.GetCommand().Value)
+	assert.Equal(t, newSetRequest("Set", 1, 4), queue.pop().entry.GetCommand().Value)
+	assert.Nil(t, queue.pop())
+}
+
+func TestProposalQueueUrgentWithoutSession(t *testing.T) {
+	queue := newProposalQueue()
+
+	// Enqueue urgent proposals outside of a session behind relaxed proposals outside of a session
+	queue.push(newProposal(&raft.CommandRequest{Value: newOpenSessionRequest()}))
+	for i := 0; i < 2; i++ {
+		p := newProposal(&raft.CommandRequest{Value: newOpenSessionRequest()})
+		p.urgent = true
+		queue.push(p)
+	}
+
+	// Verify the urgent proposals are scheduled ahead of the relaxed proposal
+	assert.True(t, queue.popUrgent().urgent)
+	assert.True(t, queue.popUrgent().urgent)
+	assert.Nil(t, queue.popUrgent())
+	assert.False(t, queue.pop().urgent)
+	assert.Nil(t, queue.pop())
+}
@@ -16,6 +16,7 @@ package roles
 
 import (
 	"context"
+	"fmt"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/state"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
@@ -111,6 +112,28 @@ func (r *raftRole) Reconfigure(ctx context.Context, request *raft.ReconfigureReq
 	return response, nil
 }
 
+// SetReadOnly handles a set read-only request
+func (r *raftRole) SetReadOnly(ctx context.Context, request *raft.SetReadOnlyRequest) (*raft.SetReadOnlyResponse, error) {
+	r.log.Request("SetReadOnlyRequest", request)
+	response := &raft.SetReadOnlyResponse{
+		Status: raft.ResponseStatus_ERROR,
+		Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+	}
+	_ = r.log.Response("SetReadOnlyResponse", response, nil)
+	return response, nil
+}
+
+// FreezeElections handles an election freeze request
+func (r *raftRole) FreezeElections(ctx context.Context, request *raft.FreezeElectionsRequest) (*raft.FreezeElectionsResponse, error) {
+	r.log.Request("FreezeElectionsRequest", request)
+	response := &raft.FreezeElectionsResponse{
+		Status: raft.ResponseStatus_ERROR,
+		Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+	}
+	_ = r.log.Response("FreezeElectionsResponse", response, nil)
+	return response, nil
+}
+
 // Poll handles a poll request
 func (r *raftRole) Poll(ctx context.Context, request *raft.PollRequest) (*raft.PollResponse, error) {
 	r.log.Request("PollRequest", request)
@@ -133,6 +156,17 @@ func (r *raftRole) Vote(ctx context.Context, request *raft.VoteRequest) (*raft.V
 	return response, nil
 }
 
+// ConfirmLeadership handles a leadership confirmation request
+func (r *raftRole) ConfirmLeadership(ctx context.Context, request *raft.ConfirmLeadershipRequest) (*raft.ConfirmLeadershipResponse, error) {
+	r.log.Request("ConfirmLeadershipRequest", request)
+	response := &raft.ConfirmLeadershipResponse{
+		Status: raft.ResponseStatus_ERROR,
+		Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+	}
+	_ = r.log.Response("ConfirmLeadershipResponse", response, nil)
+	return response, nil
+}
+
 // Transfer handles a transfer request
 func (r *raftRole) Transfer(ctx context.Context, request *raft.TransferRequest) (*raft.TransferResponse, error) {
 	r.log.Request("TransferRequest", request)
@@ -144,6 +178,17 @@ func (r *raftRole) Transfer(ctx context.Context, request *raft.TransferRequest)
 	return response, nil
 }
 
+// Hash handles a log hash request
+func (r *raftRole) Hash(ctx context.Context, request *raft.HashRequest) (*raft.HashResponse, error) {
+	r.log.Request("HashRequest", request)
+	response := &raft.HashResponse{
+		Status: raft.ResponseStatus_ERROR,
+		Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+	}
+	_ = r.log.Response("HashResponse", response, nil)
+	return response, nil
+}
+
 // Append handles a append request
 func (r *raftRole) Append(ctx context.Context, request *raft.AppendRequest) (*raft.AppendResponse, error) {
 	r.log.Request("AppendRequest", request)
@@ -178,6 +223,17 @@ func (r *raftRole) Command(request *raft.CommandRequest, ch chan<- *raft.Command
 	return nil
 }
 
+// CommandBatch handles a command batch request
+func (r *raftRole) CommandBatch(ctx context.Context, request *raft.CommandBatchRequest) (*raft.CommandBatchResponse, error) {
+	r.log.Request("CommandBatchRequest", request)
+	response := &raft.CommandBatchResponse{
+		Status: raft.ResponseStatus_ERROR,
+		Error:  raft.ResponseError_ILLEGAL_MEMBER_STATE,
+	}
+	_ = r.log.Response("CommandBatchResponse", response, nil)
+	return response, nil
+}
+
 // Query handles a query request
 func (r *raftRole) Query(request *raft.QueryRequest, ch chan<- *raft.QueryStreamResponse) error {
 	defer close(ch)
@@ -190,3 +246,41 @@ func (r *raftRole) Query(request *raft.QueryRequest, ch chan<- *raft.QueryStream
 	ch <- raft.NewQueryStreamResponse(response, nil)
 	return nil
 }
+
+// awaitAppliedIndex waits up to the configured timeout for the state machine to apply the given index,
+// returning whether the index was applied within the timeout. Reads that require an index the local
+// state machine has not yet applied wait for it to be applied rather than failing immediately.
+func (r *raftRole) awaitAppliedIndex(index raft.Index) bool {
+	if r.raft.AppliedIndex() >= index {
+		return true
+	}
+
+	timeout := r.raft.Config().GetMinAppliedIndexTimeoutOrDefault()
+	r.log.Trace("Waiting up to %s for index %d to be applied", timeout, index)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ch := make(chan *raft.ProgressResponse)
+	if err := r.raft.Progress(ctx, &raft.ProgressRequest{}, ch); err != nil {
+		return false
+	}
+	for progress := range ch {
+		if progress.AppliedIndex >= index {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMinAppliedIndex waits for the query's minimum applied index to be applied, returning an error
+// response if the index was not applied within the configured timeout
+func (r *raftRole) checkMinAppliedIndex(request *raft.QueryRequest) *raft.QueryResponse {
+	if r.awaitAppliedIndex(request.MinAppliedIndex) {
+		return nil
+	}
+	r.log.Warn("Rejected query: index %d was not applied within %s", request.MinAppliedIndex, r.raft.Config().GetMinAppliedIndexTimeoutOrDefault())
+	return &raft.QueryResponse{
+		Status:  raft.ResponseStatus_ERROR,
+		Error:   raft.ResponseError_NOT_READY,
+		Message: fmt.Sprintf("index %d was not applied within the timeout", request.MinAppliedIndex),
+	}
+}
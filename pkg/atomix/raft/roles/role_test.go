@@ -17,6 +17,7 @@ package roles
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/atomix/go-framework/pkg/atomix/cluster"
 	"github.com/atomix/go-framework/pkg/atomix/node"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
@@ -29,10 +30,30 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
 	"time"
 )
 
+// testClock is a raft.Clock that is offset from the system clock to simulate clock skew
+type testClock struct {
+	offset time.Duration
+	mu     sync.RWMutex
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return time.Now().Add(c.offset)
+}
+
+// setOffset sets the offset of the clock from the system clock
+func (c *testClock) setOffset(offset time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset = offset
+}
+
 func newRoleFuncs(roles ...raft.Role) map[raft.RoleType]func(raft.Raft) raft.Role {
 	roleFuncs := make(map[raft.RoleType]func(raft.Raft) raft.Role)
 	for _, role := range roles {
@@ -67,13 +88,45 @@ func newTestState(client raft.Client, roles ...raft.Role) (raft.Raft, state.Mana
 		},
 	}
 
-	cluster := raft.NewCluster(members)
+	cluster, err := raft.NewCluster(members)
+	if err != nil {
+		panic(err)
+	}
+	store := store.NewMemoryStore()
+	electionTimeout := 1 * time.Second
+	config := &config.ProtocolConfig{
+		ElectionTimeout: &electionTimeout,
+	}
+	state := state.NewManager(cluster.Member(), store, node.GetRegistry(), config)
+	raft := raft.NewRaft(cluster, config, client, newRoleFuncs(roles...))
+	return raft, state, store
+}
+
+// newLargeTestState returns a test state for a cluster of the given number of members
+func newLargeTestState(client raft.Client, size int, roles ...raft.Role) (raft.Raft, state.Manager, store.Store) {
+	members := cluster.Cluster{
+		MemberID: "member-0",
+		Members:  make(map[string]cluster.Member),
+	}
+	for i := 0; i < size; i++ {
+		id := fmt.Sprintf("member-%d", i)
+		members.Members[id] = cluster.Member{
+			ID:   id,
+			Host: "localhost",
+			Port: 5000 + i,
+		}
+	}
+
+	cluster, err := raft.NewCluster(members)
+	if err != nil {
+		panic(err)
+	}
 	store := store.NewMemoryStore()
-	state := state.NewManager(cluster.Member(), store, node.GetRegistry())
 	electionTimeout := 1 * time.Second
 	config := &config.ProtocolConfig{
 		ElectionTimeout: &electionTimeout,
 	}
+	state := state.NewManager(cluster.Member(), store, node.GetRegistry(), config)
 	raft := raft.NewRaft(cluster, config, client, newRoleFuncs(roles...))
 	return raft, state, store
 }
@@ -100,13 +153,16 @@ func newTestRole(client raft.Client, f func(raft.Raft, state.Manager, store.Stor
 		},
 	}
 
-	cluster := raft.NewCluster(members)
+	cluster, err := raft.NewCluster(members)
+	if err != nil {
+		panic(err)
+	}
 	store := store.NewMemoryStore()
-	state := state.NewManager(cluster.Member(), store, node.GetRegistry())
 	electionTimeout := 1 * time.Second
 	config := &config.ProtocolConfig{
 		ElectionTimeout: &electionTimeout,
 	}
+	state := state.NewManager(cluster.Member(), store, node.GetRegistry(), config)
 	roleFuncs := newRoleFuncs(roles...)
 	r := raft.NewRaft(cluster, config, client, roleFuncs)
 	role := f(r, state, store)
@@ -116,6 +172,97 @@ func newTestRole(client raft.Client, f func(raft.Raft, state.Manager, store.Stor
 	return role
 }
 
+// newTestCluster returns a set of Raft members connected by mock clients that route requests to one another
+func newTestCluster(ctrl *gomock.Controller, config *config.ProtocolConfig, members ...raft.MemberID) map[raft.MemberID]raft.Raft {
+	rafts, _ := newTestClusterStates(ctrl, config, members...)
+	return rafts
+}
+
+// newTestClusterStates returns a set of connected Raft members and their state managers
+func newTestClusterStates(ctrl *gomock.Controller, config *config.ProtocolConfig, members ...raft.MemberID) (map[raft.MemberID]raft.Raft, map[raft.MemberID]state.Manager) {
+	clusterConfig := cluster.Cluster{
+		Members: make(map[string]cluster.Member),
+	}
+	for _, member := range members {
+		clusterConfig.Members[string(member)] = cluster.Member{
+			ID:   string(member),
+			Host: "localhost",
+		}
+	}
+
+	rafts := make(map[raft.MemberID]raft.Raft)
+	states := make(map[raft.MemberID]state.Manager)
+	for _, member := range members {
+		client := mock.NewMockClient(ctrl)
+		client.EXPECT().Poll(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, request *raft.PollRequest, member raft.MemberID) (*raft.PollResponse, error) {
+				return rafts[member].Poll(ctx, request)
+			}).AnyTimes()
+		client.EXPECT().Vote(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, request *raft.VoteRequest, member raft.MemberID) (*raft.VoteResponse, error) {
+				return rafts[member].Vote(ctx, request)
+			}).AnyTimes()
+		client.EXPECT().Append(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, request *raft.AppendRequest, member raft.MemberID) (*raft.AppendResponse, error) {
+				return rafts[member].Append(ctx, request)
+			}).AnyTimes()
+		client.EXPECT().Command(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, request *raft.CommandRequest, member raft.MemberID) (<-chan *raft.CommandStreamResponse, error) {
+				ch := make(chan *raft.CommandStreamResponse)
+				go func() {
+					_ = rafts[member].Command(request, ch)
+				}()
+				return ch, nil
+			}).AnyTimes()
+		client.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, request *raft.QueryRequest, member raft.MemberID) (<-chan *raft.QueryStreamResponse, error) {
+				ch := make(chan *raft.QueryStreamResponse)
+				go func() {
+					_ = rafts[member].Query(request, ch)
+				}()
+				return ch, nil
+			}).AnyTimes()
+
+		clusterConfig.MemberID = string(member)
+		cluster, err := raft.NewCluster(clusterConfig)
+		if err != nil {
+			panic(err)
+		}
+		store := store.NewMemoryStore()
+		state := state.NewManager(cluster.Member(), store, node.GetRegistry(), config)
+		rafts[member] = raft.NewRaft(cluster, config, client, GetRoles(state, store))
+		states[member] = state
+	}
+	return rafts, states
+}
+
+// newTestMembersState returns the state of the first of the given members in a cluster of the given members
+func newTestMembersState(client raft.Client, members ...raft.MemberID) (raft.Raft, state.Manager, store.Store) {
+	clusterConfig := cluster.Cluster{
+		MemberID: string(members[0]),
+		Members:  make(map[string]cluster.Member),
+	}
+	for _, member := range members {
+		clusterConfig.Members[string(member)] = cluster.Member{
+			ID:   string(member),
+			Host: "localhost",
+		}
+	}
+
+	cluster, err := raft.NewCluster(clusterConfig)
+	if err != nil {
+		panic(err)
+	}
+	store := store.NewMemoryStore()
+	electionTimeout := 1 * time.Second
+	config := &config.ProtocolConfig{
+		ElectionTimeout: &electionTimeout,
+	}
+	state := state.NewManager(cluster.Member(), store, node.GetRegistry(), config)
+	raft := raft.NewRaft(cluster, config, client, newRoleFuncs())
+	return raft, state, store
+}
+
 func TestRole(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	protocol, sm, stores := newTestState(mock.NewMockClient(ctrl))
@@ -137,6 +284,10 @@ func TestRole(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, raft.ResponseStatus_ERROR, reconfigureResponse.Status)
 
+	setReadOnlyResponse, err := role.SetReadOnly(context.TODO(), &raft.SetReadOnlyRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, raft.ResponseStatus_ERROR, setReadOnlyResponse.Status)
+
 	pollResponse, err := role.Poll(context.TODO(), &raft.PollRequest{})
 	assert.NoError(t, err)
 	assert.Equal(t, raft.ResponseStatus_ERROR, pollResponse.Status)
@@ -239,6 +390,46 @@ func awaitCommit(r raft.Raft, index raft.Index) raft.Index {
 	}
 }
 
+// setLearners sets a configuration in which the given members are learners and all other members are voters
+func setLearners(r raft.Raft, learners ...raft.MemberID) {
+	members := make([]*raft.Member, 0, len(r.Members()))
+	for _, member := range r.Members() {
+		memberType := raft.Member_ACTIVE
+		for _, learner := range learners {
+			if member == learner {
+				memberType = raft.Member_PASSIVE
+			}
+		}
+		members = append(members, &raft.Member{
+			MemberID: member,
+			Type:     memberType,
+		})
+	}
+	r.SetConfiguration(&raft.Configuration{
+		Members: members,
+	})
+}
+
+// setWitnesses sets the given members as witnesses in the configuration of the given state
+func setWitnesses(r raft.Raft, witnesses ...raft.MemberID) {
+	members := make([]*raft.Member, 0, len(r.Members()))
+	for _, member := range r.Members() {
+		memberType := raft.Member_ACTIVE
+		for _, witness := range witnesses {
+			if member == witness {
+				memberType = raft.Member_WITNESS
+			}
+		}
+		members = append(members, &raft.Member{
+			MemberID: member,
+			Type:     memberType,
+		})
+	}
+	r.SetConfiguration(&raft.Configuration{
+		Members: members,
+	})
+}
+
 // mockRole mocks a role
 func mockRole(ctrl *gomock.Controller, roleType raft.RoleType) raft.Role {
 	role := mock.NewMockRole(ctrl)
@@ -20,6 +20,7 @@ import (
 	"github.com/atomix/go-framework/pkg/atomix/service"
 	"github.com/atomix/go-framework/pkg/atomix/stream"
 	"github.com/golang/protobuf/proto"
+	"time"
 )
 
 func init() {
@@ -46,6 +47,7 @@ func (s *testService) init() {
 	s.Executor.RegisterUnaryOperation("Set", s.Set)
 	s.Executor.RegisterStreamOperation("SetStream", s.SetStream)
 	s.Executor.RegisterUnaryOperation("SetError", s.SetError)
+	s.Executor.RegisterUnaryOperation("SetSlow", s.SetSlow)
 	s.Executor.RegisterUnaryOperation("Get", s.Get)
 	s.Executor.RegisterStreamOperation("GetStream", s.GetStream)
 	s.Executor.RegisterUnaryOperation("GetError", s.GetError)
@@ -126,3 +128,9 @@ func (s *testService) SetError(value []byte) ([]byte, error) {
 	}
 	return nil, errors.New("error")
 }
+
+// SetSlow sets the test value after a delay
+func (s *testService) SetSlow(value []byte) ([]byte, error) {
+	time.Sleep(500 * time.Millisecond)
+	return s.Set(value)
+}
@@ -26,58 +26,124 @@ import (
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
 	"google.golang.org/grpc"
 	"net"
+	"path/filepath"
 	"sync"
 )
 
-// NewServer returns a new Raft consensus protocol server
-func NewServer(clusterConfig cluster.Cluster, registry *node.Registry, protocolConfig *config.ProtocolConfig) *Server {
+// NewServer returns a new Raft consensus protocol server. The given options are applied to the Raft protocol,
+// e.g. raft.WithLogger to write the protocol's logs to a structured logging backend.
+func NewServer(clusterConfig cluster.Cluster, registry *node.Registry, protocolConfig *config.ProtocolConfig, opts ...raft.Option) (*Server, error) {
 	member, ok := clusterConfig.Members[clusterConfig.MemberID]
 	if !ok {
 		panic("Local member is not present in cluster configuration!")
 	}
 
-	cluster := raft.NewCluster(clusterConfig)
-	protocol := raft.NewClient(cluster)
-	store := store.NewMemoryStore()
-	state := state.NewManager(cluster.Member(), store, registry)
-	roles := roles.GetRoles(state, store)
-	raft := raft.NewRaft(cluster, protocolConfig, protocol, roles)
-	server := &Server{
-		raft:  raft,
-		state: state,
-		store: store,
-		port:  member.ProtocolPort,
-		mu:    sync.Mutex{},
+	cluster, err := raft.NewCluster(clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+	server, err := newServer(cluster, raft.NewRouter(), "", registry, protocolConfig, opts...)
+	if err != nil {
+		return nil, err
+	}
+	server.port = member.ProtocolPort
+	return server, nil
+}
+
+// newServer returns a new server for the given group
+func newServer(cluster raft.Cluster, router *raft.Router, group raft.GroupID, registry *node.Registry, protocolConfig *config.ProtocolConfig, opts ...raft.Option) (*Server, error) {
+	// If a storage directory is configured, persist the term and vote to the group's directory.
+	if directory := protocolConfig.GetStorage().GetDirectory(); directory != "" {
+		metadata, err := raft.NewFileMetadataStore(filepath.Join(directory, string(group)))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, raft.WithMetadataStore(metadata))
 	}
-	return server
+
+	protocol := raft.NewGroupClient(cluster, group)
+	store := store.NewMemoryStoreWithRetention(protocolConfig.GetRetainedSnapshotsOrDefault())
+	state := state.NewManager(cluster.Member(), store, registry, protocolConfig)
+	roles := roles.GetRoles(state, store)
+	raft := raft.NewRaft(cluster, protocolConfig, protocol, roles, append(opts, raft.WithGroup(group))...)
+	state.Watch(raft.SetAppliedIndex)
+	return &Server{
+		config:  protocolConfig,
+		cluster: cluster,
+		group:   group,
+		router:  router,
+		raft:    raft,
+		state:   state,
+		store:   store,
+		mu:      sync.Mutex{},
+	}, nil
 }
 
 // Server implements the Raft consensus protocol server
 type Server struct {
-	raft   raft.Raft
-	state  state.Manager
-	store  store.Store
-	server *grpc.Server
-	port   int
-	mu     sync.Mutex
+	config  *config.ProtocolConfig
+	cluster raft.Cluster
+	group   raft.GroupID
+	router  *raft.Router
+	raft    raft.Raft
+	state   state.Manager
+	store   store.Store
+	server  *grpc.Server
+	port    int
+	mu      sync.Mutex
+}
+
+// AddGroup adds a Raft group with the given ID to the server. The group has its own log, state machine,
+// roles, and election timers, and elects its leader independently of the server's other groups. The group
+// shares the server's gRPC server, which routes requests to the group by its ID. The returned group server
+// must be started and stopped independently of the server.
+func (s *Server) AddGroup(group raft.GroupID, registry *node.Registry, protocolConfig *config.ProtocolConfig, opts ...raft.Option) (*Server, error) {
+	if group == "" {
+		return nil, errors.New("group ID cannot be empty")
+	}
+	return newServer(s.cluster, s.router, group, registry, protocolConfig, opts...)
 }
 
 // Start starts the Raft server
 func (s *Server) Start() error {
 	s.mu.Lock()
 
+	// If torn entry truncation is enabled, recover the log to its last intact entry before verifying snapshots
+	if s.config.GetRecovery().GetTruncateTornEntries() {
+		if _, err := store.RecoverLog(s.store); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	}
+
+	// If snapshot verification is enabled, refuse to start if no retained snapshot is continuous with the log
+	if s.config.GetRecovery().GetVerifySnapshot() {
+		if _, err := store.RecoverSnapshot(s.store); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	}
+
 	// Initialize the Raft state
 	s.raft.WriteLock()
 	s.raft.Init()
 	s.raft.WriteUnlock()
+	s.router.Register(s.group, s.raft)
+
+	// Groups added to a server share the server's gRPC server.
+	if s.group != "" {
+		s.mu.Unlock()
+		return nil
+	}
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
 	if err != nil {
+		s.mu.Unlock()
 		return err
 	}
 
 	s.server = grpc.NewServer()
-	raft.RegisterRaftServiceServer(s.server, raft.NewServer(s.raft))
+	raft.RegisterRaftServiceServer(s.server, s.router)
 	s.mu.Unlock()
 	return s.server.Serve(lis)
 }
@@ -98,10 +164,29 @@ func (s *Server) WaitForReady() error {
 	return errors.New("server stopped")
 }
 
+// AuthorizeCommands adds an authorizer to the chain of authorizers invoked before commands are appended to the log
+func (s *Server) AuthorizeCommands(authorizer raft.CommandAuthorizer) {
+	s.raft.AuthorizeCommands(authorizer)
+}
+
+// SetAuditSink sets the sink to which records of state transitions are passed. The sink must be set
+// before the server is started.
+func (s *Server) SetAuditSink(sink raft.AuditSink) {
+	s.raft.SetAuditSink(sink)
+}
+
+// Health returns the health of the Raft node, e.g. for liveness and readiness probes
+func (s *Server) Health() raft.Health {
+	s.raft.ReadLock()
+	defer s.raft.ReadUnlock()
+	return s.raft.Health()
+}
+
 // Stop shuts down the Raft server
 func (s *Server) Stop() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.router.Unregister(s.group)
 	if s.server != nil {
 		s.server.Stop()
 	}
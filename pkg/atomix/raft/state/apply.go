@@ -0,0 +1,82 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"github.com/atomix/go-framework/pkg/atomix/node"
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
+	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
+	"io"
+)
+
+// ApplyFunc applies a committed command or query to a user-provided state machine.
+// The index is the index of the applied command, or the last applied index for queries.
+// The returned output or error is routed back to the client via the operation's response stream.
+type ApplyFunc func(index raft.Index, entry []byte) ([]byte, error)
+
+// NewManagerWithApplyFunc returns a new Raft state manager that applies commands and queries to the given function
+// in place of a primitive state machine. The function is called from the apply loop and must not retain the entry.
+// Because an ApplyFunc cannot be snapshotted, the state machine is never snapshotted and the log is never compacted
+// by a manager created with this function. On restart, all committed entries are replayed to the function.
+func NewManagerWithApplyFunc(member raft.MemberID, store store.Store, config *config.ProtocolConfig, apply ApplyFunc) Manager {
+	return newManager(member, store, config, func(ctx node.Context) node.StateMachine {
+		return &applyStateMachine{
+			ctx:   ctx,
+			apply: apply,
+		}
+	}, false)
+}
+
+// applyStateMachine is a node.StateMachine that delegates commands and queries to an ApplyFunc
+type applyStateMachine struct {
+	ctx   node.Context
+	apply ApplyFunc
+}
+
+func (s *applyStateMachine) Snapshot(writer io.Writer) error {
+	return nil
+}
+
+func (s *applyStateMachine) Install(reader io.Reader) error {
+	return nil
+}
+
+func (s *applyStateMachine) CanDelete(index uint64) bool {
+	return false
+}
+
+func (s *applyStateMachine) Command(bytes []byte, stream streams.WriteStream) {
+	s.exec(bytes, stream)
+}
+
+func (s *applyStateMachine) Query(bytes []byte, stream streams.WriteStream) {
+	s.exec(bytes, stream)
+}
+
+// exec applies the given entry and writes the result to the stream
+func (s *applyStateMachine) exec(bytes []byte, stream streams.WriteStream) {
+	output, err := s.apply(raft.Index(s.ctx.Index()), bytes)
+	if stream == nil {
+		return
+	}
+	if err != nil {
+		stream.Error(err)
+	} else {
+		stream.Value(output)
+	}
+	stream.Close()
+}
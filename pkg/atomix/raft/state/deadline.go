@@ -0,0 +1,81 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"sync"
+)
+
+// newDeadlineStream returns a new stream wrapping the given stream, which may be nil
+func newDeadlineStream(stream streams.WriteStream) *deadlineStream {
+	return &deadlineStream{
+		stream: stream,
+	}
+}
+
+// deadlineStream is a stream for an apply with a deadline.
+// Once the deadline is exceeded, the stream is failed and further results from the apply are discarded.
+type deadlineStream struct {
+	stream streams.WriteStream
+	closed bool
+	mu     sync.Mutex
+}
+
+// expire fails the stream with the given error and discards any subsequent results
+func (s *deadlineStream) expire(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	if s.stream != nil {
+		s.stream.Error(err)
+		s.stream.Close()
+	}
+}
+
+func (s *deadlineStream) Send(result streams.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed && s.stream != nil {
+		s.stream.Send(result)
+	}
+}
+
+func (s *deadlineStream) Result(value interface{}, err error) {
+	s.Send(streams.Result{
+		Value: value,
+		Error: err,
+	})
+}
+
+func (s *deadlineStream) Value(value interface{}) {
+	s.Result(value, nil)
+}
+
+func (s *deadlineStream) Error(err error) {
+	s.Result(nil, err)
+}
+
+func (s *deadlineStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed && s.stream != nil {
+		s.stream.Close()
+	}
+	s.closed = true
+}
@@ -15,25 +15,43 @@
 package state
 
 import (
+	"fmt"
 	"github.com/atomix/go-framework/pkg/atomix/node"
 	"github.com/atomix/go-framework/pkg/atomix/service"
 	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store/log"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store/snapshot"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/util"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // NewManager returns a new Raft state manager
-func NewManager(member raft.MemberID, store store.Store, registry *node.Registry) Manager {
+func NewManager(member raft.MemberID, store store.Store, registry *node.Registry, config *config.ProtocolConfig) Manager {
+	return newManager(member, store, config, func(ctx node.Context) node.StateMachine {
+		return node.NewPrimitiveStateMachine(registry, ctx)
+	}, true)
+}
+
+// newManager returns a new Raft state manager using the given state machine factory.
+// If snapshots is false, the state machine is never snapshotted and is always recovered by replaying the log.
+func newManager(member raft.MemberID, store store.Store, config *config.ProtocolConfig, factory func(node.Context) node.StateMachine, snapshots bool) Manager {
 	sm := &manager{
-		member: member,
-		log:    util.NewNodeLogger(string(member)),
-		reader: store.Log().OpenReader(0),
-		ch:     make(chan *change, stateBufferSize),
+		member:    member,
+		config:    config,
+		log:       util.NewNodeLogger(string(member)),
+		store:     store,
+		reader:    store.Log().OpenReader(0),
+		ch:        make(chan *change, stateBufferSize),
+		commitCh:  make(chan struct{}, 1),
+		snapshots: snapshots,
 	}
-	sm.state = node.NewPrimitiveStateMachine(registry, sm)
+	sm.state = factory(sm)
+	sm.recover()
 	go sm.start()
 	return sm
 }
@@ -46,6 +64,16 @@ type Manager interface {
 	// Apply applies a committed entry to the state machine
 	ApplyEntry(entry *log.Entry, stream streams.WriteStream)
 
+	// AppliedIndex returns the index of the last entry applied to the state machine
+	AppliedIndex() raft.Index
+
+	// Watch registers a watcher to be notified of the index of each entry applied to the state machine.
+	// Watchers are called from the apply loop and must not block.
+	Watch(watcher func(raft.Index))
+
+	// RebuildStateMachine replays committed entries from the given index into a new state machine
+	RebuildStateMachine(factory func(node.Context) node.StateMachine, fromIndex raft.Index) (node.StateMachine, error)
+
 	// Close closes the state manager
 	Close() error
 }
@@ -57,15 +85,31 @@ const (
 
 // manager manages the Raft state machine
 type manager struct {
+	// commitIndex is the highest index passed to ApplyIndex and must be accessed atomically
+	commitIndex uint64
+	// appliedIndex mirrors lastApplied for readers outside the apply loop and must be accessed atomically
+	appliedIndex uint64
 	member       raft.MemberID
+	config       *config.ProtocolConfig
 	state        node.StateMachine
 	log          util.Logger
 	currentIndex raft.Index
 	currentTime  time.Time
 	lastApplied  raft.Index
+	store        store.Store
 	reader       log.Reader
 	operation    service.OperationType
 	ch           chan *change
+	commitCh     chan struct{}
+	wakeups      uint64
+	halted       bool
+	// snapshots indicates whether the state machine supports snapshots
+	snapshots bool
+	// uncompactedEntries and uncompactedBytes track the entries applied since the last snapshot
+	uncompactedEntries uint64
+	uncompactedBytes   uint64
+	watchers           []func(raft.Index)
+	mu                 sync.RWMutex
 }
 
 // Node returns the local node identifier
@@ -73,12 +117,40 @@ func (m *manager) Node() string {
 	return string(m.member)
 }
 
-// applyIndex applies entries up to the given index
+// ApplyIndex applies entries up to the given index.
+// Notifications are coalesced: if the apply loop has not yet woken for a prior index, the commit index
+// is simply advanced and the loop applies all newly committed entries when it next wakes.
 func (m *manager) ApplyIndex(index raft.Index) {
-	m.ch <- &change{
-		entry: &log.Entry{
-			Index: index,
-		},
+	for {
+		commitIndex := atomic.LoadUint64(&m.commitIndex)
+		if uint64(index) <= commitIndex || atomic.CompareAndSwapUint64(&m.commitIndex, commitIndex, uint64(index)) {
+			break
+		}
+	}
+	select {
+	case m.commitCh <- struct{}{}:
+	default:
+	}
+}
+
+// AppliedIndex returns the index of the last entry applied to the state machine
+func (m *manager) AppliedIndex() raft.Index {
+	return raft.Index(atomic.LoadUint64(&m.appliedIndex))
+}
+
+// Watch registers a watcher to be notified of applied indexes
+func (m *manager) Watch(watcher func(raft.Index)) {
+	m.mu.Lock()
+	m.watchers = append(m.watchers, watcher)
+	m.mu.Unlock()
+}
+
+// notifyApplied notifies watchers that the given index was applied
+func (m *manager) notifyApplied(index raft.Index) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, watcher := range m.watchers {
+		watcher(index)
 	}
 }
 
@@ -97,10 +169,114 @@ func (m *manager) updateClock(index raft.Index, timestamp time.Time) {
 	}
 }
 
+// recover restores the state machine from the newest valid snapshot and resumes applying entries
+// after the snapshot. The snapshot index is the only durable record of applied entries, so entries applied
+// after the last snapshot are replayed exactly once from the log to rebuild the state lost on restart. If the newest
+// snapshot fails verification, recovery falls back to the next newest retained snapshot and replays the
+// entries after the older snapshot, halting the state machine if any of those entries have been compacted.
+// If snapshot verification is enabled and no retained snapshot is valid,
+// the state machine is halted. A state machine that does not support snapshots replays the entire log.
+func (m *manager) recover() {
+	if !m.snapshots {
+		return
+	}
+	var lastApplied raft.Index
+	snapshots := m.store.Snapshot().Snapshots()
+	recovered := false
+	for i, snapshot := range snapshots {
+		if err := m.verifySnapshot(snapshot); err != nil {
+			m.log.Error("Failed to verify snapshot %d", snapshot.Index(), err)
+			continue
+		}
+		reader := snapshot.Reader()
+		err := m.state.Install(reader)
+		_ = reader.Close()
+		if err != nil {
+			m.log.Error("Failed to install snapshot %d", snapshot.Index(), err)
+			continue
+		}
+		if i > 0 {
+			// Falling back to an older snapshot requires replaying all the entries following it. If the
+			// entries have been compacted, the state following the snapshot cannot be recovered.
+			if firstIndex := m.reader.FirstIndex(); firstIndex > snapshot.Index()+1 {
+				m.log.Error("Failed to recover from snapshot %d: entries %d-%d have been compacted; halting state machine", snapshot.Index(), snapshot.Index()+1, firstIndex-1)
+				m.halted = true
+				return
+			}
+			m.log.Warn("Recovered from snapshot %d", snapshot.Index())
+		}
+		lastApplied = snapshot.Index()
+		recovered = true
+		break
+	}
+	if !recovered && len(snapshots) > 0 && m.config.GetRecovery().GetVerifySnapshot() {
+		m.log.Error("No valid snapshot found; halting state machine")
+		m.halted = true
+		return
+	}
+	if lastApplied > 0 {
+		m.log.Debug("Resuming from applied index %d", lastApplied)
+		m.lastApplied = lastApplied
+		atomic.StoreUint64(&m.appliedIndex, uint64(lastApplied))
+		m.currentIndex = lastApplied
+		m.reader.Reset(lastApplied + 1)
+	}
+}
+
+// verifySnapshot verifies the given snapshot's integrity and, if snapshot verification is enabled,
+// the snapshot's continuity with the log
+func (m *manager) verifySnapshot(snapshot snapshot.Snapshot) error {
+	if m.config.GetRecovery().GetVerifySnapshot() {
+		return store.VerifySnapshot(m.store, snapshot)
+	}
+	return snapshot.Verify()
+}
+
 // start begins applying entries to the state machine
 func (m *manager) start() {
-	for change := range m.ch {
-		m.execChange(change)
+	for {
+		select {
+		case change, ok := <-m.ch:
+			if !ok {
+				return
+			}
+			// Apply committed entries before the change to preserve the order in which they were submitted.
+			m.execCommits()
+			m.maybeCompact()
+			m.execChange(change)
+		case <-m.commitCh:
+			m.wakeups++
+			m.execCommits()
+			m.maybeCompact()
+		}
+	}
+}
+
+// maybeCompact snapshots the state machine and compacts the log once the entries applied since the last
+// snapshot exceed the configured entry or size threshold
+func (m *manager) maybeCompact() {
+	if m.halted || !m.snapshots {
+		return
+	}
+	entryThreshold := m.config.GetCompaction().GetEntryThreshold()
+	sizeThreshold := m.config.GetCompaction().GetSizeThreshold()
+	if (entryThreshold == 0 || m.uncompactedEntries < entryThreshold) && (sizeThreshold == 0 || m.uncompactedBytes < sizeThreshold) {
+		return
+	}
+	if err := m.execSnapshot(); err != nil {
+		m.log.Warn("Failed to compact log", err)
+	}
+}
+
+// execCommits applies all entries up to the commit index
+func (m *manager) execCommits() {
+	commitIndex := raft.Index(atomic.LoadUint64(&m.commitIndex))
+	if commitIndex > m.lastApplied {
+		m.execChange(&change{
+			entry: &log.Entry{
+				Index: commitIndex,
+			},
+		})
 	}
 }
 
@@ -112,37 +288,77 @@ func (m *manager) execChange(change *change) {
 			m.log.Error("Recovered from panic %v", err)
 		}
 	}()
+	if change.snapshot != nil {
+		change.snapshot <- m.execSnapshot()
+		return
+	}
+	if change.task != nil {
+		change.task()
+		return
+	}
+	if m.halted {
+		m.failEntry(change.entry, change.stream)
+		return
+	}
 	if change.entry.Entry != nil {
 		// If the entry is a query, apply it without incrementing the lastApplied index
 		if query, ok := change.entry.Entry.Entry.(*raft.LogEntry_Query); ok {
 			m.execQuery(change.entry.Index, change.entry.Entry.Timestamp, query.Query, change.stream)
-		} else {
+		} else if change.entry.Index > m.lastApplied {
 			m.execPendingChanges(change.entry.Index - 1)
-			m.execEntry(change.entry, change.stream)
-			m.lastApplied = change.entry.Index
+			m.applyEntry(change.entry, change.stream)
+		} else {
+			m.skipEntry(change.entry, change.stream)
 		}
 	} else if change.entry.Index > m.lastApplied {
 		m.execPendingChanges(change.entry.Index - 1)
-		m.execEntry(change.entry, change.stream)
-		m.lastApplied = change.entry.Index
+		m.applyEntry(change.entry, change.stream)
+	}
+}
+
+// skipEntry completes the given stream for an entry that has already been applied
+func (m *manager) skipEntry(entry *log.Entry, stream streams.WriteStream) {
+	m.log.Trace("Skipping entry %d: already applied up to %d", entry.Index, m.lastApplied)
+	if stream != nil {
+		stream.Error(fmt.Errorf("entry %d has already been applied", entry.Index))
+		stream.Close()
+	}
+}
+
+// failEntry fails the given stream for an entry submitted after the state machine was halted
+func (m *manager) failEntry(entry *log.Entry, stream streams.WriteStream) {
+	m.log.Trace("Failing entry %d: state machine is halted", entry.Index)
+	if stream != nil {
+		stream.Error(fmt.Errorf("state machine is halted"))
+		stream.Close()
 	}
 }
 
 // execPendingChanges reads and executes changes up to the given index
 func (m *manager) execPendingChanges(index raft.Index) {
 	if m.lastApplied < index {
-		for m.lastApplied < index {
+		for m.lastApplied < index && !m.halted {
 			entry := m.reader.NextEntry()
-			if entry != nil {
-				m.execEntry(entry, nil)
-				m.lastApplied = entry.Index
-			} else {
+			if entry == nil {
 				return
 			}
+			if entry.Index > m.lastApplied {
+				m.applyEntry(entry, nil)
+			}
 		}
 	}
 }
 
+// applyEntry records the given entry as the last applied entry and applies it to the state machine.
+// The applied index is not persisted here: the state machine's state is only durable once it's
+// snapshotted, so the snapshot index is the applied index recovered on restart.
+func (m *manager) applyEntry(entry *log.Entry, stream streams.WriteStream) {
+	m.lastApplied = entry.Index
+	atomic.StoreUint64(&m.appliedIndex, uint64(entry.Index))
+	defer m.notifyApplied(entry.Index)
+	m.execEntry(entry, stream)
+}
+
 // execEntry applies the given entry to the state machine and returns the result(s) on the given channel
 func (m *manager) execEntry(entry *log.Entry, stream streams.WriteStream) {
 	if entry.Entry == nil {
@@ -150,6 +366,11 @@ func (m *manager) execEntry(entry *log.Entry, stream streams.WriteStream) {
 		entry = m.reader.NextEntry()
 	}
 
+	m.uncompactedEntries++
+	if m.config.GetCompaction().GetSizeThreshold() > 0 {
+		m.uncompactedBytes += uint64(entry.Entry.Size())
+	}
+
 	switch e := entry.Entry.Entry.(type) {
 	case *raft.LogEntry_Query:
 		m.execQuery(entry.Index, entry.Entry.Timestamp, e.Query, stream)
@@ -160,6 +381,12 @@ func (m *manager) execEntry(entry *log.Entry, stream streams.WriteStream) {
 		m.execConfig(entry.Index, entry.Entry.Timestamp, e.Configuration, stream)
 	case *raft.LogEntry_Initialize:
 		m.execInit(entry.Index, entry.Entry.Timestamp, e.Initialize, stream)
+	case *raft.LogEntry_ReadOnly:
+		m.execReadOnly(entry.Index, entry.Entry.Timestamp, e.ReadOnly, stream)
+	case *raft.LogEntry_Freeze:
+		m.execFreeze(entry.Index, entry.Entry.Timestamp, e.Freeze, stream)
+	case *raft.LogEntry_Timing:
+		m.execTiming(entry.Index, entry.Entry.Timestamp, e.Timing, stream)
 	}
 }
 
@@ -179,6 +406,30 @@ func (m *manager) execConfig(index raft.Index, timestamp time.Time, config *raft
 	}
 }
 
+func (m *manager) execReadOnly(index raft.Index, timestamp time.Time, readOnly *raft.ReadOnlyEntry, stream streams.WriteStream) {
+	m.updateClock(index, timestamp)
+	if stream != nil {
+		stream.Value(nil)
+		stream.Close()
+	}
+}
+
+func (m *manager) execFreeze(index raft.Index, timestamp time.Time, freeze *raft.FreezeEntry, stream streams.WriteStream) {
+	m.updateClock(index, timestamp)
+	if stream != nil {
+		stream.Value(nil)
+		stream.Close()
+	}
+}
+
+func (m *manager) execTiming(index raft.Index, timestamp time.Time, timing *raft.TimingEntry, stream streams.WriteStream) {
+	m.updateClock(index, timestamp)
+	if stream != nil {
+		stream.Value(nil)
+		stream.Close()
+	}
+}
+
 func (m *manager) execQuery(index raft.Index, timestamp time.Time, query *raft.QueryEntry, stream streams.WriteStream) {
 	m.log.Trace("Applying query %d", index)
 	m.operation = service.OpTypeQuery
@@ -188,12 +439,48 @@ func (m *manager) execQuery(index raft.Index, timestamp time.Time, query *raft.Q
 func (m *manager) execCommand(index raft.Index, timestamp time.Time, command *raft.CommandEntry, stream streams.WriteStream) {
 	m.updateClock(index, timestamp)
 	m.operation = service.OpTypeCommand
-	m.state.Command(command.Value, stream)
+	// Commands applied on followers and replayed on restart have no client stream to write the output to,
+	// but the state machine expects a stream, so the output is discarded.
+	if stream == nil {
+		stream = streams.NewNilStream()
+	}
+	m.execDeadline(index, stream, func(stream streams.WriteStream) {
+		m.state.Command(command.Value, stream)
+	})
+}
+
+// execDeadline applies an entry to the state machine, failing the entry's stream if the apply does not complete
+// within the configured apply deadline. The deadline is measured on the local clock, so replicas may disagree
+// about which entries exceed it; the entry is therefore always applied to completion, in order, and only the
+// client's stream is failed. Once an entry that exceeded the deadline has been applied, the configured error
+// policy is applied: SKIP continues applying subsequent entries, and HALT stops applying entries altogether.
+func (m *manager) execDeadline(index raft.Index, stream streams.WriteStream, f func(streams.WriteStream)) {
+	deadline := m.config.GetApply().GetDeadline()
+	if deadline == nil || *deadline <= 0 {
+		f(stream)
+		return
+	}
+
+	deadlineStream := newDeadlineStream(stream)
+	timer := time.AfterFunc(*deadline, func() {
+		m.log.Error("Failed to apply entry %d: apply deadline %s exceeded", index, *deadline)
+		deadlineStream.expire(fmt.Errorf("entry %d exceeded the apply deadline", index))
+	})
+	defer timer.Stop()
+	f(deadlineStream)
+
+	if !timer.Stop() && m.config.GetApply().GetErrorPolicy() == config.ApplyErrorPolicy_HALT {
+		m.log.Error("Halting state machine after entry %d", index)
+		m.halted = true
+	}
 }
 
 type change struct {
-	entry  *log.Entry
-	stream streams.WriteStream
+	entry    *log.Entry
+	stream   streams.WriteStream
+	snapshot chan<- error
+	// task is a function to run on the apply loop
+	task func()
 }
 
 func (m *manager) Index() uint64 {
@@ -208,6 +495,85 @@ func (m *manager) OperationType() service.OperationType {
 	return m.operation
 }
 
+// Close closes the state manager. If a shutdown snapshot timeout is configured, the state machine is
+// snapshotted at the last applied index and the log is compacted before the manager is closed so a
+// restarted node can recover from the snapshot rather than replaying the log. The snapshot is best-effort:
+// if it cannot be taken within the timeout, the manager is closed without it.
 func (m *manager) Close() error {
+	timeout := m.config.GetCompaction().GetShutdownSnapshotTimeout()
+	if timeout == nil || *timeout <= 0 {
+		return nil
+	}
+
+	ch := make(chan error, 1)
+	timer := time.NewTimer(*timeout)
+	defer timer.Stop()
+	select {
+	case m.ch <- &change{snapshot: ch}:
+	case <-timer.C:
+		m.log.Warn("Failed to take shutdown snapshot: timed out after %s", *timeout)
+		return nil
+	}
+	select {
+	case err := <-ch:
+		if err != nil {
+			m.log.Warn("Failed to take shutdown snapshot", err)
+		}
+	case <-timer.C:
+		m.log.Warn("Failed to take shutdown snapshot: timed out after %s", *timeout)
+	}
+	return nil
+}
+
+// execSnapshot snapshots the state machine at the last applied index and compacts the log up to the oldest
+// retained snapshot
+func (m *manager) execSnapshot() error {
+	if m.halted {
+		return fmt.Errorf("state machine is halted")
+	}
+	m.execCommits()
+	if !m.snapshots {
+		return nil
+	}
+
+	index := m.lastApplied
+	if index == 0 {
+		return nil
+	}
+	if current := m.store.Snapshot().CurrentSnapshot(); current != nil && current.Index() >= index {
+		return nil
+	}
+
+	reader := m.store.Log().OpenReader(index)
+	defer reader.Close()
+	reader.Reset(index)
+	entry := reader.NextEntry()
+	if entry == nil || entry.Index != index {
+		return fmt.Errorf("entry %d is missing from the log", index)
+	}
+
+	snapshot := m.store.Snapshot().NewSnapshot(index, entry.Entry.Term, m.currentTime)
+	writer := snapshot.Writer()
+	if err := m.state.Snapshot(writer); err != nil {
+		_ = writer.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	m.log.Debug("Took snapshot %d", index)
+	m.uncompactedEntries = 0
+	m.uncompactedBytes = 0
+
+	// Compact the log only up to the oldest retained snapshot so that recovery can fall back to any
+	// retained snapshot and replay the entries following it.
+	compactIndex := index
+	if snapshots := m.store.Snapshot().Snapshots(); len(snapshots) > 0 && snapshots[len(snapshots)-1].Index() < compactIndex {
+		compactIndex = snapshots[len(snapshots)-1].Index()
+	}
+	if m.state.CanDelete(uint64(compactIndex)) {
+		m.log.Debug("Compacting log up to %d", compactIndex)
+		m.store.Writer().Compact(compactIndex)
+	}
 	return nil
 }
@@ -0,0 +1,693 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"github.com/atomix/go-framework/pkg/atomix/node"
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
+	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store/log"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store/snapshot"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testStateMachine is a state machine that records the indexes of applied commands
+type testStateMachine struct {
+	ctx       node.Context
+	applied   []uint64
+	installed []byte
+}
+
+// slowCommand is a command that blocks the test state machine indefinitely
+const slowCommand = "slow"
+
+// delayedCommand is a command that delays the test state machine beyond the test apply deadline
+const delayedCommand = "delayed"
+
+func (s *testStateMachine) Snapshot(writer io.Writer) error {
+	_, err := writer.Write([]byte(fmt.Sprint(s.applied)))
+	return err
+}
+
+func (s *testStateMachine) Install(reader io.Reader) error {
+	bytes, err := ioutil.ReadAll(reader)
+	s.installed = bytes
+	return err
+}
+
+func (s *testStateMachine) CanDelete(index uint64) bool {
+	return true
+}
+
+func (s *testStateMachine) Command(bytes []byte, stream streams.WriteStream) {
+	if string(bytes) == slowCommand {
+		select {}
+	}
+	if string(bytes) == delayedCommand {
+		time.Sleep(200 * time.Millisecond)
+	}
+	s.applied = append(s.applied, s.ctx.Index())
+	if stream != nil {
+		stream.Value(bytes)
+		stream.Close()
+	}
+}
+
+func (s *testStateMachine) Query(bytes []byte, stream streams.WriteStream) {
+	if stream != nil {
+		stream.Value(bytes)
+		stream.Close()
+	}
+}
+
+func newTestManager(store store.Store) (Manager, *testStateMachine) {
+	return newTestManagerWithConfig(store, &config.ProtocolConfig{})
+}
+
+func newTestManagerWithConfig(store store.Store, config *config.ProtocolConfig) (Manager, *testStateMachine) {
+	sm := &testStateMachine{}
+	manager := newManager("foo", store, config, func(ctx node.Context) node.StateMachine {
+		sm.ctx = ctx
+		return sm
+	}, true)
+	return manager, sm
+}
+
+func appendCommand(store store.Store) *log.Entry {
+	return appendCommandValue(store, []byte("Hello world!"))
+}
+
+func appendCommandValue(store store.Store, value []byte) *log.Entry {
+	return store.Writer().Append(&raft.LogEntry{
+		Term:      1,
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_Command{
+			Command: &raft.CommandEntry{
+				Value: value,
+			},
+		},
+	})
+}
+
+// awaitQuery applies a query to the manager and waits for it to complete, ensuring all prior changes have been applied
+func awaitQuery(manager Manager, index raft.Index) {
+	ch := make(chan streams.Result)
+	manager.ApplyEntry(&log.Entry{
+		Index: index,
+		Entry: &raft.LogEntry{
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Query{
+				Query: &raft.QueryEntry{},
+			},
+		},
+	}, streams.NewChannelStream(ch))
+	for range ch {
+	}
+}
+
+func TestManagerResumeFromAppliedIndex(t *testing.T) {
+	store := store.NewMemoryStore()
+	appendCommand(store)
+	appendCommand(store)
+	appendCommand(store)
+	timeout := time.Second
+	config := &config.ProtocolConfig{
+		Compaction: &config.CompactionConfig{
+			ShutdownSnapshotTimeout: &timeout,
+		},
+	}
+
+	// Verify the applied index is not persisted until the state machine is snapshotted
+	manager, sm := newTestManager(store)
+	manager.ApplyIndex(3)
+	awaitQuery(manager, 3)
+	assert.Equal(t, []uint64{1, 2, 3}, sm.applied)
+	assert.Nil(t, store.Snapshot().CurrentSnapshot())
+
+	// Restart the manager and verify entries applied since the last snapshot are replayed
+	manager, sm = newTestManagerWithConfig(store, config)
+	appendCommand(store)
+	manager.ApplyIndex(4)
+	awaitQuery(manager, 4)
+	assert.Equal(t, []uint64{1, 2, 3, 4}, sm.applied)
+
+	// Verify an entry that has already been applied is skipped
+	ch := make(chan streams.Result, 1)
+	manager.ApplyEntry(&log.Entry{
+		Index: 2,
+		Entry: &raft.LogEntry{
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Command{
+				Command: &raft.CommandEntry{},
+			},
+		},
+	}, streams.NewChannelStream(ch))
+	result := <-ch
+	assert.True(t, result.Failed())
+	awaitQuery(manager, 4)
+	assert.Equal(t, []uint64{1, 2, 3, 4}, sm.applied)
+
+	// Snapshot the state machine and verify the applied index is persisted with the snapshot
+	assert.NoError(t, manager.Close())
+	assert.Equal(t, raft.Index(4), store.Snapshot().CurrentSnapshot().Index())
+
+	// Restart the manager and verify entries included in the snapshot are not applied again
+	manager, sm = newTestManager(store)
+	assert.Equal(t, "[1 2 3 4]", string(sm.installed))
+	appendCommand(store)
+	manager.ApplyIndex(5)
+	awaitQuery(manager, 5)
+	assert.Equal(t, []uint64{5}, sm.applied)
+}
+
+func TestManagerWatch(t *testing.T) {
+	store := store.NewMemoryStore()
+	appendCommand(store)
+	appendCommand(store)
+	appendCommand(store)
+
+	manager, _ := newTestManager(store)
+	var applied []raft.Index
+	manager.Watch(func(index raft.Index) {
+		applied = append(applied, index)
+	})
+	manager.ApplyIndex(2)
+	awaitQuery(manager, 2)
+	assert.Equal(t, []raft.Index{1, 2}, applied)
+	manager.ApplyIndex(3)
+	awaitQuery(manager, 3)
+	assert.Equal(t, []raft.Index{1, 2, 3}, applied)
+}
+
+func TestManagerCrashDuringApply(t *testing.T) {
+	store := store.NewMemoryStore()
+	appendCommand(store)
+	appendCommand(store)
+	timeout := time.Second
+	config := &config.ProtocolConfig{
+		Compaction: &config.CompactionConfig{
+			ShutdownSnapshotTimeout: &timeout,
+		},
+	}
+
+	manager, sm := newTestManagerWithConfig(store, config)
+	manager.ApplyIndex(1)
+	awaitQuery(manager, 1)
+	assert.Equal(t, []uint64{1}, sm.applied)
+	assert.NoError(t, manager.Close())
+
+	// Simulate a crash while entry 2 is applied
+	manager, sm = newTestManager(store)
+	manager.ApplyIndex(2)
+	awaitQuery(manager, 2)
+	assert.Equal(t, []uint64{2}, sm.applied)
+
+	// Restart the manager and verify entry 2 is replayed on top of the snapshot rather than lost
+	manager, sm = newTestManager(store)
+	assert.Equal(t, "[1]", string(sm.installed))
+	appendCommand(store)
+	manager.ApplyIndex(3)
+	awaitQuery(manager, 3)
+	assert.Equal(t, []uint64{2, 3}, sm.applied)
+
+	// Verify no entry was applied twice to the recovered state: the snapshot and the replayed entries
+	// together apply each entry exactly once
+	var recovered []uint64
+	for _, field := range strings.Fields(strings.Trim(string(sm.installed), "[]")) {
+		index, err := strconv.ParseUint(field, 10, 64)
+		assert.NoError(t, err)
+		recovered = append(recovered, index)
+	}
+	recovered = append(recovered, sm.applied...)
+	assert.Equal(t, []uint64{1, 2, 3}, recovered)
+	assert.Equal(t, raft.Index(3), manager.AppliedIndex())
+}
+
+func TestManagerCoalesceCommits(t *testing.T) {
+	store := store.NewMemoryStore()
+	for i := 0; i < 1000; i++ {
+		appendCommand(store)
+	}
+	m, sm := newTestManager(store)
+
+	// Block the apply loop on an unconsumed query stream while the commit index advances
+	ch := make(chan streams.Result)
+	m.ApplyEntry(&log.Entry{
+		Entry: &raft.LogEntry{
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Query{
+				Query: &raft.QueryEntry{},
+			},
+		},
+	}, streams.NewChannelStream(ch))
+	for i := 1; i <= 1000; i++ {
+		m.ApplyIndex(raft.Index(i))
+	}
+	for range ch {
+	}
+
+	// Verify all entries were applied in order after a single wakeup of the apply loop
+	awaitQuery(m, 1000)
+	assert.Len(t, sm.applied, 1000)
+	for i, index := range sm.applied {
+		assert.Equal(t, uint64(i+1), index)
+	}
+	assert.Equal(t, uint64(1), m.(*manager).wakeups)
+}
+
+func newDeadlineConfig(policy config.ApplyErrorPolicy) *config.ProtocolConfig {
+	deadline := 50 * time.Millisecond
+	return &config.ProtocolConfig{
+		Apply: &config.ApplyConfig{
+			Deadline:    &deadline,
+			ErrorPolicy: policy,
+		},
+	}
+}
+
+func TestManagerApplyDeadlineSkip(t *testing.T) {
+	store := store.NewMemoryStore()
+	appendCommand(store)
+	delayed := appendCommandValue(store, []byte(delayedCommand))
+	appendCommand(store)
+
+	manager, sm := newTestManagerWithConfig(store, newDeadlineConfig(config.ApplyErrorPolicy_SKIP))
+	manager.ApplyIndex(1)
+	awaitQuery(manager, 1)
+
+	// Verify the delayed entry fails once the apply deadline is exceeded
+	ch := make(chan streams.Result, 1)
+	start := time.Now()
+	manager.ApplyEntry(delayed, streams.NewChannelStream(ch))
+	result := <-ch
+	assert.True(t, result.Failed())
+	assert.True(t, time.Since(start) < 200*time.Millisecond)
+	_, ok := <-ch
+	assert.False(t, ok)
+
+	// Verify the delayed entry is still applied in order before subsequent entries
+	manager.ApplyIndex(3)
+	awaitQuery(manager, 3)
+	assert.Equal(t, []uint64{1, 2, 3}, sm.applied)
+}
+
+func TestManagerApplyDeadlineHalt(t *testing.T) {
+	store := store.NewMemoryStore()
+	appendCommand(store)
+	delayed := appendCommandValue(store, []byte(delayedCommand))
+	appendCommand(store)
+
+	manager, sm := newTestManagerWithConfig(store, newDeadlineConfig(config.ApplyErrorPolicy_HALT))
+	manager.ApplyIndex(1)
+	awaitQuery(manager, 1)
+
+	// Verify the delayed entry fails once the apply deadline is exceeded
+	ch := make(chan streams.Result, 1)
+	manager.ApplyEntry(delayed, streams.NewChannelStream(ch))
+	result := <-ch
+	assert.True(t, result.Failed())
+
+	// Verify the delayed entry is applied to completion, but no further entries are applied
+	manager.ApplyIndex(3)
+	ch = make(chan streams.Result, 1)
+	manager.ApplyEntry(&log.Entry{
+		Index: 3,
+		Entry: &raft.LogEntry{
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Query{
+				Query: &raft.QueryEntry{},
+			},
+		},
+	}, streams.NewChannelStream(ch))
+	result = <-ch
+	assert.True(t, result.Failed())
+	assert.Equal(t, []uint64{1, 2}, sm.applied)
+	assert.Equal(t, raft.Index(2), manager.AppliedIndex())
+}
+
+func TestManagerVerifySnapshot(t *testing.T) {
+	store := store.NewMemoryStore()
+	store.Snapshot().NewSnapshot(raft.Index(10), raft.Term(1), time.Now())
+	store.Writer().Reset(raft.Index(12))
+	appendCommand(store)
+	config := &config.ProtocolConfig{
+		Recovery: &config.RecoveryConfig{
+			VerifySnapshot: true,
+		},
+	}
+
+	// Verify the state machine is halted when the snapshot is not continuous with the log
+	manager, sm := newTestManagerWithConfig(store, config)
+	manager.ApplyIndex(12)
+	ch := make(chan streams.Result, 1)
+	manager.ApplyEntry(&log.Entry{
+		Index: 12,
+		Entry: &raft.LogEntry{
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Query{
+				Query: &raft.QueryEntry{},
+			},
+		},
+	}, streams.NewChannelStream(ch))
+	result := <-ch
+	assert.True(t, result.Failed())
+	assert.Empty(t, sm.applied)
+
+	// Verify entries are applied once the log is continuous with the snapshot
+	store.Writer().Reset(raft.Index(11))
+	appendCommand(store)
+	manager, sm = newTestManagerWithConfig(store, config)
+	manager.ApplyIndex(11)
+	awaitQuery(manager, 11)
+	assert.Equal(t, []uint64{11}, sm.applied)
+}
+
+// corruptStore is a store whose latest snapshot fails verification
+type corruptStore struct {
+	store.Store
+}
+
+func (s *corruptStore) Snapshot() snapshot.Store {
+	return &corruptSnapshotStore{s.Store.Snapshot()}
+}
+
+type corruptSnapshotStore struct {
+	snapshot.Store
+}
+
+func (s *corruptSnapshotStore) Snapshots() []snapshot.Snapshot {
+	snapshots := s.Store.Snapshots()
+	if len(snapshots) > 0 {
+		snapshots[0] = &corruptSnapshot{snapshots[0]}
+	}
+	return snapshots
+}
+
+type corruptSnapshot struct {
+	snapshot.Snapshot
+}
+
+func (s *corruptSnapshot) Verify() error {
+	return errors.New("corrupt snapshot")
+}
+
+func writeSnapshot(store store.Store, index raft.Index, value string) {
+	writer := store.Snapshot().NewSnapshot(index, raft.Term(1), time.Now()).Writer()
+	_, _ = writer.Write([]byte(value))
+	_ = writer.Close()
+}
+
+func TestManagerRecoverPreviousSnapshot(t *testing.T) {
+	store := store.NewMemoryStoreWithRetention(2)
+	for i := 0; i < 4; i++ {
+		appendCommand(store)
+	}
+	writeSnapshot(store, raft.Index(1), "foo")
+	writeSnapshot(store, raft.Index(3), "bar")
+
+	// Verify the latest snapshot is installed when it's valid
+	manager, sm := newTestManager(store)
+	assert.Equal(t, "bar", string(sm.installed))
+	manager.ApplyIndex(4)
+	awaitQuery(manager, 4)
+	assert.Equal(t, []uint64{4}, sm.applied)
+
+	// Verify recovery falls back to the previous snapshot and replays the entries following it
+	// when the latest snapshot is corrupt
+	manager, sm = newTestManager(&corruptStore{store})
+	assert.Equal(t, "foo", string(sm.installed))
+	manager.ApplyIndex(4)
+	awaitQuery(manager, 4)
+	assert.Equal(t, []uint64{2, 3, 4}, sm.applied)
+}
+
+func TestManagerRecoverPreviousSnapshotCompacted(t *testing.T) {
+	store := store.NewMemoryStoreWithRetention(2)
+	for i := 0; i < 4; i++ {
+		appendCommand(store)
+	}
+	writeSnapshot(store, raft.Index(1), "foo")
+	writeSnapshot(store, raft.Index(3), "bar")
+	store.Writer().Compact(raft.Index(2))
+
+	// Verify recovery halts rather than skipping entries when the entries following the previous
+	// snapshot have been compacted
+	manager, sm := newTestManager(&corruptStore{store})
+	assert.Equal(t, "foo", string(sm.installed))
+	manager.ApplyIndex(4)
+	ch := make(chan streams.Result, 1)
+	manager.ApplyEntry(&log.Entry{
+		Index: 4,
+		Entry: &raft.LogEntry{
+			Timestamp: time.Now(),
+			Entry: &raft.LogEntry_Query{
+				Query: &raft.QueryEntry{},
+			},
+		},
+	}, streams.NewChannelStream(ch))
+	result := <-ch
+	assert.True(t, result.Failed())
+	assert.Empty(t, sm.applied)
+}
+
+func TestManagerShutdownSnapshot(t *testing.T) {
+	store := store.NewMemoryStore()
+	for i := 0; i < 3; i++ {
+		appendCommand(store)
+	}
+	timeout := time.Second
+	config := &config.ProtocolConfig{
+		Compaction: &config.CompactionConfig{
+			ShutdownSnapshotTimeout: &timeout,
+		},
+	}
+
+	// Verify closing the manager snapshots the state machine at the last applied index and compacts the log
+	manager, _ := newTestManagerWithConfig(store, config)
+	manager.ApplyIndex(3)
+	assert.NoError(t, manager.Close())
+	snapshot := store.Snapshot().CurrentSnapshot()
+	assert.NotNil(t, snapshot)
+	assert.Equal(t, raft.Index(3), snapshot.Index())
+	assert.Equal(t, raft.Term(1), snapshot.Term())
+	assert.Equal(t, raft.Index(4), store.Log().OpenReader(0).FirstIndex())
+
+	// Verify the restarted manager recovers from the snapshot and applies only later entries
+	manager, sm := newTestManagerWithConfig(store, config)
+	assert.Equal(t, "[1 2 3]", string(sm.installed))
+	appendCommand(store)
+	manager.ApplyIndex(4)
+	awaitQuery(manager, 4)
+	assert.Equal(t, []uint64{4}, sm.applied)
+}
+
+func TestManagerShutdownSnapshotTimeout(t *testing.T) {
+	store := store.NewMemoryStore()
+	appendCommand(store)
+	appendCommandValue(store, []byte(slowCommand))
+	timeout := 100 * time.Millisecond
+	manager, _ := newTestManagerWithConfig(store, &config.ProtocolConfig{
+		Compaction: &config.CompactionConfig{
+			ShutdownSnapshotTimeout: &timeout,
+		},
+	})
+	manager.ApplyIndex(2)
+
+	// Verify shutdown is not delayed beyond the timeout when the apply loop is blocked
+	start := time.Now()
+	assert.NoError(t, manager.Close())
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Nil(t, store.Snapshot().CurrentSnapshot())
+	assert.Equal(t, raft.Index(1), store.Log().OpenReader(0).FirstIndex())
+}
+
+func TestManagerCompactionEntryThreshold(t *testing.T) {
+	store := store.NewMemoryStore()
+	for i := 0; i < 5; i++ {
+		appendCommand(store)
+	}
+	manager, _ := newTestManagerWithConfig(store, &config.ProtocolConfig{
+		Compaction: &config.CompactionConfig{
+			EntryThreshold: 3,
+		},
+	})
+
+	// Verify the log is not compacted until the entry threshold is reached
+	manager.ApplyIndex(2)
+	awaitQuery(manager, 2)
+	assert.Nil(t, store.Snapshot().CurrentSnapshot())
+	assert.Equal(t, raft.Index(1), store.Log().OpenReader(0).FirstIndex())
+
+	// Verify the state machine is snapshotted and the log compacted once the threshold is reached
+	manager.ApplyIndex(3)
+	awaitQuery(manager, 3)
+	snapshot := store.Snapshot().CurrentSnapshot()
+	assert.NotNil(t, snapshot)
+	assert.Equal(t, raft.Index(3), snapshot.Index())
+	assert.Equal(t, raft.Term(1), snapshot.Term())
+	assert.Equal(t, raft.Index(4), store.Log().OpenReader(0).FirstIndex())
+
+	// Verify the threshold is counted from the last snapshot
+	manager.ApplyIndex(5)
+	awaitQuery(manager, 5)
+	assert.Equal(t, raft.Index(3), store.Snapshot().CurrentSnapshot().Index())
+	assert.Equal(t, raft.Index(4), store.Log().OpenReader(0).FirstIndex())
+}
+
+func TestManagerCompactionRetainedSnapshots(t *testing.T) {
+	store := store.NewMemoryStoreWithRetention(2)
+	for i := 0; i < 6; i++ {
+		appendCommand(store)
+	}
+	manager, _ := newTestManagerWithConfig(store, &config.ProtocolConfig{
+		Compaction: &config.CompactionConfig{
+			EntryThreshold: 2,
+		},
+	})
+
+	// Verify the log is retained after the oldest retained snapshot
+	manager.ApplyIndex(2)
+	awaitQuery(manager, 2)
+	assert.Equal(t, raft.Index(2), store.Snapshot().CurrentSnapshot().Index())
+	assert.Equal(t, raft.Index(3), store.Log().OpenReader(0).FirstIndex())
+	manager.ApplyIndex(4)
+	awaitQuery(manager, 4)
+	assert.Equal(t, raft.Index(4), store.Snapshot().CurrentSnapshot().Index())
+	assert.Equal(t, raft.Index(3), store.Log().OpenReader(0).FirstIndex())
+
+	// Verify the log is compacted up to the oldest retained snapshot as older snapshots are discarded
+	manager.ApplyIndex(6)
+	awaitQuery(manager, 6)
+	assert.Equal(t, raft.Index(6), store.Snapshot().CurrentSnapshot().Index())
+	assert.Equal(t, raft.Index(5), store.Log().OpenReader(0).FirstIndex())
+}
+
+func TestManagerCompactionSizeThreshold(t *testing.T) {
+	store := store.NewMemoryStore()
+	entry := appendCommandValue(store, make([]byte, 100))
+	appendCommandValue(store, make([]byte, 100))
+	manager, _ := newTestManagerWithConfig(store, &config.ProtocolConfig{
+		Compaction: &config.CompactionConfig{
+			SizeThreshold: uint64(entry.Entry.Size() + 1),
+		},
+	})
+
+	// Verify the log is compacted once the size of the applied entries exceeds the threshold
+	manager.ApplyIndex(1)
+	awaitQuery(manager, 1)
+	assert.Nil(t, store.Snapshot().CurrentSnapshot())
+	manager.ApplyIndex(2)
+	awaitQuery(manager, 2)
+	assert.NotNil(t, store.Snapshot().CurrentSnapshot())
+	assert.Equal(t, raft.Index(2), store.Snapshot().CurrentSnapshot().Index())
+	assert.Equal(t, raft.Index(3), store.Log().OpenReader(0).FirstIndex())
+}
+
+func BenchmarkManagerCommitBurst(b *testing.B) {
+	store := store.NewMemoryStore()
+	for i := 0; i < b.N; i++ {
+		appendCommand(store)
+	}
+	m, _ := newTestManager(store)
+
+	b.ResetTimer()
+	for i := 1; i <= b.N; i++ {
+		m.ApplyIndex(raft.Index(i))
+	}
+	awaitQuery(m, raft.Index(b.N))
+	b.StopTimer()
+	b.ReportMetric(float64(m.(*manager).wakeups)/float64(b.N), "wakeups/entry")
+}
+
+func TestManagerApplyFunc(t *testing.T) {
+	store := store.NewMemoryStore()
+	appendCommandValue(store, []byte("foo"))
+	appendCommandValue(store, []byte("fail"))
+
+	var indexes []raft.Index
+	manager := NewManagerWithApplyFunc("foo", store, &config.ProtocolConfig{}, func(index raft.Index, entry []byte) ([]byte, error) {
+		indexes = append(indexes, index)
+		if string(entry) == "fail" {
+			return nil, errors.New("failed")
+		}
+		return append([]byte("applied "), entry...), nil
+	})
+	assert.Equal(t, raft.Index(0), manager.AppliedIndex())
+
+	ch := make(chan streams.Result, 1)
+	manager.ApplyEntry(store.Log().OpenReader(1).NextEntry(), streams.NewChannelStream(ch))
+	result := <-ch
+	assert.True(t, result.Succeeded())
+	assert.Equal(t, []byte("applied foo"), result.Value)
+
+	ch = make(chan streams.Result, 1)
+	manager.ApplyEntry(store.Log().OpenReader(2).NextEntry(), streams.NewChannelStream(ch))
+	result = <-ch
+	assert.True(t, result.Failed())
+	assert.EqualError(t, result.Error, "failed")
+
+	awaitQuery(manager, 2)
+	assert.Equal(t, []raft.Index{1, 2, 2}, indexes)
+	assert.Equal(t, raft.Index(2), manager.AppliedIndex())
+}
+
+func TestManagerApplyFuncRestart(t *testing.T) {
+	store := store.NewMemoryStore()
+	appendCommandValue(store, []byte("foo"))
+	appendCommandValue(store, []byte("bar"))
+	appendCommandValue(store, []byte("baz"))
+	timeout := time.Second
+	config := &config.ProtocolConfig{
+		Compaction: &config.CompactionConfig{
+			EntryThreshold:          1,
+			ShutdownSnapshotTimeout: &timeout,
+		},
+	}
+
+	var entries []string
+	apply := func(index raft.Index, entry []byte) ([]byte, error) {
+		if entry != nil {
+			entries = append(entries, string(entry))
+		}
+		return nil, nil
+	}
+
+	// Verify neither the compaction threshold nor the shutdown snapshot snapshots an ApplyFunc
+	manager := NewManagerWithApplyFunc("foo", store, config, apply)
+	manager.ApplyIndex(3)
+	awaitQuery(manager, 3)
+	assert.Equal(t, []string{"foo", "bar", "baz"}, entries)
+	assert.NoError(t, manager.Close())
+	assert.Nil(t, store.Snapshot().CurrentSnapshot())
+	assert.Equal(t, raft.Index(1), store.Log().OpenReader(0).FirstIndex())
+
+	// Restart the manager and verify all committed entries are replayed into the ApplyFunc
+	entries = nil
+	manager = NewManagerWithApplyFunc("foo", store, config, apply)
+	assert.Equal(t, raft.Index(0), manager.AppliedIndex())
+	manager.ApplyIndex(3)
+	awaitQuery(manager, 3)
+	assert.Equal(t, []string{"foo", "bar", "baz"}, entries)
+}
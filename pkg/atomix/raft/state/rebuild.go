@@ -0,0 +1,121 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"fmt"
+	"github.com/atomix/go-framework/pkg/atomix/node"
+	"github.com/atomix/go-framework/pkg/atomix/service"
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
+	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store/log"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store/snapshot"
+	"sync/atomic"
+	"time"
+)
+
+// RebuildStateMachine replays committed entries into a new state machine created by the given factory.
+// If fromIndex is 0, the state machine is first restored from the current snapshot and entries are
+// replayed from the snapshot index forward. The snapshot is read and the log reader opened on the apply
+// loop so that neither can be read while the apply loop is snapshotting the state machine or compacting
+// the log. The replay itself runs in the calling goroutine concurrently with the apply loop, reading the
+// log through a separate reader and never modifying the log or the applied index, so the running state
+// machine is not disrupted. If the log is compacted past the replayed entries during the replay, an error
+// is returned. Entries are replayed up to the commit index at the time of the call.
+func (m *manager) RebuildStateMachine(factory func(node.Context) node.StateMachine, fromIndex raft.Index) (node.StateMachine, error) {
+	ctx := &rebuildContext{
+		member: m.member,
+	}
+	sm := factory(ctx)
+	commitIndex := raft.Index(atomic.LoadUint64(&m.commitIndex))
+
+	var current snapshot.Snapshot
+	var reader log.Reader
+	done := make(chan struct{})
+	m.ch <- &change{
+		task: func() {
+			if fromIndex == 0 {
+				current = m.store.Snapshot().CurrentSnapshot()
+			}
+			reader = m.store.Log().OpenReader(0)
+			close(done)
+		},
+	}
+	<-done
+	defer reader.Close()
+
+	if fromIndex == 0 {
+		fromIndex = 1
+		if current != nil {
+			snapshotReader := current.Reader()
+			err := sm.Install(snapshotReader)
+			_ = snapshotReader.Close()
+			if err != nil {
+				return nil, err
+			}
+			ctx.index = current.Index()
+			ctx.timestamp = current.Timestamp()
+			fromIndex = current.Index() + 1
+		}
+	}
+
+	if fromIndex < reader.FirstIndex() {
+		return nil, fmt.Errorf("entry %d has been compacted", fromIndex)
+	}
+	reader.Reset(fromIndex)
+
+	m.log.Debug("Rebuilding state machine from index %d to %d", fromIndex, commitIndex)
+	for index := fromIndex; index <= commitIndex; index++ {
+		entry := reader.NextEntry()
+		if entry == nil || entry.Index != index {
+			return nil, fmt.Errorf("entry %d is missing from the log", index)
+		}
+		ctx.update(entry.Index, entry.Entry.Timestamp)
+		if command, ok := entry.Entry.Entry.(*raft.LogEntry_Command); ok {
+			sm.Command(command.Command.Value, streams.NewNilStream())
+		}
+	}
+	return sm, nil
+}
+
+// rebuildContext is the node.Context of a state machine being rebuilt
+type rebuildContext struct {
+	member    raft.MemberID
+	index     raft.Index
+	timestamp time.Time
+}
+
+func (c *rebuildContext) update(index raft.Index, timestamp time.Time) {
+	c.index = index
+	if timestamp.UnixNano() > c.timestamp.UnixNano() {
+		c.timestamp = timestamp
+	}
+}
+
+func (c *rebuildContext) Node() string {
+	return string(c.member)
+}
+
+func (c *rebuildContext) Index() uint64 {
+	return uint64(c.index)
+}
+
+func (c *rebuildContext) Timestamp() time.Time {
+	return c.timestamp
+}
+
+func (c *rebuildContext) OperationType() service.OperationType {
+	return service.OpTypeCommand
+}
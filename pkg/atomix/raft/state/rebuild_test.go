@@ -0,0 +1,146 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"github.com/atomix/go-framework/pkg/atomix/node"
+	"github.com/atomix/go-framework/pkg/atomix/service"
+	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"github.com/atomix/raft-replica/pkg/atomix/raft/store"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestFactory() (func(node.Context) node.StateMachine, *testStateMachine) {
+	sm := &testStateMachine{}
+	return func(ctx node.Context) node.StateMachine {
+		sm.ctx = ctx
+		return sm
+	}, sm
+}
+
+func TestManagerRebuildStateMachine(t *testing.T) {
+	store := store.NewMemoryStore()
+	for i := 0; i < 5; i++ {
+		appendCommand(store)
+	}
+	manager, sm := newTestManager(store)
+	manager.ApplyIndex(5)
+	awaitQuery(manager, 5)
+
+	// Verify a state machine rebuilt from the start of the log is equivalent to the running state machine
+	factory, rebuilt := newTestFactory()
+	result, err := manager.RebuildStateMachine(factory, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, rebuilt, result)
+	assert.Equal(t, sm.applied, rebuilt.applied)
+	assert.Equal(t, uint64(5), rebuilt.ctx.Index())
+
+	// Verify entries are replayed only from the given index
+	factory, rebuilt = newTestFactory()
+	_, err = manager.RebuildStateMachine(factory, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{3, 4, 5}, rebuilt.applied)
+
+	// Verify the rebuild does not disrupt the running state machine
+	assert.Equal(t, raft.Index(5), manager.AppliedIndex())
+	appendCommand(store)
+	manager.ApplyIndex(6)
+	awaitQuery(manager, 6)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6}, sm.applied)
+}
+
+func TestManagerRebuildPrimitiveStateMachine(t *testing.T) {
+	store := store.NewMemoryStore()
+	bytes, err := proto.Marshal(&service.ServiceRequest{
+		Id: &service.ServiceId{
+			Type:      "unknown",
+			Name:      "test",
+			Namespace: "test",
+		},
+		Request: &service.ServiceRequest_Command{
+			Command: []byte("Hello world!"),
+		},
+	})
+	assert.NoError(t, err)
+	appendCommandValue(store, bytes)
+	appendCommandValue(store, []byte{0xff})
+	manager, _ := newTestManager(store)
+	manager.ApplyIndex(2)
+	awaitQuery(manager, 2)
+
+	// Verify commands that fail in the primitive state machine are replayed without a client stream
+	result, err := manager.RebuildStateMachine(func(ctx node.Context) node.StateMachine {
+		return node.NewPrimitiveStateMachine(node.GetRegistry(), ctx)
+	}, 1)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestManagerRebuildStateMachineUncommitted(t *testing.T) {
+	store := store.NewMemoryStore()
+	for i := 0; i < 5; i++ {
+		appendCommand(store)
+	}
+	manager, _ := newTestManager(store)
+	manager.ApplyIndex(3)
+	awaitQuery(manager, 3)
+
+	// Verify uncommitted entries are not replayed
+	factory, rebuilt := newTestFactory()
+	_, err := manager.RebuildStateMachine(factory, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, rebuilt.applied)
+}
+
+func TestManagerRebuildStateMachineCompacted(t *testing.T) {
+	store := store.NewMemoryStore()
+	store.Log().Writer().Reset(10)
+	appendCommand(store)
+	manager, _ := newTestManager(store)
+	manager.ApplyIndex(10)
+
+	// Verify entries that have been compacted cannot be replayed
+	factory, _ := newTestFactory()
+	_, err := manager.RebuildStateMachine(factory, 5)
+	assert.Error(t, err)
+}
+
+func TestManagerRebuildStateMachineConcurrentAppend(t *testing.T) {
+	store := store.NewMemoryStore()
+	for i := 0; i < 100; i++ {
+		appendCommand(store)
+	}
+	manager, _ := newTestManager(store)
+	manager.ApplyIndex(100)
+	awaitQuery(manager, 100)
+
+	// Verify the rebuild is not disrupted by entries appended and applied concurrently
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 101; i <= 300; i++ {
+			appendCommand(store)
+			manager.ApplyIndex(raft.Index(i))
+		}
+	}()
+	factory, rebuilt := newTestFactory()
+	_, err := manager.RebuildStateMachine(factory, 0)
+	assert.NoError(t, err)
+	assert.True(t, len(rebuilt.applied) >= 100)
+	<-done
+	awaitQuery(manager, 300)
+}
@@ -15,8 +15,11 @@
 package log
 
 import (
+	"fmt"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"hash/crc32"
 	"io"
+	"sync"
 )
 
 // NewMemoryLog creates a new in-memory Log
@@ -61,6 +64,12 @@ type Writer interface {
 
 	// Truncate truncates the tail of the log to the given index
 	Truncate(index raft.Index)
+
+	// Compact removes entries up to and including the given index from the head of the log
+	Compact(index raft.Index)
+
+	// Flush flushes entries written to the log to durable storage
+	Flush() error
 }
 
 // Reader supports reading of entries from the Raft log
@@ -93,13 +102,36 @@ type Reader interface {
 type Entry struct {
 	Index raft.Index
 	Entry *raft.LogEntry
+	// checksum is the checksum of the entry computed when the entry was written to the log
+	checksum uint32
+}
+
+// Verify verifies the integrity of an entry read from the log. An entry fails verification if its
+// contents differ from the contents written to the log, e.g. if the write was torn by a crash.
+func (e *Entry) Verify() error {
+	if checksumEntry(e.Entry) != e.checksum {
+		return fmt.Errorf("entry %d failed checksum verification", e.Index)
+	}
+	return nil
 }
 
+// checksumEntry returns the checksum of the given entry's encoded bytes
+func checksumEntry(entry *raft.LogEntry) uint32 {
+	bytes, err := entry.Marshal()
+	if err != nil {
+		return 0
+	}
+	return crc32.ChecksumIEEE(bytes)
+}
+
+// memoryLog is an in-memory Log. The log is safe for concurrent use: the writer and the log's readers
+// may be used from different goroutines, but each reader must be used by a single goroutine at a time.
 type memoryLog struct {
 	entries    []*Entry
 	firstIndex raft.Index
 	writer     *memoryWriter
 	readers    []*memoryReader
+	mu         sync.RWMutex
 }
 
 func (l *memoryLog) Writer() Writer {
@@ -107,6 +139,8 @@ func (l *memoryLog) Writer() Writer {
 }
 
 func (l *memoryLog) OpenReader(index raft.Index) Reader {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	readerIndex := -1
 	for i := 0; i < len(l.entries); i++ {
 		if l.entries[i].Index == index {
@@ -122,6 +156,34 @@ func (l *memoryLog) OpenReader(index raft.Index) Reader {
 	return reader
 }
 
+// closeReader removes the given reader from the log's readers
+func (l *memoryLog) closeReader(reader *memoryReader) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, r := range l.readers {
+		if r == reader {
+			l.readers = append(l.readers[:i], l.readers[i+1:]...)
+			return
+		}
+	}
+}
+
+// lastEntry returns the last entry in the log. The caller must hold the log's lock.
+func (l *memoryLog) lastEntry() *Entry {
+	if len(l.entries) == 0 {
+		return nil
+	}
+	return l.entries[len(l.entries)-1]
+}
+
+// lastIndex returns the last index in the log. The caller must hold the log's lock.
+func (l *memoryLog) lastIndex() raft.Index {
+	if entry := l.lastEntry(); entry != nil {
+		return entry.Index
+	}
+	return l.firstIndex - 1
+}
+
 func (l *memoryLog) Close() error {
 	return nil
 }
@@ -131,36 +193,32 @@ type memoryWriter struct {
 }
 
 func (w *memoryWriter) LastIndex() raft.Index {
-	if entry := w.LastEntry(); entry != nil {
-		return entry.Index
-	}
-	return w.log.firstIndex - 1
+	w.log.mu.RLock()
+	defer w.log.mu.RUnlock()
+	return w.log.lastIndex()
 }
 
 func (w *memoryWriter) LastEntry() *Entry {
-	if len(w.log.entries) == 0 {
-		return nil
-	}
-	return w.log.entries[len(w.log.entries)-1]
-}
-
-func (w *memoryWriter) nextIndex() raft.Index {
-	if len(w.log.entries) == 0 {
-		return w.log.firstIndex
-	}
-	return w.log.entries[len(w.log.entries)-1].Index + 1
+	w.log.mu.RLock()
+	defer w.log.mu.RUnlock()
+	return w.log.lastEntry()
 }
 
 func (w *memoryWriter) Append(entry *raft.LogEntry) *Entry {
 	indexed := &Entry{
-		Index: w.nextIndex(),
-		Entry: entry,
+		Entry:    entry,
+		checksum: checksumEntry(entry),
 	}
+	w.log.mu.Lock()
+	defer w.log.mu.Unlock()
+	indexed.Index = w.log.lastIndex() + 1
 	w.log.entries = append(w.log.entries, indexed)
 	return indexed
 }
 
 func (w *memoryWriter) Reset(index raft.Index) {
+	w.log.mu.Lock()
+	defer w.log.mu.Unlock()
 	w.log.entries = w.log.entries[:0]
 	w.log.firstIndex = index
 	for _, reader := range w.log.readers {
@@ -169,6 +227,8 @@ func (w *memoryWriter) Reset(index raft.Index) {
 }
 
 func (w *memoryWriter) Truncate(index raft.Index) {
+	w.log.mu.Lock()
+	defer w.log.mu.Unlock()
 	for i := 0; i < len(w.log.entries); i++ {
 		if w.log.entries[i].Index > index {
 			w.log.entries = w.log.entries[:i]
@@ -180,6 +240,30 @@ func (w *memoryWriter) Truncate(index raft.Index) {
 	}
 }
 
+func (w *memoryWriter) Compact(index raft.Index) {
+	w.log.mu.Lock()
+	defer w.log.mu.Unlock()
+	if index < w.log.firstIndex {
+		return
+	}
+	count := 0
+	for count < len(w.log.entries) && w.log.entries[count].Index <= index {
+		count++
+	}
+	w.log.entries = w.log.entries[count:]
+	w.log.firstIndex = index + 1
+	for _, reader := range w.log.readers {
+		reader.index -= count
+		if reader.index < -1 {
+			reader.index = -1
+		}
+	}
+}
+
+func (w *memoryWriter) Flush() error {
+	return nil
+}
+
 func (w *memoryWriter) Close() error {
 	panic("implement me")
 }
@@ -190,17 +274,20 @@ type memoryReader struct {
 }
 
 func (r *memoryReader) FirstIndex() raft.Index {
+	r.log.mu.RLock()
+	defer r.log.mu.RUnlock()
 	return r.log.firstIndex
 }
 
 func (r *memoryReader) LastIndex() raft.Index {
-	if len(r.log.entries) == 0 {
-		return r.log.firstIndex - 1
-	}
-	return r.log.entries[len(r.log.entries)-1].Index
+	r.log.mu.RLock()
+	defer r.log.mu.RUnlock()
+	return r.log.lastIndex()
 }
 
 func (r *memoryReader) CurrentIndex() raft.Index {
+	r.log.mu.RLock()
+	defer r.log.mu.RUnlock()
 	if r.index == -1 || len(r.log.entries) == 0 {
 		return r.log.firstIndex - 1
 	}
@@ -208,6 +295,8 @@ func (r *memoryReader) CurrentIndex() raft.Index {
 }
 
 func (r *memoryReader) CurrentEntry() *Entry {
+	r.log.mu.RLock()
+	defer r.log.mu.RUnlock()
 	if r.index == -1 || len(r.log.entries) == 0 {
 		return nil
 	}
@@ -215,6 +304,8 @@ func (r *memoryReader) CurrentEntry() *Entry {
 }
 
 func (r *memoryReader) NextIndex() raft.Index {
+	r.log.mu.RLock()
+	defer r.log.mu.RUnlock()
 	if r.index == -1 || len(r.log.entries) == 0 {
 		return r.log.firstIndex
 	}
@@ -222,6 +313,8 @@ func (r *memoryReader) NextIndex() raft.Index {
 }
 
 func (r *memoryReader) NextEntry() *Entry {
+	r.log.mu.RLock()
+	defer r.log.mu.RUnlock()
 	if len(r.log.entries) > r.index+1 {
 		r.index++
 		return r.log.entries[r.index]
@@ -230,6 +323,8 @@ func (r *memoryReader) NextEntry() *Entry {
 }
 
 func (r *memoryReader) Reset(index raft.Index) {
+	r.log.mu.RLock()
+	defer r.log.mu.RUnlock()
 	for i := 0; i < len(r.log.entries); i++ {
 		if r.log.entries[i].Index >= index {
 			r.index = i - 1
@@ -238,6 +333,8 @@ func (r *memoryReader) Reset(index raft.Index) {
 	}
 }
 
+// maybeReset moves the reader back to the end of the log if the log was truncated beneath it.
+// The caller must hold the log's write lock.
 func (r *memoryReader) maybeReset() {
 	if r.index >= 0 && len(r.log.entries) <= r.index {
 		r.index = len(r.log.entries) - 1
@@ -245,5 +342,6 @@ func (r *memoryReader) maybeReset() {
 }
 
 func (r *memoryReader) Close() error {
+	r.log.closeReader(r)
 	return nil
 }
@@ -123,3 +123,111 @@ func TestMemoryLog(t *testing.T) {
 	assert.Equal(t, raft.Index(10), reader.NextIndex())
 	assert.Nil(t, reader.NextEntry())
 }
+
+func TestMemoryLogCompact(t *testing.T) {
+	log := NewMemoryLog()
+	writer := log.Writer()
+	for i := 0; i < 5; i++ {
+		writer.Append(&raft.LogEntry{
+			Term:      1,
+			Timestamp: time.Now(),
+			Entry:     &raft.LogEntry_Initialize{},
+		})
+	}
+	reader := log.OpenReader(0)
+	assert.Equal(t, raft.Index(1), reader.NextEntry().Index)
+	assert.Equal(t, raft.Index(2), reader.NextEntry().Index)
+	ahead := log.OpenReader(0)
+	ahead.Reset(4)
+	assert.Equal(t, raft.Index(4), ahead.NextEntry().Index)
+
+	writer.Compact(3)
+	assert.Equal(t, raft.Index(4), reader.FirstIndex())
+	assert.Equal(t, raft.Index(5), writer.LastIndex())
+
+	// Verify readers positioned before the compaction index resume from the head of the log
+	assert.Equal(t, raft.Index(4), reader.NextEntry().Index)
+	assert.Equal(t, raft.Index(5), reader.NextEntry().Index)
+	assert.Nil(t, reader.NextEntry())
+
+	// Verify readers positioned after the compaction index are not moved
+	assert.Equal(t, raft.Index(4), ahead.CurrentIndex())
+	assert.Equal(t, raft.Index(5), ahead.NextEntry().Index)
+
+	// Verify the log can be compacted beyond its last entry
+	writer.Compact(10)
+	assert.Equal(t, raft.Index(11), reader.FirstIndex())
+	assert.Equal(t, raft.Index(10), writer.LastIndex())
+	entry := writer.Append(&raft.LogEntry{
+		Term:      1,
+		Timestamp: time.Now(),
+		Entry:     &raft.LogEntry_Initialize{},
+	})
+	assert.Equal(t, raft.Index(11), entry.Index)
+	assert.Equal(t, raft.Index(11), reader.NextEntry().Index)
+}
+
+func TestMemoryLogCloseReader(t *testing.T) {
+	log := NewMemoryLog().(*memoryLog)
+	reader := log.OpenReader(0)
+	other := log.OpenReader(0)
+	assert.Len(t, log.readers, 2)
+
+	// Verify closed readers are no longer tracked by the log
+	assert.NoError(t, reader.Close())
+	assert.Len(t, log.readers, 1)
+	assert.Equal(t, other, log.readers[0])
+	assert.NoError(t, other.Close())
+	assert.Len(t, log.readers, 0)
+}
+
+func TestMemoryLogConcurrentAccess(t *testing.T) {
+	log := NewMemoryLog()
+	writer := log.Writer()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			writer.Append(&raft.LogEntry{
+				Term:      1,
+				Timestamp: time.Now(),
+				Entry:     &raft.LogEntry_Initialize{},
+			})
+			if i%100 == 0 {
+				writer.Compact(writer.LastIndex() / 2)
+			}
+		}
+	}()
+
+	// Verify entries can be read while the log is appended and compacted concurrently
+	reader := log.OpenReader(0)
+	defer reader.Close()
+	var index raft.Index
+	for index < 1000 {
+		if entry := reader.NextEntry(); entry != nil {
+			assert.True(t, entry.Index > index)
+			index = entry.Index
+		}
+		_ = reader.LastIndex()
+	}
+	<-done
+}
+
+func TestEntryVerify(t *testing.T) {
+	log := NewMemoryLog()
+	entry := log.Writer().Append(&raft.LogEntry{
+		Term:      1,
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_Command{
+			Command: &raft.CommandEntry{
+				Value: []byte("Hello world!"),
+			},
+		},
+	})
+	assert.NoError(t, entry.Verify())
+
+	// Verify a partially written entry fails verification
+	command := entry.Entry.GetCommand()
+	command.Value = command.Value[:5]
+	assert.Error(t, entry.Verify())
+}
@@ -16,26 +16,52 @@ package snapshot
 
 import (
 	"bytes"
+	"fmt"
 	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"hash/crc32"
 	"io"
 	"time"
 )
 
-// NewMemoryStore creates a new in-memory snapshot store
+// Version is the format version of snapshots written by this member
+const Version uint32 = 1
+
+// IsSupportedVersion returns whether snapshots in the given format version can be installed by this member.
+// Version 0 denotes a snapshot sent by a member that predates snapshot versioning, which uses the format of version 1.
+func IsSupportedVersion(version uint32) bool {
+	return version <= Version
+}
+
+// NewMemoryStore creates a new in-memory snapshot store retaining only the latest snapshot
 func NewMemoryStore() Store {
+	return NewMemoryStoreWithRetention(1)
+}
+
+// NewMemoryStoreWithRetention creates a new in-memory snapshot store retaining the given number of snapshots
+func NewMemoryStoreWithRetention(retained int) Store {
+	if retained < 1 {
+		retained = 1
+	}
 	return &memorySnapshotStore{
-		snapshots: make(map[raft.Index]Snapshot),
+		retained: retained,
 	}
 }
 
 // Store is an interface for managing snapshots
 type Store interface {
 	// NewSnapshot creates a new snapshot
-	NewSnapshot(index raft.Index, timestamp time.Time) Snapshot
+	NewSnapshot(index raft.Index, term raft.Term, timestamp time.Time) Snapshot
 
 	// CurrentSnapshot returns the current snapshot
 	CurrentSnapshot() Snapshot
 
+	// PendingSnapshot returns the partially received snapshot at the given index, creating it if no snapshot
+	// is pending at the index. A pending snapshot at any other index is discarded.
+	PendingSnapshot(index raft.Index, term raft.Term, timestamp time.Time) PendingSnapshot
+
+	// Snapshots returns the retained snapshots ordered from the newest to the oldest snapshot
+	Snapshots() []Snapshot
+
 	// Close closes the store
 	Close() error
 }
@@ -45,6 +71,9 @@ type Snapshot interface {
 	// Index is the index at which the snapshot was taken
 	Index() raft.Index
 
+	// Term is the term of the entry at which the snapshot was taken
+	Term() raft.Term
+
 	// Timestamp is the time at which the snapshot was taken
 	Timestamp() time.Time
 
@@ -53,21 +82,47 @@ type Snapshot interface {
 
 	// Writer returns a new snapshot writer
 	Writer() io.WriteCloser
+
+	// Verify verifies the integrity of the snapshot
+	Verify() error
+}
+
+// PendingSnapshot is a snapshot that is being received from another member. Pending snapshots are staged
+// outside the store until they're committed, allowing an interrupted install to resume from its offset.
+type PendingSnapshot interface {
+	// Index is the index at which the snapshot was taken
+	Index() raft.Index
+
+	// Offset returns the number of bytes of the snapshot that have been received
+	Offset() uint64
+
+	// WriteAt writes the given bytes at the given offset, discarding any bytes received beyond the offset.
+	// The offset must not be greater than the number of bytes received.
+	WriteAt(bytes []byte, offset uint64) error
+
+	// Commit adds the snapshot to the store as the current snapshot
+	Commit() Snapshot
 }
 
 // memorySnapshotStore is an in-memory Store
 type memorySnapshotStore struct {
-	snapshots       map[raft.Index]Snapshot
+	retained        int
+	snapshots       []Snapshot
 	currentSnapshot Snapshot
+	pendingSnapshot *memoryPendingSnapshot
 }
 
-func (s *memorySnapshotStore) NewSnapshot(index raft.Index, timestamp time.Time) Snapshot {
+func (s *memorySnapshotStore) NewSnapshot(index raft.Index, term raft.Term, timestamp time.Time) Snapshot {
 	snapshot := &memorySnapshot{
 		index:     index,
+		term:      term,
 		timestamp: timestamp,
 		bytes:     make([]byte, 0, 1024*1024),
 	}
-	s.snapshots[index] = snapshot
+	s.snapshots = append(s.snapshots, snapshot)
+	if len(s.snapshots) > s.retained {
+		s.snapshots = s.snapshots[len(s.snapshots)-s.retained:]
+	}
 	s.currentSnapshot = snapshot
 	return snapshot
 }
@@ -76,20 +131,46 @@ func (s *memorySnapshotStore) CurrentSnapshot() Snapshot {
 	return s.currentSnapshot
 }
 
+func (s *memorySnapshotStore) PendingSnapshot(index raft.Index, term raft.Term, timestamp time.Time) PendingSnapshot {
+	if s.pendingSnapshot == nil || s.pendingSnapshot.index != index {
+		s.pendingSnapshot = &memoryPendingSnapshot{
+			store:     s,
+			index:     index,
+			term:      term,
+			timestamp: timestamp,
+		}
+	}
+	return s.pendingSnapshot
+}
+
+func (s *memorySnapshotStore) Snapshots() []Snapshot {
+	snapshots := make([]Snapshot, len(s.snapshots))
+	for i, snapshot := range s.snapshots {
+		snapshots[len(s.snapshots)-i-1] = snapshot
+	}
+	return snapshots
+}
+
 func (s *memorySnapshotStore) Close() error {
 	return nil
 }
 
 type memorySnapshot struct {
 	index     raft.Index
+	term      raft.Term
 	timestamp time.Time
 	bytes     []byte
+	checksum  uint32
 }
 
 func (s *memorySnapshot) Index() raft.Index {
 	return s.index
 }
 
+func (s *memorySnapshot) Term() raft.Term {
+	return s.term
+}
+
 func (s *memorySnapshot) Timestamp() time.Time {
 	return s.timestamp
 }
@@ -107,6 +188,48 @@ func (s *memorySnapshot) Writer() io.WriteCloser {
 	}
 }
 
+func (s *memorySnapshot) Verify() error {
+	if crc32.ChecksumIEEE(s.bytes) != s.checksum {
+		return fmt.Errorf("snapshot %d failed checksum verification", s.index)
+	}
+	return nil
+}
+
+type memoryPendingSnapshot struct {
+	store     *memorySnapshotStore
+	index     raft.Index
+	term      raft.Term
+	timestamp time.Time
+	bytes     []byte
+}
+
+func (s *memoryPendingSnapshot) Index() raft.Index {
+	return s.index
+}
+
+func (s *memoryPendingSnapshot) Offset() uint64 {
+	return uint64(len(s.bytes))
+}
+
+func (s *memoryPendingSnapshot) WriteAt(bytes []byte, offset uint64) error {
+	if offset > uint64(len(s.bytes)) {
+		return fmt.Errorf("offset %d exceeds received bytes %d of snapshot %d", offset, len(s.bytes), s.index)
+	}
+	s.bytes = append(s.bytes[:offset], bytes...)
+	return nil
+}
+
+func (s *memoryPendingSnapshot) Commit() Snapshot {
+	snapshot := s.store.NewSnapshot(s.index, s.term, s.timestamp)
+	writer := snapshot.Writer()
+	_, _ = writer.Write(s.bytes)
+	_ = writer.Close()
+	if s.store.pendingSnapshot == s {
+		s.store.pendingSnapshot = nil
+	}
+	return snapshot
+}
+
 type memoryReader struct {
 	reader io.Reader
 }
@@ -130,5 +253,6 @@ func (w *memoryWriter) Write(p []byte) (n int, err error) {
 
 func (w *memoryWriter) Close() error {
 	w.snapshot.bytes = w.buf.Bytes()
+	w.snapshot.checksum = crc32.ChecksumIEEE(w.snapshot.bytes)
 	return nil
 }
@@ -26,8 +26,9 @@ func TestSnapshot(t *testing.T) {
 	assert.Nil(t, store.CurrentSnapshot())
 
 	ts := time.Now()
-	snapshot := store.NewSnapshot(raft.Index(1), ts)
+	snapshot := store.NewSnapshot(raft.Index(1), raft.Term(2), ts)
 	assert.Equal(t, raft.Index(1), snapshot.Index())
+	assert.Equal(t, raft.Term(2), snapshot.Term())
 	assert.Equal(t, ts, snapshot.Timestamp())
 
 	writer := snapshot.Writer()
@@ -53,3 +54,72 @@ func TestSnapshot(t *testing.T) {
 	err = reader.Close()
 	assert.NoError(t, err)
 }
+
+func TestSnapshotRetention(t *testing.T) {
+	store := NewMemoryStoreWithRetention(2)
+	assert.Empty(t, store.Snapshots())
+
+	store.NewSnapshot(raft.Index(1), raft.Term(1), time.Now())
+	store.NewSnapshot(raft.Index(2), raft.Term(1), time.Now())
+	store.NewSnapshot(raft.Index(3), raft.Term(1), time.Now())
+
+	// Verify only the latest snapshots are retained, from newest to oldest
+	snapshots := store.Snapshots()
+	assert.Len(t, snapshots, 2)
+	assert.Equal(t, raft.Index(3), snapshots[0].Index())
+	assert.Equal(t, raft.Index(2), snapshots[1].Index())
+	assert.Equal(t, raft.Index(3), store.CurrentSnapshot().Index())
+}
+
+func TestSnapshotVerify(t *testing.T) {
+	store := NewMemoryStore()
+	snapshot := store.NewSnapshot(raft.Index(1), raft.Term(1), time.Now())
+	writer := snapshot.Writer()
+	_, err := writer.Write([]byte("Hello world!"))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, snapshot.Verify())
+
+	// Verify corruption of the snapshot is detected
+	snapshot.(*memorySnapshot).bytes[0] = 'h'
+	assert.Error(t, snapshot.Verify())
+}
+
+func TestSnapshotVersion(t *testing.T) {
+	assert.True(t, IsSupportedVersion(0))
+	assert.True(t, IsSupportedVersion(Version))
+	assert.False(t, IsSupportedVersion(Version+1))
+}
+
+func TestPendingSnapshot(t *testing.T) {
+	store := NewMemoryStore()
+	timestamp := time.Now()
+	pending := store.PendingSnapshot(raft.Index(10), raft.Term(2), timestamp)
+	assert.NoError(t, pending.WriteAt([]byte("abc"), 0))
+	assert.Equal(t, uint64(3), pending.Offset())
+
+	// Verify the pending snapshot is resumed at the same index and writes beyond the offset are rejected
+	pending = store.PendingSnapshot(raft.Index(10), raft.Term(2), timestamp)
+	assert.Equal(t, uint64(3), pending.Offset())
+	assert.Error(t, pending.WriteAt([]byte("e"), 4))
+	assert.NoError(t, pending.WriteAt([]byte("xd"), 2))
+	assert.Equal(t, uint64(4), pending.Offset())
+	assert.Nil(t, store.CurrentSnapshot())
+
+	// Verify a pending snapshot at a different index replaces the pending snapshot
+	assert.Equal(t, uint64(0), store.PendingSnapshot(raft.Index(20), raft.Term(2), timestamp).Offset())
+	pending = store.PendingSnapshot(raft.Index(10), raft.Term(2), timestamp)
+	assert.Equal(t, uint64(0), pending.Offset())
+	assert.NoError(t, pending.WriteAt([]byte("abxd"), 0))
+
+	// Verify the committed snapshot becomes the current snapshot
+	snapshot := pending.Commit()
+	assert.Equal(t, snapshot, store.CurrentSnapshot())
+	assert.Equal(t, raft.Index(10), snapshot.Index())
+	assert.Equal(t, raft.Term(2), snapshot.Term())
+	assert.NoError(t, snapshot.Verify())
+	bytes := make([]byte, 4)
+	_, _ = snapshot.Reader().Read(bytes)
+	assert.Equal(t, "abxd", string(bytes))
+	assert.Equal(t, uint64(0), store.PendingSnapshot(raft.Index(10), raft.Term(2), timestamp).Offset())
+}
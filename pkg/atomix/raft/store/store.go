@@ -15,18 +15,25 @@
 package store
 
 import (
+	"fmt"
+	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store/log"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/store/snapshot"
 )
 
 // NewMemoryStore returns a new in-memory store
 func NewMemoryStore() Store {
+	return NewMemoryStoreWithRetention(1)
+}
+
+// NewMemoryStoreWithRetention returns a new in-memory store retaining the given number of snapshots
+func NewMemoryStoreWithRetention(retainedSnapshots int) Store {
 	log := log.NewMemoryLog()
 	return &store{
 		log:      log,
 		reader:   log.OpenReader(0),
 		writer:   log.Writer(),
-		snapshot: snapshot.NewMemoryStore(),
+		snapshot: snapshot.NewMemoryStoreWithRetention(retainedSnapshots),
 	}
 }
 
@@ -77,3 +84,74 @@ func (s *store) Close() error {
 	s.snapshot.Close()
 	return nil
 }
+
+// RecoverSnapshot returns the newest retained snapshot that passes verification.
+// If no snapshots are retained, a nil snapshot is returned. If snapshots are retained but none
+// passes verification, an error is returned.
+func RecoverSnapshot(store Store) (snapshot.Snapshot, error) {
+	var err error
+	for _, snapshot := range store.Snapshot().Snapshots() {
+		if err = VerifySnapshot(store, snapshot); err == nil {
+			return snapshot, nil
+		}
+	}
+	return nil, err
+}
+
+// VerifySnapshot verifies the integrity of the given snapshot and its continuity with the log.
+// The log must begin no later than the entry immediately following the snapshot, and if the log contains
+// the entry at which the snapshot was taken, the entry's term must match the snapshot's term.
+func VerifySnapshot(store Store, snapshot snapshot.Snapshot) error {
+	if err := snapshot.Verify(); err != nil {
+		return err
+	}
+
+	reader := store.Log().OpenReader(0)
+	defer reader.Close()
+	firstIndex := reader.FirstIndex()
+	lastIndex := reader.LastIndex()
+	if firstIndex > snapshot.Index()+1 {
+		return fmt.Errorf("log begins at index %d but snapshot ends at index %d", firstIndex, snapshot.Index())
+	}
+	if lastIndex < firstIndex || lastIndex < snapshot.Index() {
+		return nil
+	}
+
+	if snapshot.Index() < firstIndex {
+		reader.Reset(firstIndex)
+		entry := reader.NextEntry()
+		if entry != nil && entry.Entry.Term < snapshot.Term() {
+			return fmt.Errorf("entry %d term %d precedes snapshot term %d", entry.Index, entry.Entry.Term, snapshot.Term())
+		}
+		return nil
+	}
+
+	reader.Reset(snapshot.Index())
+	entry := reader.NextEntry()
+	if entry == nil || entry.Index != snapshot.Index() {
+		return fmt.Errorf("entry %d is missing from the log", snapshot.Index())
+	}
+	if entry.Entry.Term != snapshot.Term() {
+		return fmt.Errorf("entry %d term %d does not match snapshot term %d", entry.Index, entry.Entry.Term, snapshot.Term())
+	}
+	return nil
+}
+
+// RecoverLog truncates a torn final entry from the log, returning the index of the last intact entry.
+// A crash during an append may leave the last entry partially written. Only the final entry can be torn,
+// so if the entry preceding a torn entry also fails verification, the log is corrupt and an error is returned.
+func RecoverLog(store Store) (raft.Index, error) {
+	writer := store.Writer()
+	entry := writer.LastEntry()
+	if entry == nil || entry.Verify() == nil {
+		return writer.LastIndex(), nil
+	}
+
+	writer.Truncate(entry.Index - 1)
+	if prev := writer.LastEntry(); prev != nil {
+		if err := prev.Verify(); err != nil {
+			return prev.Index, err
+		}
+	}
+	return writer.LastIndex(), nil
+}
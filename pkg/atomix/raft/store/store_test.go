@@ -0,0 +1,145 @@
+// Copyright 2019-present Open Networking Foundation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	raft "github.com/atomix/raft-replica/pkg/atomix/raft/protocol"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func appendEntry(store Store, term raft.Term) {
+	store.Writer().Append(&raft.LogEntry{
+		Term:      term,
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_Initialize{
+			Initialize: &raft.InitializeEntry{},
+		},
+	})
+}
+
+func TestVerifySnapshot(t *testing.T) {
+	store := NewMemoryStore()
+	snapshot, err := RecoverSnapshot(store)
+	assert.NoError(t, err)
+	assert.Nil(t, snapshot)
+
+	// Verify a snapshot followed by the next entry in the log is continuous
+	store.Snapshot().NewSnapshot(raft.Index(10), raft.Term(2), time.Now())
+	store.Writer().Reset(raft.Index(11))
+	appendEntry(store, raft.Term(2))
+	assert.NoError(t, VerifySnapshot(store, store.Snapshot().CurrentSnapshot()))
+
+	// Verify a snapshot overlapping the log is continuous if the terms match
+	store.Writer().Reset(raft.Index(5))
+	for i := 5; i <= 12; i++ {
+		appendEntry(store, raft.Term(2))
+	}
+	assert.NoError(t, VerifySnapshot(store, store.Snapshot().CurrentSnapshot()))
+
+	// Verify a gap between the snapshot and the log is detected
+	store.Writer().Reset(raft.Index(12))
+	appendEntry(store, raft.Term(2))
+	assert.Error(t, VerifySnapshot(store, store.Snapshot().CurrentSnapshot()))
+
+	// Verify a mismatched term at the snapshot index is detected
+	store.Writer().Reset(raft.Index(9))
+	appendEntry(store, raft.Term(1))
+	appendEntry(store, raft.Term(1))
+	appendEntry(store, raft.Term(2))
+	assert.Error(t, VerifySnapshot(store, store.Snapshot().CurrentSnapshot()))
+
+	// Verify a log following the snapshot with a lesser term is detected
+	store.Writer().Reset(raft.Index(11))
+	appendEntry(store, raft.Term(1))
+	assert.Error(t, VerifySnapshot(store, store.Snapshot().CurrentSnapshot()))
+}
+
+func TestRecoverSnapshot(t *testing.T) {
+	store := NewMemoryStoreWithRetention(2)
+	store.Snapshot().NewSnapshot(raft.Index(5), raft.Term(1), time.Now())
+	store.Snapshot().NewSnapshot(raft.Index(10), raft.Term(2), time.Now())
+	store.Writer().Reset(raft.Index(6))
+	for i := 6; i <= 12; i++ {
+		appendEntry(store, raft.Term(1))
+	}
+
+	// Verify recovery falls back to the previous snapshot when the latest snapshot does not match the log
+	snapshot, err := RecoverSnapshot(store)
+	assert.NoError(t, err)
+	assert.Equal(t, raft.Index(5), snapshot.Index())
+
+	// Verify an error is returned when no retained snapshot matches the log
+	store.Writer().Reset(raft.Index(20))
+	appendEntry(store, raft.Term(2))
+	_, err = RecoverSnapshot(store)
+	assert.Error(t, err)
+}
+
+func appendCommand(store Store, term raft.Term, value string) {
+	store.Writer().Append(&raft.LogEntry{
+		Term:      term,
+		Timestamp: time.Now(),
+		Entry: &raft.LogEntry_Command{
+			Command: &raft.CommandEntry{
+				Value: []byte(value),
+			},
+		},
+	})
+}
+
+// tearEntry simulates a crash during the write of the entry at the given index by truncating its contents
+func tearEntry(store Store, index raft.Index) {
+	reader := store.Log().OpenReader(index)
+	defer reader.Close()
+	command := reader.NextEntry().Entry.GetCommand()
+	command.Value = command.Value[:len(command.Value)/2]
+}
+
+func TestRecoverLog(t *testing.T) {
+	store := NewMemoryStore()
+	index, err := RecoverLog(store)
+	assert.NoError(t, err)
+	assert.Equal(t, raft.Index(0), index)
+
+	for i := 1; i <= 3; i++ {
+		appendCommand(store, raft.Term(1), "Hello world!")
+	}
+
+	// Verify an intact log is not truncated
+	index, err = RecoverLog(store)
+	assert.NoError(t, err)
+	assert.Equal(t, raft.Index(3), index)
+
+	// Verify a torn final entry is truncated and the log recovers to the prior entry
+	tearEntry(store, raft.Index(3))
+	index, err = RecoverLog(store)
+	assert.NoError(t, err)
+	assert.Equal(t, raft.Index(2), index)
+	assert.Equal(t, raft.Index(2), store.Writer().LastIndex())
+	assert.NoError(t, store.Writer().LastEntry().Verify())
+	assert.Equal(t, "Hello world!", string(store.Writer().LastEntry().Entry.GetCommand().Value))
+
+	// Verify entries can be appended to the recovered log
+	appendCommand(store, raft.Term(1), "Hello world!")
+	assert.Equal(t, raft.Index(3), store.Writer().LastIndex())
+
+	// Verify corruption preceding the final entry is not treated as a torn write
+	tearEntry(store, raft.Index(2))
+	tearEntry(store, raft.Index(3))
+	_, err = RecoverLog(store)
+	assert.Error(t, err)
+}
@@ -14,7 +14,10 @@
 
 package util
 
-import "github.com/atomix/go-framework/pkg/atomix/util"
+import (
+	"fmt"
+	"github.com/atomix/go-framework/pkg/atomix/util"
+)
 
 // NewNodeLogger creates a new role logger
 func NewNodeLogger(node string) Logger {
@@ -167,3 +170,139 @@ func (l *roleLogger) Response(responseType string, response interface{}, err err
 		Tracef("Sending %v", response)
 	return err
 }
+
+// Level is the severity of a log message
+type Level int
+
+const (
+	// TraceLevel is the level of trace messages, including requests and responses
+	TraceLevel Level = iota
+	// DebugLevel is the level of debug messages
+	DebugLevel
+	// InfoLevel is the level of informational messages
+	InfoLevel
+	// WarnLevel is the level of warning messages
+	WarnLevel
+	// ErrorLevel is the level of error messages
+	ErrorLevel
+)
+
+func (l Level) String() string {
+	switch l {
+	case TraceLevel:
+		return "trace"
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+const (
+	// MemberField is the field containing the ID of the local member
+	MemberField = "member"
+	// RoleField is the field containing the role of the local member
+	RoleField = "role"
+	// TermField is the field containing the current term of the local member
+	TermField = "term"
+	// MessageTypeField is the field containing the type of a request or response
+	MessageTypeField = "messageType"
+	// PeerField is the field containing the ID of the member to or from which a message is sent
+	PeerField = "peer"
+	// GroupField is the field containing the ID of the Raft group to which the local member belongs
+	GroupField = "group"
+)
+
+// Fields are structured fields attached to a log message. A field value of type func() interface{}
+// is evaluated each time a message is logged, e.g. to attach the current term.
+type Fields map[string]interface{}
+
+// LogSink is a structured, leveled logging backend, e.g. an adapter for zap or zerolog.
+// Sinks must be safe for concurrent use.
+type LogSink interface {
+	// Log logs a message at the given level with the given structured fields
+	Log(level Level, message string, fields Fields)
+}
+
+// NewSinkLogger returns a Logger that writes to the given sink, attaching the given fields to every message
+func NewSinkLogger(sink LogSink, fields Fields) Logger {
+	return &sinkLogger{
+		sink:   sink,
+		fields: fields,
+	}
+}
+
+// sinkLogger is a Logger implementation that writes structured messages to a LogSink
+type sinkLogger struct {
+	sink   LogSink
+	fields Fields
+}
+
+// log writes a message to the sink with the logger's fields and the given additional fields
+func (l *sinkLogger) log(level Level, message string, extra Fields) {
+	fields := make(Fields, len(l.fields)+len(extra))
+	for key, value := range l.fields {
+		if f, ok := value.(func() interface{}); ok {
+			value = f()
+		}
+		fields[key] = value
+	}
+	for key, value := range extra {
+		fields[key] = value
+	}
+	l.sink.Log(level, message, fields)
+}
+
+func (l *sinkLogger) Error(message string, args ...interface{}) {
+	l.log(ErrorLevel, fmt.Sprintf(message, args...), nil)
+}
+
+func (l *sinkLogger) Warn(message string, args ...interface{}) {
+	l.log(WarnLevel, fmt.Sprintf(message, args...), nil)
+}
+
+func (l *sinkLogger) Info(message string, args ...interface{}) {
+	l.log(InfoLevel, fmt.Sprintf(message, args...), nil)
+}
+
+func (l *sinkLogger) Debug(message string, args ...interface{}) {
+	l.log(DebugLevel, fmt.Sprintf(message, args...), nil)
+}
+
+func (l *sinkLogger) Trace(message string, args ...interface{}) {
+	l.log(TraceLevel, fmt.Sprintf(message, args...), nil)
+}
+
+func (l *sinkLogger) Send(messageType string, request interface{}) {
+	_ = l.Response(messageType, request, nil)
+}
+
+func (l *sinkLogger) Receive(messageType string, response interface{}) {
+	l.Request(messageType, response)
+}
+
+func (l *sinkLogger) SendTo(messageType string, request interface{}, member interface{}) {
+	l.log(TraceLevel, fmt.Sprintf("Sending %v", request), Fields{MessageTypeField: messageType, PeerField: member})
+}
+
+func (l *sinkLogger) ReceiveFrom(messageType string, response interface{}, member interface{}) {
+	l.log(TraceLevel, fmt.Sprintf("Received %v", response), Fields{MessageTypeField: messageType, PeerField: member})
+}
+
+func (l *sinkLogger) ErrorFrom(messageType string, err error, member interface{}) {
+	l.log(TraceLevel, fmt.Sprintf("Received error %v", err), Fields{MessageTypeField: messageType, PeerField: member})
+}
+
+func (l *sinkLogger) Request(requestType string, request interface{}) {
+	l.log(TraceLevel, fmt.Sprintf("Received %v", request), Fields{MessageTypeField: requestType})
+}
+
+func (l *sinkLogger) Response(responseType string, response interface{}, err error) error {
+	l.log(TraceLevel, fmt.Sprintf("Sending %v", response), Fields{MessageTypeField: responseType})
+	return err
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/atomix/go-framework/pkg/atomix/cluster"
 	"github.com/atomix/go-framework/pkg/atomix/node"
 	"github.com/atomix/go-framework/pkg/atomix/service"
+	streams "github.com/atomix/go-framework/pkg/atomix/stream"
 	"github.com/atomix/raft-replica/pkg/atomix/raft"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/client"
 	"github.com/atomix/raft-replica/pkg/atomix/raft/config"
@@ -37,9 +38,9 @@ func TestRaftNode(t *testing.T) {
 		MemberID: "foo",
 		Members: map[string]cluster.Member{
 			"foo": {
-				ID:   "foo",
-				Host: "localhost",
-				Port: 5001,
+				ID:           "foo",
+				Host:         "localhost",
+				ProtocolPort: 5001,
 			},
 		},
 	}
@@ -49,35 +50,36 @@ func TestRaftNode(t *testing.T) {
 	defer server.Stop()
 	_ = server.WaitForReady()
 
-	client := client.NewClient(cluster, protocol.ReadConsistency_SEQUENTIAL)
+	client, err := client.NewClient(cluster, &config.ProtocolConfig{}, protocol.ReadConsistency_SEQUENTIAL)
+	assert.NoError(t, err)
 
-	ch := make(chan node.Output)
-	assert.NoError(t, client.Write(context.Background(), newOpenSessionRequest(), ch))
+	ch := make(chan streams.Result)
+	assert.NoError(t, client.Write(context.Background(), newOpenSessionRequest(), streams.NewChannelStream(ch)))
 	out := <-ch
 	assert.True(t, out.Succeeded())
-	openSessionResponse := getOpenSessionResponse(out.Value)
+	openSessionResponse := getOpenSessionResponse(out.Value.([]byte))
 	assert.NotEqual(t, 0, openSessionResponse.SessionID)
 	sessionID := openSessionResponse.SessionID
 
-	ch = make(chan node.Output)
+	ch = make(chan streams.Result)
 	bytes, err := proto.Marshal(&SetRequest{
 		Value: "Hello world!",
 	})
 	assert.NoError(t, err)
-	assert.NoError(t, client.Write(context.Background(), newCommandRequest(sessionID, 1, "set", bytes), ch))
+	assert.NoError(t, client.Write(context.Background(), newCommandRequest(sessionID, 1, "set", bytes), streams.NewChannelStream(ch)))
 	out = <-ch
 	assert.True(t, out.Succeeded())
-	commandResponse := getCommandResponse(out.Value)
+	commandResponse := getCommandResponse(out.Value.([]byte))
 	setResponse := &SetResponse{}
 	assert.NoError(t, proto.Unmarshal(commandResponse.Output, setResponse))
 
-	ch = make(chan node.Output)
+	ch = make(chan streams.Result)
 	bytes, err = proto.Marshal(&GetRequest{})
 	assert.NoError(t, err)
-	assert.NoError(t, client.Read(context.Background(), newQueryRequest(sessionID, commandResponse.Context.Index, 1, "get", bytes), ch))
+	assert.NoError(t, client.Read(context.Background(), newQueryRequest(sessionID, commandResponse.Context.Index, 1, "get", bytes), streams.NewChannelStream(ch)))
 	out = <-ch
 	assert.True(t, out.Succeeded())
-	queryResponse := getQueryResponse(out.Value)
+	queryResponse := getQueryResponse(out.Value.([]byte))
 	getResponse := &GetResponse{}
 	assert.NoError(t, proto.Unmarshal(queryResponse.Output, getResponse))
 	assert.Equal(t, "Hello world!", getResponse.Value)
@@ -88,19 +90,19 @@ func TestRaftCluster(t *testing.T) {
 		MemberID: "foo",
 		Members: map[string]cluster.Member{
 			"foo": {
-				ID:   "foo",
-				Host: "localhost",
-				Port: 5001,
+				ID:           "foo",
+				Host:         "localhost",
+				ProtocolPort: 5001,
 			},
 			"bar": {
-				ID:   "bar",
-				Host: "localhost",
-				Port: 5002,
+				ID:           "bar",
+				Host:         "localhost",
+				ProtocolPort: 5002,
 			},
 			"baz": {
-				ID:   "baz",
-				Host: "localhost",
-				Port: 5003,
+				ID:           "baz",
+				Host:         "localhost",
+				ProtocolPort: 5003,
 			},
 		},
 	}
@@ -116,35 +118,36 @@ func TestRaftCluster(t *testing.T) {
 	go startServer(serverBaz, wg)
 	wg.Wait()
 
-	client := client.NewClient(cluster, protocol.ReadConsistency_SEQUENTIAL)
+	client, err := client.NewClient(cluster, &config.ProtocolConfig{}, protocol.ReadConsistency_SEQUENTIAL)
+	assert.NoError(t, err)
 
-	ch := make(chan node.Output)
-	assert.NoError(t, client.Write(context.Background(), newOpenSessionRequest(), ch))
+	ch := make(chan streams.Result)
+	assert.NoError(t, client.Write(context.Background(), newOpenSessionRequest(), streams.NewChannelStream(ch)))
 	out := <-ch
 	assert.True(t, out.Succeeded())
-	openSessionResponse := getOpenSessionResponse(out.Value)
+	openSessionResponse := getOpenSessionResponse(out.Value.([]byte))
 	assert.NotEqual(t, 0, openSessionResponse.SessionID)
 	sessionID := openSessionResponse.SessionID
 
-	ch = make(chan node.Output)
+	ch = make(chan streams.Result)
 	bytes, err := proto.Marshal(&SetRequest{
 		Value: "Hello world!",
 	})
 	assert.NoError(t, err)
-	assert.NoError(t, client.Write(context.Background(), newCommandRequest(sessionID, 1, "set", bytes), ch))
+	assert.NoError(t, client.Write(context.Background(), newCommandRequest(sessionID, 1, "set", bytes), streams.NewChannelStream(ch)))
 	out = <-ch
 	assert.True(t, out.Succeeded())
-	commandResponse := getCommandResponse(out.Value)
+	commandResponse := getCommandResponse(out.Value.([]byte))
 	setResponse := &SetResponse{}
 	assert.NoError(t, proto.Unmarshal(commandResponse.Output, setResponse))
 
-	ch = make(chan node.Output)
+	ch = make(chan streams.Result)
 	bytes, err = proto.Marshal(&GetRequest{})
 	assert.NoError(t, err)
-	assert.NoError(t, client.Read(context.Background(), newQueryRequest(sessionID, commandResponse.Context.Index, 1, "get", bytes), ch))
+	assert.NoError(t, client.Read(context.Background(), newQueryRequest(sessionID, commandResponse.Context.Index, 1, "get", bytes), streams.NewChannelStream(ch)))
 	out = <-ch
 	assert.True(t, out.Succeeded())
-	queryResponse := getQueryResponse(out.Value)
+	queryResponse := getQueryResponse(out.Value.([]byte))
 	getResponse := &GetResponse{}
 	assert.NoError(t, proto.Unmarshal(queryResponse.Output, getResponse))
 	assert.Equal(t, "Hello world!", getResponse.Value)
@@ -161,19 +164,19 @@ func BenchmarkRaftCluster(b *testing.B) {
 		MemberID: "foo",
 		Members: map[string]cluster.Member{
 			"foo": {
-				ID:   "foo",
-				Host: "localhost",
-				Port: 5001,
+				ID:           "foo",
+				Host:         "localhost",
+				ProtocolPort: 5001,
 			},
 			"bar": {
-				ID:   "bar",
-				Host: "localhost",
-				Port: 5002,
+				ID:           "bar",
+				Host:         "localhost",
+				ProtocolPort: 5002,
 			},
 			"baz": {
-				ID:   "baz",
-				Host: "localhost",
-				Port: 5003,
+				ID:           "baz",
+				Host:         "localhost",
+				ProtocolPort: 5003,
 			},
 		},
 	}
@@ -193,13 +196,14 @@ func BenchmarkRaftCluster(b *testing.B) {
 	defer stopServer(serverBar)
 	defer stopServer(serverBaz)
 
-	client := client.NewClient(cluster, protocol.ReadConsistency_SEQUENTIAL)
+	client, err := client.NewClient(cluster, &config.ProtocolConfig{}, protocol.ReadConsistency_SEQUENTIAL)
+	assert.NoError(b, err)
 
-	ch := make(chan node.Output)
-	assert.NoError(b, client.Write(context.Background(), newOpenSessionRequest(), ch))
+	ch := make(chan streams.Result)
+	assert.NoError(b, client.Write(context.Background(), newOpenSessionRequest(), streams.NewChannelStream(ch)))
 	out := <-ch
 	assert.True(b, out.Succeeded())
-	openSessionResponse := getOpenSessionResponse(out.Value)
+	openSessionResponse := getOpenSessionResponse(out.Value.([]byte))
 	assert.NotEqual(b, 0, openSessionResponse.SessionID)
 	sessionID := openSessionResponse.SessionID
 
@@ -212,11 +216,11 @@ func BenchmarkRaftCluster(b *testing.B) {
 			wg.Add(1)
 			go func() {
 				for commandID := range ch {
-					ch := make(chan node.Output)
+					ch := make(chan streams.Result)
 					bytes, _ := proto.Marshal(&SetRequest{
 						Value: "Hello world!",
 					})
-					_ = client.Write(context.Background(), newCommandRequest(sessionID, commandID, "set", bytes), ch)
+					_ = client.Write(context.Background(), newCommandRequest(sessionID, commandID, "set", bytes), streams.NewChannelStream(ch))
 					out = <-ch
 				}
 				wg.Done()
@@ -237,9 +241,13 @@ func BenchmarkRaftCluster(b *testing.B) {
 func newServer(memberID string, cluster cluster.Cluster) *raft.Server {
 	cluster.MemberID = memberID
 	timeout := 5 * time.Second
-	return raft.NewServer(cluster, node.GetRegistry(), &config.ProtocolConfig{
+	server, err := raft.NewServer(cluster, node.GetRegistry(), &config.ProtocolConfig{
 		ElectionTimeout: &timeout,
 	})
+	if err != nil {
+		panic(err)
+	}
+	return server
 }
 
 func startServer(server *raft.Server, wg *sync.WaitGroup) {